@@ -2,16 +2,18 @@ package main
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/github-real-lb/bookings-web-app/db"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const ContextTimeout = 3 * time.Second
 
 // AuthenticateUser authenticate the user email and password.
-// If successful, it returns the id of the user and nil, otherwise a 0 and error
-func (s *Server) AuthenticateUser(email, password string) (int64, error) {
+// If successful, it returns the user and nil, otherwise an empty user and error
+func (s *Server) AuthenticateUser(email, password string) (User, error) {
 	// create context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
 	defer cancel()
@@ -22,159 +24,1627 @@ func (s *Server) AuthenticateUser(email, password string) (int64, error) {
 		Password: password,
 	})
 	if err != nil {
-		return 0, err
+		return User{}, err
 	}
 
-	return dbUser.ID, err
+	user := User{}
+	user.Import(dbUser)
+
+	return user, nil
+}
+
+// ChangeUserPassword sets password as the password of the user with id, and clears the user password token
+func (s *Server) ChangeUserPassword(id int64, password string) error {
+	arg := db.UpdateUserPasswordParams{
+		ID:       id,
+		Password: password,
+	}
+	arg.UpdatedAt.Scan(time.Now())
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	return s.DatabaseStore.ChangeUserPassword(ctx, arg)
+}
+
+func (s *Server) CheckRoomAvailability(roomID int64, startDate, endData time.Time, adults, children int) (bool, error) {
+	// parse form's data to query arguments
+	arg := db.CheckRoomAvailabilityParams{
+		Adults:   int32(adults),
+		Children: int32(children),
+	}
+	arg.RoomID = roomID
+	err := arg.StartDate.Scan(startDate)
+	if err != nil {
+		return false, err
+	}
+
+	err = arg.EndDate.Scan(endData)
+	if err != nil {
+		return false, err
+	}
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	// get list of availabe rooms
+	return s.DatabaseStore.CheckRoomAvailability(ctx, arg)
+}
+
+// CheckStayRules checks a stay from startDate to endDate in each of rooms against the stay rules of the room.
+// It returns the rooms allowed, and the stay rule errors of the rooms not allowed.
+func (s *Server) CheckStayRules(rooms []Room, startDate, endDate time.Time) ([]Room, []*db.StayRuleError, error) {
+	arg := db.ListStayRulesForDatesParams{}
+	arg.StartDate.Scan(startDate)
+	arg.EndDate.Scan(endDate)
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	rules, err := s.DatabaseStore.ListStayRulesForDates(ctx, arg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	allowed := make([]Room, 0, len(rooms))
+	ruleErrs := []*db.StayRuleError{}
+	for _, room := range rooms {
+		var ruleErr *db.StayRuleError
+		for _, rule := range rules {
+			if rule.RoomID != room.ID {
+				continue
+			}
+
+			if errors.As(rule.Check(startDate, endDate), &ruleErr) {
+				break
+			}
+		}
+
+		if ruleErr != nil {
+			ruleErrs = append(ruleErrs, ruleErr)
+		} else {
+			allowed = append(allowed, room)
+		}
+	}
+
+	return allowed, ruleErrs, nil
+}
+
+// CancelReservation cancels reservation r and releases its room.
+// cancelledBy records who cancelled, and feePercent the cancellation fee charged.
+// It returns the cancelled reservation, including the room data of r.
+func (s *Server) CancelReservation(r Reservation, cancelledBy string, feePercent int) (Reservation, error) {
+	arg := db.CancelReservationParams{
+		ID:                     r.ID,
+		CancellationFeePercent: int32(feePercent),
+	}
+	arg.CancelledBy.Scan(cancelledBy)
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	// execute database transaction
+	dbRsv, err := s.DatabaseStore.CancelReservationTx(ctx, arg)
+	if err != nil {
+		return r, err
+	}
+
+	rsv := Reservation{}
+	rsv.Import(dbRsv)
+	rsv.Room = r.Room
+
+	return rsv, nil
+}
+
+// CreateReservation insert reservation data into database.
+// it updates r with new data from database.
+func (s *Server) CreateReservation(r Reservation) error {
+	// create database transaction arguments
+	arg := db.CreateReservationParams{
+		Code:      r.Code,
+		FirstName: r.FirstName,
+		LastName:  r.LastName,
+		Email:     r.Email,
+		RoomID:    r.RoomID,
+		Adults:    int32(r.Adults),
+		Children:  int32(r.Children),
+	}
+	arg.Phone.Scan(r.Phone)
+	arg.StartDate.Scan(r.StartDate)
+	arg.EndDate.Scan(r.EndDate)
+	arg.Notes.Scan(r.Notes)
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	// execute database transaction
+	_, err := s.DatabaseStore.CreateReservationTx(ctx, arg)
+
+	return err
+}
+
+// CreateReservations insert the data of several reservations booked together into database.
+// All reservations are created, or none if any of the rooms is not available.
+// The rooms held with holdToken are released once the reservations are created,
+// and every reservation is discounted by promoCode if not empty.
+// It returns the reservations created, including the room data of rsvs.
+func (s *Server) CreateReservations(rsvs []Reservation, holdToken string, promoCode string) ([]Reservation, error) {
+	// create database transaction arguments
+	args := make([]db.CreateReservationParams, len(rsvs))
+	for i, r := range rsvs {
+		args[i] = db.CreateReservationParams{
+			Code:      r.Code,
+			FirstName: r.FirstName,
+			LastName:  r.LastName,
+			Email:     r.Email,
+			RoomID:    r.RoomID,
+			Adults:    int32(r.Adults),
+			Children:  int32(r.Children),
+		}
+		args[i].Phone.Scan(r.Phone)
+		args[i].StartDate.Scan(r.StartDate)
+		args[i].EndDate.Scan(r.EndDate)
+		args[i].Notes.Scan(r.Notes)
+		args[i].ParentCode.Scan(r.ParentCode)
+	}
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	// execute database transaction
+	dbRsvs, err := s.DatabaseStore.CreateReservationsTx(ctx, args, holdToken, promoCode)
+	if err != nil {
+		return nil, err
+	}
+
+	created := make([]Reservation, len(dbRsvs))
+	for i := range dbRsvs {
+		created[i].Import(dbRsvs[i])
+		created[i].Room = rsvs[i].Room
+
+		// load the taxes and fees itemised with the reservation
+		created[i].Charges, err = s.ListReservationCharges(created[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return created, nil
+}
+
+// CreateCharge inserts the tax or fee c into database
+func (s *Server) CreateCharge(c Charge) error {
+	arg := db.CreateChargeParams{
+		Name:   c.Name,
+		Kind:   db.ChargeKind(c.Kind),
+		Amount: c.Amount,
+	}
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	_, err := s.DatabaseStore.CreateCharge(ctx, arg)
+
+	return err
+}
+
+// CreateFolioEntry posts the charge or payment e to the folio of its reservation, and returns the entry created
+func (s *Server) CreateFolioEntry(e FolioEntry) (FolioEntry, error) {
+	arg := db.CreateFolioEntryParams{
+		ReservationID: e.ReservationID,
+		Kind:          db.FolioEntryKind(e.Kind),
+		Description:   e.Description,
+		Amount:        int64(e.Amount),
+	}
+	if e.UserID != 0 {
+		arg.UserID.Scan(e.UserID)
+	}
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbEntry, err := s.DatabaseStore.CreateFolioEntry(ctx, arg)
+	if err != nil {
+		return e, err
+	}
+
+	e.Import(dbEntry)
+
+	return e, nil
+}
+
+// CreatePayment inserts the payment transaction p into database, and returns the payment created
+func (s *Server) CreatePayment(p Payment) (Payment, error) {
+	arg := db.CreatePaymentParams{
+		ReservationID: p.ReservationID,
+		Provider:      p.Provider,
+		ProviderRef:   p.ProviderRef,
+		Kind:          db.PaymentKind(p.Kind),
+		Status:        db.PaymentStatus(p.Status),
+		Amount:        int64(p.Amount),
+	}
+	if p.ParentID != 0 {
+		arg.ParentID.Scan(p.ParentID)
+	}
+	if p.RefundID != 0 {
+		arg.RefundID.Scan(p.RefundID)
+	}
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbPayment, err := s.DatabaseStore.CreatePayment(ctx, arg)
+	if err != nil {
+		return p, err
+	}
+
+	p.Import(dbPayment)
+
+	return p, nil
+}
+
+// CreateRefund inserts refund into database together with its refund transactions, all or none of them,
+// and returns the refund created
+func (s *Server) CreateRefund(refund Refund, payments []Payment) (Refund, error) {
+	arg := db.CreateRefundTxParams{
+		CreateRefundParams: db.CreateRefundParams{
+			ReservationID: refund.ReservationID,
+			Paid:          int64(refund.Paid),
+			PolicyAmount:  int64(refund.PolicyAmount),
+			Amount:        int64(refund.Amount),
+			Reason:        refund.Reason,
+		},
+		Payments: make([]db.CreatePaymentParams, len(payments)),
+	}
+	if refund.UserID != 0 {
+		arg.UserID.Scan(refund.UserID)
+	}
+
+	for i, p := range payments {
+		arg.Payments[i] = db.CreatePaymentParams{
+			ReservationID: p.ReservationID,
+			Provider:      p.Provider,
+			ProviderRef:   p.ProviderRef,
+			Kind:          db.PaymentKind(p.Kind),
+			Status:        db.PaymentStatus(p.Status),
+			Amount:        int64(p.Amount),
+		}
+		if p.ParentID != 0 {
+			arg.Payments[i].ParentID.Scan(p.ParentID)
+		}
+	}
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	// execute database transaction
+	dbRefund, err := s.DatabaseStore.CreateRefundTx(ctx, arg)
+	if err != nil {
+		return refund, err
+	}
+
+	refund.Import(dbRefund)
+
+	return refund, nil
+}
+
+// CreateOwnerBlocks blocks every room of roomIDs for the owner over the nights and with the reason of block.
+// Rooms reserved on some of the nights are not blocked, and db.ErrOwnerBlockConflict is returned,
+// unless override is true.
+func (s *Server) CreateOwnerBlocks(block RoomRestriction, roomIDs []int64, override bool) error {
+	arg := db.CreateOwnerBlocksTxParams{
+		RoomIDs:  roomIDs,
+		Reason:   block.Reason,
+		Override: override,
+	}
+	arg.StartDate.Scan(block.StartDate)
+	arg.EndDate.Scan(block.EndDate)
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	_, err := s.DatabaseStore.CreateOwnerBlocksTx(ctx, arg)
+
+	return err
+}
+
+// CreateRoom inserts room r into database, and returns it with its id
+func (s *Server) CreateRoom(r Room) (Room, error) {
+	arg := db.CreateRoomParams{
+		Name:          r.Name,
+		Description:   r.Description,
+		ImageFilename: r.ImageFilename,
+		MaxAdults:     int32(r.MaxAdults),
+		MaxChildren:   int32(r.MaxChildren),
+		MaxOccupancy:  int32(r.MaxOccupancy),
+		Slug:          r.Slug,
+		NightlyRate:   int64(r.NightlyRate),
+	}
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbRoom, err := s.DatabaseStore.CreateRoom(ctx, arg)
+	if err != nil {
+		return r, err
+	}
+
+	r.Import(dbRoom)
+
+	return r, nil
+}
+
+// CreateRoomRates inserts the room rates rates into database, all or none of them
+func (s *Server) CreateRoomRates(rates []RoomRate) error {
+	args := make([]db.CreateRoomRateParams, len(rates))
+	for i, rate := range rates {
+		args[i] = db.CreateRoomRateParams{
+			RoomID:     rate.RoomID,
+			Name:       rate.Name,
+			DaysOfWeek: int32(rate.DaysOfWeek),
+			Rate:       int64(rate.Rate),
+			Priority:   int32(rate.Priority),
+		}
+		args[i].StartDate.Scan(rate.StartDate)
+		args[i].EndDate.Scan(rate.EndDate)
+	}
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	_, err := s.DatabaseStore.CreateRoomRatesTx(ctx, args)
+
+	return err
+}
+
+// CreateUser creates the staff user u with the password u.Password, which is hashed before it is saved
+func (s *Server) CreateUser(u User) (User, error) {
+	arg := db.CreateUserParams{
+		FirstName:   u.FirstName,
+		LastName:    u.LastName,
+		Email:       u.Email,
+		Password:    u.Password,
+		AccessLevel: int64(u.AccessLevel),
+	}
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbUser, err := s.DatabaseStore.CreateNewUser(ctx, arg)
+	if err != nil {
+		return u, err
+	}
+
+	u.Import(dbUser)
+
+	return u, nil
+}
+
+// DeleteCharges deletes the taxes and fees with the ids specified.
+// The charges of existing reservations are kept.
+func (s *Server) DeleteCharges(ids []int64) error {
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	return s.DatabaseStore.DeleteCharges(ctx, ids)
+}
+
+// DeleteExchangeRates deletes the exchange rates of the currencies specified
+func (s *Server) DeleteExchangeRates(currencies []string) error {
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	return s.DatabaseStore.DeleteExchangeRates(ctx, currencies)
+}
+
+// DeleteOwnerBlocks deletes the owner blocks with the ids specified, and returns the blocks deleted
+func (s *Server) DeleteOwnerBlocks(ids []int64) ([]RoomRestriction, error) {
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbBlocks, err := s.DatabaseStore.DeleteOwnerBlocks(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]RoomRestriction, len(dbBlocks))
+	for i, v := range dbBlocks {
+		blocks[i].Import(v)
+	}
+
+	return blocks, nil
+}
+
+// DeleteRoom deletes the room with id, and returns the room deleted.
+// Rooms with reservations are not deleted, and db.ErrRoomHasReservations is returned.
+func (s *Server) DeleteRoom(id int64) (Room, error) {
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbRoom, err := s.DatabaseStore.DeleteRoomTx(ctx, id)
+	if err != nil {
+		return Room{}, err
+	}
+
+	room := Room{}
+	room.Import(dbRoom)
+
+	return room, nil
+}
+
+// DeleteRoomRates deletes the room rates with the ids specified
+func (s *Server) DeleteRoomRates(ids []int64) error {
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	return s.DatabaseStore.DeleteRoomRates(ctx, ids)
+}
+
+// DeleteExpiredRoomHolds deletes all expired room holds, and returns the holds deleted
+func (s *Server) DeleteExpiredRoomHolds() ([]RoomRestriction, error) {
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbHolds, err := s.DatabaseStore.DeleteExpiredRoomHolds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	holds := make([]RoomRestriction, len(dbHolds))
+	for i, v := range dbHolds {
+		holds[i].Import(v)
+	}
+
+	return holds, nil
+}
+
+// CreateWaitlistEntry insert the waitlist entry e into database
+func (s *Server) CreateWaitlistEntry(e WaitlistEntry) error {
+	arg := db.CreateWaitlistEntryParams{
+		Token:     e.Token,
+		FirstName: e.FirstName,
+		LastName:  e.LastName,
+		Email:     e.Email,
+		Adults:    int32(e.Adults),
+		Children:  int32(e.Children),
+	}
+	arg.StartDate.Scan(e.StartDate)
+	arg.EndDate.Scan(e.EndDate)
+	if e.RoomID != 0 {
+		arg.RoomID.Scan(e.RoomID)
+	}
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	_, err := s.DatabaseStore.CreateWaitlistEntry(ctx, arg)
+
+	return err
+}
+
+// GetFolioBalance returns the balance of the folio of reservation reservationID, without the voided entries
+func (s *Server) GetFolioBalance(reservationID int64) (Price, error) {
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	balance, err := s.DatabaseStore.GetFolioBalance(ctx, reservationID)
+
+	return Price(balance), err
+}
+
+// GetInvoiceByReservation returns the invoice of reservation reservationID without issuing it
+func (s *Server) GetInvoiceByReservation(reservationID int64) (Invoice, error) {
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbInvoice, err := s.DatabaseStore.GetInvoiceByReservation(ctx, reservationID)
+	if err != nil {
+		return Invoice{}, err
+	}
+
+	invoice := Invoice{}
+	invoice.Import(dbInvoice)
+
+	return invoice, nil
+}
+
+// GetOwnerBlock returns the owner block with id
+func (s *Server) GetOwnerBlock(id int64) (RoomRestriction, error) {
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbBlock, err := s.DatabaseStore.GetOwnerBlock(ctx, id)
+	if err != nil {
+		return RoomRestriction{}, err
+	}
+
+	block := RoomRestriction{}
+	block.Import(dbBlock)
+
+	return block, nil
+}
+
+// GetReservation returns the reservation with id, including the room data
+func (s *Server) GetReservation(id int64) (Reservation, error) {
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbRsv, err := s.DatabaseStore.GetReservationAndRoom(ctx, id)
+	if err != nil {
+		return Reservation{}, err
+	}
+
+	rsv := Reservation{}
+	rsv.Import(dbRsv.Reservation)
+	rsv.Room.Import(dbRsv.Room)
+
+	return rsv, nil
+}
+
+// GetReservationByLastName returns the reservation matching code and lastName, including the room data
+func (s *Server) GetReservationByLastName(code, lastName string) (Reservation, error) {
+	arg := db.GetReservationByLastNameParams{
+		Code:     code,
+		LastName: lastName,
+	}
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	// get reservation
+	dbRsv, err := s.DatabaseStore.GetReservationByLastName(ctx, arg)
+	if err != nil {
+		return Reservation{}, err
+	}
+
+	// get the room of the reservation
+	dbRoom, err := s.DatabaseStore.GetRoom(ctx, dbRsv.RoomID)
+	if err != nil {
+		return Reservation{}, err
+	}
+
+	rsv := Reservation{}
+	rsv.Import(dbRsv)
+	rsv.Room.Import(dbRoom)
+
+	return rsv, nil
+}
+
+// GetRoom returns the room with id
+func (s *Server) GetRoom(id int64) (Room, error) {
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbRoom, err := s.DatabaseStore.GetRoom(ctx, id)
+	if err != nil {
+		return Room{}, err
+	}
+
+	room := Room{}
+	room.Import(dbRoom)
+
+	return room, nil
+}
+
+// GetRoomBySlug returns the room with slug
+func (s *Server) GetRoomBySlug(slug string) (Room, error) {
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbRoom, err := s.DatabaseStore.GetRoomBySlug(ctx, slug)
+	if err != nil {
+		return Room{}, err
+	}
+
+	room := Room{}
+	room.Import(dbRoom)
+
+	return room, nil
+}
+
+// GetUser returns the staff user with id
+func (s *Server) GetUser(id int64) (User, error) {
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbUser, err := s.DatabaseStore.GetUser(ctx, id)
+	if err != nil {
+		return User{}, err
+	}
+
+	user := User{}
+	user.Import(dbUser)
+
+	return user, nil
+}
+
+// GetUserByEmail returns the staff user with email
+func (s *Server) GetUserByEmail(email string) (User, error) {
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbUser, err := s.DatabaseStore.GetUserByEmail(ctx, email)
+	if err != nil {
+		return User{}, err
+	}
+
+	user := User{}
+	user.Import(dbUser)
+
+	return user, nil
+}
+
+// GetUserByPasswordToken returns the staff user with the password token
+func (s *Server) GetUserByPasswordToken(token string) (User, error) {
+	arg := pgtype.Text{}
+	arg.Scan(token)
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbUser, err := s.DatabaseStore.GetUserByPasswordToken(ctx, arg)
+	if err != nil {
+		return User{}, err
+	}
+
+	user := User{}
+	user.Import(dbUser)
+
+	return user, nil
+}
+
+// GetWaitlistEntryByToken returns the waitlist entry of token
+func (s *Server) GetWaitlistEntryByToken(token string) (WaitlistEntry, error) {
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbEntry, err := s.DatabaseStore.GetWaitlistEntryByToken(ctx, token)
+	if err != nil {
+		return WaitlistEntry{}, err
+	}
+
+	entry := WaitlistEntry{}
+	entry.Import(dbEntry)
+
+	return entry, nil
+}
+
+// HoldRoom holds the room of reservation r for the guest identified by holdToken for app.RoomHoldTTL.
+// It returns db.ErrRoomUnavailable if the room is not available.
+func (s *Server) HoldRoom(r Reservation, holdToken string) error {
+	arg := db.CreateRoomHoldTxParams{
+		CreateRoomHoldParams: db.CreateRoomHoldParams{
+			RoomID: r.RoomID,
+		},
+		Adults:   int32(r.Adults),
+		Children: int32(r.Children),
+	}
+	arg.StartDate.Scan(r.StartDate)
+	arg.EndDate.Scan(r.EndDate)
+	arg.HoldToken.Scan(holdToken)
+	arg.ExpiresAt.Scan(time.Now().Add(app.RoomHoldTTL()))
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	// execute database transaction
+	_, err := s.DatabaseStore.CreateRoomHoldTx(ctx, arg)
+
+	return err
+}
+
+// IssueInvoice returns the invoice of reservation reservationID,
+// issuing it with the next invoice number of the property if the reservation has no invoice yet
+func (s *Server) IssueInvoice(reservationID int64) (Invoice, error) {
+	arg := db.IssueInvoiceTxParams{
+		Property:      app.Listing.Name,
+		ReservationID: reservationID,
+	}
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	// execute database transaction
+	dbInvoice, err := s.DatabaseStore.IssueInvoiceTx(ctx, arg)
+	if err != nil {
+		return Invoice{}, err
+	}
+
+	invoice := Invoice{}
+	invoice.Import(dbInvoice)
+
+	return invoice, nil
+}
+
+// ListAvailableRooms returns limit amount of avaiable rooms in a date range, with the offset specified.
+// Rooms that cannot accommodate the whole party of adults and children are also returned,
+// as a party can be split across several rooms booked together.
+func (s *Server) ListAvailableRooms(limit, offset int, startDate, endData time.Time, adults, children int) ([]Room, error) {
+	// parse form's data to query arguments
+	arg := db.ListAvailableRoomsParams{
+		Limit:    int32(limit),
+		Offset:   int32(offset),
+		Adults:   int32(adults),
+		Children: int32(children),
+	}
+	err := arg.StartDate.Scan(startDate)
+	if err != nil {
+		return []Room{}, err
+	}
+
+	err = arg.EndDate.Scan(endData)
+	if err != nil {
+		return []Room{}, err
+	}
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	// get list of availabe rooms
+	dbRooms, err := s.DatabaseStore.ListAvailableRooms(ctx, arg)
+	if err != nil {
+		return []Room{}, err
+	}
+
+	l := len(dbRooms)
+	if l == 0 {
+		return []Room{}, nil
+	}
+
+	rooms := make([]Room, l)
+	for i := 0; i < l; i++ {
+		rooms[i].Import(dbRooms[i])
+	}
+
+	return rooms, nil
+}
+
+// ListReservations returns limit amount of reservations, with the offset specified.
+func (s *Server) ListReservations(limit, offset int) ([]Reservation, error) {
+	arg := db.ListReservationsAndRoomsParams{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	// get list of availabe rooms
+	dbRsvs, err := s.DatabaseStore.ListReservationsAndRooms(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	l := len(dbRsvs)
+	if l == 0 {
+		return []Reservation{}, nil
+	}
+
+	rsvs := make([]Reservation, l)
+	for i := 0; i < l; i++ {
+		rsvs[i].ImportWithRoom(dbRsvs[i])
+	}
+
+	return rsvs, nil
+}
+
+// ListArrivals returns the reservations arriving on date, including the room data
+func (s *Server) ListArrivals(date time.Time) ([]Reservation, error) {
+	var arg pgtype.Date
+	arg.Scan(date)
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbRsvs, err := s.DatabaseStore.ListArrivalsAndRooms(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	rsvs := make([]Reservation, len(dbRsvs))
+	for i, v := range dbRsvs {
+		rsvs[i].Import(v.Reservation)
+		rsvs[i].Room.Import(v.Room)
+	}
+
+	return rsvs, nil
+}
+
+// ListDepartures returns the reservations due to depart on date or before, and the reservations checked out on date,
+// including the room data
+func (s *Server) ListDepartures(date time.Time) ([]Reservation, error) {
+	var arg pgtype.Date
+	arg.Scan(date)
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbRsvs, err := s.DatabaseStore.ListDeparturesAndRooms(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	rsvs := make([]Reservation, len(dbRsvs))
+	for i, v := range dbRsvs {
+		rsvs[i].Import(v.Reservation)
+		rsvs[i].Room.Import(v.Room)
+	}
+
+	return rsvs, nil
+}
+
+// ListReservationsByStatus returns limit amount of reservations in status, with the offset specified
+func (s *Server) ListReservationsByStatus(status ReservationStatus, limit, offset int) ([]Reservation, error) {
+	arg := db.ListReservationsAndRoomsByStatusParams{
+		Status: db.ReservationStatus(status),
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbRsvs, err := s.DatabaseStore.ListReservationsAndRoomsByStatus(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	rsvs := make([]Reservation, len(dbRsvs))
+	for i, v := range dbRsvs {
+		rsvs[i].Import(v.Reservation)
+		rsvs[i].Room.Import(v.Room)
+	}
+
+	return rsvs, nil
+}
+
+// ListCharges returns all taxes and fees
+func (s *Server) ListCharges() ([]Charge, error) {
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbCharges, err := s.DatabaseStore.ListCharges(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	charges := make([]Charge, len(dbCharges))
+	for i, v := range dbCharges {
+		charges[i].Import(v)
+	}
+
+	return charges, nil
+}
+
+// ListExchangeRates returns the exchange rates of all display currencies
+func (s *Server) ListExchangeRates() ([]ExchangeRate, error) {
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbRates, err := s.DatabaseStore.ListExchangeRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make([]ExchangeRate, len(dbRates))
+	for i, v := range dbRates {
+		rates[i].Import(v)
+	}
+
+	return rates, nil
+}
+
+// ListFolioEntries returns the folio entries of reservation reservationID, including the voided ones
+func (s *Server) ListFolioEntries(reservationID int64) ([]FolioEntry, error) {
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbEntries, err := s.DatabaseStore.ListFolioEntriesByReservation(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]FolioEntry, len(dbEntries))
+	for i, v := range dbEntries {
+		entries[i].Import(v)
+	}
+
+	return entries, nil
+}
+
+// ListOwnerBlocks returns limit amount of owner blocks ending after date, with the offset specified.
+// The room data is included.
+func (s *Server) ListOwnerBlocks(date time.Time, limit, offset int) ([]RoomRestriction, error) {
+	arg := db.ListOwnerBlocksAndRoomsParams{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+	arg.Date.Scan(date)
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbBlocks, err := s.DatabaseStore.ListOwnerBlocksAndRooms(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]RoomRestriction, len(dbBlocks))
+	for i, v := range dbBlocks {
+		blocks[i].ImportWithRoom(v)
+	}
+
+	return blocks, nil
+}
+
+// ListPayments returns the payment transactions of reservation reservationID
+func (s *Server) ListPayments(reservationID int64) ([]Payment, error) {
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbPayments, err := s.DatabaseStore.ListPaymentsByReservation(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	payments := make([]Payment, len(dbPayments))
+	for i, v := range dbPayments {
+		payments[i].Import(v)
+	}
+
+	return payments, nil
+}
+
+// ListRefunds returns the refunds of reservation reservationID
+func (s *Server) ListRefunds(reservationID int64) ([]Refund, error) {
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbRefunds, err := s.DatabaseStore.ListRefundsByReservation(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	refunds := make([]Refund, len(dbRefunds))
+	for i, v := range dbRefunds {
+		refunds[i].Import(v)
+	}
+
+	return refunds, nil
+}
+
+// ListReservationCharges returns the taxes and fees charged on reservation reservationID
+func (s *Server) ListReservationCharges(reservationID int64) ([]ReservationCharge, error) {
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbCharges, err := s.DatabaseStore.ListReservationCharges(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	charges := make([]ReservationCharge, len(dbCharges))
+	for i, v := range dbCharges {
+		charges[i].Import(v)
+	}
+
+	return charges, nil
+}
+
+// ListRoomRates returns limit amount of room rates ending on date or later, with the offset specified,
+// including the room data
+func (s *Server) ListRoomRates(date time.Time, limit, offset int) ([]RoomRate, error) {
+	arg := db.ListRoomRatesAndRoomsParams{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+	arg.Date.Scan(date)
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbRates, err := s.DatabaseStore.ListRoomRatesAndRooms(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make([]RoomRate, len(dbRates))
+	for i, v := range dbRates {
+		rates[i].ImportWithRoom(v)
+	}
+
+	return rates, nil
+}
+
+// ListRoomRestrictions returns the room restrictions of reservation reservationID
+func (s *Server) ListRoomRestrictions(reservationID int64) ([]RoomRestriction, error) {
+	arg := pgtype.Int8{
+		Int64: reservationID,
+		Valid: true,
+	}
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbRestrictions, err := s.DatabaseStore.ListRoomRestrictionsByReservationID(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	restrictions := make([]RoomRestriction, len(dbRestrictions))
+	for i, v := range dbRestrictions {
+		restrictions[i].Import(v)
+	}
+
+	return restrictions, nil
+}
+
+// ListRoomRestrictionsForPeriod returns the reservations and owner blocks of all rooms
+// overlapping the nights from startDate to endDate
+func (s *Server) ListRoomRestrictionsForPeriod(startDate, endDate time.Time) ([]RoomRestriction, error) {
+	arg := db.ListRoomRestrictionsForPeriodParams{}
+	arg.StartDate.Scan(startDate)
+	arg.EndDate.Scan(endDate)
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbRestrictions, err := s.DatabaseStore.ListRoomRestrictionsForPeriod(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	restrictions := make([]RoomRestriction, len(dbRestrictions))
+	for i, v := range dbRestrictions {
+		restrictions[i].Import(v)
+	}
+
+	return restrictions, nil
+}
+
+// ListRooms returns limit amount of rooms, with the offset specified
+func (s *Server) ListRooms(limit, offset int) ([]Room, error) {
+	arg := db.ListRoomsParams{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	// get list of availabe rooms
+	dbRooms, err := s.DatabaseStore.ListRooms(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	l := len(dbRooms)
+	if l == 0 {
+		return []Room{}, nil
+	}
+
+	rooms := make([]Room, l)
+	for i := 0; i < l; i++ {
+		rooms[i].Import(dbRooms[i])
+	}
+
+	return rooms, nil
+}
+
+// ListUsers returns limit amount of staff users ordered by name, with the offset specified
+func (s *Server) ListUsers(limit, offset int) ([]User, error) {
+	arg := db.ListUsersParams{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbUsers, err := s.DatabaseStore.ListUsers(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]User, len(dbUsers))
+	for i, dbUser := range dbUsers {
+		users[i].Import(dbUser)
+	}
+
+	return users, nil
+}
+
+// NotifyWaitlist offers room roomID, freed between startDate and endDate, to the guests on the waitlist.
+// The room is held for each guest offered for app.WaitlistOfferTTL.
+// It returns the waitlist entries of the guests offered the room.
+func (s *Server) NotifyWaitlist(roomID int64, startDate, endDate time.Time) ([]WaitlistEntry, error) {
+	arg := db.NotifyWaitlistTxParams{RoomID: roomID}
+	arg.StartDate.Scan(startDate)
+	arg.EndDate.Scan(endDate)
+	arg.ExpiresAt.Scan(time.Now().Add(app.WaitlistOfferTTL()))
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	// execute database transaction
+	dbEntries, err := s.DatabaseStore.NotifyWaitlistTx(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]WaitlistEntry, len(dbEntries))
+	for i, v := range dbEntries {
+		entries[i].Import(v)
+	}
+
+	return entries, nil
+}
+
+// QuoteStay returns the price of a stay of adults and children in room from startDate to endDate,
+// including taxes and fees, and discounted by promoCode if not empty
+func (s *Server) QuoteStay(room Room, startDate, endDate time.Time, adults, children int, promoCode string) (Quote, error) {
+	arg := db.QuoteStayParams{
+		RoomID:    room.ID,
+		Adults:    int32(adults),
+		Children:  int32(children),
+		PromoCode: promoCode,
+	}
+	arg.StartDate.Scan(startDate)
+	arg.EndDate.Scan(endDate)
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbQuote, err := s.DatabaseStore.QuoteStay(ctx, arg)
+	if err != nil {
+		return Quote{}, err
+	}
+
+	quote := Quote{Room: room}
+	quote.Import(dbQuote)
+
+	return quote, nil
+}
+
+// QuoteStays returns the price of a stay from startDate to endDate in every room of rooms booked together,
+// for the guests staying in each room as split, including taxes and fees, and discounted by promoCode if not empty.
+// The promo code applies once to the booking, so a fixed discount is split across the rooms.
+func (s *Server) QuoteStays(rooms []Room, startDate, endDate time.Time, split []Guests, promoCode string) ([]Quote, error) {
+	args := make([]db.QuoteStayParams, len(rooms))
+	for i, room := range rooms {
+		args[i] = db.QuoteStayParams{
+			RoomID:    room.ID,
+			Adults:    int32(split[i].Adults),
+			Children:  int32(split[i].Children),
+			PromoCode: promoCode,
+		}
+		args[i].StartDate.Scan(startDate)
+		args[i].EndDate.Scan(endDate)
+	}
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbQuotes, err := s.DatabaseStore.QuoteStays(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	quotes := make([]Quote, len(dbQuotes))
+	for i, dbQuote := range dbQuotes {
+		quotes[i].Room = rooms[i]
+		quotes[i].Import(dbQuote)
+	}
+
+	return quotes, nil
+}
+
+// QuoteReservationDates returns the price of the stay of reservation r from startDate to endDate,
+// discounted by the promo code of the reservation if any
+func (s *Server) QuoteReservationDates(r Reservation, startDate, endDate time.Time) (Quote, error) {
+	arg := db.QuoteStayParams{
+		RoomID:   r.RoomID,
+		Adults:   int32(r.Adults),
+		Children: int32(r.Children),
+	}
+	arg.StartDate.Scan(startDate)
+	arg.EndDate.Scan(endDate)
+	if r.PromoCodeID != 0 {
+		arg.PromoCodeID.Scan(r.PromoCodeID)
+	}
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbQuote, err := s.DatabaseStore.QuoteStay(ctx, arg)
+	if err != nil {
+		return Quote{}, err
+	}
+
+	quote := Quote{Room: r.Room}
+	quote.Import(dbQuote)
+
+	return quote, nil
+}
+
+// ReleaseRoomHold releases the hold of the guest identified by holdToken on room roomID
+func (s *Server) ReleaseRoomHold(roomID int64, holdToken string) error {
+	arg := db.DeleteRoomHoldParams{
+		RoomID: roomID,
+	}
+	arg.HoldToken.Scan(holdToken)
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	return s.DatabaseStore.DeleteRoomHold(ctx, arg)
+}
+
+// ReleaseRoomHolds releases all the holds of the guest identified by holdToken
+func (s *Server) ReleaseRoomHolds(holdToken string) error {
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	return s.DatabaseStore.DeleteRoomHoldsByToken(ctx, pgtype.Text{
+		String: holdToken,
+		Valid:  true,
+	})
 }
 
-// CheckRoomAvailability checks if room is available
-func (s *Server) CheckRoomAvailability(roomID int64, startDate, endData time.Time) (bool, error) {
-	// parse form's data to query arguments
-	arg := db.CheckRoomAvailabilityParams{}
-	arg.RoomID = roomID
-	err := arg.StartDate.Scan(startDate)
-	if err != nil {
-		return false, err
+// UpdateOwnerBlock updates the room, nights and reason of owner block b.
+// The block is not changed if the room is reserved on some of the nights, and db.ErrOwnerBlockConflict is returned,
+// unless override is true.
+func (s *Server) UpdateOwnerBlock(b RoomRestriction, override bool) (RoomRestriction, error) {
+	arg := db.UpdateOwnerBlockTxParams{
+		UpdateOwnerBlockParams: db.UpdateOwnerBlockParams{
+			ID:     b.ID,
+			RoomID: b.RoomID,
+			Reason: b.Reason,
+		},
+		Override: override,
 	}
+	arg.StartDate.Scan(b.StartDate)
+	arg.EndDate.Scan(b.EndDate)
 
-	err = arg.EndDate.Scan(endData)
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbBlock, err := s.DatabaseStore.UpdateOwnerBlockTx(ctx, arg)
 	if err != nil {
-		return false, err
+		return b, err
+	}
+
+	b.Import(dbBlock)
+
+	return b, nil
+}
+
+// UpdateRoom updates room r in database
+func (s *Server) UpdateRoom(r Room) error {
+	arg := db.UpdateRoomParams{
+		ID:            r.ID,
+		Name:          r.Name,
+		Description:   r.Description,
+		ImageFilename: r.ImageFilename,
+		MaxAdults:     int32(r.MaxAdults),
+		MaxChildren:   int32(r.MaxChildren),
+		MaxOccupancy:  int32(r.MaxOccupancy),
+		Slug:          r.Slug,
+		NightlyRate:   int64(r.NightlyRate),
 	}
+	arg.UpdatedAt.Scan(time.Now())
 
 	// create context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
 	defer cancel()
 
-	// get list of availabe rooms
-	return s.DatabaseStore.CheckRoomAvailability(ctx, arg)
+	return s.DatabaseStore.UpdateRoom(ctx, arg)
 }
 
-// CreateReservation insert reservation data into database.
-// it updates r with new data from database.
-func (s *Server) CreateReservation(r Reservation) error {
-	// create database transaction arguments
-	arg := db.CreateReservationParams{
-		Code:      r.Code,
+// UpdateUser updates the name, email and role of staff user u
+func (s *Server) UpdateUser(u User) error {
+	arg := db.UpdateUserParams{
+		ID:          u.ID,
+		FirstName:   u.FirstName,
+		LastName:    u.LastName,
+		Email:       u.Email,
+		AccessLevel: int64(u.AccessLevel),
+	}
+	arg.UpdatedAt.Scan(time.Now())
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	return s.DatabaseStore.UpdateUser(ctx, arg)
+}
+
+// UpdateUserDeactivatedAt deactivates the staff user with id at deactivatedAt.
+// A zero deactivatedAt reactivates the user.
+func (s *Server) UpdateUserDeactivatedAt(id int64, deactivatedAt time.Time) error {
+	arg := db.UpdateUserDeactivatedAtParams{ID: id}
+	if !deactivatedAt.IsZero() {
+		arg.DeactivatedAt.Scan(deactivatedAt)
+	}
+	arg.UpdatedAt.Scan(time.Now())
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	return s.DatabaseStore.UpdateUserDeactivatedAt(ctx, arg)
+}
+
+// UpdateUserPasswordToken updates the password token of staff user u, and the time it expires
+func (s *Server) UpdateUserPasswordToken(u User) error {
+	arg := db.UpdateUserPasswordTokenParams{ID: u.ID}
+	arg.PasswordToken.Scan(u.PasswordToken)
+	arg.PasswordTokenExpiresAt.Scan(u.PasswordTokenExpiresAt)
+	arg.UpdatedAt.Scan(time.Now())
+
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	return s.DatabaseStore.UpdateUserPasswordToken(ctx, arg)
+}
+
+// UpdateReservation updates the guest details and the stay of reservation r, together with its room restrictions.
+// It returns db.ErrRoomUnavailable if the room of r is not available on its dates or does not fit its guests,
+// or the updated reservation, without the room data.
+func (s *Server) UpdateReservation(r Reservation) (Reservation, error) {
+	arg := db.UpdateReservationParams{
+		ID:        r.ID,
 		FirstName: r.FirstName,
 		LastName:  r.LastName,
 		Email:     r.Email,
 		RoomID:    r.RoomID,
+		Adults:    int32(r.Adults),
+		Children:  int32(r.Children),
 	}
 	arg.Phone.Scan(r.Phone)
-	arg.StartDate.Scan(r.StartDate)
-	arg.EndDate.Scan(r.EndDate)
 	arg.Notes.Scan(r.Notes)
 
+	err := arg.StartDate.Scan(r.StartDate)
+	if err != nil {
+		return r, err
+	}
+
+	err = arg.EndDate.Scan(r.EndDate)
+	if err != nil {
+		return r, err
+	}
+
 	// create context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
 	defer cancel()
 
 	// execute database transaction
-	_, err := s.DatabaseStore.CreateReservationTx(ctx, arg)
+	dbRsv, err := s.DatabaseStore.UpdateReservationTx(ctx, arg)
+	if err != nil {
+		return r, err
+	}
 
-	return err
+	rsv := Reservation{}
+	rsv.Import(dbRsv)
+
+	return rsv, nil
 }
 
-// ListAvailableRooms returns limit amount of avaiable rooms in a date range, with the offset specified
-func (s *Server) ListAvailableRooms(limit, offset int, startDate, endData time.Time) ([]Room, error) {
-	// parse form's data to query arguments
-	arg := db.ListAvailableRoomsParams{
-		Limit:  int32(limit),
-		Offset: int32(offset),
-	}
+// UpdateReservationDates changes the dates of reservation r to startDate and endDate,
+// if the room is available on the new dates.
+// It returns the updated reservation, including the room data of r.
+func (s *Server) UpdateReservationDates(r Reservation, startDate, endDate time.Time) (Reservation, error) {
+	arg := db.UpdateReservationDatesParams{ID: r.ID}
 	err := arg.StartDate.Scan(startDate)
 	if err != nil {
-		return []Room{}, err
+		return r, err
 	}
 
-	err = arg.EndDate.Scan(endData)
+	err = arg.EndDate.Scan(endDate)
 	if err != nil {
-		return []Room{}, err
+		return r, err
 	}
 
 	// create context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
 	defer cancel()
 
-	// get list of availabe rooms
-	dbRooms, err := s.DatabaseStore.ListAvailableRooms(ctx, arg)
+	// execute database transaction
+	dbRsv, err := s.DatabaseStore.UpdateReservationDatesTx(ctx, arg)
 	if err != nil {
-		return []Room{}, err
+		return r, err
 	}
 
-	l := len(dbRooms)
-	if l == 0 {
-		return []Room{}, nil
+	rsv := Reservation{}
+	rsv.Import(dbRsv)
+	rsv.Room = r.Room
+
+	return rsv, nil
+}
+
+// UpdateReservationStatus moves reservation r to status on date.
+// It returns db.ErrInvalidStatusTransition if r cannot move to status,
+// or the updated reservation, including the room data of r.
+func (s *Server) UpdateReservationStatus(r Reservation, status ReservationStatus, date time.Time) (Reservation, error) {
+	arg := db.UpdateReservationStatusTxParams{
+		UpdateReservationStatusParams: db.UpdateReservationStatusParams{
+			ID:     r.ID,
+			Status: db.ReservationStatus(status),
+		},
 	}
+	arg.Date.Scan(date)
 
-	rooms := make([]Room, l)
-	for i := 0; i < l; i++ {
-		rooms[i].Import(dbRooms[i])
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	// execute database transaction
+	dbRsv, err := s.DatabaseStore.UpdateReservationStatusTx(ctx, arg)
+	if err != nil {
+		return r, err
 	}
 
-	return rooms, nil
+	rsv := Reservation{}
+	rsv.Import(dbRsv)
+	rsv.Room = r.Room
+
+	return rsv, nil
 }
 
-// ListReservations returns limit amount of reservations, with the offset specified.
-func (s *Server) ListReservations(limit, offset int) ([]Reservation, error) {
-	arg := db.ListReservationsAndRoomsParams{
-		Limit:  int32(limit),
-		Offset: int32(offset),
+// UpdateInvoiceDocument updates the document of the invoice with id and returns the invoice updated
+func (s *Server) UpdateInvoiceDocument(id int64, document string) (Invoice, error) {
+	arg := db.UpdateInvoiceDocumentParams{
+		ID:       id,
+		Document: document,
 	}
 
 	// create context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
 	defer cancel()
 
-	// get list of availabe rooms
-	dbRsvs, err := s.DatabaseStore.ListReservationsAndRooms(ctx, arg)
+	dbInvoice, err := s.DatabaseStore.UpdateInvoiceDocument(ctx, arg)
 	if err != nil {
-		return nil, err
+		return Invoice{}, err
 	}
 
-	l := len(dbRsvs)
-	if l == 0 {
-		return []Reservation{}, nil
+	invoice := Invoice{}
+	invoice.Import(dbInvoice)
+
+	return invoice, nil
+}
+
+// UpdatePaymentStatus updates the status of the payment transactions reported by provider as providerRef,
+// and returns the payments updated
+func (s *Server) UpdatePaymentStatus(provider, providerRef string, status PaymentStatus) ([]Payment, error) {
+	arg := db.UpdatePaymentStatusByProviderRefParams{
+		Provider:    provider,
+		ProviderRef: providerRef,
+		Status:      db.PaymentStatus(status),
 	}
 
-	rsvs := make([]Reservation, l)
-	for i := 0; i < l; i++ {
-		rsvs[i].ImportWithRoom(dbRsvs[i])
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbPayments, err := s.DatabaseStore.UpdatePaymentStatusByProviderRef(ctx, arg)
+	if err != nil {
+		return nil, err
 	}
 
-	return rsvs, nil
+	payments := make([]Payment, len(dbPayments))
+	for i, v := range dbPayments {
+		payments[i].Import(v)
+	}
+
+	return payments, nil
 }
 
-// ListRooms returns limit amount of rooms, with the offset specified
-func (s *Server) ListRooms(limit, offset int) ([]Room, error) {
-	arg := db.ListRoomsParams{
-		Limit:  int32(limit),
-		Offset: int32(offset),
+// UpsertExchangeRate inserts the exchange rate e into database, or updates it if its currency already has one,
+// and returns the exchange rate saved
+func (s *Server) UpsertExchangeRate(e ExchangeRate) (ExchangeRate, error) {
+	arg := db.UpsertExchangeRateParams{
+		Currency: e.Currency,
+		Symbol:   e.Symbol,
+		Rate:     e.Rate,
 	}
 
 	// create context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
 	defer cancel()
 
-	// get list of availabe rooms
-	dbRooms, err := s.DatabaseStore.ListRooms(ctx, arg)
+	dbRate, err := s.DatabaseStore.UpsertExchangeRate(ctx, arg)
 	if err != nil {
-		return nil, err
+		return e, err
 	}
 
-	l := len(dbRooms)
-	if l == 0 {
-		return []Room{}, nil
+	e.Import(dbRate)
+
+	return e, nil
+}
+
+// VoidFolioEntry voids the folio entry with id of reservation reservationID on behalf of staff member userID,
+// and returns the entry voided. It returns pgx.ErrNoRows if the reservation has no such entry or it is already voided.
+func (s *Server) VoidFolioEntry(id, reservationID, userID int64) (FolioEntry, error) {
+	arg := db.VoidFolioEntryParams{
+		ID:            id,
+		ReservationID: reservationID,
+	}
+	if userID != 0 {
+		arg.VoidedBy.Scan(userID)
 	}
 
-	rooms := make([]Room, l)
-	for i := 0; i < l; i++ {
-		rooms[i].Import(dbRooms[i])
+	// create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ContextTimeout)
+	defer cancel()
+
+	dbEntry, err := s.DatabaseStore.VoidFolioEntry(ctx, arg)
+	if err != nil {
+		return FolioEntry{}, err
 	}
 
-	return rooms, nil
+	e := FolioEntry{}
+	e.Import(dbEntry)
+
+	return e, nil
 }
 
 // Import update r with the data from dbr
@@ -191,6 +1661,20 @@ func (r *Reservation) Import(dbr db.Reservation) {
 	r.Notes = dbr.Notes.String
 	r.CreatedAt = dbr.CreatedAt.Time
 	r.UpdatedAt = dbr.UpdatedAt.Time
+	r.CancelledAt = dbr.CancelledAt.Time
+	r.CancelledBy = dbr.CancelledBy.String
+	r.CancellationFeePercent = int(dbr.CancellationFeePercent)
+	r.ParentCode = dbr.ParentCode.String
+	r.Adults = int(dbr.Adults)
+	r.Children = int(dbr.Children)
+	r.Status = ReservationStatus(dbr.Status)
+	r.ConfirmedAt = dbr.ConfirmedAt.Time
+	r.CheckedInAt = dbr.CheckedInAt.Time
+	r.CheckedOutAt = dbr.CheckedOutAt.Time
+	r.NoShowAt = dbr.NoShowAt.Time
+	r.TotalPrice = Price(dbr.TotalPrice)
+	r.PromoCodeID = dbr.PromoCodeID.Int64
+	r.Discount = Price(dbr.Discount)
 }
 
 // Export update dbr with the data from r
@@ -207,6 +1691,36 @@ func (r *Reservation) Export(dbr *db.Reservation) {
 	dbr.Notes.Scan(r.Notes)
 	dbr.CreatedAt.Scan(r.CreatedAt)
 	dbr.UpdatedAt.Scan(r.UpdatedAt)
+	if !r.CancelledAt.IsZero() {
+		dbr.CancelledAt.Scan(r.CancelledAt)
+	}
+	if r.CancelledBy != "" {
+		dbr.CancelledBy.Scan(r.CancelledBy)
+	}
+	dbr.CancellationFeePercent = int32(r.CancellationFeePercent)
+	if r.ParentCode != "" {
+		dbr.ParentCode.Scan(r.ParentCode)
+	}
+	dbr.Adults = int32(r.Adults)
+	dbr.Children = int32(r.Children)
+	dbr.Status = db.ReservationStatus(r.Status)
+	if !r.ConfirmedAt.IsZero() {
+		dbr.ConfirmedAt.Scan(r.ConfirmedAt)
+	}
+	if !r.CheckedInAt.IsZero() {
+		dbr.CheckedInAt.Scan(r.CheckedInAt)
+	}
+	if !r.CheckedOutAt.IsZero() {
+		dbr.CheckedOutAt.Scan(r.CheckedOutAt)
+	}
+	if !r.NoShowAt.IsZero() {
+		dbr.NoShowAt.Scan(r.NoShowAt)
+	}
+	dbr.TotalPrice = int64(r.TotalPrice)
+	if r.PromoCodeID != 0 {
+		dbr.PromoCodeID.Scan(r.PromoCodeID)
+	}
+	dbr.Discount = int64(r.Discount)
 }
 
 // ImportWithRoom update r with the data from dbr, imcluding the room data
@@ -225,8 +1739,13 @@ func (r *Reservation) ExportWithRoom(dbr *db.ListReservationsAndRoomsRow) {
 func (r *Room) Import(dbr db.Room) {
 	r.ID = dbr.ID
 	r.Name = dbr.Name
+	r.Slug = dbr.Slug
 	r.Description = dbr.Description
 	r.ImageFilename = dbr.ImageFilename
+	r.MaxAdults = int(dbr.MaxAdults)
+	r.MaxChildren = int(dbr.MaxChildren)
+	r.MaxOccupancy = int(dbr.MaxOccupancy)
+	r.NightlyRate = Price(dbr.NightlyRate)
 	r.CreatedAt = dbr.CreatedAt.Time
 	r.UpdatedAt = dbr.UpdatedAt.Time
 }
@@ -235,8 +1754,364 @@ func (r *Room) Import(dbr db.Room) {
 func (r *Room) Export(dbr *db.Room) {
 	dbr.ID = r.ID
 	dbr.Name = r.Name
+	dbr.Slug = r.Slug
 	dbr.Description = r.Description
 	dbr.ImageFilename = r.ImageFilename
+	dbr.MaxAdults = int32(r.MaxAdults)
+	dbr.MaxChildren = int32(r.MaxChildren)
+	dbr.MaxOccupancy = int32(r.MaxOccupancy)
+	dbr.NightlyRate = int64(r.NightlyRate)
+	dbr.CreatedAt.Scan(r.CreatedAt)
+	dbr.UpdatedAt.Scan(r.UpdatedAt)
+}
+
+// Import update r with the data from dbr
+func (r *RoomRate) Import(dbr db.RoomRate) {
+	r.ID = dbr.ID
+	r.RoomID = dbr.RoomID
+	r.Name = dbr.Name
+	r.StartDate = dbr.StartDate.Time
+	r.EndDate = dbr.EndDate.Time
+	r.DaysOfWeek = int(dbr.DaysOfWeek)
+	r.Rate = Price(dbr.Rate)
+	r.Priority = int(dbr.Priority)
+	r.CreatedAt = dbr.CreatedAt.Time
+	r.UpdatedAt = dbr.UpdatedAt.Time
+}
+
+// Export update dbr with the data from r
+func (r *RoomRate) Export(dbr *db.RoomRate) {
+	dbr.ID = r.ID
+	dbr.RoomID = r.RoomID
+	dbr.Name = r.Name
+	dbr.StartDate.Scan(r.StartDate)
+	dbr.EndDate.Scan(r.EndDate)
+	dbr.DaysOfWeek = int32(r.DaysOfWeek)
+	dbr.Rate = int64(r.Rate)
+	dbr.Priority = int32(r.Priority)
+	dbr.CreatedAt.Scan(r.CreatedAt)
+	dbr.UpdatedAt.Scan(r.UpdatedAt)
+}
+
+// ImportWithRoom update r with the data from dbr, imcluding the room data
+func (r *RoomRate) ImportWithRoom(dbr db.ListRoomRatesAndRoomsRow) {
+	r.Import(dbr.RoomRate)
+	r.Room.Import(dbr.Room)
+}
+
+// Import update r with the data from dbr
+func (r *RoomRestriction) Import(dbr db.RoomRestriction) {
+	r.ID = dbr.ID
+	r.StartDate = dbr.StartDate.Time
+	r.EndDate = dbr.EndDate.Time
+	r.RoomID = dbr.RoomID
+	r.ReservationID = dbr.ReservationID.Int64
+	r.Restriction = Restriction(dbr.Restriction)
+	r.CreatedAt = dbr.CreatedAt.Time
+	r.UpdatedAt = dbr.UpdatedAt.Time
+	r.HoldToken = dbr.HoldToken.String
+	r.ExpiresAt = dbr.ExpiresAt.Time
+	r.Reason = dbr.Reason
+}
+
+// ImportWithRoom update r with the data from dbr, including the room data
+func (r *RoomRestriction) ImportWithRoom(dbr db.ListOwnerBlocksAndRoomsRow) {
+	r.Import(dbr.RoomRestriction)
+	r.Room.Import(dbr.Room)
+}
+
+// Export update dbr with the data from r
+func (r *RoomRestriction) Export(dbr *db.RoomRestriction) {
+	dbr.ID = r.ID
+	dbr.StartDate.Scan(r.StartDate)
+	dbr.EndDate.Scan(r.EndDate)
+	dbr.RoomID = r.RoomID
+	if r.ReservationID != 0 {
+		dbr.ReservationID.Scan(r.ReservationID)
+	}
+	dbr.Restriction = db.Restriction(r.Restriction)
 	dbr.CreatedAt.Scan(r.CreatedAt)
 	dbr.UpdatedAt.Scan(r.UpdatedAt)
+	if r.HoldToken != "" {
+		dbr.HoldToken.Scan(r.HoldToken)
+	}
+	if !r.ExpiresAt.IsZero() {
+		dbr.ExpiresAt.Scan(r.ExpiresAt)
+	}
+	dbr.Reason = r.Reason
+}
+
+// Import update e with the data from dbe
+func (e *WaitlistEntry) Import(dbe db.WaitlistEntry) {
+	e.ID = dbe.ID
+	e.Token = dbe.Token
+	e.FirstName = dbe.FirstName
+	e.LastName = dbe.LastName
+	e.Email = dbe.Email
+	e.StartDate = dbe.StartDate.Time
+	e.EndDate = dbe.EndDate.Time
+	e.RoomID = dbe.RoomID.Int64
+	e.Adults = int(dbe.Adults)
+	e.Children = int(dbe.Children)
+	e.OfferedRoomID = dbe.OfferedRoomID.Int64
+	e.NotifiedAt = dbe.NotifiedAt.Time
+	e.ExpiresAt = dbe.ExpiresAt.Time
+	e.CreatedAt = dbe.CreatedAt.Time
+	e.UpdatedAt = dbe.UpdatedAt.Time
+}
+
+// Export update dbe with the data from e
+func (e *WaitlistEntry) Export(dbe *db.WaitlistEntry) {
+	dbe.ID = e.ID
+	dbe.Token = e.Token
+	dbe.FirstName = e.FirstName
+	dbe.LastName = e.LastName
+	dbe.Email = e.Email
+	dbe.StartDate.Scan(e.StartDate)
+	dbe.EndDate.Scan(e.EndDate)
+	if e.RoomID != 0 {
+		dbe.RoomID.Scan(e.RoomID)
+	}
+	dbe.Adults = int32(e.Adults)
+	dbe.Children = int32(e.Children)
+	if e.OfferedRoomID != 0 {
+		dbe.OfferedRoomID.Scan(e.OfferedRoomID)
+	}
+	if !e.NotifiedAt.IsZero() {
+		dbe.NotifiedAt.Scan(e.NotifiedAt)
+	}
+	if !e.ExpiresAt.IsZero() {
+		dbe.ExpiresAt.Scan(e.ExpiresAt)
+	}
+	dbe.CreatedAt.Scan(e.CreatedAt)
+	dbe.UpdatedAt.Scan(e.UpdatedAt)
+}
+
+// Import update q with the data from dbq
+func (q *Quote) Import(dbq db.Quote) {
+	q.Nights = make([]NightlyRate, len(dbq.Nights))
+	for i, night := range dbq.Nights {
+		q.Nights[i] = NightlyRate{
+			Date: night.Date.Time,
+			Rate: Price(night.Rate),
+		}
+	}
+	q.Subtotal = Price(dbq.Subtotal)
+	q.Discount = Price(dbq.Discount)
+	q.Charges = make([]QuoteCharge, len(dbq.Charges))
+	for i, c := range dbq.Charges {
+		q.Charges[i] = QuoteCharge{
+			Name:   c.Name,
+			Amount: Price(c.Amount),
+		}
+	}
+	q.Total = Price(dbq.Total)
+}
+
+// Import update c with the data from dbc
+func (c *Charge) Import(dbc db.Charge) {
+	c.ID = dbc.ID
+	c.Name = dbc.Name
+	c.Kind = ChargeKind(dbc.Kind)
+	c.Amount = dbc.Amount
+	c.CreatedAt = dbc.CreatedAt.Time
+	c.UpdatedAt = dbc.UpdatedAt.Time
+}
+
+// Export update dbc with the data from c
+func (c *Charge) Export(dbc *db.Charge) {
+	dbc.ID = c.ID
+	dbc.Name = c.Name
+	dbc.Kind = db.ChargeKind(c.Kind)
+	dbc.Amount = c.Amount
+	dbc.CreatedAt.Scan(c.CreatedAt)
+	dbc.UpdatedAt.Scan(c.UpdatedAt)
+}
+
+// Import update c with the data from dbc
+func (c *ReservationCharge) Import(dbc db.ReservationCharge) {
+	c.ID = dbc.ID
+	c.ReservationID = dbc.ReservationID
+	c.ChargeID = dbc.ChargeID.Int64
+	c.Name = dbc.Name
+	c.Amount = Price(dbc.Amount)
+	c.CreatedAt = dbc.CreatedAt.Time
+}
+
+// Export update dbc with the data from c
+func (c *ReservationCharge) Export(dbc *db.ReservationCharge) {
+	dbc.ID = c.ID
+	dbc.ReservationID = c.ReservationID
+	if c.ChargeID != 0 {
+		dbc.ChargeID.Scan(c.ChargeID)
+	}
+	dbc.Name = c.Name
+	dbc.Amount = int64(c.Amount)
+	dbc.CreatedAt.Scan(c.CreatedAt)
+}
+
+// Import update p with the data from dbp
+func (p *Payment) Import(dbp db.Payment) {
+	p.ID = dbp.ID
+	p.ReservationID = dbp.ReservationID
+	p.ParentID = dbp.ParentID.Int64
+	p.RefundID = dbp.RefundID.Int64
+	p.Provider = dbp.Provider
+	p.ProviderRef = dbp.ProviderRef
+	p.Kind = PaymentKind(dbp.Kind)
+	p.Status = PaymentStatus(dbp.Status)
+	p.Amount = Price(dbp.Amount)
+	p.CreatedAt = dbp.CreatedAt.Time
+	p.UpdatedAt = dbp.UpdatedAt.Time
+}
+
+// Export update dbp with the data from p
+func (p *Payment) Export(dbp *db.Payment) {
+	dbp.ID = p.ID
+	dbp.ReservationID = p.ReservationID
+	if p.ParentID != 0 {
+		dbp.ParentID.Scan(p.ParentID)
+	}
+	if p.RefundID != 0 {
+		dbp.RefundID.Scan(p.RefundID)
+	}
+	dbp.Provider = p.Provider
+	dbp.ProviderRef = p.ProviderRef
+	dbp.Kind = db.PaymentKind(p.Kind)
+	dbp.Status = db.PaymentStatus(p.Status)
+	dbp.Amount = int64(p.Amount)
+	dbp.CreatedAt.Scan(p.CreatedAt)
+	dbp.UpdatedAt.Scan(p.UpdatedAt)
+}
+
+// Import update r with the data from dbr
+func (r *Refund) Import(dbr db.Refund) {
+	r.ID = dbr.ID
+	r.ReservationID = dbr.ReservationID
+	r.Paid = Price(dbr.Paid)
+	r.PolicyAmount = Price(dbr.PolicyAmount)
+	r.Amount = Price(dbr.Amount)
+	r.Reason = dbr.Reason
+	r.UserID = dbr.UserID.Int64
+	r.CreatedAt = dbr.CreatedAt.Time
+}
+
+// Export update dbr with the data from r
+func (r *Refund) Export(dbr *db.Refund) {
+	dbr.ID = r.ID
+	dbr.ReservationID = r.ReservationID
+	dbr.Paid = int64(r.Paid)
+	dbr.PolicyAmount = int64(r.PolicyAmount)
+	dbr.Amount = int64(r.Amount)
+	dbr.Reason = r.Reason
+	if r.UserID != 0 {
+		dbr.UserID.Scan(r.UserID)
+	}
+	dbr.CreatedAt.Scan(r.CreatedAt)
+}
+
+// Import update i with the data from dbi
+func (i *Invoice) Import(dbi db.Invoice) {
+	i.ID = dbi.ID
+	i.Property = dbi.Property
+	i.Number = dbi.Number
+	i.ReservationID = dbi.ReservationID
+	i.Document = dbi.Document
+	i.IssuedAt = dbi.IssuedAt.Time
+	i.UpdatedAt = dbi.UpdatedAt.Time
+}
+
+// Export update dbi with the data from i
+func (i *Invoice) Export(dbi *db.Invoice) {
+	dbi.ID = i.ID
+	dbi.Property = i.Property
+	dbi.Number = i.Number
+	dbi.ReservationID = i.ReservationID
+	dbi.Document = i.Document
+	dbi.IssuedAt.Scan(i.IssuedAt)
+	dbi.UpdatedAt.Scan(i.UpdatedAt)
+}
+
+// Import update e with the data from dbe
+func (e *FolioEntry) Import(dbe db.FolioEntry) {
+	e.ID = dbe.ID
+	e.ReservationID = dbe.ReservationID
+	e.Kind = FolioEntryKind(dbe.Kind)
+	e.Description = dbe.Description
+	e.Amount = Price(dbe.Amount)
+	e.UserID = dbe.UserID.Int64
+	e.VoidedAt = dbe.VoidedAt.Time
+	e.VoidedBy = dbe.VoidedBy.Int64
+	e.CreatedAt = dbe.CreatedAt.Time
+}
+
+// Export update dbe with the data from e
+func (e *FolioEntry) Export(dbe *db.FolioEntry) {
+	dbe.ID = e.ID
+	dbe.ReservationID = e.ReservationID
+	dbe.Kind = db.FolioEntryKind(e.Kind)
+	dbe.Description = e.Description
+	dbe.Amount = int64(e.Amount)
+	if e.UserID != 0 {
+		dbe.UserID.Scan(e.UserID)
+	}
+	if !e.VoidedAt.IsZero() {
+		dbe.VoidedAt.Scan(e.VoidedAt)
+	}
+	if e.VoidedBy != 0 {
+		dbe.VoidedBy.Scan(e.VoidedBy)
+	}
+	dbe.CreatedAt.Scan(e.CreatedAt)
+}
+
+// Import update e with the data from dbe
+func (e *ExchangeRate) Import(dbe db.ExchangeRate) {
+	e.Currency = dbe.Currency
+	e.Symbol = dbe.Symbol
+	e.Rate = dbe.Rate
+	e.UpdatedAt = dbe.UpdatedAt.Time
+}
+
+// Export update dbe with the data from e
+func (e *ExchangeRate) Export(dbe *db.ExchangeRate) {
+	dbe.Currency = e.Currency
+	dbe.Symbol = e.Symbol
+	dbe.Rate = e.Rate
+	dbe.UpdatedAt.Scan(e.UpdatedAt)
+}
+
+// Import update u with the data from dbu
+func (u *User) Import(dbu db.User) {
+	u.ID = dbu.ID
+	u.FirstName = dbu.FirstName
+	u.LastName = dbu.LastName
+	u.Email = dbu.Email
+	u.Password = dbu.Password
+	u.AccessLevel = Role(dbu.AccessLevel)
+	u.DeactivatedAt = dbu.DeactivatedAt.Time
+	u.PasswordToken = dbu.PasswordToken.String
+	u.PasswordTokenExpiresAt = dbu.PasswordTokenExpiresAt.Time
+	u.CreatedAt = dbu.CreatedAt.Time
+	u.UpdatedAt = dbu.UpdatedAt.Time
+}
+
+// Export update dbu with the data from u
+func (u *User) Export(dbu *db.User) {
+	dbu.ID = u.ID
+	dbu.FirstName = u.FirstName
+	dbu.LastName = u.LastName
+	dbu.Email = u.Email
+	dbu.Password = u.Password
+	dbu.AccessLevel = int64(u.AccessLevel)
+	if !u.DeactivatedAt.IsZero() {
+		dbu.DeactivatedAt.Scan(u.DeactivatedAt)
+	}
+	if u.PasswordToken != "" {
+		dbu.PasswordToken.Scan(u.PasswordToken)
+	}
+	if !u.PasswordTokenExpiresAt.IsZero() {
+		dbu.PasswordTokenExpiresAt.Scan(u.PasswordTokenExpiresAt)
+	}
+	dbu.CreatedAt.Scan(u.CreatedAt)
+	dbu.UpdatedAt.Scan(u.UpdatedAt)
 }
@@ -3,11 +3,14 @@ package main
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/github-real-lb/bookings-web-app/db"
 	"github.com/github-real-lb/bookings-web-app/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -19,19 +22,58 @@ func randomReservation() Reservation {
 	rRoom := randomRoom()
 
 	return Reservation{
-		ID:        util.RandomID(),
-		Code:      util.RandomString(ReservationCodeLenght),
-		FirstName: util.RandomName(),
-		LastName:  util.RandomName(),
-		Email:     util.RandomEmail(),
-		Phone:     util.RandomPhone(),
-		StartDate: rDate.Add(time.Hour * 24 * 30),
-		EndDate:   rDate.Add(time.Hour * 24 * 37),
-		RoomID:    rRoom.ID,
-		Notes:     util.RandomNote(),
-		CreatedAt: rDate.Add(time.Hour * 3),
-		UpdatedAt: rDate.Add(time.Hour * 3),
-		Room:      rRoom,
+		ID:         util.RandomID(),
+		Code:       util.RandomString(ReservationCodeLenght),
+		FirstName:  util.RandomName(),
+		LastName:   util.RandomName(),
+		Email:      util.RandomEmail(),
+		Phone:      util.RandomPhone(),
+		StartDate:  rDate.Add(time.Hour * 24 * 30),
+		EndDate:    rDate.Add(time.Hour * 24 * 37),
+		RoomID:     rRoom.ID,
+		Notes:      util.RandomNote(),
+		CreatedAt:  rDate.Add(time.Hour * 3),
+		UpdatedAt:  rDate.Add(time.Hour * 3),
+		Room:       rRoom,
+		Adults:     int(util.RandomInt64(1, int64(rRoom.MaxAdults))),
+		Children:   int(util.RandomInt64(0, int64(rRoom.MaxChildren))),
+		Status:     ReservationPending,
+		TotalPrice: 7 * rRoom.NightlyRate,
+	}
+}
+
+// randomRoomRestriction returns a RoomRestriction struct with random data, restricting the room of reservation rsv
+func randomRoomRestriction(rsv Reservation) RoomRestriction {
+	randomTime := util.RandomDatetime()
+
+	return RoomRestriction{
+		ID:            util.RandomID(),
+		StartDate:     rsv.StartDate,
+		EndDate:       rsv.EndDate,
+		RoomID:        rsv.RoomID,
+		ReservationID: rsv.ID,
+		Restriction:   RestrictionReservation,
+		CreatedAt:     randomTime,
+		UpdatedAt:     randomTime,
+	}
+}
+
+// randomOwnerBlock returns an owner block of a random room with random data
+func randomOwnerBlock() RoomRestriction {
+	randomTime := util.RandomDatetime()
+	startDate := util.RandomDate()
+	room := randomRoom()
+
+	return RoomRestriction{
+		ID:          util.RandomID(),
+		StartDate:   startDate,
+		EndDate:     startDate.AddDate(0, 0, 3),
+		RoomID:      room.ID,
+		Restriction: RestrictionOwnerBlock,
+		CreatedAt:   randomTime,
+		UpdatedAt:   randomTime,
+		Reason:      util.RandomString(20),
+		Room:        room,
 	}
 }
 
@@ -41,8 +83,13 @@ func randomRoom() Room {
 	return Room{
 		ID:            util.RandomID(),
 		Name:          util.RandomName(),
+		Slug:          util.Slugify(util.RandomName()),
 		Description:   util.RandomNote(),
 		ImageFilename: fmt.Sprint(util.RandomName(), ".png"),
+		MaxAdults:     2,
+		MaxChildren:   2,
+		MaxOccupancy:  4,
+		NightlyRate:   Price(util.RandomInt64(50, 500) * 100),
 		CreatedAt:     randomTime,
 		UpdatedAt:     randomTime,
 	}
@@ -59,6 +106,136 @@ func randomRooms(n int) []Room {
 	return rooms
 }
 
+// randomWaitlistEntry returns a WaitlistEntry struct with random data, offered the room of the entry
+func randomWaitlistEntry() WaitlistEntry {
+	rDate := util.RandomDate()
+	roomID := util.RandomID()
+
+	return WaitlistEntry{
+		ID:            util.RandomID(),
+		Token:         util.RandomString(HoldTokenLength),
+		FirstName:     util.RandomName(),
+		LastName:      util.RandomName(),
+		Email:         util.RandomEmail(),
+		StartDate:     rDate.Add(time.Hour * 24 * 30),
+		EndDate:       rDate.Add(time.Hour * 24 * 37),
+		RoomID:        roomID,
+		Adults:        2,
+		Children:      1,
+		OfferedRoomID: roomID,
+		NotifiedAt:    time.Now().Add(-time.Hour),
+		ExpiresAt:     time.Now().Add(app.WaitlistOfferTTL() - time.Hour),
+		CreatedAt:     rDate.Add(time.Hour * 3),
+		UpdatedAt:     rDate.Add(time.Hour * 3),
+	}
+}
+
+// randomRoomRate returns a RoomRate struct with random data, including the room data
+func randomRoomRate() RoomRate {
+	rDate := util.RandomDate()
+	rRoom := randomRoom()
+
+	return RoomRate{
+		ID:         util.RandomID(),
+		RoomID:     rRoom.ID,
+		Name:       util.RandomName(),
+		StartDate:  rDate,
+		EndDate:    rDate.AddDate(0, 0, 30),
+		DaysOfWeek: int(db.DaysOfWeekMask(time.Friday, time.Saturday)),
+		Rate:       rRoom.NightlyRate + 5000,
+		Priority:   int(util.RandomInt64(0, 10)),
+		CreatedAt:  rDate.Add(time.Hour * 3),
+		UpdatedAt:  rDate.Add(time.Hour * 3),
+		Room:       rRoom,
+	}
+}
+
+// randomCharge returns a Charge struct with random data
+func randomCharge() Charge {
+	randomTime := util.RandomDatetime()
+
+	return Charge{
+		ID:        util.RandomID(),
+		Name:      util.RandomName(),
+		Kind:      ChargePerPersonNight,
+		Amount:    util.RandomInt64(100, 1000),
+		CreatedAt: randomTime,
+		UpdatedAt: randomTime,
+	}
+}
+
+// randomExchangeRate returns an ExchangeRate struct with random data
+func randomExchangeRate() ExchangeRate {
+	return ExchangeRate{
+		Currency:  strings.ToUpper(util.RandomString(3)),
+		Symbol:    util.RandomString(1),
+		Rate:      util.RandomInt64(1, 200*RateScale),
+		UpdatedAt: util.RandomDatetime(),
+	}
+}
+
+// randomFolioEntry returns a FolioEntry struct with random data
+func randomFolioEntry() FolioEntry {
+	return FolioEntry{
+		ID:            util.RandomID(),
+		ReservationID: util.RandomID(),
+		Kind:          FolioCharge,
+		Description:   util.RandomName(),
+		Amount:        Price(util.RandomInt64(100, 10000)),
+		UserID:        util.RandomID(),
+		CreatedAt:     util.RandomDatetime(),
+	}
+}
+
+// randomInvoice returns an Invoice struct with random data
+func randomInvoice() Invoice {
+	randomTime := util.RandomDatetime()
+
+	return Invoice{
+		ID:            util.RandomID(),
+		Property:      app.Listing.Name,
+		Number:        util.RandomInt64(1, 1000),
+		ReservationID: util.RandomID(),
+		Document:      "<html>" + util.RandomNote() + "</html>",
+		IssuedAt:      randomTime,
+		UpdatedAt:     randomTime,
+	}
+}
+
+// randomPayment returns a Payment struct with random data
+func randomPayment() Payment {
+	randomTime := util.RandomDatetime()
+
+	return Payment{
+		ID:            util.RandomID(),
+		ReservationID: util.RandomID(),
+		ParentID:      util.RandomID(),
+		Provider:      "fake",
+		ProviderRef:   util.RandomString(20),
+		Kind:          PaymentCapture,
+		Status:        PaymentSucceeded,
+		Amount:        Price(util.RandomInt64(100, 10000)),
+		CreatedAt:     randomTime,
+		UpdatedAt:     randomTime,
+	}
+}
+
+// randomRefund returns a Refund struct with random data
+func randomRefund() Refund {
+	paid := Price(util.RandomInt64(1000, 10000))
+
+	return Refund{
+		ID:            util.RandomID(),
+		ReservationID: util.RandomID(),
+		Paid:          paid,
+		PolicyAmount:  paid / 2,
+		Amount:        paid,
+		Reason:        util.RandomNote(),
+		UserID:        util.RandomID(),
+		CreatedAt:     util.RandomDatetime(),
+	}
+}
+
 // randomUser returns a User struct with random data
 func randomUser() User {
 	randomTime := util.RandomDatetime()
@@ -69,7 +246,7 @@ func randomUser() User {
 		LastName:    util.RandomName(),
 		Email:       util.RandomEmail(),
 		Password:    util.RandomPassword(),
-		AccessLevel: util.RandomInt64(1, 10),
+		AccessLevel: Role(util.RandomInt64(int64(RoleOwner), int64(RoleReadOnly))),
 		CreatedAt:   randomTime,
 		UpdatedAt:   randomTime,
 	}
@@ -91,8 +268,7 @@ func TestServer_AuthenticateUser(t *testing.T) {
 	t.Run("OK", func(t *testing.T) {
 		// create stub return arguments
 		dbUser := db.User{}
-		err := util.CopyDataUsingJSON(user, &dbUser)
-		require.NoError(t, err)
+		user.Export(&dbUser)
 
 		// build stub
 		ts.MockDBStore.On("AuthenticateUser", mock.Anything, arg).
@@ -101,7 +277,8 @@ func TestServer_AuthenticateUser(t *testing.T) {
 
 		result, err := ts.AuthenticateUser(user.Email, user.Password)
 		require.NoError(t, err)
-		assert.Equal(t, user.ID, result)
+		assert.Equal(t, user.ID, result.ID)
+		assert.Equal(t, user.AccessLevel, result.AccessLevel)
 	})
 
 	t.Run("Error", func(t *testing.T) {
@@ -112,8 +289,43 @@ func TestServer_AuthenticateUser(t *testing.T) {
 
 		result, err := ts.AuthenticateUser(user.Email, user.Password)
 		require.Error(t, err)
-		require.Equal(t, int64(0), result)
+		require.Empty(t, result)
+
+	})
+}
+
+func TestServer_ChangeUserPassword(t *testing.T) {
+	user := randomUser()
+
+	// matchArg returns true if arg sets the password of user
+	matchArg := mock.MatchedBy(func(arg db.UpdateUserPasswordParams) bool {
+		return arg.ID == user.ID && arg.Password == user.Password && arg.UpdatedAt.Valid
+	})
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ChangeUserPassword", mock.Anything, matchArg).
+			Return(nil).
+			Once()
+
+		// execute method and tesify
+		assert.NoError(t, ts.ChangeUserPassword(user.ID, user.Password))
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ChangeUserPassword", mock.Anything, matchArg).
+			Return(errors.New("any error")).
+			Once()
 
+		// execute method and tesify
+		assert.Error(t, ts.ChangeUserPassword(user.ID, user.Password))
 	})
 }
 
@@ -145,7 +357,9 @@ func TestServer_CheckRoomAvailability(t *testing.T) {
 
 	// create ts.MockDBStore mehod arguments
 	arg := db.CheckRoomAvailabilityParams{
-		RoomID: rsv.RoomID,
+		RoomID:   rsv.RoomID,
+		Adults:   int32(rsv.Adults),
+		Children: int32(rsv.Children),
 	}
 	arg.StartDate.Scan(rsv.StartDate)
 	arg.EndDate.Scan(rsv.EndDate)
@@ -161,7 +375,7 @@ func TestServer_CheckRoomAvailability(t *testing.T) {
 				Once()
 
 			// execute method
-			ok, err := ts.CheckRoomAvailability(rsv.RoomID, rsv.StartDate, rsv.EndDate)
+			ok, err := ts.CheckRoomAvailability(rsv.RoomID, rsv.StartDate, rsv.EndDate, rsv.Adults, rsv.Children)
 
 			// testify
 			assert.Equal(t, test.Available, ok)
@@ -170,6 +384,124 @@ func TestServer_CheckRoomAvailability(t *testing.T) {
 	}
 }
 
+func TestServer_CheckStayRules(t *testing.T) {
+	rooms := randomRooms(3)
+	startDate := util.RandomDate()
+	endDate := startDate.AddDate(0, 0, 1)
+
+	// create stub call arguments
+	arg := db.ListStayRulesForDatesParams{}
+	arg.StartDate.Scan(startDate)
+	arg.EndDate.Scan(endDate)
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments, with a minimum stay rule on the first room
+		// and a rule the stay does not break on the second room
+		dbRules := []db.StayRule{
+			{ID: util.RandomID(), RoomID: rooms[0].ID, DaysOfWeek: db.AllDaysOfWeek, MinNights: 2},
+			{ID: util.RandomID(), RoomID: rooms[1].ID, DaysOfWeek: db.AllDaysOfWeek, MaxNights: 7},
+		}
+		for i := range dbRules {
+			dbRules[i].StartDate.Scan(startDate)
+			dbRules[i].EndDate.Scan(endDate)
+		}
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListStayRulesForDates", mock.Anything, arg).
+			Return(dbRules, nil).
+			Once()
+
+		// execute method
+		allowed, ruleErrs, err := ts.CheckStayRules(rooms, startDate, endDate)
+
+		// tesify
+		require.NoError(t, err)
+		assert.Equal(t, rooms[1:], allowed)
+		require.Len(t, ruleErrs, 1)
+		assert.Equal(t, dbRules[0], ruleErrs[0].Rule)
+		assert.Equal(t, db.ViolationMinNights, ruleErrs[0].Violation)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListStayRulesForDates", mock.Anything, arg).
+			Return(nil, errors.New("any error")).
+			Once()
+
+		// execute method
+		allowed, ruleErrs, err := ts.CheckStayRules(rooms, startDate, endDate)
+
+		// tesify
+		assert.Error(t, err)
+		assert.Nil(t, allowed)
+		assert.Nil(t, ruleErrs)
+	})
+}
+
+func TestServer_CancelReservation(t *testing.T) {
+	// create random reservation with room data
+	rsv := randomReservation()
+
+	// create stub call arguments
+	arg := db.CancelReservationParams{
+		ID:                     rsv.ID,
+		CancellationFeePercent: 50,
+	}
+	arg.CancelledBy.Scan("guest")
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		cancelled := rsv
+		cancelled.CancelledAt = time.Now()
+		cancelled.Status = ReservationCancelled
+		cancelled.CancelledBy = "guest"
+		cancelled.CancellationFeePercent = 50
+
+		dbRsv := db.Reservation{}
+		cancelled.Export(&dbRsv)
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("CancelReservationTx", mock.Anything, arg).
+			Return(dbRsv, nil).
+			Once()
+
+		// execute method
+		result, err := ts.CancelReservation(rsv, "guest", 50)
+
+		// tesify
+		require.NoError(t, err)
+		testReservation(t, dbRsv, result)
+		assert.Equal(t, rsv.Room, result.Room)
+		assert.True(t, result.IsCancelled())
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("CancelReservationTx", mock.Anything, arg).
+			Return(db.Reservation{}, db.ErrReservationCancelled).
+			Once()
+
+		// execute method
+		result, err := ts.CancelReservation(rsv, "guest", 50)
+
+		// tesify
+		assert.ErrorIs(t, err, db.ErrReservationCancelled)
+		assert.Equal(t, rsv, result)
+	})
+}
+
 func TestServer_CreateReservation(t *testing.T) {
 	t.Run("Test OK", func(t *testing.T) {
 		// create random reservation with room data
@@ -182,6 +514,8 @@ func TestServer_CreateReservation(t *testing.T) {
 			LastName:  rsv.LastName,
 			Email:     rsv.Email,
 			RoomID:    rsv.RoomID,
+			Adults:    int32(rsv.Adults),
+			Children:  int32(rsv.Children),
 		}
 		arg.Phone.Scan(rsv.Phone)
 		arg.StartDate.Scan(rsv.StartDate)
@@ -218,6 +552,8 @@ func TestServer_CreateReservation(t *testing.T) {
 			LastName:  rsv.LastName,
 			Email:     rsv.Email,
 			RoomID:    rsv.RoomID,
+			Adults:    int32(rsv.Adults),
+			Children:  int32(rsv.Children),
 		}
 		arg.Phone.Scan(rsv.Phone)
 		arg.StartDate.Scan(rsv.StartDate)
@@ -240,238 +576,3208 @@ func TestServer_CreateReservation(t *testing.T) {
 	})
 }
 
-func TestServer_ListAvailableRooms(t *testing.T) {
-	// create random reservation with room data
-	rsv := randomReservation()
-
-	//create db stub call arguments
-	arg := db.ListAvailableRoomsParams{
-		Limit:  LimitRoomsPerPage,
-		Offset: 0,
+func TestServer_CreateReservations(t *testing.T) {
+	// create random reservations booked together
+	rsvs := []Reservation{randomReservation(), randomReservation()}
+	for i := range rsvs {
+		rsvs[i].ParentCode = rsvs[0].Code
 	}
-	arg.StartDate.Scan(rsv.StartDate)
-	arg.EndDate.Scan(rsv.EndDate)
-
-	t.Run("Test Available []Room", func(t *testing.T) {
-		// create stub return arguments
-		rooms := make([]Room, LimitRoomsPerPage)
-		dbRooms := make([]db.Room, LimitRoomsPerPage)
+	holdToken := util.RandomString(HoldTokenLength)
 
-		for i := 0; i < LimitRoomsPerPage; i++ {
-			rooms[i] = randomRoom()
-			err := util.CopyDataUsingJSON(rooms[i], &dbRooms[i])
-			require.NoError(t, err)
+	// create stub call arguments
+	args := make([]db.CreateReservationParams, len(rsvs))
+	for i, rsv := range rsvs {
+		args[i] = db.CreateReservationParams{
+			Code:      rsv.Code,
+			FirstName: rsv.FirstName,
+			LastName:  rsv.LastName,
+			Email:     rsv.Email,
+			RoomID:    rsv.RoomID,
+			Adults:    int32(rsv.Adults),
+			Children:  int32(rsv.Children),
 		}
+		args[i].Phone.Scan(rsv.Phone)
+		args[i].StartDate.Scan(rsv.StartDate)
+		args[i].EndDate.Scan(rsv.EndDate)
+		args[i].Notes.Scan(rsv.Notes)
+		args[i].ParentCode.Scan(rsv.ParentCode)
+	}
 
+	t.Run("Test OK", func(t *testing.T) {
 		// create a new server with mock database store
 		ts := NewTestServer(t)
 
+		// create stub return arguments
+		dbRsvs := make([]db.Reservation, len(rsvs))
+		for i, rsv := range rsvs {
+			rsv.Export(&dbRsvs[i])
+		}
+
 		// build stub
-		ts.MockDBStore.On("ListAvailableRooms", mock.Anything, arg).
-			Return(dbRooms, nil).
+		ts.MockDBStore.On("CreateReservationsTx", mock.Anything, args, holdToken, "SUMMER").
+			Return(dbRsvs, nil).
 			Once()
+		for _, dbRsv := range dbRsvs {
+			ts.MockDBStore.On("ListReservationCharges", mock.Anything, dbRsv.ID).
+				Return([]db.ReservationCharge{{ReservationID: dbRsv.ID, Name: "VAT", Amount: 1700}}, nil).
+				Once()
+		}
 
 		// execute method
-		resultRooms, err := ts.ListAvailableRooms(int(arg.Limit), int(arg.Offset), rsv.StartDate, rsv.EndDate)
+		result, err := ts.CreateReservations(rsvs, holdToken, "SUMMER")
 
 		// tesify
 		assert.NoError(t, err)
-		require.Len(t, resultRooms, LimitRoomsPerPage)
-
-		for i := 0; i < LimitRoomsPerPage; i++ {
-			room := rooms[i]
-			resultRoom := resultRooms[i]
-			require.Equal(t, room.ID, resultRoom.ID)
-			require.Equal(t, room.Name, resultRoom.Name)
-			require.Equal(t, room.Description, resultRoom.Description)
-			require.Equal(t, room.ImageFilename, resultRoom.ImageFilename)
-			require.WithinDuration(t, room.CreatedAt, resultRoom.CreatedAt, time.Second)
-			require.WithinDuration(t, room.UpdatedAt, resultRoom.UpdatedAt, time.Second)
+		require.Len(t, result, len(rsvs))
+		for i := range result {
+			testReservation(t, dbRsvs[i], result[i])
+			assert.Equal(t, rsvs[i].Room, result[i].Room)
+			require.Len(t, result[i].Charges, 1)
+			assert.Equal(t, "VAT", result[i].Charges[0].Name)
+			assert.Equal(t, Price(1700), result[i].Charges[0].Amount)
 		}
 	})
 
-	t.Run("Test No Available []Room", func(t *testing.T) {
+	t.Run("Test Error", func(t *testing.T) {
 		// create a new server with mock database store
 		ts := NewTestServer(t)
 
 		// build stub
-		ts.MockDBStore.On("ListAvailableRooms", mock.Anything, arg).
-			Return([]db.Room{}, nil).
+		ts.MockDBStore.On("CreateReservationsTx", mock.Anything, args, holdToken, "").
+			Return(nil, db.ErrRoomUnavailable).
 			Once()
 
 		// execute method
-		resultRooms, err := ts.ListAvailableRooms(int(arg.Limit), int(arg.Offset), rsv.StartDate, rsv.EndDate)
+		result, err := ts.CreateReservations(rsvs, holdToken, "")
 
 		// tesify
-		assert.NoError(t, err)
-		require.Len(t, resultRooms, 0)
+		assert.ErrorIs(t, err, db.ErrRoomUnavailable)
+		assert.Empty(t, result)
 	})
+}
 
-	t.Run("Test Error", func(t *testing.T) {
-		// create random reservation with room data
-		rsv := randomReservation()
+func TestServer_CreateWaitlistEntry(t *testing.T) {
+	// create random waitlist entry
+	entry := randomWaitlistEntry()
 
-		//create db stub call arguments
-		arg := db.ListAvailableRoomsParams{
-			Limit:  LimitRoomsPerPage,
-			Offset: 0,
-		}
-		arg.StartDate.Scan(rsv.StartDate)
-		arg.EndDate.Scan(rsv.EndDate)
+	// create stub call arguments
+	arg := db.CreateWaitlistEntryParams{
+		Token:     entry.Token,
+		FirstName: entry.FirstName,
+		LastName:  entry.LastName,
+		Email:     entry.Email,
+		Adults:    int32(entry.Adults),
+		Children:  int32(entry.Children),
+	}
+	arg.StartDate.Scan(entry.StartDate)
+	arg.EndDate.Scan(entry.EndDate)
+	arg.RoomID.Scan(entry.RoomID)
 
+	t.Run("Test OK", func(t *testing.T) {
 		// create a new server with mock database store
 		ts := NewTestServer(t)
 
 		// build stub
-		ts.MockDBStore.On("ListAvailableRooms", mock.Anything, arg).
-			Return([]db.Room{}, errors.New("any error")).
+		ts.MockDBStore.On("CreateWaitlistEntry", mock.Anything, arg).
+			Return(db.WaitlistEntry{}, nil).
 			Once()
 
 		// execute method
-		rooms, err := ts.ListAvailableRooms(int(arg.Limit), int(arg.Offset), rsv.StartDate, rsv.EndDate)
+		err := ts.CreateWaitlistEntry(entry)
 
 		// tesify
-		assert.Error(t, err)
-		require.Len(t, rooms, 0)
+		assert.NoError(t, err)
 	})
-}
-
-func TestServer_ListReservations(t *testing.T) {
-	//create stub db call arguments
-	arg := db.ListReservationsAndRoomsParams{
-		Limit:  LimitReservationsPerPage,
-		Offset: 0,
-	}
 
-	t.Run("Test All Reservations", func(t *testing.T) {
-		// create stub return arguments
-		rsvs := make([]Reservation, LimitReservationsPerPage)
-		dbRsvs := make([]db.ListReservationsAndRoomsRow, LimitReservationsPerPage)
-		var err error
+	t.Run("Test Any Room", func(t *testing.T) {
+		// create a waitlist entry for any room
+		anyRoom := entry
+		anyRoom.RoomID = 0
 
-		for i := 0; i < LimitReservationsPerPage; i++ {
-			rsvs[i] = randomReservation()
-			rsvs[i].ExportWithRoom(&dbRsvs[i])
-		}
+		argAnyRoom := arg
+		argAnyRoom.RoomID = pgtype.Int8{}
 
 		// create a new server with mock database store
 		ts := NewTestServer(t)
 
 		// build stub
-		ts.MockDBStore.On("ListReservationsAndRooms", mock.Anything, arg).
-			Return(dbRsvs, nil).
+		ts.MockDBStore.On("CreateWaitlistEntry", mock.Anything, argAnyRoom).
+			Return(db.WaitlistEntry{}, nil).
 			Once()
 
 		// execute method
-		result, err := ts.ListReservations(int(arg.Limit), int(arg.Offset))
+		err := ts.CreateWaitlistEntry(anyRoom)
 
 		// tesify
 		assert.NoError(t, err)
-		require.Len(t, result, LimitReservationsPerPage)
-
-		for i := 0; i < LimitReservationsPerPage; i++ {
-			require.Equal(t, rsvs[i].ID, result[i].ID)
-			require.Equal(t, rsvs[i].Room.ID, result[i].Room.ID)
-		}
 	})
 
-	t.Run("Test No Reservations", func(t *testing.T) {
+	t.Run("Test Error", func(t *testing.T) {
 		// create a new server with mock database store
 		ts := NewTestServer(t)
 
 		// build stub
-		ts.MockDBStore.On("ListReservationsAndRooms", mock.Anything, arg).
-			Return([]db.ListReservationsAndRoomsRow{}, nil).
+		ts.MockDBStore.On("CreateWaitlistEntry", mock.Anything, arg).
+			Return(db.WaitlistEntry{}, errors.New("any error")).
+			Once()
+
+		// execute method
+		err := ts.CreateWaitlistEntry(entry)
+
+		// tesify
+		assert.Error(t, err)
+	})
+}
+
+func TestServer_CreateOwnerBlocks(t *testing.T) {
+	// create random owner block of several rooms
+	block := randomOwnerBlock()
+	roomIDs := []int64{block.RoomID, util.RandomID()}
+
+	// create stub call arguments
+	arg := db.CreateOwnerBlocksTxParams{
+		RoomIDs:  roomIDs,
+		Reason:   block.Reason,
+		Override: true,
+	}
+	arg.StartDate.Scan(block.StartDate)
+	arg.EndDate.Scan(block.EndDate)
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("CreateOwnerBlocksTx", mock.Anything, arg).
+			Return(make([]db.RoomRestriction, len(roomIDs)), nil).
+			Once()
+
+		// execute method and tesify
+		assert.NoError(t, ts.CreateOwnerBlocks(block, roomIDs, true))
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("CreateOwnerBlocksTx", mock.Anything, arg).
+			Return(nil, db.ErrOwnerBlockConflict).
+			Once()
+
+		// execute method and tesify
+		assert.ErrorIs(t, ts.CreateOwnerBlocks(block, roomIDs, true), db.ErrOwnerBlockConflict)
+	})
+}
+
+func TestServer_CreateRoom(t *testing.T) {
+	// create random room
+	room := randomRoom()
+
+	// create stub call arguments
+	arg := db.CreateRoomParams{
+		Name:          room.Name,
+		Description:   room.Description,
+		ImageFilename: room.ImageFilename,
+		MaxAdults:     int32(room.MaxAdults),
+		MaxChildren:   int32(room.MaxChildren),
+		MaxOccupancy:  int32(room.MaxOccupancy),
+		Slug:          room.Slug,
+		NightlyRate:   int64(room.NightlyRate),
+	}
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbRoom := db.Room{}
+		room.Export(&dbRoom)
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("CreateRoom", mock.Anything, arg).
+			Return(dbRoom, nil).
+			Once()
+
+		// execute method
+		result, err := ts.CreateRoom(Room{
+			Name:          room.Name,
+			Slug:          room.Slug,
+			Description:   room.Description,
+			ImageFilename: room.ImageFilename,
+			MaxAdults:     room.MaxAdults,
+			MaxChildren:   room.MaxChildren,
+			MaxOccupancy:  room.MaxOccupancy,
+			NightlyRate:   room.NightlyRate,
+		})
+
+		// tesify
+		require.NoError(t, err)
+		testRoom(t, dbRoom, result)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("CreateRoom", mock.Anything, arg).
+			Return(db.Room{}, errors.New("any error")).
+			Once()
+
+		// execute method and tesify
+		_, err := ts.CreateRoom(room)
+		assert.Error(t, err)
+	})
+}
+
+func TestServer_CreateRoomRates(t *testing.T) {
+	// create random room rates
+	rates := []RoomRate{randomRoomRate(), randomRoomRate()}
+
+	// create stub call arguments
+	args := make([]db.CreateRoomRateParams, len(rates))
+	for i, rate := range rates {
+		args[i] = db.CreateRoomRateParams{
+			RoomID:     rate.RoomID,
+			Name:       rate.Name,
+			DaysOfWeek: int32(rate.DaysOfWeek),
+			Rate:       int64(rate.Rate),
+			Priority:   int32(rate.Priority),
+		}
+		args[i].StartDate.Scan(rate.StartDate)
+		args[i].EndDate.Scan(rate.EndDate)
+	}
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("CreateRoomRatesTx", mock.Anything, args).
+			Return(make([]db.RoomRate, len(rates)), nil).
+			Once()
+
+		// execute method and tesify
+		assert.NoError(t, ts.CreateRoomRates(rates))
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("CreateRoomRatesTx", mock.Anything, args).
+			Return(nil, errors.New("any error")).
+			Once()
+
+		// execute method and tesify
+		assert.Error(t, ts.CreateRoomRates(rates))
+	})
+}
+
+func TestServer_CreateUser(t *testing.T) {
+	user := randomUser()
+
+	// create stub call arguments
+	arg := db.CreateUserParams{
+		FirstName:   user.FirstName,
+		LastName:    user.LastName,
+		Email:       user.Email,
+		Password:    user.Password,
+		AccessLevel: int64(user.AccessLevel),
+	}
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbUser := db.User{}
+		user.Export(&dbUser)
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("CreateNewUser", mock.Anything, arg).
+			Return(dbUser, nil).
+			Once()
+
+		// execute method
+		result, err := ts.CreateUser(User{
+			FirstName:   user.FirstName,
+			LastName:    user.LastName,
+			Email:       user.Email,
+			Password:    user.Password,
+			AccessLevel: user.AccessLevel,
+		})
+
+		// tesify
+		require.NoError(t, err)
+		testUser(t, dbUser, result)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("CreateNewUser", mock.Anything, arg).
+			Return(db.User{}, errors.New("any error")).
+			Once()
+
+		// execute method and tesify
+		_, err := ts.CreateUser(user)
+		assert.Error(t, err)
+	})
+}
+
+func TestServer_CreateCharge(t *testing.T) {
+	c := randomCharge()
+
+	// create stub call arguments
+	arg := db.CreateChargeParams{
+		Name:   c.Name,
+		Kind:   db.ChargeKindPerPersonNight,
+		Amount: c.Amount,
+	}
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("CreateCharge", mock.Anything, arg).
+			Return(db.Charge{}, nil).
+			Once()
+
+		// execute method and tesify
+		assert.NoError(t, ts.CreateCharge(c))
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("CreateCharge", mock.Anything, arg).
+			Return(db.Charge{}, errors.New("any error")).
+			Once()
+
+		// execute method and tesify
+		assert.Error(t, ts.CreateCharge(c))
+	})
+}
+
+func TestServer_CreateFolioEntry(t *testing.T) {
+	entry := randomFolioEntry()
+
+	// create stub call arguments
+	arg := db.CreateFolioEntryParams{
+		ReservationID: entry.ReservationID,
+		Kind:          db.FolioEntryKind(entry.Kind),
+		Description:   entry.Description,
+		Amount:        int64(entry.Amount),
+	}
+	arg.UserID.Scan(entry.UserID)
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbEntry := db.FolioEntry{}
+		entry.Export(&dbEntry)
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("CreateFolioEntry", mock.Anything, arg).
+			Return(dbEntry, nil).
+			Once()
+
+		// execute method
+		result, err := ts.CreateFolioEntry(entry)
+
+		// tesify
+		require.NoError(t, err)
+		testFolioEntry(t, dbEntry, result)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("CreateFolioEntry", mock.Anything, arg).
+			Return(db.FolioEntry{}, errors.New("any error")).
+			Once()
+
+		// execute method
+		_, err := ts.CreateFolioEntry(entry)
+
+		// tesify
+		assert.Error(t, err)
+	})
+}
+
+func TestServer_CreatePayment(t *testing.T) {
+	p := randomPayment()
+
+	// create stub call arguments
+	arg := db.CreatePaymentParams{
+		ReservationID: p.ReservationID,
+		Provider:      p.Provider,
+		ProviderRef:   p.ProviderRef,
+		Kind:          db.PaymentKindCapture,
+		Status:        db.PaymentStatusSucceeded,
+		Amount:        int64(p.Amount),
+	}
+	arg.ParentID.Scan(p.ParentID)
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbPayment := db.Payment{}
+		p.Export(&dbPayment)
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("CreatePayment", mock.Anything, arg).
+			Return(dbPayment, nil).
+			Once()
+
+		// execute method
+		result, err := ts.CreatePayment(p)
+
+		// tesify
+		require.NoError(t, err)
+		testPayment(t, dbPayment, result)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("CreatePayment", mock.Anything, arg).
+			Return(db.Payment{}, errors.New("any error")).
+			Once()
+
+		// execute method and tesify
+		_, err := ts.CreatePayment(p)
+		assert.Error(t, err)
+	})
+}
+
+func TestServer_CreateRefund(t *testing.T) {
+	refund := randomRefund()
+	p := randomPayment()
+	p.ReservationID = refund.ReservationID
+	p.Kind = PaymentRefund
+
+	// create stub call arguments
+	arg := db.CreateRefundTxParams{
+		CreateRefundParams: db.CreateRefundParams{
+			ReservationID: refund.ReservationID,
+			Paid:          int64(refund.Paid),
+			PolicyAmount:  int64(refund.PolicyAmount),
+			Amount:        int64(refund.Amount),
+			Reason:        refund.Reason,
+		},
+		Payments: []db.CreatePaymentParams{{
+			ReservationID: p.ReservationID,
+			Provider:      p.Provider,
+			ProviderRef:   p.ProviderRef,
+			Kind:          db.PaymentKindRefund,
+			Status:        db.PaymentStatusSucceeded,
+			Amount:        int64(p.Amount),
+		}},
+	}
+	arg.UserID.Scan(refund.UserID)
+	arg.Payments[0].ParentID.Scan(p.ParentID)
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbRefund := db.Refund{}
+		refund.Export(&dbRefund)
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("CreateRefundTx", mock.Anything, arg).
+			Return(dbRefund, nil).
+			Once()
+
+		// execute method
+		result, err := ts.CreateRefund(refund, []Payment{p})
+
+		// tesify
+		require.NoError(t, err)
+		testRefund(t, dbRefund, result)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("CreateRefundTx", mock.Anything, arg).
+			Return(db.Refund{}, errors.New("any error")).
+			Once()
+
+		// execute method and tesify
+		_, err := ts.CreateRefund(refund, []Payment{p})
+		assert.Error(t, err)
+	})
+}
+
+func TestServer_DeleteCharges(t *testing.T) {
+	ids := []int64{util.RandomID(), util.RandomID()}
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("DeleteCharges", mock.Anything, ids).
+			Return(nil).
+			Once()
+
+		// execute method and tesify
+		assert.NoError(t, ts.DeleteCharges(ids))
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("DeleteCharges", mock.Anything, ids).
+			Return(errors.New("any error")).
+			Once()
+
+		// execute method and tesify
+		assert.Error(t, ts.DeleteCharges(ids))
+	})
+}
+
+func TestServer_DeleteExchangeRates(t *testing.T) {
+	currencies := []string{"EUR", "GBP"}
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("DeleteExchangeRates", mock.Anything, currencies).
+			Return(nil).
+			Once()
+
+		// execute method and tesify
+		assert.NoError(t, ts.DeleteExchangeRates(currencies))
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("DeleteExchangeRates", mock.Anything, currencies).
+			Return(errors.New("any error")).
+			Once()
+
+		// execute method and tesify
+		assert.Error(t, ts.DeleteExchangeRates(currencies))
+	})
+}
+
+func TestServer_DeleteOwnerBlocks(t *testing.T) {
+	ids := []int64{util.RandomID(), util.RandomID()}
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbBlocks := make([]db.RoomRestriction, len(ids))
+		for i := range dbBlocks {
+			block := randomOwnerBlock()
+			block.Export(&dbBlocks[i])
+		}
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("DeleteOwnerBlocks", mock.Anything, ids).
+			Return(dbBlocks, nil).
+			Once()
+
+		// execute method
+		blocks, err := ts.DeleteOwnerBlocks(ids)
+
+		// tesify
+		require.NoError(t, err)
+		require.Len(t, blocks, len(dbBlocks))
+		for i, v := range blocks {
+			testRoomRestriction(t, dbBlocks[i], v)
+		}
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("DeleteOwnerBlocks", mock.Anything, ids).
+			Return(nil, errors.New("any error")).
+			Once()
+
+		// execute method
+		blocks, err := ts.DeleteOwnerBlocks(ids)
+
+		// tesify
+		assert.Error(t, err)
+		assert.Nil(t, blocks)
+	})
+}
+
+func TestServer_DeleteRoom(t *testing.T) {
+	// create random room
+	room := randomRoom()
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbRoom := db.Room{}
+		room.Export(&dbRoom)
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("DeleteRoomTx", mock.Anything, room.ID).
+			Return(dbRoom, nil).
+			Once()
+
+		// execute method
+		result, err := ts.DeleteRoom(room.ID)
+
+		// tesify
+		require.NoError(t, err)
+		testRoom(t, dbRoom, result)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("DeleteRoomTx", mock.Anything, room.ID).
+			Return(db.Room{}, db.ErrRoomHasReservations).
+			Once()
+
+		// execute method
+		result, err := ts.DeleteRoom(room.ID)
+
+		// tesify
+		assert.ErrorIs(t, err, db.ErrRoomHasReservations)
+		assert.Empty(t, result)
+	})
+}
+
+func TestServer_DeleteRoomRates(t *testing.T) {
+	ids := []int64{util.RandomID(), util.RandomID()}
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("DeleteRoomRates", mock.Anything, ids).
+			Return(nil).
+			Once()
+
+		// execute method and tesify
+		assert.NoError(t, ts.DeleteRoomRates(ids))
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("DeleteRoomRates", mock.Anything, ids).
+			Return(errors.New("any error")).
+			Once()
+
+		// execute method and tesify
+		assert.Error(t, ts.DeleteRoomRates(ids))
+	})
+}
+
+func TestServer_DeleteExpiredRoomHolds(t *testing.T) {
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbHolds := make([]db.RoomRestriction, 3)
+		for i := range dbHolds {
+			dbHolds[i] = db.RoomRestriction{
+				ID:          util.RandomID(),
+				RoomID:      util.RandomID(),
+				Restriction: db.RestrictionHold,
+			}
+			dbHolds[i].StartDate.Scan(util.RandomDate())
+			dbHolds[i].EndDate.Scan(util.RandomDate())
+			dbHolds[i].HoldToken.Scan(util.RandomString(HoldTokenLength))
+		}
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("DeleteExpiredRoomHolds", mock.Anything).
+			Return(dbHolds, nil).
+			Once()
+
+		// execute method
+		holds, err := ts.DeleteExpiredRoomHolds()
+
+		// tesify
+		assert.NoError(t, err)
+		require.Len(t, holds, len(dbHolds))
+		for i, hold := range holds {
+			assert.Equal(t, dbHolds[i].ID, hold.ID)
+			assert.Equal(t, dbHolds[i].RoomID, hold.RoomID)
+			assert.Equal(t, RestrictionHold, hold.Restriction)
+			assert.Equal(t, dbHolds[i].HoldToken.String, hold.HoldToken)
+			assert.WithinDuration(t, dbHolds[i].StartDate.Time, hold.StartDate, time.Second)
+			assert.WithinDuration(t, dbHolds[i].EndDate.Time, hold.EndDate, time.Second)
+		}
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("DeleteExpiredRoomHolds", mock.Anything).
+			Return(nil, errors.New("any error")).
+			Once()
+
+		// execute method
+		_, err := ts.DeleteExpiredRoomHolds()
+
+		// tesify
+		assert.Error(t, err)
+	})
+}
+
+func TestServer_GetFolioBalance(t *testing.T) {
+	id := util.RandomID()
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("GetFolioBalance", mock.Anything, id).
+			Return(int64(1850), nil).
+			Once()
+
+		// execute method
+		balance, err := ts.GetFolioBalance(id)
+
+		// tesify
+		require.NoError(t, err)
+		assert.Equal(t, Price(1850), balance)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("GetFolioBalance", mock.Anything, id).
+			Return(int64(0), errors.New("any error")).
+			Once()
+
+		// execute method
+		_, err := ts.GetFolioBalance(id)
+
+		// tesify
+		assert.Error(t, err)
+	})
+}
+
+func TestServer_GetInvoiceByReservation(t *testing.T) {
+	// create random invoice
+	invoice := randomInvoice()
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbInvoice := db.Invoice{}
+		invoice.Export(&dbInvoice)
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("GetInvoiceByReservation", mock.Anything, invoice.ReservationID).
+			Return(dbInvoice, nil).
+			Once()
+
+		// execute method
+		result, err := ts.GetInvoiceByReservation(invoice.ReservationID)
+
+		// tesify
+		require.NoError(t, err)
+		testInvoice(t, dbInvoice, result)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("GetInvoiceByReservation", mock.Anything, invoice.ReservationID).
+			Return(db.Invoice{}, pgx.ErrNoRows).
+			Once()
+
+		// execute method
+		result, err := ts.GetInvoiceByReservation(invoice.ReservationID)
+
+		// tesify
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.Empty(t, result)
+	})
+}
+
+func TestServer_GetOwnerBlock(t *testing.T) {
+	// create random owner block
+	block := randomOwnerBlock()
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbBlock := db.RoomRestriction{}
+		block.Export(&dbBlock)
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("GetOwnerBlock", mock.Anything, block.ID).
+			Return(dbBlock, nil).
+			Once()
+
+		// execute method
+		result, err := ts.GetOwnerBlock(block.ID)
+
+		// tesify
+		require.NoError(t, err)
+		testRoomRestriction(t, dbBlock, result)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("GetOwnerBlock", mock.Anything, block.ID).
+			Return(db.RoomRestriction{}, pgx.ErrNoRows).
+			Once()
+
+		// execute method
+		result, err := ts.GetOwnerBlock(block.ID)
+
+		// tesify
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.Empty(t, result)
+	})
+}
+
+func TestServer_GetReservation(t *testing.T) {
+	// create random reservation
+	rsv := randomReservation()
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbRow := db.GetReservationAndRoomRow{}
+		rsv.Export(&dbRow.Reservation)
+		rsv.Room.Export(&dbRow.Room)
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(dbRow, nil).
+			Once()
+
+		// execute method
+		result, err := ts.GetReservation(rsv.ID)
+
+		// tesify
+		require.NoError(t, err)
+		testReservation(t, dbRow.Reservation, result)
+		testRoom(t, dbRow.Room, result.Room)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(db.GetReservationAndRoomRow{}, errors.New("any error")).
+			Once()
+
+		// execute method
+		result, err := ts.GetReservation(rsv.ID)
+
+		// tesify
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestServer_GetReservationByLastName(t *testing.T) {
+	// create random reservation with room data
+	rsv := randomReservation()
+
+	// create stub call arguments
+	arg := db.GetReservationByLastNameParams{
+		Code:     rsv.Code,
+		LastName: rsv.LastName,
+	}
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbRsv := db.Reservation{}
+		rsv.Export(&dbRsv)
+
+		dbRoom := db.Room{}
+		rsv.Room.Export(&dbRoom)
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationByLastName", mock.Anything, arg).
+			Return(dbRsv, nil).
+			Once()
+		ts.MockDBStore.On("GetRoom", mock.Anything, rsv.RoomID).
+			Return(dbRoom, nil).
+			Once()
+
+		// execute method
+		result, err := ts.GetReservationByLastName(rsv.Code, rsv.LastName)
+
+		// tesify
+		require.NoError(t, err)
+		testReservation(t, dbRsv, result)
+		testRoom(t, dbRoom, result.Room)
+	})
+
+	t.Run("Test Error Reservation", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("GetReservationByLastName", mock.Anything, arg).
+			Return(db.Reservation{}, errors.New("any error")).
+			Once()
+
+		// execute method
+		result, err := ts.GetReservationByLastName(rsv.Code, rsv.LastName)
+
+		// tesify
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("Test Error Room", func(t *testing.T) {
+		// create stub return arguments
+		dbRsv := db.Reservation{}
+		rsv.Export(&dbRsv)
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationByLastName", mock.Anything, arg).
+			Return(dbRsv, nil).
+			Once()
+		ts.MockDBStore.On("GetRoom", mock.Anything, rsv.RoomID).
+			Return(db.Room{}, errors.New("any error")).
+			Once()
+
+		// execute method
+		result, err := ts.GetReservationByLastName(rsv.Code, rsv.LastName)
+
+		// tesify
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestServer_GetRoom(t *testing.T) {
+	// create random room
+	room := randomRoom()
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbRoom := db.Room{}
+		room.Export(&dbRoom)
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("GetRoom", mock.Anything, room.ID).
+			Return(dbRoom, nil).
+			Once()
+
+		// execute method
+		result, err := ts.GetRoom(room.ID)
+
+		// tesify
+		assert.NoError(t, err)
+		testRoom(t, dbRoom, result)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("GetRoom", mock.Anything, room.ID).
+			Return(db.Room{}, errors.New("any error")).
+			Once()
+
+		// execute method
+		result, err := ts.GetRoom(room.ID)
+
+		// tesify
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestServer_GetRoomBySlug(t *testing.T) {
+	// create random room
+	room := randomRoom()
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbRoom := db.Room{}
+		room.Export(&dbRoom)
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("GetRoomBySlug", mock.Anything, room.Slug).
+			Return(dbRoom, nil).
+			Once()
+
+		// execute method
+		result, err := ts.GetRoomBySlug(room.Slug)
+
+		// tesify
+		assert.NoError(t, err)
+		testRoom(t, dbRoom, result)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("GetRoomBySlug", mock.Anything, room.Slug).
+			Return(db.Room{}, errors.New("any error")).
+			Once()
+
+		// execute method
+		result, err := ts.GetRoomBySlug(room.Slug)
+
+		// tesify
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestServer_GetUser(t *testing.T) {
+	user := randomUser()
+	dbUser := db.User{}
+	user.Export(&dbUser)
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("GetUser", mock.Anything, user.ID).
+			Return(dbUser, nil).
+			Once()
+
+		// execute method
+		result, err := ts.GetUser(user.ID)
+
+		// tesify
+		require.NoError(t, err)
+		testUser(t, dbUser, result)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("GetUser", mock.Anything, user.ID).
+			Return(db.User{}, pgx.ErrNoRows).
+			Once()
+
+		// execute method
+		result, err := ts.GetUser(user.ID)
+
+		// tesify
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.Empty(t, result)
+	})
+}
+
+func TestServer_GetUserByEmail(t *testing.T) {
+	user := randomUser()
+	dbUser := db.User{}
+	user.Export(&dbUser)
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("GetUserByEmail", mock.Anything, user.Email).
+			Return(dbUser, nil).
+			Once()
+
+		// execute method
+		result, err := ts.GetUserByEmail(user.Email)
+
+		// tesify
+		require.NoError(t, err)
+		testUser(t, dbUser, result)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("GetUserByEmail", mock.Anything, user.Email).
+			Return(db.User{}, pgx.ErrNoRows).
+			Once()
+
+		// execute method
+		result, err := ts.GetUserByEmail(user.Email)
+
+		// tesify
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.Empty(t, result)
+	})
+}
+
+func TestServer_GetUserByPasswordToken(t *testing.T) {
+	user := randomUser()
+	user.PasswordToken = util.RandomString(PasswordTokenLength)
+	user.PasswordTokenExpiresAt = time.Now().Add(PasswordTokenTTL)
+	dbUser := db.User{}
+	user.Export(&dbUser)
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("GetUserByPasswordToken", mock.Anything, dbUser.PasswordToken).
+			Return(dbUser, nil).
+			Once()
+
+		// execute method
+		result, err := ts.GetUserByPasswordToken(user.PasswordToken)
+
+		// tesify
+		require.NoError(t, err)
+		testUser(t, dbUser, result)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("GetUserByPasswordToken", mock.Anything, dbUser.PasswordToken).
+			Return(db.User{}, pgx.ErrNoRows).
+			Once()
+
+		// execute method
+		result, err := ts.GetUserByPasswordToken(user.PasswordToken)
+
+		// tesify
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.Empty(t, result)
+	})
+}
+
+func TestServer_GetWaitlistEntryByToken(t *testing.T) {
+	// create random waitlist entry
+	entry := randomWaitlistEntry()
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbEntry := db.WaitlistEntry{}
+		entry.Export(&dbEntry)
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("GetWaitlistEntryByToken", mock.Anything, entry.Token).
+			Return(dbEntry, nil).
+			Once()
+
+		// execute method
+		result, err := ts.GetWaitlistEntryByToken(entry.Token)
+
+		// tesify
+		assert.NoError(t, err)
+		testWaitlistEntry(t, dbEntry, result)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("GetWaitlistEntryByToken", mock.Anything, entry.Token).
+			Return(db.WaitlistEntry{}, errors.New("any error")).
+			Once()
+
+		// execute method
+		result, err := ts.GetWaitlistEntryByToken(entry.Token)
+
+		// tesify
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestServer_HoldRoom(t *testing.T) {
+	// create random reservation and hold token
+	rsv := randomReservation()
+	holdToken := util.RandomString(HoldTokenLength)
+
+	// matchArg checks the stub call arguments
+	matchArg := mock.MatchedBy(func(arg db.CreateRoomHoldTxParams) bool {
+		return arg.RoomID == rsv.RoomID &&
+			arg.HoldToken == pgtype.Text{String: holdToken, Valid: true} &&
+			arg.StartDate.Time.Equal(rsv.StartDate) &&
+			arg.EndDate.Time.Equal(rsv.EndDate) &&
+			arg.Adults == int32(rsv.Adults) &&
+			arg.Children == int32(rsv.Children) &&
+			arg.ExpiresAt.Valid &&
+			time.Until(arg.ExpiresAt.Time) > app.RoomHoldTTL()-time.Minute
+	})
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("CreateRoomHoldTx", mock.Anything, matchArg).
+			Return(db.RoomRestriction{}, nil).
+			Once()
+
+		// execute method
+		err := ts.HoldRoom(rsv, holdToken)
+
+		// tesify
+		assert.NoError(t, err)
+	})
+
+	t.Run("Test Room Unavailable", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("CreateRoomHoldTx", mock.Anything, matchArg).
+			Return(db.RoomRestriction{}, db.ErrRoomUnavailable).
+			Once()
+
+		// execute method
+		err := ts.HoldRoom(rsv, holdToken)
+
+		// tesify
+		assert.ErrorIs(t, err, db.ErrRoomUnavailable)
+	})
+}
+
+func TestServer_IssueInvoice(t *testing.T) {
+	invoice := randomInvoice()
+
+	// create stub call arguments
+	arg := db.IssueInvoiceTxParams{
+		Property:      app.Listing.Name,
+		ReservationID: invoice.ReservationID,
+	}
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbInvoice := db.Invoice{}
+		invoice.Export(&dbInvoice)
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("IssueInvoiceTx", mock.Anything, arg).
+			Return(dbInvoice, nil).
+			Once()
+
+		// execute method
+		result, err := ts.IssueInvoice(invoice.ReservationID)
+
+		// tesify
+		require.NoError(t, err)
+		testInvoice(t, dbInvoice, result)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("IssueInvoiceTx", mock.Anything, arg).
+			Return(db.Invoice{}, errors.New("any error")).
+			Once()
+
+		// execute method
+		result, err := ts.IssueInvoice(invoice.ReservationID)
+
+		// tesify
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestServer_ListAvailableRooms(t *testing.T) {
+	// create random reservation with room data
+	rsv := randomReservation()
+
+	//create db stub call arguments
+	arg := db.ListAvailableRoomsParams{
+		Limit:    LimitRoomsPerPage,
+		Offset:   0,
+		Adults:   int32(rsv.Adults),
+		Children: int32(rsv.Children),
+	}
+	arg.StartDate.Scan(rsv.StartDate)
+	arg.EndDate.Scan(rsv.EndDate)
+
+	t.Run("Test Available []Room", func(t *testing.T) {
+		// create stub return arguments
+		rooms := make([]Room, LimitRoomsPerPage)
+		dbRooms := make([]db.Room, LimitRoomsPerPage)
+
+		for i := 0; i < LimitRoomsPerPage; i++ {
+			rooms[i] = randomRoom()
+			err := util.CopyDataUsingJSON(rooms[i], &dbRooms[i])
+			require.NoError(t, err)
+		}
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListAvailableRooms", mock.Anything, arg).
+			Return(dbRooms, nil).
+			Once()
+
+		// execute method
+		resultRooms, err := ts.ListAvailableRooms(int(arg.Limit), int(arg.Offset), rsv.StartDate, rsv.EndDate, rsv.Adults, rsv.Children)
+
+		// tesify
+		assert.NoError(t, err)
+		require.Len(t, resultRooms, LimitRoomsPerPage)
+
+		for i := 0; i < LimitRoomsPerPage; i++ {
+			room := rooms[i]
+			resultRoom := resultRooms[i]
+			require.Equal(t, room.ID, resultRoom.ID)
+			require.Equal(t, room.Name, resultRoom.Name)
+			require.Equal(t, room.Description, resultRoom.Description)
+			require.Equal(t, room.ImageFilename, resultRoom.ImageFilename)
+			require.WithinDuration(t, room.CreatedAt, resultRoom.CreatedAt, time.Second)
+			require.WithinDuration(t, room.UpdatedAt, resultRoom.UpdatedAt, time.Second)
+		}
+	})
+
+	t.Run("Test No Available []Room", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListAvailableRooms", mock.Anything, arg).
+			Return([]db.Room{}, nil).
+			Once()
+
+		// execute method
+		resultRooms, err := ts.ListAvailableRooms(int(arg.Limit), int(arg.Offset), rsv.StartDate, rsv.EndDate, rsv.Adults, rsv.Children)
+
+		// tesify
+		assert.NoError(t, err)
+		require.Len(t, resultRooms, 0)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create random reservation with room data
+		rsv := randomReservation()
+
+		//create db stub call arguments
+		arg := db.ListAvailableRoomsParams{
+			Limit:    LimitRoomsPerPage,
+			Offset:   0,
+			Adults:   int32(rsv.Adults),
+			Children: int32(rsv.Children),
+		}
+		arg.StartDate.Scan(rsv.StartDate)
+		arg.EndDate.Scan(rsv.EndDate)
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListAvailableRooms", mock.Anything, arg).
+			Return([]db.Room{}, errors.New("any error")).
+			Once()
+
+		// execute method
+		rooms, err := ts.ListAvailableRooms(int(arg.Limit), int(arg.Offset), rsv.StartDate, rsv.EndDate, rsv.Adults, rsv.Children)
+
+		// tesify
+		assert.Error(t, err)
+		require.Len(t, rooms, 0)
+	})
+}
+
+func TestServer_ListReservations(t *testing.T) {
+	//create stub db call arguments
+	arg := db.ListReservationsAndRoomsParams{
+		Limit:  LimitReservationsPerPage,
+		Offset: 0,
+	}
+
+	t.Run("Test All Reservations", func(t *testing.T) {
+		// create stub return arguments
+		rsvs := make([]Reservation, LimitReservationsPerPage)
+		dbRsvs := make([]db.ListReservationsAndRoomsRow, LimitReservationsPerPage)
+		var err error
+
+		for i := 0; i < LimitReservationsPerPage; i++ {
+			rsvs[i] = randomReservation()
+			rsvs[i].ExportWithRoom(&dbRsvs[i])
+		}
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListReservationsAndRooms", mock.Anything, arg).
+			Return(dbRsvs, nil).
+			Once()
+
+		// execute method
+		result, err := ts.ListReservations(int(arg.Limit), int(arg.Offset))
+
+		// tesify
+		assert.NoError(t, err)
+		require.Len(t, result, LimitReservationsPerPage)
+
+		for i := 0; i < LimitReservationsPerPage; i++ {
+			require.Equal(t, rsvs[i].ID, result[i].ID)
+			require.Equal(t, rsvs[i].Room.ID, result[i].Room.ID)
+		}
+	})
+
+	t.Run("Test No Reservations", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListReservationsAndRooms", mock.Anything, arg).
+			Return([]db.ListReservationsAndRoomsRow{}, nil).
+			Once()
+
+		// execute method
+		result, err := ts.ListReservations(int(arg.Limit), int(arg.Offset))
+
+		// tesify
+		assert.NoError(t, err)
+		require.Len(t, result, 0)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListReservationsAndRooms", mock.Anything, arg).
+			Return(nil, errors.New("any error")).
 			Once()
 
 		// execute method
 		result, err := ts.ListReservations(int(arg.Limit), int(arg.Offset))
 
+		// tesify
+		assert.Error(t, err)
+		require.Nil(t, result)
+	})
+}
+
+func TestServer_ListArrivals(t *testing.T) {
+	// create stub call arguments
+	today := Today()
+	var arg pgtype.Date
+	arg.Scan(today)
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		rsvs := make([]Reservation, 3)
+		dbRsvs := make([]db.ListArrivalsAndRoomsRow, 3)
+
+		for i := range rsvs {
+			rsvs[i] = randomReservation()
+			rsvs[i].StartDate = today
+			rsvs[i].Export(&dbRsvs[i].Reservation)
+			rsvs[i].Room.Export(&dbRsvs[i].Room)
+		}
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListArrivalsAndRooms", mock.Anything, arg).
+			Return(dbRsvs, nil).
+			Once()
+
+		// execute method
+		result, err := ts.ListArrivals(today)
+
+		// tesify
+		assert.NoError(t, err)
+		require.Len(t, result, len(rsvs))
+
+		for i := range rsvs {
+			require.Equal(t, rsvs[i].ID, result[i].ID)
+			require.Equal(t, rsvs[i].Room.ID, result[i].Room.ID)
+		}
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListArrivalsAndRooms", mock.Anything, arg).
+			Return(nil, errors.New("any error")).
+			Once()
+
+		// execute method
+		result, err := ts.ListArrivals(today)
+
+		// tesify
+		assert.Error(t, err)
+		require.Nil(t, result)
+	})
+}
+
+func TestServer_ListDepartures(t *testing.T) {
+	// create stub call arguments
+	today := Today()
+	var arg pgtype.Date
+	arg.Scan(today)
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		rsvs := make([]Reservation, 3)
+		dbRsvs := make([]db.ListDeparturesAndRoomsRow, 3)
+
+		for i := range rsvs {
+			rsvs[i] = randomReservation()
+			rsvs[i].EndDate = today
+			rsvs[i].Status = ReservationCheckedIn
+			rsvs[i].Export(&dbRsvs[i].Reservation)
+			rsvs[i].Room.Export(&dbRsvs[i].Room)
+		}
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListDeparturesAndRooms", mock.Anything, arg).
+			Return(dbRsvs, nil).
+			Once()
+
+		// execute method
+		result, err := ts.ListDepartures(today)
+
+		// tesify
+		assert.NoError(t, err)
+		require.Len(t, result, len(rsvs))
+
+		for i := range rsvs {
+			require.Equal(t, rsvs[i].ID, result[i].ID)
+			require.Equal(t, ReservationCheckedIn, result[i].Status)
+			require.Equal(t, rsvs[i].Room.ID, result[i].Room.ID)
+		}
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListDeparturesAndRooms", mock.Anything, arg).
+			Return(nil, errors.New("any error")).
+			Once()
+
+		// execute method
+		result, err := ts.ListDepartures(today)
+
+		// tesify
+		assert.Error(t, err)
+		require.Nil(t, result)
+	})
+}
+
+func TestServer_ListReservationsByStatus(t *testing.T) {
+	//create stub db call arguments
+	arg := db.ListReservationsAndRoomsByStatusParams{
+		Status: db.ReservationStatusPending,
+		Limit:  LimitReservationsPerPage,
+		Offset: 0,
+	}
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		rsvs := make([]Reservation, 3)
+		dbRsvs := make([]db.ListReservationsAndRoomsByStatusRow, 3)
+
+		for i := range rsvs {
+			rsvs[i] = randomReservation()
+			rsvs[i].Export(&dbRsvs[i].Reservation)
+			rsvs[i].Room.Export(&dbRsvs[i].Room)
+		}
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListReservationsAndRoomsByStatus", mock.Anything, arg).
+			Return(dbRsvs, nil).
+			Once()
+
+		// execute method
+		result, err := ts.ListReservationsByStatus(ReservationPending, int(arg.Limit), int(arg.Offset))
+
+		// tesify
+		assert.NoError(t, err)
+		require.Len(t, result, len(rsvs))
+
+		for i := range rsvs {
+			require.Equal(t, rsvs[i].ID, result[i].ID)
+			require.Equal(t, ReservationPending, result[i].Status)
+			require.Equal(t, rsvs[i].Room.ID, result[i].Room.ID)
+		}
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListReservationsAndRoomsByStatus", mock.Anything, arg).
+			Return(nil, errors.New("any error")).
+			Once()
+
+		// execute method
+		result, err := ts.ListReservationsByStatus(ReservationPending, int(arg.Limit), int(arg.Offset))
+
+		// tesify
+		assert.Error(t, err)
+		require.Nil(t, result)
+	})
+}
+
+func TestServer_ListCharges(t *testing.T) {
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		charges := []Charge{randomCharge(), randomCharge()}
+		dbCharges := make([]db.Charge, len(charges))
+		for i, c := range charges {
+			c.Export(&dbCharges[i])
+		}
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListCharges", mock.Anything).
+			Return(dbCharges, nil).
+			Once()
+
+		// execute method
+		result, err := ts.ListCharges()
+
+		// tesify
+		require.NoError(t, err)
+		require.Len(t, result, len(charges))
+		for i, c := range result {
+			testCharge(t, dbCharges[i], c)
+		}
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListCharges", mock.Anything).
+			Return(nil, errors.New("any error")).
+			Once()
+
+		// execute method
+		result, err := ts.ListCharges()
+
+		// tesify
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestServer_ListExchangeRates(t *testing.T) {
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbRates := make([]db.ExchangeRate, 3)
+		for i := range dbRates {
+			rate := randomExchangeRate()
+			rate.Export(&dbRates[i])
+		}
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListExchangeRates", mock.Anything).
+			Return(dbRates, nil).
+			Once()
+
+		// execute method
+		rates, err := ts.ListExchangeRates()
+
+		// tesify
+		require.NoError(t, err)
+		require.Len(t, rates, len(dbRates))
+		for i, v := range rates {
+			testExchangeRate(t, dbRates[i], v)
+		}
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListExchangeRates", mock.Anything).
+			Return(nil, errors.New("any error")).
+			Once()
+
+		// execute method
+		rates, err := ts.ListExchangeRates()
+
+		// tesify
+		assert.Error(t, err)
+		assert.Nil(t, rates)
+	})
+}
+
+func TestServer_ListFolioEntries(t *testing.T) {
+	id := util.RandomID()
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbEntries := make([]db.FolioEntry, 3)
+		for i := range dbEntries {
+			entry := randomFolioEntry()
+			entry.ReservationID = id
+			entry.Export(&dbEntries[i])
+		}
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListFolioEntriesByReservation", mock.Anything, id).
+			Return(dbEntries, nil).
+			Once()
+
+		// execute method
+		entries, err := ts.ListFolioEntries(id)
+
+		// tesify
+		require.NoError(t, err)
+		require.Len(t, entries, len(dbEntries))
+		for i, v := range entries {
+			testFolioEntry(t, dbEntries[i], v)
+		}
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListFolioEntriesByReservation", mock.Anything, id).
+			Return(nil, errors.New("any error")).
+			Once()
+
+		// execute method
+		entries, err := ts.ListFolioEntries(id)
+
+		// tesify
+		assert.Error(t, err)
+		assert.Nil(t, entries)
+	})
+}
+
+func TestServer_ListOwnerBlocks(t *testing.T) {
+	date := Today()
+
+	//create stub db call arguments
+	arg := db.ListOwnerBlocksAndRoomsParams{
+		Limit:  LimitOwnerBlocksPerPage,
+		Offset: 0,
+	}
+	arg.Date.Scan(date)
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		blocks := []RoomRestriction{randomOwnerBlock(), randomOwnerBlock()}
+		dbBlocks := make([]db.ListOwnerBlocksAndRoomsRow, len(blocks))
+		for i, block := range blocks {
+			block.Export(&dbBlocks[i].RoomRestriction)
+			block.Room.Export(&dbBlocks[i].Room)
+		}
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListOwnerBlocksAndRooms", mock.Anything, arg).
+			Return(dbBlocks, nil).
+			Once()
+
+		// execute method
+		result, err := ts.ListOwnerBlocks(date, LimitOwnerBlocksPerPage, 0)
+
+		// tesify
+		require.NoError(t, err)
+		require.Len(t, result, len(blocks))
+		for i, block := range result {
+			testRoomRestriction(t, dbBlocks[i].RoomRestriction, block)
+			testRoom(t, dbBlocks[i].Room, block.Room)
+		}
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListOwnerBlocksAndRooms", mock.Anything, arg).
+			Return(nil, errors.New("any error")).
+			Once()
+
+		// execute method
+		result, err := ts.ListOwnerBlocks(date, LimitOwnerBlocksPerPage, 0)
+
+		// tesify
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestServer_ListPayments(t *testing.T) {
+	reservationID := util.RandomID()
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbPayments := make([]db.Payment, 2)
+		for i := range dbPayments {
+			p := randomPayment()
+			p.ReservationID = reservationID
+			p.Export(&dbPayments[i])
+		}
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, reservationID).
+			Return(dbPayments, nil).
+			Once()
+
+		// execute method
+		result, err := ts.ListPayments(reservationID)
+
+		// tesify
+		require.NoError(t, err)
+		require.Len(t, result, len(dbPayments))
+		for i, p := range result {
+			testPayment(t, dbPayments[i], p)
+		}
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, reservationID).
+			Return(nil, errors.New("any error")).
+			Once()
+
+		// execute method
+		result, err := ts.ListPayments(reservationID)
+
+		// tesify
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestServer_ListRefunds(t *testing.T) {
+	reservationID := util.RandomID()
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbRefunds := make([]db.Refund, 2)
+		for i := range dbRefunds {
+			r := randomRefund()
+			r.ReservationID = reservationID
+			r.Export(&dbRefunds[i])
+		}
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListRefundsByReservation", mock.Anything, reservationID).
+			Return(dbRefunds, nil).
+			Once()
+
+		// execute method
+		result, err := ts.ListRefunds(reservationID)
+
+		// tesify
+		require.NoError(t, err)
+		require.Len(t, result, len(dbRefunds))
+		for i, r := range result {
+			testRefund(t, dbRefunds[i], r)
+		}
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListRefundsByReservation", mock.Anything, reservationID).
+			Return(nil, errors.New("any error")).
+			Once()
+
+		// execute method
+		result, err := ts.ListRefunds(reservationID)
+
+		// tesify
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestServer_ListReservationCharges(t *testing.T) {
+	reservationID := util.RandomID()
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbCharges := []db.ReservationCharge{
+			{ID: util.RandomID(), ReservationID: reservationID, Name: "VAT", Amount: 4250},
+			{ID: util.RandomID(), ReservationID: reservationID, Name: "Cleaning Fee", Amount: 4000},
+		}
+		dbCharges[0].ChargeID.Scan(util.RandomID())
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListReservationCharges", mock.Anything, reservationID).
+			Return(dbCharges, nil).
+			Once()
+
+		// execute method
+		result, err := ts.ListReservationCharges(reservationID)
+
+		// tesify
+		require.NoError(t, err)
+		require.Len(t, result, len(dbCharges))
+		for i, c := range result {
+			assert.Equal(t, dbCharges[i].ID, c.ID)
+			assert.Equal(t, reservationID, c.ReservationID)
+			assert.Equal(t, dbCharges[i].ChargeID.Int64, c.ChargeID)
+			assert.Equal(t, dbCharges[i].Name, c.Name)
+			assert.Equal(t, Price(dbCharges[i].Amount), c.Amount)
+		}
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListReservationCharges", mock.Anything, reservationID).
+			Return(nil, errors.New("any error")).
+			Once()
+
+		// execute method
+		result, err := ts.ListReservationCharges(reservationID)
+
+		// tesify
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestServer_ListRoomRates(t *testing.T) {
+	date := Today()
+
+	//create stub db call arguments
+	arg := db.ListRoomRatesAndRoomsParams{
+		Limit:  LimitRoomRatesPerPage,
+		Offset: 0,
+	}
+	arg.Date.Scan(date)
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		rates := []RoomRate{randomRoomRate(), randomRoomRate()}
+		dbRates := make([]db.ListRoomRatesAndRoomsRow, len(rates))
+		for i, rate := range rates {
+			rate.Export(&dbRates[i].RoomRate)
+			rate.Room.Export(&dbRates[i].Room)
+		}
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListRoomRatesAndRooms", mock.Anything, arg).
+			Return(dbRates, nil).
+			Once()
+
+		// execute method
+		result, err := ts.ListRoomRates(date, LimitRoomRatesPerPage, 0)
+
+		// tesify
+		require.NoError(t, err)
+		require.Len(t, result, len(rates))
+		for i, rate := range result {
+			testRoomRate(t, dbRates[i].RoomRate, rate)
+			testRoom(t, dbRates[i].Room, rate.Room)
+		}
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListRoomRatesAndRooms", mock.Anything, arg).
+			Return(nil, errors.New("any error")).
+			Once()
+
+		// execute method
+		result, err := ts.ListRoomRates(date, LimitRoomRatesPerPage, 0)
+
+		// tesify
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestServer_ListRoomRestrictions(t *testing.T) {
+	rsv := randomReservation()
+
+	// create stub call arguments
+	arg := pgtype.Int8{
+		Int64: rsv.ID,
+		Valid: true,
+	}
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbRestrictions := make([]db.RoomRestriction, 2)
+		for i := range dbRestrictions {
+			restriction := randomRoomRestriction(rsv)
+			restriction.Export(&dbRestrictions[i])
+		}
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListRoomRestrictionsByReservationID", mock.Anything, arg).
+			Return(dbRestrictions, nil).
+			Once()
+
+		// execute method
+		restrictions, err := ts.ListRoomRestrictions(rsv.ID)
+
+		// tesify
+		require.NoError(t, err)
+		require.Len(t, restrictions, len(dbRestrictions))
+		for i, v := range restrictions {
+			testRoomRestriction(t, dbRestrictions[i], v)
+		}
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListRoomRestrictionsByReservationID", mock.Anything, arg).
+			Return(nil, errors.New("any error")).
+			Once()
+
+		// execute method
+		restrictions, err := ts.ListRoomRestrictions(rsv.ID)
+
+		// tesify
+		assert.Error(t, err)
+		assert.Nil(t, restrictions)
+	})
+}
+
+func TestServer_ListRoomRestrictionsForPeriod(t *testing.T) {
+	startDate := FirstOfMonth(util.RandomDate())
+	endDate := startDate.AddDate(0, 1, 0)
+
+	// create stub call arguments
+	arg := db.ListRoomRestrictionsForPeriodParams{}
+	arg.StartDate.Scan(startDate)
+	arg.EndDate.Scan(endDate)
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbRestrictions := make([]db.RoomRestriction, 2)
+		for i := range dbRestrictions {
+			restriction := randomRoomRestriction(randomReservation())
+			restriction.Export(&dbRestrictions[i])
+		}
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListRoomRestrictionsForPeriod", mock.Anything, arg).
+			Return(dbRestrictions, nil).
+			Once()
+
+		// execute method
+		restrictions, err := ts.ListRoomRestrictionsForPeriod(startDate, endDate)
+
+		// tesify
+		require.NoError(t, err)
+		require.Len(t, restrictions, len(dbRestrictions))
+		for i, v := range restrictions {
+			testRoomRestriction(t, dbRestrictions[i], v)
+		}
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListRoomRestrictionsForPeriod", mock.Anything, arg).
+			Return(nil, errors.New("any error")).
+			Once()
+
+		// execute method
+		restrictions, err := ts.ListRoomRestrictionsForPeriod(startDate, endDate)
+
+		// tesify
+		assert.Error(t, err)
+		assert.Nil(t, restrictions)
+	})
+}
+
+func TestServer_ListRooms(t *testing.T) {
+	//create stub db call arguments
+	arg := db.ListRoomsParams{
+		Limit:  LimitRoomsPerPage,
+		Offset: 0,
+	}
+
+	t.Run("Test All []Room", func(t *testing.T) {
+		// create stub return arguments
+		rooms := make([]Room, LimitRoomsPerPage)
+		dbRooms := make([]db.Room, LimitRoomsPerPage)
+		var err error
+
+		for i := 0; i < LimitRoomsPerPage; i++ {
+			rooms[i] = randomRoom()
+			err = util.CopyDataUsingJSON(rooms[i], &dbRooms[i])
+			require.NoError(t, err)
+		}
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListRooms", mock.Anything, arg).
+			Return(dbRooms, nil).
+			Once()
+
+		// execute method
+		returnedRooms, err := ts.ListRooms(int(arg.Limit), int(arg.Offset))
+
+		// tesify
+		assert.NoError(t, err)
+		require.Len(t, returnedRooms, LimitRoomsPerPage)
+
+		for i := 0; i < LimitRoomsPerPage; i++ {
+			room := rooms[i]
+			returnedRoom := returnedRooms[i]
+			require.Equal(t, room.ID, returnedRoom.ID)
+			require.Equal(t, room.Name, returnedRoom.Name)
+			require.Equal(t, room.Description, returnedRoom.Description)
+			require.Equal(t, room.ImageFilename, returnedRoom.ImageFilename)
+			require.WithinDuration(t, room.CreatedAt, returnedRoom.CreatedAt, time.Second)
+			require.WithinDuration(t, room.UpdatedAt, returnedRoom.UpdatedAt, time.Second)
+		}
+	})
+
+	t.Run("Test No []Room", func(t *testing.T) {
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListRooms", mock.Anything, arg).
+			Return([]db.Room{}, nil).
+			Once()
+
+		// execute method
+		ResultRooms, err := ts.ListRooms(int(arg.Limit), int(arg.Offset))
+
+		// tesify
+		assert.NoError(t, err)
+		require.Len(t, ResultRooms, 0)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListRooms", mock.Anything, arg).
+			Return(nil, errors.New("any error")).
+			Once()
+
+		// execute method
+		rooms, err := ts.ListRooms(int(arg.Limit), int(arg.Offset))
+
+		// tesify
+		assert.Error(t, err)
+		require.Nil(t, rooms)
+	})
+}
+
+func TestServer_ListUsers(t *testing.T) {
+	// create stub call arguments
+	arg := db.ListUsersParams{
+		Limit:  LimitUsersPerPage,
+		Offset: 0,
+	}
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		users := []User{randomUser(), randomUser()}
+		dbUsers := make([]db.User, len(users))
+		for i, user := range users {
+			user.Export(&dbUsers[i])
+		}
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListUsers", mock.Anything, arg).
+			Return(dbUsers, nil).
+			Once()
+
+		// execute method
+		result, err := ts.ListUsers(LimitUsersPerPage, 0)
+
+		// tesify
+		require.NoError(t, err)
+		require.Len(t, result, len(users))
+		for i := range users {
+			testUser(t, dbUsers[i], result[i])
+		}
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListUsers", mock.Anything, arg).
+			Return(nil, errors.New("any error")).
+			Once()
+
+		// execute method
+		result, err := ts.ListUsers(LimitUsersPerPage, 0)
+
+		// tesify
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestServer_NotifyWaitlist(t *testing.T) {
+	// create random waitlist entry and the room freed
+	entry := randomWaitlistEntry()
+
+	// matchArg checks the stub call arguments
+	matchArg := mock.MatchedBy(func(arg db.NotifyWaitlistTxParams) bool {
+		return arg.RoomID == entry.OfferedRoomID &&
+			arg.StartDate.Time.Equal(entry.StartDate) &&
+			arg.EndDate.Time.Equal(entry.EndDate) &&
+			arg.ExpiresAt.Valid &&
+			time.Until(arg.ExpiresAt.Time) > app.WaitlistOfferTTL()-time.Minute
+	})
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbEntry := db.WaitlistEntry{}
+		entry.Export(&dbEntry)
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("NotifyWaitlistTx", mock.Anything, matchArg).
+			Return([]db.WaitlistEntry{dbEntry}, nil).
+			Once()
+
+		// execute method
+		entries, err := ts.NotifyWaitlist(entry.OfferedRoomID, entry.StartDate, entry.EndDate)
+
+		// tesify
+		assert.NoError(t, err)
+		require.Len(t, entries, 1)
+		testWaitlistEntry(t, dbEntry, entries[0])
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("NotifyWaitlistTx", mock.Anything, matchArg).
+			Return(nil, errors.New("any error")).
+			Once()
+
+		// execute method
+		entries, err := ts.NotifyWaitlist(entry.OfferedRoomID, entry.StartDate, entry.EndDate)
+
+		// tesify
+		assert.Error(t, err)
+		assert.Nil(t, entries)
+	})
+}
+
+func TestServer_QuoteStay(t *testing.T) {
+	// create random room and dates
+	room := randomRoom()
+	startDate := util.RandomDate()
+	endDate := startDate.AddDate(0, 0, 2)
+
+	// create stub call arguments
+	arg := db.QuoteStayParams{RoomID: room.ID}
+	arg.StartDate.Scan(startDate)
+	arg.EndDate.Scan(endDate)
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbQuote := db.Quote{
+			RoomID: room.ID,
+			Nights: []db.NightlyRate{
+				{Date: arg.StartDate, Rate: int64(room.NightlyRate)},
+				{Date: pgtype.Date{Time: startDate.AddDate(0, 0, 1), Valid: true}, Rate: int64(room.NightlyRate)},
+			},
+			Subtotal: 2 * int64(room.NightlyRate),
+			Charges:  []db.QuoteCharge{{ChargeID: util.RandomID(), Name: "Cleaning Fee", Amount: 4000}},
+			Total:    2*int64(room.NightlyRate) + 4000,
+		}
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("QuoteStay", mock.Anything, arg).
+			Return(dbQuote, nil).
+			Once()
+
+		// execute method
+		quote, err := ts.QuoteStay(room, startDate, endDate, 0, 0, "")
+
+		// tesify
+		require.NoError(t, err)
+		assert.Equal(t, room, quote.Room)
+		require.Len(t, quote.Nights, 2)
+		for i, night := range quote.Nights {
+			assert.WithinDuration(t, dbQuote.Nights[i].Date.Time, night.Date, time.Second)
+			assert.Equal(t, room.NightlyRate, night.Rate)
+		}
+		assert.Equal(t, 2*room.NightlyRate, quote.Subtotal)
+		assert.Zero(t, quote.Discount)
+		assert.Equal(t, []QuoteCharge{{Name: "Cleaning Fee", Amount: 4000}}, quote.Charges)
+		assert.Equal(t, 2*room.NightlyRate+4000, quote.Total)
+	})
+
+	t.Run("Test Promo Code", func(t *testing.T) {
+		promoArg := arg
+		promoArg.PromoCode = "SUMMER"
+
+		// create stub return arguments
+		dbQuote := db.Quote{
+			RoomID:      room.ID,
+			Subtotal:    10000,
+			PromoCodeID: pgtype.Int8{Int64: util.RandomID(), Valid: true},
+			Discount:    1000,
+			Total:       9000,
+		}
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("QuoteStay", mock.Anything, promoArg).
+			Return(dbQuote, nil).
+			Once()
+
+		// execute method
+		quote, err := ts.QuoteStay(room, startDate, endDate, 0, 0, "SUMMER")
+
+		// tesify
+		require.NoError(t, err)
+		assert.Equal(t, Price(10000), quote.Subtotal)
+		assert.Equal(t, Price(1000), quote.Discount)
+		assert.Equal(t, Price(9000), quote.Total)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("QuoteStay", mock.Anything, arg).
+			Return(db.Quote{}, db.ErrInvalidDateRange).
+			Once()
+
+		// execute method
+		quote, err := ts.QuoteStay(room, startDate, endDate, 0, 0, "")
+
+		// tesify
+		assert.ErrorIs(t, err, db.ErrInvalidDateRange)
+		assert.Empty(t, quote)
+	})
+}
+
+func TestServer_QuoteStays(t *testing.T) {
+	// create random rooms and dates
+	rooms := randomRooms(2)
+	split := []Guests{{Adults: 2, Children: 1}, {Adults: 1}}
+	startDate := util.RandomDate()
+	endDate := startDate.AddDate(0, 0, 2)
+
+	// create stub call arguments
+	args := make([]db.QuoteStayParams, len(rooms))
+	for i, room := range rooms {
+		args[i] = db.QuoteStayParams{
+			RoomID:    room.ID,
+			Adults:    int32(split[i].Adults),
+			Children:  int32(split[i].Children),
+			PromoCode: "SUMMER",
+		}
+		args[i].StartDate.Scan(startDate)
+		args[i].EndDate.Scan(endDate)
+	}
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		promoCodeID := util.RandomID()
+		dbQuotes := []db.Quote{
+			{RoomID: rooms[0].ID, Subtotal: 20000, Discount: 2000, Total: 18000},
+			{RoomID: rooms[1].ID, Subtotal: 30000, Discount: 3000, Total: 27000},
+		}
+		for i := range dbQuotes {
+			dbQuotes[i].PromoCodeID = pgtype.Int8{Int64: promoCodeID, Valid: true}
+		}
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("QuoteStays", mock.Anything, args).
+			Return(dbQuotes, nil).
+			Once()
+
+		// execute method
+		quotes, err := ts.QuoteStays(rooms, startDate, endDate, split, "SUMMER")
+
+		// tesify
+		require.NoError(t, err)
+		require.Len(t, quotes, len(rooms))
+		for i, quote := range quotes {
+			assert.Equal(t, rooms[i], quote.Room)
+			assert.Equal(t, Price(dbQuotes[i].Discount), quote.Discount)
+			assert.Equal(t, Price(dbQuotes[i].Total), quote.Total)
+		}
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		promoErr := &db.PromoCodeError{Code: "SUMMER", Rejection: db.RejectionUsedUp}
+		ts.MockDBStore.On("QuoteStays", mock.Anything, args).
+			Return(nil, promoErr).
+			Once()
+
+		// execute method
+		quotes, err := ts.QuoteStays(rooms, startDate, endDate, split, "SUMMER")
+
+		// tesify
+		assert.ErrorIs(t, err, promoErr)
+		assert.Empty(t, quotes)
+	})
+}
+
+func TestServer_ReleaseRoomHold(t *testing.T) {
+	roomID := util.RandomID()
+	holdToken := util.RandomString(HoldTokenLength)
+
+	// create stub call arguments
+	arg := db.DeleteRoomHoldParams{
+		RoomID:    roomID,
+		HoldToken: pgtype.Text{String: holdToken, Valid: true},
+	}
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("DeleteRoomHold", mock.Anything, arg).
+			Return(nil).
+			Once()
+
+		// execute method and tesify
+		assert.NoError(t, ts.ReleaseRoomHold(roomID, holdToken))
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("DeleteRoomHold", mock.Anything, arg).
+			Return(errors.New("any error")).
+			Once()
+
+		// execute method and tesify
+		assert.Error(t, ts.ReleaseRoomHold(roomID, holdToken))
+	})
+}
+
+func TestServer_ReleaseRoomHolds(t *testing.T) {
+	holdToken := util.RandomString(HoldTokenLength)
+
+	// create stub call arguments
+	arg := pgtype.Text{String: holdToken, Valid: true}
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("DeleteRoomHoldsByToken", mock.Anything, arg).
+			Return(nil).
+			Once()
+
+		// execute method and tesify
+		assert.NoError(t, ts.ReleaseRoomHolds(holdToken))
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("DeleteRoomHoldsByToken", mock.Anything, arg).
+			Return(errors.New("any error")).
+			Once()
+
+		// execute method and tesify
+		assert.Error(t, ts.ReleaseRoomHolds(holdToken))
+	})
+}
+
+// updateReservationArg returns the UpdateReservationTx stub call arguments of reservation rsv
+func updateReservationArg(rsv Reservation) db.UpdateReservationParams {
+	arg := db.UpdateReservationParams{
+		ID:        rsv.ID,
+		FirstName: rsv.FirstName,
+		LastName:  rsv.LastName,
+		Email:     rsv.Email,
+		RoomID:    rsv.RoomID,
+		Adults:    int32(rsv.Adults),
+		Children:  int32(rsv.Children),
+	}
+	arg.Phone.Scan(rsv.Phone)
+	arg.Notes.Scan(rsv.Notes)
+	arg.StartDate.Scan(rsv.StartDate)
+	arg.EndDate.Scan(rsv.EndDate)
+
+	return arg
+}
+
+func TestServer_UpdateOwnerBlock(t *testing.T) {
+	// create random owner block moved to another room
+	block := randomOwnerBlock()
+	block.RoomID = util.RandomID()
+
+	// create stub call arguments
+	arg := db.UpdateOwnerBlockTxParams{
+		UpdateOwnerBlockParams: db.UpdateOwnerBlockParams{
+			ID:     block.ID,
+			RoomID: block.RoomID,
+			Reason: block.Reason,
+		},
+	}
+	arg.StartDate.Scan(block.StartDate)
+	arg.EndDate.Scan(block.EndDate)
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbBlock := db.RoomRestriction{}
+		block.Export(&dbBlock)
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("UpdateOwnerBlockTx", mock.Anything, arg).
+			Return(dbBlock, nil).
+			Once()
+
+		// execute method
+		result, err := ts.UpdateOwnerBlock(block, false)
+
+		// tesify
+		require.NoError(t, err)
+		testRoomRestriction(t, dbBlock, result)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("UpdateOwnerBlockTx", mock.Anything, arg).
+			Return(db.RoomRestriction{}, db.ErrOwnerBlockConflict).
+			Once()
+
+		// execute method
+		result, err := ts.UpdateOwnerBlock(block, false)
+
+		// tesify
+		assert.ErrorIs(t, err, db.ErrOwnerBlockConflict)
+		assert.Equal(t, block, result)
+	})
+}
+
+func TestServer_UpdateRoom(t *testing.T) {
+	// create random room
+	room := randomRoom()
+
+	// matchRoom returns true if arg updates room
+	matchRoom := mock.MatchedBy(func(arg db.UpdateRoomParams) bool {
+		return arg.ID == room.ID && arg.Name == room.Name && arg.Slug == room.Slug &&
+			arg.Description == room.Description && arg.ImageFilename == room.ImageFilename &&
+			arg.MaxAdults == int32(room.MaxAdults) && arg.MaxChildren == int32(room.MaxChildren) &&
+			arg.MaxOccupancy == int32(room.MaxOccupancy) && arg.NightlyRate == int64(room.NightlyRate) &&
+			arg.UpdatedAt.Valid
+	})
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("UpdateRoom", mock.Anything, matchRoom).
+			Return(nil).
+			Once()
+
+		// execute method and tesify
+		assert.NoError(t, ts.UpdateRoom(room))
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("UpdateRoom", mock.Anything, matchRoom).
+			Return(errors.New("any error")).
+			Once()
+
+		// execute method and tesify
+		assert.Error(t, ts.UpdateRoom(room))
+	})
+}
+
+func TestServer_UpdateUser(t *testing.T) {
+	user := randomUser()
+
+	// matchArg returns true if arg updates user
+	matchArg := mock.MatchedBy(func(arg db.UpdateUserParams) bool {
+		return arg.ID == user.ID && arg.FirstName == user.FirstName && arg.LastName == user.LastName &&
+			arg.Email == user.Email && arg.AccessLevel == int64(user.AccessLevel) && arg.UpdatedAt.Valid
+	})
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("UpdateUser", mock.Anything, matchArg).
+			Return(nil).
+			Once()
+
+		// execute method and tesify
+		assert.NoError(t, ts.UpdateUser(user))
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("UpdateUser", mock.Anything, matchArg).
+			Return(errors.New("any error")).
+			Once()
+
+		// execute method and tesify
+		assert.Error(t, ts.UpdateUser(user))
+	})
+}
+
+func TestServer_UpdateUserDeactivatedAt(t *testing.T) {
+	id := util.RandomID()
+	deactivatedAt := util.RandomDatetime()
+
+	t.Run("Test Deactivate", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("UpdateUserDeactivatedAt", mock.Anything, mock.MatchedBy(func(arg db.UpdateUserDeactivatedAtParams) bool {
+			return arg.ID == id && arg.DeactivatedAt.Valid && arg.DeactivatedAt.Time.Equal(deactivatedAt)
+		})).
+			Return(nil).
+			Once()
+
+		// execute method and tesify
+		assert.NoError(t, ts.UpdateUserDeactivatedAt(id, deactivatedAt))
+	})
+
+	t.Run("Test Reactivate", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("UpdateUserDeactivatedAt", mock.Anything, mock.MatchedBy(func(arg db.UpdateUserDeactivatedAtParams) bool {
+			return arg.ID == id && !arg.DeactivatedAt.Valid
+		})).
+			Return(errors.New("any error")).
+			Once()
+
+		// execute method and tesify
+		assert.Error(t, ts.UpdateUserDeactivatedAt(id, time.Time{}))
+	})
+}
+
+func TestServer_UpdateUserPasswordToken(t *testing.T) {
+	user := randomUser()
+	user.PasswordToken = util.RandomString(PasswordTokenLength)
+	user.PasswordTokenExpiresAt = util.RandomDatetime()
+
+	// matchArg returns true if arg updates the password token of user
+	matchArg := mock.MatchedBy(func(arg db.UpdateUserPasswordTokenParams) bool {
+		return arg.ID == user.ID && arg.PasswordToken.String == user.PasswordToken &&
+			arg.PasswordTokenExpiresAt.Time.Equal(user.PasswordTokenExpiresAt)
+	})
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("UpdateUserPasswordToken", mock.Anything, matchArg).
+			Return(nil).
+			Once()
+
+		// execute method and tesify
+		assert.NoError(t, ts.UpdateUserPasswordToken(user))
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("UpdateUserPasswordToken", mock.Anything, matchArg).
+			Return(errors.New("any error")).
+			Once()
+
+		// execute method and tesify
+		assert.Error(t, ts.UpdateUserPasswordToken(user))
+	})
+}
+
+func TestServer_UpdateReservation(t *testing.T) {
+	// create random reservation with room data, moved to another room
+	rsv := randomReservation()
+	rsv.RoomID = util.RandomID()
+	rsv.Adults = 2
+
+	// create stub call arguments
+	arg := updateReservationArg(rsv)
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbRsv := db.Reservation{}
+		rsv.Export(&dbRsv)
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("UpdateReservationTx", mock.Anything, arg).
+			Return(dbRsv, nil).
+			Once()
+
+		// execute method
+		result, err := ts.UpdateReservation(rsv)
+
+		// tesify
+		require.NoError(t, err)
+		testReservation(t, dbRsv, result)
+		assert.Empty(t, result.Room)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("UpdateReservationTx", mock.Anything, arg).
+			Return(db.Reservation{}, db.ErrRoomUnavailable).
+			Once()
+
+		// execute method
+		result, err := ts.UpdateReservation(rsv)
+
+		// tesify
+		assert.ErrorIs(t, err, db.ErrRoomUnavailable)
+		assert.Equal(t, rsv, result)
+	})
+}
+
+func TestServer_UpdateReservationDates(t *testing.T) {
+	// create random reservation with room data
+	rsv := randomReservation()
+	startDate := rsv.StartDate.AddDate(0, 0, 1)
+	endDate := rsv.EndDate.AddDate(0, 0, 1)
+
+	// create stub call arguments
+	arg := db.UpdateReservationDatesParams{ID: rsv.ID}
+	arg.StartDate.Scan(startDate)
+	arg.EndDate.Scan(endDate)
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		updated := rsv
+		updated.StartDate = startDate
+		updated.EndDate = endDate
+
+		dbRsv := db.Reservation{}
+		updated.Export(&dbRsv)
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("UpdateReservationDatesTx", mock.Anything, arg).
+			Return(dbRsv, nil).
+			Once()
+
+		// execute method
+		result, err := ts.UpdateReservationDates(rsv, startDate, endDate)
+
+		// tesify
+		require.NoError(t, err)
+		testReservation(t, dbRsv, result)
+		assert.Equal(t, rsv.Room, result.Room)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("UpdateReservationDatesTx", mock.Anything, arg).
+			Return(db.Reservation{}, db.ErrRoomUnavailable).
+			Once()
+
+		// execute method
+		result, err := ts.UpdateReservationDates(rsv, startDate, endDate)
+
+		// tesify
+		assert.ErrorIs(t, err, db.ErrRoomUnavailable)
+		assert.Equal(t, rsv, result)
+	})
+}
+
+func TestServer_QuoteReservationDates(t *testing.T) {
+	// create random reservation with room data and a redeemed promo code
+	rsv := randomReservation()
+	rsv.PromoCodeID = util.RandomID()
+	startDate := rsv.StartDate.AddDate(0, 0, 1)
+	endDate := rsv.EndDate.AddDate(0, 0, 1)
+
+	// create stub call arguments
+	arg := db.QuoteStayParams{
+		RoomID:   rsv.RoomID,
+		Adults:   int32(rsv.Adults),
+		Children: int32(rsv.Children),
+	}
+	arg.StartDate.Scan(startDate)
+	arg.EndDate.Scan(endDate)
+	arg.PromoCodeID.Scan(rsv.PromoCodeID)
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbQuote := db.Quote{
+			RoomID:      rsv.RoomID,
+			Subtotal:    10000,
+			PromoCodeID: arg.PromoCodeID,
+			Discount:    1000,
+			Total:       9000,
+		}
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("QuoteStay", mock.Anything, arg).
+			Return(dbQuote, nil).
+			Once()
+
+		// execute method
+		quote, err := ts.QuoteReservationDates(rsv, startDate, endDate)
+
+		// tesify
+		require.NoError(t, err)
+		assert.Equal(t, rsv.Room, quote.Room)
+		assert.Equal(t, Price(1000), quote.Discount)
+		assert.Equal(t, Price(9000), quote.Total)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("QuoteStay", mock.Anything, arg).
+			Return(db.Quote{}, db.ErrInvalidDateRange).
+			Once()
+
+		// execute method
+		quote, err := ts.QuoteReservationDates(rsv, startDate, endDate)
+
+		// tesify
+		assert.ErrorIs(t, err, db.ErrInvalidDateRange)
+		assert.Empty(t, quote)
+	})
+}
+
+func TestServer_UpdateReservationStatus(t *testing.T) {
+	// create random reservation with room data
+	rsv := randomReservation()
+
+	// create stub call arguments
+	arg := db.UpdateReservationStatusTxParams{
+		UpdateReservationStatusParams: db.UpdateReservationStatusParams{
+			ID:     rsv.ID,
+			Status: db.ReservationStatusConfirmed,
+		},
+	}
+	arg.Date.Scan(Today())
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		confirmed := rsv
+		confirmed.Status = ReservationConfirmed
+		confirmed.ConfirmedAt = time.Now()
+
+		dbRsv := db.Reservation{}
+		confirmed.Export(&dbRsv)
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("UpdateReservationStatusTx", mock.Anything, arg).
+			Return(dbRsv, nil).
+			Once()
+
+		// execute method
+		result, err := ts.UpdateReservationStatus(rsv, ReservationConfirmed, Today())
+
 		// tesify
 		assert.NoError(t, err)
-		require.Len(t, result, 0)
+		testReservation(t, dbRsv, result)
+		assert.Equal(t, rsv.Room, result.Room)
 	})
 
-	t.Run("Test Error", func(t *testing.T) {
+	t.Run("Test Invalid Transition", func(t *testing.T) {
 		// create a new server with mock database store
 		ts := NewTestServer(t)
 
 		// build stub
-		ts.MockDBStore.On("ListReservationsAndRooms", mock.Anything, arg).
-			Return(nil, errors.New("any error")).
+		ts.MockDBStore.On("UpdateReservationStatusTx", mock.Anything, arg).
+			Return(db.Reservation{}, db.ErrInvalidStatusTransition).
 			Once()
 
 		// execute method
-		result, err := ts.ListReservations(int(arg.Limit), int(arg.Offset))
+		result, err := ts.UpdateReservationStatus(rsv, ReservationConfirmed, Today())
 
 		// tesify
-		assert.Error(t, err)
-		require.Nil(t, result)
+		assert.ErrorIs(t, err, db.ErrInvalidStatusTransition)
+		assert.Equal(t, rsv, result)
 	})
 }
 
-func TestServer_ListRooms(t *testing.T) {
-	//create stub db call arguments
-	arg := db.ListRoomsParams{
-		Limit:  LimitRoomsPerPage,
-		Offset: 0,
+func TestServer_UpdateInvoiceDocument(t *testing.T) {
+	invoice := randomInvoice()
+
+	// create stub call arguments
+	arg := db.UpdateInvoiceDocumentParams{
+		ID:       invoice.ID,
+		Document: invoice.Document,
 	}
 
-	t.Run("Test All []Room", func(t *testing.T) {
+	t.Run("Test OK", func(t *testing.T) {
 		// create stub return arguments
-		rooms := make([]Room, LimitRoomsPerPage)
-		dbRooms := make([]db.Room, LimitRoomsPerPage)
-		var err error
-
-		for i := 0; i < LimitRoomsPerPage; i++ {
-			rooms[i] = randomRoom()
-			err = util.CopyDataUsingJSON(rooms[i], &dbRooms[i])
-			require.NoError(t, err)
-		}
+		dbInvoice := db.Invoice{}
+		invoice.Export(&dbInvoice)
 
 		// create a new server with mock database store
 		ts := NewTestServer(t)
 
 		// build stub
-		ts.MockDBStore.On("ListRooms", mock.Anything, arg).
-			Return(dbRooms, nil).
+		ts.MockDBStore.On("UpdateInvoiceDocument", mock.Anything, arg).
+			Return(dbInvoice, nil).
 			Once()
 
 		// execute method
-		returnedRooms, err := ts.ListRooms(int(arg.Limit), int(arg.Offset))
+		result, err := ts.UpdateInvoiceDocument(invoice.ID, invoice.Document)
 
 		// tesify
-		assert.NoError(t, err)
-		require.Len(t, returnedRooms, LimitRoomsPerPage)
+		require.NoError(t, err)
+		testInvoice(t, dbInvoice, result)
+	})
 
-		for i := 0; i < LimitRoomsPerPage; i++ {
-			room := rooms[i]
-			returnedRoom := returnedRooms[i]
-			require.Equal(t, room.ID, returnedRoom.ID)
-			require.Equal(t, room.Name, returnedRoom.Name)
-			require.Equal(t, room.Description, returnedRoom.Description)
-			require.Equal(t, room.ImageFilename, returnedRoom.ImageFilename)
-			require.WithinDuration(t, room.CreatedAt, returnedRoom.CreatedAt, time.Second)
-			require.WithinDuration(t, room.UpdatedAt, returnedRoom.UpdatedAt, time.Second)
-		}
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("UpdateInvoiceDocument", mock.Anything, arg).
+			Return(db.Invoice{}, errors.New("any error")).
+			Once()
+
+		// execute method
+		result, err := ts.UpdateInvoiceDocument(invoice.ID, invoice.Document)
+
+		// tesify
+		assert.Error(t, err)
+		assert.Empty(t, result)
 	})
+}
 
-	t.Run("Test No []Room", func(t *testing.T) {
+func TestServer_UpdatePaymentStatus(t *testing.T) {
+	p := randomPayment()
+
+	// create stub call arguments
+	arg := db.UpdatePaymentStatusByProviderRefParams{
+		Provider:    p.Provider,
+		ProviderRef: p.ProviderRef,
+		Status:      db.PaymentStatusFailed,
+	}
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		p.Status = PaymentFailed
+		dbPayment := db.Payment{}
+		p.Export(&dbPayment)
 
 		// create a new server with mock database store
 		ts := NewTestServer(t)
 
 		// build stub
-		ts.MockDBStore.On("ListRooms", mock.Anything, arg).
-			Return([]db.Room{}, nil).
+		ts.MockDBStore.On("UpdatePaymentStatusByProviderRef", mock.Anything, arg).
+			Return([]db.Payment{dbPayment}, nil).
 			Once()
 
 		// execute method
-		ResultRooms, err := ts.ListRooms(int(arg.Limit), int(arg.Offset))
+		result, err := ts.UpdatePaymentStatus(p.Provider, p.ProviderRef, PaymentFailed)
 
 		// tesify
-		assert.NoError(t, err)
-		require.Len(t, ResultRooms, 0)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		testPayment(t, dbPayment, result[0])
 	})
 
 	t.Run("Test Error", func(t *testing.T) {
@@ -479,16 +3785,16 @@ func TestServer_ListRooms(t *testing.T) {
 		ts := NewTestServer(t)
 
 		// build stub
-		ts.MockDBStore.On("ListRooms", mock.Anything, arg).
+		ts.MockDBStore.On("UpdatePaymentStatusByProviderRef", mock.Anything, arg).
 			Return(nil, errors.New("any error")).
 			Once()
 
 		// execute method
-		rooms, err := ts.ListRooms(int(arg.Limit), int(arg.Offset))
+		result, err := ts.UpdatePaymentStatus(p.Provider, p.ProviderRef, PaymentFailed)
 
 		// tesify
 		assert.Error(t, err)
-		require.Nil(t, rooms)
+		assert.Nil(t, result)
 	})
 }
 
@@ -533,6 +3839,236 @@ func TestRoom_ImportAndExport(t *testing.T) {
 
 }
 
+func TestRoomRate_ImportAndExportWithRoom(t *testing.T) {
+	rr := randomRoomRate()
+	dbr := db.ListRoomRatesAndRoomsRow{}
+
+	rr.Export(&dbr.RoomRate)
+	rr.Room.Export(&dbr.Room)
+
+	r := RoomRate{}
+	r.ImportWithRoom(dbr)
+	testRoomRate(t, dbr.RoomRate, r)
+	testRoom(t, dbr.Room, r.Room)
+}
+
+func TestRoomRestriction_ImportAndExport(t *testing.T) {
+	rr := randomRoomRestriction(randomReservation())
+	dbr := db.RoomRestriction{}
+
+	rr.Export(&dbr)
+
+	r := RoomRestriction{}
+	r.Import(dbr)
+	testRoomRestriction(t, dbr, r)
+}
+
+func TestRoomRestriction_ImportAndExportWithRoom(t *testing.T) {
+	rr := randomOwnerBlock()
+	dbr := db.ListOwnerBlocksAndRoomsRow{}
+
+	rr.Export(&dbr.RoomRestriction)
+	rr.Room.Export(&dbr.Room)
+
+	r := RoomRestriction{}
+	r.ImportWithRoom(dbr)
+	testRoomRestriction(t, dbr.RoomRestriction, r)
+	testRoom(t, dbr.Room, r.Room)
+}
+
+func TestCharge_ImportAndExport(t *testing.T) {
+	rc := randomCharge()
+	dbc := db.Charge{}
+
+	rc.Export(&dbc)
+
+	c := Charge{}
+	c.Import(dbc)
+	testCharge(t, dbc, c)
+}
+
+func TestInvoice_ImportAndExport(t *testing.T) {
+	ri := randomInvoice()
+	dbi := db.Invoice{}
+
+	ri.Export(&dbi)
+
+	i := Invoice{}
+	i.Import(dbi)
+	testInvoice(t, dbi, i)
+}
+
+func TestServer_UpsertExchangeRate(t *testing.T) {
+	rate := randomExchangeRate()
+
+	// create stub call arguments
+	arg := db.UpsertExchangeRateParams{
+		Currency: rate.Currency,
+		Symbol:   rate.Symbol,
+		Rate:     rate.Rate,
+	}
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		dbRate := db.ExchangeRate{}
+		rate.Export(&dbRate)
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("UpsertExchangeRate", mock.Anything, arg).
+			Return(dbRate, nil).
+			Once()
+
+		// execute method
+		result, err := ts.UpsertExchangeRate(rate)
+
+		// tesify
+		require.NoError(t, err)
+		testExchangeRate(t, dbRate, result)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("UpsertExchangeRate", mock.Anything, arg).
+			Return(db.ExchangeRate{}, errors.New("any error")).
+			Once()
+
+		// execute method and tesify
+		_, err := ts.UpsertExchangeRate(rate)
+		assert.Error(t, err)
+	})
+}
+
+func TestServer_VoidFolioEntry(t *testing.T) {
+	entry := randomFolioEntry()
+	userID := util.RandomID()
+
+	// create stub call arguments
+	arg := db.VoidFolioEntryParams{
+		ID:            entry.ID,
+		ReservationID: entry.ReservationID,
+	}
+	arg.VoidedBy.Scan(userID)
+
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		voided := entry
+		voided.VoidedAt = time.Now()
+		voided.VoidedBy = userID
+		dbEntry := db.FolioEntry{}
+		voided.Export(&dbEntry)
+
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("VoidFolioEntry", mock.Anything, arg).
+			Return(dbEntry, nil).
+			Once()
+
+		// execute method
+		result, err := ts.VoidFolioEntry(entry.ID, entry.ReservationID, userID)
+
+		// tesify
+		require.NoError(t, err)
+		testFolioEntry(t, dbEntry, result)
+		assert.True(t, result.IsVoided())
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// create a new server with mock database store
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("VoidFolioEntry", mock.Anything, arg).
+			Return(db.FolioEntry{}, pgx.ErrNoRows).
+			Once()
+
+		// execute method
+		result, err := ts.VoidFolioEntry(entry.ID, entry.ReservationID, userID)
+
+		// tesify
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.Empty(t, result)
+	})
+}
+
+func TestExchangeRate_ImportAndExport(t *testing.T) {
+	rr := randomExchangeRate()
+	dbr := db.ExchangeRate{}
+
+	rr.Export(&dbr)
+
+	r := ExchangeRate{}
+	r.Import(dbr)
+	testExchangeRate(t, dbr, r)
+}
+
+func TestFolioEntry_ImportAndExport(t *testing.T) {
+	re := randomFolioEntry()
+	re.VoidedAt = util.RandomDatetime()
+	re.VoidedBy = util.RandomID()
+	dbe := db.FolioEntry{}
+
+	re.Export(&dbe)
+
+	e := FolioEntry{}
+	e.Import(dbe)
+	testFolioEntry(t, dbe, e)
+}
+
+func TestPayment_ImportAndExport(t *testing.T) {
+	rp := randomPayment()
+	dbp := db.Payment{}
+
+	rp.Export(&dbp)
+
+	p := Payment{}
+	p.Import(dbp)
+	testPayment(t, dbp, p)
+}
+
+func TestRefund_ImportAndExport(t *testing.T) {
+	rr := randomRefund()
+	dbr := db.Refund{}
+
+	rr.Export(&dbr)
+
+	r := Refund{}
+	r.Import(dbr)
+	testRefund(t, dbr, r)
+}
+
+func TestWaitlistEntry_ImportAndExport(t *testing.T) {
+	re := randomWaitlistEntry()
+	dbe := db.WaitlistEntry{}
+
+	re.Export(&dbe)
+
+	e := WaitlistEntry{}
+	e.Import(dbe)
+	testWaitlistEntry(t, dbe, e)
+}
+
+func TestUser_ImportAndExport(t *testing.T) {
+	ru := randomUser()
+	ru.DeactivatedAt = util.RandomDatetime()
+	ru.PasswordToken = util.RandomString(PasswordTokenLength)
+	ru.PasswordTokenExpiresAt = util.RandomDatetime()
+	dbu := db.User{}
+
+	ru.Export(&dbu)
+
+	u := User{}
+	u.Import(dbu)
+	testUser(t, dbu, u)
+}
+
 // testReservation asserts that expected equals to actual
 func testReservation(t *testing.T, expected db.Reservation, actual Reservation) {
 	assert.Equal(t, expected.ID, actual.ID)
@@ -547,6 +4083,18 @@ func testReservation(t *testing.T, expected db.Reservation, actual Reservation)
 	assert.Equal(t, expected.Notes.String, actual.Notes)
 	assert.WithinDuration(t, expected.CreatedAt.Time, actual.CreatedAt, time.Second)
 	assert.WithinDuration(t, expected.UpdatedAt.Time, actual.UpdatedAt, time.Second)
+	assert.WithinDuration(t, expected.CancelledAt.Time, actual.CancelledAt, time.Second)
+	assert.Equal(t, expected.CancelledBy.String, actual.CancelledBy)
+	assert.Equal(t, int(expected.CancellationFeePercent), actual.CancellationFeePercent)
+	assert.Equal(t, expected.ParentCode.String, actual.ParentCode)
+	assert.Equal(t, int(expected.Adults), actual.Adults)
+	assert.Equal(t, int(expected.Children), actual.Children)
+	assert.Equal(t, ReservationStatus(expected.Status), actual.Status)
+	assert.Equal(t, Price(expected.TotalPrice), actual.TotalPrice)
+	assert.WithinDuration(t, expected.ConfirmedAt.Time, actual.ConfirmedAt, time.Second)
+	assert.WithinDuration(t, expected.CheckedInAt.Time, actual.CheckedInAt, time.Second)
+	assert.WithinDuration(t, expected.CheckedOutAt.Time, actual.CheckedOutAt, time.Second)
+	assert.WithinDuration(t, expected.NoShowAt.Time, actual.NoShowAt, time.Second)
 }
 
 // testDBReservation asserts that expected equals to actual
@@ -563,14 +4111,27 @@ func testDBReservation(t *testing.T, expected Reservation, actual db.Reservation
 	assert.Equal(t, expected.Notes, actual.Notes.String)
 	assert.WithinDuration(t, expected.CreatedAt, actual.CreatedAt.Time, time.Second)
 	assert.WithinDuration(t, expected.UpdatedAt, actual.UpdatedAt.Time, time.Second)
+	assert.WithinDuration(t, expected.CancelledAt, actual.CancelledAt.Time, time.Second)
+	assert.Equal(t, expected.CancelledBy, actual.CancelledBy.String)
+	assert.Equal(t, expected.CancellationFeePercent, int(actual.CancellationFeePercent))
+	assert.Equal(t, expected.ParentCode, actual.ParentCode.String)
+	assert.Equal(t, expected.Adults, int(actual.Adults))
+	assert.Equal(t, expected.Children, int(actual.Children))
+	assert.Equal(t, db.ReservationStatus(expected.Status), actual.Status)
+	assert.Equal(t, int64(expected.TotalPrice), actual.TotalPrice)
 }
 
 // testRoom asserts that expected equals to actual
 func testRoom(t *testing.T, expected db.Room, actual Room) {
 	assert.Equal(t, expected.ID, actual.ID)
 	assert.Equal(t, expected.Name, actual.Name)
+	assert.Equal(t, expected.Slug, actual.Slug)
 	assert.Equal(t, expected.Description, actual.Description)
 	assert.Equal(t, expected.ImageFilename, actual.ImageFilename)
+	assert.Equal(t, int(expected.MaxAdults), actual.MaxAdults)
+	assert.Equal(t, int(expected.MaxChildren), actual.MaxChildren)
+	assert.Equal(t, int(expected.MaxOccupancy), actual.MaxOccupancy)
+	assert.Equal(t, Price(expected.NightlyRate), actual.NightlyRate)
 	assert.WithinDuration(t, expected.CreatedAt.Time, actual.CreatedAt, time.Second)
 	assert.WithinDuration(t, expected.UpdatedAt.Time, actual.UpdatedAt, time.Second)
 }
@@ -579,8 +4140,145 @@ func testRoom(t *testing.T, expected db.Room, actual Room) {
 func testDBRoom(t *testing.T, expected Room, actual db.Room) {
 	assert.Equal(t, expected.ID, actual.ID)
 	assert.Equal(t, expected.Name, actual.Name)
+	assert.Equal(t, expected.Slug, actual.Slug)
 	assert.Equal(t, expected.Description, actual.Description)
 	assert.Equal(t, expected.ImageFilename, actual.ImageFilename)
+	assert.Equal(t, expected.MaxAdults, int(actual.MaxAdults))
+	assert.Equal(t, expected.MaxChildren, int(actual.MaxChildren))
+	assert.Equal(t, expected.MaxOccupancy, int(actual.MaxOccupancy))
+	assert.Equal(t, int64(expected.NightlyRate), actual.NightlyRate)
 	assert.WithinDuration(t, expected.CreatedAt, actual.CreatedAt.Time, time.Second)
 	assert.WithinDuration(t, expected.UpdatedAt, actual.UpdatedAt.Time, time.Second)
 }
+
+// testWaitlistEntry asserts that expected equals to actual
+func testWaitlistEntry(t *testing.T, expected db.WaitlistEntry, actual WaitlistEntry) {
+	assert.Equal(t, expected.ID, actual.ID)
+	assert.Equal(t, expected.Token, actual.Token)
+	assert.Equal(t, expected.FirstName, actual.FirstName)
+	assert.Equal(t, expected.LastName, actual.LastName)
+	assert.Equal(t, expected.Email, actual.Email)
+	assert.WithinDuration(t, expected.StartDate.Time, actual.StartDate, time.Second)
+	assert.WithinDuration(t, expected.EndDate.Time, actual.EndDate, time.Second)
+	assert.Equal(t, expected.RoomID.Int64, actual.RoomID)
+	assert.Equal(t, expected.Adults, int32(actual.Adults))
+	assert.Equal(t, expected.Children, int32(actual.Children))
+	assert.Equal(t, expected.OfferedRoomID.Int64, actual.OfferedRoomID)
+	assert.WithinDuration(t, expected.NotifiedAt.Time, actual.NotifiedAt, time.Second)
+	assert.WithinDuration(t, expected.ExpiresAt.Time, actual.ExpiresAt, time.Second)
+	assert.WithinDuration(t, expected.CreatedAt.Time, actual.CreatedAt, time.Second)
+	assert.WithinDuration(t, expected.UpdatedAt.Time, actual.UpdatedAt, time.Second)
+}
+
+// testCharge asserts that expected equals to actual
+func testCharge(t *testing.T, expected db.Charge, actual Charge) {
+	assert.Equal(t, expected.ID, actual.ID)
+	assert.Equal(t, expected.Name, actual.Name)
+	assert.Equal(t, ChargeKind(expected.Kind), actual.Kind)
+	assert.Equal(t, expected.Amount, actual.Amount)
+	assert.WithinDuration(t, expected.CreatedAt.Time, actual.CreatedAt, time.Second)
+	assert.WithinDuration(t, expected.UpdatedAt.Time, actual.UpdatedAt, time.Second)
+}
+
+// testExchangeRate asserts that expected equals to actual
+func testExchangeRate(t *testing.T, expected db.ExchangeRate, actual ExchangeRate) {
+	assert.Equal(t, expected.Currency, actual.Currency)
+	assert.Equal(t, expected.Symbol, actual.Symbol)
+	assert.Equal(t, expected.Rate, actual.Rate)
+	assert.WithinDuration(t, expected.UpdatedAt.Time, actual.UpdatedAt, time.Second)
+}
+
+// testRoomRestriction asserts that expected equals to actual
+func testRoomRestriction(t *testing.T, expected db.RoomRestriction, actual RoomRestriction) {
+	assert.Equal(t, expected.ID, actual.ID)
+	assert.WithinDuration(t, expected.StartDate.Time, actual.StartDate, time.Second)
+	assert.WithinDuration(t, expected.EndDate.Time, actual.EndDate, time.Second)
+	assert.Equal(t, expected.RoomID, actual.RoomID)
+	assert.Equal(t, expected.ReservationID.Int64, actual.ReservationID)
+	assert.Equal(t, expected.Restriction, db.Restriction(actual.Restriction))
+	assert.WithinDuration(t, expected.CreatedAt.Time, actual.CreatedAt, time.Second)
+	assert.WithinDuration(t, expected.UpdatedAt.Time, actual.UpdatedAt, time.Second)
+	assert.Equal(t, expected.HoldToken.String, actual.HoldToken)
+	assert.WithinDuration(t, expected.ExpiresAt.Time, actual.ExpiresAt, time.Second)
+	assert.Equal(t, expected.Reason, actual.Reason)
+}
+
+// testFolioEntry asserts that expected equals to actual
+func testFolioEntry(t *testing.T, expected db.FolioEntry, actual FolioEntry) {
+	assert.Equal(t, expected.ID, actual.ID)
+	assert.Equal(t, expected.ReservationID, actual.ReservationID)
+	assert.Equal(t, expected.Kind, db.FolioEntryKind(actual.Kind))
+	assert.Equal(t, expected.Description, actual.Description)
+	assert.Equal(t, expected.Amount, int64(actual.Amount))
+	assert.Equal(t, expected.UserID.Int64, actual.UserID)
+	assert.WithinDuration(t, expected.VoidedAt.Time, actual.VoidedAt, time.Second)
+	assert.Equal(t, expected.VoidedBy.Int64, actual.VoidedBy)
+	assert.WithinDuration(t, expected.CreatedAt.Time, actual.CreatedAt, time.Second)
+}
+
+// testInvoice asserts that expected equals to actual
+func testInvoice(t *testing.T, expected db.Invoice, actual Invoice) {
+	assert.Equal(t, expected.ID, actual.ID)
+	assert.Equal(t, expected.Property, actual.Property)
+	assert.Equal(t, expected.Number, actual.Number)
+	assert.Equal(t, expected.ReservationID, actual.ReservationID)
+	assert.Equal(t, expected.Document, actual.Document)
+	assert.WithinDuration(t, expected.IssuedAt.Time, actual.IssuedAt, time.Second)
+	assert.WithinDuration(t, expected.UpdatedAt.Time, actual.UpdatedAt, time.Second)
+}
+
+// testPayment asserts that expected equals to actual
+func testPayment(t *testing.T, expected db.Payment, actual Payment) {
+	assert.Equal(t, expected.ID, actual.ID)
+	assert.Equal(t, expected.ReservationID, actual.ReservationID)
+	assert.Equal(t, expected.ParentID.Int64, actual.ParentID)
+	assert.Equal(t, expected.RefundID.Int64, actual.RefundID)
+	assert.Equal(t, expected.Provider, actual.Provider)
+	assert.Equal(t, expected.ProviderRef, actual.ProviderRef)
+	assert.Equal(t, PaymentKind(expected.Kind), actual.Kind)
+	assert.Equal(t, PaymentStatus(expected.Status), actual.Status)
+	assert.Equal(t, Price(expected.Amount), actual.Amount)
+	assert.WithinDuration(t, expected.CreatedAt.Time, actual.CreatedAt, time.Second)
+	assert.WithinDuration(t, expected.UpdatedAt.Time, actual.UpdatedAt, time.Second)
+}
+
+// testRefund asserts that expected equals to actual
+func testRefund(t *testing.T, expected db.Refund, actual Refund) {
+	assert.Equal(t, expected.ID, actual.ID)
+	assert.Equal(t, expected.ReservationID, actual.ReservationID)
+	assert.Equal(t, Price(expected.Paid), actual.Paid)
+	assert.Equal(t, Price(expected.PolicyAmount), actual.PolicyAmount)
+	assert.Equal(t, Price(expected.Amount), actual.Amount)
+	assert.Equal(t, expected.Reason, actual.Reason)
+	assert.Equal(t, expected.UserID.Int64, actual.UserID)
+	assert.WithinDuration(t, expected.CreatedAt.Time, actual.CreatedAt, time.Second)
+}
+
+// testRoomRate asserts that expected equals to actual
+func testRoomRate(t *testing.T, expected db.RoomRate, actual RoomRate) {
+	assert.Equal(t, expected.ID, actual.ID)
+	assert.Equal(t, expected.RoomID, actual.RoomID)
+	assert.Equal(t, expected.Name, actual.Name)
+	assert.WithinDuration(t, expected.StartDate.Time, actual.StartDate, time.Second)
+	assert.WithinDuration(t, expected.EndDate.Time, actual.EndDate, time.Second)
+	assert.Equal(t, int(expected.DaysOfWeek), actual.DaysOfWeek)
+	assert.Equal(t, Price(expected.Rate), actual.Rate)
+	assert.Equal(t, int(expected.Priority), actual.Priority)
+	assert.WithinDuration(t, expected.CreatedAt.Time, actual.CreatedAt, time.Second)
+	assert.WithinDuration(t, expected.UpdatedAt.Time, actual.UpdatedAt, time.Second)
+}
+
+// testUser asserts that expected equals to actual
+func testUser(t *testing.T, expected db.User, actual User) {
+	assert.Equal(t, expected.ID, actual.ID)
+	assert.Equal(t, expected.FirstName, actual.FirstName)
+	assert.Equal(t, expected.LastName, actual.LastName)
+	assert.Equal(t, expected.Email, actual.Email)
+	assert.Equal(t, expected.Password, actual.Password)
+	assert.Equal(t, Role(expected.AccessLevel), actual.AccessLevel)
+	assert.WithinDuration(t, expected.DeactivatedAt.Time, actual.DeactivatedAt, time.Second)
+	assert.Equal(t, expected.PasswordToken.String, actual.PasswordToken)
+	assert.WithinDuration(t, expected.PasswordTokenExpiresAt.Time, actual.PasswordTokenExpiresAt, time.Second)
+	assert.WithinDuration(t, expected.CreatedAt.Time, actual.CreatedAt, time.Second)
+	assert.WithinDuration(t, expected.UpdatedAt.Time, actual.UpdatedAt, time.Second)
+}
@@ -69,3 +69,14 @@ func CreateServerError(errType ErrorType, url string, err error) ServerError {
 		return ServerError{}
 	}
 }
+
+var (
+	// ErrRefundAmount is returned when a refund amount is not positive or exceeds the amount paid
+	ErrRefundAmount = errors.New("refund amount must be positive and no more than the amount paid")
+
+	// ErrRefundReason is returned when a refund overrides the amount refundable by the cancellation policy without a reason
+	ErrRefundReason = errors.New("refund overrides the cancellation policy without a reason")
+
+	// ErrNotInvoiceable is returned when an invoice is issued for a pending or cancelled reservation
+	ErrNotInvoiceable = errors.New("reservation is pending or cancelled and cannot be invoiced")
+)
@@ -3,22 +3,66 @@ package main
 import (
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/github-real-lb/bookings-web-app/db"
+	"github.com/github-real-lb/bookings-web-app/util"
 	"github.com/github-real-lb/bookings-web-app/util/config"
 	"github.com/github-real-lb/bookings-web-app/util/forms"
+	"github.com/github-real-lb/bookings-web-app/util/payments"
 	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
 )
 
 // LimitRoomsPerPage sets the maximum number of rooms to display on a page
 const LimitRoomsPerPage = 10
 
+// MaxAdults and MaxChildren set the maximum number of guests that can be searched for a room
+const (
+	MaxAdults   = 10
+	MaxChildren = 10
+)
+
 // LimitReservationsPerPage sets the maximum number of reservations to display on a page
 const LimitReservationsPerPage = 10
 
+// LimitRoomRatesPerPage sets the maximum number of room rates to display on a page
+const LimitRoomRatesPerPage = 100
+
+// LimitOwnerBlocksPerPage sets the maximum number of owner blocks to display on a page
+const LimitOwnerBlocksPerPage = 100
+
+// LimitUsersPerPage sets the maximum number of staff users listed
+const LimitUsersPerPage = 100
+
+// MaxRoomImageSize sets the maximum size in bytes of an uploaded room image
+const MaxRoomImageSize = 5 << 20
+
+// OwnerBlockConflictMessage is the form error of an owner block overlapping a reservation
+const OwnerBlockConflictMessage = "The rooms are reserved on some of the nights selected. Select override to block them anyway."
+
+// PriceCalendarDays and MaxPriceCalendarDays set the default and maximum number of days
+// priced by the room prices json endpoint
+const (
+	PriceCalendarDays    = 180
+	MaxPriceCalendarDays = 366
+)
+
+// WebhookSignatureHeader is the header holding the signature of a payment provider webhook,
+// and MaxWebhookBytes sets the maximum size of a webhook payload
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	MaxWebhookBytes        = 64 << 10
+)
+
 // HomeHandler is the GET "/" home page handler
 func (s *Server) HomeHandler(w http.ResponseWriter, r *http.Request) {
 	err := s.Renderer.RenderGoHtmlPageTemplate(w, r, "home.page.gohtml", &TemplateData{})
@@ -35,23 +79,22 @@ func (s *Server) AboutHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // RoomsHandler is the GET "/rooms/{index}" page handler
+// Index links are kept for old URLs and are redirected permanently to the room slug URL.
 func (s *Server) RoomsHandler(w http.ResponseWriter, r *http.Request) {
-	// if no id paramater exists in URL render a new page
-	if chi.URLParam(r, "index") == "list" {
-		//TODO: change the offset to request input
-		rooms, err := s.ListRooms(LimitRoomsPerPage, 0)
-		if err != nil {
-			sErr := ServerError{
-				Prompt: "Unable to load rooms from database.",
-				URL:    r.URL.Path,
-				Err:    err,
-			}
-			s.LogErrorAndRedirect(w, r, sErr, "/")
-			return
+	//TODO: change the offset to request input
+	rooms, err := s.ListRooms(LimitRoomsPerPage, 0)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load rooms from database.",
+			URL:    r.URL.Path,
+			Err:    err,
 		}
+		s.LogErrorAndRedirect(w, r, sErr, "/")
+		return
+	}
 
-		app.Session.Put(r.Context(), "rooms", rooms)
-
+	// if no id paramater exists in URL render a new page
+	if chi.URLParam(r, "index") == "list" {
 		s.Render(w, r, "rooms.page.gohtml",
 			&TemplateData{
 				Data: map[string]any{"rooms": rooms},
@@ -59,13 +102,7 @@ func (s *Server) RoomsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	rooms, ok := app.Session.Get(r.Context(), "rooms").([]Room)
-	if !ok {
-		http.Redirect(w, r, "/rooms/list", http.StatusTemporaryRedirect)
-		return
-	}
-
-	// get room id from URL
+	// get room index from URL
 	index, err := strconv.Atoi(chi.URLParam(r, "index"))
 	if err != nil {
 		sErr := CreateServerError(ErrorInvalidParameter, r.URL.Path, err)
@@ -74,41 +111,140 @@ func (s *Server) RoomsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// check if index is out of scope
-	if index >= len(rooms) {
+	if index < 0 || index >= len(rooms) {
 		http.Redirect(w, r, "/rooms/list", http.StatusTemporaryRedirect)
 		return
 	}
 
-	// put selected room data to session
-	room := rooms[index]
-	app.Session.Put(r.Context(), "room", room)
-
-	// remove rooms data from session
-	app.Session.Remove(r.Context(), "rooms")
-
-	// create redirect url
-	url := strings.ReplaceAll(room.Name, "'", "")
-	url = strings.ReplaceAll(url, " ", "-")
-	url = fmt.Sprint("/rooms/room/", url)
-
-	//redirecting to make-reservation page
-	http.Redirect(w, r, url, http.StatusSeeOther)
+	// redirecting to the room page
+	http.Redirect(w, r, rooms[index].URL(), http.StatusMovedPermanently)
 }
 
 // RoomHandler is the GET "/rooms/room/{name}" page handler
+// The room is loaded from the database by its slug.
 func (s *Server) RoomHandler(w http.ResponseWriter, r *http.Request) {
-	room, ok := app.Session.Get(r.Context(), "room").(Room)
-	if !ok {
-		http.Redirect(w, r, "/rooms/list", http.StatusTemporaryRedirect)
+	slug := chi.URLParam(r, "name")
+
+	room, err := s.GetRoomBySlug(slug)
+	if errors.Is(err, pgx.ErrNoRows) {
+		app.Session.Put(r.Context(), "warning", "Room not found.")
+		http.Redirect(w, r, "/rooms/list", http.StatusSeeOther)
+		return
+	} else if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load room from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/rooms/list")
+		return
+	}
+
+	// redirect to the canonical URL of the room
+	if slug != room.Slug {
+		http.Redirect(w, r, room.URL(), http.StatusMovedPermanently)
 		return
 	}
 
+	// put selected room data to session for the availability search
+	app.Session.Put(r.Context(), "room", room)
+
 	s.Render(w, r, "room.page.gohtml",
 		&TemplateData{
 			Data: map[string]any{"room": room},
 		}, "/rooms/list")
 }
 
+// define the type of json response
+type RoomPricesResponse struct {
+	OK     bool              `json:"ok"`
+	Prices map[string]string `json:"prices"`
+	Error  string            `json:"error"`
+}
+
+// RoomPricesHandler is the GET "/rooms/room/{name}/prices" json handler.
+// It returns the price of every night from the start_date to the end_date query parameters,
+// keyed by date, for the datepicker of the room.page.
+// The nights priced default to PriceCalendarDays from today, and are limited to MaxPriceCalendarDays.
+func (s *Server) RoomPricesHandler(w http.ResponseWriter, r *http.Request) {
+	room, err := s.GetRoomBySlug(chi.URLParam(r, "name"))
+	if errors.Is(err, pgx.ErrNoRows) {
+		s.ResponseJSON(w, r, RoomPricesResponse{
+			OK:    false,
+			Error: "Room not found.",
+		})
+		return
+	} else if err != nil {
+		s.ResponseJSON(w, r, RoomPricesResponse{
+			OK:    false,
+			Error: "Internal Error. Please reload and try again.",
+		})
+
+		s.LogError(ServerError{
+			Prompt: "Unable to load room from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		})
+		return
+	}
+
+	// parse the dates requested
+	form := forms.New(r.URL.Query())
+	form.TrimSpaces()
+
+	startDate := Today()
+	err = form.GetValue("start_date", &startDate)
+	if err != nil {
+		s.ResponseJSON(w, r, RoomPricesResponse{
+			OK:    false,
+			Error: "Invalid start date.",
+		})
+		return
+	}
+
+	endDate := startDate.AddDate(0, 0, PriceCalendarDays)
+	err = form.GetValue("end_date", &endDate)
+	if err != nil || !endDate.After(startDate) {
+		s.ResponseJSON(w, r, RoomPricesResponse{
+			OK:    false,
+			Error: "Invalid end date.",
+		})
+		return
+	}
+
+	if maxDate := startDate.AddDate(0, 0, MaxPriceCalendarDays); endDate.After(maxDate) {
+		endDate = maxDate
+	}
+
+	quote, err := s.QuoteStay(room, startDate, endDate, 0, 0, "")
+	if err != nil {
+		s.ResponseJSON(w, r, RoomPricesResponse{
+			OK:    false,
+			Error: "Internal Error. Please reload and try again.",
+		})
+
+		s.LogError(ServerError{
+			Prompt: "Unable to quote room price.",
+			URL:    r.URL.Path,
+			Err:    err,
+		})
+		return
+	}
+
+	// prices are formatted in the display currency of the session
+	currency := s.DisplayCurrency(r)
+
+	prices := make(map[string]string, len(quote.Nights))
+	for _, night := range quote.Nights {
+		prices[night.Date.Format(config.DateLayout)] = currency.Format(night.Rate)
+	}
+
+	s.ResponseJSON(w, r, RoomPricesResponse{
+		OK:     true,
+		Prices: prices,
+	})
+}
+
 // define the type of json response
 type SearchRoomAvailabilityResponse struct {
 	OK      bool   `json:"ok"`
@@ -156,6 +292,8 @@ func (s *Server) PostSearchRoomAvailabilityHandler(w http.ResponseWriter, r *htt
 		errMsg = form.Errors.Get("end_date")
 	} else if ok = form.CheckDateRange("start_date", "end_date"); !ok {
 		errMsg = form.Errors.Get("end_date")
+	} else if ok = CheckGuests(form); !ok {
+		errMsg = form.Errors.Get("adults") + form.Errors.Get("children")
 	}
 
 	// returns response if form data are invalid
@@ -171,12 +309,32 @@ func (s *Server) PostSearchRoomAvailabilityHandler(w http.ResponseWriter, r *htt
 	rsv := Reservation{}
 	form.GetValue("start_date", &rsv.StartDate)
 	form.GetValue("end_date", &rsv.EndDate)
+	form.GetValue("adults", &rsv.Adults)
+	form.GetValue("children", &rsv.Children)
 	rsv.RoomID = room.ID
 	rsv.Room = room
 
-	// check if room is available
-	ok, err = s.CheckRoomAvailability(rsv.RoomID, rsv.StartDate, rsv.EndDate)
-	if err != nil {
+	// release the rooms held by previous searches, and hold the room if it is available
+	holdToken := getHoldToken(r)
+	err = s.ReleaseRoomHolds(holdToken)
+	if err == nil {
+		err = s.HoldRoom(rsv, holdToken)
+	}
+
+	var ruleErr *db.StayRuleError
+	if errors.Is(err, db.ErrRoomUnavailable) {
+		s.ResponseJSON(w, r, SearchRoomAvailabilityResponse{
+			OK:      false,
+			Message: "Room is unavailable for the dates and guests selected. Please try different dates.",
+		})
+		return
+	} else if errors.As(err, &ruleErr) {
+		s.ResponseJSON(w, r, SearchRoomAvailabilityResponse{
+			OK:      false,
+			Message: StayRuleMessage(ruleErr),
+		})
+		return
+	} else if err != nil {
 		s.ResponseJSON(w, r, SearchRoomAvailabilityResponse{
 			OK:    false,
 			Error: "Internal Error. Please reload and try again.",
@@ -190,18 +348,12 @@ func (s *Server) PostSearchRoomAvailabilityHandler(w http.ResponseWriter, r *htt
 		return
 	}
 
-	if ok {
-		// load reservation to session data
-		app.Session.Put(r.Context(), "reservation", rsv)
+	// load reservation to session data, and empty the booking cart of previous searches
+	app.Session.Put(r.Context(), "reservation", rsv)
+	app.Session.Remove(r.Context(), "cart")
 
-		// write the json response
-		s.ResponseJSON(w, r, SearchRoomAvailabilityResponse{OK: true})
-	} else {
-		s.ResponseJSON(w, r, SearchRoomAvailabilityResponse{
-			OK:      false,
-			Message: "Room is unavailable. PLease try different dates.",
-		})
-	}
+	// write the json response
+	s.ResponseJSON(w, r, SearchRoomAvailabilityResponse{OK: true})
 }
 
 // ContactHandler is the GET "/contact" page handler
@@ -209,10 +361,38 @@ func (s *Server) ContactHandler(w http.ResponseWriter, r *http.Request) {
 	s.Render(w, r, "contact.page.gohtml", &TemplateData{}, "/")
 }
 
+// PostCurrencyHandler is the POST "/currency" page handler.
+// It sets the currency prices are displayed in for the session, and redirects back to the page of the guest.
+// Prices are always charged in the base currency.
+func (s *Server) PostCurrencyHandler(w http.ResponseWriter, r *http.Request) {
+	redirectURL := refererPath(r)
+
+	err := r.ParseForm()
+	if err != nil {
+		sErr := CreateServerError(ErrorParseForm, r.URL.Path, err)
+		s.LogErrorAndRedirect(w, r, sErr, redirectURL)
+		return
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(r.PostForm.Get("currency")))
+	if !slices.ContainsFunc(s.DisplayCurrencies(), func(e ExchangeRate) bool { return e.Currency == code }) {
+		app.Session.Put(r.Context(), "warning", "Currency is not available.")
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+
+	app.Session.Put(r.Context(), "currency", code)
+	http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+}
+
 // AvailabilityHandler is the GET "/available-rooms-search" page handler
 func (s *Server) AvailableRoomsSearchHandler(w http.ResponseWriter, r *http.Request) {
+	form := forms.New(nil)
+	form.Set("adults", "1")
+	form.Set("children", "0")
+
 	s.Render(w, r, "available-rooms-search.page.gohtml",
-		&TemplateData{Form: forms.New(nil)}, "/")
+		&TemplateData{Form: form}, "/")
 }
 
 // PostAvailability is the POST "/available-rooms-search" page handler
@@ -229,6 +409,7 @@ func (s *Server) PostAvailableRoomsSearchHandler(w http.ResponseWriter, r *http.
 	form.TrimSpaces()
 	form.Required("start_date", "end_date")
 	form.CheckDateRange("start_date", "end_date")
+	CheckGuests(form)
 
 	if !form.Valid() {
 		s.Render(w, r, "available-rooms-search.page.gohtml",
@@ -240,9 +421,19 @@ func (s *Server) PostAvailableRoomsSearchHandler(w http.ResponseWriter, r *http.
 	rsv := Reservation{}
 	form.GetValue("start_date", &rsv.StartDate)
 	form.GetValue("end_date", &rsv.EndDate)
-
-	// get list of available rooms
-	rooms, err := s.ListAvailableRooms(LimitRoomsPerPage, 0, rsv.StartDate, rsv.EndDate)
+	form.GetValue("adults", &rsv.Adults)
+	form.GetValue("children", &rsv.Children)
+
+	// release the rooms held by previous searches, and get list of available rooms allowed by the stay rules
+	var rooms []Room
+	var ruleErrs []*db.StayRuleError
+	err = s.ReleaseRoomHolds(getHoldToken(r))
+	if err == nil {
+		rooms, err = s.ListAvailableRooms(LimitRoomsPerPage, 0, rsv.StartDate, rsv.EndDate, rsv.Adults, rsv.Children)
+	}
+	if err == nil {
+		rooms, ruleErrs, err = s.CheckStayRules(rooms, rsv.StartDate, rsv.EndDate)
+	}
 	if err != nil {
 		sErr := ServerError{
 			Prompt: "Unable to load available rooms.",
@@ -253,17 +444,36 @@ func (s *Server) PostAvailableRoomsSearchHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
-	// check if there are rooms available
-	if len(rooms) == 0 {
-		app.Session.Put(r.Context(), "warning", "No rooms are available. Please try different dates.")
+	// check if there are rooms available, and explain the stay rules that blocked the search
+	if len(rooms) == 0 && len(ruleErrs) > 0 {
+		msgs := []string{}
+		for _, ruleErr := range ruleErrs {
+			if msg := StayRuleMessage(ruleErr); !slices.Contains(msgs, msg) {
+				msgs = append(msgs, msg)
+			}
+		}
+
+		app.Session.Put(r.Context(), "warning", "No rooms can be booked for the dates selected because of the stay rules.")
 		s.Render(w, r, "available-rooms-search.page.gohtml",
-			&TemplateData{Form: form}, "/")
+			&TemplateData{
+				Data: map[string]any{"stay_rules": msgs},
+				Form: form,
+			}, "/")
+		return
+	} else if len(rooms) == 0 {
+		app.Session.Put(r.Context(), "warning", "No rooms are available for the dates and guests selected. Please try different dates.")
+		s.Render(w, r, "available-rooms-search.page.gohtml",
+			&TemplateData{
+				Data: map[string]any{"waitlist_url": "/waitlist?" + form.Values.Encode()},
+				Form: form,
+			}, "/")
 		return
 	}
 
-	// load reservation to session data
+	// load reservation to session data, and empty the booking cart of previous searches
 	app.Session.Put(r.Context(), "reservation", rsv)
 	app.Session.Put(r.Context(), "rooms", rooms)
+	app.Session.Remove(r.Context(), "cart")
 
 	// redirecting to choose-room page
 	http.Redirect(w, r, "/available-rooms/available", http.StatusSeeOther)
@@ -281,15 +491,28 @@ func (s *Server) AvailableRoomsListHandler(w http.ResponseWriter, r *http.Reques
 
 	// if no id paramater exists in URL render a new page
 	if chi.URLParam(r, "index") == "available" {
+		// mark the rooms that were already added to the booking cart
+		cart, _ := app.Session.Get(r.Context(), "cart").([]Room)
+		inCart := make(map[int]bool)
+		for i, room := range rooms {
+			inCart[i] = containsRoom(cart, room.ID)
+		}
+
 		s.Render(w, r, "available-rooms.page.gohtml",
 			&TemplateData{
-				Data: map[string]any{"rooms": rooms},
+				Data: map[string]any{
+					"rooms":   rooms,
+					"in_cart": inCart,
+				},
 			}, "/")
 		return
 	}
 
 	// get room id from URL
 	index, err := strconv.Atoi(chi.URLParam(r, "index"))
+	if err == nil && (index < 0 || index >= len(rooms)) {
+		err = fmt.Errorf("room index %d is out of range", index)
+	}
 	if err != nil {
 		sErr := CreateServerError(ErrorInvalidParameter, r.URL.Path, err)
 		s.LogErrorAndRedirect(w, r, sErr, "/available-rooms/available")
@@ -306,8 +529,37 @@ func (s *Server) AvailableRoomsListHandler(w http.ResponseWriter, r *http.Reques
 
 	rsv.RoomID = rooms[index].ID
 	rsv.Room = rooms[index]
+
+	// hold the room until the reservation is made
+	err = s.HoldRoom(rsv, getHoldToken(r))
+	var ruleErr *db.StayRuleError
+	if errors.Is(err, db.ErrRoomUnavailable) {
+		app.Session.Put(r.Context(), "warning", "This room is no longer available. Please choose another room.")
+		http.Redirect(w, r, "/available-rooms/available", http.StatusSeeOther)
+		return
+	} else if errors.As(err, &ruleErr) {
+		app.Session.Put(r.Context(), "warning", StayRuleMessage(ruleErr))
+		http.Redirect(w, r, "/available-rooms/available", http.StatusSeeOther)
+		return
+	} else if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to hold room.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/available-rooms/available")
+		return
+	}
+
 	app.Session.Put(r.Context(), "reservation", rsv)
 
+	// add room to the booking cart
+	cart, _ := app.Session.Get(r.Context(), "cart").([]Room)
+	if !containsRoom(cart, rsv.RoomID) {
+		cart = append(cart, rsv.Room)
+	}
+	app.Session.Put(r.Context(), "cart", cart)
+
 	// redirecting to make-reservation page
 	http.Redirect(w, r, "/make-reservation", http.StatusSeeOther)
 }
@@ -321,15 +573,144 @@ func (s *Server) MakeReservationHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// fill the form with the guests searched for
+	form := forms.New(nil)
+	form.Set("adults", strconv.Itoa(max(reservation.Adults, 1)))
+	form.Set("children", strconv.Itoa(reservation.Children))
+
+	s.renderMakeReservation(w, r, reservation, getCart(r, reservation), form, "/")
+}
+
+// renderMakeReservation renders the make-reservation page of rsv with the price quoted for every room in cart,
+// including taxes and fees, and discounted by the promo code of form if it applies to all of them.
+// It redirects to redirectURL if the page cannot be rendered.
+func (s *Server) renderMakeReservation(w http.ResponseWriter, r *http.Request, rsv Reservation, cart []Room, form *forms.Form, redirectURL string) {
+	promoCode := strings.ToUpper(form.Get("promo_code"))
+
+	// quote the guests of the form staying in every room for the charges per guest,
+	// or a single adult per room if the rooms cannot accommodate them
+	split := make([]Guests, len(cart))
+	for i := range split {
+		split[i].Adults = 1
+	}
+
+	var adults, children int
+	if form.GetValue("adults", &adults) == nil && form.GetValue("children", &children) == nil {
+		rsv.Adults, rsv.Children = adults, children
+		if guests, ok := splitGuests(cart, adults, children); ok {
+			split = guests
+		}
+	}
+
+	quotes, err := s.quoteCart(rsv, cart, split, promoCode)
+	var promoErr *db.PromoCodeError
+	if errors.As(err, &promoErr) {
+		// quote without the promo code
+		if form.Errors.Get("promo_code") == "" {
+			form.Errors.Add("promo_code", PromoCodeMessage(promoErr))
+		}
+		quotes, err = s.quoteCart(rsv, cart, split, "")
+	}
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to quote room price.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/")
+		return
+	}
+
+	var subtotal, discount, total Price
+	for _, quote := range quotes {
+		subtotal += quote.Subtotal
+		discount += quote.Discount
+		total += quote.Total
+	}
+
 	s.Render(w, r, "make-reservation.page.gohtml",
 		&TemplateData{
 			Data: map[string]any{
-				"start_date":  reservation.StartDate.Format(config.DateLayout),
-				"end_date":    reservation.EndDate.Format(config.DateLayout),
-				"reservation": reservation,
+				"start_date":      rsv.StartDate.Format(config.DateLayout),
+				"end_date":        rsv.EndDate.Format(config.DateLayout),
+				"reservation":     rsv,
+				"quotes":          quotes,
+				"guests":          split,
+				"subtotal":        subtotal,
+				"discount":        discount,
+				"total_price":     total,
+				"deposit":         total.Percent(app.DepositPercent),
+				"deposit_percent": app.DepositPercent,
+				"can_add":         app.Session.Exists(r.Context(), "rooms"),
+				"hold_minutes":    app.RoomHoldMinutes,
 			},
-			Form: forms.New(nil),
-		}, "/")
+			Form: form,
+		}, redirectURL)
+}
+
+// quoteCart returns the price quoted for a stay of rsv in every room in cart, discounted once by promoCode if not empty.
+// The charges per guest of every room are quoted for the guests staying in it, as split.
+// It returns a PromoCodeError if the promo code does not apply to any of the rooms.
+func (s *Server) quoteCart(rsv Reservation, cart []Room, split []Guests, promoCode string) ([]Quote, error) {
+	return s.QuoteStays(cart, rsv.StartDate, rsv.EndDate, split, promoCode)
+}
+
+// PostRemoveCartRoomHandler is the POST "/make-reservation/remove-room" page handler.
+// It removes a room from the booking cart.
+func (s *Server) PostRemoveCartRoomHandler(w http.ResponseWriter, r *http.Request) {
+	rsv, ok := app.Session.Get(r.Context(), "reservation").(Reservation)
+	if !ok {
+		sErr := CreateServerError(ErrorMissingReservation, r.URL.Path, nil)
+		s.LogErrorAndRedirect(w, r, sErr, "/")
+		return
+	}
+
+	err := r.ParseForm()
+	if err != nil {
+		sErr := CreateServerError(ErrorParseForm, r.URL.Path, err)
+		s.LogErrorAndRedirect(w, r, sErr, "/make-reservation")
+		return
+	}
+
+	roomID, err := strconv.ParseInt(r.PostForm.Get("room_id"), 10, 64)
+	if err != nil {
+		sErr := CreateServerError(ErrorInvalidParameter, r.URL.Path, err)
+		s.LogErrorAndRedirect(w, r, sErr, "/make-reservation")
+		return
+	}
+
+	// release the room and remove it from the booking cart
+	err = s.ReleaseRoomHold(roomID, getHoldToken(r))
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to release room.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/make-reservation")
+		return
+	}
+
+	cart := []Room{}
+	for _, room := range getCart(r, rsv) {
+		if room.ID != roomID {
+			cart = append(cart, room)
+		}
+	}
+
+	// choose another room if the cart is empty
+	if len(cart) == 0 {
+		app.Session.Remove(r.Context(), "cart")
+		http.Redirect(w, r, "/available-rooms/available", http.StatusSeeOther)
+		return
+	}
+
+	rsv.RoomID = cart[0].ID
+	rsv.Room = cart[0]
+	app.Session.Put(r.Context(), "reservation", rsv)
+	app.Session.Put(r.Context(), "cart", cart)
+
+	http.Redirect(w, r, "/make-reservation", http.StatusSeeOther)
 }
 
 // PostReservationHandler is the POST "/make-reservation" page handler
@@ -351,23 +732,40 @@ func (s *Server) PostMakeReservationHandler(w http.ResponseWriter, r *http.Reque
 	// create a new form with data and validate the form
 	form := forms.New(r.PostForm)
 	form.TrimSpaces()
+
+	// apply the promo code to the prices without booking
+	cart := getCart(r, rsv)
+	if form.Has("apply_promo") {
+		s.renderMakeReservation(w, r, rsv, cart, form, "/make-reservation")
+		return
+	}
+
 	form.Required("first_name", "last_name", "email")
 	form.CheckMinLenght("first_name", 3)
 	form.CheckMinLenght("last_name", 3)
 	form.CheckEmail("email")
+	if app.DepositPercent > 0 {
+		form.Required("payment_token")
+	}
 
 	log.Println("TODO: validate phone and notes even if not required")
 
+	// check that the guests fit in the rooms of the cart together, and split them across the rooms
+	var split []Guests
+	if CheckGuests(form) {
+		var adults, children int
+		form.GetValue("adults", &adults)
+		form.GetValue("children", &children)
+
+		var ok bool
+		split, ok = splitGuests(cart, adults, children)
+		if !ok {
+			form.Errors.Add("adults", capacityMessage(cart))
+		}
+	}
+
 	if !form.Valid() {
-		s.Render(w, r, "make-reservation.page.gohtml",
-			&TemplateData{
-				Data: map[string]any{
-					"start_date":  rsv.StartDate.Format(config.DateLayout),
-					"end_date":    rsv.StartDate.Format(config.DateLayout),
-					"reservation": rsv,
-				},
-				Form: form,
-			}, "/make-reservation")
+		s.renderMakeReservation(w, r, rsv, cart, form, "/make-reservation")
 		return
 	}
 
@@ -377,15 +775,87 @@ func (s *Server) PostMakeReservationHandler(w http.ResponseWriter, r *http.Reque
 	form.GetValue("email", &rsv.Email)
 	form.GetValue("phone", &rsv.Phone)
 	form.GetValue("notes", &rsv.Notes)
+	form.GetValue("adults", &rsv.Adults)
+	form.GetValue("children", &rsv.Children)
 
-	// generate reservation code
+	// generate reservation code, which is also the parent code of all rooms in the cart
 	rsv.GenerateReservationCode()
+	rsv.ParentCode = rsv.Code
+
+	// create a reservation for every room in the cart, with the guests staying in the room
+	rsvs := make([]Reservation, len(cart))
+	for i, room := range cart {
+		rsvs[i] = rsv
+		rsvs[i].RoomID = room.ID
+		rsvs[i].Room = room
+		rsvs[i].Adults = split[i].Adults
+		rsvs[i].Children = split[i].Children
+		if i > 0 {
+			rsvs[i].GenerateReservationCode()
+		}
+	}
 
-	// insert reservation into database
-	err = s.CreateReservation(rsv)
-	if err != nil {
-		sErr := ServerError{
-			Prompt: "Unable to create reservation.",
+	// authorize the deposits before booking, so that a declined card books no room
+	promoCode := strings.ToUpper(form.Get("promo_code"))
+	var auths []payments.Transaction
+	var promoErr *db.PromoCodeError
+	if app.DepositPercent > 0 {
+		quotes, err := s.quoteCart(rsv, cart, split, promoCode)
+		if errors.As(err, &promoErr) {
+			form.Errors.Add("promo_code", PromoCodeMessage(promoErr))
+			s.renderMakeReservation(w, r, rsv, cart, form, "/make-reservation")
+			return
+		} else if err != nil {
+			sErr := ServerError{
+				Prompt: "Unable to quote room price.",
+				URL:    r.URL.Path,
+				Err:    err,
+			}
+			s.LogErrorAndRedirect(w, r, sErr, "/make-reservation")
+			return
+		}
+
+		auths, err = s.AuthorizeDeposits(rsv, quotes, form.Get("payment_token"))
+		if errors.Is(err, payments.ErrPaymentDeclined) || errors.Is(err, payments.ErrInvalidPayment) {
+			form.Errors.Add("payment_token", "Your card was declined. Please check the card number or use another card.")
+			s.renderMakeReservation(w, r, rsv, cart, form, "/make-reservation")
+			return
+		} else if err != nil {
+			sErr := ServerError{
+				Prompt: "Unable to authorize deposit.",
+				URL:    r.URL.Path,
+				Err:    err,
+			}
+			s.LogErrorAndRedirect(w, r, sErr, "/make-reservation")
+			return
+		}
+	}
+
+	// insert reservations into database
+	rsvs, err = s.CreateReservations(rsvs, getHoldToken(r), promoCode)
+	if err != nil {
+		// no room was booked, so the deposits authorized are released
+		s.VoidDeposits(auths)
+	}
+
+	var ruleErr *db.StayRuleError
+	if errors.Is(err, db.ErrRoomUnavailable) {
+		app.Session.Remove(r.Context(), "cart")
+		app.Session.Put(r.Context(), "warning", "One of the rooms is no longer available. Please search again.")
+		http.Redirect(w, r, "/available-rooms-search", http.StatusSeeOther)
+		return
+	} else if errors.As(err, &ruleErr) {
+		app.Session.Remove(r.Context(), "cart")
+		app.Session.Put(r.Context(), "warning", StayRuleMessage(ruleErr))
+		http.Redirect(w, r, "/available-rooms-search", http.StatusSeeOther)
+		return
+	} else if errors.As(err, &promoErr) {
+		form.Errors.Add("promo_code", PromoCodeMessage(promoErr))
+		s.renderMakeReservation(w, r, rsv, cart, form, "/make-reservation")
+		return
+	} else if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to create reservation.",
 			URL:    r.URL.Path,
 			Err:    err,
 		}
@@ -393,10 +863,16 @@ func (s *Server) PostMakeReservationHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	// load reservation data into session
-	app.Session.Put(r.Context(), "reservation", rsv)
+	// take the deposits and confirm the reservations
+	if app.DepositPercent > 0 {
+		rsvs = s.CaptureDeposits(rsvs, auths, form.Get("payment_token"))
+	}
+
+	// load reservations data into session
+	app.Session.Put(r.Context(), "reservation", rsvs[0])
+	app.Session.Put(r.Context(), "reservations", rsvs)
 
-	data, err := s.Renderer.CreateReservationConfirmationMail(rsv)
+	data, err := s.Renderer.CreateReservationConfirmationMail(rsvs...)
 	if err != nil {
 		sErr := ServerError{
 			Prompt: "Unable to render confirmation email.",
@@ -430,139 +906,3103 @@ func (s *Server) ReservationSummaryHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	// remove reservation and rooms data from session
+	// get all reservations booked together
+	rsvs, ok := app.Session.Get(r.Context(), "reservations").([]Reservation)
+	if !ok {
+		rsvs = []Reservation{reservation}
+	}
+
+	// remove reservation, rooms and cart data from session
 	app.Session.Remove(r.Context(), "reservation")
+	app.Session.Remove(r.Context(), "reservations")
 	app.Session.Remove(r.Context(), "rooms")
+	app.Session.Remove(r.Context(), "cart")
 
 	s.Render(w, r, "reservation-summary.page.gohtml",
 		&TemplateData{
 			Data: map[string]any{
-				"start_date":  reservation.StartDate.Format(config.DateLayout),
-				"end_date":    reservation.EndDate.Format(config.DateLayout),
-				"reservation": reservation,
+				"start_date":   reservation.StartDate.Format(config.DateLayout),
+				"end_date":     reservation.EndDate.Format(config.DateLayout),
+				"reservation":  reservation,
+				"reservations": rsvs,
+				"per_room":     len(rsvs) > 1,
+				"total_price":  TotalPrice(rsvs),
+				"discount":     TotalDiscount(rsvs),
 			},
 		}, "/")
 }
 
-// LoginHandler is the GET "/user/login" page handler
-func (s *Server) LoginHandler(w http.ResponseWriter, r *http.Request) {
-	s.Render(w, r, "login.page.gohtml",
+// FindReservationHandler is the GET "/find-reservation" page handler
+func (s *Server) FindReservationHandler(w http.ResponseWriter, r *http.Request) {
+	s.Render(w, r, "find-reservation.page.gohtml",
 		&TemplateData{Form: forms.New(nil)}, "/")
 }
 
-// PostLoginHandler is the POST "/user/login" page handler
-func (s *Server) PostLoginHandler(w http.ResponseWriter, r *http.Request) {
-	app.Session.RenewToken(r.Context())
-
+// PostFindReservationHandler is the POST "/find-reservation" page handler
+func (s *Server) PostFindReservationHandler(w http.ResponseWriter, r *http.Request) {
 	err := r.ParseForm()
 	if err != nil {
 		sErr := CreateServerError(ErrorParseForm, r.URL.Path, err)
-		s.LogErrorAndRedirect(w, r, sErr, "/user/login")
+		s.LogErrorAndRedirect(w, r, sErr, "/find-reservation")
 		return
 	}
 
 	// create a new form with data and validate the form
 	form := forms.New(r.PostForm)
 	form.TrimSpaces()
-	form.Required("email", "password")
-	form.CheckEmail("email")
-	form.CheckPassword("password")
+	form.Required("code", "last_name")
 
 	if !form.Valid() {
-		s.Render(w, r, "login.page.gohtml",
+		s.Render(w, r, "find-reservation.page.gohtml",
 			&TemplateData{Form: form}, "/")
 		return
 	}
 
-	// authenticate the user
-	userID, err := s.AuthenticateUser(form.Get("email"), form.Get("password"))
-	if err != nil {
-		form.Del("password")
+	// reservation codes are always in uppercase
+	code := strings.ToUpper(form.Get("code"))
 
-		s.LogInfo(fmt.Sprintf("Unsuccessful login with email %s", form.Get("email")))
+	// get reservation from database
+	rsv, err := s.GetReservationByLastName(code, form.Get("last_name"))
+	if errors.Is(err, pgx.ErrNoRows) {
+		s.LogInfo(fmt.Sprintf("Unsuccessful reservation lookup with code %s", code))
 
-		s.Render(w, r, "login.page.gohtml",
+		// the same message is used for both fields to avoid revealing which one is wrong
+		s.Render(w, r, "find-reservation.page.gohtml",
 			&TemplateData{
-				Form:  forms.New(nil),
-				Error: "Invalid Email and/or Password. Please try again.",
+				Form:  form,
+				Error: "No reservation matches the code and last name provided. Please try again.",
 			}, "/")
+		return
+	} else if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load reservation from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/find-reservation")
+		return
+	}
+
+	// load reservation to session data
+	app.Session.Put(r.Context(), "lookup", rsv)
 
+	// redirecting to my-reservation page
+	http.Redirect(w, r, "/my-reservation", http.StatusSeeOther)
+}
+
+// MyReservationHandler is the GET "/my-reservation" page handler
+func (s *Server) MyReservationHandler(w http.ResponseWriter, r *http.Request) {
+	// get reservation found by the guest from session
+	lookup, ok := app.Session.Get(r.Context(), "lookup").(Reservation)
+	if !ok {
+		http.Redirect(w, r, "/find-reservation", http.StatusTemporaryRedirect)
 		return
 	}
 
-	app.Session.Put(r.Context(), "user_id", userID)
-	app.Session.Put(r.Context(), "flash", "Successfully logged in!")
-	s.LogInfo(fmt.Sprintf("Successful login by user %d", userID))
+	// load the reservation again, as staff may have changed it since it was found
+	rsv, err := s.GetReservation(lookup.ID)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load reservation from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/find-reservation")
+		return
+	}
+	app.Session.Put(r.Context(), "lookup", rsv)
+
+	data := map[string]any{
+		"start_date":   rsv.StartDate.Format(config.DateLayout),
+		"end_date":     rsv.EndDate.Format(config.DateLayout),
+		"reservation":  rsv,
+		"reservations": []Reservation{rsv},
+		"total_price":  rsv.TotalPrice,
+		"manage":       true,
+		"cancellable":  rsv.IsCancellable(Today()),
+	}
+	if rsv.IsCancelled() {
+		data["cancelled_at"] = rsv.CancelledAt.Format(config.DateLayout)
+	}
 
-	// redirecting to home page
-	http.Redirect(w, r, "/", http.StatusSeeOther)
+	s.Render(w, r, "reservation-summary.page.gohtml", &TemplateData{Data: data}, "/")
 }
 
-// LogoutHandler is the GET "/user/logout" page handler
-func (s *Server) LogoutHandler(w http.ResponseWriter, r *http.Request) {
-	app.Session.Destroy(r.Context())
-	app.Session.RenewToken(r.Context())
-	app.Session.Put(r.Context(), "flash", "Successfully logged out!")
+// CancelReservationHandler is the GET "/my-reservation/cancel" page handler
+func (s *Server) CancelReservationHandler(w http.ResponseWriter, r *http.Request) {
+	// get reservation found by the guest from session
+	rsv, ok := app.Session.Get(r.Context(), "lookup").(Reservation)
+	if !ok {
+		http.Redirect(w, r, "/find-reservation", http.StatusTemporaryRedirect)
+		return
+	}
 
-	// redirecting to login page
-	http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+	today := Today()
+	if !rsv.IsCancellable(today) {
+		app.Session.Put(r.Context(), "warning", "This reservation can no longer be cancelled.")
+		http.Redirect(w, r, "/my-reservation", http.StatusTemporaryRedirect)
+		return
+	}
+
+	policy := app.CancellationPolicy
+	s.Render(w, r, "cancel-reservation.page.gohtml",
+		&TemplateData{
+			Data: map[string]any{
+				"start_date":       rsv.StartDate.Format(config.DateLayout),
+				"end_date":         rsv.EndDate.Format(config.DateLayout),
+				"free_until":       rsv.FreeCancellationDate(policy).Format(config.DateLayout),
+				"fee_percent":      rsv.CancellationFee(policy, today),
+				"late_fee_percent": policy.LateCancellationFeePercent,
+				"free_days":        policy.FreeCancellationDays,
+				"reservation":      rsv,
+			},
+			Form: forms.New(nil),
+		}, "/my-reservation")
 }
 
-// AdminDashboardHandler is the GET "/admin/dashboard" page handler
-func (s *Server) AdminDashboardHandler(w http.ResponseWriter, r *http.Request) {
-	s.Render(w, r, "dashbaord.panel.gohtml", &TemplateData{
-		Data: map[string]any{
-			"path": r.URL.Path,
-		},
-	}, "/")
+// PostCancelReservationHandler is the POST "/my-reservation/cancel" page handler
+func (s *Server) PostCancelReservationHandler(w http.ResponseWriter, r *http.Request) {
+	// get reservation found by the guest from session
+	rsv, ok := app.Session.Get(r.Context(), "lookup").(Reservation)
+	if !ok {
+		sErr := CreateServerError(ErrorMissingReservation, r.URL.Path, nil)
+		s.LogErrorAndRedirect(w, r, sErr, "/find-reservation")
+		return
+	}
+
+	today := Today()
+	if !rsv.IsCancellable(today) {
+		app.Session.Put(r.Context(), "warning", "This reservation can no longer be cancelled.")
+		http.Redirect(w, r, "/my-reservation", http.StatusSeeOther)
+		return
+	}
+
+	// cancel reservation in database
+	rsv, err := s.CancelReservation(rsv, "guest", rsv.CancellationFee(app.CancellationPolicy, today))
+	if errors.Is(err, db.ErrReservationCancelled) {
+		// the session holds outdated data, so the guest must look up the reservation again
+		app.Session.Remove(r.Context(), "lookup")
+		app.Session.Put(r.Context(), "warning", "This reservation has already been cancelled.")
+		http.Redirect(w, r, "/find-reservation", http.StatusSeeOther)
+		return
+	} else if errors.Is(err, db.ErrInvalidStatusTransition) {
+		// the reservation was checked in or marked as a no show since it was found by the guest
+		app.Session.Put(r.Context(), "warning", "This reservation can no longer be cancelled.")
+		http.Redirect(w, r, "/my-reservation", http.StatusSeeOther)
+		return
+	} else if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to cancel reservation.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/my-reservation")
+		return
+	}
+
+	// refund the payments of the guest according to the cancellation policy.
+	// Errors are logged for staff to refund manually, as the reservation is already cancelled.
+	flash := "Your reservation has been cancelled."
+	refund, err := s.RefundCancellation(rsv)
+	if err != nil {
+		s.LogError(ServerError{
+			Prompt: fmt.Sprintf("Unable to refund cancelled reservation %s.", rsv.Code),
+			URL:    r.URL.Path,
+			Err:    err,
+		})
+	}
+	if refund.Amount > 0 {
+		flash += fmt.Sprintf(" A refund of %s has been issued to your card.", refund.Amount)
+	}
+
+	// load cancelled reservation to session data
+	app.Session.Put(r.Context(), "lookup", rsv)
+	app.Session.Put(r.Context(), "flash", flash)
+
+	data, err := s.Renderer.CreateReservationCancellationMail(rsv)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to render cancellation email.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/my-reservation")
+		return
+	}
+
+	// send cancellation notification email to guest and log
+	s.SendMail(data)
+	s.LogInfo(fmt.Sprintf("MAIL cancellation notice sent to %s", data.To))
+
+	// send cancellation notification email to admin and log
+	data.To = "admin@listingdomain.com" // TODO: this should be update based on app admin setting
+	s.SendMail(data)
+	s.LogInfo(fmt.Sprintf("MAIL cancellation notice sent to %s", data.To))
+
+	// offer the room released to the guests on the waitlist
+	s.OfferFreedRoom(rsv.RoomID, rsv.StartDate, rsv.EndDate)
+
+	// redirecting to my-reservation page
+	http.Redirect(w, r, "/my-reservation", http.StatusSeeOther)
 }
 
-// AdminReservationsHandler is the GET "/admin/reservations/{show}" page handler
-func (s *Server) AdminReservationsHandler(w http.ResponseWriter, r *http.Request) {
-	var rsvs []Reservation
-	var err error
+// ChangeReservationDatesHandler is the GET "/my-reservation/change-dates" page handler
+func (s *Server) ChangeReservationDatesHandler(w http.ResponseWriter, r *http.Request) {
+	// get reservation found by the guest from session
+	rsv, ok := app.Session.Get(r.Context(), "lookup").(Reservation)
+	if !ok {
+		http.Redirect(w, r, "/find-reservation", http.StatusTemporaryRedirect)
+		return
+	}
 
-	param := chi.URLParam(r, "show")
-	if param == "new" {
-		// load only new reservations
-		//TODO: change the offset to request input
-		rsvs, err = s.ListReservations(LimitReservationsPerPage, 0)
-		if err != nil {
-			sErr := ServerError{
-				Prompt: "Unable to load reservations from database.",
-				URL:    r.URL.Path,
-				Err:    err,
-			}
-			s.LogErrorAndRedirect(w, r, sErr, "/admin/dashboard")
-			return
+	if !rsv.IsCancellable(Today()) {
+		app.Session.Put(r.Context(), "warning", "The dates of this reservation can no longer be changed.")
+		http.Redirect(w, r, "/my-reservation", http.StatusTemporaryRedirect)
+		return
+	}
+
+	// fill the form with the current dates of the reservation
+	form := forms.New(nil)
+	form.Set("start_date", rsv.StartDate.Format(config.DateLayout))
+	form.Set("end_date", rsv.EndDate.Format(config.DateLayout))
+
+	s.Render(w, r, "change-reservation-dates.page.gohtml",
+		&TemplateData{
+			Data: map[string]any{"reservation": rsv},
+			Form: form,
+		}, "/my-reservation")
+}
+
+// PostChangeReservationDatesHandler is the POST "/my-reservation/change-dates" page handler.
+// The new dates are quoted first, and the guest confirms the change with the new total price.
+func (s *Server) PostChangeReservationDatesHandler(w http.ResponseWriter, r *http.Request) {
+	// get reservation found by the guest from session
+	rsv, ok := app.Session.Get(r.Context(), "lookup").(Reservation)
+	if !ok {
+		sErr := CreateServerError(ErrorMissingReservation, r.URL.Path, nil)
+		s.LogErrorAndRedirect(w, r, sErr, "/find-reservation")
+		return
+	}
+
+	today := Today()
+	if !rsv.IsCancellable(today) {
+		app.Session.Put(r.Context(), "warning", "The dates of this reservation can no longer be changed.")
+		http.Redirect(w, r, "/my-reservation", http.StatusSeeOther)
+		return
+	}
+
+	err := r.ParseForm()
+	if err != nil {
+		sErr := CreateServerError(ErrorParseForm, r.URL.Path, err)
+		s.LogErrorAndRedirect(w, r, sErr, "/my-reservation/change-dates")
+		return
+	}
+
+	// create a new form with data and validate the form
+	form := forms.New(r.PostForm)
+	form.TrimSpaces()
+	form.Required("start_date", "end_date")
+	if form.CheckDateRange("start_date", "end_date") {
+		var startDate, endDate time.Time
+		form.GetValue("start_date", &startDate)
+		form.GetValue("end_date", &endDate)
+
+		if startDate.Before(today) {
+			form.Errors.Add("start_date", "Arrival date cannot be in the past.")
+		} else if !endDate.After(startDate) {
+			form.Errors.Add("end_date", "Departure date must be after arrival date.")
 		}
-	} else if param == "all" {
-		// load all reservations
-		//TODO: change the offset to request input
-		rsvs, err = s.ListReservations(LimitReservationsPerPage, 0)
+	}
+
+	td := &TemplateData{
+		Data: map[string]any{"reservation": rsv},
+		Form: form,
+	}
+
+	if !form.Valid() {
+		s.Render(w, r, "change-reservation-dates.page.gohtml", td, "/my-reservation")
+		return
+	}
+
+	// parse form's data
+	var startDate, endDate time.Time
+	form.GetValue("start_date", &startDate)
+	form.GetValue("end_date", &endDate)
+
+	// quote the new dates for the guest to confirm the new total price
+	if !form.Has("confirm") {
+		quote, err := s.QuoteReservationDates(rsv, startDate, endDate)
 		if err != nil {
 			sErr := ServerError{
-				Prompt: "Unable to load reservations from database.",
+				Prompt: "Unable to quote room price.",
 				URL:    r.URL.Path,
 				Err:    err,
 			}
-			s.LogErrorAndRedirect(w, r, sErr, "/admin/dashboard")
+			s.LogErrorAndRedirect(w, r, sErr, "/my-reservation")
 			return
 		}
-	} else {
-		sErr := CreateServerError(ErrorInvalidParameter, r.URL.Path, nil)
-		s.LogErrorAndRedirect(w, r, sErr, "/admin/dashboard")
+
+		td.Data["quote"] = quote
+		s.Render(w, r, "change-reservation-dates.page.gohtml", td, "/my-reservation")
+		return
+	}
+
+	// update reservation dates in database
+	updated, err := s.UpdateReservationDates(rsv, startDate, endDate)
+	var ruleErr *db.StayRuleError
+	if errors.Is(err, db.ErrReservationClosed) {
+		app.Session.Put(r.Context(), "warning", "The dates of this reservation can no longer be changed.")
+		http.Redirect(w, r, "/my-reservation", http.StatusSeeOther)
+		return
+	} else if errors.Is(err, db.ErrRoomUnavailable) {
+		td.Warning = "The room is unavailable on the dates selected. Please try different dates."
+		s.Render(w, r, "change-reservation-dates.page.gohtml", td, "/my-reservation")
+		return
+	} else if errors.As(err, &ruleErr) {
+		td.Warning = StayRuleMessage(ruleErr)
+		s.Render(w, r, "change-reservation-dates.page.gohtml", td, "/my-reservation")
+		return
+	} else if errors.Is(err, db.ErrReservationCancelled) {
+		// the session holds outdated data, so the guest must look up the reservation again
+		app.Session.Remove(r.Context(), "lookup")
+		app.Session.Put(r.Context(), "warning", "This reservation has been cancelled.")
+		http.Redirect(w, r, "/find-reservation", http.StatusSeeOther)
+		return
+	} else if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to change reservation dates.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/my-reservation")
 		return
 	}
 
-	//app.Session.Put(r.Context(), "reservations", rsvs)
+	// load updated reservation to session data
+	app.Session.Put(r.Context(), "lookup", updated)
+	app.Session.Put(r.Context(), "flash", fmt.Sprintf("The dates of your reservation have been changed. The total price is now %s.", updated.TotalPrice))
 
-	s.Render(w, r, "reservations.panel.gohtml",
+	// redirecting to my-reservation page
+	http.Redirect(w, r, "/my-reservation", http.StatusSeeOther)
+}
+
+// InvoiceHandler is the GET "/my-reservation/invoice" page handler.
+// It downloads the invoice of the reservation found by the guest, issuing it on the first download.
+// The reservation is reloaded from the database, since the one found by the guest may have changed since.
+func (s *Server) InvoiceHandler(w http.ResponseWriter, r *http.Request) {
+	// get reservation found by the guest from session
+	lookup, ok := app.Session.Get(r.Context(), "lookup").(Reservation)
+	if !ok {
+		http.Redirect(w, r, "/find-reservation", http.StatusTemporaryRedirect)
+		return
+	}
+
+	rsv, err := s.GetReservation(lookup.ID)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load reservation from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/my-reservation")
+		return
+	}
+
+	invoice, err := s.GetInvoice(rsv, false)
+	if errors.Is(err, ErrNotInvoiceable) {
+		app.Session.Put(r.Context(), "warning", "An invoice is only available for confirmed reservations.")
+		http.Redirect(w, r, "/my-reservation", http.StatusSeeOther)
+		return
+	} else if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to create invoice.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/my-reservation")
+		return
+	}
+
+	s.WriteInvoice(w, invoice)
+}
+
+// WaitlistHandler is the GET "/waitlist" page handler.
+// The form is filled with the search of the guest passed in the URL query.
+func (s *Server) WaitlistHandler(w http.ResponseWriter, r *http.Request) {
+	rooms, err := s.ListRooms(LimitRoomsPerPage, 0)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load rooms from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/")
+		return
+	}
+
+	form := forms.New(r.URL.Query())
+	if !form.Has("adults") {
+		form.Set("adults", "1")
+	}
+	if !form.Has("children") {
+		form.Set("children", "0")
+	}
+
+	s.Render(w, r, "waitlist.page.gohtml",
 		&TemplateData{
-			Data: map[string]any{
-				"path":         r.URL.Path,
-				"showall":      param == "all",
-				"reservations": rsvs,
-			},
+			Data: map[string]any{"rooms": rooms},
+			Form: form,
 		}, "/")
 }
+
+// PostWaitlistHandler is the POST "/waitlist" page handler
+func (s *Server) PostWaitlistHandler(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		sErr := CreateServerError(ErrorParseForm, r.URL.Path, err)
+		s.LogErrorAndRedirect(w, r, sErr, "/waitlist")
+		return
+	}
+
+	// create a new form with data and validate the form
+	form := forms.New(r.PostForm)
+	form.TrimSpaces()
+	form.Required("first_name", "last_name", "email", "start_date", "end_date")
+	form.CheckMinLenght("first_name", 3)
+	form.CheckMinLenght("last_name", 3)
+	form.CheckEmail("email")
+	if form.CheckDateRange("start_date", "end_date") {
+		var startDate, endDate time.Time
+		form.GetValue("start_date", &startDate)
+		form.GetValue("end_date", &endDate)
+
+		if startDate.Before(Today()) {
+			form.Errors.Add("start_date", "Arrival date cannot be in the past.")
+		} else if !endDate.After(startDate) {
+			form.Errors.Add("end_date", "Departure date must be after arrival date.")
+		}
+	}
+	CheckGuests(form)
+
+	// parse form's data to waitlist entry
+	entry := WaitlistEntry{}
+	if err = form.GetValue("room_id", &entry.RoomID); err != nil {
+		form.Errors.Add("room_id", "Invalid room!")
+	}
+
+	if !form.Valid() {
+		rooms, err := s.ListRooms(LimitRoomsPerPage, 0)
+		if err != nil {
+			sErr := ServerError{
+				Prompt: "Unable to load rooms from database.",
+				URL:    r.URL.Path,
+				Err:    err,
+			}
+			s.LogErrorAndRedirect(w, r, sErr, "/")
+			return
+		}
+
+		s.Render(w, r, "waitlist.page.gohtml",
+			&TemplateData{
+				Data: map[string]any{"rooms": rooms},
+				Form: form,
+			}, "/")
+		return
+	}
+
+	form.GetValue("first_name", &entry.FirstName)
+	form.GetValue("last_name", &entry.LastName)
+	form.GetValue("email", &entry.Email)
+	form.GetValue("start_date", &entry.StartDate)
+	form.GetValue("end_date", &entry.EndDate)
+	form.GetValue("adults", &entry.Adults)
+	form.GetValue("children", &entry.Children)
+	entry.Token = util.NewToken(HoldTokenLength)
+
+	// insert waitlist entry into database
+	err = s.CreateWaitlistEntry(entry)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to join the waitlist.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/waitlist")
+		return
+	}
+
+	app.Session.Put(r.Context(), "flash", "You have joined the waitlist. We will email you if a room becomes available.")
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+// WaitlistBookingHandler is the GET "/waitlist/book/{token}" page handler.
+// It starts the booking of the room offered to a waitlisted guest, which is held with the waitlist token.
+func (s *Server) WaitlistBookingHandler(w http.ResponseWriter, r *http.Request) {
+	entry, err := s.GetWaitlistEntryByToken(chi.URLParam(r, "token"))
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && !entry.IsOfferValid(time.Now())) {
+		app.Session.Put(r.Context(), "warning", "This booking link is invalid or has expired.")
+		http.Redirect(w, r, "/available-rooms-search", http.StatusSeeOther)
+		return
+	} else if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load waitlist entry.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/")
+		return
+	}
+
+	room, err := s.GetRoom(entry.OfferedRoomID)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load room from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/")
+		return
+	}
+
+	// load reservation to session data, using the waitlist token as the hold token of the room
+	app.Session.Put(r.Context(), "reservation", Reservation{
+		StartDate: entry.StartDate,
+		EndDate:   entry.EndDate,
+		RoomID:    room.ID,
+		Room:      room,
+		Adults:    entry.Adults,
+		Children:  entry.Children,
+	})
+	app.Session.Put(r.Context(), "hold_token", entry.Token)
+	app.Session.Remove(r.Context(), "rooms")
+	app.Session.Remove(r.Context(), "cart")
+
+	http.Redirect(w, r, "/make-reservation", http.StatusSeeOther)
+}
+
+// PostPaymentWebhookHandler is the POST "/payments/webhook" handler.
+// It updates the status of the payment transactions reported in a webhook signed by the payment provider.
+func (s *Server) PostPaymentWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxWebhookBytes))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	event, err := s.Payments.VerifyWebhook(payload, r.Header.Get(WebhookSignatureHeader))
+	if err != nil {
+		s.LogError(ServerError{
+			Prompt: "Invalid payment webhook.",
+			URL:    r.URL.Path,
+			Err:    err,
+		})
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	status := PaymentStatus(event.Status)
+	if status != PaymentPending && status != PaymentSucceeded && status != PaymentFailed {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	updated, err := s.UpdatePaymentStatus(s.Payments.Name(), event.TransactionID, status)
+	if err != nil {
+		s.LogError(ServerError{
+			Prompt: "Unable to update payment status.",
+			URL:    r.URL.Path,
+			Err:    err,
+		})
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	s.LogInfo(fmt.Sprintf("PAYMENT %d transactions of %s updated to %s", len(updated), event.TransactionID, status))
+	w.WriteHeader(http.StatusOK)
+}
+
+// LoginHandler is the GET "/user/login" page handler
+func (s *Server) LoginHandler(w http.ResponseWriter, r *http.Request) {
+	s.Render(w, r, "login.page.gohtml",
+		&TemplateData{Form: forms.New(nil)}, "/")
+}
+
+// PostLoginHandler is the POST "/user/login" page handler
+func (s *Server) PostLoginHandler(w http.ResponseWriter, r *http.Request) {
+	app.Session.RenewToken(r.Context())
+
+	err := r.ParseForm()
+	if err != nil {
+		sErr := CreateServerError(ErrorParseForm, r.URL.Path, err)
+		s.LogErrorAndRedirect(w, r, sErr, "/user/login")
+		return
+	}
+
+	// create a new form with data and validate the form
+	form := forms.New(r.PostForm)
+	form.TrimSpaces()
+	form.Required("email", "password")
+	form.CheckEmail("email")
+	form.CheckPassword("password")
+
+	if !form.Valid() {
+		s.Render(w, r, "login.page.gohtml",
+			&TemplateData{Form: form}, "/")
+		return
+	}
+
+	// authenticate the user
+	user, err := s.AuthenticateUser(form.Get("email"), form.Get("password"))
+	if err != nil {
+		form.Del("password")
+
+		s.LogInfo(fmt.Sprintf("Unsuccessful login with email %s", form.Get("email")))
+
+		s.Render(w, r, "login.page.gohtml",
+			&TemplateData{
+				Form:  forms.New(nil),
+				Error: "Invalid Email and/or Password. Please try again.",
+			}, "/")
+
+		return
+	}
+
+	app.Session.Put(r.Context(), "user_id", user.ID)
+	app.Session.Put(r.Context(), "access_level", int64(user.AccessLevel))
+	app.Session.Put(r.Context(), "flash", "Successfully logged in!")
+	s.LogInfo(fmt.Sprintf("Successful login by user %d", user.ID))
+
+	// redirecting to home page
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+// LogoutHandler is the GET "/user/logout" page handler
+func (s *Server) LogoutHandler(w http.ResponseWriter, r *http.Request) {
+	app.Session.Destroy(r.Context())
+	app.Session.RenewToken(r.Context())
+	app.Session.Put(r.Context(), "flash", "Successfully logged out!")
+
+	// redirecting to login page
+	http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+}
+
+// PasswordHandler is the GET "/user/password/{token}" page handler.
+// It shows a form for the staff user of the token mailed to set a password.
+func (s *Server) PasswordHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.getPasswordUser(w, r)
+	if !ok {
+		return
+	}
+
+	s.Render(w, r, "password.page.gohtml",
+		&TemplateData{
+			Data: map[string]any{"user": user},
+			Form: forms.New(nil),
+		}, "/user/login")
+}
+
+// PostPasswordHandler is the POST "/user/password/{token}" page handler.
+// It sets the password of the staff user of the token, which can then no longer be used.
+func (s *Server) PostPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.getPasswordUser(w, r)
+	if !ok {
+		return
+	}
+
+	err := r.ParseForm()
+	if err != nil {
+		sErr := CreateServerError(ErrorParseForm, r.URL.Path, err)
+		s.LogErrorAndRedirect(w, r, sErr, "/user/login")
+		return
+	}
+
+	// create a new form with data and validate the form
+	form := forms.New(r.PostForm)
+	form.Required("password", "confirm_password")
+	form.CheckPassword("password")
+	if form.Has("confirm_password") && form.Get("confirm_password") != form.Get("password") {
+		form.Errors.Add("confirm_password", "Passwords do not match!")
+	}
+
+	if !form.Valid() {
+		form.Del("password")
+		form.Del("confirm_password")
+
+		s.Render(w, r, "password.page.gohtml",
+			&TemplateData{
+				Data: map[string]any{"user": user},
+				Form: form,
+			}, "/user/login")
+		return
+	}
+
+	err = s.ChangeUserPassword(user.ID, form.Get("password"))
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to set password.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/user/login")
+		return
+	}
+
+	s.LogInfo(fmt.Sprintf("USER %s set a new password", user.Email))
+
+	app.Session.Put(r.Context(), "flash", "Your password was set. Please log in.")
+	http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+}
+
+// getPasswordUser returns the active staff user of the password token in the URL of r.
+// On an invalid or expired token, or on error, it redirects to the login page and returns ok as false.
+func (s *Server) getPasswordUser(w http.ResponseWriter, r *http.Request) (user User, ok bool) {
+	user, err := s.GetUserByPasswordToken(chi.URLParam(r, "token"))
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		sErr := ServerError{
+			Prompt: "Unable to load user from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/user/login")
+		return user, false
+	}
+
+	if err != nil || !user.IsActive() || !user.IsPasswordTokenValid(time.Now()) {
+		app.Session.Put(r.Context(), "error", "The link is invalid or has expired. Please ask the owner for a new link.")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return user, false
+	}
+
+	return user, true
+}
+
+// AdminDashboardHandler is the GET "/admin/dashboard" page handler
+func (s *Server) AdminDashboardHandler(w http.ResponseWriter, r *http.Request) {
+	s.Render(w, r, "dashbaord.panel.gohtml", &TemplateData{
+		Data: map[string]any{
+			"path": r.URL.Path,
+		},
+	}, "/")
+}
+
+// AdminReservationsHandler is the GET "/admin/reservations/{show}" page handler.
+// show is "new" for the pending reservations not processed yet, "all", or any reservation status.
+func (s *Server) AdminReservationsHandler(w http.ResponseWriter, r *http.Request) {
+	var rsvs []Reservation
+	var err error
+
+	param := chi.URLParam(r, "show")
+	switch {
+	case param == "all":
+		// load all reservations
+		//TODO: change the offset to request input
+		rsvs, err = s.ListReservations(LimitReservationsPerPage, 0)
+	case param == "new":
+		// load only new reservations
+		//TODO: change the offset to request input
+		rsvs, err = s.ListReservationsByStatus(ReservationPending, LimitReservationsPerPage, 0)
+	case slices.Contains(ReservationStatuses, ReservationStatus(param)):
+		// load reservations by status
+		//TODO: change the offset to request input
+		rsvs, err = s.ListReservationsByStatus(ReservationStatus(param), LimitReservationsPerPage, 0)
+	default:
+		sErr := CreateServerError(ErrorInvalidParameter, r.URL.Path, nil)
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/dashboard")
+		return
+	}
+
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load reservations from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/dashboard")
+		return
+	}
+
+	s.Render(w, r, "reservations.panel.gohtml",
+		&TemplateData{
+			Data: map[string]any{
+				"path":         r.URL.Path,
+				"show":         param,
+				"statuses":     ReservationStatuses[1:], // pending reservations are listed as new
+				"reservations": rsvs,
+			},
+		}, "/")
+}
+
+// AdminReservationHandler is the GET "/admin/reservations/{id}" page handler.
+// It shows all the data of a reservation, with a form to edit the guest details and the stay.
+func (s *Server) AdminReservationHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		sErr := CreateServerError(ErrorInvalidParameter, r.URL.Path, nil)
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/reservations/new")
+		return
+	}
+
+	rsv, ok := s.getAdminReservation(w, r, id)
+	if !ok {
+		return
+	}
+
+	form := forms.New(nil)
+	form.Set("first_name", rsv.FirstName)
+	form.Set("last_name", rsv.LastName)
+	form.Set("email", rsv.Email)
+	form.Set("phone", rsv.Phone)
+	form.Set("room_id", fmt.Sprint(rsv.RoomID))
+	form.Set("start_date", rsv.StartDate.Format(config.DateLayout))
+	form.Set("end_date", rsv.EndDate.Format(config.DateLayout))
+	form.Set("adults", fmt.Sprint(rsv.Adults))
+	form.Set("children", fmt.Sprint(rsv.Children))
+	form.Set("notes", rsv.Notes)
+
+	s.renderAdminReservation(w, r, rsv, form)
+}
+
+// PostAdminReservationHandler is the POST "/admin/reservations/{id}" page handler.
+// It updates the guest details and the stay of a reservation together with its room restriction.
+// If the room or dates change, the room is offered to the guests on the waitlist for the dates released.
+func (s *Server) PostAdminReservationHandler(w http.ResponseWriter, r *http.Request) {
+	id, _, ok := s.parseAdminReservationRequest(w, r)
+	if !ok {
+		return
+	}
+
+	rsv, ok := s.getAdminReservation(w, r, id)
+	if !ok {
+		return
+	}
+
+	rsvURL := fmt.Sprintf("/admin/reservations/%d", rsv.ID)
+
+	// create a new form with data and validate the form
+	form := forms.New(r.PostForm)
+	form.TrimSpaces()
+	form.Required("first_name", "last_name", "email", "room_id", "start_date", "end_date")
+	form.CheckEmail("email")
+	if form.CheckDateRange("start_date", "end_date") {
+		var startDate, endDate time.Time
+		form.GetValue("start_date", &startDate)
+		form.GetValue("end_date", &endDate)
+
+		if !endDate.After(startDate) {
+			form.Errors.Add("end_date", "Departure date must be after arrival date.")
+		}
+	}
+	CheckGuests(form)
+
+	var roomID int64
+	if form.GetValue("room_id", &roomID) != nil {
+		form.Errors.Add("room_id", "Invalid room!")
+	}
+
+	if !form.Valid() {
+		s.renderAdminReservation(w, r, rsv, form)
+		return
+	}
+
+	// parse form's data to reservation
+	updated := rsv
+	updated.RoomID = roomID
+	form.GetValue("first_name", &updated.FirstName)
+	form.GetValue("last_name", &updated.LastName)
+	form.GetValue("email", &updated.Email)
+	form.GetValue("start_date", &updated.StartDate)
+	form.GetValue("end_date", &updated.EndDate)
+	form.GetValue("adults", &updated.Adults)
+	form.GetValue("children", &updated.Children)
+	updated.Phone = form.Get("phone")
+	updated.Notes = form.Get("notes")
+
+	updated, err := s.UpdateReservation(updated)
+	if errors.Is(err, db.ErrRoomUnavailable) {
+		form.Errors.Add("room_id", "The room is unavailable on the dates selected or does not fit the guests.")
+		s.renderAdminReservation(w, r, rsv, form)
+		return
+	} else if errors.Is(err, db.ErrReservationCancelled) {
+		app.Session.Put(r.Context(), "warning", "Reservation is cancelled and can no longer be changed.")
+		http.Redirect(w, r, rsvURL, http.StatusSeeOther)
+		return
+	} else if errors.Is(err, db.ErrReservationClosed) {
+		app.Session.Put(r.Context(), "warning", "Reservation is checked out or a no show and can no longer be changed.")
+		http.Redirect(w, r, rsvURL, http.StatusSeeOther)
+		return
+	} else if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to update reservation.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, rsvURL)
+		return
+	}
+
+	userID := app.Session.GetInt64(r.Context(), "user_id")
+	s.LogInfo(fmt.Sprintf("UPDATE reservation %s updated by user %d", rsv.Code, userID))
+
+	// offer the room released to the guests on the waitlist
+	if updated.RoomID != rsv.RoomID || !updated.StartDate.Equal(rsv.StartDate) || !updated.EndDate.Equal(rsv.EndDate) {
+		s.OfferFreedRoom(rsv.RoomID, rsv.StartDate, rsv.EndDate)
+	}
+
+	msg := fmt.Sprintf("Reservation %s updated.", rsv.Code)
+	if updated.TotalPrice != rsv.TotalPrice {
+		msg = fmt.Sprintf("Reservation %s updated. The total price is now %s.", rsv.Code, updated.TotalPrice)
+	}
+
+	app.Session.Put(r.Context(), "flash", msg)
+	http.Redirect(w, r, rsvURL, http.StatusSeeOther)
+}
+
+// PostAdminReservationStatusHandler is the POST "/admin/reservations/{id}/status" page handler
+func (s *Server) PostAdminReservationStatusHandler(w http.ResponseWriter, r *http.Request) {
+	id, redirectURL, ok := s.parseAdminReservationRequest(w, r)
+	if !ok {
+		return
+	}
+
+	s.changeReservationStatus(w, r, id, ReservationStatus(r.PostForm.Get("status")), redirectURL)
+}
+
+// PostAdminCheckInHandler is the POST "/admin/reservations/{id}/check-in" page handler
+func (s *Server) PostAdminCheckInHandler(w http.ResponseWriter, r *http.Request) {
+	id, redirectURL, ok := s.parseAdminReservationRequest(w, r)
+	if !ok {
+		return
+	}
+
+	s.changeReservationStatus(w, r, id, ReservationCheckedIn, redirectURL)
+}
+
+// PostAdminCheckOutHandler is the POST "/admin/reservations/{id}/check-out" page handler.
+// A guest checking out before the departure date releases the remaining nights.
+// Guests cannot check out while they owe a balance on their folio,
+// while a credit left on the folio is flagged to staff for refund.
+func (s *Server) PostAdminCheckOutHandler(w http.ResponseWriter, r *http.Request) {
+	id, redirectURL, ok := s.parseAdminReservationRequest(w, r)
+	if !ok {
+		return
+	}
+
+	s.changeReservationStatus(w, r, id, ReservationCheckedOut, redirectURL)
+}
+
+// AdminFolioHandler is the GET "/admin/reservations/{id}/folio" page handler.
+// It shows the charges and payments posted to the folio of the reservation with their running balance.
+func (s *Server) AdminFolioHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		sErr := CreateServerError(ErrorInvalidParameter, r.URL.Path, nil)
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/reservations/new")
+		return
+	}
+
+	rsv, ok := s.getAdminReservation(w, r, id)
+	if !ok {
+		return
+	}
+
+	form := forms.New(nil)
+	form.Set("kind", string(FolioCharge))
+
+	s.renderAdminFolio(w, r, rsv, form)
+}
+
+// PostAdminFolioHandler is the POST "/admin/reservations/{id}/folio" page handler.
+// It posts a charge, such as the minibar or parking, or a payment to the folio of the reservation.
+func (s *Server) PostAdminFolioHandler(w http.ResponseWriter, r *http.Request) {
+	id, _, ok := s.parseAdminReservationRequest(w, r)
+	if !ok {
+		return
+	}
+
+	rsv, ok := s.getAdminReservation(w, r, id)
+	if !ok {
+		return
+	}
+
+	// create a new form with data and validate the form
+	form := forms.New(r.PostForm)
+	form.TrimSpaces()
+	form.Required("kind", "description", "amount")
+
+	kind := FolioEntryKind(form.Get("kind"))
+	if !slices.Contains(FolioEntryKinds, kind) {
+		form.Errors.Add("kind", "Invalid entry type!")
+	}
+
+	amount, err := ParsePrice(form.Get("amount"))
+	if err != nil || amount == 0 {
+		form.Errors.Add("amount", "Invalid amount. Please enter an amount such as 12 or 12.50.")
+	}
+
+	if !form.Valid() {
+		s.renderAdminFolio(w, r, rsv, form)
+		return
+	}
+
+	folioURL := fmt.Sprintf("/admin/reservations/%d/folio", rsv.ID)
+	userID := app.Session.GetInt64(r.Context(), "user_id")
+
+	entry, err := s.CreateFolioEntry(FolioEntry{
+		ReservationID: rsv.ID,
+		Kind:          kind,
+		Description:   form.Get("description"),
+		Amount:        amount,
+		UserID:        userID,
+	})
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to post folio entry.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, folioURL)
+		return
+	}
+
+	s.LogInfo(fmt.Sprintf("FOLIO %s %s %s posted to reservation %s by user %d",
+		string(entry.Kind), entry.Amount, entry.Description, rsv.Code, userID))
+
+	app.Session.Put(r.Context(), "flash", fmt.Sprintf("%s of %s posted.", entry.Kind, entry.Amount))
+	http.Redirect(w, r, folioURL, http.StatusSeeOther)
+}
+
+// PostAdminVoidFolioEntryHandler is the POST "/admin/reservations/{id}/folio/{entry}/void" page handler.
+// The entry voided stays in the folio but no longer counts in its balance.
+func (s *Server) PostAdminVoidFolioEntryHandler(w http.ResponseWriter, r *http.Request) {
+	id, _, ok := s.parseAdminReservationRequest(w, r)
+	if !ok {
+		return
+	}
+
+	folioURL := fmt.Sprintf("/admin/reservations/%d/folio", id)
+
+	entryID, err := strconv.ParseInt(chi.URLParam(r, "entry"), 10, 64)
+	if err != nil {
+		sErr := CreateServerError(ErrorInvalidParameter, r.URL.Path, nil)
+		s.LogErrorAndRedirect(w, r, sErr, folioURL)
+		return
+	}
+
+	userID := app.Session.GetInt64(r.Context(), "user_id")
+
+	entry, err := s.VoidFolioEntry(entryID, id, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		app.Session.Put(r.Context(), "warning", "Folio entry is already voided.")
+		http.Redirect(w, r, folioURL, http.StatusSeeOther)
+		return
+	} else if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to void folio entry.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, folioURL)
+		return
+	}
+
+	s.LogInfo(fmt.Sprintf("FOLIO %s %s %s of reservation %d voided by user %d",
+		string(entry.Kind), entry.Amount, entry.Description, id, userID))
+
+	app.Session.Put(r.Context(), "flash", fmt.Sprintf("%s of %s voided.", entry.Kind, entry.Amount))
+	http.Redirect(w, r, folioURL, http.StatusSeeOther)
+}
+
+// AdminRefundHandler is the GET "/admin/reservations/{id}/refund" page handler.
+// It shows the payments and refunds of the reservation, with a form prefilled with the amount refundable by the cancellation policy.
+func (s *Server) AdminRefundHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		sErr := CreateServerError(ErrorInvalidParameter, r.URL.Path, nil)
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/reservations/new")
+		return
+	}
+
+	rsv, ok := s.getAdminReservation(w, r, id)
+	if !ok {
+		return
+	}
+
+	s.renderAdminRefund(w, r, rsv, forms.New(nil))
+}
+
+// PostAdminRefundHandler is the POST "/admin/reservations/{id}/refund" page handler.
+// It refunds the amount entered through the payment provider. Overriding the policy amount requires a reason.
+func (s *Server) PostAdminRefundHandler(w http.ResponseWriter, r *http.Request) {
+	id, _, ok := s.parseAdminReservationRequest(w, r)
+	if !ok {
+		return
+	}
+
+	rsv, ok := s.getAdminReservation(w, r, id)
+	if !ok {
+		return
+	}
+
+	// create a new form with data and validate the form
+	form := forms.New(r.PostForm)
+	form.TrimSpaces()
+	form.Required("amount")
+
+	amount, err := ParsePrice(form.Get("amount"))
+	if err != nil || amount == 0 {
+		form.Errors.Add("amount", "Invalid amount. Please enter an amount such as 70 or 70.50.")
+	}
+
+	if !form.Valid() {
+		s.renderAdminRefund(w, r, rsv, form)
+		return
+	}
+
+	refundURL := fmt.Sprintf("/admin/reservations/%d/refund", rsv.ID)
+	userID := app.Session.GetInt64(r.Context(), "user_id")
+
+	refund, err := s.RefundReservation(rsv, amount, form.Get("reason"), userID)
+	switch {
+	case errors.Is(err, ErrRefundAmount):
+		form.Errors.Add("amount", "Amount cannot exceed the amount paid.")
+		s.renderAdminRefund(w, r, rsv, form)
+		return
+	case errors.Is(err, db.ErrRefundExceedsPaid):
+		// the payments were refunded concurrently, so the refund made through the provider is not recorded
+		s.LogError(ServerError{
+			Prompt: fmt.Sprintf("Unable to record refund of %s of reservation %s.", amount, rsv.Code),
+			URL:    r.URL.Path,
+			Err:    err,
+		})
+		app.Session.Put(r.Context(), "warning", "The payments of this reservation were refunded meanwhile. Please check the payments with the payment provider.")
+		http.Redirect(w, r, refundURL, http.StatusSeeOther)
+		return
+	case errors.Is(err, ErrRefundReason):
+		form.Errors.Add("reason", "Please give a reason for refunding an amount other than the policy amount.")
+		s.renderAdminRefund(w, r, rsv, form)
+		return
+	case err != nil && refund.Amount > 0:
+		// part of the refund went through and was recorded
+		s.LogError(ServerError{
+			Prompt: fmt.Sprintf("Unable to refund %s of reservation %s in full.", amount, rsv.Code),
+			URL:    r.URL.Path,
+			Err:    err,
+		})
+		app.Session.Put(r.Context(), "warning", fmt.Sprintf("Only %s of %s was refunded. Please check the payments with the payment provider.", refund.Amount, amount))
+		http.Redirect(w, r, refundURL, http.StatusSeeOther)
+		return
+	case err != nil:
+		sErr := ServerError{
+			Prompt: "Unable to refund reservation.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, refundURL)
+		return
+	}
+
+	app.Session.Put(r.Context(), "flash", fmt.Sprintf("Refunded %s of reservation %s.", refund.Amount, rsv.Code))
+	http.Redirect(w, r, refundURL, http.StatusSeeOther)
+}
+
+// AdminInvoiceHandler is the GET "/admin/reservations/{id}/invoice" page handler.
+// It downloads the invoice of the reservation. Invoices are only issued by PostAdminInvoiceHandler.
+func (s *Server) AdminInvoiceHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		sErr := CreateServerError(ErrorInvalidParameter, r.URL.Path, nil)
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/reservations/new")
+		return
+	}
+
+	rsv, ok := s.getAdminReservation(w, r, id)
+	if !ok {
+		return
+	}
+
+	invoice, err := s.GetInvoiceByReservation(rsv.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		app.Session.Put(r.Context(), "warning", fmt.Sprintf("The invoice of reservation %s is not issued yet.", rsv.Code))
+		http.Redirect(w, r, fmt.Sprintf("/admin/reservations/%d", rsv.ID), http.StatusSeeOther)
+		return
+	}
+	if err == nil && invoice.Document == "" {
+		invoice, err = s.RenderInvoice(invoice, rsv)
+	}
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load invoice.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/reservations/new")
+		return
+	}
+
+	s.WriteInvoice(w, invoice)
+}
+
+// PostAdminInvoiceHandler is the POST "/admin/reservations/{id}/invoice" page handler.
+// It issues the invoice of the reservation if it has none yet, or renders it again with its current charges and payments,
+// keeping the invoice number.
+func (s *Server) PostAdminInvoiceHandler(w http.ResponseWriter, r *http.Request) {
+	id, redirectURL, ok := s.parseAdminReservationRequest(w, r)
+	if !ok {
+		return
+	}
+
+	rsv, ok := s.getAdminReservation(w, r, id)
+	if !ok {
+		return
+	}
+
+	invoice, err := s.GetInvoice(rsv, true)
+	if errors.Is(err, ErrNotInvoiceable) {
+		app.Session.Put(r.Context(), "warning", fmt.Sprintf("Reservation %s is pending or cancelled and cannot be invoiced.", rsv.Code))
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	} else if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to issue invoice.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, redirectURL)
+		return
+	}
+
+	s.LogInfo(fmt.Sprintf("INVOICE %s of reservation %s issued", invoice.InvoiceNumber(), rsv.Code))
+
+	app.Session.Put(r.Context(), "flash", fmt.Sprintf("Invoice %s issued.", invoice.InvoiceNumber()))
+	http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+}
+
+// PostAdminSendInvoiceHandler is the POST "/admin/reservations/{id}/invoice/send" page handler.
+// It emails the invoice of the reservation to the guest as an attachment.
+func (s *Server) PostAdminSendInvoiceHandler(w http.ResponseWriter, r *http.Request) {
+	id, redirectURL, ok := s.parseAdminReservationRequest(w, r)
+	if !ok {
+		return
+	}
+
+	rsv, ok := s.getAdminReservation(w, r, id)
+	if !ok {
+		return
+	}
+
+	invoice, err := s.GetInvoice(rsv, false)
+	if errors.Is(err, ErrNotInvoiceable) {
+		app.Session.Put(r.Context(), "warning", fmt.Sprintf("Reservation %s is pending or cancelled and cannot be invoiced.", rsv.Code))
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	} else if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to create invoice.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, redirectURL)
+		return
+	}
+
+	data, err := s.Renderer.CreateInvoiceMail(invoice, rsv)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to render invoice email.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, redirectURL)
+		return
+	}
+
+	// send invoice email to guest and log
+	s.SendMail(data)
+	s.LogInfo(fmt.Sprintf("MAIL invoice %s sent to %s", invoice.InvoiceNumber(), data.To))
+
+	app.Session.Put(r.Context(), "flash", fmt.Sprintf("Invoice %s sent to %s.", invoice.InvoiceNumber(), data.To))
+	http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+}
+
+// getAdminReservation returns the reservation with id including its room.
+// On error, it logs and redirects to the reservations panel, and returns ok as false.
+func (s *Server) getAdminReservation(w http.ResponseWriter, r *http.Request, id int64) (rsv Reservation, ok bool) {
+	rsv, err := s.GetReservation(id)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load reservation from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/reservations/new")
+		return rsv, false
+	}
+
+	return rsv, true
+}
+
+// renderAdminReservation renders the reservation panel of rsv with its taxes and fees, room restrictions and form
+func (s *Server) renderAdminReservation(w http.ResponseWriter, r *http.Request, rsv Reservation, form *forms.Form) {
+	charges, err := s.ListReservationCharges(rsv.ID)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load taxes and fees from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/reservations/new")
+		return
+	}
+	rsv.Charges = charges
+
+	restrictions, err := s.ListRoomRestrictions(rsv.ID)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load room restrictions from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/reservations/new")
+		return
+	}
+
+	rooms, err := s.ListRooms(LimitRoomsPerPage, 0)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load rooms from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/reservations/new")
+		return
+	}
+
+	s.Render(w, r, "reservation.panel.gohtml",
+		&TemplateData{
+			Data: map[string]any{
+				"path":         "/admin/reservations",
+				"reservation":  rsv,
+				"restrictions": restrictions,
+				"rooms":        rooms,
+				"nights":       rsv.Nights(),
+			},
+			Form: form,
+		}, "/admin/reservations/new")
+}
+
+// renderAdminRefund renders the refund panel of rsv with its payments, refunds and form.
+// The amount of a new form is set to the amount refundable by the cancellation policy.
+func (s *Server) renderAdminRefund(w http.ResponseWriter, r *http.Request, rsv Reservation, form *forms.Form) {
+	pays, err := s.ListPayments(rsv.ID)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load payments from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/reservations/new")
+		return
+	}
+
+	refunds, err := s.ListRefunds(rsv.ID)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load refunds from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/reservations/new")
+		return
+	}
+
+	paid := PaidAmount(pays)
+	policyAmount := rsv.RefundableAmount(paid)
+	if r.Method == http.MethodGet {
+		form.Set("amount", policyAmount.Amount())
+	}
+
+	s.Render(w, r, "refund.panel.gohtml",
+		&TemplateData{
+			Data: map[string]any{
+				"path":          "/admin/reservations",
+				"reservation":   rsv,
+				"payments":      pays,
+				"refunds":       refunds,
+				"paid":          paid,
+				"policy_amount": policyAmount,
+			},
+			Form: form,
+		}, "/admin/reservations/new")
+}
+
+// AdminTodayHandler is the GET "/admin/today" page handler.
+// It lists the arrivals and departures expected today for the front desk.
+func (s *Server) AdminTodayHandler(w http.ResponseWriter, r *http.Request) {
+	today := Today()
+
+	arrivals, err := s.ListArrivals(today)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load arrivals from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/dashboard")
+		return
+	}
+
+	departures, err := s.ListDepartures(today)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load departures from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/dashboard")
+		return
+	}
+
+	s.Render(w, r, "today.panel.gohtml",
+		&TemplateData{
+			Data: map[string]any{
+				"path":       r.URL.Path,
+				"today":      today.Format(config.DateLayout),
+				"arrivals":   arrivals,
+				"departures": departures,
+			},
+		}, "/")
+}
+
+// AdminCalendarHandler is the GET "/admin/calendar" page handler.
+// It shows the reservations and owner blocks of every room on every night of the month of the "month" query parameter,
+// such as 2024-06, or of the current month if none.
+func (s *Server) AdminCalendarHandler(w http.ResponseWriter, r *http.Request) {
+	month := FirstOfMonth(Today())
+	if param := r.URL.Query().Get("month"); param != "" {
+		date, err := time.Parse(config.MonthLayout, param)
+		if err != nil {
+			sErr := CreateServerError(ErrorInvalidParameter, r.URL.Path, err)
+			s.LogErrorAndRedirect(w, r, sErr, "/admin/calendar")
+			return
+		}
+		month = date
+	}
+
+	rooms, err := s.ListRooms(LimitRoomsPerPage, 0)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load rooms from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/dashboard")
+		return
+	}
+
+	restrictions, err := s.ListRoomRestrictionsForPeriod(month, month.AddDate(0, 1, 0))
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load room restrictions from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/dashboard")
+		return
+	}
+
+	s.Render(w, r, "calendar.panel.gohtml",
+		&TemplateData{
+			Data: map[string]any{
+				"path":     r.URL.Path,
+				"today":    Today(),
+				"calendar": NewCalendar(month, rooms, restrictions),
+			},
+		}, "/admin/dashboard")
+}
+
+// CheckboxOption holds a checkbox of a multiple choice form field, or an option of a select form field
+type CheckboxOption struct {
+	Value   string
+	Label   string
+	Checked bool
+}
+
+// AdminRoomRatesHandler is the GET "/admin/rates" page handler.
+// It lists the room rates that have not ended yet, and the form to create room rates for several rooms at once.
+func (s *Server) AdminRoomRatesHandler(w http.ResponseWriter, r *http.Request) {
+	form := forms.New(nil)
+	form.Set("priority", "0")
+
+	s.renderAdminRoomRates(w, r, form)
+}
+
+// PostAdminRoomRatesHandler is the POST "/admin/rates" page handler.
+// It creates the same room rate for every room selected.
+func (s *Server) PostAdminRoomRatesHandler(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		sErr := CreateServerError(ErrorParseForm, r.URL.Path, err)
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/rates")
+		return
+	}
+
+	// create a new form with data and validate the form
+	form := forms.New(r.PostForm)
+	form.TrimSpaces()
+	form.Required("start_date", "end_date", "rate", "priority")
+	form.CheckDateRange("start_date", "end_date")
+	form.CheckIntRange("priority", -1000, 1000)
+
+	rate, err := ParsePrice(form.Get("rate"))
+	if err != nil {
+		form.Errors.Add("rate", "Invalid rate. Please enter an amount such as 150 or 149.90.")
+	}
+
+	roomIDs := make([]int64, 0, len(form.Values["room_id"]))
+	for _, v := range form.Values["room_id"] {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			form.Errors.Add("room_id", "Invalid room!")
+			break
+		}
+		roomIDs = append(roomIDs, id)
+	}
+	if len(roomIDs) == 0 {
+		form.Errors.Add("room_id", "Select at least one room.")
+	}
+
+	var days []time.Weekday
+	for _, v := range form.Values["days"] {
+		day, err := strconv.Atoi(v)
+		if err != nil || day < int(time.Sunday) || day > int(time.Saturday) {
+			form.Errors.Add("days", "Invalid day of the week!")
+			break
+		}
+		days = append(days, time.Weekday(day))
+	}
+	if len(days) == 0 {
+		form.Errors.Add("days", "Select at least one day of the week.")
+	}
+
+	if !form.Valid() {
+		s.renderAdminRoomRates(w, r, form)
+		return
+	}
+
+	// parse form's data to room rates
+	rr := RoomRate{
+		DaysOfWeek: int(db.DaysOfWeekMask(days...)),
+		Rate:       rate,
+	}
+	form.GetValue("name", &rr.Name)
+	form.GetValue("start_date", &rr.StartDate)
+	form.GetValue("end_date", &rr.EndDate)
+	form.GetValue("priority", &rr.Priority)
+
+	rates := make([]RoomRate, len(roomIDs))
+	for i, id := range roomIDs {
+		rates[i] = rr
+		rates[i].RoomID = id
+	}
+
+	err = s.CreateRoomRates(rates)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to create room rates.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/rates")
+		return
+	}
+
+	app.Session.Put(r.Context(), "flash", fmt.Sprintf("%d room rates created.", len(rates)))
+	http.Redirect(w, r, "/admin/rates", http.StatusSeeOther)
+}
+
+// PostAdminDeleteRoomRatesHandler is the POST "/admin/rates/delete" page handler.
+// It deletes the room rates selected.
+func (s *Server) PostAdminDeleteRoomRatesHandler(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		sErr := CreateServerError(ErrorParseForm, r.URL.Path, err)
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/rates")
+		return
+	}
+
+	ids := make([]int64, len(r.PostForm["rate_id"]))
+	for i, v := range r.PostForm["rate_id"] {
+		ids[i], err = strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			sErr := CreateServerError(ErrorInvalidParameter, r.URL.Path, err)
+			s.LogErrorAndRedirect(w, r, sErr, "/admin/rates")
+			return
+		}
+	}
+
+	if len(ids) == 0 {
+		app.Session.Put(r.Context(), "warning", "No room rates selected.")
+		http.Redirect(w, r, "/admin/rates", http.StatusSeeOther)
+		return
+	}
+
+	err = s.DeleteRoomRates(ids)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to delete room rates.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/rates")
+		return
+	}
+
+	app.Session.Put(r.Context(), "flash", fmt.Sprintf("%d room rates deleted.", len(ids)))
+	http.Redirect(w, r, "/admin/rates", http.StatusSeeOther)
+}
+
+// renderAdminRoomRates renders the room rates panel with the room rates that have not ended yet and form
+func (s *Server) renderAdminRoomRates(w http.ResponseWriter, r *http.Request, form *forms.Form) {
+	rates, err := s.ListRoomRates(Today(), LimitRoomRatesPerPage, 0)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load room rates from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/dashboard")
+		return
+	}
+
+	rooms, err := s.ListRooms(LimitRoomsPerPage, 0)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load rooms from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/dashboard")
+		return
+	}
+
+	// a new form selects all rooms and days of the week
+	isNew := len(form.Values["room_id"]) == 0 && len(form.Values["days"]) == 0 && form.Valid()
+
+	roomOptions := make([]CheckboxOption, len(rooms))
+	for i, room := range rooms {
+		value := strconv.FormatInt(room.ID, 10)
+		roomOptions[i] = CheckboxOption{
+			Value:   value,
+			Label:   room.Name,
+			Checked: isNew || slices.Contains(form.Values["room_id"], value),
+		}
+	}
+
+	dayOptions := make([]CheckboxOption, 0, 7)
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		value := strconv.Itoa(int(day))
+		dayOptions = append(dayOptions, CheckboxOption{
+			Value:   value,
+			Label:   day.String()[:3],
+			Checked: isNew || slices.Contains(form.Values["days"], value),
+		})
+	}
+
+	s.Render(w, r, "rates.panel.gohtml",
+		&TemplateData{
+			Data: map[string]any{
+				"path":     r.URL.Path,
+				"rates":    rates,
+				"rooms":    roomOptions,
+				"weekdays": dayOptions,
+			},
+			Form: form,
+		}, "/admin/dashboard")
+}
+
+// AdminOwnerBlocksHandler is the GET "/admin/blocks" page handler
+func (s *Server) AdminOwnerBlocksHandler(w http.ResponseWriter, r *http.Request) {
+	s.renderAdminOwnerBlocks(w, r, forms.New(nil))
+}
+
+// PostAdminOwnerBlocksHandler is the POST "/admin/blocks" page handler.
+// It blocks every room selected for the owner over the same nights.
+// Rooms reserved on some of the nights are not blocked unless the user overrides.
+func (s *Server) PostAdminOwnerBlocksHandler(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		sErr := CreateServerError(ErrorParseForm, r.URL.Path, err)
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/blocks")
+		return
+	}
+
+	// create a new form with data and validate the form
+	form := forms.New(r.PostForm)
+	form.TrimSpaces()
+	CheckOwnerBlockDates(form)
+
+	roomIDs := make([]int64, 0, len(form.Values["room_id"]))
+	for _, v := range form.Values["room_id"] {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			form.Errors.Add("room_id", "Invalid room!")
+			break
+		}
+		roomIDs = append(roomIDs, id)
+	}
+	if len(roomIDs) == 0 {
+		form.Errors.Add("room_id", "Select at least one room.")
+	}
+
+	if !form.Valid() {
+		s.renderAdminOwnerBlocks(w, r, form)
+		return
+	}
+
+	// parse form's data to owner block
+	block := RoomRestriction{Reason: form.Get("reason")}
+	form.GetValue("start_date", &block.StartDate)
+	form.GetValue("end_date", &block.EndDate)
+	override := form.Has("override")
+
+	err = s.CreateOwnerBlocks(block, roomIDs, override)
+	if errors.Is(err, db.ErrOwnerBlockConflict) {
+		form.Errors.Add("override", OwnerBlockConflictMessage)
+		s.renderAdminOwnerBlocks(w, r, form)
+		return
+	} else if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to create owner blocks.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/blocks")
+		return
+	}
+
+	userID := app.Session.GetInt64(r.Context(), "user_id")
+	s.LogInfo(fmt.Sprintf("BLOCK %d rooms blocked from %s to %s by user %d%s", len(roomIDs),
+		block.StartDate.Format(config.DateLayout), block.EndDate.Format(config.DateLayout), userID, overrideNote(override)))
+
+	app.Session.Put(r.Context(), "flash", fmt.Sprintf("%d owner blocks created.", len(roomIDs)))
+	http.Redirect(w, r, "/admin/blocks", http.StatusSeeOther)
+}
+
+// PostAdminDeleteOwnerBlocksHandler is the POST "/admin/blocks/delete" page handler.
+// It deletes the owner blocks selected, and offers the rooms released to the guests on the waitlist.
+func (s *Server) PostAdminDeleteOwnerBlocksHandler(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		sErr := CreateServerError(ErrorParseForm, r.URL.Path, err)
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/blocks")
+		return
+	}
+
+	ids := make([]int64, len(r.PostForm["block_id"]))
+	for i, v := range r.PostForm["block_id"] {
+		ids[i], err = strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			sErr := CreateServerError(ErrorInvalidParameter, r.URL.Path, err)
+			s.LogErrorAndRedirect(w, r, sErr, "/admin/blocks")
+			return
+		}
+	}
+
+	if len(ids) == 0 {
+		app.Session.Put(r.Context(), "warning", "No owner blocks selected.")
+		http.Redirect(w, r, "/admin/blocks", http.StatusSeeOther)
+		return
+	}
+
+	blocks, err := s.DeleteOwnerBlocks(ids)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to delete owner blocks.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/blocks")
+		return
+	}
+
+	userID := app.Session.GetInt64(r.Context(), "user_id")
+	s.LogInfo(fmt.Sprintf("BLOCK %d owner blocks deleted by user %d", len(blocks), userID))
+
+	for _, block := range blocks {
+		s.OfferFreedRoom(block.RoomID, block.StartDate, block.EndDate)
+	}
+
+	app.Session.Put(r.Context(), "flash", fmt.Sprintf("%d owner blocks deleted.", len(blocks)))
+	http.Redirect(w, r, "/admin/blocks", http.StatusSeeOther)
+}
+
+// AdminOwnerBlockHandler is the GET "/admin/blocks/{id}" page handler.
+// It shows a form to edit the room, nights and reason of an owner block.
+func (s *Server) AdminOwnerBlockHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		sErr := CreateServerError(ErrorInvalidParameter, r.URL.Path, nil)
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/blocks")
+		return
+	}
+
+	block, ok := s.getAdminOwnerBlock(w, r, id)
+	if !ok {
+		return
+	}
+
+	form := forms.New(nil)
+	form.Set("room_id", fmt.Sprint(block.RoomID))
+	form.Set("start_date", block.StartDate.Format(config.DateLayout))
+	form.Set("end_date", block.EndDate.Format(config.DateLayout))
+	form.Set("reason", block.Reason)
+
+	s.renderAdminOwnerBlock(w, r, block, form)
+}
+
+// PostAdminOwnerBlockHandler is the POST "/admin/blocks/{id}" page handler.
+// It updates the room, nights and reason of an owner block.
+// The room is not blocked over reserved nights unless the user overrides,
+// and the nights released are offered to the guests on the waitlist.
+func (s *Server) PostAdminOwnerBlockHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		sErr := CreateServerError(ErrorInvalidParameter, r.URL.Path, nil)
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/blocks")
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		sErr := CreateServerError(ErrorParseForm, r.URL.Path, err)
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/blocks")
+		return
+	}
+
+	block, ok := s.getAdminOwnerBlock(w, r, id)
+	if !ok {
+		return
+	}
+
+	// create a new form with data and validate the form
+	form := forms.New(r.PostForm)
+	form.TrimSpaces()
+	form.Required("room_id")
+	CheckOwnerBlockDates(form)
+
+	var roomID int64
+	if form.GetValue("room_id", &roomID) != nil {
+		form.Errors.Add("room_id", "Invalid room!")
+	}
+
+	if !form.Valid() {
+		s.renderAdminOwnerBlock(w, r, block, form)
+		return
+	}
+
+	// parse form's data to owner block
+	updated := block
+	updated.RoomID = roomID
+	updated.Reason = form.Get("reason")
+	form.GetValue("start_date", &updated.StartDate)
+	form.GetValue("end_date", &updated.EndDate)
+	override := form.Has("override")
+
+	updated, err = s.UpdateOwnerBlock(updated, override)
+	if errors.Is(err, db.ErrOwnerBlockConflict) {
+		form.Errors.Add("override", OwnerBlockConflictMessage)
+		s.renderAdminOwnerBlock(w, r, block, form)
+		return
+	} else if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to update owner block.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/blocks")
+		return
+	}
+
+	userID := app.Session.GetInt64(r.Context(), "user_id")
+	s.LogInfo(fmt.Sprintf("BLOCK owner block %d updated by user %d%s", block.ID, userID, overrideNote(override)))
+
+	// offer the nights released to the guests on the waitlist
+	if updated.RoomID != block.RoomID || !updated.StartDate.Equal(block.StartDate) || !updated.EndDate.Equal(block.EndDate) {
+		s.OfferFreedRoom(block.RoomID, block.StartDate, block.EndDate)
+	}
+
+	app.Session.Put(r.Context(), "flash", "Owner block updated.")
+	http.Redirect(w, r, "/admin/blocks", http.StatusSeeOther)
+}
+
+// getAdminOwnerBlock returns the owner block with id.
+// On error, it logs and redirects to the owner blocks panel, and returns ok as false.
+func (s *Server) getAdminOwnerBlock(w http.ResponseWriter, r *http.Request, id int64) (block RoomRestriction, ok bool) {
+	block, err := s.GetOwnerBlock(id)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load owner block from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/blocks")
+		return block, false
+	}
+
+	return block, true
+}
+
+// renderAdminOwnerBlocks renders the owner blocks panel with the owner blocks that have not ended yet and form
+func (s *Server) renderAdminOwnerBlocks(w http.ResponseWriter, r *http.Request, form *forms.Form) {
+	blocks, err := s.ListOwnerBlocks(Today(), LimitOwnerBlocksPerPage, 0)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load owner blocks from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/dashboard")
+		return
+	}
+
+	rooms, err := s.ListRooms(LimitRoomsPerPage, 0)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load rooms from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/dashboard")
+		return
+	}
+
+	roomOptions := make([]CheckboxOption, len(rooms))
+	for i, room := range rooms {
+		value := strconv.FormatInt(room.ID, 10)
+		roomOptions[i] = CheckboxOption{
+			Value:   value,
+			Label:   room.Name,
+			Checked: slices.Contains(form.Values["room_id"], value),
+		}
+	}
+
+	s.Render(w, r, "blocks.panel.gohtml",
+		&TemplateData{
+			Data: map[string]any{
+				"path":   "/admin/blocks",
+				"blocks": blocks,
+				"rooms":  roomOptions,
+			},
+			Form: form,
+		}, "/admin/dashboard")
+}
+
+// renderAdminOwnerBlock renders the owner block panel of block with form
+func (s *Server) renderAdminOwnerBlock(w http.ResponseWriter, r *http.Request, block RoomRestriction, form *forms.Form) {
+	rooms, err := s.ListRooms(LimitRoomsPerPage, 0)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load rooms from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/blocks")
+		return
+	}
+
+	s.Render(w, r, "block.panel.gohtml",
+		&TemplateData{
+			Data: map[string]any{
+				"path":  "/admin/blocks",
+				"block": block,
+				"rooms": rooms,
+			},
+			Form: form,
+		}, "/admin/blocks")
+}
+
+// AdminRoomsHandler is the GET "/admin/rooms" page handler
+func (s *Server) AdminRoomsHandler(w http.ResponseWriter, r *http.Request) {
+	rooms, err := s.ListRooms(LimitRoomsPerPage, 0)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load rooms from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/dashboard")
+		return
+	}
+
+	s.Render(w, r, "rooms.panel.gohtml",
+		&TemplateData{
+			Data: map[string]any{
+				"path":  "/admin/rooms",
+				"rooms": rooms,
+			},
+		}, "/admin/dashboard")
+}
+
+// AdminNewRoomHandler is the GET "/admin/rooms/new" page handler
+func (s *Server) AdminNewRoomHandler(w http.ResponseWriter, r *http.Request) {
+	form := forms.New(nil)
+	form.Set("max_adults", "2")
+	form.Set("max_children", "0")
+	form.Set("max_occupancy", "2")
+
+	s.renderAdminRoom(w, r, Room{}, form)
+}
+
+// PostAdminRoomsHandler is the POST "/admin/rooms" page handler.
+// It creates a room with the image uploaded, which is saved to the static images directory.
+func (s *Server) PostAdminRoomsHandler(w http.ResponseWriter, r *http.Request) {
+	room, image, ok := s.parseAdminRoomForm(w, r, Room{})
+	if !ok {
+		return
+	}
+
+	var err error
+	room.ImageFilename, err = saveRoomImage(room.Slug, image)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to save room image.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/rooms")
+		return
+	}
+
+	room, err = s.CreateRoom(room)
+	if err != nil {
+		s.removeRoomImage(room.ImageFilename)
+
+		sErr := ServerError{
+			Prompt: "Unable to create room.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/rooms")
+		return
+	}
+
+	userID := app.Session.GetInt64(r.Context(), "user_id")
+	s.LogInfo(fmt.Sprintf("ROOM %s created by user %d", room.Name, userID))
+
+	app.Session.Put(r.Context(), "flash", fmt.Sprintf("Room %s created.", room.Name))
+	http.Redirect(w, r, "/admin/rooms", http.StatusSeeOther)
+}
+
+// AdminRoomHandler is the GET "/admin/rooms/{id}" page handler.
+// It shows a form to edit the room.
+func (s *Server) AdminRoomHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		sErr := CreateServerError(ErrorInvalidParameter, r.URL.Path, nil)
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/rooms")
+		return
+	}
+
+	room, ok := s.getAdminRoom(w, r, id)
+	if !ok {
+		return
+	}
+
+	form := forms.New(nil)
+	form.Set("name", room.Name)
+	form.Set("description", room.Description)
+	form.Set("max_adults", fmt.Sprint(room.MaxAdults))
+	form.Set("max_children", fmt.Sprint(room.MaxChildren))
+	form.Set("max_occupancy", fmt.Sprint(room.MaxOccupancy))
+	form.Set("nightly_rate", formatAmount("", room.NightlyRate))
+
+	s.renderAdminRoom(w, r, room, form)
+}
+
+// PostAdminRoomHandler is the POST "/admin/rooms/{id}" page handler.
+// It updates the room, and replaces its image if a new image was uploaded.
+// Previous images are kept, as they are linked from the mails already sent to guests.
+func (s *Server) PostAdminRoomHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		sErr := CreateServerError(ErrorInvalidParameter, r.URL.Path, nil)
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/rooms")
+		return
+	}
+
+	room, ok := s.getAdminRoom(w, r, id)
+	if !ok {
+		return
+	}
+
+	updated, image, ok := s.parseAdminRoomForm(w, r, room)
+	if !ok {
+		return
+	}
+
+	if len(image) > 0 {
+		updated.ImageFilename, err = saveRoomImage(updated.Slug, image)
+		if err != nil {
+			sErr := ServerError{
+				Prompt: "Unable to save room image.",
+				URL:    r.URL.Path,
+				Err:    err,
+			}
+			s.LogErrorAndRedirect(w, r, sErr, "/admin/rooms")
+			return
+		}
+	}
+
+	err = s.UpdateRoom(updated)
+	if err != nil {
+		if updated.ImageFilename != room.ImageFilename {
+			s.removeRoomImage(updated.ImageFilename)
+		}
+
+		sErr := ServerError{
+			Prompt: "Unable to update room.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/rooms")
+		return
+	}
+
+	userID := app.Session.GetInt64(r.Context(), "user_id")
+	s.LogInfo(fmt.Sprintf("ROOM %s updated by user %d", updated.Name, userID))
+
+	app.Session.Put(r.Context(), "flash", fmt.Sprintf("Room %s updated.", updated.Name))
+	http.Redirect(w, r, "/admin/rooms", http.StatusSeeOther)
+}
+
+// PostAdminDeleteRoomHandler is the POST "/admin/rooms/{id}/delete" page handler.
+// Rooms with reservations are not deleted, as the reservations are kept for the accounts.
+func (s *Server) PostAdminDeleteRoomHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		sErr := CreateServerError(ErrorInvalidParameter, r.URL.Path, nil)
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/rooms")
+		return
+	}
+
+	room, err := s.DeleteRoom(id)
+	if errors.Is(err, db.ErrRoomHasReservations) {
+		app.Session.Put(r.Context(), "warning", "The room has reservations and cannot be deleted.")
+		http.Redirect(w, r, "/admin/rooms", http.StatusSeeOther)
+		return
+	} else if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to delete room.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/rooms")
+		return
+	}
+
+	userID := app.Session.GetInt64(r.Context(), "user_id")
+	s.LogInfo(fmt.Sprintf("ROOM %s deleted by user %d", room.Name, userID))
+
+	app.Session.Put(r.Context(), "flash", fmt.Sprintf("Room %s deleted.", room.Name))
+	http.Redirect(w, r, "/admin/rooms", http.StatusSeeOther)
+}
+
+// parseAdminRoomForm parses and validates the multipart room form of r, including the room image.
+// It returns room updated with the form data, and the image uploaded, which is empty if no image was uploaded.
+// An image must be uploaded for a new room. An invalid form is rendered again with the errors.
+// On error, it logs and redirects, and returns ok as false.
+func (s *Server) parseAdminRoomForm(w http.ResponseWriter, r *http.Request, room Room) (updated Room, image []byte, ok bool) {
+	roomURL := "/admin/rooms/new"
+	if room.ID != 0 {
+		roomURL = fmt.Sprintf("/admin/rooms/%d", room.ID)
+	}
+
+	// limit the request to the image and a generous allowance for the other fields
+	r.Body = http.MaxBytesReader(w, r.Body, 2*MaxRoomImageSize)
+	err := r.ParseMultipartForm(MaxRoomImageSize)
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		app.Session.Put(r.Context(), "warning", fmt.Sprintf("The image is too large. Please upload an image of up to %d MB.", MaxRoomImageSize>>20))
+		http.Redirect(w, r, roomURL, http.StatusSeeOther)
+		return room, nil, false
+	} else if err != nil {
+		sErr := CreateServerError(ErrorParseForm, r.URL.Path, err)
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/rooms")
+		return room, nil, false
+	}
+
+	// create a new form with data and validate the form
+	form := forms.New(r.PostForm)
+	form.TrimSpaces()
+	form.Required("name", "description", "max_adults", "max_children", "max_occupancy", "nightly_rate")
+	ok = form.CheckIntRange("max_adults", 1, MaxAdults)
+	ok = form.CheckIntRange("max_children", 0, MaxChildren) && ok
+	ok = form.CheckIntRange("max_occupancy", 1, MaxAdults+MaxChildren) && ok
+
+	updated = room
+	form.GetValue("max_adults", &updated.MaxAdults)
+	form.GetValue("max_children", &updated.MaxChildren)
+	form.GetValue("max_occupancy", &updated.MaxOccupancy)
+	if ok && updated.MaxOccupancy > updated.MaxAdults+updated.MaxChildren {
+		form.Errors.Add("max_occupancy", "Maximum occupancy cannot exceed the maximum adults and children together.")
+	}
+
+	updated.NightlyRate, err = ParsePrice(form.Get("nightly_rate"))
+	if err != nil {
+		form.Errors.Add("nightly_rate", "Invalid rate. Please enter an amount such as 150 or 149.90.")
+	}
+
+	updated.Name = form.Get("name")
+	updated.Description = form.Get("description")
+	updated.Slug = util.Slugify(updated.Name)
+	if form.Has("name") && updated.Slug == "" {
+		form.Errors.Add("name", "Room name must contain letters or digits.")
+	}
+
+	image = readRoomImage(r, form, room.ID == 0)
+
+	if !form.Valid() {
+		s.renderAdminRoom(w, r, room, form)
+		return room, nil, false
+	}
+
+	// room slugs are unique, as they are used in the room page URL
+	existing, err := s.GetRoomBySlug(updated.Slug)
+	if err == nil && existing.ID != room.ID {
+		form.Errors.Add("name", "Another room has the same name. Please choose another name.")
+		s.renderAdminRoom(w, r, room, form)
+		return room, nil, false
+	} else if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		sErr := ServerError{
+			Prompt: "Unable to load room from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/rooms")
+		return room, nil, false
+	}
+
+	return updated, image, true
+}
+
+// readRoomImage returns the image uploaded in the image field of the multipart form of r.
+// The image must be a JPEG, PNG or WebP image of up to MaxRoomImageSize bytes, as detected from its content.
+// Error messages are added to form.Errors, including for a missing image if required.
+func readRoomImage(r *http.Request, form *forms.Form, required bool) []byte {
+	file, header, err := r.FormFile("image")
+	if errors.Is(err, http.ErrMissingFile) {
+		if required {
+			form.Errors.Add("image", "Please upload an image of the room.")
+		}
+		return nil
+	} else if err != nil {
+		form.Errors.Add("image", "Invalid image!")
+		return nil
+	}
+	defer file.Close()
+
+	if header.Size > MaxRoomImageSize {
+		form.Errors.Add("image", fmt.Sprintf("The image is too large. Please upload an image of up to %d MB.", MaxRoomImageSize>>20))
+		return nil
+	}
+
+	image, err := io.ReadAll(file)
+	if err != nil {
+		form.Errors.Add("image", "Invalid image!")
+		return nil
+	}
+
+	if _, ok := RoomImageTypes[http.DetectContentType(image)]; !ok {
+		form.Errors.Add("image", "Invalid image type. Please upload a JPEG, PNG or WebP image.")
+		return nil
+	}
+
+	return image
+}
+
+// getAdminRoom returns the room with id.
+// On error, it logs and redirects to the rooms panel, and returns ok as false.
+func (s *Server) getAdminRoom(w http.ResponseWriter, r *http.Request, id int64) (room Room, ok bool) {
+	room, err := s.GetRoom(id)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load room from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/rooms")
+		return room, false
+	}
+
+	return room, true
+}
+
+// renderAdminRoom renders the room panel of room with form. A room without id is a new room.
+func (s *Server) renderAdminRoom(w http.ResponseWriter, r *http.Request, room Room, form *forms.Form) {
+	s.Render(w, r, "room.panel.gohtml",
+		&TemplateData{
+			Data: map[string]any{
+				"path": "/admin/rooms",
+				"room": room,
+			},
+			Form: form,
+		}, "/admin/rooms")
+}
+
+// removeRoomImage removes the room image filename from the static images directory.
+// Errors are logged, as the image is only left unused.
+func (s *Server) removeRoomImage(filename string) {
+	err := os.Remove(filepath.Join(app.StaticPath, RoomImagesDirectory, filename))
+	if err != nil {
+		s.LogError(ServerError{
+			Prompt: "Unable to remove room image.",
+			Err:    err,
+		})
+	}
+}
+
+// AdminUsersHandler is the GET "/admin/users" page handler
+func (s *Server) AdminUsersHandler(w http.ResponseWriter, r *http.Request) {
+	form := forms.New(nil)
+	form.Set("access_level", fmt.Sprint(int64(RoleFrontDesk)))
+
+	s.renderAdminUsers(w, r, form)
+}
+
+// PostAdminUsersHandler is the POST "/admin/users" page handler.
+// It invites a new staff user, who is mailed a link to set a password.
+func (s *Server) PostAdminUsersHandler(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		sErr := CreateServerError(ErrorParseForm, r.URL.Path, err)
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/users")
+		return
+	}
+
+	// create a new form with data and validate the form
+	form := forms.New(r.PostForm)
+	user, ok := s.parseAdminUserForm(w, r, form, User{})
+	if !ok {
+		return
+	}
+
+	if !form.Valid() {
+		s.renderAdminUsers(w, r, form)
+		return
+	}
+
+	// the user cannot log in until a password is set with the link mailed
+	user.Password = util.NewToken(PasswordTokenLength)
+	user, err = s.CreateUser(user)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to create user.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/users")
+		return
+	}
+
+	userID := app.Session.GetInt64(r.Context(), "user_id")
+	s.LogInfo(fmt.Sprintf("USER %s invited as %s by user %d", user.Email, user.AccessLevel.Label(), userID))
+
+	err = s.MailPasswordLink(user, true)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to send invitation. Please reset the user password to send a new link.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/users")
+		return
+	}
+
+	app.Session.Put(r.Context(), "flash", fmt.Sprintf("Invitation sent to %s.", user.Email))
+	http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
+}
+
+// AdminUserHandler is the GET "/admin/users/{id}" page handler.
+// It shows a form to edit the staff user.
+func (s *Server) AdminUserHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.getAdminUser(w, r)
+	if !ok {
+		return
+	}
+
+	form := forms.New(nil)
+	form.Set("first_name", user.FirstName)
+	form.Set("last_name", user.LastName)
+	form.Set("email", user.Email)
+	form.Set("access_level", fmt.Sprint(int64(user.AccessLevel)))
+
+	s.renderAdminUser(w, r, user, form)
+}
+
+// PostAdminUserHandler is the POST "/admin/users/{id}" page handler.
+// It updates the name, email and role of the staff user. A user with a new role is logged out.
+func (s *Server) PostAdminUserHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.getAdminUser(w, r)
+	if !ok {
+		return
+	}
+
+	err := r.ParseForm()
+	if err != nil {
+		sErr := CreateServerError(ErrorParseForm, r.URL.Path, err)
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/users")
+		return
+	}
+
+	// create a new form with data and validate the form
+	form := forms.New(r.PostForm)
+	updated, ok := s.parseAdminUserForm(w, r, form, user)
+	if !ok {
+		return
+	}
+
+	// prevent the owner from locking themselves out
+	userID := app.Session.GetInt64(r.Context(), "user_id")
+	if user.ID == userID && updated.AccessLevel != user.AccessLevel {
+		form.Errors.Add("access_level", "You cannot change your own role.")
+	}
+
+	if !form.Valid() {
+		s.renderAdminUser(w, r, user, form)
+		return
+	}
+
+	err = s.UpdateUser(updated)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to update user.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/users")
+		return
+	}
+
+	if updated.AccessLevel != user.AccessLevel {
+		s.LogOutUser(user.ID)
+	}
+
+	s.LogInfo(fmt.Sprintf("USER %s updated as %s by user %d", updated.Email, updated.AccessLevel.Label(), userID))
+
+	app.Session.Put(r.Context(), "flash", fmt.Sprintf("User %s updated.", updated.Email))
+	http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
+}
+
+// PostAdminDeactivateUserHandler is the POST "/admin/users/{id}/deactivate" page handler.
+// The staff user deactivated is logged out, and can no longer log in.
+func (s *Server) PostAdminDeactivateUserHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.getAdminUser(w, r)
+	if !ok {
+		return
+	}
+
+	userID := app.Session.GetInt64(r.Context(), "user_id")
+	if user.ID == userID {
+		app.Session.Put(r.Context(), "warning", "You cannot deactivate your own user.")
+		http.Redirect(w, r, fmt.Sprintf("/admin/users/%d", user.ID), http.StatusSeeOther)
+		return
+	}
+
+	err := s.UpdateUserDeactivatedAt(user.ID, time.Now())
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to deactivate user.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/users")
+		return
+	}
+
+	s.LogOutUser(user.ID)
+	s.LogInfo(fmt.Sprintf("USER %s deactivated by user %d", user.Email, userID))
+
+	app.Session.Put(r.Context(), "flash", fmt.Sprintf("User %s deactivated.", user.Email))
+	http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
+}
+
+// PostAdminReactivateUserHandler is the POST "/admin/users/{id}/reactivate" page handler
+func (s *Server) PostAdminReactivateUserHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.getAdminUser(w, r)
+	if !ok {
+		return
+	}
+
+	err := s.UpdateUserDeactivatedAt(user.ID, time.Time{})
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to reactivate user.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/users")
+		return
+	}
+
+	userID := app.Session.GetInt64(r.Context(), "user_id")
+	s.LogInfo(fmt.Sprintf("USER %s reactivated by user %d", user.Email, userID))
+
+	app.Session.Put(r.Context(), "flash", fmt.Sprintf("User %s reactivated.", user.Email))
+	http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
+}
+
+// PostAdminResetUserHandler is the POST "/admin/users/{id}/reset" page handler.
+// It replaces the password of the staff user, logs the user out, and mails the user a link to set a new password.
+func (s *Server) PostAdminResetUserHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.getAdminUser(w, r)
+	if !ok {
+		return
+	}
+
+	if !user.IsActive() {
+		app.Session.Put(r.Context(), "warning", "Deactivated users cannot set a password. Please reactivate the user first.")
+		http.Redirect(w, r, fmt.Sprintf("/admin/users/%d", user.ID), http.StatusSeeOther)
+		return
+	}
+
+	// the current password can no longer be used
+	err := s.ChangeUserPassword(user.ID, util.NewToken(PasswordTokenLength))
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to reset password.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/users")
+		return
+	}
+
+	s.LogOutUser(user.ID)
+
+	userID := app.Session.GetInt64(r.Context(), "user_id")
+	s.LogInfo(fmt.Sprintf("USER %s password reset by user %d", user.Email, userID))
+
+	err = s.MailPasswordLink(user, false)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to send password link.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/users")
+		return
+	}
+
+	app.Session.Put(r.Context(), "flash", fmt.Sprintf("Password link sent to %s.", user.Email))
+	http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
+}
+
+// parseAdminUserForm validates the staff user form, and returns user updated with the form data.
+// Error messages are added to form.Errors, including for an email of another user.
+// On error, it logs and redirects, and returns ok as false.
+func (s *Server) parseAdminUserForm(w http.ResponseWriter, r *http.Request, form *forms.Form, user User) (updated User, ok bool) {
+	form.TrimSpaces()
+	form.Required("first_name", "last_name", "email", "access_level")
+	form.CheckEmail("email")
+	form.CheckIntRange("access_level", int(RoleOwner), int(RoleReadOnly))
+
+	updated = user
+	updated.FirstName = form.Get("first_name")
+	updated.LastName = form.Get("last_name")
+	updated.Email = form.Get("email")
+
+	var level int64
+	form.GetValue("access_level", &level)
+	updated.AccessLevel = Role(level)
+
+	if !form.Valid() {
+		return updated, true
+	}
+
+	// emails are unique, as users log in with their email
+	existing, err := s.GetUserByEmail(updated.Email)
+	if err == nil && existing.ID != user.ID {
+		form.Errors.Add("email", "Another user has this email.")
+	} else if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		sErr := ServerError{
+			Prompt: "Unable to load user from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/users")
+		return updated, false
+	}
+
+	return updated, true
+}
+
+// getAdminUser returns the staff user of the id in the URL of r.
+// On error, it logs and redirects to the users panel, and returns ok as false.
+func (s *Server) getAdminUser(w http.ResponseWriter, r *http.Request) (user User, ok bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		sErr := CreateServerError(ErrorInvalidParameter, r.URL.Path, nil)
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/users")
+		return user, false
+	}
+
+	user, err = s.GetUser(id)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load user from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/users")
+		return user, false
+	}
+
+	return user, true
+}
+
+// renderAdminUsers renders the users panel with the invitation form
+func (s *Server) renderAdminUsers(w http.ResponseWriter, r *http.Request, form *forms.Form) {
+	users, err := s.ListUsers(LimitUsersPerPage, 0)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load users from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/dashboard")
+		return
+	}
+
+	s.Render(w, r, "users.panel.gohtml",
+		&TemplateData{
+			Data: map[string]any{
+				"path":  "/admin/users",
+				"users": users,
+				"roles": Roles,
+			},
+			Form: form,
+		}, "/admin/dashboard")
+}
+
+// renderAdminUser renders the user panel of user with form
+func (s *Server) renderAdminUser(w http.ResponseWriter, r *http.Request, user User, form *forms.Form) {
+	s.Render(w, r, "user.panel.gohtml",
+		&TemplateData{
+			Data: map[string]any{
+				"path":  "/admin/users",
+				"user":  user,
+				"roles": Roles,
+			},
+			Form: form,
+		}, "/admin/users")
+}
+
+// AdminChargesHandler is the GET "/admin/charges" page handler
+func (s *Server) AdminChargesHandler(w http.ResponseWriter, r *http.Request) {
+	form := forms.New(nil)
+	form.Set("kind", string(ChargePercent))
+
+	s.renderAdminCharges(w, r, form)
+}
+
+// PostAdminChargesHandler is the POST "/admin/charges" page handler.
+// It creates a tax or fee added to the price of every new stay.
+func (s *Server) PostAdminChargesHandler(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		sErr := CreateServerError(ErrorParseForm, r.URL.Path, err)
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/charges")
+		return
+	}
+
+	// create a new form with data and validate the form
+	form := forms.New(r.PostForm)
+	form.TrimSpaces()
+	form.Required("name", "kind", "amount")
+
+	kind := ChargeKind(form.Get("kind"))
+	if !slices.Contains(ChargeKinds, kind) {
+		form.Errors.Add("kind", "Invalid charge type!")
+	}
+
+	// a percent is parsed like a price, into hundredths of a percent
+	amount, err := ParsePrice(strings.TrimSuffix(form.Get("amount"), "%"))
+	if err != nil || amount == 0 {
+		form.Errors.Add("amount", "Invalid amount. Please enter an amount such as 17, 2.50 or 7.75.")
+	}
+
+	if !form.Valid() {
+		s.renderAdminCharges(w, r, form)
+		return
+	}
+
+	c := Charge{
+		Name:   form.Get("name"),
+		Kind:   kind,
+		Amount: int64(amount),
+	}
+
+	err = s.CreateCharge(c)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to create charge.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/charges")
+		return
+	}
+
+	app.Session.Put(r.Context(), "flash", fmt.Sprintf("%s created.", c.Name))
+	http.Redirect(w, r, "/admin/charges", http.StatusSeeOther)
+}
+
+// PostAdminDeleteChargesHandler is the POST "/admin/charges/delete" page handler.
+// It deletes the taxes and fees selected.
+func (s *Server) PostAdminDeleteChargesHandler(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		sErr := CreateServerError(ErrorParseForm, r.URL.Path, err)
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/charges")
+		return
+	}
+
+	ids := make([]int64, len(r.PostForm["charge_id"]))
+	for i, v := range r.PostForm["charge_id"] {
+		ids[i], err = strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			sErr := CreateServerError(ErrorInvalidParameter, r.URL.Path, err)
+			s.LogErrorAndRedirect(w, r, sErr, "/admin/charges")
+			return
+		}
+	}
+
+	if len(ids) == 0 {
+		app.Session.Put(r.Context(), "warning", "No charges selected.")
+		http.Redirect(w, r, "/admin/charges", http.StatusSeeOther)
+		return
+	}
+
+	err = s.DeleteCharges(ids)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to delete charges.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/charges")
+		return
+	}
+
+	app.Session.Put(r.Context(), "flash", fmt.Sprintf("%d charges deleted.", len(ids)))
+	http.Redirect(w, r, "/admin/charges", http.StatusSeeOther)
+}
+
+// renderAdminCharges renders the taxes and fees panel with all charges and form
+func (s *Server) renderAdminCharges(w http.ResponseWriter, r *http.Request, form *forms.Form) {
+	charges, err := s.ListCharges()
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load charges from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/dashboard")
+		return
+	}
+
+	kindOptions := make([]CheckboxOption, len(ChargeKinds))
+	for i, kind := range ChargeKinds {
+		kindOptions[i] = CheckboxOption{
+			Value:   string(kind),
+			Label:   kind.String(),
+			Checked: form.Get("kind") == string(kind),
+		}
+	}
+
+	s.Render(w, r, "charges.panel.gohtml",
+		&TemplateData{
+			Data: map[string]any{
+				"path":    r.URL.Path,
+				"charges": charges,
+				"kinds":   kindOptions,
+			},
+			Form: form,
+		}, "/admin/dashboard")
+}
+
+// renderAdminFolio renders the folio panel of rsv with its entries, running balance and form
+func (s *Server) renderAdminFolio(w http.ResponseWriter, r *http.Request, rsv Reservation, form *forms.Form) {
+	entries, err := s.ListFolioEntries(rsv.ID)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load folio from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/reservations/new")
+		return
+	}
+
+	balance := FolioBalance(entries)
+
+	kindOptions := make([]CheckboxOption, len(FolioEntryKinds))
+	for i, kind := range FolioEntryKinds {
+		kindOptions[i] = CheckboxOption{
+			Value:   string(kind),
+			Label:   kind.String(),
+			Checked: form.Get("kind") == string(kind),
+		}
+	}
+
+	s.Render(w, r, "folio.panel.gohtml",
+		&TemplateData{
+			Data: map[string]any{
+				"path":        "/admin/reservations",
+				"reservation": rsv,
+				"entries":     entries,
+				"balance":     balance,
+				"kinds":       kindOptions,
+			},
+			Form: form,
+		}, "/admin/reservations/new")
+}
+
+// AdminExchangeRatesHandler is the GET "/admin/currencies" page handler.
+// It lists the exchange rates of the display currencies, with a form to add or update one.
+func (s *Server) AdminExchangeRatesHandler(w http.ResponseWriter, r *http.Request) {
+	s.renderAdminExchangeRates(w, r, forms.New(nil))
+}
+
+// PostAdminExchangeRatesHandler is the POST "/admin/currencies" page handler.
+// It sets the exchange rate of a display currency, adding the currency if it has no exchange rate yet.
+func (s *Server) PostAdminExchangeRatesHandler(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		sErr := CreateServerError(ErrorParseForm, r.URL.Path, err)
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/currencies")
+		return
+	}
+
+	// create a new form with data and validate the form
+	form := forms.New(r.PostForm)
+	form.TrimSpaces()
+	form.Required("currency", "symbol", "rate")
+
+	code := strings.ToUpper(form.Get("currency"))
+	if !isCurrencyCode(code) {
+		form.Errors.Add("currency", "Invalid currency. Please enter a 3 letter code such as EUR.")
+	} else if code == app.BaseCurrency.Code {
+		form.Errors.Add("currency", "The base currency needs no exchange rate.")
+	}
+
+	if len(form.Get("symbol")) > 8 {
+		form.Errors.Add("symbol", "Symbol is too long.")
+	}
+
+	rate, err := ParseRate(form.Get("rate"))
+	if err != nil || rate == 0 {
+		form.Errors.Add("rate", "Invalid rate. Please enter a rate with up to 6 decimals such as 0.9215.")
+	}
+
+	if !form.Valid() {
+		s.renderAdminExchangeRates(w, r, form)
+		return
+	}
+
+	e, err := s.UpsertExchangeRate(ExchangeRate{
+		Currency: code,
+		Symbol:   form.Get("symbol"),
+		Rate:     rate,
+	})
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to save exchange rate.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/currencies")
+		return
+	}
+
+	s.reloadExchangeRates(r)
+
+	app.Session.Put(r.Context(), "flash", fmt.Sprintf("1 %s = %s %s.", app.BaseCurrency.Code, e.RateString(), e.Currency))
+	http.Redirect(w, r, "/admin/currencies", http.StatusSeeOther)
+}
+
+// PostAdminDeleteExchangeRatesHandler is the POST "/admin/currencies/delete" page handler.
+// It deletes the exchange rates of the currencies selected, which can no longer be chosen as display currencies.
+func (s *Server) PostAdminDeleteExchangeRatesHandler(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		sErr := CreateServerError(ErrorParseForm, r.URL.Path, err)
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/currencies")
+		return
+	}
+
+	currencies := r.PostForm["currency"]
+	if len(currencies) == 0 {
+		app.Session.Put(r.Context(), "warning", "No currencies selected.")
+		http.Redirect(w, r, "/admin/currencies", http.StatusSeeOther)
+		return
+	}
+
+	err = s.DeleteExchangeRates(currencies)
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to delete exchange rates.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/currencies")
+		return
+	}
+
+	s.reloadExchangeRates(r)
+
+	app.Session.Put(r.Context(), "flash", fmt.Sprintf("%d exchange rates deleted.", len(currencies)))
+	http.Redirect(w, r, "/admin/currencies", http.StatusSeeOther)
+}
+
+// reloadExchangeRates reloads the exchange rates cache of the server after staff changed the exchange rates.
+// Errors are logged, and the previous exchange rates are displayed until the next reload.
+func (s *Server) reloadExchangeRates(r *http.Request) {
+	err := s.LoadExchangeRates()
+	if err != nil {
+		s.LogError(ServerError{
+			Prompt: "Unable to reload exchange rates.",
+			URL:    r.URL.Path,
+			Err:    err,
+		})
+	}
+}
+
+// renderAdminExchangeRates renders the currencies panel with all exchange rates and form
+func (s *Server) renderAdminExchangeRates(w http.ResponseWriter, r *http.Request, form *forms.Form) {
+	rates, err := s.ListExchangeRates()
+	if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to load exchange rates from database.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/dashboard")
+		return
+	}
+
+	s.Render(w, r, "currencies.panel.gohtml",
+		&TemplateData{
+			Data: map[string]any{
+				"path":  r.URL.Path,
+				"base":  app.BaseCurrency,
+				"rates": rates,
+			},
+			Form: form,
+		}, "/admin/dashboard")
+}
+
+// parseAdminReservationRequest parses the reservation id in the URL of r and the form of r.
+// It returns the id and the admin page to redirect to after the request, taken from the "redirect_to" form field.
+// On error, it logs and redirects, and returns ok as false.
+func (s *Server) parseAdminReservationRequest(w http.ResponseWriter, r *http.Request) (id int64, redirectURL string, ok bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		sErr := CreateServerError(ErrorInvalidParameter, r.URL.Path, nil)
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/reservations/new")
+		return 0, "", false
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		sErr := CreateServerError(ErrorParseForm, r.URL.Path, err)
+		s.LogErrorAndRedirect(w, r, sErr, "/admin/reservations/new")
+		return 0, "", false
+	}
+
+	// redirect only to admin pages
+	redirectURL = r.PostForm.Get("redirect_to")
+	if !strings.HasPrefix(redirectURL, "/admin/") {
+		redirectURL = "/admin/reservations/new"
+	}
+
+	return id, redirectURL, true
+}
+
+// changeReservationStatus moves the reservation with id to status today, logs the change and redirects to redirectURL.
+// The rooms released by a cancellation, a no-show or an early check-out are offered to the guests on the waitlist.
+// Staff cancelling a reservation with a refund due are redirected to the refund page of the reservation,
+// and staff checking out a guest with a credit left on the folio are prompted to refund it.
+func (s *Server) changeReservationStatus(w http.ResponseWriter, r *http.Request, id int64, status ReservationStatus, redirectURL string) {
+	today := Today()
+
+	rsv, err := s.UpdateReservationStatus(Reservation{ID: id}, status, today)
+	var folioErr *db.FolioBalanceError
+	if errors.Is(err, db.ErrInvalidStatusTransition) {
+		app.Session.Put(r.Context(), "warning", fmt.Sprintf("Reservation cannot be marked as %s.", status.Label()))
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	} else if errors.Is(err, db.ErrEarlyCheckIn) {
+		app.Session.Put(r.Context(), "warning", "Reservation cannot be checked in before its arrival date.")
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	} else if errors.As(err, &folioErr) {
+		app.Session.Put(r.Context(), "warning", fmt.Sprintf("Reservation cannot be checked out with an unsettled folio balance of %s.", Price(folioErr.Balance)))
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	} else if err != nil {
+		sErr := ServerError{
+			Prompt: "Unable to update reservation status.",
+			URL:    r.URL.Path,
+			Err:    err,
+		}
+		s.LogErrorAndRedirect(w, r, sErr, redirectURL)
+		return
+	}
+
+	userID := app.Session.GetInt64(r.Context(), "user_id")
+	s.LogInfo(fmt.Sprintf("STATUS reservation %s marked as %s by user %d", rsv.Code, rsv.Status, userID))
+
+	// offer the room released to the guests on the waitlist
+	switch {
+	case rsv.Status == ReservationCancelled || rsv.Status == ReservationNoShow:
+		s.OfferFreedRoom(rsv.RoomID, rsv.StartDate, rsv.EndDate)
+	case rsv.Status == ReservationCheckedOut && today.Before(rsv.EndDate):
+		s.LogInfo(fmt.Sprintf("STATUS reservation %s checked out early, nights from %s to %s released",
+			rsv.Code, today.Format(config.DateLayout), rsv.EndDate.Format(config.DateLayout)))
+		s.OfferFreedRoom(rsv.RoomID, today, rsv.EndDate)
+	}
+
+	// prompt staff to refund the guest, as the reservation is already cancelled
+	if rsv.Status == ReservationCancelled {
+		pays, err := s.ListPayments(rsv.ID)
+		if err != nil {
+			s.LogError(ServerError{
+				Prompt: fmt.Sprintf("Unable to load payments of cancelled reservation %s.", rsv.Code),
+				URL:    r.URL.Path,
+				Err:    err,
+			})
+		} else if amount := rsv.RefundableAmount(PaidAmount(pays)); amount > 0 {
+			app.Session.Put(r.Context(), "warning", fmt.Sprintf("A refund of %s is due to the guest. Please refund it.", amount))
+			redirectURL = fmt.Sprintf("/admin/reservations/%d/refund", rsv.ID)
+		}
+	}
+
+	// prompt staff to refund the credit left on the folio of the guest checked out
+	if rsv.Status == ReservationCheckedOut {
+		balance, err := s.GetFolioBalance(rsv.ID)
+		if err != nil {
+			s.LogError(ServerError{
+				Prompt: fmt.Sprintf("Unable to load folio balance of reservation %s.", rsv.Code),
+				URL:    r.URL.Path,
+				Err:    err,
+			})
+		} else if balance < 0 {
+			s.LogInfo(fmt.Sprintf("FOLIO credit of %s on reservation %d due for refund", -balance, rsv.ID))
+			app.Session.Put(r.Context(), "warning", fmt.Sprintf("The folio has a credit of %s due to the guest. Please refund it.", -balance))
+		}
+	}
+
+	app.Session.Put(r.Context(), "flash", fmt.Sprintf("Reservation %s is now %s.", rsv.Code, rsv.Status.Label()))
+	http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+}
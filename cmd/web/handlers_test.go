@@ -1,12 +1,18 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -15,6 +21,8 @@ import (
 	"github.com/github-real-lb/bookings-web-app/util"
 	"github.com/github-real-lb/bookings-web-app/util/config"
 	"github.com/github-real-lb/bookings-web-app/util/forms"
+	"github.com/github-real-lb/bookings-web-app/util/payments"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -33,6 +41,7 @@ func TestStaticPageHandlers(t *testing.T) {
 		{"/about page", http.MethodGet, "/about", http.StatusOK},
 		{"/contact page", http.MethodGet, "/contact", http.StatusOK},
 		{"/available-rooms-search page", http.MethodGet, "/available-rooms-search", http.StatusOK},
+		{"/find-reservation page", http.MethodGet, "/find-reservation", http.StatusOK},
 	}
 
 	for _, test := range tests {
@@ -51,37 +60,25 @@ func TestStaticPageHandlers(t *testing.T) {
 }
 
 func TestServer_RoomsHandler(t *testing.T) {
+	// create stub call arguments
+	arg := db.ListRoomsParams{
+		Limit:  LimitRoomsPerPage,
+		Offset: 0,
+	}
+
+	// create stub return arguments
+	rooms := randomRooms(LimitRoomsPerPage)
+	dbRooms := make([]db.Room, LimitRoomsPerPage)
+	for i := range rooms {
+		rooms[i].Export(&dbRooms[i])
+	}
+
 	// test displaying the GET /rooms/list
 	t.Run("OK List []Room", func(t *testing.T) {
 		// create a new test server, a mock database store and a request
 		ts := NewTestServer(t)
 		req := ts.NewRequestWithSession(t, http.MethodGet, "/rooms/list", nil)
 
-		// screate stub call arguments
-		arg := db.ListRoomsParams{
-			Limit:  LimitRoomsPerPage,
-			Offset: 0,
-		}
-
-		//create stub return arguments
-		dbRooms := make([]db.Room, LimitRoomsPerPage)
-		for i := 0; i < LimitRoomsPerPage; i++ {
-			dbRooms[i] = db.Room{
-				ID:            util.RandomID(),
-				Name:          util.RandomName(),
-				Description:   util.RandomNote(),
-				ImageFilename: fmt.Sprint(util.RandomName(), ".png"),
-				CreatedAt: pgtype.Timestamptz{
-					Time:  util.RandomDatetime(),
-					Valid: true,
-				},
-				UpdatedAt: pgtype.Timestamptz{
-					Time:  util.RandomDatetime(),
-					Valid: true,
-				},
-			}
-		}
-
 		// build stubs
 		ts.MockDBStore.On("ListRooms", mock.Anything, arg).
 			Return(dbRooms, nil).
@@ -90,12 +87,10 @@ func TestServer_RoomsHandler(t *testing.T) {
 		//  server the request
 		rr := ts.ServeRequest(req)
 
-		// checks rooms is in session and remove it
-		rooms := app.Session.Pop(req.Context(), "rooms").([]Room)
-		require.Len(t, rooms, LimitRoomsPerPage)
-
 		// testify
 		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.False(t, app.Session.Exists(req.Context(), "rooms"))
+		assert.Contains(t, rr.Body.String(), rooms[0].URL())
 	})
 
 	// test handling the GET /rooms/{room index}
@@ -104,27 +99,17 @@ func TestServer_RoomsHandler(t *testing.T) {
 		ts := NewTestServer(t)
 		req := ts.NewRequestWithSession(t, http.MethodGet, "/rooms/1", nil)
 
-		// create slice of []Room to put in session
-		rooms := randomRooms(LimitRoomsPerPage)
-
-		// put rooms in session
-		app.Session.Put(req.Context(), "rooms", rooms)
+		// build stubs
+		ts.MockDBStore.On("ListRooms", mock.Anything, arg).
+			Return(dbRooms, nil).
+			Once()
 
 		// server the request
 		rr := ts.ServeRequest(req)
 
-		// remove rooms from session
-		app.Session.Remove(req.Context(), "rooms")
-
-		// check room is in session and removes it
-		room := app.Session.Pop(req.Context(), "room").(Room)
-		require.Equal(t, rooms[1], room)
-
 		// testify
-		assert.Equal(t, http.StatusSeeOther, rr.Code)
-		ok := strings.HasPrefix(rr.Header().Get("Location"), "/rooms/room/")
-		assert.True(t, ok)
-
+		assert.Equal(t, http.StatusMovedPermanently, rr.Code)
+		assert.Equal(t, rooms[1].URL(), rr.Header().Get("Location"))
 	})
 
 	// test database error while displaying the GET /rooms/list
@@ -133,12 +118,6 @@ func TestServer_RoomsHandler(t *testing.T) {
 		ts := NewTestServer(t)
 		req := ts.NewRequestWithSession(t, http.MethodGet, "/rooms/list", nil)
 
-		// create stubs arguments
-		arg := db.ListRoomsParams{
-			Limit:  LimitRoomsPerPage,
-			Offset: 0,
-		}
-
 		err := errors.New("any error")
 
 		sErr := ServerError{
@@ -165,11 +144,17 @@ func TestServer_RoomsHandler(t *testing.T) {
 		assert.Equal(t, "/", rr.Header().Get("Location"))
 	})
 
-	// test handling the GET /rooms/{room index} with rooms missing from session
-	t.Run("Error Missing []Room", func(t *testing.T) {
+	// test handling the GET /rooms/{room index} with index not a number
+	t.Run("Error Index Not a Number", func(t *testing.T) {
 		// create a new test server, and a new request
 		ts := NewTestServer(t)
-		req := ts.NewRequestWithSession(t, http.MethodGet, "/rooms/1", nil)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/rooms/abc", nil)
+
+		// build stubs
+		ts.MockDBStore.On("ListRooms", mock.Anything, arg).
+			Return(dbRooms, nil).
+			Once()
+		ts.BuildLogAnyErrorStub()
 
 		// server the request
 		rr := ts.ServeRequest(req)
@@ -179,52 +164,153 @@ func TestServer_RoomsHandler(t *testing.T) {
 		assert.Equal(t, "/rooms/list", rr.Header().Get("Location"))
 	})
 
-	// test handling the GET /rooms/{room index} with index not a number
-	t.Run("Error Index Not a Number", func(t *testing.T) {
+	// test handling the GET /rooms/{room index} with index bigger than rooms lenght
+	t.Run("Error Index Out of Scope", func(t *testing.T) {
 		// create a new test server, and a new request
 		ts := NewTestServer(t)
-		req := ts.NewRequestWithSession(t, http.MethodGet, "/rooms/abc", nil)
+		url := fmt.Sprint("/rooms/", LimitRoomsPerPage+10)
+		req := ts.NewRequestWithSession(t, http.MethodGet, url, nil)
+
+		// build stubs
+		ts.MockDBStore.On("ListRooms", mock.Anything, arg).
+			Return(dbRooms, nil).
+			Once()
+
+		// server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/rooms/list", rr.Header().Get("Location"))
+	})
+}
 
-		// create slice of []Room to put in session
-		rooms := randomRooms(LimitRoomsPerPage)
+func TestServer_RoomHandler(t *testing.T) {
+	// create room with random data
+	room := randomRoom()
+	dbRoom := db.Room{}
+	room.Export(&dbRoom)
+
+	// test displaying the GET /rooms/room/{name}
+	t.Run("OK", func(t *testing.T) {
+		// create a new test server and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, room.URL(), nil)
 
 		// build stub
-		ts.BuildLogAnyErrorStub()
+		ts.MockDBStore.On("GetRoomBySlug", mock.Anything, room.Slug).
+			Return(dbRoom, nil).
+			Once()
 
-		// put rooms in session
-		app.Session.Put(req.Context(), "rooms", rooms)
+		//  server the request
+		rr := ts.ServeRequest(req)
 
-		// server the request
+		// check room is in session and remove it
+		sessionRoom, ok := app.Session.Pop(req.Context(), "room").(Room)
+		require.True(t, ok)
+		assert.Equal(t, room.ID, sessionRoom.ID)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	// test displaying the room price in the display currency selected
+	t.Run("OK Display Currency", func(t *testing.T) {
+		priced := room
+		priced.NightlyRate = 10000
+		dbPriced := db.Room{}
+		priced.Export(&dbPriced)
+
+		// create a new test server and a request
+		ts := NewTestServer(t)
+		ts.exchangeRates = []ExchangeRate{{Currency: "EUR", Symbol: "€", Rate: 921500}}
+		req := ts.NewRequestWithSession(t, http.MethodGet, room.URL(), nil)
+		app.Session.Put(req.Context(), "currency", "EUR")
+
+		// build stub
+		ts.MockDBStore.On("GetRoomBySlug", mock.Anything, room.Slug).
+			Return(dbPriced, nil).
+			Once()
+
+		//  server the request
 		rr := ts.ServeRequest(req)
 
-		// checks rooms is in session and remove it
-		sessionRooms := app.Session.Pop(req.Context(), "rooms").([]Room)
-		require.Len(t, sessionRooms, LimitRoomsPerPage)
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "From €92.15 per night")
+		assert.Contains(t, rr.Body.String(), `<option value="EUR" selected>`)
+	})
+
+	// test redirecting a slug with different letter case to the canonical URL
+	t.Run("OK Redirect to Canonical URL", func(t *testing.T) {
+		// create a new test server and a request
+		ts := NewTestServer(t)
+		slug := strings.ToUpper(room.Slug)
+		req := ts.NewRequestWithSession(t, http.MethodGet, fmt.Sprint("/rooms/room/", slug), nil)
+
+		// build stub
+		ts.MockDBStore.On("GetRoomBySlug", mock.Anything, slug).
+			Return(dbRoom, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
 
 		// testify
-		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, http.StatusMovedPermanently, rr.Code)
+		assert.Equal(t, room.URL(), rr.Header().Get("Location"))
+		assert.False(t, app.Session.Exists(req.Context(), "room"))
+	})
+
+	// test room not found
+	t.Run("Room Not Found", func(t *testing.T) {
+		// create a new test server and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/rooms/room/test", nil)
+
+		// build stub
+		ts.MockDBStore.On("GetRoomBySlug", mock.Anything, "test").
+			Return(db.Room{}, pgx.ErrNoRows).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get warning message from session and remove it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "Room not found.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
 		assert.Equal(t, "/rooms/list", rr.Header().Get("Location"))
 	})
 
-	// test handling the GET /rooms/{room index} with index bigger than rooms lenght
-	t.Run("Error Index Out of Scope", func(t *testing.T) {
-		// create a new test server, and a new request
+	// test database error
+	t.Run("Error In DB", func(t *testing.T) {
+		// create a new test server and a request
 		ts := NewTestServer(t)
-		url := fmt.Sprint("/rooms/", LimitRoomsPerPage+10)
-		req := ts.NewRequestWithSession(t, http.MethodGet, url, nil)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/rooms/room/test", nil)
+
+		err := errors.New("any error")
 
-		// create slice of []Room to put in session
-		rooms := randomRooms(LimitRoomsPerPage)
+		sErr := ServerError{
+			Prompt: "Unable to load room from database.",
+			URL:    req.URL.Path,
+			Err:    err,
+		}
 
-		// put rooms in session
-		app.Session.Put(req.Context(), "rooms", rooms)
+		// build stubs
+		ts.MockDBStore.On("GetRoomBySlug", mock.Anything, "test").
+			Return(db.Room{}, err).
+			Once()
+		ts.BuildLogErrorStub(sErr)
 
-		// server the request
+		//  server the request
 		rr := ts.ServeRequest(req)
 
-		// checks rooms is in session and remove it
-		sessionRooms := app.Session.Pop(req.Context(), "rooms").([]Room)
-		require.Len(t, sessionRooms, LimitRoomsPerPage)
+		// get error message from session and remove it
+		errMsg := app.Session.PopString(req.Context(), "error")
+		assert.Equal(t, sErr.Prompt, errMsg)
 
 		// testify
 		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
@@ -232,41 +318,202 @@ func TestServer_RoomsHandler(t *testing.T) {
 	})
 }
 
-func TestServer_RoomHandler(t *testing.T) {
-	// test displaying the GET /rooms/room/{name}
+func TestServer_PostCurrencyHandler(t *testing.T) {
+	// test selecting a display currency
 	t.Run("OK", func(t *testing.T) {
 		// create a new test server and a request
 		ts := NewTestServer(t)
-		req := ts.NewRequestWithSession(t, http.MethodGet, "/rooms/room/test", nil)
+		ts.exchangeRates = []ExchangeRate{{Currency: "EUR", Symbol: "€", Rate: 921500}}
+		values := url.Values{"currency": {"eur"}}
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/currency", strings.NewReader(values.Encode()))
+		req.Header.Set("Referer", "http://localhost:8080/rooms/list")
 
-		// create room with random data to put in the session
-		room := randomRoom()
+		//  server the request
+		rr := ts.ServeRequest(req)
 
-		// put reservation in session
-		app.Session.Put(req.Context(), "room", room)
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/rooms/list", rr.Header().Get("Location"))
+		assert.Equal(t, "EUR", app.Session.PopString(req.Context(), "currency"))
+	})
+
+	// test selecting a currency with no exchange rate
+	t.Run("Currency Not Available", func(t *testing.T) {
+		// create a new test server and a request
+		ts := NewTestServer(t)
+		values := url.Values{"currency": {"EUR"}}
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/currency", strings.NewReader(values.Encode()))
 
 		//  server the request
 		rr := ts.ServeRequest(req)
 
-		// remove reservation from session
-		app.Session.Remove(req.Context(), "room")
+		// get warning message from session and remove it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "Currency is not available.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/", rr.Header().Get("Location"))
+		assert.False(t, app.Session.Exists(req.Context(), "currency"))
+	})
+}
+
+func TestServer_RoomPricesHandler(t *testing.T) {
+	// create room with random data
+	room := randomRoom()
+	dbRoom := db.Room{}
+	room.Export(&dbRoom)
+	requestURL := room.URL() + "/prices"
+
+	// Test OK: the nights requested are priced
+	t.Run("OK", func(t *testing.T) {
+		startDate := Today().AddDate(0, 0, 10)
+		endDate := startDate.AddDate(0, 0, 3)
+
+		// create a new test server and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequest(http.MethodGet, fmt.Sprintf("%s?start_date=%s&end_date=%s", requestURL,
+			startDate.Format(config.DateLayout), endDate.Format(config.DateLayout)), nil)
+
+		// build stubs
+		ts.MockDBStore.On("GetRoomBySlug", mock.Anything, room.Slug).
+			Return(dbRoom, nil).
+			Once()
+		ts.BuildQuoteStayStub(room, startDate, endDate, 0, 0)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
 
 		// testify
 		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var resp RoomPricesResponse
+		jsonResponseUnmarshal(t, rr, &resp)
+		assert.True(t, resp.OK)
+		require.Len(t, resp.Prices, 3)
+		assert.Equal(t, room.NightlyRate.String(), resp.Prices[startDate.Format(config.DateLayout)])
+		assert.Empty(t, resp.Error)
 	})
 
-	// test missing room from session
-	t.Run("Missing Room from Session", func(t *testing.T) {
-		// create a new test server, and a new request
+	// Test OK: the nights priced default to PriceCalendarDays from today
+	t.Run("OK Default Dates", func(t *testing.T) {
+		// create a new test server and a request
 		ts := NewTestServer(t)
-		req := ts.NewRequestWithSession(t, http.MethodGet, "/rooms/room/test", nil)
+		req := ts.NewRequest(http.MethodGet, requestURL, nil)
+
+		// build stubs
+		ts.MockDBStore.On("GetRoomBySlug", mock.Anything, room.Slug).
+			Return(dbRoom, nil).
+			Once()
+		ts.BuildQuoteStayStub(room, Today(), Today().AddDate(0, 0, PriceCalendarDays), 0, 0)
 
 		//  server the request
 		rr := ts.ServeRequest(req)
 
 		// testify
-		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
-		assert.Equal(t, "/rooms/list", rr.Header().Get("Location"))
+		var resp RoomPricesResponse
+		jsonResponseUnmarshal(t, rr, &resp)
+		assert.True(t, resp.OK)
+		assert.Len(t, resp.Prices, PriceCalendarDays)
+	})
+
+	// Test OK: the nights priced are limited to MaxPriceCalendarDays
+	t.Run("OK Limited Dates", func(t *testing.T) {
+		startDate := Today()
+
+		// create a new test server and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequest(http.MethodGet, fmt.Sprintf("%s?end_date=%s", requestURL,
+			startDate.AddDate(2, 0, 0).Format(config.DateLayout)), nil)
+
+		// build stubs
+		ts.MockDBStore.On("GetRoomBySlug", mock.Anything, room.Slug).
+			Return(dbRoom, nil).
+			Once()
+		ts.BuildQuoteStayStub(room, startDate, startDate.AddDate(0, 0, MaxPriceCalendarDays), 0, 0)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		var resp RoomPricesResponse
+		jsonResponseUnmarshal(t, rr, &resp)
+		assert.True(t, resp.OK)
+		assert.Len(t, resp.Prices, MaxPriceCalendarDays)
+	})
+
+	// Test Error: invalid dates
+	t.Run("Invalid Dates", func(t *testing.T) {
+		for query, errMsg := range map[string]string{
+			"?start_date=invalid":                        "Invalid start date.",
+			"?start_date=2050-01-10&end_date=invalid":    "Invalid end date.",
+			"?start_date=2050-01-10&end_date=2050-01-10": "Invalid end date.",
+			"?start_date=2050-01-10&end_date=2050-01-01": "Invalid end date.",
+		} {
+			// create a new test server and a request
+			ts := NewTestServer(t)
+			req := ts.NewRequest(http.MethodGet, requestURL+query, nil)
+
+			// build stub
+			ts.MockDBStore.On("GetRoomBySlug", mock.Anything, room.Slug).
+				Return(dbRoom, nil).
+				Once()
+
+			//  server the request
+			rr := ts.ServeRequest(req)
+
+			// testify
+			var resp RoomPricesResponse
+			jsonResponseUnmarshal(t, rr, &resp)
+			assert.False(t, resp.OK, query)
+			assert.Equal(t, errMsg, resp.Error, query)
+		}
+	})
+
+	// Test Error: room not found
+	t.Run("Room Not Found", func(t *testing.T) {
+		// create a new test server and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequest(http.MethodGet, requestURL, nil)
+
+		// build stub
+		ts.MockDBStore.On("GetRoomBySlug", mock.Anything, room.Slug).
+			Return(db.Room{}, pgx.ErrNoRows).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		var resp RoomPricesResponse
+		jsonResponseUnmarshal(t, rr, &resp)
+		assert.False(t, resp.OK)
+		assert.Equal(t, "Room not found.", resp.Error)
+	})
+
+	// Test Error: internal server error on QuoteStay
+	t.Run("Error Quote", func(t *testing.T) {
+		// create a new test server and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequest(http.MethodGet, requestURL, nil)
+
+		// build stubs
+		ts.MockDBStore.On("GetRoomBySlug", mock.Anything, room.Slug).
+			Return(dbRoom, nil).
+			Once()
+		ts.MockDBStore.On("QuoteStay", mock.Anything, mock.Anything).
+			Return(db.Quote{}, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		var resp RoomPricesResponse
+		jsonResponseUnmarshal(t, rr, &resp)
+		assert.False(t, resp.OK)
+		assert.Equal(t, "Internal Error. Please reload and try again.", resp.Error)
 	})
 }
 
@@ -299,19 +546,19 @@ func TestServer_PostSearchRoomAvailabilityHandler(t *testing.T) {
 			Room:      room,
 		}
 
-		arg := db.CheckRoomAvailabilityParams{
-			RoomID: rsv.RoomID,
-		}
-		arg.StartDate.Scan(rsv.StartDate)
-		arg.EndDate.Scan(rsv.EndDate)
+		holdToken := util.RandomString(HoldTokenLength)
 
-		//build stub
-		ts.MockDBStore.On("CheckRoomAvailability", mock.Anything, arg).
-			Return(true, nil).
+		//build stubs
+		ts.MockDBStore.On("DeleteRoomHoldsByToken", mock.Anything, pgtype.Text{String: holdToken, Valid: true}).
+			Return(nil).
+			Once()
+		ts.MockDBStore.On("CreateRoomHoldTx", mock.Anything, matchRoomHold(rsv, holdToken)).
+			Return(db.RoomRestriction{}, nil).
 			Once()
 
-		// put room in session
+		// put room and hold token in session
 		app.Session.Put(req.Context(), "room", room)
+		app.Session.Put(req.Context(), "hold_token", holdToken)
 
 		//  server the request
 		rr := ts.ServeRequest(req)
@@ -365,15 +612,74 @@ func TestServer_PostSearchRoomAvailabilityHandler(t *testing.T) {
 			Room:      room,
 		}
 
-		arg := db.CheckRoomAvailabilityParams{
-			RoomID: rsv.RoomID,
-		}
-		arg.StartDate.Scan(rsv.StartDate)
-		arg.EndDate.Scan(rsv.EndDate)
+		holdToken := util.RandomString(HoldTokenLength)
 
-		//build stub
-		ts.MockDBStore.On("CheckRoomAvailability", mock.Anything, arg).
-			Return(false, nil).
+		//build stubs
+		ts.MockDBStore.On("DeleteRoomHoldsByToken", mock.Anything, pgtype.Text{String: holdToken, Valid: true}).
+			Return(nil).
+			Once()
+		ts.MockDBStore.On("CreateRoomHoldTx", mock.Anything, matchRoomHold(rsv, holdToken)).
+			Return(db.RoomRestriction{}, db.ErrRoomUnavailable).
+			Once()
+
+		// put room and hold token in session
+		app.Session.Put(req.Context(), "room", room)
+		app.Session.Put(req.Context(), "hold_token", holdToken)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// remove room from session
+		app.Session.Remove(req.Context(), "room")
+
+		// get the json response
+		resp := SearchRoomAvailabilityResponse{}
+		jsonResponseUnmarshal(t, rr, &resp)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.False(t, resp.OK)
+		assert.Equal(t, "Room is unavailable for the dates and guests selected. Please try different dates.", resp.Message)
+		assert.Empty(t, resp.Error)
+	})
+
+	// Test OK: the stay breaks a stay rule of the room, which is explained
+	t.Run("Stay Rule", func(t *testing.T) {
+		// create room with random data to put in the session
+		room := randomRoom()
+
+		// creating dates for the request
+		startDate := util.RandomDate()
+		endDate := startDate.AddDate(0, 0, 1)
+
+		// create the body of the request
+		values := url.Values{
+			"start_date": {startDate.Format(config.DateLayout)},
+			"end_date":   {endDate.Format(config.DateLayout)},
+		}
+		body := strings.NewReader(values.Encode())
+
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/search-room-availability", body)
+
+		// create stub return arguments
+		ruleErr := &db.StayRuleError{
+			Rule: db.StayRule{
+				DaysOfWeek: db.AllDaysOfWeek,
+				StartDate:  pgtype.Date{Time: startDate, Valid: true},
+				EndDate:    pgtype.Date{Time: endDate, Valid: true},
+				MinNights:  2,
+			},
+			Violation: db.ViolationMinNights,
+		}
+
+		//build stubs
+		ts.MockDBStore.On("DeleteRoomHoldsByToken", mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		ts.MockDBStore.On("CreateRoomHoldTx", mock.Anything, mock.Anything).
+			Return(db.RoomRestriction{}, ruleErr).
 			Once()
 
 		// put room in session
@@ -392,7 +698,7 @@ func TestServer_PostSearchRoomAvailabilityHandler(t *testing.T) {
 		// testify
 		assert.Equal(t, http.StatusOK, rr.Code)
 		assert.False(t, resp.OK)
-		assert.Equal(t, "Room is unavailable. PLease try different dates.", resp.Message)
+		assert.Equal(t, StayRuleMessage(ruleErr), resp.Message)
 		assert.Empty(t, resp.Error)
 	})
 
@@ -571,12 +877,7 @@ func TestServer_PostSearchRoomAvailabilityHandler(t *testing.T) {
 			Room:      room,
 		}
 
-		arg := db.CheckRoomAvailabilityParams{
-			RoomID: rsv.RoomID,
-		}
-		arg.StartDate.Scan(rsv.StartDate)
-		arg.EndDate.Scan(rsv.EndDate)
-
+		holdToken := util.RandomString(HoldTokenLength)
 		err := errors.New("any error")
 
 		sErr := ServerError{
@@ -586,13 +887,17 @@ func TestServer_PostSearchRoomAvailabilityHandler(t *testing.T) {
 		}
 
 		//build stubs
-		ts.MockDBStore.On("CheckRoomAvailability", mock.Anything, arg).
-			Return(false, err).
+		ts.MockDBStore.On("DeleteRoomHoldsByToken", mock.Anything, pgtype.Text{String: holdToken, Valid: true}).
+			Return(nil).
+			Once()
+		ts.MockDBStore.On("CreateRoomHoldTx", mock.Anything, matchRoomHold(rsv, holdToken)).
+			Return(db.RoomRestriction{}, err).
 			Once()
 		ts.BuildLogErrorStub(sErr)
 
-		// put room in session
+		// put room and hold token in session
 		app.Session.Put(req.Context(), "room", room)
+		app.Session.Put(req.Context(), "hold_token", holdToken)
 
 		//  server the request
 		rr := ts.ServeRequest(req)
@@ -612,6 +917,17 @@ func TestServer_PostSearchRoomAvailabilityHandler(t *testing.T) {
 	})
 }
 
+// matchRoomHold returns an argument matcher of the CreateRoomHoldTx stub for the room and dates of rsv held with holdToken
+func matchRoomHold(rsv Reservation, holdToken string) any {
+	return mock.MatchedBy(func(arg db.CreateRoomHoldTxParams) bool {
+		return arg.RoomID == rsv.RoomID &&
+			arg.HoldToken.String == holdToken &&
+			arg.StartDate.Time.Format(config.DateLayout) == rsv.StartDate.Format(config.DateLayout) &&
+			arg.EndDate.Time.Format(config.DateLayout) == rsv.EndDate.Format(config.DateLayout) &&
+			arg.ExpiresAt.Time.After(time.Now())
+	})
+}
+
 // jsonResponseUnmarshal parses rr body and stores the result in the value pointed to by v.
 // Any error is testified.
 func jsonResponseUnmarshal(t *testing.T, rr *httptest.ResponseRecorder, v any) {
@@ -645,8 +961,10 @@ func TestServer_PostAvailableRoomsSearchHandler(t *testing.T) {
 		}
 
 		arg := db.ListAvailableRoomsParams{
-			Limit:  LimitRoomsPerPage,
-			Offset: 0,
+			Limit:    LimitRoomsPerPage,
+			Offset:   0,
+			Adults:   1,
+			Children: 0,
 		}
 		err := arg.StartDate.Scan(rsv.StartDate)
 		require.NoError(t, err)
@@ -654,16 +972,22 @@ func TestServer_PostAvailableRoomsSearchHandler(t *testing.T) {
 		require.NoError(t, err)
 
 		//build stub
+		ts.MockDBStore.On("DeleteRoomHoldsByToken", mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
 		ts.MockDBStore.On("ListAvailableRooms", mock.Anything, arg).
 			Return([]db.Room{}, nil).
 			Once()
+		ts.MockDBStore.On("ListStayRulesForDates", mock.Anything, mock.Anything).
+			Return([]db.StayRule{}, nil).
+			Once()
 
 		//  server the request
 		rr := ts.ServeRequest(req)
 
 		// testify
 		assert.Equal(t, http.StatusOK, rr.Code)
-		assert.Contains(t, rr.Body.String(), `message: "No rooms are available. Please try different dates."`)
+		assert.Contains(t, rr.Body.String(), `message: "No rooms are available for the dates and guests selected. Please try different dates."`)
 	})
 
 	// Test OK: rooms are available
@@ -690,8 +1014,10 @@ func TestServer_PostAvailableRoomsSearchHandler(t *testing.T) {
 		}
 
 		arg := db.ListAvailableRoomsParams{
-			Limit:  LimitRoomsPerPage,
-			Offset: 0,
+			Limit:    LimitRoomsPerPage,
+			Offset:   0,
+			Adults:   1,
+			Children: 0,
 		}
 		err := arg.StartDate.Scan(rsv.StartDate)
 		require.NoError(t, err)
@@ -708,9 +1034,15 @@ func TestServer_PostAvailableRoomsSearchHandler(t *testing.T) {
 		}
 
 		//build stub
+		ts.MockDBStore.On("DeleteRoomHoldsByToken", mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
 		ts.MockDBStore.On("ListAvailableRooms", mock.Anything, arg).
 			Return(dbRooms, nil).
 			Once()
+		ts.MockDBStore.On("ListStayRulesForDates", mock.Anything, mock.Anything).
+			Return([]db.StayRule{}, nil).
+			Once()
 
 		//  server the request
 		rr := ts.ServeRequest(req)
@@ -729,6 +1061,65 @@ func TestServer_PostAvailableRoomsSearchHandler(t *testing.T) {
 		assert.Equal(t, "/available-rooms/available", rr.Header().Get("Location"))
 	})
 
+	// Test OK: the stay rules of the rooms block the search, and are explained
+	t.Run("Stay Rules Block Search", func(t *testing.T) {
+		// creating dates for the request, for a single night
+		startDate := util.RandomDate()
+		endDate := startDate.AddDate(0, 0, 1)
+
+		// create the body of the request
+		values := url.Values{
+			"start_date": {startDate.Format(config.DateLayout)},
+			"end_date":   {endDate.Format(config.DateLayout)},
+		}
+		body := strings.NewReader(values.Encode())
+
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/available-rooms-search", body)
+
+		// create stub call arguments
+		arg := db.ListStayRulesForDatesParams{}
+		arg.StartDate.Scan(startDate)
+		arg.EndDate.Scan(endDate)
+
+		// create stub return arguments, with a minimum stay rule on every room
+		rooms := randomRooms(2)
+		dbRooms := make([]db.Room, len(rooms))
+		dbRules := make([]db.StayRule, len(rooms))
+		for i, room := range rooms {
+			room.Export(&dbRooms[i])
+			dbRules[i] = db.StayRule{
+				ID:         util.RandomID(),
+				RoomID:     room.ID,
+				DaysOfWeek: db.AllDaysOfWeek,
+				MinNights:  2,
+			}
+			dbRules[i].StartDate.Scan(startDate)
+			dbRules[i].EndDate.Scan(startDate.AddDate(0, 0, 30))
+		}
+
+		//build stub
+		ts.MockDBStore.On("DeleteRoomHoldsByToken", mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		ts.MockDBStore.On("ListAvailableRooms", mock.Anything, mock.Anything).
+			Return(dbRooms, nil).
+			Once()
+		ts.MockDBStore.On("ListStayRulesForDates", mock.Anything, arg).
+			Return(dbRules, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify the rule is explained once
+		assert.Equal(t, http.StatusOK, rr.Code)
+		msg := StayRuleMessage(&db.StayRuleError{Rule: dbRules[0], Violation: db.ViolationMinNights})
+		assert.Equal(t, 1, strings.Count(rr.Body.String(), "<li>"+msg+"</li>"))
+		assert.False(t, app.Session.Exists(req.Context(), "rooms"))
+	})
+
 	// Test Error: invalid body data cause error in ParseForm()
 	t.Run("Ivalid Body Data", func(t *testing.T) {
 		// creating invalid body
@@ -761,6 +1152,7 @@ func TestServer_PostAvailableRoomsSearchHandler(t *testing.T) {
 		date := util.RandomDate()
 		startDate := date.Format(config.DateLayout)
 		endDate := date.Add(-time.Hour * 24 * 7).Format(config.DateLayout)
+		validEndDate := date.Add(time.Hour * 24 * 7).Format(config.DateLayout)
 
 		// create test cases for the form validation
 		tests := []struct {
@@ -800,6 +1192,22 @@ func TestServer_PostAvailableRoomsSearchHandler(t *testing.T) {
 					"end_date":   {util.RandomName()},
 				},
 			},
+			{
+				Name: "Invalid Adults",
+				Values: url.Values{
+					"start_date": {startDate},
+					"end_date":   {validEndDate},
+					"adults":     {"0"},
+				},
+			},
+			{
+				Name: "Invalid Children",
+				Values: url.Values{
+					"start_date": {startDate},
+					"end_date":   {validEndDate},
+					"children":   {util.RandomName()},
+				},
+			},
 		}
 
 		// create a new test server and a mock database store
@@ -846,8 +1254,10 @@ func TestServer_PostAvailableRoomsSearchHandler(t *testing.T) {
 		}
 
 		arg := db.ListAvailableRoomsParams{
-			Limit:  LimitRoomsPerPage,
-			Offset: 0,
+			Limit:    LimitRoomsPerPage,
+			Offset:   0,
+			Adults:   1,
+			Children: 0,
 		}
 		err := arg.StartDate.Scan(rsv.StartDate)
 		require.NoError(t, err)
@@ -863,6 +1273,9 @@ func TestServer_PostAvailableRoomsSearchHandler(t *testing.T) {
 		}
 
 		//build stub
+		ts.MockDBStore.On("DeleteRoomHoldsByToken", mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
 		ts.MockDBStore.On("ListAvailableRooms", mock.Anything, arg).
 			Return(nil, err).
 			Once()
@@ -1000,9 +1413,57 @@ func TestServer_AvailableRoomsListHandler(t *testing.T) {
 		ts := NewTestServer(t)
 		req := ts.NewRequestWithSession(t, http.MethodGet, "/available-rooms/1", nil)
 
-		// put rooms and reservation in session
+		// build stub
+		holdToken := util.RandomString(HoldTokenLength)
+		rsv.RoomID = rooms[1].ID
+		ts.MockDBStore.On("CreateRoomHoldTx", mock.Anything, matchRoomHold(rsv, holdToken)).
+			Return(db.RoomRestriction{}, nil).
+			Once()
+
+		// put rooms, reservation and hold token in session
+		app.Session.Put(req.Context(), "rooms", rooms)
+		app.Session.Put(req.Context(), "reservation", rsv)
+		app.Session.Put(req.Context(), "hold_token", holdToken)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// remove rooms and reservation from session
+		app.Session.Remove(req.Context(), "rooms")
+		app.Session.Remove(req.Context(), "reservation")
+
+		// checks that the room was added to the cart and remove it
+		cart, ok := app.Session.Pop(req.Context(), "cart").([]Room)
+		require.True(t, ok)
+		assert.Equal(t, []Room{rooms[1]}, cart)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/make-reservation", rr.Header().Get("Location"))
+	})
+
+	// Test OK: room is added to a cart with other rooms
+	t.Run("OK Add To Cart", func(t *testing.T) {
+		//create rooms slice with random data of n rooms
+		const N = 5
+		rooms := randomRooms(N)
+
+		// create random reservation
+		rsv := randomReservation()
+
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/available-rooms/2", nil)
+
+		// build stub
+		ts.MockDBStore.On("CreateRoomHoldTx", mock.Anything, mock.Anything).
+			Return(db.RoomRestriction{}, nil).
+			Once()
+
+		// put rooms, reservation and cart in session
 		app.Session.Put(req.Context(), "rooms", rooms)
 		app.Session.Put(req.Context(), "reservation", rsv)
+		app.Session.Put(req.Context(), "cart", []Room{rooms[0], rooms[2]})
 
 		//  server the request
 		rr := ts.ServeRequest(req)
@@ -1011,10 +1472,79 @@ func TestServer_AvailableRoomsListHandler(t *testing.T) {
 		app.Session.Remove(req.Context(), "rooms")
 		app.Session.Remove(req.Context(), "reservation")
 
+		// checks that the room was not added twice to the cart and remove it
+		cart, ok := app.Session.Pop(req.Context(), "cart").([]Room)
+		require.True(t, ok)
+		assert.Equal(t, []Room{rooms[0], rooms[2]}, cart)
+
 		// testify
 		assert.Equal(t, http.StatusSeeOther, rr.Code)
 		assert.Equal(t, "/make-reservation", rr.Header().Get("Location"))
 	})
+
+	// Test Error: room was held or booked by another guest
+	t.Run("Room No Longer Available", func(t *testing.T) {
+		//create rooms slice with random data of n rooms
+		const N = 5
+		rooms := randomRooms(N)
+
+		// create random reservation
+		rsv := randomReservation()
+
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/available-rooms/1", nil)
+
+		// build stub
+		ts.MockDBStore.On("CreateRoomHoldTx", mock.Anything, mock.Anything).
+			Return(db.RoomRestriction{}, db.ErrRoomUnavailable).
+			Once()
+
+		// put rooms and reservation in session
+		app.Session.Put(req.Context(), "rooms", rooms)
+		app.Session.Put(req.Context(), "reservation", rsv)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// remove rooms and reservation from session
+		app.Session.Remove(req.Context(), "rooms")
+		app.Session.Remove(req.Context(), "reservation")
+
+		// testify
+		assert.False(t, app.Session.Exists(req.Context(), "cart"))
+		assert.Equal(t, "This room is no longer available. Please choose another room.",
+			app.Session.PopString(req.Context(), "warning"))
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/available-rooms/available", rr.Header().Get("Location"))
+	})
+
+	// test handling the GET /available-rooms/{index} with index out of range
+	t.Run("Error Index Out of Range", func(t *testing.T) {
+		//create rooms slice with random data of n rooms
+		const N = 5
+		rooms := randomRooms(N)
+
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, fmt.Sprintf("/available-rooms/%d", N), nil)
+
+		// build stub
+		ts.BuildLogAnyErrorStub()
+
+		// put rooms in session
+		app.Session.Put(req.Context(), "rooms", rooms)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// remove rooms from session
+		app.Session.Remove(req.Context(), "rooms")
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/available-rooms/available", rr.Header().Get("Location"))
+	})
 }
 
 func TestServer_MakeReservationHandler(t *testing.T) {
@@ -1035,6 +1565,9 @@ func TestServer_MakeReservationHandler(t *testing.T) {
 			Room:      rRoom,
 		}
 
+		// build stub
+		ts.BuildQuoteStaysStub([]Room{rRoom}, rsv.StartDate, rsv.EndDate, []Guests{{Adults: 1}})
+
 		// put reservation in session
 		app.Session.Put(req.Context(), "reservation", rsv)
 
@@ -1046,6 +1579,82 @@ func TestServer_MakeReservationHandler(t *testing.T) {
 
 		// testify
 		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), (7 * rRoom.NightlyRate).String())
+	})
+
+	// Test OK: several rooms in cart
+	t.Run("OK Cart", func(t *testing.T) {
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/make-reservation", nil)
+
+		// create reservation and cart with random data to put in the session
+		rDate := util.RandomDate()
+		rooms := randomRooms(2)
+
+		rsv := Reservation{
+			StartDate: rDate,
+			EndDate:   rDate.Add(time.Hour * 24 * 7),
+			RoomID:    rooms[1].ID,
+			Room:      rooms[1],
+		}
+
+		// build stub
+		ts.BuildQuoteStaysStub(rooms, rsv.StartDate, rsv.EndDate, []Guests{{Adults: 1}, {Adults: 1}})
+
+		// put reservation and cart in session
+		app.Session.Put(req.Context(), "reservation", rsv)
+		app.Session.Put(req.Context(), "cart", rooms)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// remove reservation and cart from session
+		app.Session.Remove(req.Context(), "reservation")
+		app.Session.Remove(req.Context(), "cart")
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), rooms[0].Name)
+		assert.Contains(t, rr.Body.String(), rooms[1].Name)
+		assert.Contains(t, rr.Body.String(), (7 * (rooms[0].NightlyRate + rooms[1].NightlyRate)).String())
+	})
+
+	// Test Error: price quote failed
+	t.Run("Error Quote", func(t *testing.T) {
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/make-reservation", nil)
+
+		// create reservation with random data to put in the session
+		rsv := randomReservation()
+
+		// build stubs
+		err := errors.New("any error")
+		sErr := ServerError{
+			Prompt: "Unable to quote room price.",
+			URL:    req.URL.Path,
+			Err:    err,
+		}
+		ts.MockDBStore.On("QuoteStays", mock.Anything, mock.Anything).
+			Return(nil, err).
+			Once()
+		ts.BuildLogErrorStub(sErr)
+
+		// put reservation in session
+		app.Session.Put(req.Context(), "reservation", rsv)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get error message from session and remove it
+		app.Session.Remove(req.Context(), "reservation")
+		errMsg := app.Session.PopString(req.Context(), "error")
+		assert.Equal(t, sErr.Prompt, errMsg)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/", rr.Header().Get("Location"))
 	})
 
 	// Test Error: reservation missing from session
@@ -1071,114 +1680,92 @@ func TestServer_MakeReservationHandler(t *testing.T) {
 	})
 }
 
-func TestServer_PostMakeReservationHandler(t *testing.T) {
+func TestServer_PostRemoveCartRoomHandler(t *testing.T) {
 	// create initial reservation with random data to put in the session
 	rDate := util.RandomDate()
-	rRoom := randomRoom()
+	rooms := randomRooms(2)
 
 	initRsv := Reservation{
 		StartDate: rDate,
 		EndDate:   rDate.Add(time.Hour * 24 * 7),
-		RoomID:    rRoom.ID,
-		Room:      rRoom,
+		RoomID:    rooms[0].ID,
+		Room:      rooms[0],
 	}
 
-	// Test OK: reservation exists in session and form is valid
+	// newBody returns the body of a request removing roomID
+	newBody := func(roomID string) *strings.Reader {
+		return strings.NewReader(url.Values{"room_id": {roomID}}.Encode())
+	}
+
+	// Test OK: room is removed from the cart
 	t.Run("OK", func(t *testing.T) {
-		// create the final reservation that we are expected to get from the session
-		finalRsv := initRsv
-		finalRsv.FirstName = util.RandomName()
-		finalRsv.LastName = util.RandomName()
-		finalRsv.Email = util.RandomEmail()
-		finalRsv.Phone = util.RandomPhone()
-		finalRsv.Notes = util.RandomNote()
-		finalRsv.GenerateReservationCode()
-
-		// create form data for the body of the request
-		f := forms.New(nil)
-		f.Add("first_name", finalRsv.FirstName)
-		f.Add("last_name", finalRsv.LastName)
-		f.Add("email", finalRsv.Email)
-		f.Add("phone", finalRsv.Phone)
-		f.Add("notes", finalRsv.Notes)
-
-		// create the body of the request
-		body := strings.NewReader(f.Encode())
-
-		// create a new test server, a mock database store and a request
+		// create a new test server, and a new request
 		ts := NewTestServer(t)
-		req := ts.NewRequestWithSession(t, http.MethodPost, "/make-reservation", body)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/make-reservation/remove-room",
+			newBody(fmt.Sprint(rooms[0].ID)))
 
-		//create stub return arguments for CreateReservationTx
-		dbRsv := db.Reservation{}
-		finalRsv.Export(&dbRsv)
-
-		// build stub for CreateReservationTx
-		ts.MockDBStore.On("CreateReservationTx", mock.Anything, mock.Anything).
-			Return(dbRsv, nil).
+		// build stub
+		holdToken := util.RandomString(HoldTokenLength)
+		arg := db.DeleteRoomHoldParams{
+			RoomID:    rooms[0].ID,
+			HoldToken: pgtype.Text{String: holdToken, Valid: true},
+		}
+		ts.MockDBStore.On("DeleteRoomHold", mock.Anything, arg).
+			Return(nil).
 			Once()
 
-		// build stubs for mailing and logging of mail sent to guest and admin
-		ts.BuildSendAnyMailStub()
-		ts.BuildLogAnyInfoStub()
-		ts.BuildSendAnyMailStub()
-		ts.BuildLogAnyInfoStub()
-
-		// put reservation in session
+		// put reservation, cart and hold token in session
 		app.Session.Put(req.Context(), "reservation", initRsv)
+		app.Session.Put(req.Context(), "cart", rooms)
+		app.Session.Put(req.Context(), "hold_token", holdToken)
 
 		//  server the request
 		rr := ts.ServeRequest(req)
 
-		// check reservation is in session and removes it
-		scsRsv := app.Session.Pop(req.Context(), "reservation").(Reservation)
-		require.NotEmpty(t, scsRsv.Code)
-		require.Equal(t, finalRsv.FirstName, scsRsv.FirstName)
-		require.Equal(t, finalRsv.LastName, scsRsv.LastName)
-		require.Equal(t, finalRsv.Email, scsRsv.Email)
-		require.Equal(t, finalRsv.Phone, scsRsv.Phone)
-		require.WithinDuration(t, finalRsv.StartDate, scsRsv.StartDate, time.Second)
-		require.WithinDuration(t, finalRsv.EndDate, scsRsv.EndDate, time.Second)
-		require.Equal(t, finalRsv.RoomID, scsRsv.RoomID)
-		require.Equal(t, finalRsv.Notes, scsRsv.Notes)
-		require.Equal(t, finalRsv.Room, scsRsv.Room)
+		// checks the cart and the room of the reservation, and remove them
+		cart, ok := app.Session.Pop(req.Context(), "cart").([]Room)
+		require.True(t, ok)
+		assert.Equal(t, []Room{rooms[1]}, cart)
+
+		rsv, ok := app.Session.Pop(req.Context(), "reservation").(Reservation)
+		require.True(t, ok)
+		assert.Equal(t, rooms[1].ID, rsv.RoomID)
 
 		// testify
 		assert.Equal(t, http.StatusSeeOther, rr.Code)
-		assert.Equal(t, "/reservation-summary", rr.Header().Get("Location"))
+		assert.Equal(t, "/make-reservation", rr.Header().Get("Location"))
 	})
 
-	// Test Error: reservation missing from session
-	t.Run("Missing Reservation from Session", func(t *testing.T) {
-		// create a new test server, a mock database store and a request
+	// Test OK: last room is removed from the cart
+	t.Run("OK Empty Cart", func(t *testing.T) {
+		// create a new test server, and a new request
 		ts := NewTestServer(t)
-		req := ts.NewRequestWithSession(t, http.MethodPost, "/make-reservation", nil)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/make-reservation/remove-room",
+			newBody(fmt.Sprint(rooms[0].ID)))
 
 		// build stub
-		sErr := CreateServerError(ErrorMissingReservation, req.URL.Path, nil)
-		ts.BuildLogErrorStub(sErr)
+		ts.MockDBStore.On("DeleteRoomHold", mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+
+		// put reservation in session without a cart
+		app.Session.Put(req.Context(), "reservation", initRsv)
 
 		//  server the request
 		rr := ts.ServeRequest(req)
-
-		// get error message from session and removes it
-		errMsg := app.Session.PopString(req.Context(), "error")
-		assert.Equal(t, sErr.Prompt, errMsg)
+		app.Session.Remove(req.Context(), "reservation")
 
 		// testify
-		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
-		assert.Equal(t, "/", rr.Header().Get("Location"))
-
+		assert.False(t, app.Session.Exists(req.Context(), "cart"))
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/available-rooms/available", rr.Header().Get("Location"))
 	})
 
-	// Test Error: invalid body data cause error in ParseForm()
-	t.Run("Invalid Body Data", func(t *testing.T) {
-		// creating invalid body
-		body := strings.NewReader("%^")
-
-		// create a new test server, a mock database store and a request
+	// Test Error: invalid room id
+	t.Run("Invalid Room ID", func(t *testing.T) {
+		// create a new test server, and a new request
 		ts := NewTestServer(t)
-		req := ts.NewRequestWithSession(t, http.MethodPost, "/make-reservation", body)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/make-reservation/remove-room", newBody("abc"))
 
 		// build stub
 		ts.BuildLogAnyErrorStub()
@@ -1188,107 +1775,86 @@ func TestServer_PostMakeReservationHandler(t *testing.T) {
 
 		//  server the request
 		rr := ts.ServeRequest(req)
-
-		// remove reservation from session
 		app.Session.Remove(req.Context(), "reservation")
 
 		// get error message from session and removes it
 		errMsg := app.Session.PopString(req.Context(), "error")
-		sErr := CreateServerError(ErrorParseForm, req.URL.Path, nil)
+		sErr := CreateServerError(ErrorInvalidParameter, req.URL.Path, nil)
 		assert.Equal(t, sErr.Prompt, errMsg)
 
 		// testify
 		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
 		assert.Equal(t, "/make-reservation", rr.Header().Get("Location"))
-
 	})
 
-	// Test Error: reservation exists in session but form is invalid
-	t.Run("Invalid Form", func(t *testing.T) {
-		firstName := util.RandomName()
-		lastName := util.RandomName()
-		email := util.RandomEmail()
+	// Test Error: reservation missing from session
+	t.Run("Missing Reservation", func(t *testing.T) {
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/make-reservation/remove-room",
+			newBody(fmt.Sprint(rooms[0].ID)))
 
-		// create test cases for the form validation
-		tests := []struct {
-			Name   string
-			Values url.Values
-		}{
-			{
-				Name: "Missing First Name",
-				Values: url.Values{
-					"last_name": {lastName},
-					"email":     {email},
-				},
-			},
-			{
-				Name: "Missing Last Name",
-				Values: url.Values{
-					"first_name": {firstName},
-					"email":      {email},
-				},
-			},
-			{
-				Name: "Missing Email",
-				Values: url.Values{
-					"first_name": {firstName},
-					"last_name":  {lastName},
-				},
-			},
-			{
-				Name: "Invalid First Name",
-				Values: url.Values{
-					"first_name": {"x"},
-					"last_name":  {lastName},
-					"email":      {email},
-				},
-			},
-			{
-				Name: "Invalid Last Name",
-				Values: url.Values{
-					"first_name": {firstName},
-					"last_name":  {"x"},
-					"email":      {email},
-				},
-			},
-			{
-				Name: "Invalid Email",
-				Values: url.Values{
-					"first_name": {firstName},
-					"last_name":  {lastName},
-					"email":      {"x"},
-				},
-			},
-		}
+		// build stub
+		sErr := CreateServerError(ErrorMissingReservation, req.URL.Path, nil)
+		ts.BuildLogErrorStub(sErr)
 
-		// create a new test server and a mock database store
-		ts := NewTestServer(t)
+		//  server the request
+		rr := ts.ServeRequest(req)
 
-		for _, test := range tests {
-			t.Run(test.Name, func(t *testing.T) {
-				// create the body of the request
-				body := strings.NewReader(test.Values.Encode())
+		// get error message from session and removes it
+		errMsg := app.Session.PopString(req.Context(), "error")
+		assert.Equal(t, sErr.Prompt, errMsg)
 
-				// create a new request
-				req := ts.NewRequestWithSession(t, http.MethodPost, "/make-reservation", body)
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/", rr.Header().Get("Location"))
+	})
+}
 
-				// put reservation in session
-				app.Session.Put(req.Context(), "reservation", initRsv)
+// testCardToken is a card token authorized by the payment provider
+const testCardToken = "4242424242424242"
+
+// createReservationsTx mocks CreateReservationsTx by returning the reservations of args,
+// priced at 100 dollars per night
+func createReservationsTx(_ context.Context, args []db.CreateReservationParams, _ string, _ string) ([]db.Reservation, error) {
+	rsvs := make([]db.Reservation, len(args))
+	for i, arg := range args {
+		rsvs[i] = db.Reservation{
+			ID:         util.RandomID(),
+			Code:       arg.Code,
+			FirstName:  arg.FirstName,
+			LastName:   arg.LastName,
+			Email:      arg.Email,
+			Phone:      arg.Phone,
+			StartDate:  arg.StartDate,
+			EndDate:    arg.EndDate,
+			RoomID:     arg.RoomID,
+			Notes:      arg.Notes,
+			ParentCode: arg.ParentCode,
+			Adults:     arg.Adults,
+			Children:   arg.Children,
+			Status:     db.ReservationStatusPending,
+			TotalPrice: int64(arg.EndDate.Time.Sub(arg.StartDate.Time).Hours()/24) * 10000,
+		}
+	}
 
-				//  server the request
-				rr := ts.ServeRequest(req)
+	return rsvs, nil
+}
 
-				// remove reservation from session
-				app.Session.Remove(req.Context(), "reservation")
+func TestServer_PostMakeReservationHandler(t *testing.T) {
+	// create initial reservation with random data to put in the session
+	rDate := util.RandomDate()
+	rRoom := randomRoom()
 
-				// testify
-				assert.Equal(t, http.StatusOK, rr.Code)
-			})
-		}
-	})
+	initRsv := Reservation{
+		StartDate: rDate,
+		EndDate:   rDate.Add(time.Hour * 24 * 7),
+		RoomID:    rRoom.ID,
+		Room:      rRoom,
+	}
 
-	// Test Error: reservation exists and form it valid, but internal server error on CreateReservation
-	t.Run("Internal Server Error", func(t *testing.T) {
+	// Test OK: reservation exists in session and form is valid
+	t.Run("OK", func(t *testing.T) {
 		// create the final reservation that we are expected to get from the session
 		finalRsv := initRsv
 		finalRsv.FirstName = util.RandomName()
@@ -1305,6 +1871,7 @@ func TestServer_PostMakeReservationHandler(t *testing.T) {
 		f.Add("email", finalRsv.Email)
 		f.Add("phone", finalRsv.Phone)
 		f.Add("notes", finalRsv.Notes)
+		f.Add("payment_token", testCardToken)
 
 		// create the body of the request
 		body := strings.NewReader(f.Encode())
@@ -1313,20 +1880,28 @@ func TestServer_PostMakeReservationHandler(t *testing.T) {
 		ts := NewTestServer(t)
 		req := ts.NewRequestWithSession(t, http.MethodPost, "/make-reservation", body)
 
-		// create stub return arguments
-		err := errors.New("this is a test error")
+		// build stubs for the deposit authorized before booking
+		ts.BuildAuthorizeDepositsStub(1, Price(7*10000), testCardToken)
 
-		sErr := ServerError{
-			Prompt: "Unable to create reservation.",
-			URL:    req.URL.Path,
-			Err:    err,
-		}
+		// build stub for CreateReservationsTx
+		ts.MockDBStore.On("CreateReservationsTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(createReservationsTx, nil).
+			Once()
 
-		// build stub
-		ts.MockDBStore.On("CreateReservationTx", mock.Anything, mock.Anything).
-			Return(db.Reservation{}, err).
+		// build stubs for the deposit captured and the reservation confirmed
+		ts.BuildCaptureDepositsStub(1)
+
+		// build stub for the taxes and fees of the reservation
+		charges := []db.ReservationCharge{{Name: "Cleaning Fee", Amount: 4000}}
+		ts.MockDBStore.On("ListReservationCharges", mock.Anything, mock.Anything).
+			Return(charges, nil).
 			Once()
-		ts.BuildLogErrorStub(sErr)
+
+		// build stubs for mailing and logging of mail sent to guest and admin
+		ts.BuildSendAnyMailStub()
+		ts.BuildLogAnyInfoStub()
+		ts.BuildSendAnyMailStub()
+		ts.BuildLogAnyInfoStub()
 
 		// put reservation in session
 		app.Session.Put(req.Context(), "reservation", initRsv)
@@ -1334,67 +1909,7216 @@ func TestServer_PostMakeReservationHandler(t *testing.T) {
 		//  server the request
 		rr := ts.ServeRequest(req)
 
-		// get error message from session and remove it
-		errMsg := app.Session.PopString(req.Context(), "error")
-		assert.Equal(t, sErr.Prompt, errMsg)
+		// check reservation is in session and removes it
+		scsRsv := app.Session.Pop(req.Context(), "reservation").(Reservation)
+		require.NotEmpty(t, scsRsv.Code)
+		require.Equal(t, finalRsv.FirstName, scsRsv.FirstName)
+		require.Equal(t, finalRsv.LastName, scsRsv.LastName)
+		require.Equal(t, finalRsv.Email, scsRsv.Email)
+		require.Equal(t, finalRsv.Phone, scsRsv.Phone)
+		require.WithinDuration(t, finalRsv.StartDate, scsRsv.StartDate, time.Second)
+		require.WithinDuration(t, finalRsv.EndDate, scsRsv.EndDate, time.Second)
+		require.Equal(t, finalRsv.RoomID, scsRsv.RoomID)
+		require.Equal(t, finalRsv.Notes, scsRsv.Notes)
+		require.Equal(t, finalRsv.Room, scsRsv.Room)
+		require.Equal(t, scsRsv.Code, scsRsv.ParentCode)
+		require.Equal(t, Price(7*10000), scsRsv.TotalPrice)
+		require.Len(t, scsRsv.Charges, 1)
+		require.Equal(t, "Cleaning Fee", scsRsv.Charges[0].Name)
+		require.Equal(t, Price(4000), scsRsv.Charges[0].Amount)
+		require.Equal(t, ReservationConfirmed, scsRsv.Status)
+
+		scsRsvs := app.Session.Pop(req.Context(), "reservations").([]Reservation)
+		require.Equal(t, []Reservation{scsRsv}, scsRsvs)
+
+		// check the deposit of the reservation is captured
+		ts.MockPayments.AssertCalled(t, "Capture", "fake_auth_000001", int64(7*10000*app.DepositPercent/100))
 
 		// testify
-		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
-		assert.Equal(t, "/", rr.Header().Get("Location"))
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/reservation-summary", rr.Header().Get("Location"))
 	})
-}
 
-func TestServer_ReservationSummaryHandler(t *testing.T) {
-	// Test Error: reservation data is missing from session
-	t.Run("Error Missing Reservation Data", func(t *testing.T) {
-		// create a new test server, and a new request
-		ts := NewTestServer(t)
-		req := ts.NewRequestWithSession(t, http.MethodGet, "/reservation-summary", nil)
+	// Test OK: several rooms in cart are booked together
+	t.Run("OK Cart", func(t *testing.T) {
+		rooms := randomRooms(3)
 
-		// build stub
-		sErr := CreateServerError(ErrorMissingReservation, req.URL.Path, nil)
-		ts.BuildLogErrorStub(sErr)
+		// create form data for the body of the request
+		f := forms.New(nil)
+		f.Add("first_name", util.RandomName())
+		f.Add("last_name", util.RandomName())
+		f.Add("email", util.RandomEmail())
+		f.Add("adults", "4")
+		f.Add("children", "1")
+		f.Add("payment_token", testCardToken)
 
-		//  server the request
-		rr := ts.ServeRequest(req)
+		// the party is split across the rooms, filling the first room first
+		split := []Guests{{Adults: 2, Children: 1}, {Adults: 1}, {Adults: 1}}
 
-		// get error message from session and remove it
-		errMsg := app.Session.PopString(req.Context(), "error")
-		assert.Equal(t, sErr.Prompt, errMsg)
+		// create the body of the request
+		body := strings.NewReader(f.Encode())
 
-		// testify
-		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
-		assert.Equal(t, "/", rr.Header().Get("Location"))
-	})
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/make-reservation", body)
 
-	// Test OK
-	t.Run("OK", func(t *testing.T) {
-		//create rooms slice with random data of n rooms
-		const N = 5
+		// build stubs for the deposits authorized before booking
+		ts.BuildAuthorizeDepositsStub(len(rooms), Price(7*10000), testCardToken)
+
+		// build stub for CreateReservationsTx, which checks that all rooms share the same parent code
+		// and that every reservation holds the guests staying in its room
+		ts.MockDBStore.On("CreateReservationsTx", mock.Anything, mock.MatchedBy(func(args []db.CreateReservationParams) bool {
+			if len(args) != len(rooms) {
+				return false
+			}
+			for i, arg := range args {
+				if arg.RoomID != rooms[i].ID || arg.ParentCode.String != args[0].Code ||
+					int(arg.Adults) != split[i].Adults || int(arg.Children) != split[i].Children {
+					return false
+				}
+			}
+			return true
+		}), mock.Anything, mock.Anything).
+			Return(createReservationsTx, nil).
+			Once()
+		ts.MockDBStore.On("ListReservationCharges", mock.Anything, mock.Anything).
+			Return([]db.ReservationCharge{}, nil).
+			Times(len(rooms))
+
+		// build stubs for the deposits captured and the reservations confirmed
+		ts.BuildCaptureDepositsStub(len(rooms))
+
+		// build stubs for mailing and logging of a single mail sent to guest and admin
+		ts.BuildSendAnyMailStub()
+		ts.BuildLogAnyInfoStub()
+		ts.BuildSendAnyMailStub()
+		ts.BuildLogAnyInfoStub()
+
+		// put reservation and cart in session
+		app.Session.Put(req.Context(), "reservation", initRsv)
+		app.Session.Put(req.Context(), "cart", rooms)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// check reservations are in session and removes them
+		app.Session.Remove(req.Context(), "reservation")
+		app.Session.Remove(req.Context(), "cart")
+		scsRsvs := app.Session.Pop(req.Context(), "reservations").([]Reservation)
+		require.Len(t, scsRsvs, len(rooms))
+
+		codes := make(util.KeysMap)
+		for i, rsv := range scsRsvs {
+			assert.Equal(t, rooms[i], rsv.Room)
+			assert.Equal(t, scsRsvs[0].Code, rsv.ParentCode)
+			util.RequireUnique(t, rsv.Code, codes)
+			assert.Equal(t, ReservationConfirmed, rsv.Status)
+		}
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/reservation-summary", rr.Header().Get("Location"))
+	})
+
+	// Test Error: a room in cart is no longer available
+	t.Run("Room Unavailable", func(t *testing.T) {
+		// create form data for the body of the request
+		f := forms.New(nil)
+		f.Add("first_name", util.RandomName())
+		f.Add("last_name", util.RandomName())
+		f.Add("email", util.RandomEmail())
+		f.Add("adults", "2")
+		f.Add("payment_token", testCardToken)
+
+		// create the body of the request
+		body := strings.NewReader(f.Encode())
+
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/make-reservation", body)
+
+		// build stubs
+		ts.BuildAuthorizeDepositsStub(2, Price(7*10000), testCardToken)
+		ts.MockDBStore.On("CreateReservationsTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, db.ErrRoomUnavailable).
+			Once()
+		ts.BuildVoidDepositsStub(2)
+
+		// put reservation and cart in session
+		app.Session.Put(req.Context(), "reservation", initRsv)
+		app.Session.Put(req.Context(), "cart", randomRooms(2))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+		app.Session.Remove(req.Context(), "reservation")
+
+		// checks that cart was emptied
+		assert.False(t, app.Session.Exists(req.Context(), "cart"))
+
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "One of the rooms is no longer available. Please search again.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/available-rooms-search", rr.Header().Get("Location"))
+	})
+
+	// Test Error: a room in cart breaks a stay rule
+	t.Run("Stay Rule", func(t *testing.T) {
+		// create form data for the body of the request
+		f := forms.New(nil)
+		f.Add("first_name", util.RandomName())
+		f.Add("last_name", util.RandomName())
+		f.Add("email", util.RandomEmail())
+		f.Add("adults", "2")
+		f.Add("payment_token", testCardToken)
+
+		// create the body of the request
+		body := strings.NewReader(f.Encode())
+
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/make-reservation", body)
+
+		// create stub return arguments
+		ruleErr := &db.StayRuleError{
+			Rule: db.StayRule{
+				DaysOfWeek: db.DaysOfWeekMask(time.Sunday),
+				StartDate:  pgtype.Date{Time: initRsv.StartDate, Valid: true},
+				EndDate:    pgtype.Date{Time: initRsv.EndDate, Valid: true},
+			},
+			Violation: db.ViolationClosedToArrival,
+		}
+
+		// build stubs
+		ts.BuildAuthorizeDepositsStub(2, Price(7*10000), testCardToken)
+		ts.MockDBStore.On("CreateReservationsTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, ruleErr).
+			Once()
+		ts.BuildVoidDepositsStub(2)
+
+		// put reservation and cart in session
+		app.Session.Put(req.Context(), "reservation", initRsv)
+		app.Session.Put(req.Context(), "cart", randomRooms(2))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+		app.Session.Remove(req.Context(), "reservation")
+
+		// checks that cart was emptied
+		assert.False(t, app.Session.Exists(req.Context(), "cart"))
+
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, StayRuleMessage(ruleErr), msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/available-rooms-search", rr.Header().Get("Location"))
+	})
+
+	// Test OK: the promo code is applied to the prices without booking
+	t.Run("Apply Promo Code", func(t *testing.T) {
+		// create form data for the body of the request
+		f := forms.New(nil)
+		f.Add("promo_code", "summer")
+		f.Add("apply_promo", "1")
+
+		// create the body of the request
+		body := strings.NewReader(f.Encode())
+
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/make-reservation", body)
+
+		// build stub for the quote of the room with the promo code, for a single adult as no guests were searched
+		arg := db.QuoteStayParams{RoomID: rRoom.ID, Adults: 1, PromoCode: "SUMMER"}
+		arg.StartDate.Scan(initRsv.StartDate)
+		arg.EndDate.Scan(initRsv.EndDate)
+		ts.MockDBStore.On("QuoteStays", mock.Anything, []db.QuoteStayParams{arg}).
+			Return([]db.Quote{{RoomID: rRoom.ID, Subtotal: 70000, Discount: 7000, Total: 63000}}, nil).
+			Once()
+
+		// put reservation in session
+		app.Session.Put(req.Context(), "reservation", initRsv)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+		app.Session.Remove(req.Context(), "reservation")
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Promo Discount: -$70.00")
+		assert.Contains(t, rr.Body.String(), "Total Price: $630.00")
+	})
+
+	// Test OK: every room in cart is quoted for the guests staying in it
+	t.Run("Quote Cart Guests", func(t *testing.T) {
+		rooms := randomRooms(2)
+		split := []Guests{{Adults: 2, Children: 1}, {Adults: 1}}
+
+		// create form data for the body of the request
+		f := forms.New(nil)
+		f.Add("adults", "3")
+		f.Add("children", "1")
+		f.Add("apply_promo", "1")
+
+		// create the body of the request
+		body := strings.NewReader(f.Encode())
+
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/make-reservation", body)
+
+		// build stub for the quote of every room with its own guests
+		args := make([]db.QuoteStayParams, len(rooms))
+		quotes := make([]db.Quote, len(rooms))
+		for i, room := range rooms {
+			args[i] = db.QuoteStayParams{RoomID: room.ID, Adults: int32(split[i].Adults), Children: int32(split[i].Children)}
+			args[i].StartDate.Scan(initRsv.StartDate)
+			args[i].EndDate.Scan(initRsv.EndDate)
+			quotes[i] = db.Quote{RoomID: room.ID, Subtotal: 70000, Total: 70000}
+		}
+		ts.MockDBStore.On("QuoteStays", mock.Anything, args).
+			Return(quotes, nil).
+			Once()
+
+		// put reservation and cart in session
+		app.Session.Put(req.Context(), "reservation", initRsv)
+		app.Session.Put(req.Context(), "cart", rooms)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+		app.Session.Remove(req.Context(), "reservation")
+		app.Session.Remove(req.Context(), "cart")
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Guests: 2 adults, 1 children")
+		assert.Contains(t, rr.Body.String(), "Guests: 1 adults, 0 children")
+		assert.Contains(t, rr.Body.String(), "Total Price: $1400.00")
+	})
+
+	// Test Error: the promo code does not apply and the page is rendered without it
+	t.Run("Promo Code Rejected", func(t *testing.T) {
+		// create form data for the body of the request
+		f := forms.New(nil)
+		f.Add("first_name", util.RandomName())
+		f.Add("last_name", util.RandomName())
+		f.Add("email", util.RandomEmail())
+		f.Add("promo_code", "SUMMER")
+		f.Add("payment_token", testCardToken)
+
+		// create the body of the request
+		body := strings.NewReader(f.Encode())
+
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/make-reservation", body)
+
+		// build stubs for the quote of the deposit and the quote of the page rendered
+		promoErr := &db.PromoCodeError{Code: "SUMMER", Rejection: db.RejectionUsedUp}
+		ts.MockDBStore.On("QuoteStays", mock.Anything, mock.MatchedBy(func(args []db.QuoteStayParams) bool {
+			return args[0].PromoCode == "SUMMER"
+		})).
+			Return(nil, promoErr).
+			Twice()
+		ts.BuildQuoteStaysStub([]Room{rRoom}, initRsv.StartDate, initRsv.EndDate, []Guests{{Adults: 1}})
+
+		// put reservation in session
+		app.Session.Put(req.Context(), "reservation", initRsv)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+		app.Session.Remove(req.Context(), "reservation")
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), PromoCodeMessage(promoErr))
+		assert.Equal(t, 1, strings.Count(rr.Body.String(), PromoCodeMessage(promoErr)))
+	})
+
+	// Test Error: the card is declined and the page is rendered without booking
+	t.Run("Card Declined", func(t *testing.T) {
+		// create form data for the body of the request
+		f := forms.New(nil)
+		f.Add("first_name", util.RandomName())
+		f.Add("last_name", util.RandomName())
+		f.Add("email", util.RandomEmail())
+		f.Add("payment_token", payments.DeclinedCardToken)
+
+		// create the body of the request
+		body := strings.NewReader(f.Encode())
+
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/make-reservation", body)
+
+		// build stubs for the quote of the deposit, the declined authorization and the quote of the page rendered
+		ts.BuildQuoteStaysStub([]Room{rRoom}, initRsv.StartDate, initRsv.EndDate, []Guests{{Adults: 1}})
+		ts.MockPayments.On("Authorize", mock.Anything).
+			Return(payments.Transaction{Status: payments.StatusFailed}, payments.ErrPaymentDeclined).
+			Once()
+		ts.BuildQuoteStaysStub([]Room{rRoom}, initRsv.StartDate, initRsv.EndDate, []Guests{{Adults: 1}})
+
+		// put reservation in session
+		app.Session.Put(req.Context(), "reservation", initRsv)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+		app.Session.Remove(req.Context(), "reservation")
+
+		// testify
+		ts.MockDBStore.AssertNotCalled(t, "CreateReservationsTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Your card was declined.")
+	})
+
+	// Test Error: the deposit is not captured and the reservation is booked but left pending
+	t.Run("Capture Error", func(t *testing.T) {
+		// create form data for the body of the request
+		f := forms.New(nil)
+		f.Add("first_name", util.RandomName())
+		f.Add("last_name", util.RandomName())
+		f.Add("email", util.RandomEmail())
+		f.Add("payment_token", testCardToken)
+
+		// create the body of the request
+		body := strings.NewReader(f.Encode())
+
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/make-reservation", body)
+
+		// build stubs for the booking
+		ts.BuildAuthorizeDepositsStub(1, Price(7*10000), testCardToken)
+		ts.MockDBStore.On("CreateReservationsTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(createReservationsTx, nil).
+			Once()
+		ts.MockDBStore.On("ListReservationCharges", mock.Anything, mock.Anything).
+			Return([]db.ReservationCharge{}, nil).
+			Once()
+
+		// build stubs for the authorization recorded and the capture failed
+		ts.MockPayments.On("Name").Return("fake")
+		ts.MockDBStore.On("CreatePayment", mock.Anything, mock.MatchedBy(func(arg db.CreatePaymentParams) bool {
+			return arg.Kind == db.PaymentKindAuthorization
+		})).
+			Return(db.Payment{ID: util.RandomID()}, nil).
+			Once()
+		ts.MockPayments.On("Capture", "fake_auth_000001", mock.Anything).
+			Return(payments.Transaction{}, payments.ErrInvalidPayment).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		// build stubs for mailing and logging of mail sent to guest and admin
+		ts.BuildSendAnyMailStub()
+		ts.BuildLogAnyInfoStub()
+		ts.BuildSendAnyMailStub()
+		ts.BuildLogAnyInfoStub()
+
+		// put reservation in session
+		app.Session.Put(req.Context(), "reservation", initRsv)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// check the reservation is left pending
+		app.Session.Remove(req.Context(), "reservations")
+		scsRsv := app.Session.Pop(req.Context(), "reservation").(Reservation)
+		assert.Equal(t, ReservationPending, scsRsv.Status)
+
+		// testify
+		ts.MockDBStore.AssertNotCalled(t, "UpdateReservationStatusTx", mock.Anything, mock.Anything)
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/reservation-summary", rr.Header().Get("Location"))
+	})
+
+	// Test Error: reservation missing from session
+	t.Run("Missing Reservation from Session", func(t *testing.T) {
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/make-reservation", nil)
+
+		// build stub
+		sErr := CreateServerError(ErrorMissingReservation, req.URL.Path, nil)
+		ts.BuildLogErrorStub(sErr)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get error message from session and removes it
+		errMsg := app.Session.PopString(req.Context(), "error")
+		assert.Equal(t, sErr.Prompt, errMsg)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/", rr.Header().Get("Location"))
+
+	})
+
+	// Test Error: invalid body data cause error in ParseForm()
+	t.Run("Invalid Body Data", func(t *testing.T) {
+		// creating invalid body
+		body := strings.NewReader("%^")
+
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/make-reservation", body)
+
+		// build stub
+		ts.BuildLogAnyErrorStub()
+
+		// put reservation in session
+		app.Session.Put(req.Context(), "reservation", initRsv)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// remove reservation from session
+		app.Session.Remove(req.Context(), "reservation")
+
+		// get error message from session and removes it
+		errMsg := app.Session.PopString(req.Context(), "error")
+		sErr := CreateServerError(ErrorParseForm, req.URL.Path, nil)
+		assert.Equal(t, sErr.Prompt, errMsg)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/make-reservation", rr.Header().Get("Location"))
+
+	})
+
+	// Test Error: reservation exists in session but form is invalid
+	t.Run("Invalid Form", func(t *testing.T) {
+		firstName := util.RandomName()
+		lastName := util.RandomName()
+		email := util.RandomEmail()
+
+		// create test cases for the form validation
+		tests := []struct {
+			Name   string
+			Values url.Values
+		}{
+			{
+				Name: "Missing First Name",
+				Values: url.Values{
+					"last_name": {lastName},
+					"email":     {email},
+				},
+			},
+			{
+				Name: "Missing Last Name",
+				Values: url.Values{
+					"first_name": {firstName},
+					"email":      {email},
+				},
+			},
+			{
+				Name: "Missing Email",
+				Values: url.Values{
+					"first_name": {firstName},
+					"last_name":  {lastName},
+				},
+			},
+			{
+				Name: "Invalid First Name",
+				Values: url.Values{
+					"first_name": {"x"},
+					"last_name":  {lastName},
+					"email":      {email},
+				},
+			},
+			{
+				Name: "Invalid Last Name",
+				Values: url.Values{
+					"first_name": {firstName},
+					"last_name":  {"x"},
+					"email":      {email},
+				},
+			},
+			{
+				Name: "Invalid Email",
+				Values: url.Values{
+					"first_name": {firstName},
+					"last_name":  {lastName},
+					"email":      {"x"},
+				},
+			},
+			{
+				Name: "Invalid Adults",
+				Values: url.Values{
+					"first_name": {firstName},
+					"last_name":  {lastName},
+					"email":      {email},
+					"adults":     {"0"},
+				},
+			},
+			{
+				Name: "Missing Payment Token",
+				Values: url.Values{
+					"first_name": {firstName},
+					"last_name":  {lastName},
+					"email":      {email},
+				},
+			},
+			{
+				Name: "Room Capacity Exceeded",
+				Values: url.Values{
+					"first_name": {firstName},
+					"last_name":  {lastName},
+					"email":      {email},
+					"adults":     {fmt.Sprint(initRsv.Room.MaxAdults + 1)},
+				},
+			},
+		}
+
+		// create a new test server and a mock database store
+		ts := NewTestServer(t)
+
+		for _, test := range tests {
+			t.Run(test.Name, func(t *testing.T) {
+				// create the body of the request
+				body := strings.NewReader(test.Values.Encode())
+
+				// create a new request
+				req := ts.NewRequestWithSession(t, http.MethodPost, "/make-reservation", body)
+
+				// build stub
+				ts.MockDBStore.On("QuoteStays", mock.Anything, mock.Anything).
+					Return([]db.Quote{{RoomID: initRsv.RoomID}}, nil).
+					Once()
+
+				// put reservation in session
+				app.Session.Put(req.Context(), "reservation", initRsv)
+
+				//  server the request
+				rr := ts.ServeRequest(req)
+
+				// remove reservation from session
+				app.Session.Remove(req.Context(), "reservation")
+
+				// testify
+				assert.Equal(t, http.StatusOK, rr.Code)
+			})
+		}
+	})
+
+	// Test Error: reservation exists and form it valid, but internal server error on CreateReservation
+	t.Run("Internal Server Error", func(t *testing.T) {
+		// create the final reservation that we are expected to get from the session
+		finalRsv := initRsv
+		finalRsv.FirstName = util.RandomName()
+		finalRsv.LastName = util.RandomName()
+		finalRsv.Email = util.RandomEmail()
+		finalRsv.Phone = util.RandomPhone()
+		finalRsv.Notes = util.RandomNote()
+		finalRsv.GenerateReservationCode()
+
+		// create form data for the body of the request
+		f := forms.New(nil)
+		f.Add("first_name", finalRsv.FirstName)
+		f.Add("last_name", finalRsv.LastName)
+		f.Add("email", finalRsv.Email)
+		f.Add("phone", finalRsv.Phone)
+		f.Add("notes", finalRsv.Notes)
+		f.Add("payment_token", testCardToken)
+
+		// create the body of the request
+		body := strings.NewReader(f.Encode())
+
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/make-reservation", body)
+
+		// create stub return arguments
+		err := errors.New("this is a test error")
+
+		sErr := ServerError{
+			Prompt: "Unable to create reservation.",
+			URL:    req.URL.Path,
+			Err:    err,
+		}
+
+		// build stubs
+		ts.BuildAuthorizeDepositsStub(1, Price(7*10000), testCardToken)
+		ts.MockDBStore.On("CreateReservationsTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, err).
+			Once()
+		ts.BuildVoidDepositsStub(1)
+		ts.BuildLogErrorStub(sErr)
+
+		// put reservation in session
+		app.Session.Put(req.Context(), "reservation", initRsv)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get error message from session and remove it
+		errMsg := app.Session.PopString(req.Context(), "error")
+		assert.Equal(t, sErr.Prompt, errMsg)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_ReservationSummaryHandler(t *testing.T) {
+	// Test Error: reservation data is missing from session
+	t.Run("Error Missing Reservation Data", func(t *testing.T) {
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/reservation-summary", nil)
+
+		// build stub
+		sErr := CreateServerError(ErrorMissingReservation, req.URL.Path, nil)
+		ts.BuildLogErrorStub(sErr)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get error message from session and remove it
+		errMsg := app.Session.PopString(req.Context(), "error")
+		assert.Equal(t, sErr.Prompt, errMsg)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/", rr.Header().Get("Location"))
+	})
+
+	// Test OK
+	t.Run("OK", func(t *testing.T) {
+		//create rooms slice with random data of n rooms
+		const N = 5
 		rooms := randomRooms(N)
 
-		// create random reservation
-		rsv := randomReservation()
+		// create random reservation
+		rsv := randomReservation()
+
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/reservation-summary", nil)
+
+		// put rooms and reservation in session
+		app.Session.Put(req.Context(), "rooms", rooms)
+		app.Session.Put(req.Context(), "reservation", rsv)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// checks that rooms and reservation are not in session
+		ok := app.Session.Exists(req.Context(), "rooms")
+		require.False(t, ok)
+
+		ok = app.Session.Exists(req.Context(), "reservation")
+		require.False(t, ok)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	// Test OK: several reservations booked together
+	t.Run("OK Multiple Rooms", func(t *testing.T) {
+		// create random reservations booked together
+		rsvs := []Reservation{randomReservation(), randomReservation()}
+
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/reservation-summary", nil)
+
+		// put reservations in session
+		app.Session.Put(req.Context(), "reservation", rsvs[0])
+		app.Session.Put(req.Context(), "reservations", rsvs)
+		app.Session.Put(req.Context(), "cart", []Room{rsvs[0].Room, rsvs[1].Room})
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// checks that reservations and cart are not in session
+		require.False(t, app.Session.Exists(req.Context(), "reservations"))
+		require.False(t, app.Session.Exists(req.Context(), "cart"))
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		for _, rsv := range rsvs {
+			assert.Contains(t, rr.Body.String(), rsv.Code)
+			assert.Contains(t, rr.Body.String(), rsv.Room.Name)
+		}
+	})
+}
+
+func TestServer_PostFindReservationHandler(t *testing.T) {
+	// create random reservation with room data, with an uppercase code as generated by the server
+	rsv := randomReservation()
+	rsv.Code = strings.ToUpper(rsv.Code)
+
+	// create stub call arguments
+	arg := db.GetReservationByLastNameParams{
+		Code:     rsv.Code,
+		LastName: rsv.LastName,
+	}
+
+	// create form data for the body of the request
+	values := url.Values{
+		"code":      {strings.ToLower(rsv.Code)},
+		"last_name": {rsv.LastName},
+	}
+
+	// Test OK: reservation is found and put in session
+	t.Run("OK", func(t *testing.T) {
+		// create stub return arguments
+		dbRsv := db.Reservation{}
+		rsv.Export(&dbRsv)
+
+		dbRoom := db.Room{}
+		rsv.Room.Export(&dbRoom)
+
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		body := strings.NewReader(values.Encode())
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/find-reservation", body)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationByLastName", mock.Anything, arg).
+			Return(dbRsv, nil).
+			Once()
+		ts.MockDBStore.On("GetRoom", mock.Anything, rsv.RoomID).
+			Return(dbRoom, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// check reservation is in session and removes it
+		scsRsv, ok := app.Session.Pop(req.Context(), "lookup").(Reservation)
+		require.True(t, ok)
+		assert.Equal(t, rsv.ID, scsRsv.ID)
+		assert.Equal(t, rsv.Room.ID, scsRsv.Room.ID)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/my-reservation", rr.Header().Get("Location"))
+	})
+
+	// Test Not Found: no reservation matches code and last name
+	t.Run("Not Found", func(t *testing.T) {
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		body := strings.NewReader(values.Encode())
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/find-reservation", body)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationByLastName", mock.Anything, arg).
+			Return(db.Reservation{}, pgx.ErrNoRows).
+			Once()
+		ts.BuildLogAnyInfoStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// check reservation is not in session
+		ok := app.Session.Exists(req.Context(), "lookup")
+		require.False(t, ok)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "No reservation matches the code and last name provided.")
+	})
+
+	// Test Error: invalid body data cause error in ParseForm()
+	t.Run("Invalid Body Data", func(t *testing.T) {
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/find-reservation", strings.NewReader("%^"))
+
+		// build stub
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get error message from session and removes it
+		errMsg := app.Session.PopString(req.Context(), "error")
+		sErr := CreateServerError(ErrorParseForm, req.URL.Path, nil)
+		assert.Equal(t, sErr.Prompt, errMsg)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/find-reservation", rr.Header().Get("Location"))
+	})
+
+	// Test Error: form is invalid
+	t.Run("Invalid Form", func(t *testing.T) {
+		tests := []struct {
+			Name   string
+			Values url.Values
+		}{
+			{
+				Name:   "Missing Code",
+				Values: url.Values{"last_name": {rsv.LastName}},
+			},
+			{
+				Name:   "Missing Last Name",
+				Values: url.Values{"code": {rsv.Code}},
+			},
+		}
+
+		// create a new test server and a mock database store
+		ts := NewTestServer(t)
+
+		for _, test := range tests {
+			t.Run(test.Name, func(t *testing.T) {
+				// create a new request
+				body := strings.NewReader(test.Values.Encode())
+				req := ts.NewRequestWithSession(t, http.MethodPost, "/find-reservation", body)
+
+				//  server the request
+				rr := ts.ServeRequest(req)
+
+				// testify
+				assert.Equal(t, http.StatusOK, rr.Code)
+			})
+		}
+	})
+
+	// Test Error: internal server error on GetReservationByLastName
+	t.Run("Internal Server Error", func(t *testing.T) {
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		body := strings.NewReader(values.Encode())
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/find-reservation", body)
+
+		// create stub return arguments
+		err := errors.New("any error")
+
+		sErr := ServerError{
+			Prompt: "Unable to load reservation from database.",
+			URL:    req.URL.Path,
+			Err:    err,
+		}
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationByLastName", mock.Anything, arg).
+			Return(db.Reservation{}, err).
+			Once()
+		ts.BuildLogErrorStub(sErr)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get error message from session and remove it
+		errMsg := app.Session.PopString(req.Context(), "error")
+		assert.Equal(t, sErr.Prompt, errMsg)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/find-reservation", rr.Header().Get("Location"))
+	})
+
+	// Test Error: too many lookups from the same client
+	t.Run("Rate Limited", func(t *testing.T) {
+		// create a new test server and a mock database store
+		ts := NewTestServer(t)
+
+		for i := 0; i <= LookupRateLimit; i++ {
+			// create a new request with a missing last name
+			body := strings.NewReader(url.Values{"code": {rsv.Code}}.Encode())
+			req := ts.NewRequestWithSession(t, http.MethodPost, "/find-reservation", body)
+
+			//  server the request
+			rr := ts.ServeRequest(req)
+
+			// testify
+			if i < LookupRateLimit {
+				assert.Equal(t, http.StatusOK, rr.Code)
+			} else {
+				assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+			}
+		}
+	})
+}
+
+func TestServer_MyReservationHandler(t *testing.T) {
+	// Test OK: reservation found by the guest exists in session
+	t.Run("OK", func(t *testing.T) {
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/my-reservation", nil)
+
+		// put reservation in session
+		rsv, row, _ := adminReservationRow()
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		// build stub
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// checks that reservation is kept in session and remove it
+		ok := app.Session.Exists(req.Context(), "lookup")
+		require.True(t, ok)
+		app.Session.Remove(req.Context(), "lookup")
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), rsv.Code)
+	})
+
+	// Test OK: the reservation changed by staff since it was found is shown as changed
+	t.Run("OK Changed", func(t *testing.T) {
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/my-reservation", nil)
+
+		// put reservation in session, and change its dates in the database
+		rsv, row, _ := adminReservationRow()
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		changed := rsv
+		changed.StartDate = rsv.StartDate.AddDate(0, 0, 3)
+		changed.EndDate = rsv.EndDate.AddDate(0, 0, 3)
+		changed.Export(&row.Reservation)
+
+		// build stub
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// checks that the reservation in session is updated and remove it
+		lookup, ok := app.Session.Get(req.Context(), "lookup").(Reservation)
+		require.True(t, ok)
+		app.Session.Remove(req.Context(), "lookup")
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), changed.StartDate.Format(config.DateLayout))
+		assert.WithinDuration(t, changed.StartDate, lookup.StartDate, time.Second)
+	})
+
+	// Test Error: internal server error on GetReservationAndRoom
+	t.Run("Database Error", func(t *testing.T) {
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/my-reservation", nil)
+
+		// put reservation in session
+		rsv := randomReservation()
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(db.GetReservationAndRoomRow{}, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+		app.Session.Remove(req.Context(), "lookup")
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/find-reservation", rr.Header().Get("Location"))
+	})
+
+	// Test Error: reservation is missing from session
+	t.Run("Missing Reservation", func(t *testing.T) {
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/my-reservation", nil)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/find-reservation", rr.Header().Get("Location"))
+	})
+}
+
+// randomCancellableReservation returns a reservation with random data arriving in days from today
+func randomCancellableReservation(days int) Reservation {
+	rsv := randomReservation()
+	rsv.StartDate = Today().AddDate(0, 0, days)
+	rsv.EndDate = rsv.StartDate.AddDate(0, 0, 7)
+	return rsv
+}
+
+func TestServer_CancelReservationHandler(t *testing.T) {
+	// Test OK: reservation is free of charge
+	t.Run("OK Free", func(t *testing.T) {
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/my-reservation/cancel", nil)
+
+		// put reservation in session
+		rsv := randomCancellableReservation(app.CancellationPolicy.FreeCancellationDays)
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+		app.Session.Remove(req.Context(), "lookup")
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), rsv.Code)
+		assert.Contains(t, rr.Body.String(), "free of charge")
+	})
+
+	// Test OK: reservation is charged with late cancellation fee
+	t.Run("OK Late", func(t *testing.T) {
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/my-reservation/cancel", nil)
+
+		// put reservation in session
+		rsv := randomCancellableReservation(0)
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+		app.Session.Remove(req.Context(), "lookup")
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), fmt.Sprintf("<strong>%d%%</strong>", app.CancellationPolicy.LateCancellationFeePercent))
+	})
+
+	// Test Error: reservation is already cancelled
+	t.Run("Not Cancellable", func(t *testing.T) {
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/my-reservation/cancel", nil)
+
+		// put reservation in session
+		rsv := randomCancellableReservation(10)
+		rsv.CancelledAt = time.Now()
+		rsv.Status = ReservationCancelled
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+		app.Session.Remove(req.Context(), "lookup")
+
+		// get warning message from session and removes it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "This reservation can no longer be cancelled.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/my-reservation", rr.Header().Get("Location"))
+	})
+
+	// Test Error: reservation is missing from session
+	t.Run("Missing Reservation", func(t *testing.T) {
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/my-reservation/cancel", nil)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/find-reservation", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostCancelReservationHandler(t *testing.T) {
+	// Test OK: reservation is cancelled and notifications are sent
+	t.Run("OK", func(t *testing.T) {
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/my-reservation/cancel", nil)
+
+		// put reservation in session
+		rsv := randomCancellableReservation(0)
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		// create stub call and return arguments
+		arg := db.CancelReservationParams{
+			ID:                     rsv.ID,
+			CancellationFeePercent: int32(app.CancellationPolicy.LateCancellationFeePercent),
+		}
+		arg.CancelledBy.Scan("guest")
+
+		cancelled := rsv
+		cancelled.CancelledAt = time.Now()
+		cancelled.Status = ReservationCancelled
+		cancelled.CancelledBy = "guest"
+		cancelled.CancellationFeePercent = app.CancellationPolicy.LateCancellationFeePercent
+
+		dbRsv := db.Reservation{}
+		cancelled.Export(&dbRsv)
+
+		// build stubs
+		ts.MockDBStore.On("CancelReservationTx", mock.Anything, arg).
+			Return(dbRsv, nil).
+			Once()
+		ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, rsv.ID).
+			Return([]db.Payment{}, nil).
+			Once()
+
+		// build stubs for mailing and logging of mail sent to guest and admin
+		ts.BuildSendAnyMailStub()
+		ts.BuildLogAnyInfoStub()
+		ts.BuildSendAnyMailStub()
+		ts.BuildLogAnyInfoStub()
+		ts.MockDBStore.On("NotifyWaitlistTx", mock.Anything, mock.Anything).
+			Return([]db.WaitlistEntry{}, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// checks that the cancelled reservation is in session and remove it
+		scsRsv, ok := app.Session.Pop(req.Context(), "lookup").(Reservation)
+		require.True(t, ok)
+		assert.True(t, scsRsv.IsCancelled())
+		assert.Equal(t, "guest", scsRsv.CancelledBy)
+		assert.Equal(t, rsv.Room, scsRsv.Room)
+
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, "Your reservation has been cancelled.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/my-reservation", rr.Header().Get("Location"))
+	})
+
+	// Test OK: the deposit paid is refunded according to the cancellation policy
+	t.Run("OK Refund", func(t *testing.T) {
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/my-reservation/cancel", nil)
+
+		// put reservation free of charge in session
+		rsv := randomCancellableReservation(app.CancellationPolicy.FreeCancellationDays)
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		// create stub return arguments
+		cancelled := rsv
+		cancelled.CancelledAt = time.Now()
+		cancelled.Status = ReservationCancelled
+		cancelled.CancelledBy = "guest"
+
+		dbRsv := db.Reservation{}
+		cancelled.Export(&dbRsv)
+
+		capture := randomPayment()
+		capture.ReservationID = rsv.ID
+		capture.ProviderRef = "fake_capt_000002"
+		capture.Amount = 2500
+		dbPayments := make([]db.Payment, 1)
+		capture.Export(&dbPayments[0])
+
+		// build stubs
+		ts.MockDBStore.On("CancelReservationTx", mock.Anything, mock.Anything).
+			Return(dbRsv, nil).
+			Once()
+		ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, rsv.ID).
+			Return(dbPayments, nil).
+			Once()
+		ts.BuildRefundStub(1)
+		ts.BuildLogInfoStub(fmt.Sprintf("REFUND $25.00 of reservation %s on guest cancellation", rsv.Code))
+
+		// build stubs for mailing and logging of mail sent to guest and admin
+		ts.BuildSendAnyMailStub()
+		ts.BuildLogAnyInfoStub()
+		ts.BuildSendAnyMailStub()
+		ts.BuildLogAnyInfoStub()
+		ts.MockDBStore.On("NotifyWaitlistTx", mock.Anything, mock.Anything).
+			Return([]db.WaitlistEntry{}, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+		app.Session.Remove(req.Context(), "lookup")
+
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, "Your reservation has been cancelled. A refund of $25.00 has been issued to your card.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/my-reservation", rr.Header().Get("Location"))
+	})
+
+	// Test OK: a refund error is logged for staff, as the reservation is cancelled
+	t.Run("Refund Error", func(t *testing.T) {
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/my-reservation/cancel", nil)
+
+		// put reservation in session
+		rsv := randomCancellableReservation(app.CancellationPolicy.FreeCancellationDays)
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		cancelled := rsv
+		cancelled.CancelledAt = time.Now()
+		cancelled.Status = ReservationCancelled
+		dbRsv := db.Reservation{}
+		cancelled.Export(&dbRsv)
+
+		// build stubs
+		ts.MockDBStore.On("CancelReservationTx", mock.Anything, mock.Anything).
+			Return(dbRsv, nil).
+			Once()
+		ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, rsv.ID).
+			Return(nil, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+		ts.BuildSendAnyMailStub()
+		ts.BuildLogAnyInfoStub()
+		ts.BuildSendAnyMailStub()
+		ts.BuildLogAnyInfoStub()
+		ts.MockDBStore.On("NotifyWaitlistTx", mock.Anything, mock.Anything).
+			Return([]db.WaitlistEntry{}, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+		app.Session.Remove(req.Context(), "lookup")
+
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, "Your reservation has been cancelled.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/my-reservation", rr.Header().Get("Location"))
+	})
+
+	// Test Error: reservation is missing from session
+	t.Run("Missing Reservation", func(t *testing.T) {
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/my-reservation/cancel", nil)
+
+		// build stub
+		sErr := CreateServerError(ErrorMissingReservation, req.URL.Path, nil)
+		ts.BuildLogErrorStub(sErr)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get error message from session and removes it
+		errMsg := app.Session.PopString(req.Context(), "error")
+		assert.Equal(t, sErr.Prompt, errMsg)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/find-reservation", rr.Header().Get("Location"))
+	})
+
+	// Test Error: arrival date has passed
+	t.Run("Not Cancellable", func(t *testing.T) {
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/my-reservation/cancel", nil)
+
+		// put reservation in session
+		rsv := randomCancellableReservation(-1)
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+		app.Session.Remove(req.Context(), "lookup")
+
+		// get warning message from session and removes it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "This reservation can no longer be cancelled.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/my-reservation", rr.Header().Get("Location"))
+	})
+
+	// Test Error: reservation was already cancelled in the database
+	t.Run("Already Cancelled", func(t *testing.T) {
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/my-reservation/cancel", nil)
+
+		// put reservation in session
+		rsv := randomCancellableReservation(10)
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		// build stub
+		ts.MockDBStore.On("CancelReservationTx", mock.Anything, mock.Anything).
+			Return(db.Reservation{}, db.ErrReservationCancelled).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// checks that the outdated reservation was removed from session
+		assert.False(t, app.Session.Exists(req.Context(), "lookup"))
+
+		// get warning message from session and removes it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "This reservation has already been cancelled.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/find-reservation", rr.Header().Get("Location"))
+	})
+
+	// Test Error: reservation was checked in or marked as a no show in the database
+	t.Run("Invalid Status", func(t *testing.T) {
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/my-reservation/cancel", nil)
+
+		// put reservation in session
+		rsv := randomCancellableReservation(10)
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		// build stub
+		ts.MockDBStore.On("CancelReservationTx", mock.Anything, mock.Anything).
+			Return(db.Reservation{}, db.ErrInvalidStatusTransition).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+		app.Session.Remove(req.Context(), "lookup")
+
+		// get warning message from session and removes it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "This reservation can no longer be cancelled.", msg)
+		assert.Empty(t, app.Session.PopString(req.Context(), "error"))
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/my-reservation", rr.Header().Get("Location"))
+	})
+
+	// Test Error: database error
+	t.Run("Internal Error", func(t *testing.T) {
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/my-reservation/cancel", nil)
+
+		// put reservation in session
+		rsv := randomCancellableReservation(10)
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		// build stubs
+		ts.MockDBStore.On("CancelReservationTx", mock.Anything, mock.Anything).
+			Return(db.Reservation{}, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+		app.Session.Remove(req.Context(), "lookup")
+
+		// get error message from session and removes it
+		errMsg := app.Session.PopString(req.Context(), "error")
+		assert.Equal(t, "Unable to cancel reservation.", errMsg)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/my-reservation", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_ChangeReservationDatesHandler(t *testing.T) {
+	// Test OK: reservation found by the guest exists in session
+	t.Run("OK", func(t *testing.T) {
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/my-reservation/change-dates", nil)
+
+		// put reservation in session
+		rsv := randomCancellableReservation(10)
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+		app.Session.Remove(req.Context(), "lookup")
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), rsv.Code)
+		assert.Contains(t, rr.Body.String(), rsv.StartDate.Format(config.DateLayout))
+	})
+
+	// Test Error: reservation is cancelled
+	t.Run("Not Modifiable", func(t *testing.T) {
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/my-reservation/change-dates", nil)
+
+		// put reservation in session
+		rsv := randomCancellableReservation(10)
+		rsv.CancelledAt = time.Now()
+		rsv.Status = ReservationCancelled
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+		app.Session.Remove(req.Context(), "lookup")
+
+		// get warning message from session and removes it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "The dates of this reservation can no longer be changed.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/my-reservation", rr.Header().Get("Location"))
+	})
+
+	// Test Error: reservation is missing from session
+	t.Run("Missing Reservation", func(t *testing.T) {
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/my-reservation/change-dates", nil)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/find-reservation", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostChangeReservationDatesHandler(t *testing.T) {
+	// newBody returns the body of a request with the dates passed, confirmed by the guest if confirm is set
+	newBody := func(startDate, endDate time.Time, confirm bool) *strings.Reader {
+		f := forms.New(nil)
+		f.Add("start_date", startDate.Format(config.DateLayout))
+		f.Add("end_date", endDate.Format(config.DateLayout))
+		if confirm {
+			f.Add("confirm", "1")
+		}
+		return strings.NewReader(f.Encode())
+	}
+
+	// Test OK: room is available on the new dates
+	t.Run("OK", func(t *testing.T) {
+		rsv := randomCancellableReservation(10)
+		startDate := rsv.StartDate.AddDate(0, 0, 1)
+		endDate := rsv.EndDate.AddDate(0, 0, 1)
+
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/my-reservation/change-dates", newBody(startDate, endDate, true))
+
+		// put reservation in session
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		// create stub call and return arguments
+		arg := db.UpdateReservationDatesParams{ID: rsv.ID}
+		arg.StartDate.Scan(startDate)
+		arg.EndDate.Scan(endDate)
+
+		updated := rsv
+		updated.StartDate = startDate
+		updated.EndDate = endDate
+
+		dbRsv := db.Reservation{}
+		updated.Export(&dbRsv)
+
+		// build stub
+		ts.MockDBStore.On("UpdateReservationDatesTx", mock.Anything, arg).
+			Return(dbRsv, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// checks that the updated reservation is in session and remove it
+		scsRsv, ok := app.Session.Pop(req.Context(), "lookup").(Reservation)
+		require.True(t, ok)
+		assert.WithinDuration(t, startDate, scsRsv.StartDate, time.Second)
+		assert.WithinDuration(t, endDate, scsRsv.EndDate, time.Second)
+		assert.Equal(t, rsv.Room, scsRsv.Room)
+
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, fmt.Sprintf("The dates of your reservation have been changed. The total price is now %s.", updated.TotalPrice), msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/my-reservation", rr.Header().Get("Location"))
+	})
+
+	// Test OK: the new total price is quoted for the guest to confirm
+	t.Run("OK Quote", func(t *testing.T) {
+		rsv := randomCancellableReservation(10)
+		rsv.Adults, rsv.Children = 2, 1
+		startDate := rsv.StartDate.AddDate(0, 0, 1)
+		endDate := startDate.AddDate(0, 0, 2)
+
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/my-reservation/change-dates", newBody(startDate, endDate, false))
+
+		// put reservation in session
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		// build stub
+		rsv.Room.NightlyRate = rsv.TotalPrice / 4
+		ts.BuildQuoteStayStub(rsv.Room, startDate, endDate, rsv.Adults, rsv.Children)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+		app.Session.Remove(req.Context(), "lookup")
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), (2 * rsv.Room.NightlyRate).String())
+		assert.Contains(t, rr.Body.String(), `name="confirm"`)
+		ts.MockDBStore.AssertNotCalled(t, "UpdateReservationDatesTx", mock.Anything, mock.Anything)
+	})
+
+	// Test OK: dates that cost more are quoted for the guest to confirm
+	t.Run("OK Quote Longer Stay", func(t *testing.T) {
+		rsv := randomCancellableReservation(10)
+		startDate := rsv.StartDate
+		endDate := rsv.EndDate.AddDate(0, 0, 1)
+		newTotal := rsv.TotalPrice + 10000
+
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/my-reservation/change-dates", newBody(startDate, endDate, false))
+
+		// put reservation in session
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		// build stub
+		ts.MockDBStore.On("QuoteStay", mock.Anything, mock.Anything).
+			Return(db.Quote{Total: int64(newTotal)}, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+		app.Session.Remove(req.Context(), "lookup")
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), newTotal.String())
+		assert.Contains(t, rr.Body.String(), `name="confirm"`)
+	})
+
+	// Test Error: reservation is no longer pending or confirmed in the database
+	t.Run("Closed", func(t *testing.T) {
+		rsv := randomCancellableReservation(10)
+
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/my-reservation/change-dates",
+			newBody(rsv.StartDate.AddDate(0, 0, 1), rsv.EndDate.AddDate(0, 0, 1), true))
+
+		// put reservation in session
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		// build stub
+		ts.MockDBStore.On("UpdateReservationDatesTx", mock.Anything, mock.Anything).
+			Return(db.Reservation{}, db.ErrReservationClosed).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+		app.Session.Remove(req.Context(), "lookup")
+
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "The dates of this reservation can no longer be changed.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/my-reservation", rr.Header().Get("Location"))
+	})
+
+	// Test Error: room is not available on the new dates
+	t.Run("Room Unavailable", func(t *testing.T) {
+		rsv := randomCancellableReservation(10)
+
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/my-reservation/change-dates",
+			newBody(rsv.StartDate.AddDate(0, 0, 1), rsv.EndDate.AddDate(0, 0, 1), true))
+
+		// put reservation in session
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		// build stub
+		ts.MockDBStore.On("UpdateReservationDatesTx", mock.Anything, mock.Anything).
+			Return(db.Reservation{}, db.ErrRoomUnavailable).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// checks that the original reservation is kept in session and remove it
+		scsRsv, ok := app.Session.Pop(req.Context(), "lookup").(Reservation)
+		require.True(t, ok)
+		assert.Equal(t, rsv, scsRsv)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "The room is unavailable on the dates selected.")
+	})
+
+	// Test Error: invalid form data
+	t.Run("Invalid Form", func(t *testing.T) {
+		rsv := randomCancellableReservation(10)
+
+		tests := []struct {
+			name      string
+			startDate time.Time
+			endDate   time.Time
+			message   string
+		}{
+			{"Past Arrival", Today().AddDate(0, 0, -1), Today().AddDate(0, 0, 3), "Arrival date cannot be in the past."},
+			{"Same Dates", rsv.StartDate, rsv.StartDate, "Departure date must be after arrival date."},
+			{"Reversed Dates", rsv.EndDate, rsv.StartDate, "End date cannot be prior to start date."},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				// create a new test server, and a new request
+				ts := NewTestServer(t)
+				req := ts.NewRequestWithSession(t, http.MethodPost, "/my-reservation/change-dates",
+					newBody(test.startDate, test.endDate, false))
+
+				// put reservation in session
+				app.Session.Put(req.Context(), "lookup", rsv)
+
+				//  server the request
+				rr := ts.ServeRequest(req)
+				app.Session.Remove(req.Context(), "lookup")
+
+				// testify
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Contains(t, rr.Body.String(), test.message)
+			})
+		}
+	})
+
+	// Test Error: reservation was cancelled in the database
+	t.Run("Cancelled", func(t *testing.T) {
+		rsv := randomCancellableReservation(10)
+
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/my-reservation/change-dates",
+			newBody(rsv.StartDate.AddDate(0, 0, 1), rsv.EndDate.AddDate(0, 0, 1), true))
+
+		// put reservation in session
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		// build stub
+		ts.MockDBStore.On("UpdateReservationDatesTx", mock.Anything, mock.Anything).
+			Return(db.Reservation{}, db.ErrReservationCancelled).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// checks that the outdated reservation was removed from session
+		assert.False(t, app.Session.Exists(req.Context(), "lookup"))
+
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "This reservation has been cancelled.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/find-reservation", rr.Header().Get("Location"))
+	})
+
+	// Test Error: database error
+	t.Run("Internal Error", func(t *testing.T) {
+		rsv := randomCancellableReservation(10)
+
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/my-reservation/change-dates",
+			newBody(rsv.StartDate.AddDate(0, 0, 1), rsv.EndDate.AddDate(0, 0, 1), true))
+
+		// put reservation in session
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		// build stubs
+		ts.MockDBStore.On("UpdateReservationDatesTx", mock.Anything, mock.Anything).
+			Return(db.Reservation{}, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+		app.Session.Remove(req.Context(), "lookup")
+
+		// get error message from session and removes it
+		errMsg := app.Session.PopString(req.Context(), "error")
+		assert.Equal(t, "Unable to change reservation dates.", errMsg)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/my-reservation", rr.Header().Get("Location"))
+	})
+
+	// Test Error: reservation is missing from session
+	t.Run("Missing Reservation", func(t *testing.T) {
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/my-reservation/change-dates", nil)
+
+		// build stub
+		sErr := CreateServerError(ErrorMissingReservation, req.URL.Path, nil)
+		ts.BuildLogErrorStub(sErr)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get error message from session and removes it
+		errMsg := app.Session.PopString(req.Context(), "error")
+		assert.Equal(t, sErr.Prompt, errMsg)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/find-reservation", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_InvoiceHandler(t *testing.T) {
+	// Test OK: the issued invoice is downloaded
+	t.Run("OK", func(t *testing.T) {
+		rsv, row, invoice := invoicedReservationRow()
+		dbInvoice := db.Invoice{}
+		invoice.Export(&dbInvoice)
+
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/my-reservation/invoice", nil)
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("GetInvoiceByReservation", mock.Anything, rsv.ID).
+			Return(dbInvoice, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+		app.Session.Remove(req.Context(), "lookup")
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, fmt.Sprintf("attachment; filename=%q", invoice.Filename()), rr.Header().Get("Content-Disposition"))
+		assert.Equal(t, invoice.Document, rr.Body.String())
+	})
+
+	// Test Not Invoiceable: the reservation found by the guest was cancelled since, so no invoice is issued
+	t.Run("Not Invoiceable", func(t *testing.T) {
+		rsv, row, _ := invoicedReservationRow()
+		row.Reservation.Status = db.ReservationStatusCancelled
+
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/my-reservation/invoice", nil)
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("GetInvoiceByReservation", mock.Anything, rsv.ID).
+			Return(db.Invoice{}, pgx.ErrNoRows).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+		app.Session.Remove(req.Context(), "lookup")
+
+		// get warning message from session and remove it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "An invoice is only available for confirmed reservations.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/my-reservation", rr.Header().Get("Location"))
+	})
+
+	// Test Error: no reservation was found by the guest
+	t.Run("Missing Reservation", func(t *testing.T) {
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/my-reservation/invoice", nil)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/find-reservation", rr.Header().Get("Location"))
+	})
+
+	// Test Error: internal server error on GetReservationAndRoom
+	t.Run("Database Error Reservation", func(t *testing.T) {
+		rsv, _, _ := invoicedReservationRow()
+
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/my-reservation/invoice", nil)
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(db.GetReservationAndRoomRow{}, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+		app.Session.Remove(req.Context(), "lookup")
+
+		// get error message from session and remove it
+		msg := app.Session.PopString(req.Context(), "error")
+		assert.Equal(t, "Unable to load reservation from database.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/my-reservation", rr.Header().Get("Location"))
+	})
+
+	// Test Error: internal server error on IssueInvoiceTx
+	t.Run("Database Error", func(t *testing.T) {
+		rsv, row, _ := invoicedReservationRow()
+
+		// create a new test server, and a new request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/my-reservation/invoice", nil)
+		app.Session.Put(req.Context(), "lookup", rsv)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("GetInvoiceByReservation", mock.Anything, rsv.ID).
+			Return(db.Invoice{}, pgx.ErrNoRows).
+			Once()
+		ts.MockDBStore.On("IssueInvoiceTx", mock.Anything, db.IssueInvoiceTxParams{Property: app.Listing.Name, ReservationID: rsv.ID}).
+			Return(db.Invoice{}, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+		app.Session.Remove(req.Context(), "lookup")
+
+		// get error message from session and remove it
+		msg := app.Session.PopString(req.Context(), "error")
+		assert.Equal(t, "Unable to create invoice.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/my-reservation", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_WaitlistHandler(t *testing.T) {
+	// create random rooms
+	rooms := randomRooms(3)
+
+	// create stub call arguments
+	arg := db.ListRoomsParams{
+		Limit:  int32(LimitRoomsPerPage),
+		Offset: 0,
+	}
+
+	// Test OK: waitlist page is rendered with the search of the guest
+	t.Run("OK", func(t *testing.T) {
+		// create stub return arguments
+		dbRooms := make([]db.Room, len(rooms))
+		for i, v := range rooms {
+			v.Export(&dbRooms[i])
+		}
+
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/waitlist?start_date=2030-01-10&end_date=2030-01-15", nil)
+
+		// build stub
+		ts.MockDBStore.On("ListRooms", mock.Anything, arg).
+			Return(dbRooms, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "2030-01-10")
+		for _, room := range rooms {
+			assert.Contains(t, rr.Body.String(), room.Name)
+		}
+	})
+
+	// Test Error: internal server error on ListRooms
+	t.Run("Internal Server Error", func(t *testing.T) {
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/waitlist", nil)
+
+		// build stubs
+		ts.MockDBStore.On("ListRooms", mock.Anything, arg).
+			Return(nil, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostWaitlistHandler(t *testing.T) {
+	// create random waitlist entry
+	entry := randomWaitlistEntry()
+	entry.StartDate = Today().Add(time.Hour * 24 * 30)
+	entry.EndDate = Today().Add(time.Hour * 24 * 37)
+
+	// create form data for the body of the request
+	values := url.Values{
+		"first_name": {entry.FirstName},
+		"last_name":  {entry.LastName},
+		"email":      {entry.Email},
+		"start_date": {entry.StartDate.Format(config.DateLayout)},
+		"end_date":   {entry.EndDate.Format(config.DateLayout)},
+		"adults":     {fmt.Sprint(entry.Adults)},
+		"children":   {fmt.Sprint(entry.Children)},
+		"room_id":    {fmt.Sprint(entry.RoomID)},
+	}
+
+	// matchArg checks the stub call arguments
+	matchArg := mock.MatchedBy(func(arg db.CreateWaitlistEntryParams) bool {
+		return len(arg.Token) == base64.RawURLEncoding.EncodedLen(HoldTokenLength) &&
+			arg.FirstName == entry.FirstName &&
+			arg.LastName == entry.LastName &&
+			arg.Email == entry.Email &&
+			arg.StartDate.Time.Equal(entry.StartDate) &&
+			arg.EndDate.Time.Equal(entry.EndDate) &&
+			arg.RoomID == pgtype.Int8{Int64: entry.RoomID, Valid: true} &&
+			arg.Adults == int32(entry.Adults) &&
+			arg.Children == int32(entry.Children)
+	})
+
+	// Test OK: guest joins the waitlist
+	t.Run("OK", func(t *testing.T) {
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		body := strings.NewReader(values.Encode())
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/waitlist", body)
+
+		// build stub
+		ts.MockDBStore.On("CreateWaitlistEntry", mock.Anything, matchArg).
+			Return(db.WaitlistEntry{}, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, "You have joined the waitlist. We will email you if a room becomes available.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/", rr.Header().Get("Location"))
+	})
+
+	// Test Error: invalid body data cause error in ParseForm()
+	t.Run("Invalid Body Data", func(t *testing.T) {
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/waitlist", strings.NewReader("%^"))
+
+		// build stub
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/waitlist", rr.Header().Get("Location"))
+	})
+
+	// Test Error: form is invalid
+	t.Run("Invalid Form", func(t *testing.T) {
+		// pastValues has an arrival date in the past
+		pastValues := url.Values{}
+		for k, v := range values {
+			pastValues[k] = v
+		}
+		pastValues.Set("start_date", Today().Add(-time.Hour*24).Format(config.DateLayout))
+
+		// invalidRoomValues has an invalid room id
+		invalidRoomValues := url.Values{}
+		for k, v := range values {
+			invalidRoomValues[k] = v
+		}
+		invalidRoomValues.Set("room_id", "any")
+
+		// zeroNightsValues has the departure date on the arrival date
+		zeroNightsValues := url.Values{}
+		for k, v := range values {
+			zeroNightsValues[k] = v
+		}
+		zeroNightsValues.Set("end_date", values.Get("start_date"))
+
+		tests := []struct {
+			Name   string
+			Values url.Values
+		}{
+			{
+				Name:   "Missing Email",
+				Values: url.Values{"first_name": {entry.FirstName}, "last_name": {entry.LastName}},
+			},
+			{
+				Name:   "Past Arrival Date",
+				Values: pastValues,
+			},
+			{
+				Name:   "Invalid Room",
+				Values: invalidRoomValues,
+			},
+			{
+				Name:   "Zero Nights",
+				Values: zeroNightsValues,
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.Name, func(t *testing.T) {
+				// create a new test server, a mock database store and a request
+				ts := NewTestServer(t)
+				body := strings.NewReader(test.Values.Encode())
+				req := ts.NewRequestWithSession(t, http.MethodPost, "/waitlist", body)
+
+				// build stub
+				ts.MockDBStore.On("ListRooms", mock.Anything, mock.Anything).
+					Return([]db.Room{}, nil).
+					Once()
+
+				//  server the request
+				rr := ts.ServeRequest(req)
+
+				// testify
+				assert.Equal(t, http.StatusOK, rr.Code)
+			})
+		}
+	})
+
+	// Test Error: internal server error on CreateWaitlistEntry
+	t.Run("Internal Server Error", func(t *testing.T) {
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		body := strings.NewReader(values.Encode())
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/waitlist", body)
+
+		// build stubs
+		ts.MockDBStore.On("CreateWaitlistEntry", mock.Anything, matchArg).
+			Return(db.WaitlistEntry{}, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get error message from session and remove it
+		errMsg := app.Session.PopString(req.Context(), "error")
+		assert.Equal(t, "Unable to join the waitlist.", errMsg)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/waitlist", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_WaitlistBookingHandler(t *testing.T) {
+	// create random waitlist entry offered a room
+	entry := randomWaitlistEntry()
+	room := randomRoom()
+	room.ID = entry.OfferedRoomID
+
+	dbRoom := db.Room{}
+	room.Export(&dbRoom)
+
+	// Test OK: offered room is held for the guest in session
+	t.Run("OK", func(t *testing.T) {
+		// create stub return arguments
+		dbEntry := db.WaitlistEntry{}
+		entry.Export(&dbEntry)
+
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/waitlist/book/"+entry.Token, nil)
+
+		// build stubs
+		ts.MockDBStore.On("GetWaitlistEntryByToken", mock.Anything, entry.Token).
+			Return(dbEntry, nil).
+			Once()
+		ts.MockDBStore.On("GetRoom", mock.Anything, room.ID).
+			Return(dbRoom, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// check reservation and hold token are in session
+		scsRsv, ok := app.Session.Pop(req.Context(), "reservation").(Reservation)
+		require.True(t, ok)
+		assert.Equal(t, room.ID, scsRsv.RoomID)
+		assert.Equal(t, entry.Adults, scsRsv.Adults)
+		assert.Equal(t, entry.Children, scsRsv.Children)
+		assert.Equal(t, entry.Token, app.Session.PopString(req.Context(), "hold_token"))
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/make-reservation", rr.Header().Get("Location"))
+	})
+
+	// Test Error: booking link is unknown or the offer expired
+	t.Run("Invalid Link", func(t *testing.T) {
+		// create an expired waitlist entry
+		expired := entry
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+
+		dbExpired := db.WaitlistEntry{}
+		expired.Export(&dbExpired)
+
+		tests := []struct {
+			Name    string
+			DBEntry db.WaitlistEntry
+			Err     error
+		}{
+			{Name: "Not Found", DBEntry: db.WaitlistEntry{}, Err: pgx.ErrNoRows},
+			{Name: "Expired", DBEntry: dbExpired, Err: nil},
+		}
+
+		for _, test := range tests {
+			t.Run(test.Name, func(t *testing.T) {
+				// create a new test server, a mock database store and a request
+				ts := NewTestServer(t)
+				req := ts.NewRequestWithSession(t, http.MethodGet, "/waitlist/book/"+entry.Token, nil)
+
+				// build stub
+				ts.MockDBStore.On("GetWaitlistEntryByToken", mock.Anything, entry.Token).
+					Return(test.DBEntry, test.Err).
+					Once()
+
+				//  server the request
+				rr := ts.ServeRequest(req)
+
+				// get warning message from session and remove it
+				msg := app.Session.PopString(req.Context(), "warning")
+				assert.Equal(t, "This booking link is invalid or has expired.", msg)
+
+				// testify
+				assert.Equal(t, http.StatusSeeOther, rr.Code)
+				assert.Equal(t, "/available-rooms-search", rr.Header().Get("Location"))
+			})
+		}
+	})
+
+	// Test Error: internal server error on GetWaitlistEntryByToken
+	t.Run("Internal Server Error", func(t *testing.T) {
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/waitlist/book/"+entry.Token, nil)
+
+		// build stubs
+		ts.MockDBStore.On("GetWaitlistEntryByToken", mock.Anything, entry.Token).
+			Return(db.WaitlistEntry{}, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostPaymentWebhookHandler(t *testing.T) {
+	fake := payments.NewFakeProvider("secret")
+	payload := []byte(`{"type":"capture.updated","transaction_id":"fake_capt_000001","status":"failed"}`)
+
+	// newWebhookRequest creates a new webhook request with payload signed by signature
+	newWebhookRequest := func(ts *TestServer, signature string) *http.Request {
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/payments/webhook", strings.NewReader(string(payload)))
+		req.Header.Set(WebhookSignatureHeader, signature)
+		return req
+	}
+
+	// Test OK: the status of the payment is updated
+	t.Run("OK", func(t *testing.T) {
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := newWebhookRequest(ts, fake.Sign(payload))
+
+		// build stubs
+		ts.MockPayments.On("VerifyWebhook", payload, fake.Sign(payload)).
+			Return(fake.VerifyWebhook(payload, fake.Sign(payload))).
+			Once()
+		ts.MockPayments.On("Name").Return("fake")
+		arg := db.UpdatePaymentStatusByProviderRefParams{
+			Provider:    "fake",
+			ProviderRef: "fake_capt_000001",
+			Status:      db.PaymentStatusFailed,
+		}
+		ts.MockDBStore.On("UpdatePaymentStatusByProviderRef", mock.Anything, arg).
+			Return([]db.Payment{{ID: util.RandomID(), Status: db.PaymentStatusFailed}}, nil).
+			Once()
+		ts.BuildLogAnyInfoStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	// Test Error: the signature of the webhook is invalid
+	t.Run("Invalid Signature", func(t *testing.T) {
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := newWebhookRequest(ts, "bad signature")
+
+		// build stubs
+		ts.MockPayments.On("VerifyWebhook", payload, "bad signature").
+			Return(payments.WebhookEvent{}, payments.ErrInvalidSignature).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		ts.MockDBStore.AssertNotCalled(t, "UpdatePaymentStatusByProviderRef", mock.Anything, mock.Anything)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	// Test Error: the status of the webhook is unknown
+	t.Run("Invalid Status", func(t *testing.T) {
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := newWebhookRequest(ts, fake.Sign(payload))
+
+		// build stubs
+		ts.MockPayments.On("VerifyWebhook", payload, fake.Sign(payload)).
+			Return(payments.WebhookEvent{TransactionID: "fake_capt_000001", Status: "refunded"}, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		ts.MockDBStore.AssertNotCalled(t, "UpdatePaymentStatusByProviderRef", mock.Anything, mock.Anything)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	// Test Error: internal server error on UpdatePaymentStatusByProviderRef
+	t.Run("Internal Server Error", func(t *testing.T) {
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := newWebhookRequest(ts, fake.Sign(payload))
+
+		// build stubs
+		ts.MockPayments.On("VerifyWebhook", payload, fake.Sign(payload)).
+			Return(fake.VerifyWebhook(payload, fake.Sign(payload))).
+			Once()
+		ts.MockPayments.On("Name").Return("fake")
+		ts.MockDBStore.On("UpdatePaymentStatusByProviderRef", mock.Anything, mock.Anything).
+			Return(nil, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestServer_AdminReservationsHandler(t *testing.T) {
+	// create random reservations with room data
+	rsvs := []Reservation{randomReservation(), randomReservation()}
+
+	// create stub return arguments
+	dbRsvs := make([]db.ListReservationsAndRoomsRow, len(rsvs))
+	dbRsvsByStatus := make([]db.ListReservationsAndRoomsByStatusRow, len(rsvs))
+	for i, rsv := range rsvs {
+		rsv.ExportWithRoom(&dbRsvs[i])
+		dbRsvsByStatus[i] = db.ListReservationsAndRoomsByStatusRow(dbRsvs[i])
+	}
+
+	// Test OK: reservations are listed by status
+	t.Run("OK", func(t *testing.T) {
+		tests := []struct {
+			Name   string
+			Show   string
+			Status db.ReservationStatus
+		}{
+			{Name: "New", Show: "new", Status: db.ReservationStatusPending},
+			{Name: "Confirmed", Show: "confirmed", Status: db.ReservationStatusConfirmed},
+			{Name: "Checked In", Show: "checked_in", Status: db.ReservationStatusCheckedIn},
+		}
+
+		for _, test := range tests {
+			t.Run(test.Name, func(t *testing.T) {
+				// create a new test server, a mock database store and an authenticated request
+				ts := NewTestServer(t)
+				req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/reservations/"+test.Show, nil)
+				app.Session.Put(req.Context(), "user_id", 1)
+
+				// build stub
+				arg := db.ListReservationsAndRoomsByStatusParams{
+					Status: test.Status,
+					Limit:  LimitReservationsPerPage,
+					Offset: 0,
+				}
+				ts.MockDBStore.On("ListReservationsAndRoomsByStatus", mock.Anything, arg).
+					Return(dbRsvsByStatus, nil).
+					Once()
+
+				//  server the request
+				rr := ts.ServeRequest(req)
+
+				// testify
+				assert.Equal(t, http.StatusOK, rr.Code)
+				for _, rsv := range rsvs {
+					assert.Contains(t, rr.Body.String(), rsv.Code)
+				}
+				assert.Contains(t, rr.Body.String(), "Confirmed")
+			})
+		}
+	})
+
+	// Test OK: all reservations are listed
+	t.Run("OK All", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/reservations/all", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stub
+		arg := db.ListReservationsAndRoomsParams{
+			Limit:  LimitReservationsPerPage,
+			Offset: 0,
+		}
+		ts.MockDBStore.On("ListReservationsAndRooms", mock.Anything, arg).
+			Return(dbRsvs, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		for _, rsv := range rsvs {
+			assert.Contains(t, rr.Body.String(), rsv.Code)
+		}
+	})
+
+	// Test Error: unknown list in URL
+	t.Run("Invalid Parameter", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/reservations/any", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stub
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/dashboard", rr.Header().Get("Location"))
+	})
+
+	// Test Error: internal server error on ListReservationsAndRoomsByStatus
+	t.Run("Internal Server Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/reservations/new", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("ListReservationsAndRoomsByStatus", mock.Anything, mock.Anything).
+			Return(nil, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/dashboard", rr.Header().Get("Location"))
+	})
+}
+
+// adminReservationRow returns a confirmed reservation with its database row, and the rooms listed for the edit form
+func adminReservationRow() (Reservation, db.GetReservationAndRoomRow, []db.Room) {
+	rsv := randomReservation()
+	rsv.Status = ReservationConfirmed
+
+	row := db.GetReservationAndRoomRow{}
+	rsv.Export(&row.Reservation)
+	rsv.Room.Export(&row.Room)
+
+	other := randomRoom()
+	dbRooms := make([]db.Room, 2)
+	rsv.Room.Export(&dbRooms[0])
+	other.Export(&dbRooms[1])
+
+	return rsv, row, dbRooms
+}
+
+// buildAdminReservationStubs builds the stubs to render the reservation panel of rsv
+func buildAdminReservationStubs(ts *TestServer, rsv Reservation, dbRooms []db.Room) {
+	restriction := randomRoomRestriction(rsv)
+	dbRestriction := db.RoomRestriction{}
+	restriction.Export(&dbRestriction)
+
+	ts.MockDBStore.On("ListReservationCharges", mock.Anything, rsv.ID).
+		Return([]db.ReservationCharge{}, nil).
+		Once()
+	ts.MockDBStore.On("ListRoomRestrictionsByReservationID", mock.Anything, pgtype.Int8{Int64: rsv.ID, Valid: true}).
+		Return([]db.RoomRestriction{dbRestriction}, nil).
+		Once()
+	ts.MockDBStore.On("ListRooms", mock.Anything, db.ListRoomsParams{Limit: LimitRoomsPerPage}).
+		Return(dbRooms, nil).
+		Once()
+}
+
+// adminReservationValues returns the edit form values of reservation rsv
+func adminReservationValues(rsv Reservation) url.Values {
+	return url.Values{
+		"first_name": {rsv.FirstName},
+		"last_name":  {rsv.LastName},
+		"email":      {rsv.Email},
+		"phone":      {rsv.Phone},
+		"room_id":    {fmt.Sprint(rsv.RoomID)},
+		"start_date": {rsv.StartDate.Format(config.DateLayout)},
+		"end_date":   {rsv.EndDate.Format(config.DateLayout)},
+		"adults":     {fmt.Sprint(rsv.Adults)},
+		"children":   {fmt.Sprint(rsv.Children)},
+		"notes":      {rsv.Notes},
+	}
+}
+
+func TestServer_AdminReservationHandler(t *testing.T) {
+	// Test OK: the reservation is shown with its restrictions and the edit form
+	t.Run("OK", func(t *testing.T) {
+		rsv, row, dbRooms := adminReservationRow()
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, fmt.Sprintf("/admin/reservations/%d", rsv.ID), nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		buildAdminReservationStubs(ts, rsv, dbRooms)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), fmt.Sprintf("Reservation %s", rsv.Code))
+		assert.Contains(t, rr.Body.String(), rsv.Email)
+		assert.Contains(t, rr.Body.String(), "(7 nights)")
+		assert.Contains(t, rr.Body.String(), "<td>Reservation</td>")
+		assert.Contains(t, rr.Body.String(), fmt.Sprintf(`<option value="%d" selected>%s</option>`, rsv.RoomID, rsv.Room.Name))
+		assert.Contains(t, rr.Body.String(), fmt.Sprintf(`value='%s'`, rsv.StartDate.Format(config.DateLayout)))
+		assert.Contains(t, rr.Body.String(), "Save Reservation")
+	})
+
+	// Test OK: a cancelled reservation cannot be edited
+	t.Run("OK Cancelled", func(t *testing.T) {
+		rsv, row, dbRooms := adminReservationRow()
+		rsv.Status = ReservationCancelled
+		rsv.CancelledAt = time.Now()
+		rsv.Export(&row.Reservation)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, fmt.Sprintf("/admin/reservations/%d", rsv.ID), nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		buildAdminReservationStubs(ts, rsv, dbRooms)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Cancelled reservations can no longer be changed.")
+		assert.NotContains(t, rr.Body.String(), "Save Reservation")
+	})
+
+	// Test Error: reservation not found
+	t.Run("Reservation Not Found", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/reservations/1", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, int64(1)).
+			Return(db.GetReservationAndRoomRow{}, pgx.ErrNoRows).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/reservations/new", rr.Header().Get("Location"))
+	})
+
+	// Test Error: internal server error on ListRoomRestrictionsByReservationID
+	t.Run("Database Error", func(t *testing.T) {
+		rsv, row, _ := adminReservationRow()
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, fmt.Sprintf("/admin/reservations/%d", rsv.ID), nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("ListReservationCharges", mock.Anything, rsv.ID).
+			Return([]db.ReservationCharge{}, nil).
+			Once()
+		ts.MockDBStore.On("ListRoomRestrictionsByReservationID", mock.Anything, mock.Anything).
+			Return(nil, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/reservations/new", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostAdminReservationHandler(t *testing.T) {
+	// Test OK: guest details are updated, keeping the stay and its price
+	t.Run("OK", func(t *testing.T) {
+		rsv, row, _ := adminReservationRow()
+		rsvURL := fmt.Sprintf("/admin/reservations/%d", rsv.ID)
+
+		updated := rsv
+		updated.FirstName = "Jane"
+		updated.Phone = ""
+		values := adminReservationValues(updated)
+
+		dbUpdated := db.Reservation{}
+		updated.Export(&dbUpdated)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, rsvURL, strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("UpdateReservationTx", mock.Anything, updateReservationArg(updated)).
+			Return(dbUpdated, nil).
+			Once()
+		ts.BuildLogInfoStub(fmt.Sprintf("UPDATE reservation %s updated by user 1", rsv.Code))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, fmt.Sprintf("Reservation %s updated.", rsv.Code), msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, rsvURL, rr.Header().Get("Location"))
+	})
+
+	// Test OK: the stay is moved to another room, priced again, and the room released is offered to the waitlist
+	t.Run("OK Stay Moved", func(t *testing.T) {
+		rsv, row, dbRooms := adminReservationRow()
+		rsvURL := fmt.Sprintf("/admin/reservations/%d", rsv.ID)
+
+		updated := rsv
+		updated.RoomID = dbRooms[1].ID
+		updated.StartDate = rsv.StartDate.AddDate(0, 0, 1)
+		updated.EndDate = rsv.EndDate.AddDate(0, 0, 1)
+		values := adminReservationValues(updated)
+
+		priced := updated
+		priced.TotalPrice = rsv.TotalPrice + 1000
+		dbUpdated := db.Reservation{}
+		priced.Export(&dbUpdated)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, rsvURL, strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("UpdateReservationTx", mock.Anything, updateReservationArg(updated)).
+			Return(dbUpdated, nil).
+			Once()
+		ts.BuildLogInfoStub(fmt.Sprintf("UPDATE reservation %s updated by user 1", rsv.Code))
+		ts.MockDBStore.On("NotifyWaitlistTx", mock.Anything, mock.MatchedBy(func(arg db.NotifyWaitlistTxParams) bool {
+			return arg.RoomID == rsv.RoomID && arg.StartDate.Time.Equal(rsv.StartDate) && arg.EndDate.Time.Equal(rsv.EndDate)
+		})).
+			Return([]db.WaitlistEntry{}, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, fmt.Sprintf("Reservation %s updated. The total price is now %s.", rsv.Code, priced.TotalPrice), msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, rsvURL, rr.Header().Get("Location"))
+	})
+
+	// Test Error: invalid form is rendered again with the errors
+	t.Run("Invalid Form", func(t *testing.T) {
+		rsv, row, dbRooms := adminReservationRow()
+
+		values := adminReservationValues(rsv)
+		values.Set("first_name", "")
+		values.Set("email", "jane")
+		values.Set("end_date", values.Get("start_date"))
+		values.Set("adults", "0")
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, fmt.Sprintf("/admin/reservations/%d", rsv.ID), strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		buildAdminReservationStubs(ts, rsv, dbRooms)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Required field!")
+		assert.Contains(t, rr.Body.String(), "Invalid email address!")
+		assert.Contains(t, rr.Body.String(), "Departure date must be after arrival date.")
+		assert.Contains(t, rr.Body.String(), "Field requires a number between")
+	})
+
+	// Test Error: the room is unavailable on the new dates
+	t.Run("Room Unavailable", func(t *testing.T) {
+		rsv, row, dbRooms := adminReservationRow()
+
+		updated := rsv
+		updated.RoomID = dbRooms[1].ID
+		values := adminReservationValues(updated)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, fmt.Sprintf("/admin/reservations/%d", rsv.ID), strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("UpdateReservationTx", mock.Anything, updateReservationArg(updated)).
+			Return(db.Reservation{}, db.ErrRoomUnavailable).
+			Once()
+		buildAdminReservationStubs(ts, rsv, dbRooms)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "The room is unavailable on the dates selected or does not fit the guests.")
+		assert.Contains(t, rr.Body.String(), fmt.Sprintf(`<option value="%d" selected>`, updated.RoomID))
+	})
+
+	// Test Warning: the reservation was cancelled
+	t.Run("Reservation Cancelled", func(t *testing.T) {
+		rsv, row, _ := adminReservationRow()
+		rsvURL := fmt.Sprintf("/admin/reservations/%d", rsv.ID)
+		values := adminReservationValues(rsv)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, rsvURL, strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("UpdateReservationTx", mock.Anything, updateReservationArg(rsv)).
+			Return(db.Reservation{}, db.ErrReservationCancelled).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get warning message from session and remove it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "Reservation is cancelled and can no longer be changed.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, rsvURL, rr.Header().Get("Location"))
+	})
+
+	// Test Warning: the reservation was checked out or is a no show
+	t.Run("Reservation Closed", func(t *testing.T) {
+		rsv, row, _ := adminReservationRow()
+		rsvURL := fmt.Sprintf("/admin/reservations/%d", rsv.ID)
+		values := adminReservationValues(rsv)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, rsvURL, strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("UpdateReservationTx", mock.Anything, updateReservationArg(rsv)).
+			Return(db.Reservation{}, db.ErrReservationClosed).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get warning message from session and remove it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "Reservation is checked out or a no show and can no longer be changed.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, rsvURL, rr.Header().Get("Location"))
+	})
+
+	// Test Error: internal server error on UpdateReservationTx
+	t.Run("Database Error", func(t *testing.T) {
+		rsv, row, _ := adminReservationRow()
+		rsvURL := fmt.Sprintf("/admin/reservations/%d", rsv.ID)
+		values := adminReservationValues(rsv)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, rsvURL, strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("UpdateReservationTx", mock.Anything, updateReservationArg(rsv)).
+			Return(db.Reservation{}, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, rsvURL, rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostAdminReservationStatusHandler(t *testing.T) {
+	// create random reservation with room data
+	rsv := randomReservation()
+	requestURL := fmt.Sprintf("/admin/reservations/%d/status", rsv.ID)
+
+	// newBody returns the form data of the request moving the reservation to status from the list show
+	newBody := func(status ReservationStatus, show string) *strings.Reader {
+		values := url.Values{
+			"status":      {string(status)},
+			"redirect_to": {"/admin/reservations/" + show},
+		}
+		return strings.NewReader(values.Encode())
+	}
+
+	// Test OK: reservation is confirmed
+	t.Run("OK", func(t *testing.T) {
+		// create stub return arguments
+		confirmed := rsv
+		confirmed.Status = ReservationConfirmed
+
+		dbRsv := db.Reservation{}
+		confirmed.Export(&dbRsv)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, requestURL, newBody(ReservationConfirmed, "new"))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		arg := db.UpdateReservationStatusTxParams{
+			UpdateReservationStatusParams: db.UpdateReservationStatusParams{
+				ID:     rsv.ID,
+				Status: db.ReservationStatusConfirmed,
+			},
+		}
+		arg.Date.Scan(Today())
+		ts.MockDBStore.On("UpdateReservationStatusTx", mock.Anything, arg).
+			Return(dbRsv, nil).
+			Once()
+		ts.BuildLogInfoStub(fmt.Sprintf("STATUS reservation %s marked as confirmed by user 1", rsv.Code))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, fmt.Sprintf("Reservation %s is now Confirmed.", rsv.Code), msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/reservations/new", rr.Header().Get("Location"))
+	})
+
+	// Test OK: room of a no-show reservation is offered to the waitlist
+	t.Run("OK No Show", func(t *testing.T) {
+		// create stub return arguments
+		noShow := rsv
+		noShow.Status = ReservationNoShow
+
+		dbRsv := db.Reservation{}
+		noShow.Export(&dbRsv)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, requestURL, newBody(ReservationNoShow, "confirmed"))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("UpdateReservationStatusTx", mock.Anything, mock.Anything).
+			Return(dbRsv, nil).
+			Once()
+		ts.BuildLogAnyInfoStub()
+		ts.MockDBStore.On("NotifyWaitlistTx", mock.Anything, mock.Anything).
+			Return([]db.WaitlistEntry{}, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/reservations/confirmed", rr.Header().Get("Location"))
+	})
+
+	// Test OK: staff cancelling a paid reservation are prompted to refund the guest
+	t.Run("OK Cancelled Refund Due", func(t *testing.T) {
+		// create stub return arguments
+		cancelled := rsv
+		cancelled.Status = ReservationCancelled
+		cancelled.CancelledBy = "staff"
+		cancelled.CancellationFeePercent = 0
+
+		dbRsv := db.Reservation{}
+		cancelled.Export(&dbRsv)
+
+		capture := randomPayment()
+		capture.ReservationID = rsv.ID
+		capture.Amount = 2500
+		dbPayments := make([]db.Payment, 1)
+		capture.Export(&dbPayments[0])
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, requestURL, newBody(ReservationCancelled, "confirmed"))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("UpdateReservationStatusTx", mock.Anything, mock.Anything).
+			Return(dbRsv, nil).
+			Once()
+		ts.BuildLogInfoStub(fmt.Sprintf("STATUS reservation %s marked as cancelled by user 1", rsv.Code))
+		ts.MockDBStore.On("NotifyWaitlistTx", mock.Anything, mock.Anything).
+			Return([]db.WaitlistEntry{}, nil).
+			Once()
+		ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, rsv.ID).
+			Return(dbPayments, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash and warning messages from session and remove them
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, fmt.Sprintf("Reservation %s is now Cancelled.", rsv.Code), msg)
+		msg = app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "A refund of $25.00 is due to the guest. Please refund it.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, fmt.Sprintf("/admin/reservations/%d/refund", rsv.ID), rr.Header().Get("Location"))
+		ts.MockPayments.AssertNotCalled(t, "Refund", mock.Anything, mock.Anything)
+	})
+
+	// Test OK: staff cancelling an unpaid reservation are not prompted to refund
+	t.Run("OK Cancelled Unpaid", func(t *testing.T) {
+		// create stub return arguments
+		cancelled := rsv
+		cancelled.Status = ReservationCancelled
+		cancelled.CancelledBy = "staff"
+
+		dbRsv := db.Reservation{}
+		cancelled.Export(&dbRsv)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, requestURL, newBody(ReservationCancelled, "confirmed"))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("UpdateReservationStatusTx", mock.Anything, mock.Anything).
+			Return(dbRsv, nil).
+			Once()
+		ts.BuildLogAnyInfoStub()
+		ts.MockDBStore.On("NotifyWaitlistTx", mock.Anything, mock.Anything).
+			Return([]db.WaitlistEntry{}, nil).
+			Once()
+		ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, rsv.ID).
+			Return([]db.Payment{}, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.False(t, app.Session.Exists(req.Context(), "warning"))
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/reservations/confirmed", rr.Header().Get("Location"))
+	})
+
+	// Test Error: reservation cannot move to the status requested
+	t.Run("Invalid Transition", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, requestURL, newBody(ReservationCheckedOut, "new"))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stub
+		ts.MockDBStore.On("UpdateReservationStatusTx", mock.Anything, mock.Anything).
+			Return(db.Reservation{}, db.ErrInvalidStatusTransition).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get warning message from session and remove it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "Reservation cannot be marked as Checked Out.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/reservations/new", rr.Header().Get("Location"))
+	})
+
+	// Test Error: invalid reservation id in URL
+	t.Run("Invalid Parameter", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/reservations/any/status", newBody(ReservationConfirmed, "new"))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stub
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/reservations/new", rr.Header().Get("Location"))
+	})
+
+	// Test Error: internal server error on UpdateReservationStatusTx
+	t.Run("Internal Server Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, requestURL, newBody(ReservationConfirmed, "new"))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("UpdateReservationStatusTx", mock.Anything, mock.Anything).
+			Return(db.Reservation{}, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/reservations/new", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostAdminCheckInHandler(t *testing.T) {
+	// create random reservation arriving today
+	rsv := randomReservation()
+	rsv.StartDate = Today()
+	rsv.EndDate = Today().AddDate(0, 0, 3)
+	requestURL := fmt.Sprintf("/admin/reservations/%d/check-in", rsv.ID)
+	values := url.Values{"redirect_to": {"/admin/today"}}
+
+	// create stub call arguments
+	arg := db.UpdateReservationStatusTxParams{
+		UpdateReservationStatusParams: db.UpdateReservationStatusParams{
+			ID:     rsv.ID,
+			Status: db.ReservationStatusCheckedIn,
+		},
+	}
+	arg.Date.Scan(Today())
+
+	// Test OK: guest is checked in
+	t.Run("OK", func(t *testing.T) {
+		// create stub return arguments
+		checkedIn := rsv
+		checkedIn.Status = ReservationCheckedIn
+
+		dbRsv := db.Reservation{}
+		checkedIn.Export(&dbRsv)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, requestURL, strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("UpdateReservationStatusTx", mock.Anything, arg).
+			Return(dbRsv, nil).
+			Once()
+		ts.BuildLogInfoStub(fmt.Sprintf("STATUS reservation %s marked as checked_in by user 1", rsv.Code))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, fmt.Sprintf("Reservation %s is now Checked In.", rsv.Code), msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/today", rr.Header().Get("Location"))
+	})
+
+	// Test Error: a pending reservation cannot be checked in
+	t.Run("Invalid Transition", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, requestURL, strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stub
+		ts.MockDBStore.On("UpdateReservationStatusTx", mock.Anything, arg).
+			Return(db.Reservation{}, db.ErrInvalidStatusTransition).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get warning message from session and remove it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "Reservation cannot be marked as Checked In.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/today", rr.Header().Get("Location"))
+	})
+
+	// Test Error: guest cannot be checked in before the arrival date
+	t.Run("Early Check In", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, requestURL, strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stub
+		ts.MockDBStore.On("UpdateReservationStatusTx", mock.Anything, arg).
+			Return(db.Reservation{}, db.ErrEarlyCheckIn).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get warning message from session and remove it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "Reservation cannot be checked in before its arrival date.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/today", rr.Header().Get("Location"))
+	})
+
+	// Test OK: redirect outside the admin pages is ignored
+	t.Run("Redirect Outside Admin", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		body := strings.NewReader(url.Values{"redirect_to": {"https://example.com"}}.Encode())
+		req := ts.NewRequestWithSession(t, http.MethodPost, requestURL, body)
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stub
+		ts.MockDBStore.On("UpdateReservationStatusTx", mock.Anything, arg).
+			Return(db.Reservation{}, db.ErrInvalidStatusTransition).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/reservations/new", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostAdminCheckOutHandler(t *testing.T) {
+	// create random reservation of a guest in house
+	rsv := randomReservation()
+	rsv.Status = ReservationCheckedIn
+	requestURL := fmt.Sprintf("/admin/reservations/%d/check-out", rsv.ID)
+	values := url.Values{"redirect_to": {"/admin/today"}}
+
+	// create stub call arguments
+	arg := db.UpdateReservationStatusTxParams{
+		UpdateReservationStatusParams: db.UpdateReservationStatusParams{
+			ID:     rsv.ID,
+			Status: db.ReservationStatusCheckedOut,
+		},
+	}
+	arg.Date.Scan(Today())
+
+	// Test OK: guest checks out on the departure date
+	t.Run("OK", func(t *testing.T) {
+		// create stub return arguments
+		checkedOut := rsv
+		checkedOut.StartDate = Today().AddDate(0, 0, -3)
+		checkedOut.EndDate = Today()
+		checkedOut.Status = ReservationCheckedOut
+
+		dbRsv := db.Reservation{}
+		checkedOut.Export(&dbRsv)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, requestURL, strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("UpdateReservationStatusTx", mock.Anything, arg).
+			Return(dbRsv, nil).
+			Once()
+		ts.BuildLogInfoStub(fmt.Sprintf("STATUS reservation %s marked as checked_out by user 1", rsv.Code))
+		ts.MockDBStore.On("GetFolioBalance", mock.Anything, rsv.ID).
+			Return(int64(0), nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, fmt.Sprintf("Reservation %s is now Checked Out.", rsv.Code), msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/today", rr.Header().Get("Location"))
+	})
+
+	// Test OK: guest checks out early and the remaining nights are offered to the waitlist
+	t.Run("OK Early Check-Out", func(t *testing.T) {
+		// create stub return arguments
+		checkedOut := rsv
+		checkedOut.StartDate = Today().AddDate(0, 0, -1)
+		checkedOut.EndDate = Today().AddDate(0, 0, 3)
+		checkedOut.Status = ReservationCheckedOut
+
+		dbRsv := db.Reservation{}
+		checkedOut.Export(&dbRsv)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, requestURL, strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// matchArg checks the nights released are offered to the waitlist
+		matchArg := mock.MatchedBy(func(arg db.NotifyWaitlistTxParams) bool {
+			return arg.RoomID == checkedOut.RoomID &&
+				arg.StartDate.Time.Equal(Today()) &&
+				arg.EndDate.Time.Equal(checkedOut.EndDate)
+		})
+
+		// build stubs
+		ts.MockDBStore.On("UpdateReservationStatusTx", mock.Anything, arg).
+			Return(dbRsv, nil).
+			Once()
+		ts.BuildLogInfoStub(fmt.Sprintf("STATUS reservation %s marked as checked_out by user 1", rsv.Code))
+		ts.MockDBStore.On("GetFolioBalance", mock.Anything, rsv.ID).
+			Return(int64(0), nil).
+			Once()
+		ts.BuildLogInfoStub(fmt.Sprintf("STATUS reservation %s checked out early, nights from %s to %s released",
+			rsv.Code, Today().Format(config.DateLayout), checkedOut.EndDate.Format(config.DateLayout)))
+		ts.MockDBStore.On("NotifyWaitlistTx", mock.Anything, matchArg).
+			Return([]db.WaitlistEntry{}, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/today", rr.Header().Get("Location"))
+	})
+
+	// Test OK: guest checks out with a credit on the folio, which is flagged for refund
+	t.Run("OK Folio Credit", func(t *testing.T) {
+		// create stub return arguments
+		checkedOut := rsv
+		checkedOut.StartDate = Today().AddDate(0, 0, -3)
+		checkedOut.EndDate = Today()
+		checkedOut.Status = ReservationCheckedOut
+
+		dbRsv := db.Reservation{}
+		checkedOut.Export(&dbRsv)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, requestURL, strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("UpdateReservationStatusTx", mock.Anything, arg).
+			Return(dbRsv, nil).
+			Once()
+		ts.BuildLogInfoStub(fmt.Sprintf("STATUS reservation %s marked as checked_out by user 1", rsv.Code))
+		ts.MockDBStore.On("GetFolioBalance", mock.Anything, rsv.ID).
+			Return(int64(-1850), nil).
+			Once()
+		ts.BuildLogInfoStub(fmt.Sprintf("FOLIO credit of $18.50 on reservation %d due for refund", rsv.ID))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash and warning messages from session and remove them
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, fmt.Sprintf("Reservation %s is now Checked Out.", rsv.Code), msg)
+		msg = app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "The folio has a credit of $18.50 due to the guest. Please refund it.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/today", rr.Header().Get("Location"))
+	})
+
+	// Test Error: the folio of the guest has an unsettled balance
+	t.Run("Unsettled Folio", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, requestURL, strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stub, the balance is checked while the reservation is locked
+		ts.MockDBStore.On("UpdateReservationStatusTx", mock.Anything, arg).
+			Return(db.Reservation{}, &db.FolioBalanceError{Balance: 1850}).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get warning message from session and remove it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "Reservation cannot be checked out with an unsettled folio balance of $18.50.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/today", rr.Header().Get("Location"))
+	})
+
+	// Test OK: the guest is checked out even if the folio balance cannot be loaded to check for a credit
+	t.Run("OK Folio Balance Error", func(t *testing.T) {
+		// create stub return arguments
+		checkedOut := rsv
+		checkedOut.StartDate = Today().AddDate(0, 0, -3)
+		checkedOut.EndDate = Today()
+		checkedOut.Status = ReservationCheckedOut
+
+		dbRsv := db.Reservation{}
+		checkedOut.Export(&dbRsv)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, requestURL, strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("UpdateReservationStatusTx", mock.Anything, arg).
+			Return(dbRsv, nil).
+			Once()
+		ts.BuildLogInfoStub(fmt.Sprintf("STATUS reservation %s marked as checked_out by user 1", rsv.Code))
+		ts.MockDBStore.On("GetFolioBalance", mock.Anything, rsv.ID).
+			Return(int64(0), errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, fmt.Sprintf("Reservation %s is now Checked Out.", rsv.Code), msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/today", rr.Header().Get("Location"))
+	})
+
+	// Test Error: internal server error on UpdateReservationStatusTx
+	t.Run("Database Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, requestURL, strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("UpdateReservationStatusTx", mock.Anything, arg).
+			Return(db.Reservation{}, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get error message from session and remove it
+		msg := app.Session.PopString(req.Context(), "error")
+		assert.Equal(t, "Unable to update reservation status.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/today", rr.Header().Get("Location"))
+	})
+}
+
+// folioReservationRow returns a reservation of a guest in house, its database row
+// and the database entries of its folio: a charge, a voided charge and a payment
+func folioReservationRow() (Reservation, db.GetReservationAndRoomRow, []db.FolioEntry) {
+	rsv := randomReservation()
+	rsv.Status = ReservationCheckedIn
+
+	row := db.GetReservationAndRoomRow{}
+	rsv.Export(&row.Reservation)
+	rsv.Room.Export(&row.Room)
+
+	entries := []FolioEntry{randomFolioEntry(), randomFolioEntry(), randomFolioEntry()}
+	entries[0].Kind, entries[0].Description, entries[0].Amount = FolioCharge, "Minibar", 2400
+	entries[1].Kind, entries[1].Description, entries[1].Amount = FolioCharge, "Parking", 1500
+	entries[1].VoidedAt, entries[1].VoidedBy = time.Now(), 1
+	entries[2].Kind, entries[2].Description, entries[2].Amount = FolioPayment, "Cash payment", 1000
+
+	dbEntries := make([]db.FolioEntry, len(entries))
+	for i := range entries {
+		entries[i].ReservationID = rsv.ID
+		entries[i].Export(&dbEntries[i])
+	}
+
+	return rsv, row, dbEntries
+}
+
+func TestServer_AdminFolioHandler(t *testing.T) {
+	// Test OK: entries are listed with the running balance, and check-out is blocked by the unsettled balance
+	t.Run("OK", func(t *testing.T) {
+		rsv, row, dbEntries := folioReservationRow()
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, fmt.Sprintf("/admin/reservations/%d/folio", rsv.ID), nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("ListFolioEntriesByReservation", mock.Anything, rsv.ID).
+			Return(dbEntries, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), rsv.Code)
+		assert.Contains(t, rr.Body.String(), "Minibar")
+		assert.Contains(t, rr.Body.String(), "<s>Parking</s>")
+		assert.Contains(t, rr.Body.String(), "-$10.00")
+		assert.Contains(t, rr.Body.String(), "<strong>$14.00</strong>")
+		assert.Contains(t, rr.Body.String(), "Unsettled")
+		assert.Contains(t, rr.Body.String(), "Settle the balance of the folio to check out.")
+	})
+
+	// Test Error: invalid reservation id
+	t.Run("Invalid ID", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/reservations/abc/folio", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stub
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/reservations/new", rr.Header().Get("Location"))
+	})
+
+	// Test Error: internal server error on ListFolioEntriesByReservation
+	t.Run("Database Error", func(t *testing.T) {
+		rsv, row, _ := folioReservationRow()
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, fmt.Sprintf("/admin/reservations/%d/folio", rsv.ID), nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("ListFolioEntriesByReservation", mock.Anything, rsv.ID).
+			Return(nil, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get error message from session and remove it
+		msg := app.Session.PopString(req.Context(), "error")
+		assert.Equal(t, "Unable to load folio from database.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/reservations/new", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostAdminFolioHandler(t *testing.T) {
+	// Test OK: a charge is posted by the staff member
+	t.Run("OK", func(t *testing.T) {
+		rsv, row, _ := folioReservationRow()
+		folioURL := fmt.Sprintf("/admin/reservations/%d/folio", rsv.ID)
+		values := url.Values{
+			"kind":        {"charge"},
+			"description": {" Late check-out "},
+			"amount":      {"$25"},
+		}
+
+		// create stub call arguments
+		arg := db.CreateFolioEntryParams{
+			ReservationID: rsv.ID,
+			Kind:          db.FolioEntryKindCharge,
+			Description:   "Late check-out",
+			Amount:        2500,
+		}
+		arg.UserID.Scan(int64(1))
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, folioURL, strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("CreateFolioEntry", mock.Anything, arg).
+			Return(db.FolioEntry{
+				ID:            util.RandomID(),
+				ReservationID: arg.ReservationID,
+				Kind:          arg.Kind,
+				Description:   arg.Description,
+				Amount:        arg.Amount,
+				UserID:        arg.UserID,
+			}, nil).
+			Once()
+		ts.BuildLogInfoStub(fmt.Sprintf("FOLIO charge $25.00 Late check-out posted to reservation %s by user 1", rsv.Code))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, "Charge of $25.00 posted.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, folioURL, rr.Header().Get("Location"))
+	})
+
+	// Test Error: invalid form is rendered again with the errors
+	for _, test := range []struct {
+		Name   string
+		Values url.Values
+		Error  string
+	}{
+		{Name: "Invalid Kind", Values: url.Values{"kind": {"refund"}, "description": {"Minibar"}, "amount": {"12"}}, Error: "Invalid entry type!"},
+		{Name: "Invalid Amount", Values: url.Values{"kind": {"charge"}, "description": {"Minibar"}, "amount": {"abc"}}, Error: "Invalid amount."},
+		{Name: "Zero Amount", Values: url.Values{"kind": {"payment"}, "description": {"Cash payment"}, "amount": {"0"}}, Error: "Invalid amount."},
+	} {
+		t.Run(test.Name, func(t *testing.T) {
+			rsv, row, dbEntries := folioReservationRow()
+
+			// create a new test server, a mock database store and an authenticated request
+			ts := NewTestServer(t)
+			req := ts.NewRequestWithSession(t, http.MethodPost, fmt.Sprintf("/admin/reservations/%d/folio", rsv.ID),
+				strings.NewReader(test.Values.Encode()))
+			app.Session.Put(req.Context(), "user_id", int64(1))
+
+			// build stubs
+			ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+				Return(row, nil).
+				Once()
+			ts.MockDBStore.On("ListFolioEntriesByReservation", mock.Anything, rsv.ID).
+				Return(dbEntries, nil).
+				Once()
+
+			//  server the request
+			rr := ts.ServeRequest(req)
+
+			// testify
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Contains(t, rr.Body.String(), test.Error)
+		})
+	}
+
+	// Test Error: internal server error on CreateFolioEntry
+	t.Run("Database Error", func(t *testing.T) {
+		rsv, row, _ := folioReservationRow()
+		folioURL := fmt.Sprintf("/admin/reservations/%d/folio", rsv.ID)
+		values := url.Values{"kind": {"charge"}, "description": {"Minibar"}, "amount": {"12"}}
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, folioURL, strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("CreateFolioEntry", mock.Anything, mock.Anything).
+			Return(db.FolioEntry{}, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get error message from session and remove it
+		msg := app.Session.PopString(req.Context(), "error")
+		assert.Equal(t, "Unable to post folio entry.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, folioURL, rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostAdminVoidFolioEntryHandler(t *testing.T) {
+	rsvID := util.RandomID()
+	entry := randomFolioEntry()
+	entry.ReservationID = rsvID
+	folioURL := fmt.Sprintf("/admin/reservations/%d/folio", rsvID)
+	requestURL := fmt.Sprintf("%s/%d/void", folioURL, entry.ID)
+
+	// create stub call arguments
+	arg := db.VoidFolioEntryParams{
+		ID:            entry.ID,
+		ReservationID: rsvID,
+	}
+	arg.VoidedBy.Scan(int64(1))
+
+	// Test OK: the entry is voided by the staff member
+	t.Run("OK", func(t *testing.T) {
+		voided := entry
+		voided.VoidedAt = time.Now()
+		voided.VoidedBy = 1
+		dbEntry := db.FolioEntry{}
+		voided.Export(&dbEntry)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, requestURL, nil)
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("VoidFolioEntry", mock.Anything, arg).
+			Return(dbEntry, nil).
+			Once()
+		ts.BuildLogInfoStub(fmt.Sprintf("FOLIO %s %s %s of reservation %d voided by user 1",
+			string(entry.Kind), entry.Amount, entry.Description, rsvID))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, fmt.Sprintf("Charge of %s voided.", entry.Amount), msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, folioURL, rr.Header().Get("Location"))
+	})
+
+	// Test Error: the entry is already voided
+	t.Run("Already Voided", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, requestURL, nil)
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stub
+		ts.MockDBStore.On("VoidFolioEntry", mock.Anything, arg).
+			Return(db.FolioEntry{}, pgx.ErrNoRows).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get warning message from session and remove it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "Folio entry is already voided.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, folioURL, rr.Header().Get("Location"))
+	})
+
+	// Test Error: invalid entry id
+	t.Run("Invalid ID", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, folioURL+"/abc/void", nil)
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stub
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, folioURL, rr.Header().Get("Location"))
+	})
+
+	// Test Error: internal server error on VoidFolioEntry
+	t.Run("Database Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, requestURL, nil)
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("VoidFolioEntry", mock.Anything, arg).
+			Return(db.FolioEntry{}, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get error message from session and remove it
+		msg := app.Session.PopString(req.Context(), "error")
+		assert.Equal(t, "Unable to void folio entry.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, folioURL, rr.Header().Get("Location"))
+	})
+}
+
+// cancelledReservationRow returns a cancelled reservation with a cancellation fee of feePercent,
+// its database row and the database capture of amount paid for it
+func cancelledReservationRow(feePercent int, total, amount Price) (Reservation, db.GetReservationAndRoomRow, []db.Payment) {
+	rsv := randomReservation()
+	rsv.Status = ReservationCancelled
+	rsv.CancelledAt = time.Now()
+	rsv.CancellationFeePercent = feePercent
+	rsv.TotalPrice = total
+
+	row := db.GetReservationAndRoomRow{}
+	rsv.Export(&row.Reservation)
+	rsv.Room.Export(&row.Room)
+
+	capture := randomPayment()
+	capture.ReservationID = rsv.ID
+	capture.ProviderRef = "fake_capt_000002"
+	capture.Amount = amount
+	dbPayments := make([]db.Payment, 1)
+	capture.Export(&dbPayments[0])
+
+	return rsv, row, dbPayments
+}
+
+func TestServer_AdminRefundHandler(t *testing.T) {
+	// Test OK: payments and refunds are listed, and the amount refundable by policy is prefilled
+	t.Run("OK", func(t *testing.T) {
+		rsv, row, dbPayments := cancelledReservationRow(50, 10000, 8000)
+
+		refund := randomRefund()
+		refund.ReservationID = rsv.ID
+		dbRefunds := make([]db.Refund, 1)
+		refund.Export(&dbRefunds[0])
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, fmt.Sprintf("/admin/reservations/%d/refund", rsv.ID), nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, rsv.ID).
+			Return(dbPayments, nil).
+			Once()
+		ts.MockDBStore.On("ListRefundsByReservation", mock.Anything, rsv.ID).
+			Return(dbRefunds, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), rsv.Code)
+		assert.Contains(t, rr.Body.String(), "fake_capt_000002")
+		assert.Contains(t, rr.Body.String(), refund.Reason)
+		assert.Contains(t, rr.Body.String(), `value='30.00'`)
+	})
+
+	// Test Error: invalid reservation id
+	t.Run("Invalid ID", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/reservations/abc/refund", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stub
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/reservations/new", rr.Header().Get("Location"))
+	})
+
+	// Test Error: internal server error on ListPaymentsByReservation
+	t.Run("Database Error", func(t *testing.T) {
+		rsv, row, _ := cancelledReservationRow(0, 10000, 2000)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, fmt.Sprintf("/admin/reservations/%d/refund", rsv.ID), nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, rsv.ID).
+			Return(nil, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/reservations/new", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostAdminRefundHandler(t *testing.T) {
+	// Test OK: staff override the policy amount with a reason
+	t.Run("OK", func(t *testing.T) {
+		rsv, row, dbPayments := cancelledReservationRow(50, 10000, 8000)
+		refundURL := fmt.Sprintf("/admin/reservations/%d/refund", rsv.ID)
+		values := url.Values{
+			"amount": {"80"},
+			"reason": {"Flight cancelled"},
+		}
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, refundURL, strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, rsv.ID).
+			Return(dbPayments, nil).
+			Once()
+		ts.BuildRefundStub(1)
+		ts.BuildLogInfoStub(fmt.Sprintf("REFUND $80.00 of reservation %s by user 1 overrides policy amount $30.00: Flight cancelled", rsv.Code))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, fmt.Sprintf("Refunded $80.00 of reservation %s.", rsv.Code), msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, refundURL, rr.Header().Get("Location"))
+	})
+
+	// Test Error: invalid form is rendered again with the errors
+	for _, test := range []struct {
+		Name   string
+		Values url.Values
+		Error  string
+		Refund bool
+	}{
+		{Name: "Invalid Amount", Values: url.Values{"amount": {"abc"}}, Error: "Invalid amount."},
+		{Name: "Amount Exceeds Paid", Values: url.Values{"amount": {"90"}, "reason": {"any"}}, Error: "Amount cannot exceed the amount paid.", Refund: true},
+		{Name: "Missing Reason", Values: url.Values{"amount": {"80"}}, Error: "Please give a reason", Refund: true},
+	} {
+		t.Run(test.Name, func(t *testing.T) {
+			rsv, row, dbPayments := cancelledReservationRow(50, 10000, 8000)
+
+			// create a new test server, a mock database store and an authenticated request
+			ts := NewTestServer(t)
+			req := ts.NewRequestWithSession(t, http.MethodPost, fmt.Sprintf("/admin/reservations/%d/refund", rsv.ID),
+				strings.NewReader(test.Values.Encode()))
+			app.Session.Put(req.Context(), "user_id", int64(1))
+
+			// build stubs
+			ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+				Return(row, nil).
+				Once()
+			if test.Refund {
+				ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, rsv.ID).
+					Return(dbPayments, nil).
+					Once()
+			}
+			ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, rsv.ID).
+				Return(dbPayments, nil).
+				Once()
+			ts.MockDBStore.On("ListRefundsByReservation", mock.Anything, rsv.ID).
+				Return([]db.Refund{}, nil).
+				Once()
+
+			//  server the request
+			rr := ts.ServeRequest(req)
+
+			// testify
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Contains(t, rr.Body.String(), test.Error)
+		})
+	}
+
+	// Test Error: the payments were refunded meanwhile by another request
+	t.Run("Refunded Meanwhile", func(t *testing.T) {
+		rsv, row, dbPayments := cancelledReservationRow(50, 10000, 8000)
+		refundURL := fmt.Sprintf("/admin/reservations/%d/refund", rsv.ID)
+		values := url.Values{"amount": {"30"}}
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, refundURL, strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, rsv.ID).
+			Return(dbPayments, nil).
+			Once()
+		ts.MockPayments.On("Name").Return("fake")
+		ts.MockPayments.On("Refund", mock.Anything, mock.Anything).
+			Return(payments.Transaction{ID: "fake_rfnd_000001", Amount: 3000, Status: payments.StatusSucceeded}, nil).
+			Once()
+		ts.MockDBStore.On("CreateRefundTx", mock.Anything, mock.Anything).
+			Return(db.Refund{}, db.ErrRefundExceedsPaid).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get warning message from session and remove it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "The payments of this reservation were refunded meanwhile. Please check the payments with the payment provider.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, refundURL, rr.Header().Get("Location"))
+	})
+
+	// Test Error: the payment provider fails
+	t.Run("Provider Error", func(t *testing.T) {
+		rsv, row, dbPayments := cancelledReservationRow(50, 10000, 8000)
+		refundURL := fmt.Sprintf("/admin/reservations/%d/refund", rsv.ID)
+		values := url.Values{"amount": {"30"}}
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, refundURL, strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, rsv.ID).
+			Return(dbPayments, nil).
+			Once()
+		ts.MockPayments.On("Refund", "fake_capt_000002", int64(3000)).
+			Return(payments.Transaction{}, payments.ErrInvalidPayment).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get error message from session and remove it
+		msg := app.Session.PopString(req.Context(), "error")
+		assert.Equal(t, "Unable to refund reservation.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, refundURL, rr.Header().Get("Location"))
+	})
+
+	// Test Error: internal server error on GetReservationAndRoom
+	t.Run("Database Error", func(t *testing.T) {
+		id := util.RandomID()
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, fmt.Sprintf("/admin/reservations/%d/refund", id),
+			strings.NewReader(url.Values{"amount": {"30"}}.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, id).
+			Return(db.GetReservationAndRoomRow{}, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/reservations/new", rr.Header().Get("Location"))
+	})
+}
+
+// invoicedReservationRow returns a random reservation, its database row and its issued invoice.
+func invoicedReservationRow() (Reservation, db.GetReservationAndRoomRow, Invoice) {
+	rsv := randomReservation()
+	rsv.Status = ReservationConfirmed
+
+	row := db.GetReservationAndRoomRow{}
+	rsv.Export(&row.Reservation)
+	rsv.Room.Export(&row.Room)
+
+	invoice := randomInvoice()
+	invoice.ReservationID = rsv.ID
+
+	return rsv, row, invoice
+}
+
+func TestServer_AdminInvoiceHandler(t *testing.T) {
+	// Test OK: the issued invoice is downloaded
+	t.Run("OK", func(t *testing.T) {
+		rsv, row, invoice := invoicedReservationRow()
+		dbInvoice := db.Invoice{}
+		invoice.Export(&dbInvoice)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, fmt.Sprintf("/admin/reservations/%d/invoice", rsv.ID), nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("GetInvoiceByReservation", mock.Anything, rsv.ID).
+			Return(dbInvoice, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, fmt.Sprintf("attachment; filename=%q", invoice.Filename()), rr.Header().Get("Content-Disposition"))
+		assert.Equal(t, invoice.Document, rr.Body.String())
+	})
+
+	// Test Not Issued: downloading does not issue an invoice number
+	t.Run("Not Issued", func(t *testing.T) {
+		rsv, row, _ := invoicedReservationRow()
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, fmt.Sprintf("/admin/reservations/%d/invoice", rsv.ID), nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("GetInvoiceByReservation", mock.Anything, rsv.ID).
+			Return(db.Invoice{}, pgx.ErrNoRows).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get warning message from session and remove it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, fmt.Sprintf("The invoice of reservation %s is not issued yet.", rsv.Code), msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, fmt.Sprintf("/admin/reservations/%d", rsv.ID), rr.Header().Get("Location"))
+		ts.MockDBStore.AssertNotCalled(t, "IssueInvoiceTx", mock.Anything, mock.Anything)
+	})
+
+	// Test Error: invalid reservation id
+	t.Run("Invalid ID", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/reservations/abc/invoice", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stub
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/reservations/new", rr.Header().Get("Location"))
+	})
+
+	// Test Error: internal server error on GetInvoiceByReservation
+	t.Run("Database Error", func(t *testing.T) {
+		rsv, row, _ := invoicedReservationRow()
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, fmt.Sprintf("/admin/reservations/%d/invoice", rsv.ID), nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("GetInvoiceByReservation", mock.Anything, rsv.ID).
+			Return(db.Invoice{}, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get error message from session and remove it
+		msg := app.Session.PopString(req.Context(), "error")
+		assert.Equal(t, "Unable to load invoice.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/reservations/new", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostAdminInvoiceHandler(t *testing.T) {
+	values := url.Values{"redirect_to": {"/admin/reservations/all"}}
+
+	// Test OK: the invoice is issued and rendered
+	t.Run("OK", func(t *testing.T) {
+		rsv, row, invoice := invoicedReservationRow()
+		dbInvoice := db.Invoice{}
+		invoice.Export(&dbInvoice)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, fmt.Sprintf("/admin/reservations/%d/invoice", rsv.ID),
+			strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("GetInvoiceByReservation", mock.Anything, rsv.ID).
+			Return(db.Invoice{}, pgx.ErrNoRows).
+			Once()
+		ts.MockDBStore.On("IssueInvoiceTx", mock.Anything, db.IssueInvoiceTxParams{Property: app.Listing.Name, ReservationID: rsv.ID}).
+			Return(dbInvoice, nil).
+			Once()
+		ts.MockDBStore.On("ListReservationCharges", mock.Anything, rsv.ID).
+			Return([]db.ReservationCharge{}, nil).
+			Once()
+		ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, rsv.ID).
+			Return([]db.Payment{}, nil).
+			Once()
+		ts.MockDBStore.On("UpdateInvoiceDocument", mock.Anything, mock.MatchedBy(func(arg db.UpdateInvoiceDocumentParams) bool {
+			return arg.ID == invoice.ID && arg.Document != invoice.Document
+		})).
+			Return(dbInvoice, nil).
+			Once()
+		ts.BuildLogInfoStub(fmt.Sprintf("INVOICE %s of reservation %s issued", invoice.InvoiceNumber(), rsv.Code))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, fmt.Sprintf("Invoice %s issued.", invoice.InvoiceNumber()), msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/reservations/all", rr.Header().Get("Location"))
+	})
+
+	// Test Not Invoiceable: a pending reservation is not issued an invoice
+	t.Run("Not Invoiceable", func(t *testing.T) {
+		rsv, row, _ := invoicedReservationRow()
+		row.Reservation.Status = db.ReservationStatusPending
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, fmt.Sprintf("/admin/reservations/%d/invoice", rsv.ID),
+			strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("GetInvoiceByReservation", mock.Anything, rsv.ID).
+			Return(db.Invoice{}, pgx.ErrNoRows).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get warning message from session and remove it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, fmt.Sprintf("Reservation %s is pending or cancelled and cannot be invoiced.", rsv.Code), msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/reservations/all", rr.Header().Get("Location"))
+		ts.MockDBStore.AssertNotCalled(t, "IssueInvoiceTx", mock.Anything, mock.Anything)
+	})
+
+	// Test Error: internal server error on UpdateInvoiceDocument
+	t.Run("Database Error", func(t *testing.T) {
+		rsv, row, invoice := invoicedReservationRow()
+		dbInvoice := db.Invoice{}
+		invoice.Export(&dbInvoice)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, fmt.Sprintf("/admin/reservations/%d/invoice", rsv.ID),
+			strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("GetInvoiceByReservation", mock.Anything, rsv.ID).
+			Return(dbInvoice, nil).
+			Once()
+		ts.MockDBStore.On("ListReservationCharges", mock.Anything, rsv.ID).
+			Return([]db.ReservationCharge{}, nil).
+			Once()
+		ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, rsv.ID).
+			Return([]db.Payment{}, nil).
+			Once()
+		ts.MockDBStore.On("UpdateInvoiceDocument", mock.Anything, mock.Anything).
+			Return(db.Invoice{}, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get error message from session and remove it
+		msg := app.Session.PopString(req.Context(), "error")
+		assert.Equal(t, "Unable to issue invoice.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/reservations/all", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostAdminSendInvoiceHandler(t *testing.T) {
+	values := url.Values{"redirect_to": {"/admin/reservations/all"}}
+
+	// Test OK: the invoice is emailed to the guest
+	t.Run("OK", func(t *testing.T) {
+		rsv, row, invoice := invoicedReservationRow()
+		dbInvoice := db.Invoice{}
+		invoice.Export(&dbInvoice)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, fmt.Sprintf("/admin/reservations/%d/invoice/send", rsv.ID),
+			strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("GetInvoiceByReservation", mock.Anything, rsv.ID).
+			Return(dbInvoice, nil).
+			Once()
+		ts.BuildSendAnyMailStub()
+		ts.BuildLogInfoStub(fmt.Sprintf("MAIL invoice %s sent to %s", invoice.InvoiceNumber(), rsv.Email))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, fmt.Sprintf("Invoice %s sent to %s.", invoice.InvoiceNumber(), rsv.Email), msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/reservations/all", rr.Header().Get("Location"))
+	})
+
+	// Test Error: internal server error on GetInvoiceByReservation
+	t.Run("Database Error", func(t *testing.T) {
+		rsv, row, _ := invoicedReservationRow()
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, fmt.Sprintf("/admin/reservations/%d/invoice/send", rsv.ID),
+			strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("GetReservationAndRoom", mock.Anything, rsv.ID).
+			Return(row, nil).
+			Once()
+		ts.MockDBStore.On("GetInvoiceByReservation", mock.Anything, rsv.ID).
+			Return(db.Invoice{}, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get error message from session and remove it
+		msg := app.Session.PopString(req.Context(), "error")
+		assert.Equal(t, "Unable to create invoice.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/reservations/all", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_AdminTodayHandler(t *testing.T) {
+	// create stub call arguments
+	var arg pgtype.Date
+	arg.Scan(Today())
+
+	// create random arrival and departure
+	arrival := randomReservation()
+	arrival.StartDate = Today()
+	arrival.Status = ReservationConfirmed
+
+	departure := randomReservation()
+	departure.EndDate = Today()
+	departure.Status = ReservationCheckedIn
+
+	// Test OK: arrivals and departures are listed
+	t.Run("OK", func(t *testing.T) {
+		// create stub return arguments
+		dbArrivals := make([]db.ListArrivalsAndRoomsRow, 1)
+		arrival.Export(&dbArrivals[0].Reservation)
+		arrival.Room.Export(&dbArrivals[0].Room)
+
+		dbDepartures := make([]db.ListDeparturesAndRoomsRow, 1)
+		departure.Export(&dbDepartures[0].Reservation)
+		departure.Room.Export(&dbDepartures[0].Room)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/today", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("ListArrivalsAndRooms", mock.Anything, arg).
+			Return(dbArrivals, nil).
+			Once()
+		ts.MockDBStore.On("ListDeparturesAndRooms", mock.Anything, arg).
+			Return(dbDepartures, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), arrival.Code)
+		assert.Contains(t, rr.Body.String(), fmt.Sprintf("/admin/reservations/%d/check-in", arrival.ID))
+		assert.Contains(t, rr.Body.String(), departure.Code)
+		assert.Contains(t, rr.Body.String(), fmt.Sprintf("/admin/reservations/%d/check-out", departure.ID))
+	})
+
+	// Test Error: internal server error on ListArrivalsAndRooms
+	t.Run("Arrivals Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/today", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("ListArrivalsAndRooms", mock.Anything, arg).
+			Return(nil, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/dashboard", rr.Header().Get("Location"))
+	})
+
+	// Test Error: internal server error on ListDeparturesAndRooms
+	t.Run("Departures Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/today", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("ListArrivalsAndRooms", mock.Anything, arg).
+			Return([]db.ListArrivalsAndRoomsRow{}, nil).
+			Once()
+		ts.MockDBStore.On("ListDeparturesAndRooms", mock.Anything, arg).
+			Return(nil, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/dashboard", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_AdminCalendarHandler(t *testing.T) {
+	// create stub call arguments
+	month := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	roomsArg := db.ListRoomsParams{Limit: LimitRoomsPerPage}
+	arg := db.ListRoomRestrictionsForPeriodParams{}
+	arg.StartDate.Scan(month)
+	arg.EndDate.Scan(month.AddDate(0, 1, 0))
+
+	// create a random reservation in the month
+	rsv := randomReservation()
+	rsv.StartDate = month.AddDate(0, 0, 9)
+	rsv.EndDate = month.AddDate(0, 0, 12)
+
+	// Test OK: the rooms are listed with their reservations
+	t.Run("OK", func(t *testing.T) {
+		// create stub return arguments
+		dbRooms := make([]db.Room, 1)
+		rsv.Room.Export(&dbRooms[0])
+
+		dbRestrictions := make([]db.RoomRestriction, 1)
+		restriction := randomRoomRestriction(rsv)
+		restriction.Export(&dbRestrictions[0])
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/calendar?month=2026-10", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("ListRooms", mock.Anything, roomsArg).
+			Return(dbRooms, nil).
+			Once()
+		ts.MockDBStore.On("ListRoomRestrictionsForPeriod", mock.Anything, arg).
+			Return(dbRestrictions, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "October 2026")
+		assert.Contains(t, rr.Body.String(), rsv.Room.Name)
+		assert.Contains(t, rr.Body.String(), fmt.Sprintf("/admin/reservations/%d", rsv.ID))
+		assert.Contains(t, rr.Body.String(), "/admin/calendar?month=2026-09")
+		assert.Contains(t, rr.Body.String(), "/admin/calendar?month=2026-11")
+	})
+
+	// Test Error: invalid month
+	t.Run("Invalid Month Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/calendar?month=2026-13", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/calendar", rr.Header().Get("Location"))
+	})
+
+	// Test Error: internal server error on ListRooms
+	t.Run("Rooms Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/calendar?month=2026-10", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("ListRooms", mock.Anything, roomsArg).
+			Return(nil, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/dashboard", rr.Header().Get("Location"))
+	})
+
+	// Test Error: internal server error on ListRoomRestrictionsForPeriod
+	t.Run("Restrictions Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/calendar?month=2026-10", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("ListRooms", mock.Anything, roomsArg).
+			Return([]db.Room{}, nil).
+			Once()
+		ts.MockDBStore.On("ListRoomRestrictionsForPeriod", mock.Anything, arg).
+			Return(nil, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/dashboard", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_AdminRoomRatesHandler(t *testing.T) {
+	// create stub call arguments
+	arg := db.ListRoomRatesAndRoomsParams{Limit: LimitRoomRatesPerPage}
+	arg.Date.Scan(Today())
+	roomsArg := db.ListRoomsParams{Limit: LimitRoomsPerPage}
+
+	// Test OK: room rates and rooms are listed
+	t.Run("OK", func(t *testing.T) {
+		// create stub return arguments
+		rate := randomRoomRate()
+		dbRates := make([]db.ListRoomRatesAndRoomsRow, 1)
+		rate.Export(&dbRates[0].RoomRate)
+		rate.Room.Export(&dbRates[0].Room)
+
+		dbRooms := make([]db.Room, 1)
+		rate.Room.Export(&dbRooms[0])
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/rates", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("ListRoomRatesAndRooms", mock.Anything, arg).
+			Return(dbRates, nil).
+			Once()
+		ts.MockDBStore.On("ListRooms", mock.Anything, roomsArg).
+			Return(dbRooms, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), rate.Name)
+		assert.Contains(t, rr.Body.String(), "Fri, Sat")
+		assert.Contains(t, rr.Body.String(), rate.Rate.String())
+		assert.Contains(t, rr.Body.String(), fmt.Sprintf(`name="rate_id" value="%d"`, rate.ID))
+	})
+
+	// Test Error: internal server error on ListRoomRatesAndRooms
+	t.Run("Room Rates Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/rates", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("ListRoomRatesAndRooms", mock.Anything, arg).
+			Return(nil, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/dashboard", rr.Header().Get("Location"))
+	})
+
+	// Test Error: internal server error on ListRooms
+	t.Run("Rooms Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/rates", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("ListRoomRatesAndRooms", mock.Anything, arg).
+			Return([]db.ListRoomRatesAndRoomsRow{}, nil).
+			Once()
+		ts.MockDBStore.On("ListRooms", mock.Anything, roomsArg).
+			Return(nil, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/dashboard", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostAdminRoomRatesHandler(t *testing.T) {
+	// create random rooms and form values
+	rooms := randomRooms(2)
+	startDate := Today().AddDate(0, 1, 0)
+	endDate := startDate.AddDate(0, 2, 0)
+
+	values := url.Values{
+		"name":       {"Summer Weekends"},
+		"start_date": {startDate.Format(config.DateLayout)},
+		"end_date":   {endDate.Format(config.DateLayout)},
+		"rate":       {"275.50"},
+		"priority":   {"5"},
+		"room_id":    {fmt.Sprint(rooms[0].ID), fmt.Sprint(rooms[1].ID)},
+		"days":       {"5", "6"},
+	}
+
+	// create stub call arguments
+	args := make([]db.CreateRoomRateParams, len(rooms))
+	for i, room := range rooms {
+		args[i] = db.CreateRoomRateParams{
+			RoomID:     room.ID,
+			Name:       "Summer Weekends",
+			DaysOfWeek: db.DaysOfWeekMask(time.Friday, time.Saturday),
+			Rate:       27550,
+			Priority:   5,
+		}
+		args[i].StartDate.Scan(startDate)
+		args[i].EndDate.Scan(endDate)
+	}
+
+	// Test OK: a room rate is created for every room selected
+	t.Run("OK", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/rates", strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stub
+		ts.MockDBStore.On("CreateRoomRatesTx", mock.Anything, args).
+			Return(make([]db.RoomRate, len(args)), nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, "2 room rates created.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/rates", rr.Header().Get("Location"))
+	})
+
+	// Test Error: invalid form is rendered again with the errors
+	t.Run("Invalid Form", func(t *testing.T) {
+		invalid := url.Values{
+			"start_date": {endDate.Format(config.DateLayout)},
+			"end_date":   {startDate.Format(config.DateLayout)},
+			"rate":       {"abc"},
+			"priority":   {"0"},
+		}
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/rates", strings.NewReader(invalid.Encode()))
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		arg := db.ListRoomRatesAndRoomsParams{Limit: LimitRoomRatesPerPage}
+		arg.Date.Scan(Today())
+		ts.MockDBStore.On("ListRoomRatesAndRooms", mock.Anything, arg).
+			Return([]db.ListRoomRatesAndRoomsRow{}, nil).
+			Once()
+		ts.MockDBStore.On("ListRooms", mock.Anything, db.ListRoomsParams{Limit: LimitRoomsPerPage}).
+			Return([]db.Room{}, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "End date cannot be prior to start date.")
+		assert.Contains(t, rr.Body.String(), "Invalid rate.")
+		assert.Contains(t, rr.Body.String(), "Select at least one room.")
+		assert.Contains(t, rr.Body.String(), "Select at least one day of the week.")
+	})
+
+	// Test Error: internal server error on CreateRoomRatesTx
+	t.Run("Database Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/rates", strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("CreateRoomRatesTx", mock.Anything, args).
+			Return(nil, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/rates", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostAdminDeleteRoomRatesHandler(t *testing.T) {
+	ids := []int64{util.RandomID(), util.RandomID()}
+	values := url.Values{"rate_id": {fmt.Sprint(ids[0]), fmt.Sprint(ids[1])}}
+
+	// Test OK: the room rates selected are deleted
+	t.Run("OK", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/rates/delete", strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stub
+		ts.MockDBStore.On("DeleteRoomRates", mock.Anything, ids).
+			Return(nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, "2 room rates deleted.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/rates", rr.Header().Get("Location"))
+	})
+
+	// Test Warning: no room rates selected
+	t.Run("No Rates Selected", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/rates/delete", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get warning message from session and remove it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "No room rates selected.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/rates", rr.Header().Get("Location"))
+	})
+
+	// Test Error: invalid room rate id
+	t.Run("Invalid ID", func(t *testing.T) {
+		invalid := url.Values{"rate_id": {"abc"}}
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/rates/delete", strings.NewReader(invalid.Encode()))
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stub
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/rates", rr.Header().Get("Location"))
+	})
+
+	// Test Error: internal server error on DeleteRoomRates
+	t.Run("Database Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/rates/delete", strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("DeleteRoomRates", mock.Anything, ids).
+			Return(errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/rates", rr.Header().Get("Location"))
+	})
+}
+
+// buildAdminOwnerBlocksStubs builds the stubs rendering the owner blocks panel with blocks and rooms
+func buildAdminOwnerBlocksStubs(ts *TestServer, blocks []RoomRestriction, rooms []Room) {
+	arg := db.ListOwnerBlocksAndRoomsParams{Limit: LimitOwnerBlocksPerPage}
+	arg.Date.Scan(Today())
+
+	dbBlocks := make([]db.ListOwnerBlocksAndRoomsRow, len(blocks))
+	for i, block := range blocks {
+		block.Export(&dbBlocks[i].RoomRestriction)
+		block.Room.Export(&dbBlocks[i].Room)
+	}
+
+	dbRooms := make([]db.Room, len(rooms))
+	for i, room := range rooms {
+		room.Export(&dbRooms[i])
+	}
+
+	ts.MockDBStore.On("ListOwnerBlocksAndRooms", mock.Anything, arg).
+		Return(dbBlocks, nil).
+		Once()
+	ts.MockDBStore.On("ListRooms", mock.Anything, db.ListRoomsParams{Limit: LimitRoomsPerPage}).
+		Return(dbRooms, nil).
+		Once()
+}
+
+func TestServer_AdminOwnerBlocksHandler(t *testing.T) {
+	// Test OK: owner blocks and rooms are listed
+	t.Run("OK", func(t *testing.T) {
+		block := randomOwnerBlock()
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/blocks", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		buildAdminOwnerBlocksStubs(ts, []RoomRestriction{block}, []Room{block.Room})
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), block.Room.Name)
+		assert.Contains(t, rr.Body.String(), block.Reason)
+		assert.Contains(t, rr.Body.String(), fmt.Sprintf("/admin/blocks/%d", block.ID))
+		assert.Contains(t, rr.Body.String(), fmt.Sprintf(`name="room_id" value="%d"`, block.RoomID))
+	})
+
+	// Test Error: internal server error on ListOwnerBlocksAndRooms
+	t.Run("Database Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/blocks", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("ListOwnerBlocksAndRooms", mock.Anything, mock.Anything).
+			Return(nil, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/dashboard", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostAdminOwnerBlocksHandler(t *testing.T) {
+	// create random rooms and form values
+	rooms := randomRooms(2)
+	startDate := Today().AddDate(0, 1, 0)
+	endDate := startDate.AddDate(0, 0, 5)
+
+	values := url.Values{
+		"start_date": {startDate.Format(config.DateLayout)},
+		"end_date":   {endDate.Format(config.DateLayout)},
+		"reason":     {" Repairs "},
+		"room_id":    {fmt.Sprint(rooms[0].ID), fmt.Sprint(rooms[1].ID)},
+	}
+
+	// newArg returns the stub call arguments of the form values
+	newArg := func(override bool) db.CreateOwnerBlocksTxParams {
+		arg := db.CreateOwnerBlocksTxParams{
+			RoomIDs:  []int64{rooms[0].ID, rooms[1].ID},
+			Reason:   "Repairs",
+			Override: override,
+		}
+		arg.StartDate.Scan(startDate)
+		arg.EndDate.Scan(endDate)
+		return arg
+	}
+
+	// Test OK: an owner block is created for every room selected
+	t.Run("OK", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/blocks", strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("CreateOwnerBlocksTx", mock.Anything, newArg(false)).
+			Return(make([]db.RoomRestriction, len(rooms)), nil).
+			Once()
+		ts.BuildLogInfoStub(fmt.Sprintf("BLOCK 2 rooms blocked from %s to %s by user 1",
+			startDate.Format(config.DateLayout), endDate.Format(config.DateLayout)))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, "2 owner blocks created.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/blocks", rr.Header().Get("Location"))
+	})
+
+	// Test OK: the rooms are blocked over reservations when overridden
+	t.Run("OK Override", func(t *testing.T) {
+		overridden := url.Values{"override": {"true"}}
+		for k, v := range values {
+			overridden[k] = v
+		}
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/blocks", strings.NewReader(overridden.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("CreateOwnerBlocksTx", mock.Anything, newArg(true)).
+			Return(make([]db.RoomRestriction, len(rooms)), nil).
+			Once()
+		ts.BuildLogInfoStub(fmt.Sprintf("BLOCK 2 rooms blocked from %s to %s by user 1, overriding reservations",
+			startDate.Format(config.DateLayout), endDate.Format(config.DateLayout)))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/blocks", rr.Header().Get("Location"))
+	})
+
+	// Test Error: the rooms are reserved and the form is rendered again asking to override
+	t.Run("Conflict", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/blocks", strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("CreateOwnerBlocksTx", mock.Anything, newArg(false)).
+			Return(nil, db.ErrOwnerBlockConflict).
+			Once()
+		buildAdminOwnerBlocksStubs(ts, nil, rooms)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Select override to block them anyway.")
+		assert.Contains(t, rr.Body.String(), fmt.Sprintf(`name="room_id" value="%d" checked`, rooms[0].ID))
+	})
+
+	// Test Error: invalid form is rendered again with the errors
+	t.Run("Invalid Form", func(t *testing.T) {
+		invalid := url.Values{
+			"start_date": {startDate.Format(config.DateLayout)},
+			"end_date":   {startDate.Format(config.DateLayout)},
+		}
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/blocks", strings.NewReader(invalid.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		buildAdminOwnerBlocksStubs(ts, nil, rooms)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "The room must be blocked for at least one night.")
+		assert.Contains(t, rr.Body.String(), "Select at least one room.")
+	})
+
+	// Test Error: internal server error on CreateOwnerBlocksTx
+	t.Run("Database Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/blocks", strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("CreateOwnerBlocksTx", mock.Anything, newArg(false)).
+			Return(nil, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/blocks", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostAdminDeleteOwnerBlocksHandler(t *testing.T) {
+	blocks := []RoomRestriction{randomOwnerBlock(), randomOwnerBlock()}
+	ids := []int64{blocks[0].ID, blocks[1].ID}
+	values := url.Values{"block_id": {fmt.Sprint(ids[0]), fmt.Sprint(ids[1])}}
+
+	// Test OK: the owner blocks selected are deleted and the rooms offered to the waitlist
+	t.Run("OK", func(t *testing.T) {
+		dbBlocks := make([]db.RoomRestriction, len(blocks))
+		for i, block := range blocks {
+			block.Export(&dbBlocks[i])
+		}
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/blocks/delete", strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("DeleteOwnerBlocks", mock.Anything, ids).
+			Return(dbBlocks, nil).
+			Once()
+		ts.BuildLogInfoStub("BLOCK 2 owner blocks deleted by user 1")
+		for _, block := range blocks {
+			ts.MockDBStore.On("NotifyWaitlistTx", mock.Anything, mock.MatchedBy(func(arg db.NotifyWaitlistTxParams) bool {
+				return arg.RoomID == block.RoomID && arg.StartDate.Time.Equal(block.StartDate) && arg.EndDate.Time.Equal(block.EndDate)
+			})).
+				Return([]db.WaitlistEntry{}, nil).
+				Once()
+		}
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, "2 owner blocks deleted.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/blocks", rr.Header().Get("Location"))
+	})
+
+	// Test Warning: no owner blocks selected
+	t.Run("None Selected", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/blocks/delete", strings.NewReader(""))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get warning message from session and remove it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "No owner blocks selected.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/blocks", rr.Header().Get("Location"))
+	})
+
+	// Test Error: invalid owner block id
+	t.Run("Invalid Parameter", func(t *testing.T) {
+		invalid := url.Values{"block_id": {"abc"}}
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/blocks/delete", strings.NewReader(invalid.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/blocks", rr.Header().Get("Location"))
+	})
+
+	// Test Error: internal server error on DeleteOwnerBlocks
+	t.Run("Database Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/blocks/delete", strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("DeleteOwnerBlocks", mock.Anything, ids).
+			Return(nil, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/blocks", rr.Header().Get("Location"))
+	})
+}
+
+// adminOwnerBlockValues returns the edit form values of owner block b
+func adminOwnerBlockValues(b RoomRestriction) url.Values {
+	return url.Values{
+		"room_id":    {fmt.Sprint(b.RoomID)},
+		"start_date": {b.StartDate.Format(config.DateLayout)},
+		"end_date":   {b.EndDate.Format(config.DateLayout)},
+		"reason":     {b.Reason},
+	}
+}
+
+// updateOwnerBlockArg returns the stub call arguments updating owner block b
+func updateOwnerBlockArg(b RoomRestriction, override bool) db.UpdateOwnerBlockTxParams {
+	arg := db.UpdateOwnerBlockTxParams{
+		UpdateOwnerBlockParams: db.UpdateOwnerBlockParams{
+			ID:     b.ID,
+			RoomID: b.RoomID,
+			Reason: b.Reason,
+		},
+		Override: override,
+	}
+	arg.StartDate.Scan(b.StartDate)
+	arg.EndDate.Scan(b.EndDate)
+	return arg
+}
+
+func TestServer_AdminOwnerBlockHandler(t *testing.T) {
+	block := randomOwnerBlock()
+	dbBlock := db.RoomRestriction{}
+	block.Export(&dbBlock)
+	blockURL := fmt.Sprintf("/admin/blocks/%d", block.ID)
+
+	// Test OK: the owner block is shown in the edit form
+	t.Run("OK", func(t *testing.T) {
+		dbRooms := make([]db.Room, 1)
+		block.Room.Export(&dbRooms[0])
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, blockURL, nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("GetOwnerBlock", mock.Anything, block.ID).
+			Return(dbBlock, nil).
+			Once()
+		ts.MockDBStore.On("ListRooms", mock.Anything, db.ListRoomsParams{Limit: LimitRoomsPerPage}).
+			Return(dbRooms, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), fmt.Sprintf(`<option value="%d" selected>`, block.RoomID))
+		assert.Contains(t, rr.Body.String(), block.StartDate.Format(config.DateLayout))
+		assert.Contains(t, rr.Body.String(), block.Reason)
+	})
+
+	// Test Error: the owner block does not exist
+	t.Run("Not Found", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, blockURL, nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("GetOwnerBlock", mock.Anything, block.ID).
+			Return(db.RoomRestriction{}, pgx.ErrNoRows).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/blocks", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostAdminOwnerBlockHandler(t *testing.T) {
+	block := randomOwnerBlock()
+	dbBlock := db.RoomRestriction{}
+	block.Export(&dbBlock)
+	blockURL := fmt.Sprintf("/admin/blocks/%d", block.ID)
+
+	// Test OK: the reason is updated, keeping the nights blocked
+	t.Run("OK", func(t *testing.T) {
+		updated := block
+		updated.Reason = "Painting"
+
+		dbUpdated := db.RoomRestriction{}
+		updated.Export(&dbUpdated)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, blockURL, strings.NewReader(adminOwnerBlockValues(updated).Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("GetOwnerBlock", mock.Anything, block.ID).
+			Return(dbBlock, nil).
+			Once()
+		ts.MockDBStore.On("UpdateOwnerBlockTx", mock.Anything, updateOwnerBlockArg(updated, false)).
+			Return(dbUpdated, nil).
+			Once()
+		ts.BuildLogInfoStub(fmt.Sprintf("BLOCK owner block %d updated by user 1", block.ID))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, "Owner block updated.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/blocks", rr.Header().Get("Location"))
+	})
+
+	// Test OK: the block is shortened over reservations when overridden, and the nights released offered to the waitlist
+	t.Run("OK Override", func(t *testing.T) {
+		updated := block
+		updated.StartDate = block.StartDate.AddDate(0, 0, 1)
+
+		dbUpdated := db.RoomRestriction{}
+		updated.Export(&dbUpdated)
+
+		values := adminOwnerBlockValues(updated)
+		values.Set("override", "true")
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, blockURL, strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("GetOwnerBlock", mock.Anything, block.ID).
+			Return(dbBlock, nil).
+			Once()
+		ts.MockDBStore.On("UpdateOwnerBlockTx", mock.Anything, updateOwnerBlockArg(updated, true)).
+			Return(dbUpdated, nil).
+			Once()
+		ts.BuildLogInfoStub(fmt.Sprintf("BLOCK owner block %d updated by user 1, overriding reservations", block.ID))
+		ts.MockDBStore.On("NotifyWaitlistTx", mock.Anything, mock.MatchedBy(func(arg db.NotifyWaitlistTxParams) bool {
+			return arg.RoomID == block.RoomID && arg.StartDate.Time.Equal(block.StartDate) && arg.EndDate.Time.Equal(block.EndDate)
+		})).
+			Return([]db.WaitlistEntry{}, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/blocks", rr.Header().Get("Location"))
+	})
+
+	// Test Error: the room is reserved and the form is rendered again asking to override
+	t.Run("Conflict", func(t *testing.T) {
+		updated := block
+		updated.EndDate = block.EndDate.AddDate(0, 0, 2)
+
+		dbRooms := make([]db.Room, 1)
+		block.Room.Export(&dbRooms[0])
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, blockURL, strings.NewReader(adminOwnerBlockValues(updated).Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("GetOwnerBlock", mock.Anything, block.ID).
+			Return(dbBlock, nil).
+			Once()
+		ts.MockDBStore.On("UpdateOwnerBlockTx", mock.Anything, updateOwnerBlockArg(updated, false)).
+			Return(db.RoomRestriction{}, db.ErrOwnerBlockConflict).
+			Once()
+		ts.MockDBStore.On("ListRooms", mock.Anything, db.ListRoomsParams{Limit: LimitRoomsPerPage}).
+			Return(dbRooms, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Select override to block them anyway.")
+		assert.Contains(t, rr.Body.String(), updated.EndDate.Format(config.DateLayout))
+	})
+
+	// Test Error: invalid form is rendered again with the errors
+	t.Run("Invalid Form", func(t *testing.T) {
+		values := adminOwnerBlockValues(block)
+		values.Set("room_id", "abc")
+		values.Set("end_date", block.StartDate.Format(config.DateLayout))
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, blockURL, strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("GetOwnerBlock", mock.Anything, block.ID).
+			Return(dbBlock, nil).
+			Once()
+		ts.MockDBStore.On("ListRooms", mock.Anything, db.ListRoomsParams{Limit: LimitRoomsPerPage}).
+			Return([]db.Room{}, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Invalid room!")
+		assert.Contains(t, rr.Body.String(), "The room must be blocked for at least one night.")
+	})
+
+	// Test Error: internal server error on UpdateOwnerBlockTx
+	t.Run("Database Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, blockURL, strings.NewReader(adminOwnerBlockValues(block).Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("GetOwnerBlock", mock.Anything, block.ID).
+			Return(dbBlock, nil).
+			Once()
+		ts.MockDBStore.On("UpdateOwnerBlockTx", mock.Anything, updateOwnerBlockArg(block, false)).
+			Return(db.RoomRestriction{}, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/blocks", rr.Header().Get("Location"))
+	})
+}
+
+// testRoomImage is a PNG header, detected as a PNG image
+var testRoomImage = []byte("\x89PNG\r\n\x1a\n0000")
+
+// newAdminRoomRequest returns an authenticated multipart POST request to url with the room form values,
+// and with image uploaded in the image field if image is not nil
+func newAdminRoomRequest(t *testing.T, ts *TestServer, url string, values url.Values, image []byte) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for key, vs := range values {
+		for _, v := range vs {
+			require.NoError(t, writer.WriteField(key, v))
+		}
+	}
+
+	if image != nil {
+		part, err := writer.CreateFormFile("image", "room.png")
+		require.NoError(t, err)
+		_, err = part.Write(image)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	req := ts.NewRequestWithSession(t, http.MethodPost, url, body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	app.Session.Put(req.Context(), "user_id", int64(1))
+
+	return req
+}
+
+// adminRoomValues returns the room form values of room
+func adminRoomValues(room Room) url.Values {
+	return url.Values{
+		"name":          {room.Name},
+		"description":   {room.Description},
+		"max_adults":    {fmt.Sprint(room.MaxAdults)},
+		"max_children":  {fmt.Sprint(room.MaxChildren)},
+		"max_occupancy": {fmt.Sprint(room.MaxOccupancy)},
+		"nightly_rate":  {formatAmount("", room.NightlyRate)},
+	}
+}
+
+// useTempStaticPath sets the static directory to a temporary directory for the duration of the test
+func useTempStaticPath(t *testing.T) {
+	staticPath := app.StaticPath
+	app.StaticPath = t.TempDir()
+	t.Cleanup(func() { app.StaticPath = staticPath })
+}
+
+// roomImages returns the filenames of the room images saved in the static directory
+func roomImages(t *testing.T) []string {
+	entries, err := os.ReadDir(filepath.Join(app.StaticPath, RoomImagesDirectory))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	require.NoError(t, err)
+
+	filenames := make([]string, len(entries))
+	for i, entry := range entries {
+		filenames[i] = entry.Name()
+	}
+
+	return filenames
+}
+
+func TestServer_AdminRoomsHandler(t *testing.T) {
+	// Test OK: the rooms are listed
+	t.Run("OK", func(t *testing.T) {
+		rooms := randomRooms(2)
+		dbRooms := make([]db.Room, len(rooms))
+		for i, room := range rooms {
+			room.Export(&dbRooms[i])
+		}
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/rooms", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("ListRooms", mock.Anything, db.ListRoomsParams{Limit: LimitRoomsPerPage}).
+			Return(dbRooms, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		for _, room := range rooms {
+			assert.Contains(t, rr.Body.String(), fmt.Sprintf(`href="/admin/rooms/%d"`, room.ID))
+			assert.Contains(t, rr.Body.String(), fmt.Sprintf(`action="/admin/rooms/%d/delete"`, room.ID))
+			assert.Contains(t, rr.Body.String(), room.ImageFilename)
+		}
+	})
+
+	// Test Error: internal server error on ListRooms
+	t.Run("Database Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/rooms", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("ListRooms", mock.Anything, db.ListRoomsParams{Limit: LimitRoomsPerPage}).
+			Return(nil, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/dashboard", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_AdminNewRoomHandler(t *testing.T) {
+	// create a new test server, a mock database store and an authenticated request
+	ts := NewTestServer(t)
+	req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/rooms/new", nil)
+	app.Session.Put(req.Context(), "user_id", 1)
+
+	//  server the request
+	rr := ts.ServeRequest(req)
+
+	// testify
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `action='/admin/rooms'`)
+	assert.Contains(t, rr.Body.String(), `enctype="multipart/form-data"`)
+	assert.Contains(t, rr.Body.String(), `name="max_adults" value='2'`)
+}
+
+func TestServer_PostAdminRoomsHandler(t *testing.T) {
+	room := randomRoom()
+	values := adminRoomValues(room)
+	slug := util.Slugify(room.Name)
+
+	// matchRoom returns true if arg creates room with an image saved
+	matchRoom := mock.MatchedBy(func(arg db.CreateRoomParams) bool {
+		return arg.Name == room.Name && arg.Slug == slug && arg.Description == room.Description &&
+			arg.MaxAdults == int32(room.MaxAdults) && arg.MaxChildren == int32(room.MaxChildren) &&
+			arg.MaxOccupancy == int32(room.MaxOccupancy) && arg.NightlyRate == int64(room.NightlyRate) &&
+			strings.HasPrefix(arg.ImageFilename, slug+"-") && strings.HasSuffix(arg.ImageFilename, ".png")
+	})
+
+	// Test OK: the room is created with the image saved to the static directory
+	t.Run("OK", func(t *testing.T) {
+		useTempStaticPath(t)
+		dbRoom := db.Room{}
+		room.Export(&dbRoom)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := newAdminRoomRequest(t, ts, "/admin/rooms", values, testRoomImage)
+
+		// build stubs
+		ts.MockDBStore.On("GetRoomBySlug", mock.Anything, slug).
+			Return(db.Room{}, pgx.ErrNoRows).
+			Once()
+		ts.MockDBStore.On("CreateRoom", mock.Anything, matchRoom).
+			Return(dbRoom, nil).
+			Once()
+		ts.BuildLogInfoStub(fmt.Sprintf("ROOM %s created by user 1", room.Name))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, fmt.Sprintf("Room %s created.", room.Name), msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/rooms", rr.Header().Get("Location"))
+		assert.Len(t, roomImages(t), 1)
+	})
+
+	// Test Invalid Form: a new room requires an image, and invalid values are reported
+	t.Run("Invalid Form", func(t *testing.T) {
+		useTempStaticPath(t)
+		invalid := adminRoomValues(room)
+		invalid.Set("max_occupancy", fmt.Sprint(room.MaxAdults+room.MaxChildren+1))
+		invalid.Set("nightly_rate", "abc")
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := newAdminRoomRequest(t, ts, "/admin/rooms", invalid, nil)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Please upload an image of the room.")
+		assert.Contains(t, rr.Body.String(), "Maximum occupancy cannot exceed the maximum adults and children together.")
+		assert.Contains(t, rr.Body.String(), "Invalid rate.")
+		assert.Empty(t, roomImages(t))
+	})
+
+	// Test Invalid Form: the image uploaded is not a JPEG, PNG or WebP image
+	t.Run("Invalid Image Type", func(t *testing.T) {
+		useTempStaticPath(t)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := newAdminRoomRequest(t, ts, "/admin/rooms", values, []byte("GIF89a000000"))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Invalid image type. Please upload a JPEG, PNG or WebP image.")
+		assert.Empty(t, roomImages(t))
+	})
+
+	// Test Invalid Form: the image uploaded is larger than MaxRoomImageSize
+	t.Run("Image Too Large", func(t *testing.T) {
+		useTempStaticPath(t)
+		image := append(testRoomImage, make([]byte, MaxRoomImageSize)...)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := newAdminRoomRequest(t, ts, "/admin/rooms", values, image)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "The image is too large. Please upload an image of up to 5 MB.")
+		assert.Empty(t, roomImages(t))
+	})
+
+	// Test Warning: the request is larger than the request size limit
+	t.Run("Request Too Large", func(t *testing.T) {
+		useTempStaticPath(t)
+		image := append(testRoomImage, make([]byte, 2*MaxRoomImageSize)...)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := newAdminRoomRequest(t, ts, "/admin/rooms", values, image)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get warning message from session and remove it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "The image is too large. Please upload an image of up to 5 MB.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/rooms/new", rr.Header().Get("Location"))
+		assert.Empty(t, roomImages(t))
+	})
+
+	// Test Invalid Form: another room has the same name
+	t.Run("Duplicate Name", func(t *testing.T) {
+		useTempStaticPath(t)
+		other := db.Room{ID: util.RandomID(), Slug: slug}
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := newAdminRoomRequest(t, ts, "/admin/rooms", values, testRoomImage)
+
+		// build stubs
+		ts.MockDBStore.On("GetRoomBySlug", mock.Anything, slug).
+			Return(other, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Another room has the same name. Please choose another name.")
+		assert.Empty(t, roomImages(t))
+	})
+
+	// Test Error: internal server error on CreateRoom removes the image saved
+	t.Run("Database Error", func(t *testing.T) {
+		useTempStaticPath(t)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := newAdminRoomRequest(t, ts, "/admin/rooms", values, testRoomImage)
+
+		// build stubs
+		ts.MockDBStore.On("GetRoomBySlug", mock.Anything, slug).
+			Return(db.Room{}, pgx.ErrNoRows).
+			Once()
+		ts.MockDBStore.On("CreateRoom", mock.Anything, matchRoom).
+			Return(db.Room{}, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/rooms", rr.Header().Get("Location"))
+		assert.Empty(t, roomImages(t))
+	})
+}
+
+func TestServer_AdminRoomHandler(t *testing.T) {
+	room := randomRoom()
+	dbRoom := db.Room{}
+	room.Export(&dbRoom)
+	roomURL := fmt.Sprintf("/admin/rooms/%d", room.ID)
+
+	// Test OK: the room is shown in the edit form
+	t.Run("OK", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, roomURL, nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("GetRoom", mock.Anything, room.ID).
+			Return(dbRoom, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), fmt.Sprintf(`action='%s'`, roomURL))
+		assert.Contains(t, rr.Body.String(), formatAmount("", room.NightlyRate))
+		assert.Contains(t, rr.Body.String(), room.ImageFilename)
+	})
+
+	// Test Error: the room does not exist
+	t.Run("Not Found", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, roomURL, nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("GetRoom", mock.Anything, room.ID).
+			Return(db.Room{}, pgx.ErrNoRows).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/rooms", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostAdminRoomHandler(t *testing.T) {
+	room := randomRoom()
+	room.Slug = util.Slugify(room.Name)
+	dbRoom := db.Room{}
+	room.Export(&dbRoom)
+	roomURL := fmt.Sprintf("/admin/rooms/%d", room.ID)
+
+	// the room is renamed with a higher rate
+	updated := room
+	updated.Name = fmt.Sprint(room.Name, " Deluxe")
+	updated.Slug = util.Slugify(updated.Name)
+	updated.NightlyRate += 1000
+	values := adminRoomValues(updated)
+
+	// matchRoom returns true if arg updates the room, with a new image if newImage is true
+	matchRoom := func(newImage bool) any {
+		return mock.MatchedBy(func(arg db.UpdateRoomParams) bool {
+			image := arg.ImageFilename == room.ImageFilename
+			if newImage {
+				image = strings.HasPrefix(arg.ImageFilename, updated.Slug+"-")
+			}
+
+			return arg.ID == room.ID && arg.Name == updated.Name && arg.Slug == updated.Slug &&
+				arg.NightlyRate == int64(updated.NightlyRate) && image
+		})
+	}
+
+	// Test OK: the room is updated and keeps its image
+	t.Run("OK", func(t *testing.T) {
+		useTempStaticPath(t)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := newAdminRoomRequest(t, ts, roomURL, values, nil)
+
+		// build stubs
+		ts.MockDBStore.On("GetRoom", mock.Anything, room.ID).
+			Return(dbRoom, nil).
+			Once()
+		ts.MockDBStore.On("GetRoomBySlug", mock.Anything, updated.Slug).
+			Return(db.Room{}, pgx.ErrNoRows).
+			Once()
+		ts.MockDBStore.On("UpdateRoom", mock.Anything, matchRoom(false)).
+			Return(nil).
+			Once()
+		ts.BuildLogInfoStub(fmt.Sprintf("ROOM %s updated by user 1", updated.Name))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, fmt.Sprintf("Room %s updated.", updated.Name), msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/rooms", rr.Header().Get("Location"))
+		assert.Empty(t, roomImages(t))
+	})
+
+	// Test OK: the room is updated with a new image, keeping its own slug
+	t.Run("New Image", func(t *testing.T) {
+		useTempStaticPath(t)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := newAdminRoomRequest(t, ts, roomURL, values, testRoomImage)
+
+		// build stubs
+		ts.MockDBStore.On("GetRoom", mock.Anything, room.ID).
+			Return(dbRoom, nil).
+			Once()
+		ts.MockDBStore.On("GetRoomBySlug", mock.Anything, updated.Slug).
+			Return(db.Room{ID: room.ID, Slug: updated.Slug}, nil).
+			Once()
+		ts.MockDBStore.On("UpdateRoom", mock.Anything, matchRoom(true)).
+			Return(nil).
+			Once()
+		ts.BuildLogInfoStub(fmt.Sprintf("ROOM %s updated by user 1", updated.Name))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/rooms", rr.Header().Get("Location"))
+		assert.Len(t, roomImages(t), 1)
+	})
+
+	// Test Invalid Form: missing name
+	t.Run("Invalid Form", func(t *testing.T) {
+		invalid := adminRoomValues(updated)
+		invalid.Del("name")
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := newAdminRoomRequest(t, ts, roomURL, invalid, nil)
+
+		// build stubs
+		ts.MockDBStore.On("GetRoom", mock.Anything, room.ID).
+			Return(dbRoom, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), fmt.Sprintf(`action='%s'`, roomURL))
+		assert.Contains(t, rr.Body.String(), "is-invalid")
+	})
+
+	// Test Error: internal server error on UpdateRoom removes the new image saved
+	t.Run("Database Error", func(t *testing.T) {
+		useTempStaticPath(t)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := newAdminRoomRequest(t, ts, roomURL, values, testRoomImage)
+
+		// build stubs
+		ts.MockDBStore.On("GetRoom", mock.Anything, room.ID).
+			Return(dbRoom, nil).
+			Once()
+		ts.MockDBStore.On("GetRoomBySlug", mock.Anything, updated.Slug).
+			Return(db.Room{}, pgx.ErrNoRows).
+			Once()
+		ts.MockDBStore.On("UpdateRoom", mock.Anything, matchRoom(true)).
+			Return(errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/rooms", rr.Header().Get("Location"))
+		assert.Empty(t, roomImages(t))
+	})
+}
+
+func TestServer_PostAdminDeleteRoomHandler(t *testing.T) {
+	room := randomRoom()
+	dbRoom := db.Room{}
+	room.Export(&dbRoom)
+	deleteURL := fmt.Sprintf("/admin/rooms/%d/delete", room.ID)
+
+	// Test OK: the room is deleted
+	t.Run("OK", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, deleteURL, nil)
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("DeleteRoomTx", mock.Anything, room.ID).
+			Return(dbRoom, nil).
+			Once()
+		ts.BuildLogInfoStub(fmt.Sprintf("ROOM %s deleted by user 1", room.Name))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, fmt.Sprintf("Room %s deleted.", room.Name), msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/rooms", rr.Header().Get("Location"))
+	})
+
+	// Test Warning: the room has reservations and is not deleted
+	t.Run("Reservations", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, deleteURL, nil)
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("DeleteRoomTx", mock.Anything, room.ID).
+			Return(db.Room{}, db.ErrRoomHasReservations).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get warning message from session and remove it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "The room has reservations and cannot be deleted.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/rooms", rr.Header().Get("Location"))
+	})
+
+	// Test Error: internal server error on DeleteRoomTx
+	t.Run("Database Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, deleteURL, nil)
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("DeleteRoomTx", mock.Anything, room.ID).
+			Return(db.Room{}, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/rooms", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PasswordHandler(t *testing.T) {
+	user := randomUser()
+	user.PasswordToken = util.RandomString(PasswordTokenLength)
+	user.PasswordTokenExpiresAt = time.Now().Add(PasswordTokenTTL)
+	passwordURL := fmt.Sprintf("/user/password/%s", user.PasswordToken)
+
+	// Test OK: the password form is shown
+	t.Run("OK", func(t *testing.T) {
+		dbUser := db.User{}
+		user.Export(&dbUser)
+
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, passwordURL, nil)
+
+		// build stub
+		ts.MockDBStore.On("GetUserByPasswordToken", mock.Anything, dbUser.PasswordToken).
+			Return(dbUser, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `name="confirm_password"`)
+	})
+
+	// Test Error: the link cannot be used
+	tests := []struct {
+		name string
+		user User
+		err  error
+	}{
+		{"Unknown Token", User{}, pgx.ErrNoRows},
+		{"Expired Token", User{PasswordToken: user.PasswordToken, PasswordTokenExpiresAt: time.Now().Add(-time.Hour)}, nil},
+		{"Deactivated User", User{PasswordToken: user.PasswordToken, PasswordTokenExpiresAt: user.PasswordTokenExpiresAt, DeactivatedAt: time.Now()}, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dbUser := db.User{}
+			test.user.Export(&dbUser)
+
+			// create a new test server, a mock database store and a request
+			ts := NewTestServer(t)
+			req := ts.NewRequestWithSession(t, http.MethodGet, passwordURL, nil)
+
+			// build stub
+			ts.MockDBStore.On("GetUserByPasswordToken", mock.Anything, pgtype.Text{String: user.PasswordToken, Valid: true}).
+				Return(dbUser, test.err).
+				Once()
+
+			//  server the request
+			rr := ts.ServeRequest(req)
+
+			// get error message from session and remove it
+			msg := app.Session.PopString(req.Context(), "error")
+			assert.Equal(t, "The link is invalid or has expired. Please ask the owner for a new link.", msg)
+
+			// testify
+			assert.Equal(t, http.StatusSeeOther, rr.Code)
+			assert.Equal(t, "/user/login", rr.Header().Get("Location"))
+		})
+	}
+
+	// Test Error: internal server error on GetUserByPasswordToken
+	t.Run("Database Error", func(t *testing.T) {
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, passwordURL, nil)
+
+		// build stubs
+		ts.MockDBStore.On("GetUserByPasswordToken", mock.Anything, mock.Anything).
+			Return(db.User{}, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/user/login", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostPasswordHandler(t *testing.T) {
+	user := randomUser()
+	user.PasswordToken = util.RandomString(PasswordTokenLength)
+	user.PasswordTokenExpiresAt = time.Now().Add(PasswordTokenTTL)
+	passwordURL := fmt.Sprintf("/user/password/%s", user.PasswordToken)
+
+	dbUser := db.User{}
+	user.Export(&dbUser)
+
+	password := "AAbbc12345"
+	values := url.Values{
+		"password":         {password},
+		"confirm_password": {password},
+	}
+
+	// Test OK: the password is set
+	t.Run("OK", func(t *testing.T) {
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, passwordURL, strings.NewReader(values.Encode()))
+
+		// build stubs
+		ts.MockDBStore.On("GetUserByPasswordToken", mock.Anything, dbUser.PasswordToken).
+			Return(dbUser, nil).
+			Once()
+		ts.MockDBStore.On("ChangeUserPassword", mock.Anything, mock.MatchedBy(func(arg db.UpdateUserPasswordParams) bool {
+			return arg.ID == user.ID && arg.Password == password
+		})).
+			Return(nil).
+			Once()
+		ts.BuildLogInfoStub(fmt.Sprintf("USER %s set a new password", user.Email))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, "Your password was set. Please log in.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/user/login", rr.Header().Get("Location"))
+	})
+
+	// Test Invalid Form: the password form is shown again
+	tests := []struct {
+		name   string
+		values url.Values
+		msg    string
+	}{
+		{"Weak Password", url.Values{"password": {"password"}, "confirm_password": {"password"}}, "Password requires at least 2 digits (0-9)."},
+		{"Passwords Mismatch", url.Values{"password": {password}, "confirm_password": {password + "x"}}, "Passwords do not match!"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// create a new test server, a mock database store and a request
+			ts := NewTestServer(t)
+			req := ts.NewRequestWithSession(t, http.MethodPost, passwordURL, strings.NewReader(test.values.Encode()))
+
+			// build stub
+			ts.MockDBStore.On("GetUserByPasswordToken", mock.Anything, dbUser.PasswordToken).
+				Return(dbUser, nil).
+				Once()
+
+			//  server the request
+			rr := ts.ServeRequest(req)
+
+			// testify
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Contains(t, rr.Body.String(), test.msg)
+			assert.NotContains(t, rr.Body.String(), password)
+		})
+	}
+
+	// Test Error: internal server error on ChangeUserPassword
+	t.Run("Database Error", func(t *testing.T) {
+		// create a new test server, a mock database store and a request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, passwordURL, strings.NewReader(values.Encode()))
+
+		// build stubs
+		ts.MockDBStore.On("GetUserByPasswordToken", mock.Anything, dbUser.PasswordToken).
+			Return(dbUser, nil).
+			Once()
+		ts.MockDBStore.On("ChangeUserPassword", mock.Anything, mock.Anything).
+			Return(errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/user/login", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_AdminUsersHandler(t *testing.T) {
+	// Test OK: the users are listed
+	t.Run("OK", func(t *testing.T) {
+		users := []User{randomUser(), randomUser()}
+		dbUsers := make([]db.User, len(users))
+		for i, user := range users {
+			user.Export(&dbUsers[i])
+		}
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/users", nil)
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stub
+		ts.MockDBStore.On("ListUsers", mock.Anything, db.ListUsersParams{Limit: LimitUsersPerPage}).
+			Return(dbUsers, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `action="/admin/users"`)
+		for _, user := range users {
+			assert.Contains(t, rr.Body.String(), fmt.Sprintf(`href="/admin/users/%d"`, user.ID))
+		}
+	})
+
+	// Test Error: the users panel is only for the owner
+	t.Run("Manager Role", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/users", nil)
+		app.Session.Put(req.Context(), "user_id", int64(1))
+		app.Session.Put(req.Context(), "access_level", int64(RoleManager))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get error message from session and remove it
+		msg := app.Session.PopString(req.Context(), "error")
+		assert.Equal(t, "Access denied. The Manager role cannot access this page.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/dashboard", rr.Header().Get("Location"))
+	})
+
+	// Test Error: internal server error on ListUsers
+	t.Run("Database Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/users", nil)
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("ListUsers", mock.Anything, db.ListUsersParams{Limit: LimitUsersPerPage}).
+			Return(nil, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/dashboard", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostAdminUsersHandler(t *testing.T) {
+	user := randomUser()
+	values := adminUserValues(user)
+
+	// matchArg returns true if arg creates the user
+	matchArg := mock.MatchedBy(func(arg db.CreateUserParams) bool {
+		return arg.FirstName == user.FirstName && arg.LastName == user.LastName && arg.Email == user.Email &&
+			arg.AccessLevel == int64(user.AccessLevel) && arg.Password != ""
+	})
+
+	// Test OK: the user is invited
+	t.Run("OK", func(t *testing.T) {
+		dbUser := db.User{}
+		user.Export(&dbUser)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/users", strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("GetUserByEmail", mock.Anything, user.Email).
+			Return(db.User{}, pgx.ErrNoRows).
+			Once()
+		ts.MockDBStore.On("CreateNewUser", mock.Anything, matchArg).
+			Return(dbUser, nil).
+			Once()
+		ts.MockDBStore.On("UpdateUserPasswordToken", mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		ts.BuildSendAnyMailStub()
+		ts.BuildLogInfoStub(fmt.Sprintf("USER %s invited as %s by user 1", user.Email, user.AccessLevel.Label()))
+		ts.BuildLogInfoStub(fmt.Sprintf("MAIL password link sent to %s", user.Email))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, fmt.Sprintf("Invitation sent to %s.", user.Email), msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/users", rr.Header().Get("Location"))
+	})
+
+	// Test Invalid Form: the users panel is shown again
+	tests := []struct {
+		name   string
+		values url.Values
+		stub   bool
+		msg    string
+	}{
+		{"Missing Name", url.Values{"last_name": {user.LastName}, "email": {user.Email}, "access_level": {"3"}}, false, "Required field!"},
+		{"Invalid Role", url.Values{"first_name": {user.FirstName}, "last_name": {user.LastName}, "email": {user.Email}, "access_level": {"5"}}, false, "Field requires a number between 1 and 4!"},
+		{"Existing Email", values, true, "Another user has this email."},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// create a new test server, a mock database store and an authenticated request
+			ts := NewTestServer(t)
+			req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/users", strings.NewReader(test.values.Encode()))
+			app.Session.Put(req.Context(), "user_id", int64(1))
+
+			// build stubs
+			if test.stub {
+				ts.MockDBStore.On("GetUserByEmail", mock.Anything, user.Email).
+					Return(db.User{ID: user.ID + 1}, nil).
+					Once()
+			}
+			ts.MockDBStore.On("ListUsers", mock.Anything, db.ListUsersParams{Limit: LimitUsersPerPage}).
+				Return([]db.User{}, nil).
+				Once()
+
+			//  server the request
+			rr := ts.ServeRequest(req)
+
+			// testify
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Contains(t, rr.Body.String(), test.msg)
+		})
+	}
+
+	// Test Error: internal server error on CreateNewUser
+	t.Run("Database Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/users", strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("GetUserByEmail", mock.Anything, user.Email).
+			Return(db.User{}, pgx.ErrNoRows).
+			Once()
+		ts.MockDBStore.On("CreateNewUser", mock.Anything, matchArg).
+			Return(db.User{}, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/users", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_AdminUserHandler(t *testing.T) {
+	user := randomUser()
+	dbUser := db.User{}
+	user.Export(&dbUser)
+	userURL := fmt.Sprintf("/admin/users/%d", user.ID)
+
+	// Test OK: the user form is shown
+	t.Run("OK", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, userURL, nil)
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stub
+		ts.MockDBStore.On("GetUser", mock.Anything, user.ID).
+			Return(dbUser, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), fmt.Sprintf(`action="/admin/users/%d"`, user.ID))
+		assert.Contains(t, rr.Body.String(), fmt.Sprintf(`action="/admin/users/%d/deactivate"`, user.ID))
+		assert.Contains(t, rr.Body.String(), user.Email)
+	})
+
+	// Test Error: internal server error on GetUser
+	t.Run("Database Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, userURL, nil)
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("GetUser", mock.Anything, user.ID).
+			Return(db.User{}, pgx.ErrNoRows).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/users", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostAdminUserHandler(t *testing.T) {
+	user := randomUser()
+	user.AccessLevel = RoleFrontDesk
+	dbUser := db.User{}
+	user.Export(&dbUser)
+	userURL := fmt.Sprintf("/admin/users/%d", user.ID)
+
+	// the user is promoted to manager
+	updated := user
+	updated.FirstName = util.RandomName()
+	updated.AccessLevel = RoleManager
+	values := adminUserValues(updated)
+
+	// matchArg returns true if arg updates the user
+	matchArg := mock.MatchedBy(func(arg db.UpdateUserParams) bool {
+		return arg.ID == user.ID && arg.FirstName == updated.FirstName && arg.AccessLevel == int64(RoleManager)
+	})
+
+	// Test OK: the user is updated
+	t.Run("OK", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, userURL, strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("GetUser", mock.Anything, user.ID).
+			Return(dbUser, nil).
+			Once()
+		ts.MockDBStore.On("GetUserByEmail", mock.Anything, user.Email).
+			Return(dbUser, nil).
+			Once()
+		ts.MockDBStore.On("UpdateUser", mock.Anything, matchArg).
+			Return(nil).
+			Once()
+		ts.BuildLogInfoStub(fmt.Sprintf("USER %s updated as Manager by user 1", user.Email))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, fmt.Sprintf("User %s updated.", user.Email), msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/users", rr.Header().Get("Location"))
+	})
+
+	// Test Invalid Form: the owner cannot change their own role
+	t.Run("Own Role", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, userURL, strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", user.ID)
+
+		// build stubs
+		ts.MockDBStore.On("GetUser", mock.Anything, user.ID).
+			Return(dbUser, nil).
+			Once()
+		ts.MockDBStore.On("GetUserByEmail", mock.Anything, user.Email).
+			Return(dbUser, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "You cannot change your own role.")
+	})
+
+	// Test Error: internal server error on UpdateUser
+	t.Run("Database Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, userURL, strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("GetUser", mock.Anything, user.ID).
+			Return(dbUser, nil).
+			Once()
+		ts.MockDBStore.On("GetUserByEmail", mock.Anything, user.Email).
+			Return(dbUser, nil).
+			Once()
+		ts.MockDBStore.On("UpdateUser", mock.Anything, matchArg).
+			Return(errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/users", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostAdminDeactivateUserHandler(t *testing.T) {
+	user := randomUser()
+	dbUser := db.User{}
+	user.Export(&dbUser)
+	deactivateURL := fmt.Sprintf("/admin/users/%d/deactivate", user.ID)
+
+	// matchArg returns true if arg deactivates the user
+	matchArg := mock.MatchedBy(func(arg db.UpdateUserDeactivatedAtParams) bool {
+		return arg.ID == user.ID && arg.DeactivatedAt.Valid
+	})
+
+	// Test OK: the user is deactivated
+	t.Run("OK", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, deactivateURL, nil)
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("GetUser", mock.Anything, user.ID).
+			Return(dbUser, nil).
+			Once()
+		ts.MockDBStore.On("UpdateUserDeactivatedAt", mock.Anything, matchArg).
+			Return(nil).
+			Once()
+		ts.BuildLogInfoStub(fmt.Sprintf("USER %s deactivated by user 1", user.Email))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, fmt.Sprintf("User %s deactivated.", user.Email), msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/users", rr.Header().Get("Location"))
+	})
+
+	// Test Warning: the owner cannot deactivate their own user
+	t.Run("Own User", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, deactivateURL, nil)
+		app.Session.Put(req.Context(), "user_id", user.ID)
+
+		// build stub
+		ts.MockDBStore.On("GetUser", mock.Anything, user.ID).
+			Return(dbUser, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get warning message from session and remove it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "You cannot deactivate your own user.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, fmt.Sprintf("/admin/users/%d", user.ID), rr.Header().Get("Location"))
+	})
+
+	// Test Error: internal server error on UpdateUserDeactivatedAt
+	t.Run("Database Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, deactivateURL, nil)
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("GetUser", mock.Anything, user.ID).
+			Return(dbUser, nil).
+			Once()
+		ts.MockDBStore.On("UpdateUserDeactivatedAt", mock.Anything, matchArg).
+			Return(errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/users", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostAdminReactivateUserHandler(t *testing.T) {
+	user := randomUser()
+	user.DeactivatedAt = util.RandomDatetime()
+	dbUser := db.User{}
+	user.Export(&dbUser)
+
+	// create a new test server, a mock database store and an authenticated request
+	ts := NewTestServer(t)
+	req := ts.NewRequestWithSession(t, http.MethodPost, fmt.Sprintf("/admin/users/%d/reactivate", user.ID), nil)
+	app.Session.Put(req.Context(), "user_id", int64(1))
+
+	// build stubs
+	ts.MockDBStore.On("GetUser", mock.Anything, user.ID).
+		Return(dbUser, nil).
+		Once()
+	ts.MockDBStore.On("UpdateUserDeactivatedAt", mock.Anything, mock.MatchedBy(func(arg db.UpdateUserDeactivatedAtParams) bool {
+		return arg.ID == user.ID && !arg.DeactivatedAt.Valid
+	})).
+		Return(nil).
+		Once()
+	ts.BuildLogInfoStub(fmt.Sprintf("USER %s reactivated by user 1", user.Email))
+
+	//  server the request
+	rr := ts.ServeRequest(req)
+
+	// get flash message from session and remove it
+	msg := app.Session.PopString(req.Context(), "flash")
+	assert.Equal(t, fmt.Sprintf("User %s reactivated.", user.Email), msg)
+
+	// testify
+	assert.Equal(t, http.StatusSeeOther, rr.Code)
+	assert.Equal(t, "/admin/users", rr.Header().Get("Location"))
+}
+
+func TestServer_PostAdminResetUserHandler(t *testing.T) {
+	user := randomUser()
+	dbUser := db.User{}
+	user.Export(&dbUser)
+	resetURL := fmt.Sprintf("/admin/users/%d/reset", user.ID)
+
+	// Test OK: the password is reset and a link is sent
+	t.Run("OK", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, resetURL, nil)
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stubs
+		ts.MockDBStore.On("GetUser", mock.Anything, user.ID).
+			Return(dbUser, nil).
+			Once()
+		ts.MockDBStore.On("ChangeUserPassword", mock.Anything, mock.MatchedBy(func(arg db.UpdateUserPasswordParams) bool {
+			return arg.ID == user.ID && arg.Password != user.Password
+		})).
+			Return(nil).
+			Once()
+		ts.MockDBStore.On("UpdateUserPasswordToken", mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		ts.BuildSendAnyMailStub()
+		ts.BuildLogInfoStub(fmt.Sprintf("USER %s password reset by user 1", user.Email))
+		ts.BuildLogInfoStub(fmt.Sprintf("MAIL password link sent to %s", user.Email))
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, fmt.Sprintf("Password link sent to %s.", user.Email), msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/users", rr.Header().Get("Location"))
+	})
+
+	// Test Warning: a deactivated user cannot set a password
+	t.Run("Deactivated User", func(t *testing.T) {
+		deactivated := user
+		deactivated.DeactivatedAt = util.RandomDatetime()
+		dbDeactivated := db.User{}
+		deactivated.Export(&dbDeactivated)
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, resetURL, nil)
+		app.Session.Put(req.Context(), "user_id", int64(1))
+
+		// build stub
+		ts.MockDBStore.On("GetUser", mock.Anything, user.ID).
+			Return(dbDeactivated, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
 
-		// create a new test server, and a new request
+		// get warning message from session and remove it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "Deactivated users cannot set a password. Please reactivate the user first.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, fmt.Sprintf("/admin/users/%d", user.ID), rr.Header().Get("Location"))
+	})
+
+	// Test Error: internal server error on ChangeUserPassword
+	t.Run("Database Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
 		ts := NewTestServer(t)
-		req := ts.NewRequestWithSession(t, http.MethodGet, "/reservation-summary", nil)
+		req := ts.NewRequestWithSession(t, http.MethodPost, resetURL, nil)
+		app.Session.Put(req.Context(), "user_id", int64(1))
 
-		// put rooms and reservation in session
-		app.Session.Put(req.Context(), "rooms", rooms)
-		app.Session.Put(req.Context(), "reservation", rsv)
+		// build stubs
+		ts.MockDBStore.On("GetUser", mock.Anything, user.ID).
+			Return(dbUser, nil).
+			Once()
+		ts.MockDBStore.On("ChangeUserPassword", mock.Anything, mock.Anything).
+			Return(errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
 
 		//  server the request
 		rr := ts.ServeRequest(req)
 
-		// checks that rooms and reservation are not in session
-		ok := app.Session.Exists(req.Context(), "rooms")
-		require.False(t, ok)
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/users", rr.Header().Get("Location"))
+	})
+}
 
-		ok = app.Session.Exists(req.Context(), "reservation")
-		require.False(t, ok)
+// adminUserValues returns the staff user form values of user
+func adminUserValues(user User) url.Values {
+	return url.Values{
+		"first_name":   {user.FirstName},
+		"last_name":    {user.LastName},
+		"email":        {user.Email},
+		"access_level": {fmt.Sprint(int64(user.AccessLevel))},
+	}
+}
+
+func TestServer_AdminChargesHandler(t *testing.T) {
+	// Test OK: taxes and fees are listed
+	t.Run("OK", func(t *testing.T) {
+		// create stub return arguments
+		c := randomCharge()
+		dbCharges := make([]db.Charge, 1)
+		c.Export(&dbCharges[0])
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/charges", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stub
+		ts.MockDBStore.On("ListCharges", mock.Anything).
+			Return(dbCharges, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), c.Name)
+		assert.Contains(t, rr.Body.String(), c.AmountLabel())
+		assert.Contains(t, rr.Body.String(), fmt.Sprintf(`name="charge_id" value="%d"`, c.ID))
+		assert.Contains(t, rr.Body.String(), `<option value="percent" selected>`)
+	})
+
+	// Test Error: internal server error on ListCharges
+	t.Run("Database Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/charges", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("ListCharges", mock.Anything).
+			Return(nil, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/dashboard", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostAdminChargesHandler(t *testing.T) {
+	values := url.Values{
+		"name":   {"VAT"},
+		"kind":   {"percent"},
+		"amount": {"17.5%"},
+	}
+
+	// create stub call arguments
+	arg := db.CreateChargeParams{
+		Name:   "VAT",
+		Kind:   db.ChargeKindPercent,
+		Amount: 1750,
+	}
+
+	// Test OK: the charge is created
+	t.Run("OK", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/charges", strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stub
+		ts.MockDBStore.On("CreateCharge", mock.Anything, arg).
+			Return(db.Charge{}, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, "VAT created.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/charges", rr.Header().Get("Location"))
+	})
+
+	// Test Error: invalid form is rendered again with the errors
+	t.Run("Invalid Form", func(t *testing.T) {
+		invalid := url.Values{
+			"kind":   {"per_week"},
+			"amount": {"0"},
+		}
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/charges", strings.NewReader(invalid.Encode()))
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stub
+		ts.MockDBStore.On("ListCharges", mock.Anything).
+			Return([]db.Charge{}, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Required field!")
+		assert.Contains(t, rr.Body.String(), "Invalid charge type!")
+		assert.Contains(t, rr.Body.String(), "Invalid amount.")
+	})
+
+	// Test Error: internal server error on CreateCharge
+	t.Run("Database Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/charges", strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("CreateCharge", mock.Anything, arg).
+			Return(db.Charge{}, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/charges", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostAdminDeleteChargesHandler(t *testing.T) {
+	ids := []int64{util.RandomID(), util.RandomID()}
+	values := url.Values{"charge_id": {fmt.Sprint(ids[0]), fmt.Sprint(ids[1])}}
+
+	// Test OK: the charges selected are deleted
+	t.Run("OK", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/charges/delete", strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stub
+		ts.MockDBStore.On("DeleteCharges", mock.Anything, ids).
+			Return(nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, "2 charges deleted.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/charges", rr.Header().Get("Location"))
+	})
+
+	// Test Warning: no charges selected
+	t.Run("No Charges Selected", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/charges/delete", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get warning message from session and remove it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "No charges selected.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/charges", rr.Header().Get("Location"))
+	})
+
+	// Test Error: internal server error on DeleteCharges
+	t.Run("Database Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/charges/delete", strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("DeleteCharges", mock.Anything, ids).
+			Return(errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/charges", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_AdminExchangeRatesHandler(t *testing.T) {
+	// Test OK: exchange rates are listed
+	t.Run("OK", func(t *testing.T) {
+		// create stub return arguments
+		e := ExchangeRate{Currency: "EUR", Symbol: "€", Rate: 921500, UpdatedAt: time.Now()}
+		dbRates := make([]db.ExchangeRate, 1)
+		e.Export(&dbRates[0])
+
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/currencies", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stub
+		ts.MockDBStore.On("ListExchangeRates", mock.Anything).
+			Return(dbRates, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
 
 		// testify
 		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `name="currency" value="EUR"`)
+		assert.Contains(t, rr.Body.String(), "1 USD = 0.921500 EUR")
+	})
+
+	// Test Error: internal server error on ListExchangeRates
+	t.Run("Database Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/admin/currencies", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("ListExchangeRates", mock.Anything).
+			Return(nil, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/dashboard", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostAdminExchangeRatesHandler(t *testing.T) {
+	values := url.Values{
+		"currency": {"eur"},
+		"symbol":   {"€"},
+		"rate":     {"0.9215"},
+	}
+
+	// create stub call and return arguments
+	arg := db.UpsertExchangeRateParams{
+		Currency: "EUR",
+		Symbol:   "€",
+		Rate:     921500,
+	}
+	e := ExchangeRate{Currency: "EUR", Symbol: "€", Rate: 921500, UpdatedAt: time.Now()}
+	dbRate := db.ExchangeRate{}
+	e.Export(&dbRate)
+
+	// Test OK: the exchange rate is saved and the cache is reloaded
+	t.Run("OK", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/currencies", strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("UpsertExchangeRate", mock.Anything, arg).
+			Return(dbRate, nil).
+			Once()
+		ts.MockDBStore.On("ListExchangeRates", mock.Anything).
+			Return([]db.ExchangeRate{dbRate}, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, "1 USD = 0.921500 EUR.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/currencies", rr.Header().Get("Location"))
+		assert.Len(t, ts.DisplayCurrencies(), 2)
+	})
+
+	// Test Error: invalid form is rendered again with the errors
+	for _, test := range []struct {
+		Name   string
+		Values url.Values
+		Errors []string
+	}{
+		{
+			Name:   "Invalid Form",
+			Values: url.Values{"currency": {"EURO"}, "symbol": {"€€€€€€€€€"}, "rate": {"-1"}},
+			Errors: []string{
+				"Invalid currency. Please enter a 3 letter code such as EUR.",
+				"Symbol is too long.",
+				"Invalid rate. Please enter a rate with up to 6 decimals such as 0.9215.",
+			},
+		},
+		{
+			Name:   "Base Currency",
+			Values: url.Values{"currency": {"USD"}, "symbol": {"$"}, "rate": {"0"}},
+			Errors: []string{
+				"The base currency needs no exchange rate.",
+				"Invalid rate. Please enter a rate with up to 6 decimals such as 0.9215.",
+			},
+		},
+		{
+			Name:   "Missing Fields",
+			Values: url.Values{},
+			Errors: []string{"Required field!"},
+		},
+	} {
+		t.Run(test.Name, func(t *testing.T) {
+			// create a new test server, a mock database store and an authenticated request
+			ts := NewTestServer(t)
+			req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/currencies", strings.NewReader(test.Values.Encode()))
+			app.Session.Put(req.Context(), "user_id", 1)
+
+			// build stub
+			ts.MockDBStore.On("ListExchangeRates", mock.Anything).
+				Return([]db.ExchangeRate{}, nil).
+				Once()
+
+			//  server the request
+			rr := ts.ServeRequest(req)
+
+			// testify
+			assert.Equal(t, http.StatusOK, rr.Code)
+			for _, msg := range test.Errors {
+				assert.Contains(t, rr.Body.String(), msg)
+			}
+		})
+	}
+
+	// Test Error: internal server error on UpsertExchangeRate
+	t.Run("Database Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/currencies", strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("UpsertExchangeRate", mock.Anything, arg).
+			Return(db.ExchangeRate{}, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/currencies", rr.Header().Get("Location"))
+	})
+}
+
+func TestServer_PostAdminDeleteExchangeRatesHandler(t *testing.T) {
+	currencies := []string{"EUR", "GBP"}
+	values := url.Values{"currency": currencies}
+
+	// Test OK: the exchange rates selected are deleted and the cache is reloaded
+	t.Run("OK", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		ts.exchangeRates = []ExchangeRate{randomExchangeRate()}
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/currencies/delete", strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("DeleteExchangeRates", mock.Anything, currencies).
+			Return(nil).
+			Once()
+		ts.MockDBStore.On("ListExchangeRates", mock.Anything).
+			Return([]db.ExchangeRate{}, nil).
+			Once()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get flash message from session and remove it
+		msg := app.Session.PopString(req.Context(), "flash")
+		assert.Equal(t, "2 exchange rates deleted.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/currencies", rr.Header().Get("Location"))
+		assert.Len(t, ts.DisplayCurrencies(), 1)
+	})
+
+	// Test Warning: no currencies selected
+	t.Run("No Currencies Selected", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/currencies/delete", nil)
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// get warning message from session and remove it
+		msg := app.Session.PopString(req.Context(), "warning")
+		assert.Equal(t, "No currencies selected.", msg)
+
+		// testify
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "/admin/currencies", rr.Header().Get("Location"))
+	})
+
+	// Test Error: internal server error on DeleteExchangeRates
+	t.Run("Database Error", func(t *testing.T) {
+		// create a new test server, a mock database store and an authenticated request
+		ts := NewTestServer(t)
+		req := ts.NewRequestWithSession(t, http.MethodPost, "/admin/currencies/delete", strings.NewReader(values.Encode()))
+		app.Session.Put(req.Context(), "user_id", 1)
+
+		// build stubs
+		ts.MockDBStore.On("DeleteExchangeRates", mock.Anything, currencies).
+			Return(errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		//  server the request
+		rr := ts.ServeRequest(req)
+
+		// testify
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/admin/currencies", rr.Header().Get("Location"))
 	})
 }
@@ -4,14 +4,43 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/github-real-lb/bookings-web-app/db"
 	"github.com/github-real-lb/bookings-web-app/util"
+	"github.com/github-real-lb/bookings-web-app/util/config"
+	"github.com/github-real-lb/bookings-web-app/util/forms"
 )
 
 const ReservationCodeLenght = 7
 
+// HoldTokenLength sets the number of random bytes of the tokens identifying the room holds of a session and of a waitlist offer
+const HoldTokenLength = 32
+
+// PasswordTokenLength sets the number of random bytes of the token in the link mailed to staff users to set a password,
+// and of the throwaway passwords of staff users who have not set one yet
+const PasswordTokenLength = 32
+
+// PasswordTokenTTL sets how long the link mailed to staff users to set a password can be used
+const PasswordTokenTTL = 72 * time.Hour
+
+// RoomImagesDirectory is the directory of the room images in the static directory
+const RoomImagesDirectory = "images"
+
+// RoomImageTypes maps the content types of the room images allowed to their file extensions
+var RoomImageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
 // GenerateReservationCode generate the reservation code.
 func (r *Reservation) GenerateReservationCode() {
 	// concatenating the current time with the reservation last name
@@ -49,6 +78,736 @@ func (r *Reservation) GenerateReservationCode() {
 	}
 }
 
+// IsCancelled returns true if the reservation was cancelled
+func (r *Reservation) IsCancelled() bool {
+	return !r.CancelledAt.IsZero()
+}
+
+// IsCancellable returns true if the reservation can still be cancelled on date
+func (r *Reservation) IsCancellable(date time.Time) bool {
+	return r.Status.CanTransitionTo(ReservationCancelled) && !date.After(r.StartDate)
+}
+
+// IsInvoiceable returns true if an invoice can be issued for the reservation, which is neither pending nor cancelled
+func (r *Reservation) IsInvoiceable() bool {
+	return r.Status != ReservationPending && r.Status != ReservationCancelled && !r.IsCancelled()
+}
+
+// CanTransitionTo returns true if a reservation in status rs can move to status next
+func (rs ReservationStatus) CanTransitionTo(next ReservationStatus) bool {
+	return db.ReservationStatus(rs).CanTransitionTo(db.ReservationStatus(next))
+}
+
+// NextStatuses returns the statuses a reservation in status rs can move to
+func (rs ReservationStatus) NextStatuses() []ReservationStatus {
+	dbNext := db.ReservationStatus(rs).NextStatuses()
+
+	next := make([]ReservationStatus, len(dbNext))
+	for i, v := range dbNext {
+		next[i] = ReservationStatus(v)
+	}
+
+	return next
+}
+
+// Label returns the status rs in a human readable form, such as "Checked In"
+func (rs ReservationStatus) Label() string {
+	return label(string(rs))
+}
+
+// Label returns the restriction r in a human readable form, such as "Owner Block"
+func (r Restriction) Label() string {
+	return label(string(r))
+}
+
+// Label returns the role r in a human readable form, such as "Front Desk"
+func (r Role) Label() string {
+	switch r {
+	case RoleOwner:
+		return "Owner"
+	case RoleManager:
+		return "Manager"
+	case RoleFrontDesk:
+		return "Front Desk"
+	case RoleReadOnly:
+		return "Read-Only"
+	default:
+		return fmt.Sprintf("Access Level %d", r)
+	}
+}
+
+// Valid returns true if r is one of the roles
+func (r Role) Valid() bool {
+	return r >= RoleOwner && r <= RoleReadOnly
+}
+
+// HasAccess returns true if r is a valid role with the access of role
+func (r Role) HasAccess(role Role) bool {
+	return r.Valid() && r <= role
+}
+
+// IsActive returns true if the user was not deactivated
+func (u *User) IsActive() bool {
+	return u.DeactivatedAt.IsZero()
+}
+
+// IsPasswordTokenValid returns true if the user has a password token that has not expired at now
+func (u *User) IsPasswordTokenValid(now time.Time) bool {
+	return u.PasswordToken != "" && now.Before(u.PasswordTokenExpiresAt)
+}
+
+// label returns the snake case enum value s in a human readable form, such as "Checked In"
+func label(s string) string {
+	words := strings.Split(s, "_")
+	for i, w := range words {
+		if w != "" {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+// CancellationFee returns the percentage of the reservation charged if cancelled on date according to policy
+func (r *Reservation) CancellationFee(policy config.CancellationPolicy, date time.Time) int {
+	daysBeforeArrival := int(r.StartDate.Sub(date).Hours() / 24)
+	if daysBeforeArrival >= policy.FreeCancellationDays {
+		return 0
+	}
+
+	return policy.LateCancellationFeePercent
+}
+
+// FreeCancellationDate returns the last date on which the reservation can be cancelled free of charge according to policy
+func (r *Reservation) FreeCancellationDate(policy config.CancellationPolicy) time.Time {
+	return r.StartDate.AddDate(0, 0, -policy.FreeCancellationDays)
+}
+
+// Today returns the current date in UTC, as dates are stored in the database
+func Today() time.Time {
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// FirstOfMonth returns the first day of the month of date, in UTC as dates are stored in the database
+func FirstOfMonth(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// NewCalendar returns the calendar of the rooms in the month of date, with the restrictions of the rooms on every night
+func NewCalendar(date time.Time, rooms []Room, restrictions []RoomRestriction) Calendar {
+	month := FirstOfMonth(date)
+
+	c := Calendar{Month: month}
+	for day := month; day.Month() == month.Month(); day = day.AddDate(0, 0, 1) {
+		c.Days = append(c.Days, day)
+	}
+
+	c.Rows = make([]CalendarRow, len(rooms))
+	for i, room := range rooms {
+		c.Rows[i] = CalendarRow{
+			Room:  room,
+			Cells: make([]CalendarCell, len(c.Days)),
+		}
+
+		for j, day := range c.Days {
+			c.Rows[i].Cells[j].Date = day
+			for _, rr := range restrictions {
+				if rr.RoomID == room.ID && !day.Before(rr.StartDate) && day.Before(rr.EndDate) {
+					c.Rows[i].Cells[j].Restriction = rr
+					break
+				}
+			}
+		}
+	}
+
+	return c
+}
+
+// Prev returns the previous month of the calendar, such as 2024-05
+func (c Calendar) Prev() string {
+	return c.Month.AddDate(0, -1, 0).Format(config.MonthLayout)
+}
+
+// Next returns the next month of the calendar, such as 2024-07
+func (c Calendar) Next() string {
+	return c.Month.AddDate(0, 1, 0).Format(config.MonthLayout)
+}
+
+// IsWeekend returns true if the night of the cell is a Friday or Saturday night
+func (c CalendarCell) IsWeekend() bool {
+	return c.Date.Weekday() == time.Friday || c.Date.Weekday() == time.Saturday
+}
+
+// getCart returns the rooms in the booking cart of the session.
+// If the cart is empty, it returns the room of rsv, if one was chosen.
+func getCart(r *http.Request, rsv Reservation) []Room {
+	cart, ok := app.Session.Get(r.Context(), "cart").([]Room)
+	if ok && len(cart) > 0 {
+		return cart
+	}
+
+	if rsv.RoomID == 0 {
+		return []Room{}
+	}
+
+	return []Room{rsv.Room}
+}
+
+// IsOfferValid returns true if a room was offered to the waitlisted guest and the offer has not expired on now
+func (e *WaitlistEntry) IsOfferValid(now time.Time) bool {
+	return e.OfferedRoomID != 0 && now.Before(e.ExpiresAt)
+}
+
+// getHoldToken returns the token identifying the room holds of the session.
+// A new token is generated if the session has none.
+func getHoldToken(r *http.Request) string {
+	token := app.Session.GetString(r.Context(), "hold_token")
+	if token == "" {
+		token = util.NewToken(HoldTokenLength)
+		app.Session.Put(r.Context(), "hold_token", token)
+	}
+
+	return token
+}
+
+// CheckGuests checks that the adults and children fields of form are within the limits of a search.
+// Missing fields are set to a single adult and no children.
+// Error messages are added to form.Errors.
+func CheckGuests(form *forms.Form) bool {
+	if !form.Has("adults") {
+		form.Set("adults", "1")
+	}
+	if !form.Has("children") {
+		form.Set("children", "0")
+	}
+
+	ok := form.CheckIntRange("adults", 1, MaxAdults)
+	return form.CheckIntRange("children", 0, MaxChildren) && ok
+}
+
+// CheckOwnerBlockDates checks that the start_date and end_date fields of form block the room for at least one night.
+// Error messages are added to form.Errors.
+func CheckOwnerBlockDates(form *forms.Form) bool {
+	form.Required("start_date", "end_date")
+	if !form.CheckDateRange("start_date", "end_date") {
+		return false
+	}
+
+	var startDate, endDate time.Time
+	form.GetValue("start_date", &startDate)
+	form.GetValue("end_date", &endDate)
+
+	if !endDate.After(startDate) {
+		form.Errors.Add("end_date", "The room must be blocked for at least one night.")
+		return false
+	}
+
+	return true
+}
+
+// overrideNote returns the note logged for changes made overriding reservations
+func overrideNote(override bool) string {
+	if override {
+		return ", overriding reservations"
+	}
+	return ""
+}
+
+// saveRoomImage saves image to the static images directory under a new filename starting with slug,
+// so that browsers and mails never show a cached previous image. It returns the filename.
+func saveRoomImage(slug string, image []byte) (string, error) {
+	filename := fmt.Sprint(slug, "-", util.RandomString(8), RoomImageTypes[http.DetectContentType(image)])
+
+	dir := filepath.Join(app.StaticPath, RoomImagesDirectory)
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", err
+	}
+
+	return filename, os.WriteFile(filepath.Join(dir, filename), image, 0644)
+}
+
+// Fits returns true if the room can accommodate the number of adults and children
+func (r *Room) Fits(adults, children int) bool {
+	return adults <= r.MaxAdults && children <= r.MaxChildren && adults+children <= r.MaxOccupancy
+}
+
+// splitGuests splits a party of adults and children across the rooms in cart, with at least one adult in every room.
+// Earlier rooms are filled first. It returns false if the rooms cannot accommodate the party together.
+func splitGuests(cart []Room, adults, children int) ([]Guests, bool) {
+	split := make([]Guests, len(cart))
+
+	// failed holds the parties that cannot be accommodated from a room of the cart onwards
+	failed := make(map[[3]int]bool)
+
+	var fill func(i, adults, children int) bool
+	fill = func(i, adults, children int) bool {
+		if i == len(cart) {
+			return adults == 0 && children == 0
+		}
+
+		key := [3]int{i, adults, children}
+		if failed[key] {
+			return false
+		}
+
+		room := cart[i]
+		for a := min(adults, room.MaxAdults, room.MaxOccupancy); a >= 1; a-- {
+			for c := min(children, room.MaxChildren, room.MaxOccupancy-a); c >= 0; c-- {
+				if fill(i+1, adults-a, children-c) {
+					split[i] = Guests{Adults: a, Children: c}
+					return true
+				}
+			}
+		}
+
+		failed[key] = true
+		return false
+	}
+
+	if !fill(0, adults, children) {
+		return nil, false
+	}
+
+	return split, true
+}
+
+// capacityMessage returns the error message of a party that cannot be accommodated in the rooms of cart
+func capacityMessage(cart []Room) string {
+	if len(cart) == 1 {
+		room := cart[0]
+		return fmt.Sprintf("%s accommodates up to %d adults, %d children and %d guests in total.",
+			room.Name, room.MaxAdults, room.MaxChildren, room.MaxOccupancy)
+	}
+
+	var occupancy int
+	for _, room := range cart {
+		occupancy += room.MaxOccupancy
+	}
+
+	return fmt.Sprintf("The %d rooms selected accommodate up to %d guests in total, with at least one adult in every room.",
+		len(cart), occupancy)
+}
+
+// URL returns the canonical page URL of the room
+func (r *Room) URL() string {
+	return fmt.Sprint("/rooms/room/", r.Slug)
+}
+
+// String returns p formatted in the base currency, such as $1250.00
+func (p Price) String() string {
+	return formatAmount(app.BaseCurrency.Symbol, p)
+}
+
+// Amount returns the price formatted without the currency symbol, such as 1250.00, as parsed by ParsePrice
+func (p Price) Amount() string {
+	return formatAmount("", p)
+}
+
+// formatAmount returns the amount p in cents formatted with the currency symbol, such as $1250.00
+func formatAmount(symbol string, p Price) string {
+	sign := ""
+	if p < 0 {
+		sign = "-"
+		p = -p
+	}
+
+	return fmt.Sprintf("%s%s%d.%02d", sign, symbol, p/100, p%100)
+}
+
+// Percent returns percent percent of p, rounded to the nearest cent
+func (p Price) Percent(percent int) Price {
+	return (p*Price(percent) + 50) / 100
+}
+
+// ParsePrice parses an amount in the base currency, such as 150 or 149.90, into a Price
+func ParsePrice(s string) (Price, error) {
+	dollars, cents, found := strings.Cut(strings.TrimPrefix(strings.TrimSpace(s), app.BaseCurrency.Symbol), ".")
+	if dollars == "" || (found && (cents == "" || len(cents) > 2)) {
+		return 0, fmt.Errorf("invalid price %q", s)
+	}
+
+	d, err := strconv.ParseUint(dollars, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid price %q", s)
+	}
+
+	var c uint64
+	if found {
+		c, err = strconv.ParseUint(cents, 10, 8)
+		if err != nil {
+			return 0, fmt.Errorf("invalid price %q", s)
+		}
+		if len(cents) == 1 {
+			c *= 10
+		}
+	}
+
+	return Price(d*100 + c), nil
+}
+
+// BaseExchangeRate returns the exchange rate of the base currency to itself
+func BaseExchangeRate() ExchangeRate {
+	return ExchangeRate{
+		Currency: app.BaseCurrency.Code,
+		Symbol:   app.BaseCurrency.Symbol,
+		Rate:     RateScale,
+	}
+}
+
+// Convert returns the price p in the base currency converted to the currency of e, rounded to the nearest cent
+func (e ExchangeRate) Convert(p Price) Price {
+	if p < 0 {
+		return -e.Convert(-p)
+	}
+
+	return Price((int64(p)*e.Rate + RateScale/2) / RateScale)
+}
+
+// Format returns the price p in the base currency converted and formatted in the currency of e, such as €1150.50.
+// Prices are formatted in the base currency if e is empty.
+func (e ExchangeRate) Format(p Price) string {
+	if e.Currency == "" {
+		return p.String()
+	}
+
+	return formatAmount(e.Symbol, e.Convert(p))
+}
+
+// RateString returns the rate of e as a decimal number, such as 0.921500
+func (e ExchangeRate) RateString() string {
+	return fmt.Sprintf("%d.%06d", e.Rate/RateScale, e.Rate%RateScale)
+}
+
+// ParseRate parses an exchange rate with up to 6 decimals, such as 0.9215 or 157, into millionths
+func ParseRate(s string) (int64, error) {
+	units, decimals, found := strings.Cut(strings.TrimSpace(s), ".")
+	if units == "" || (found && (decimals == "" || len(decimals) > 6)) {
+		return 0, fmt.Errorf("invalid exchange rate %q", s)
+	}
+
+	u, err := strconv.ParseUint(units, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid exchange rate %q", s)
+	}
+
+	var d uint64
+	if found {
+		d, err = strconv.ParseUint(decimals+strings.Repeat("0", 6-len(decimals)), 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("invalid exchange rate %q", s)
+		}
+	}
+
+	return int64(u*RateScale + d), nil
+}
+
+// isCurrencyCode reports whether code is a 3 letter currency code, such as EUR
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Money returns the price p in the base currency formatted in the display currency of the session.
+// Templates use it to show prices to guests, such as {{$.Money .TotalPrice}}.
+func (td *TemplateData) Money(p Price) string {
+	return td.Currency.Format(p)
+}
+
+// InBaseCurrency reports whether prices are displayed in the base currency, in which they are charged
+func (td *TemplateData) InBaseCurrency() bool {
+	return td.Currency.Currency == "" || td.Currency.Currency == app.BaseCurrency.Code
+}
+
+// BaseCurrency returns the code of the base currency, in which prices are charged
+func (td *TemplateData) BaseCurrency() string {
+	return app.BaseCurrency.Code
+}
+
+// TotalPrice returns the sum of the total prices of rsvs
+func TotalPrice(rsvs []Reservation) Price {
+	var total Price
+	for _, rsv := range rsvs {
+		total += rsv.TotalPrice
+	}
+
+	return total
+}
+
+// TotalDiscount returns the sum of the discounts of rsvs
+func TotalDiscount(rsvs []Reservation) Price {
+	var discount Price
+	for _, rsv := range rsvs {
+		discount += rsv.Discount
+	}
+
+	return discount
+}
+
+// PaidAmount returns the amount captured by the succeeded payments less the amount refunded.
+// Pending refunds are deducted, as the money is already on its way back to the guest.
+func PaidAmount(payments []Payment) Price {
+	var paid Price
+	for _, p := range payments {
+		switch {
+		case p.Kind == PaymentCapture && p.Status == PaymentSucceeded:
+			paid += p.Amount
+		case p.Kind == PaymentRefund && p.Status != PaymentFailed:
+			paid -= p.Amount
+		}
+	}
+
+	return paid
+}
+
+// RefundableCaptures returns the succeeded captures of payments that are not fully refunded,
+// with their Amount set to the amount left to refund
+func RefundableCaptures(payments []Payment) []Payment {
+	refunded := make(map[int64]Price)
+	for _, p := range payments {
+		if p.Kind == PaymentRefund && p.Status != PaymentFailed {
+			refunded[p.ParentID] += p.Amount
+		}
+	}
+
+	var captures []Payment
+	for _, p := range payments {
+		if p.Kind != PaymentCapture || p.Status != PaymentSucceeded {
+			continue
+		}
+
+		p.Amount -= refunded[p.ID]
+		if p.Amount > 0 {
+			captures = append(captures, p)
+		}
+	}
+
+	return captures
+}
+
+// RefundableAmount returns the amount of paid refundable according to the cancellation policy.
+// The cancellation fee of the reservation is kept from paid, and nothing is refundable unless the reservation is cancelled.
+func (r *Reservation) RefundableAmount(paid Price) Price {
+	if r.Status != ReservationCancelled {
+		return 0
+	}
+
+	return max(0, paid-r.TotalPrice.Percent(r.CancellationFeePercent))
+}
+
+// Nights returns the number of nights of the reservation
+func (r *Reservation) Nights() int {
+	return int(r.EndDate.Sub(r.StartDate).Hours() / 24)
+}
+
+// StayPrice returns the price of the nights of the reservation, before the promo discount and the taxes and fees
+func (r *Reservation) StayPrice() Price {
+	price := r.TotalPrice + r.Discount
+	for _, c := range r.Charges {
+		price -= c.Amount
+	}
+
+	return price
+}
+
+// AmountCharged returns the amount the guest is charged for the reservation,
+// which is the cancellation fee if the reservation was cancelled
+func (r *Reservation) AmountCharged() Price {
+	if r.Status == ReservationCancelled {
+		return r.TotalPrice.Percent(r.CancellationFeePercent)
+	}
+
+	return r.TotalPrice
+}
+
+// InvoiceNumber returns the invoice number prefixed by the invoice prefix of the property, such as FS-000042
+func (i *Invoice) InvoiceNumber() string {
+	return fmt.Sprintf("%s-%06d", app.InvoicePrefix, i.Number)
+}
+
+// Filename returns the file name of the invoice document
+func (i *Invoice) Filename() string {
+	return fmt.Sprintf("invoice-%s.html", i.InvoiceNumber())
+}
+
+// IsOverride returns true if staff refunded an amount other than the amount refundable by the cancellation policy
+func (r *Refund) IsOverride() bool {
+	return r.Amount != r.PolicyAmount
+}
+
+// String returns the description of charge kind k, such as "Per guest per night"
+func (k ChargeKind) String() string {
+	switch k {
+	case ChargePercent:
+		return "Percent of price"
+	case ChargePerNight:
+		return "Per night"
+	case ChargePerPersonNight:
+		return "Per guest per night"
+	case ChargePerStay:
+		return "Per stay"
+	default:
+		return string(k)
+	}
+}
+
+// String returns the label of the folio entry kind
+func (k FolioEntryKind) String() string {
+	switch k {
+	case FolioCharge:
+		return "Charge"
+	case FolioPayment:
+		return "Payment"
+	default:
+		return string(k)
+	}
+}
+
+// IsVoided reports whether the folio entry was voided
+func (e *FolioEntry) IsVoided() bool {
+	return !e.VoidedAt.IsZero()
+}
+
+// FolioBalance returns the balance of the folio entries, charges less payments, leaving out the voided entries.
+// It sets the running balance of every entry.
+func FolioBalance(entries []FolioEntry) Price {
+	var balance Price
+	for i, e := range entries {
+		switch {
+		case e.IsVoided():
+		case e.Kind == FolioCharge:
+			balance += e.Amount
+		case e.Kind == FolioPayment:
+			balance -= e.Amount
+		}
+		entries[i].Balance = balance
+	}
+
+	return balance
+}
+
+// AmountLabel returns the amount of the charge formatted by its kind, such as 17.00% or $2.50 per guest per night
+func (c *Charge) AmountLabel() string {
+	switch c.Kind {
+	case ChargePercent:
+		return fmt.Sprintf("%d.%02d%%", c.Amount/100, c.Amount%100)
+	case ChargePerNight:
+		return fmt.Sprintf("%s per night", Price(c.Amount))
+	case ChargePerPersonNight:
+		return fmt.Sprintf("%s per guest per night", Price(c.Amount))
+	default:
+		return Price(c.Amount).String()
+	}
+}
+
+// Weekdays returns the short names of the days of the week the rate applies on
+func (r *RoomRate) Weekdays() string {
+	if r.DaysOfWeek == int(db.AllDaysOfWeek) {
+		return "Every day"
+	}
+
+	return weekdayNames(int32(r.DaysOfWeek))
+}
+
+// weekdayNames returns the short names of the days of the week in the days of week mask daysOfWeek
+func weekdayNames(daysOfWeek int32) string {
+	days := []string{}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if daysOfWeek&db.DaysOfWeekMask(day) != 0 {
+			days = append(days, day.String()[:3])
+		}
+	}
+
+	return strings.Join(days, ", ")
+}
+
+// StayRuleMessage returns the explanation to the guest of the stay rule broken by a stay
+func StayRuleMessage(e *db.StayRuleError) string {
+	when := fmt.Sprintf("between %s and %s",
+		e.Rule.StartDate.Time.Format(config.DateLayout), e.Rule.EndDate.Time.Format(config.DateLayout))
+	if e.Rule.DaysOfWeek != db.AllDaysOfWeek {
+		when = fmt.Sprintf("on %s %s", weekdayNames(e.Rule.DaysOfWeek), when)
+	}
+
+	switch e.Violation {
+	case db.ViolationMinNights:
+		return fmt.Sprintf("Stays arriving %s require a minimum of %d nights.", when, e.Rule.MinNights)
+	case db.ViolationMaxNights:
+		return fmt.Sprintf("Stays arriving %s are limited to a maximum of %d nights.", when, e.Rule.MaxNights)
+	case db.ViolationClosedToArrival:
+		return fmt.Sprintf("Arrivals are not possible %s.", when)
+	case db.ViolationClosedToDeparture:
+		return fmt.Sprintf("Departures are not possible %s.", when)
+	default:
+		return "The dates selected are not allowed for this room."
+	}
+}
+
+// PromoCodeMessage returns the explanation to the guest of why a promo code does not apply to a stay
+func PromoCodeMessage(e *db.PromoCodeError) string {
+	switch e.Rejection {
+	case db.RejectionNotFound:
+		return fmt.Sprintf("Promo code %s does not exist.", e.Code)
+	case db.RejectionDates:
+		return fmt.Sprintf("Promo code %s is valid for arrivals between %s and %s only.", e.Code,
+			e.PromoCode.ValidFrom.Time.Format(config.DateLayout), e.PromoCode.ValidTo.Time.Format(config.DateLayout))
+	case db.RejectionMinNights:
+		return fmt.Sprintf("Promo code %s requires a minimum of %d nights.", e.Code, e.PromoCode.MinNights)
+	case db.RejectionRoom:
+		return fmt.Sprintf("Promo code %s is not valid for all the rooms selected.", e.Code)
+	case db.RejectionUsedUp:
+		return fmt.Sprintf("Promo code %s is no longer available.", e.Code)
+	default:
+		return fmt.Sprintf("Promo code %s cannot be applied to this stay.", e.Code)
+	}
+}
+
+// containsRoom returns true if a room with roomID is in rooms
+func containsRoom(rooms []Room, roomID int64) bool {
+	for _, room := range rooms {
+		if room.ID == roomID {
+			return true
+		}
+	}
+
+	return false
+}
+
 func IsAuthenticated(r *http.Request) bool {
 	return app.Session.Exists(r.Context(), "user_id")
 }
+
+// refererPath returns the path and query of the page r was sent from, or the home page if it has none.
+// Only the path is kept so that the referer cannot redirect to another site.
+func refererPath(r *http.Request) string {
+	u, err := url.Parse(r.Referer())
+	if err != nil || !strings.HasPrefix(u.Path, "/") || strings.HasPrefix(u.Path, "//") {
+		return "/"
+	}
+
+	if u.RawQuery != "" {
+		return u.Path + "?" + u.RawQuery
+	}
+
+	return u.Path
+}
+
+// ClientIP returns the ip address of the client that sent r
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
@@ -1,12 +1,22 @@
 package main
 
 import (
+	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/github-real-lb/bookings-web-app/db"
 	"github.com/github-real-lb/bookings-web-app/util"
+	"github.com/github-real-lb/bookings-web-app/util/config"
+	"github.com/github-real-lb/bookings-web-app/util/forms"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestReservation_GenerateReservationCode(t *testing.T) {
@@ -43,3 +53,616 @@ func TestIsAuthenticated(t *testing.T) {
 		assert.True(t, result)
 	})
 }
+
+func TestClientIP(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	assert.NoError(t, err)
+
+	req.RemoteAddr = "192.0.2.1:1234"
+	assert.Equal(t, "192.0.2.1", ClientIP(req))
+
+	req.RemoteAddr = "192.0.2.1"
+	assert.Equal(t, "192.0.2.1", ClientIP(req))
+}
+
+func TestReservation_IsCancellable(t *testing.T) {
+	today := Today()
+	r := Reservation{StartDate: today, Status: ReservationPending}
+	assert.False(t, r.IsCancelled())
+	assert.True(t, r.IsCancellable(today))
+
+	// arrival date has passed
+	assert.False(t, r.IsCancellable(today.AddDate(0, 0, 1)))
+
+	// guest already checked in
+	r.Status = ReservationCheckedIn
+	assert.False(t, r.IsCancellable(today))
+
+	// reservation is already cancelled
+	r.CancelledAt = time.Now()
+	r.Status = ReservationCancelled
+	assert.True(t, r.IsCancelled())
+	assert.False(t, r.IsCancellable(today))
+}
+
+func TestReservation_IsInvoiceable(t *testing.T) {
+	for _, status := range []ReservationStatus{ReservationConfirmed, ReservationCheckedIn, ReservationCheckedOut, ReservationNoShow} {
+		r := Reservation{Status: status}
+		assert.True(t, r.IsInvoiceable(), status)
+	}
+
+	for _, status := range []ReservationStatus{ReservationPending, ReservationCancelled} {
+		r := Reservation{Status: status}
+		assert.False(t, r.IsInvoiceable(), status)
+	}
+
+	// a cancelled reservation is not invoiceable even if its status was not updated
+	r := Reservation{Status: ReservationConfirmed, CancelledAt: time.Now()}
+	assert.False(t, r.IsInvoiceable())
+}
+
+func TestReservationStatus_CanTransitionTo(t *testing.T) {
+	assert.True(t, ReservationPending.CanTransitionTo(ReservationConfirmed))
+	assert.True(t, ReservationPending.CanTransitionTo(ReservationCancelled))
+	assert.False(t, ReservationPending.CanTransitionTo(ReservationCheckedIn))
+	assert.True(t, ReservationConfirmed.CanTransitionTo(ReservationCheckedIn))
+	assert.True(t, ReservationConfirmed.CanTransitionTo(ReservationNoShow))
+	assert.True(t, ReservationCheckedIn.CanTransitionTo(ReservationCheckedOut))
+	assert.False(t, ReservationCheckedIn.CanTransitionTo(ReservationCancelled))
+
+	// final statuses
+	for _, status := range []ReservationStatus{ReservationCheckedOut, ReservationCancelled, ReservationNoShow} {
+		assert.Empty(t, status.NextStatuses())
+		for _, next := range ReservationStatuses {
+			assert.False(t, status.CanTransitionTo(next))
+		}
+	}
+
+	assert.Equal(t, []ReservationStatus{ReservationCheckedIn, ReservationCancelled, ReservationNoShow}, ReservationConfirmed.NextStatuses())
+}
+
+func TestReservationStatus_Label(t *testing.T) {
+	assert.Equal(t, "Pending", ReservationPending.Label())
+	assert.Equal(t, "Checked In", ReservationCheckedIn.Label())
+	assert.Equal(t, "No Show", ReservationNoShow.Label())
+}
+
+func TestRestriction_Label(t *testing.T) {
+	assert.Equal(t, "Reservation", RestrictionReservation.Label())
+	assert.Equal(t, "Owner Block", RestrictionOwnerBlock.Label())
+	assert.Equal(t, "Hold", RestrictionHold.Label())
+}
+
+func TestRole_Label(t *testing.T) {
+	assert.Equal(t, "Owner", RoleOwner.Label())
+	assert.Equal(t, "Front Desk", RoleFrontDesk.Label())
+	assert.Equal(t, "Read-Only", RoleReadOnly.Label())
+	assert.Equal(t, "Access Level 9", Role(9).Label())
+}
+
+func TestRole_HasAccess(t *testing.T) {
+	assert.True(t, RoleOwner.HasAccess(RoleOwner))
+	assert.True(t, RoleOwner.HasAccess(RoleReadOnly))
+	assert.True(t, RoleManager.HasAccess(RoleFrontDesk))
+	assert.False(t, RoleFrontDesk.HasAccess(RoleManager))
+	assert.False(t, RoleReadOnly.HasAccess(RoleFrontDesk))
+
+	// invalid access levels have no access
+	assert.False(t, Role(0).Valid())
+	assert.False(t, Role(0).HasAccess(RoleReadOnly))
+	assert.False(t, Role(5).HasAccess(RoleReadOnly))
+}
+
+func TestReservation_CancellationFee(t *testing.T) {
+	policy := config.CancellationPolicy{
+		FreeCancellationDays:       7,
+		LateCancellationFeePercent: 50,
+	}
+
+	today := Today()
+	r := Reservation{StartDate: today.AddDate(0, 0, 10)}
+
+	assert.Equal(t, 0, r.CancellationFee(policy, today))
+	assert.Equal(t, 0, r.CancellationFee(policy, today.AddDate(0, 0, 3)))
+	assert.Equal(t, 50, r.CancellationFee(policy, today.AddDate(0, 0, 4)))
+	assert.Equal(t, 50, r.CancellationFee(policy, r.StartDate))
+	assert.Equal(t, today.AddDate(0, 0, 3), r.FreeCancellationDate(policy))
+}
+
+func TestRoom_Fits(t *testing.T) {
+	room := Room{
+		MaxAdults:    2,
+		MaxChildren:  2,
+		MaxOccupancy: 3,
+	}
+
+	assert.True(t, room.Fits(1, 0))
+	assert.True(t, room.Fits(2, 1))
+	assert.True(t, room.Fits(1, 2))
+	assert.False(t, room.Fits(3, 0))
+	assert.False(t, room.Fits(1, 3))
+	assert.False(t, room.Fits(2, 2))
+}
+
+func TestSplitGuests(t *testing.T) {
+	family := Room{Name: "Family", MaxAdults: 2, MaxChildren: 2, MaxOccupancy: 2}
+	double := Room{Name: "Double", MaxAdults: 1, MaxChildren: 1, MaxOccupancy: 2}
+
+	// a single room takes the whole party
+	split, ok := splitGuests([]Room{family}, 1, 1)
+	require.True(t, ok)
+	assert.Equal(t, []Guests{{Adults: 1, Children: 1}}, split)
+
+	// the party is split across the rooms even if filling the first room with children does not fit
+	split, ok = splitGuests([]Room{family, double}, 3, 1)
+	require.True(t, ok)
+	assert.Equal(t, []Guests{{Adults: 2}, {Adults: 1, Children: 1}}, split)
+
+	// every room requires an adult
+	_, ok = splitGuests([]Room{family, double}, 1, 2)
+	assert.False(t, ok)
+
+	// the rooms are too small for the party together
+	_, ok = splitGuests([]Room{family, double}, 3, 2)
+	assert.False(t, ok)
+}
+
+func TestCapacityMessage(t *testing.T) {
+	family := Room{Name: "Family", MaxAdults: 2, MaxChildren: 2, MaxOccupancy: 3}
+	double := Room{Name: "Double", MaxAdults: 2, MaxChildren: 0, MaxOccupancy: 2}
+
+	assert.Equal(t, "Family accommodates up to 2 adults, 2 children and 3 guests in total.",
+		capacityMessage([]Room{family}))
+	assert.Equal(t, "The 2 rooms selected accommodate up to 5 guests in total, with at least one adult in every room.",
+		capacityMessage([]Room{family, double}))
+}
+
+func TestRoom_URL(t *testing.T) {
+	room := Room{Slug: "generals-quarters"}
+	assert.Equal(t, "/rooms/room/generals-quarters", room.URL())
+}
+
+func TestPrice_String(t *testing.T) {
+	assert.Equal(t, "$0.00", Price(0).String())
+	assert.Equal(t, "$0.05", Price(5).String())
+	assert.Equal(t, "$1250.00", Price(125000).String())
+	assert.Equal(t, "-$12.34", Price(-1234).String())
+}
+
+func TestPrice_Amount(t *testing.T) {
+	assert.Equal(t, "0.05", Price(5).Amount())
+	assert.Equal(t, "1250.00", Price(125000).Amount())
+
+	// the amount is parsed back whatever the symbol of the base currency
+	symbol := app.BaseCurrency.Symbol
+	app.BaseCurrency.Symbol = "€"
+	defer func() { app.BaseCurrency.Symbol = symbol }()
+
+	price, err := ParsePrice(Price(125000).Amount())
+	require.NoError(t, err)
+	assert.Equal(t, Price(125000), price)
+}
+
+func TestPrice_Percent(t *testing.T) {
+	assert.Equal(t, Price(14000), Price(70000).Percent(20))
+	assert.Equal(t, Price(0), Price(70000).Percent(0))
+	assert.Equal(t, Price(70000), Price(70000).Percent(100))
+	assert.Equal(t, Price(25), Price(123).Percent(20))
+	assert.Equal(t, Price(1), Price(5).Percent(10))
+}
+
+func TestParsePrice(t *testing.T) {
+	for input, expected := range map[string]Price{
+		"150":     15000,
+		"149.90":  14990,
+		"149.9":   14990,
+		"$0.05":   5,
+		" 1250 ":  125000,
+		"1250.00": 125000,
+	} {
+		price, err := ParsePrice(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, price, input)
+	}
+
+	for _, input := range []string{"", "abc", "-10", "10.", ".50", "10.999", "1,000"} {
+		_, err := ParsePrice(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestExchangeRate_Convert(t *testing.T) {
+	eur := ExchangeRate{Currency: "EUR", Symbol: "€", Rate: 921500}
+
+	assert.Equal(t, Price(9215), eur.Convert(10000))
+	assert.Equal(t, Price(1), eur.Convert(1))
+	assert.Equal(t, Price(-9215), eur.Convert(-10000))
+	assert.Equal(t, Price(10000), BaseExchangeRate().Convert(10000))
+
+	jpy := ExchangeRate{Currency: "JPY", Symbol: "¥", Rate: 157_250000}
+	assert.Equal(t, Price(1572500), jpy.Convert(10000))
+}
+
+func TestExchangeRate_Format(t *testing.T) {
+	eur := ExchangeRate{Currency: "EUR", Symbol: "€", Rate: 921500}
+
+	assert.Equal(t, "€92.15", eur.Format(10000))
+	assert.Equal(t, "-€92.15", eur.Format(-10000))
+	assert.Equal(t, "$100.00", BaseExchangeRate().Format(10000))
+	assert.Equal(t, "$100.00", ExchangeRate{}.Format(10000))
+}
+
+func TestExchangeRate_RateString(t *testing.T) {
+	assert.Equal(t, "0.921500", ExchangeRate{Rate: 921500}.RateString())
+	assert.Equal(t, "157.250000", ExchangeRate{Rate: 157_250000}.RateString())
+}
+
+func TestParseRate(t *testing.T) {
+	for input, expected := range map[string]int64{
+		"1":        1_000000,
+		"0.9215":   921500,
+		"157.25":   157_250000,
+		" 1.5 ":    1_500000,
+		"0.000001": 1,
+	} {
+		rate, err := ParseRate(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, rate, input)
+	}
+
+	for _, input := range []string{"", "abc", "-1", "1.", ".5", "0.0000001", "1,5"} {
+		_, err := ParseRate(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestTemplateData_Money(t *testing.T) {
+	td := TemplateData{}
+	assert.Equal(t, "$100.00", td.Money(10000))
+	assert.True(t, td.InBaseCurrency())
+	assert.Equal(t, app.BaseCurrency.Code, td.BaseCurrency())
+
+	td.Currency = ExchangeRate{Currency: "EUR", Symbol: "€", Rate: 921500}
+	assert.Equal(t, "€92.15", td.Money(10000))
+	assert.False(t, td.InBaseCurrency())
+}
+
+func Test_isCurrencyCode(t *testing.T) {
+	assert.True(t, isCurrencyCode("EUR"))
+	for _, code := range []string{"", "eur", "EU", "EURO", "E1R"} {
+		assert.False(t, isCurrencyCode(code), code)
+	}
+}
+
+func Test_refererPath(t *testing.T) {
+	for referer, expected := range map[string]string{
+		"":                                     "/",
+		"http://localhost:8080/rooms/list":     "/rooms/list",
+		"http://localhost:8080/rooms?page=2":   "/rooms?page=2",
+		"https://example.com//evil.com/path":   "/",
+		"https://example.com":                  "/",
+		"/make-reservation":                    "/make-reservation",
+		"http://localhost:8080/my-reservation": "/my-reservation",
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/currency", nil)
+		req.Header.Set("Referer", referer)
+		assert.Equal(t, expected, refererPath(req), referer)
+	}
+}
+
+func TestRoomRate_Weekdays(t *testing.T) {
+	rate := RoomRate{DaysOfWeek: int(db.AllDaysOfWeek)}
+	assert.Equal(t, "Every day", rate.Weekdays())
+
+	rate.DaysOfWeek = int(db.DaysOfWeekMask(time.Saturday, time.Friday))
+	assert.Equal(t, "Fri, Sat", rate.Weekdays())
+}
+
+func TestStayRuleMessage(t *testing.T) {
+	rule := db.StayRule{
+		StartDate:  pgtype.Date{Time: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		EndDate:    pgtype.Date{Time: time.Date(2024, 8, 31, 0, 0, 0, 0, time.UTC), Valid: true},
+		DaysOfWeek: db.DaysOfWeekMask(time.Friday, time.Saturday),
+		MinNights:  2,
+		MaxNights:  14,
+	}
+
+	assert.Equal(t, "Stays arriving on Fri, Sat between 2024-06-01 and 2024-08-31 require a minimum of 2 nights.",
+		StayRuleMessage(&db.StayRuleError{Rule: rule, Violation: db.ViolationMinNights}))
+	assert.Equal(t, "Stays arriving on Fri, Sat between 2024-06-01 and 2024-08-31 are limited to a maximum of 14 nights.",
+		StayRuleMessage(&db.StayRuleError{Rule: rule, Violation: db.ViolationMaxNights}))
+
+	rule.DaysOfWeek = db.AllDaysOfWeek
+	assert.Equal(t, "Arrivals are not possible between 2024-06-01 and 2024-08-31.",
+		StayRuleMessage(&db.StayRuleError{Rule: rule, Violation: db.ViolationClosedToArrival}))
+	assert.Equal(t, "Departures are not possible between 2024-06-01 and 2024-08-31.",
+		StayRuleMessage(&db.StayRuleError{Rule: rule, Violation: db.ViolationClosedToDeparture}))
+}
+
+func TestTotalPrice(t *testing.T) {
+	rsvs := []Reservation{{TotalPrice: 10000}, {TotalPrice: 2550}}
+	assert.Equal(t, Price(12550), TotalPrice(rsvs))
+	assert.Zero(t, TotalPrice(nil))
+}
+
+func TestTotalDiscount(t *testing.T) {
+	rsvs := []Reservation{{Discount: 1000}, {}, {Discount: 255}}
+	assert.Equal(t, Price(1255), TotalDiscount(rsvs))
+	assert.Zero(t, TotalDiscount(nil))
+}
+
+func TestPaidAmount(t *testing.T) {
+	pays := []Payment{
+		{ID: 1, Kind: PaymentAuthorization, Status: PaymentSucceeded, Amount: 5000},
+		{ID: 2, ParentID: 1, Kind: PaymentCapture, Status: PaymentSucceeded, Amount: 5000},
+		{ID: 3, Kind: PaymentCapture, Status: PaymentFailed, Amount: 3000},
+		{ID: 4, ParentID: 2, Kind: PaymentRefund, Status: PaymentSucceeded, Amount: 1000},
+		{ID: 5, ParentID: 2, Kind: PaymentRefund, Status: PaymentPending, Amount: 500},
+		{ID: 6, ParentID: 2, Kind: PaymentRefund, Status: PaymentFailed, Amount: 2000},
+	}
+
+	assert.Equal(t, Price(3500), PaidAmount(pays))
+	assert.Equal(t, Price(0), PaidAmount(nil))
+
+	captures := RefundableCaptures(pays)
+	require.Len(t, captures, 1)
+	assert.Equal(t, int64(2), captures[0].ID)
+	assert.Equal(t, Price(3500), captures[0].Amount)
+
+	// fully refunded captures are not refundable
+	pays = append(pays, Payment{ID: 7, ParentID: 2, Kind: PaymentRefund, Status: PaymentSucceeded, Amount: 3500})
+	assert.Empty(t, RefundableCaptures(pays))
+}
+
+func TestReservation_RefundableAmount(t *testing.T) {
+	rsv := Reservation{TotalPrice: 10000, Status: ReservationConfirmed}
+	assert.Equal(t, Price(0), rsv.RefundableAmount(10000))
+
+	rsv.Status = ReservationCancelled
+	assert.Equal(t, Price(10000), rsv.RefundableAmount(10000))
+
+	rsv.CancellationFeePercent = 50
+	assert.Equal(t, Price(5000), rsv.RefundableAmount(10000))
+
+	// the fee exceeds the deposit paid
+	assert.Equal(t, Price(0), rsv.RefundableAmount(2000))
+}
+
+func TestReservation_StayPrice(t *testing.T) {
+	r := Reservation{
+		StartDate:  time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:    time.Date(2026, 5, 4, 0, 0, 0, 0, time.UTC),
+		Status:     ReservationConfirmed,
+		TotalPrice: 10700,
+		Discount:   1000,
+		Charges:    []ReservationCharge{{Name: "City Tax", Amount: 700}},
+	}
+
+	assert.Equal(t, 3, r.Nights())
+	assert.Equal(t, Price(11000), r.StayPrice())
+	assert.Equal(t, Price(10700), r.AmountCharged())
+
+	r.Status = ReservationCancelled
+	r.CancellationFeePercent = 50
+	assert.Equal(t, Price(5350), r.AmountCharged())
+}
+
+func TestInvoice_InvoiceNumber(t *testing.T) {
+	i := Invoice{Number: 42}
+	assert.Equal(t, app.InvoicePrefix+"-000042", i.InvoiceNumber())
+	assert.Equal(t, "invoice-"+app.InvoicePrefix+"-000042.html", i.Filename())
+}
+
+func TestFolioBalance(t *testing.T) {
+	entries := []FolioEntry{
+		{Kind: FolioCharge, Amount: 2400},
+		{Kind: FolioCharge, Amount: 1500, VoidedAt: time.Now()},
+		{Kind: FolioPayment, Amount: 1000},
+		{Kind: FolioPayment, Amount: 1400},
+	}
+
+	assert.Equal(t, Price(0), FolioBalance(entries))
+	assert.Equal(t, Price(2400), entries[0].Balance)
+	assert.Equal(t, Price(2400), entries[1].Balance)
+	assert.True(t, entries[1].IsVoided())
+	assert.Equal(t, Price(1400), entries[2].Balance)
+	assert.Equal(t, Price(0), entries[3].Balance)
+
+	assert.Equal(t, Price(0), FolioBalance(nil))
+}
+
+func TestRefund_IsOverride(t *testing.T) {
+	assert.False(t, (&Refund{PolicyAmount: 5000, Amount: 5000}).IsOverride())
+	assert.True(t, (&Refund{PolicyAmount: 5000, Amount: 10000}).IsOverride())
+}
+
+func TestCharge_AmountLabel(t *testing.T) {
+	tests := []struct {
+		charge Charge
+		label  string
+	}{
+		{charge: Charge{Kind: ChargePercent, Amount: 1700}, label: "17.00%"},
+		{charge: Charge{Kind: ChargePercent, Amount: 775}, label: "7.75%"},
+		{charge: Charge{Kind: ChargePerNight, Amount: 500}, label: "$5.00 per night"},
+		{charge: Charge{Kind: ChargePerPersonNight, Amount: 250}, label: "$2.50 per guest per night"},
+		{charge: Charge{Kind: ChargePerStay, Amount: 4000}, label: "$40.00"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.label, test.charge.AmountLabel())
+	}
+}
+
+func TestPromoCodeMessage(t *testing.T) {
+	promo := db.PromoCode{
+		Code:      "SUMMER",
+		ValidFrom: pgtype.Date{Time: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		ValidTo:   pgtype.Date{Time: time.Date(2024, 8, 31, 0, 0, 0, 0, time.UTC), Valid: true},
+		MinNights: 3,
+	}
+
+	assert.Equal(t, "Promo code WINTER does not exist.",
+		PromoCodeMessage(&db.PromoCodeError{Code: "WINTER", Rejection: db.RejectionNotFound}))
+	assert.Equal(t, "Promo code SUMMER is valid for arrivals between 2024-06-01 and 2024-08-31 only.",
+		PromoCodeMessage(&db.PromoCodeError{Code: "SUMMER", PromoCode: promo, Rejection: db.RejectionDates}))
+	assert.Equal(t, "Promo code SUMMER requires a minimum of 3 nights.",
+		PromoCodeMessage(&db.PromoCodeError{Code: "SUMMER", PromoCode: promo, Rejection: db.RejectionMinNights}))
+	assert.Equal(t, "Promo code SUMMER is not valid for all the rooms selected.",
+		PromoCodeMessage(&db.PromoCodeError{Code: "SUMMER", PromoCode: promo, Rejection: db.RejectionRoom}))
+	assert.Equal(t, "Promo code SUMMER is no longer available.",
+		PromoCodeMessage(&db.PromoCodeError{Code: "SUMMER", PromoCode: promo, Rejection: db.RejectionUsedUp}))
+}
+
+func TestCheckGuests(t *testing.T) {
+	// missing fields default to one adult and no children
+	form := forms.New(url.Values{})
+	assert.True(t, CheckGuests(form))
+	assert.Equal(t, "1", form.Get("adults"))
+	assert.Equal(t, "0", form.Get("children"))
+
+	form = forms.New(url.Values{"adults": {"2"}, "children": {"3"}})
+	assert.True(t, CheckGuests(form))
+
+	form = forms.New(url.Values{"adults": {"0"}})
+	assert.False(t, CheckGuests(form))
+	assert.NotEmpty(t, form.Errors.Get("adults"))
+
+	form = forms.New(url.Values{"children": {fmt.Sprint(MaxChildren + 1)}})
+	assert.False(t, CheckGuests(form))
+	assert.NotEmpty(t, form.Errors.Get("children"))
+}
+
+func TestWaitlistEntry_IsOfferValid(t *testing.T) {
+	now := time.Now()
+
+	// no room was offered yet
+	e := WaitlistEntry{}
+	assert.False(t, e.IsOfferValid(now))
+
+	e.OfferedRoomID = util.RandomID()
+	e.ExpiresAt = now.Add(time.Hour)
+	assert.True(t, e.IsOfferValid(now))
+
+	e.ExpiresAt = now.Add(-time.Hour)
+	assert.False(t, e.IsOfferValid(now))
+}
+
+func TestUser_IsActive(t *testing.T) {
+	u := User{}
+	assert.True(t, u.IsActive())
+
+	u.DeactivatedAt = time.Now()
+	assert.False(t, u.IsActive())
+}
+
+func TestUser_IsPasswordTokenValid(t *testing.T) {
+	now := time.Now()
+
+	// no password link was sent yet
+	u := User{}
+	assert.False(t, u.IsPasswordTokenValid(now))
+
+	u.PasswordToken = util.RandomString(PasswordTokenLength)
+	u.PasswordTokenExpiresAt = now.Add(time.Hour)
+	assert.True(t, u.IsPasswordTokenValid(now))
+
+	u.PasswordTokenExpiresAt = now.Add(-time.Hour)
+	assert.False(t, u.IsPasswordTokenValid(now))
+}
+
+func TestFirstOfMonth(t *testing.T) {
+	date := time.Date(2026, time.October, 17, 15, 30, 0, 0, time.Local)
+	assert.Equal(t, time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC), FirstOfMonth(date))
+}
+
+func TestNewCalendar(t *testing.T) {
+	rooms := []Room{randomRoom(), randomRoom()}
+	rr := RoomRestriction{
+		ID:            util.RandomID(),
+		StartDate:     time.Date(2026, time.October, 30, 0, 0, 0, 0, time.UTC),
+		EndDate:       time.Date(2026, time.November, 2, 0, 0, 0, 0, time.UTC),
+		RoomID:        rooms[0].ID,
+		ReservationID: util.RandomID(),
+		Restriction:   RestrictionReservation,
+	}
+
+	c := NewCalendar(time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC), rooms, []RoomRestriction{rr})
+	assert.Equal(t, time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC), c.Month)
+	require.Len(t, c.Days, 31)
+	require.Len(t, c.Rows, len(rooms))
+
+	for i, row := range c.Rows {
+		assert.Equal(t, rooms[i], row.Room)
+		require.Len(t, row.Cells, len(c.Days))
+		for j, cell := range row.Cells {
+			assert.Equal(t, c.Days[j], cell.Date)
+
+			// the restriction covers the nights of October 30 and 31 of the first room only
+			if i == 0 && j >= 29 {
+				assert.Equal(t, rr, cell.Restriction)
+			} else {
+				assert.Zero(t, cell.Restriction.ID)
+			}
+		}
+	}
+
+	// the departure night is free
+	c = NewCalendar(rr.EndDate, rooms, []RoomRestriction{rr})
+	assert.Equal(t, rr.ID, c.Rows[0].Cells[0].Restriction.ID)
+	assert.Zero(t, c.Rows[0].Cells[1].Restriction.ID)
+}
+
+func TestCalendar_PrevAndNext(t *testing.T) {
+	c := Calendar{Month: time.Date(2026, time.December, 1, 0, 0, 0, 0, time.UTC)}
+	assert.Equal(t, "2026-11", c.Prev())
+	assert.Equal(t, "2027-01", c.Next())
+
+	c = Calendar{Month: time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)}
+	assert.Equal(t, "2026-12", c.Prev())
+	assert.Equal(t, "2027-02", c.Next())
+}
+
+func TestCalendarCell_IsWeekend(t *testing.T) {
+	// October 16, 2026 is a Friday
+	friday := time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)
+	assert.True(t, CalendarCell{Date: friday}.IsWeekend())
+	assert.True(t, CalendarCell{Date: friday.AddDate(0, 0, 1)}.IsWeekend())
+	assert.False(t, CalendarCell{Date: friday.AddDate(0, 0, 2)}.IsWeekend())
+	assert.False(t, CalendarCell{Date: friday.AddDate(0, 0, -1)}.IsWeekend())
+}
+
+func TestCheckOwnerBlockDates(t *testing.T) {
+	form := forms.New(url.Values{"start_date": {"2026-10-17"}, "end_date": {"2026-10-18"}})
+	assert.True(t, CheckOwnerBlockDates(form))
+	assert.True(t, form.Valid())
+
+	form = forms.New(url.Values{"start_date": {"2026-10-17"}, "end_date": {"2026-10-17"}})
+	assert.False(t, CheckOwnerBlockDates(form))
+	assert.Equal(t, "The room must be blocked for at least one night.", form.Errors.Get("end_date"))
+
+	form = forms.New(url.Values{"start_date": {"2026-10-17"}})
+	assert.False(t, CheckOwnerBlockDates(form))
+	assert.NotEmpty(t, form.Errors.Get("end_date"))
+}
+
+func TestSaveRoomImage(t *testing.T) {
+	// save the image to a temporary static directory
+	staticPath := app.StaticPath
+	app.StaticPath = t.TempDir()
+	defer func() { app.StaticPath = staticPath }()
+
+	image := []byte("\x89PNG\r\n\x1a\n0000")
+
+	filename, err := saveRoomImage("garden-suite", image)
+	require.NoError(t, err)
+	assert.Regexp(t, `^garden-suite-[a-z]{8}\.png$`, filename)
+
+	saved, err := os.ReadFile(filepath.Join(app.StaticPath, RoomImagesDirectory, filename))
+	require.NoError(t, err)
+	assert.Equal(t, image, saved)
+
+	// a new image of the same room is saved under a new filename
+	other, err := saveRoomImage("garden-suite", image)
+	require.NoError(t, err)
+	assert.NotEqual(t, filename, other)
+}
@@ -78,6 +78,7 @@ func InitializeApp(appMode config.AppMode) error {
 	gob.Register(Room{})
 	gob.Register([]Room{})
 	gob.Register(Reservation{})
+	gob.Register([]Reservation{})
 	gob.Register(RoomRestriction{})
 
 	return nil
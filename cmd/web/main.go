@@ -11,6 +11,7 @@ import (
 	"github.com/github-real-lb/bookings-web-app/util/config"
 	"github.com/github-real-lb/bookings-web-app/util/loggers"
 	"github.com/github-real-lb/bookings-web-app/util/mailers"
+	"github.com/github-real-lb/bookings-web-app/util/payments"
 )
 
 func main() {
@@ -36,8 +37,14 @@ func main() {
 	// create a new mailer
 	mailer := mailers.NewSmartMailer()
 
+	// create a new payment provider
+	payer, err := payments.NewProvider(app.PaymentProvider, app.PaymentWebhookSecret)
+	if err != nil {
+		log.Fatal("Error creating payment provider:", err)
+	}
+
 	// create a new server
-	server := NewServer(dbStore, errLogger, infoLogger, mailer)
+	server := NewServer(dbStore, errLogger, infoLogger, mailer, payer)
 
 	// load web page templates cache
 	err = server.Renderer.LoadGoHtmlPageTemplates()
@@ -51,6 +58,18 @@ func main() {
 		log.Fatal(fmt.Sprint("error creating gohtml mail templates cache: ", err.Error()))
 	}
 
+	// load invoice templates cache
+	err = server.Renderer.LoadGoHtmlInvoiceTemplates()
+	if err != nil {
+		log.Fatal(fmt.Sprint("error creating gohtml invoice templates cache: ", err.Error()))
+	}
+
+	// load exchange rates cache
+	err = server.LoadExchangeRates()
+	if err != nil {
+		log.Fatal(fmt.Sprint("error loading exchange rates: ", err.Error()))
+	}
+
 	// start server in a separate goroutine
 	go server.Start()
 
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/github-real-lb/bookings-web-app/util/limiters"
 	"github.com/justinas/nosurf"
 )
 
@@ -18,6 +19,9 @@ func NoSurf(next http.Handler) http.Handler {
 		SameSite: http.SameSiteLaxMode,
 	})
 
+	// payment provider webhooks are authenticated by their signature
+	csrfHandler.ExemptPath("/payments/webhook")
+
 	return csrfHandler
 }
 
@@ -45,3 +49,40 @@ func Auth(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// RequireRole is a middleware that restricts access to staff users with role, or with a role of more access.
+// It follows Auth, and reads the access level put in the session on login.
+func RequireRole(role Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			level := Role(app.Session.GetInt64(r.Context(), "access_level"))
+			if !level.Valid() {
+				app.Session.Put(r.Context(), "error", "Access denied. Please log in again.")
+				http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+				return
+			}
+
+			if !level.HasAccess(role) {
+				app.Session.Put(r.Context(), "error", fmt.Sprintf("Access denied. The %s role cannot access this page.", level.Label()))
+				http.Redirect(w, r, "/admin/dashboard", http.StatusSeeOther)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RateLimit is a middleware that restrict the number of requests a client can make using limiter
+func RateLimit(limiter limiters.Limiterer) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !limiter.Allow(ClientIP(r)) {
+				http.Error(w, "Too many attempts. Please try again later.", http.StatusTooManyRequests)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/github-real-lb/bookings-web-app/util/limiters"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -62,3 +64,70 @@ func TestAuth(t *testing.T) {
 	})
 
 }
+
+func TestRequireRole(t *testing.T) {
+	h := RequireRole(RoleManager)(&testHandler{})
+	assert.Implements(t, (*http.Handler)(nil), h)
+
+	ts := NewTestServer(t)
+
+	t.Run("No Access Level", func(t *testing.T) {
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/", nil)
+		recorder := httptest.NewRecorder()
+
+		app.Session.Remove(req.Context(), "access_level")
+
+		h.ServeHTTP(recorder, req)
+		v, ok := app.Session.Pop(req.Context(), "error").(string)
+		assert.True(t, ok)
+		assert.Equal(t, "Access denied. Please log in again.", v)
+		assert.Equal(t, http.StatusSeeOther, recorder.Code)
+		assert.Equal(t, "/user/login", recorder.Header().Get("Location"))
+	})
+
+	t.Run("Insufficient Role", func(t *testing.T) {
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/", nil)
+		recorder := httptest.NewRecorder()
+
+		app.Session.Put(req.Context(), "access_level", int64(RoleFrontDesk))
+
+		h.ServeHTTP(recorder, req)
+		v, ok := app.Session.Pop(req.Context(), "error").(string)
+		assert.True(t, ok)
+		assert.Equal(t, "Access denied. The Front Desk role cannot access this page.", v)
+		assert.Equal(t, http.StatusSeeOther, recorder.Code)
+		assert.Equal(t, "/admin/dashboard", recorder.Header().Get("Location"))
+	})
+
+	t.Run("Sufficient Role", func(t *testing.T) {
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/", nil)
+		recorder := httptest.NewRecorder()
+
+		h.ServeHTTP(recorder, req)
+		ok := app.Session.Exists(req.Context(), "error")
+		assert.False(t, ok)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+}
+
+func TestRateLimit(t *testing.T) {
+	limiter := limiters.NewSmartLimiter(1, time.Minute)
+	h := RateLimit(limiter)(&testHandler{})
+	assert.Implements(t, (*http.Handler)(nil), h)
+
+	t.Run("Within Limit", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		recorder := httptest.NewRecorder()
+
+		h.ServeHTTP(recorder, req)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("Exceeds Limit", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		recorder := httptest.NewRecorder()
+
+		h.ServeHTTP(recorder, req)
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	})
+}
@@ -25,9 +25,13 @@ type TemplateData struct {
 	Form *forms.Form
 
 	IsAuthenticated bool // Determines if a user is logged in
+	AccessLevel     Role // Role of the user logged in
 
 	Listing Listing // Data of the property
 
+	Currency   ExchangeRate   // Display currency of the session
+	Currencies []ExchangeRate // Display currencies the guest can choose from
+
 	Error   string // Error message
 	Flash   string // Success message
 	Warning string // Warning message
@@ -48,24 +52,264 @@ type Reservation struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 	Room      Room      `json:"room"`
+
+	CancelledAt            time.Time `json:"cancelled_at"`
+	CancelledBy            string    `json:"cancelled_by"`
+	CancellationFeePercent int       `json:"cancellation_fee_percent"`
+
+	// ParentCode is the code shared by all reservations booked together
+	ParentCode string `json:"parent_code"`
+
+	Adults   int `json:"adults"`
+	Children int `json:"children"`
+
+	Status       ReservationStatus `json:"status"`
+	ConfirmedAt  time.Time         `json:"confirmed_at"`
+	CheckedInAt  time.Time         `json:"checked_in_at"`
+	CheckedOutAt time.Time         `json:"checked_out_at"`
+	NoShowAt     time.Time         `json:"no_show_at"`
+
+	// TotalPrice is the price of the stay quoted when the reservation was made,
+	// less the Discount of promo code PromoCodeID if any, plus the taxes and fees itemised in Charges
+	TotalPrice  Price               `json:"total_price"`
+	PromoCodeID int64               `json:"promo_code_id"`
+	Discount    Price               `json:"discount"`
+	Charges     []ReservationCharge `json:"charges"`
+}
+
+// ReservationCharge holds a tax or fee charged on a reservation
+type ReservationCharge struct {
+	ID            int64     `json:"id"`
+	ReservationID int64     `json:"reservation_id"`
+	ChargeID      int64     `json:"charge_id"`
+	Name          string    `json:"name"`
+	Amount        Price     `json:"amount"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Price is an amount of money in cents
+type Price int64
+
+// ReservationStatus is the database reservation_status enum
+type ReservationStatus db.ReservationStatus
+
+const (
+	ReservationPending    ReservationStatus = ReservationStatus(db.ReservationStatusPending)
+	ReservationConfirmed  ReservationStatus = ReservationStatus(db.ReservationStatusConfirmed)
+	ReservationCheckedIn  ReservationStatus = ReservationStatus(db.ReservationStatusCheckedIn)
+	ReservationCheckedOut ReservationStatus = ReservationStatus(db.ReservationStatusCheckedOut)
+	ReservationCancelled  ReservationStatus = ReservationStatus(db.ReservationStatusCancelled)
+	ReservationNoShow     ReservationStatus = ReservationStatus(db.ReservationStatusNoShow)
+)
+
+// ReservationStatuses lists all reservation statuses in the order of the reservation lifecycle
+var ReservationStatuses = []ReservationStatus{
+	ReservationPending,
+	ReservationConfirmed,
+	ReservationCheckedIn,
+	ReservationCheckedOut,
+	ReservationCancelled,
+	ReservationNoShow,
 }
 
 // Room holds hotel room data
 type Room struct {
 	ID            int64     `json:"id"`
 	Name          string    `json:"name"`
+	Slug          string    `json:"slug"`
 	Description   string    `json:"description"`
 	ImageFilename string    `json:"image_filename"`
+	MaxAdults     int       `json:"max_adults"`
+	MaxChildren   int       `json:"max_children"`
+	MaxOccupancy  int       `json:"max_occupancy"`
+	NightlyRate   Price     `json:"nightly_rate"`
 	CreatedAt     time.Time `json:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at"`
 }
 
+// Guests holds the number of adults and children staying in a room
+type Guests struct {
+	Adults   int `json:"adults"`
+	Children int `json:"children"`
+}
+
+// RoomRate holds the rate of a room for the nights of a date range, on some days of the week.
+// The rate of the highest priority applies when several rates apply to a night.
+type RoomRate struct {
+	ID         int64     `json:"id"`
+	RoomID     int64     `json:"room_id"`
+	Name       string    `json:"name"`
+	StartDate  time.Time `json:"start_date"`
+	EndDate    time.Time `json:"end_date"`
+	DaysOfWeek int       `json:"days_of_week"`
+	Rate       Price     `json:"rate"`
+	Priority   int       `json:"priority"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	Room       Room      `json:"room"`
+}
+
+// NightlyRate holds the rate of a single night of a stay
+type NightlyRate struct {
+	Date time.Time `json:"date"`
+	Rate Price     `json:"rate"`
+}
+
+// QuoteCharge holds a tax or fee charged on a stay
+type QuoteCharge struct {
+	Name   string `json:"name"`
+	Amount Price  `json:"amount"`
+}
+
+// Quote holds the price of a stay in a room
+type Quote struct {
+	Room     Room          `json:"room"`
+	Nights   []NightlyRate `json:"nights"`
+	Subtotal Price         `json:"subtotal"`
+	Discount Price         `json:"discount"`
+	Charges  []QuoteCharge `json:"charges"`
+	Total    Price         `json:"total"`
+}
+
+// ChargeKind is the database charge_kind enum
+type ChargeKind db.ChargeKind
+
+const (
+	ChargePercent        ChargeKind = ChargeKind(db.ChargeKindPercent)
+	ChargePerNight       ChargeKind = ChargeKind(db.ChargeKindPerNight)
+	ChargePerPersonNight ChargeKind = ChargeKind(db.ChargeKindPerPersonNight)
+	ChargePerStay        ChargeKind = ChargeKind(db.ChargeKindPerStay)
+)
+
+// ChargeKinds lists all charge kinds
+var ChargeKinds = []ChargeKind{
+	ChargePercent,
+	ChargePerNight,
+	ChargePerPersonNight,
+	ChargePerStay,
+}
+
+// Charge holds a tax or fee added to the price of every stay.
+// Amount is in hundredths of a percent for percent charges, and in cents otherwise.
+type Charge struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Kind      ChargeKind `json:"kind"`
+	Amount    int64      `json:"amount"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// PaymentKind is the database payment_kind enum
+type PaymentKind db.PaymentKind
+
+const (
+	PaymentAuthorization PaymentKind = PaymentKind(db.PaymentKindAuthorization)
+	PaymentCapture       PaymentKind = PaymentKind(db.PaymentKindCapture)
+	PaymentRefund        PaymentKind = PaymentKind(db.PaymentKindRefund)
+)
+
+// PaymentStatus is the database payment_status enum
+type PaymentStatus db.PaymentStatus
+
+const (
+	PaymentPending   PaymentStatus = PaymentStatus(db.PaymentStatusPending)
+	PaymentSucceeded PaymentStatus = PaymentStatus(db.PaymentStatusSucceeded)
+	PaymentFailed    PaymentStatus = PaymentStatus(db.PaymentStatusFailed)
+)
+
+// Payment holds a payment transaction of a reservation made through a payment provider.
+// ParentID is the authorization of a capture, or the capture of a refund, and RefundID is the refund of a refund transaction.
+type Payment struct {
+	ID            int64         `json:"id"`
+	ReservationID int64         `json:"reservation_id"`
+	ParentID      int64         `json:"parent_id"`
+	RefundID      int64         `json:"refund_id"`
+	Provider      string        `json:"provider"`
+	ProviderRef   string        `json:"provider_ref"`
+	Kind          PaymentKind   `json:"kind"`
+	Status        PaymentStatus `json:"status"`
+	Amount        Price         `json:"amount"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+}
+
+// Refund holds a refund of a reservation, which is made of one or more refund transactions.
+// PolicyAmount is the amount refundable according to the cancellation policy,
+// and Reason explains why staff member UserID refunded a different Amount.
+// UserID is zero for refunds of guest cancellations.
+type Refund struct {
+	ID            int64     `json:"id"`
+	ReservationID int64     `json:"reservation_id"`
+	Paid          Price     `json:"paid"`
+	PolicyAmount  Price     `json:"policy_amount"`
+	Amount        Price     `json:"amount"`
+	Reason        string    `json:"reason"`
+	UserID        int64     `json:"user_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Invoice holds the invoice of a reservation.
+// Number is sequential for the Property, and Document is the invoice rendered when issued or last regenerated.
+type Invoice struct {
+	ID            int64     `json:"id"`
+	Property      string    `json:"property"`
+	Number        int64     `json:"number"`
+	ReservationID int64     `json:"reservation_id"`
+	Document      string    `json:"document"`
+	IssuedAt      time.Time `json:"issued_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// FolioEntryKind is the database folio_entry_kind enum
+type FolioEntryKind db.FolioEntryKind
+
+const (
+	FolioCharge  FolioEntryKind = FolioEntryKind(db.FolioEntryKindCharge)
+	FolioPayment FolioEntryKind = FolioEntryKind(db.FolioEntryKindPayment)
+)
+
+// FolioEntryKinds lists all folio entry kinds
+var FolioEntryKinds = []FolioEntryKind{
+	FolioCharge,
+	FolioPayment,
+}
+
+// FolioEntry holds a charge or a payment posted by staff member UserID to the folio of a reservation.
+// Entries voided by staff member VoidedBy stay in the folio but no longer count in its balance.
+// Balance is the running balance of the folio after the entry, set by FolioBalance.
+type FolioEntry struct {
+	ID            int64          `json:"id"`
+	ReservationID int64          `json:"reservation_id"`
+	Kind          FolioEntryKind `json:"kind"`
+	Description   string         `json:"description"`
+	Amount        Price          `json:"amount"`
+	UserID        int64          `json:"user_id"`
+	VoidedAt      time.Time      `json:"voided_at"`
+	VoidedBy      int64          `json:"voided_by"`
+	CreatedAt     time.Time      `json:"created_at"`
+	Balance       Price          `json:"-"`
+}
+
+// RateScale is the scale of exchange rates, which are stored in millionths
+const RateScale = 1_000_000
+
+// ExchangeRate holds the rate at which prices in the base currency are converted for display in Currency.
+// Rate is in millionths of Currency per unit of the base currency.
+type ExchangeRate struct {
+	Currency  string    `json:"currency"`
+	Symbol    string    `json:"symbol"`
+	Rate      int64     `json:"rate"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
 // Restriction is the database restriction enum
 type Restriction db.Restriction
 
 const (
 	RestrictionReservation Restriction = Restriction(db.RestrictionReservation)
 	RestrictionOwnerBlock  Restriction = Restriction(db.RestrictionOwnerBlock)
+	RestrictionHold        Restriction = Restriction(db.RestrictionHold)
 )
 
 func (r *Restriction) Scan(src any) error {
@@ -90,16 +334,81 @@ type RoomRestriction struct {
 	Restriction   Restriction `json:"restriction"`
 	CreatedAt     time.Time   `json:"created_at"`
 	UpdatedAt     time.Time   `json:"updated_at"`
+
+	HoldToken string    `json:"hold_token"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Reason    string    `json:"reason"`
+	Room      Room      `json:"room"`
+}
+
+// Calendar holds the nights of a month of every room, as shown on the admin calendar
+type Calendar struct {
+	Month time.Time     `json:"month"`
+	Days  []time.Time   `json:"days"`
+	Rows  []CalendarRow `json:"rows"`
 }
 
-// User holds user data
+// CalendarRow holds the nights of a month of a room
+type CalendarRow struct {
+	Room  Room           `json:"room"`
+	Cells []CalendarCell `json:"cells"`
+}
+
+// CalendarCell holds a night of a room, and the restriction of the room on that night if any
+type CalendarCell struct {
+	Date        time.Time       `json:"date"`
+	Restriction RoomRestriction `json:"restriction"`
+}
+
+// WaitlistEntry holds the data of a guest waiting for a room to become available
+type WaitlistEntry struct {
+	ID            int64     `json:"id"`
+	Token         string    `json:"token"`
+	FirstName     string    `json:"first_name"`
+	LastName      string    `json:"last_name"`
+	Email         string    `json:"email"`
+	StartDate     time.Time `json:"start_date"`
+	EndDate       time.Time `json:"end_date"`
+	RoomID        int64     `json:"room_id"`
+	Adults        int       `json:"adults"`
+	Children      int       `json:"children"`
+	OfferedRoomID int64     `json:"offered_room_id"`
+	NotifiedAt    time.Time `json:"notified_at"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// User holds staff user data
 type User struct {
-	ID          int64     `json:"id"`
-	FirstName   string    `json:"first_name"`
-	LastName    string    `json:"last_name"`
-	Email       string    `json:"email"`
-	Password    string    `json:"password"`
-	AccessLevel int64     `json:"access_level"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                     int64     `json:"id"`
+	FirstName              string    `json:"first_name"`
+	LastName               string    `json:"last_name"`
+	Email                  string    `json:"email"`
+	Password               string    `json:"password"`
+	AccessLevel            Role      `json:"access_level"`
+	DeactivatedAt          time.Time `json:"deactivated_at"`
+	PasswordToken          string    `json:"password_token"`
+	PasswordTokenExpiresAt time.Time `json:"password_token_expires_at"`
+	CreatedAt              time.Time `json:"created_at"`
+	UpdatedAt              time.Time `json:"updated_at"`
+}
+
+// Role is the role of a staff user, stored as the user access level.
+// A lower access level has more access, and has the access of all the roles with a higher access level.
+type Role int64
+
+const (
+	RoleOwner     Role = 1 // manages the staff users
+	RoleManager   Role = 2 // manages rooms, rates, taxes and fees, currencies, owner blocks and refunds
+	RoleFrontDesk Role = 3 // manages reservations, check-ins, folios and invoices
+	RoleReadOnly  Role = 4 // views the admin panels
+)
+
+// Roles lists all roles from the most to the least access
+var Roles = []Role{
+	RoleOwner,
+	RoleManager,
+	RoleFrontDesk,
+	RoleReadOnly,
 }
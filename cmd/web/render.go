@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -94,6 +95,7 @@ func addDefaultData(td *TemplateData, r *http.Request) {
 
 	// set login status
 	td.IsAuthenticated = IsAuthenticated(r)
+	td.AccessLevel = Role(app.Session.GetInt64(r.Context(), "access_level"))
 
 	// add listing information
 	td.Listing = app.Listing
@@ -138,10 +140,16 @@ func (hr *GoHtmlRenderer) RenderGoHtmlMailTemplate(gohtml string, td *TemplateDa
 	return string(data), nil
 }
 
-// CreateReservationNotificationMail creates reservation confirmation mail
-func (hr *GoHtmlRenderer) CreateReservationConfirmationMail(r Reservation) (mailers.MailData, error) {
+// CreateReservationNotificationMail creates reservation confirmation mail.
+// It lists the rooms of all reservations passed, which must be booked together by the same guest.
+func (hr *GoHtmlRenderer) CreateReservationConfirmationMail(rsvs ...Reservation) (mailers.MailData, error) {
 	var err error
 
+	if len(rsvs) == 0 {
+		return mailers.MailData{}, errors.New("no reservation to confirm")
+	}
+	r := rsvs[0]
+
 	// create reservation confirmation email
 	data := mailers.MailData{
 		To:      r.Email,
@@ -151,9 +159,184 @@ func (hr *GoHtmlRenderer) CreateReservationConfirmationMail(r Reservation) (mail
 
 	data.Content, err = hr.RenderGoHtmlMailTemplate("reservation-confirmation.mail.gohtml", &TemplateData{
 		Data: map[string]any{
-			"start_date":  r.StartDate.Format(config.DateLayout),
-			"end_date":    r.EndDate.Format(config.DateLayout),
-			"reservation": r,
+			"start_date":   r.StartDate.Format(config.DateLayout),
+			"end_date":     r.EndDate.Format(config.DateLayout),
+			"reservation":  r,
+			"reservations": rsvs,
+			"per_room":     len(rsvs) > 1,
+			"total_price":  TotalPrice(rsvs),
+			"discount":     TotalDiscount(rsvs),
+		},
+	})
+
+	return data, err
+}
+
+// CreateReservationCancellationMail creates reservation cancellation mail
+func (hr *GoHtmlRenderer) CreateReservationCancellationMail(r Reservation) (mailers.MailData, error) {
+	var err error
+
+	// create reservation cancellation email
+	data := mailers.MailData{
+		To:      r.Email,
+		From:    app.Listing.Email,
+		Subject: fmt.Sprintf("Cancellation Notice for Reservation %s", r.Code),
+	}
+
+	data.Content, err = hr.RenderGoHtmlMailTemplate("reservation-cancellation.mail.gohtml", &TemplateData{
+		Data: map[string]any{
+			"start_date":   r.StartDate.Format(config.DateLayout),
+			"end_date":     r.EndDate.Format(config.DateLayout),
+			"cancelled_at": r.CancelledAt.Format(config.DateLayout),
+			"reservation":  r,
+		},
+	})
+
+	return data, err
+}
+
+// CreateWaitlistOfferMail creates the mail offering room to the guest of waitlist entry e, with a time-limited booking link
+func (hr *GoHtmlRenderer) CreateWaitlistOfferMail(e WaitlistEntry, room Room) (mailers.MailData, error) {
+	var err error
+
+	// create waitlist offer email
+	data := mailers.MailData{
+		To:      e.Email,
+		From:    app.Listing.Email,
+		Subject: fmt.Sprintf("%s Is Now Available for Your Dates", room.Name),
+	}
+
+	data.Content, err = hr.RenderGoHtmlMailTemplate("waitlist-offer.mail.gohtml", &TemplateData{
+		Data: map[string]any{
+			"start_date": e.StartDate.Format(config.DateLayout),
+			"end_date":   e.EndDate.Format(config.DateLayout),
+			"expires_at": e.ExpiresAt.Format(config.DateLayout + " 15:04"),
+			"link":       fmt.Sprintf("http://%s/waitlist/book/%s", app.ServerAddress, e.Token),
+			"entry":      e,
+			"room":       room,
+		},
+	})
+
+	return data, err
+}
+
+// CreatePasswordMail creates the mail with the time-limited link for staff user u to set a password.
+// The mail invites the user to the staff if invited is true, otherwise it resets the user password.
+func (hr *GoHtmlRenderer) CreatePasswordMail(u User, invited bool) (mailers.MailData, error) {
+	var err error
+
+	// create password email
+	data := mailers.MailData{
+		To:      u.Email,
+		From:    app.Listing.Email,
+		Subject: fmt.Sprintf("Reset Your %s Password", app.Listing.Name),
+	}
+	if invited {
+		data.Subject = fmt.Sprintf("You Are Invited to Join the %s Staff", app.Listing.Name)
+	}
+
+	data.Content, err = hr.RenderGoHtmlMailTemplate("user-password.mail.gohtml", &TemplateData{
+		Data: map[string]any{
+			"expires_at": u.PasswordTokenExpiresAt.Format(config.DateLayout + " 15:04"),
+			"link":       fmt.Sprintf("http://%s/user/password/%s", app.ServerAddress, u.PasswordToken),
+			"invited":    invited,
+			"user":       u,
+		},
+		Listing: app.Listing,
+	})
+
+	return data, err
+}
+
+// LoadGoHtmlInvoiceTemplates loads all invoice document templates
+func (hr *GoHtmlRenderer) LoadGoHtmlInvoiceTemplates() error {
+	// Load invoice gohtml templates
+	path := fmt.Sprintf("%s/%s", app.TemplatePath, "invoices")
+	basePattern := "base.layout.gohtml"
+	tmplPattern := "*.invoice.gohtml"
+
+	err := hr.LoadTemplates(path, basePattern, tmplPattern)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RenderGoHtmlInvoiceTemplate execute an invoice document gohtml template
+func (hr *GoHtmlRenderer) RenderGoHtmlInvoiceTemplate(gohtml string, td *TemplateData) (string, error) {
+	var err error
+
+	// load Templates from disk in developement mode in order to allow template updates on runtime.
+	if app.InDevelopmentMode() {
+		err = hr.LoadGoHtmlInvoiceTemplates()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// render template
+	data, err := hr.RenderTemplate(gohtml, td)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// CreateInvoiceDocument renders the invoice document of invoice i for reservation r,
+// itemising the stay, its taxes and fees, and the payments made
+func (hr *GoHtmlRenderer) CreateInvoiceDocument(i Invoice, r Reservation, pays []Payment) (string, error) {
+	// list the payments received and refunded
+	var lines []Payment
+	for _, p := range pays {
+		if (p.Kind == PaymentCapture && p.Status == PaymentSucceeded) || (p.Kind == PaymentRefund && p.Status != PaymentFailed) {
+			lines = append(lines, p)
+		}
+	}
+
+	paid := PaidAmount(pays)
+
+	return hr.RenderGoHtmlInvoiceTemplate("invoice.invoice.gohtml", &TemplateData{
+		Data: map[string]any{
+			"invoice":        i,
+			"invoice_number": i.InvoiceNumber(),
+			"issued_at":      i.IssuedAt.Format(config.DateLayout),
+			"start_date":     r.StartDate.Format(config.DateLayout),
+			"end_date":       r.EndDate.Format(config.DateLayout),
+			"reservation":    r,
+			"nights":         r.Nights(),
+			"stay_price":     r.StayPrice(),
+			"cancelled":      r.Status == ReservationCancelled,
+			"amount_charged": r.AmountCharged(),
+			"payments":       lines,
+			"paid":           paid,
+			"balance":        r.AmountCharged() - paid,
+		},
+		Listing: app.Listing,
+	})
+}
+
+// CreateInvoiceMail creates the mail sending invoice i of reservation r to the guest, with the invoice document attached
+func (hr *GoHtmlRenderer) CreateInvoiceMail(i Invoice, r Reservation) (mailers.MailData, error) {
+	var err error
+
+	// create invoice email
+	data := mailers.MailData{
+		To:      r.Email,
+		From:    app.Listing.Email,
+		Subject: fmt.Sprintf("Invoice %s for Reservation %s", i.InvoiceNumber(), r.Code),
+		Attachments: []mailers.Attachment{{
+			Name:     i.Filename(),
+			MimeType: "text/html",
+			Data:     []byte(i.Document),
+		}},
+	}
+
+	data.Content, err = hr.RenderGoHtmlMailTemplate("invoice.mail.gohtml", &TemplateData{
+		Data: map[string]any{
+			"invoice_number": i.InvoiceNumber(),
+			"reservation":    r,
 		},
 	})
 
@@ -5,7 +5,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/github-real-lb/bookings-web-app/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -115,4 +117,187 @@ func TestGoHtmlRenderer_CreateReservationConfirmationMail(t *testing.T) {
 	assert.Equal(t, app.Listing.Email, mailData.From)
 	assert.Equal(t, fmt.Sprintf("Confirmation Notice for Reservation %s", r.Code), mailData.Subject)
 	assert.NotEmpty(t, mailData.Content)
+
+	// test several reservations booked together
+	rsvs := []Reservation{r, randomReservation()}
+	mailData, err = hr.CreateReservationConfirmationMail(rsvs...)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("Confirmation Notice for Reservation %s", r.Code), mailData.Subject)
+	for _, rsv := range rsvs {
+		assert.Contains(t, mailData.Content, rsv.Code)
+		assert.Contains(t, mailData.Content, rsv.Room.Name)
+		assert.Contains(t, mailData.Content, rsv.TotalPrice.String())
+	}
+	assert.Contains(t, mailData.Content, TotalPrice(rsvs).String())
+
+	// test no reservations
+	_, err = hr.CreateReservationConfirmationMail()
+	assert.Error(t, err)
+}
+
+func TestGoHtmlRenderer_CreateReservationCancellationMail(t *testing.T) {
+	// create new renderer and load templates
+	hr := NewRenderer()
+	err := hr.LoadGoHtmlMailTemplates()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, hr.Templates)
+
+	// create random cancelled reservation
+	r := randomReservation()
+	r.CancelledAt = time.Now()
+	r.Status = ReservationCancelled
+	r.CancelledBy = "guest"
+	r.CancellationFeePercent = 50
+
+	mailData, err := hr.CreateReservationCancellationMail(r)
+	require.NoError(t, err)
+	assert.Equal(t, r.Email, mailData.To)
+	assert.Equal(t, app.Listing.Email, mailData.From)
+	assert.Equal(t, fmt.Sprintf("Cancellation Notice for Reservation %s", r.Code), mailData.Subject)
+	assert.Contains(t, mailData.Content, "50%")
+}
+
+func TestGoHtmlRenderer_CreateWaitlistOfferMail(t *testing.T) {
+	// create new renderer and load templates
+	hr := NewRenderer()
+	err := hr.LoadGoHtmlMailTemplates()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, hr.Templates)
+
+	// create random waitlist entry and the room offered
+	e := randomWaitlistEntry()
+	room := randomRoom()
+	room.ID = e.OfferedRoomID
+
+	mailData, err := hr.CreateWaitlistOfferMail(e, room)
+	require.NoError(t, err)
+	assert.Equal(t, e.Email, mailData.To)
+	assert.Equal(t, app.Listing.Email, mailData.From)
+	assert.Equal(t, fmt.Sprintf("%s Is Now Available for Your Dates", room.Name), mailData.Subject)
+	assert.Contains(t, mailData.Content, fmt.Sprintf("/waitlist/book/%s", e.Token))
+}
+
+func TestGoHtmlRenderer_CreatePasswordMail(t *testing.T) {
+	// create new renderer and load templates
+	hr := NewRenderer()
+	err := hr.LoadGoHtmlMailTemplates()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, hr.Templates)
+
+	// create random user with a password token
+	u := randomUser()
+	u.PasswordToken = util.RandomString(PasswordTokenLength)
+	u.PasswordTokenExpiresAt = time.Now().Add(PasswordTokenTTL)
+
+	t.Run("Invitation", func(t *testing.T) {
+		mailData, err := hr.CreatePasswordMail(u, true)
+		require.NoError(t, err)
+		assert.Equal(t, u.Email, mailData.To)
+		assert.Equal(t, app.Listing.Email, mailData.From)
+		assert.Equal(t, fmt.Sprintf("You Are Invited to Join the %s Staff", app.Listing.Name), mailData.Subject)
+		assert.Contains(t, mailData.Content, fmt.Sprintf("/user/password/%s", u.PasswordToken))
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		mailData, err := hr.CreatePasswordMail(u, false)
+		require.NoError(t, err)
+		assert.Equal(t, u.Email, mailData.To)
+		assert.Equal(t, fmt.Sprintf("Reset Your %s Password", app.Listing.Name), mailData.Subject)
+		assert.Contains(t, mailData.Content, fmt.Sprintf("/user/password/%s", u.PasswordToken))
+	})
+}
+
+func TestGoHtmlRenderer_LoadGoHtmlInvoiceTemplates(t *testing.T) {
+	hr := NewRenderer()
+	err := hr.LoadGoHtmlInvoiceTemplates()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, hr.Templates)
+}
+
+func TestGoHtmlRenderer_RenderGoHtmlInvoiceTemplate(t *testing.T) {
+	// create new renderer and load templates
+	hr := NewRenderer()
+	err := hr.LoadGoHtmlInvoiceTemplates()
+	assert.NoError(t, err)
+
+	// test ok on reloading templates cache (developement and testing modes)
+	app.SetDevelopementMode()
+	s, err := hr.RenderGoHtmlInvoiceTemplate("invoice.invoice.gohtml", &TemplateData{})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, s)
+
+	// test ok on using template cache (production modes)
+	app.SetTestingMode()
+	s, err = hr.RenderGoHtmlInvoiceTemplate("invoice.invoice.gohtml", &TemplateData{})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, s)
+
+	// test not ok on missing template
+	s, err = hr.RenderGoHtmlInvoiceTemplate("non-existing.invoice.gohtml", &TemplateData{})
+	assert.Error(t, err)
+	assert.Empty(t, s)
+}
+
+func TestGoHtmlRenderer_CreateInvoiceDocument(t *testing.T) {
+	// create new renderer and load templates
+	hr := NewRenderer()
+	err := hr.LoadGoHtmlInvoiceTemplates()
+	require.NoError(t, err)
+
+	invoice := randomInvoice()
+	capture := Payment{Kind: PaymentCapture, Status: PaymentSucceeded, ProviderRef: "fake_capt_000002", Amount: 2000}
+	failed := Payment{Kind: PaymentCapture, Status: PaymentFailed, ProviderRef: "fake_capt_000004", Amount: 2000}
+
+	t.Run("Stay", func(t *testing.T) {
+		r := randomReservation()
+		r.Status = ReservationConfirmed
+		r.Discount = 1000
+		r.Charges = []ReservationCharge{{Name: "City Tax", Amount: 700}}
+		r.TotalPrice = 10700
+
+		document, err := hr.CreateInvoiceDocument(invoice, r, []Payment{capture, failed})
+		require.NoError(t, err)
+		assert.Contains(t, document, invoice.InvoiceNumber())
+		assert.Contains(t, document, app.Listing.Name)
+		assert.Contains(t, document, r.Room.Name)
+		assert.Contains(t, document, "$110.00") // stay price
+		assert.Contains(t, document, "-$10.00")
+		assert.Contains(t, document, "City Tax")
+		assert.Contains(t, document, "$107.00")
+		assert.Contains(t, document, "fake_capt_000002")
+		assert.NotContains(t, document, "fake_capt_000004")
+		assert.Contains(t, document, "$87.00") // balance due
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		r := randomReservation()
+		r.Status = ReservationCancelled
+		r.CancellationFeePercent = 50
+		r.TotalPrice = 10000
+
+		document, err := hr.CreateInvoiceDocument(invoice, r, []Payment{capture})
+		require.NoError(t, err)
+		assert.Contains(t, document, "Cancellation fee of 50% of $100.00")
+		assert.Contains(t, document, "$30.00") // balance due
+	})
+}
+
+func TestGoHtmlRenderer_CreateInvoiceMail(t *testing.T) {
+	// create new renderer and load templates
+	hr := NewRenderer()
+	err := hr.LoadGoHtmlMailTemplates()
+	require.NoError(t, err)
+
+	invoice := randomInvoice()
+	r := randomReservation()
+
+	mailData, err := hr.CreateInvoiceMail(invoice, r)
+	require.NoError(t, err)
+	assert.Equal(t, r.Email, mailData.To)
+	assert.Equal(t, app.Listing.Email, mailData.From)
+	assert.Equal(t, fmt.Sprintf("Invoice %s for Reservation %s", invoice.InvoiceNumber(), r.Code), mailData.Subject)
+	assert.Contains(t, mailData.Content, invoice.InvoiceNumber())
+	require.Len(t, mailData.Attachments, 1)
+	assert.Equal(t, invoice.Filename(), mailData.Attachments[0].Name)
+	assert.Equal(t, []byte(invoice.Document), mailData.Attachments[0].Data)
 }
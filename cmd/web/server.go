@@ -3,15 +3,22 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/github-real-lb/bookings-web-app/db"
+	"github.com/github-real-lb/bookings-web-app/util"
+	"github.com/github-real-lb/bookings-web-app/util/config"
+	"github.com/github-real-lb/bookings-web-app/util/limiters"
 	"github.com/github-real-lb/bookings-web-app/util/loggers"
 	"github.com/github-real-lb/bookings-web-app/util/mailers"
+	"github.com/github-real-lb/bookings-web-app/util/payments"
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
 )
 
 const (
@@ -19,18 +26,36 @@ const (
 	MailerBufferSize = 100
 )
 
+const (
+	// LookupRateLimit sets the maximum number of reservation lookups a client can make in LookupRateWindow
+	LookupRateLimit = 5
+
+	// LookupRateWindow sets the duration in which LookupRateLimit applies
+	LookupRateWindow = 15 * time.Minute
+)
+
+// RoomHoldSweepInterval sets how often expired room holds are deleted
+const RoomHoldSweepInterval = time.Minute
+
 // Server handles all routing and provides all database functions
 type Server struct {
-	Router        *http.Server
-	Renderer      *GoHtmlRenderer
-	DatabaseStore db.DatabaseStore
-	ErrorLogger   loggers.Loggerer
-	InfoLogger    loggers.Loggerer
-	Mailer        mailers.Mailerer
+	Router          *http.Server
+	Renderer        *GoHtmlRenderer
+	DatabaseStore   db.DatabaseStore
+	ErrorLogger     loggers.Loggerer
+	InfoLogger      loggers.Loggerer
+	Mailer          mailers.Mailerer
+	Payments        payments.PaymentProvider
+	LookupLimiter   limiters.Limiterer
+	HoldSweeperDone chan struct{}
+
+	// exchangeRates caches the exchange rates of the display currencies, which are read on every page rendered
+	exchangeRates []ExchangeRate
+	ratesMutex    sync.RWMutex
 }
 
 // NewServer returns a new Server with Router and Database Store
-func NewServer(store db.DatabaseStore, errLogger loggers.Loggerer, infoLogger loggers.Loggerer, mailer mailers.Mailerer) *Server {
+func NewServer(store db.DatabaseStore, errLogger loggers.Loggerer, infoLogger loggers.Loggerer, mailer mailers.Mailerer, payer payments.PaymentProvider) *Server {
 	// create new router
 	mux := chi.NewRouter()
 
@@ -40,11 +65,14 @@ func NewServer(store db.DatabaseStore, errLogger loggers.Loggerer, infoLogger lo
 			Addr:    app.ServerAddress,
 			Handler: mux,
 		},
-		Renderer:      NewRenderer(),
-		DatabaseStore: store,
-		ErrorLogger:   errLogger,
-		InfoLogger:    infoLogger,
-		Mailer:        mailer,
+		Renderer:        NewRenderer(),
+		DatabaseStore:   store,
+		ErrorLogger:     errLogger,
+		InfoLogger:      infoLogger,
+		Mailer:          mailer,
+		Payments:        payer,
+		LookupLimiter:   limiters.NewSmartLimiter(LookupRateLimit, LookupRateWindow),
+		HoldSweeperDone: make(chan struct{}),
 	}
 
 	//add middleware that recover from panics
@@ -67,6 +95,7 @@ func NewServer(store db.DatabaseStore, errLogger loggers.Loggerer, infoLogger lo
 
 	mux.Get("/rooms/{index}", s.RoomsHandler)
 	mux.Get("/rooms/room/{name}", s.RoomHandler)
+	mux.Get("/rooms/room/{name}/prices", s.RoomPricesHandler)
 	mux.Post("/search-room-availability", s.PostSearchRoomAvailabilityHandler)
 
 	mux.Get("/contact", s.ContactHandler)
@@ -77,12 +106,32 @@ func NewServer(store db.DatabaseStore, errLogger loggers.Loggerer, infoLogger lo
 
 	mux.Get("/make-reservation", s.MakeReservationHandler)
 	mux.Post("/make-reservation", s.PostMakeReservationHandler)
+	mux.Post("/make-reservation/remove-room", s.PostRemoveCartRoomHandler)
 
 	mux.Get("/reservation-summary", s.ReservationSummaryHandler)
 
+	mux.Get("/find-reservation", s.FindReservationHandler)
+	mux.With(RateLimit(s.LookupLimiter)).Post("/find-reservation", s.PostFindReservationHandler)
+	mux.Get("/my-reservation", s.MyReservationHandler)
+	mux.Get("/my-reservation/cancel", s.CancelReservationHandler)
+	mux.Post("/my-reservation/cancel", s.PostCancelReservationHandler)
+	mux.Get("/my-reservation/change-dates", s.ChangeReservationDatesHandler)
+	mux.Post("/my-reservation/change-dates", s.PostChangeReservationDatesHandler)
+	mux.Get("/my-reservation/invoice", s.InvoiceHandler)
+
+	mux.Get("/waitlist", s.WaitlistHandler)
+	mux.Post("/waitlist", s.PostWaitlistHandler)
+	mux.Get("/waitlist/book/{token}", s.WaitlistBookingHandler)
+
+	mux.Post("/currency", s.PostCurrencyHandler)
+
+	mux.Post("/payments/webhook", s.PostPaymentWebhookHandler)
+
 	mux.Get("/user/login", s.LoginHandler)
 	mux.Post("/user/login", s.PostLoginHandler)
 	mux.Get("/user/logout", s.LogoutHandler)
+	mux.Get("/user/password/{token}", s.PasswordHandler)
+	mux.Post("/user/password/{token}", s.PostPasswordHandler)
 
 	// set up file server
 	fileServer := http.FileServer(http.Dir(app.StaticPath))
@@ -91,9 +140,57 @@ func NewServer(store db.DatabaseStore, errLogger loggers.Loggerer, infoLogger lo
 	// set up admin routes
 	mux.Route("/admin", func(mux chi.Router) {
 		mux.Use(Auth)
+		mux.Use(RequireRole(RoleReadOnly))
+
+		// all staff users can view the admin panels, and a role has the access of the roles below it
+		frontDesk := RequireRole(RoleFrontDesk)
+		manager := RequireRole(RoleManager)
+		owner := RequireRole(RoleOwner)
 
 		mux.Get("/dashboard", s.AdminDashboardHandler)
 		mux.Get("/reservations/{show}", s.AdminReservationsHandler)
+		mux.Get("/reservations/{id:[0-9]+}", s.AdminReservationHandler)
+		mux.With(frontDesk).Post("/reservations/{id:[0-9]+}", s.PostAdminReservationHandler)
+		mux.With(frontDesk).Post("/reservations/{id}/status", s.PostAdminReservationStatusHandler)
+		mux.With(frontDesk).Post("/reservations/{id}/check-in", s.PostAdminCheckInHandler)
+		mux.With(frontDesk).Post("/reservations/{id}/check-out", s.PostAdminCheckOutHandler)
+		mux.Get("/reservations/{id}/folio", s.AdminFolioHandler)
+		mux.With(frontDesk).Post("/reservations/{id}/folio", s.PostAdminFolioHandler)
+		mux.With(frontDesk).Post("/reservations/{id}/folio/{entry}/void", s.PostAdminVoidFolioEntryHandler)
+		mux.Get("/reservations/{id}/refund", s.AdminRefundHandler)
+		mux.Get("/reservations/{id}/invoice", s.AdminInvoiceHandler)
+		mux.With(frontDesk).Post("/reservations/{id}/invoice", s.PostAdminInvoiceHandler)
+		mux.With(frontDesk).Post("/reservations/{id}/invoice/send", s.PostAdminSendInvoiceHandler)
+		mux.With(manager).Post("/reservations/{id}/refund", s.PostAdminRefundHandler)
+		mux.Get("/today", s.AdminTodayHandler)
+		mux.Get("/calendar", s.AdminCalendarHandler)
+		mux.Get("/blocks", s.AdminOwnerBlocksHandler)
+		mux.With(manager).Post("/blocks", s.PostAdminOwnerBlocksHandler)
+		mux.With(manager).Post("/blocks/delete", s.PostAdminDeleteOwnerBlocksHandler)
+		mux.Get("/blocks/{id:[0-9]+}", s.AdminOwnerBlockHandler)
+		mux.With(manager).Post("/blocks/{id:[0-9]+}", s.PostAdminOwnerBlockHandler)
+		mux.Get("/rooms", s.AdminRoomsHandler)
+		mux.With(manager).Post("/rooms", s.PostAdminRoomsHandler)
+		mux.Get("/rooms/new", s.AdminNewRoomHandler)
+		mux.Get("/rooms/{id:[0-9]+}", s.AdminRoomHandler)
+		mux.With(manager).Post("/rooms/{id:[0-9]+}", s.PostAdminRoomHandler)
+		mux.With(manager).Post("/rooms/{id:[0-9]+}/delete", s.PostAdminDeleteRoomHandler)
+		mux.Get("/rates", s.AdminRoomRatesHandler)
+		mux.With(manager).Post("/rates", s.PostAdminRoomRatesHandler)
+		mux.With(manager).Post("/rates/delete", s.PostAdminDeleteRoomRatesHandler)
+		mux.Get("/charges", s.AdminChargesHandler)
+		mux.With(manager).Post("/charges", s.PostAdminChargesHandler)
+		mux.With(manager).Post("/charges/delete", s.PostAdminDeleteChargesHandler)
+		mux.Get("/currencies", s.AdminExchangeRatesHandler)
+		mux.With(manager).Post("/currencies", s.PostAdminExchangeRatesHandler)
+		mux.With(manager).Post("/currencies/delete", s.PostAdminDeleteExchangeRatesHandler)
+		mux.With(owner).Get("/users", s.AdminUsersHandler)
+		mux.With(owner).Post("/users", s.PostAdminUsersHandler)
+		mux.With(owner).Get("/users/{id:[0-9]+}", s.AdminUserHandler)
+		mux.With(owner).Post("/users/{id:[0-9]+}", s.PostAdminUserHandler)
+		mux.With(owner).Post("/users/{id:[0-9]+}/deactivate", s.PostAdminDeactivateUserHandler)
+		mux.With(owner).Post("/users/{id:[0-9]+}/reactivate", s.PostAdminReactivateUserHandler)
+		mux.With(owner).Post("/users/{id:[0-9]+}/reset", s.PostAdminResetUserHandler)
 	})
 
 	return &s
@@ -112,6 +209,9 @@ func (s *Server) Start() {
 	// start listening to mail data
 	go s.Mailer.ListenAndMail(s.ErrorLogger.MyLogChannel(), MailerBufferSize)
 
+	// start deleting expired room holds
+	go s.SweepRoomHolds(RoomHoldSweepInterval)
+
 	// start listening to http requests
 	err := s.Router.ListenAndServe()
 
@@ -143,6 +243,10 @@ func (s *Server) Stop() {
 	s.Mailer.Shutdown()
 	fmt.Print(".")
 
+	// stop deleting expired room holds
+	close(s.HoldSweeperDone)
+	fmt.Print(".")
+
 	// inform the server to stop accepting new requests
 	err := s.Router.Shutdown(ctx)
 	fmt.Print(".")
@@ -162,6 +266,411 @@ func (s *Server) Stop() {
 	}
 }
 
+// SweepRoomHolds deletes expired room holds every interval, until the server is stopped.
+// The rooms released are offered to the guests on the waitlist.
+func (s *Server) SweepRoomHolds(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.HoldSweeperDone:
+			return
+		case <-ticker.C:
+			holds, err := s.DeleteExpiredRoomHolds()
+			if err != nil {
+				s.LogError(ServerError{
+					Prompt: "Unable to delete expired room holds.",
+					Err:    err,
+				})
+				continue
+			}
+
+			if len(holds) > 0 {
+				s.LogInfo(fmt.Sprintf("HOLDS %d expired room holds deleted", len(holds)))
+			}
+
+			for _, hold := range holds {
+				s.OfferFreedRoom(hold.RoomID, hold.StartDate, hold.EndDate)
+			}
+		}
+	}
+}
+
+// OfferFreedRoom offers room roomID, freed between startDate and endDate, to the guests on the waitlist,
+// and mails every guest offered the room a time-limited booking link.
+// Errors are logged, as freeing the room must not fail because of the waitlist.
+func (s *Server) OfferFreedRoom(roomID int64, startDate, endDate time.Time) {
+	entries, err := s.NotifyWaitlist(roomID, startDate, endDate)
+	if err != nil {
+		s.LogError(ServerError{
+			Prompt: "Unable to notify waitlist.",
+			Err:    err,
+		})
+		return
+	}
+
+	if len(entries) == 0 {
+		return
+	}
+
+	room, err := s.GetRoom(roomID)
+	if err != nil {
+		s.LogError(ServerError{
+			Prompt: "Unable to get room offered to waitlist.",
+			Err:    err,
+		})
+		return
+	}
+
+	for _, entry := range entries {
+		data, err := s.Renderer.CreateWaitlistOfferMail(entry, room)
+		if err != nil {
+			s.LogError(ServerError{
+				Prompt: "Unable to render waitlist offer email.",
+				Err:    err,
+			})
+			continue
+		}
+
+		// send waitlist offer email to guest and log
+		s.SendMail(data)
+		s.LogInfo(fmt.Sprintf("MAIL waitlist offer sent to %s", data.To))
+	}
+}
+
+// MailPasswordLink gives user u a new password token, and mails the user a time-limited link to set a password.
+// The link invites the user to the staff if invited is true, otherwise it resets the user password.
+func (s *Server) MailPasswordLink(u User, invited bool) error {
+	u.PasswordToken = util.NewToken(PasswordTokenLength)
+	u.PasswordTokenExpiresAt = time.Now().Add(PasswordTokenTTL)
+
+	err := s.UpdateUserPasswordToken(u)
+	if err != nil {
+		return err
+	}
+
+	data, err := s.Renderer.CreatePasswordMail(u, invited)
+	if err != nil {
+		return err
+	}
+
+	// send password email to user and log
+	s.SendMail(data)
+	s.LogInfo(fmt.Sprintf("MAIL password link sent to %s", data.To))
+
+	return nil
+}
+
+// LogOutUser destroys all the sessions of the staff user with id,
+// so that deactivating the user or changing the user role takes effect immediately.
+// Errors are logged, as the sessions expire in any case.
+func (s *Server) LogOutUser(id int64) {
+	err := app.Session.Iterate(context.Background(), func(ctx context.Context) error {
+		if app.Session.GetInt64(ctx, "user_id") != id {
+			return nil
+		}
+
+		return app.Session.Destroy(ctx)
+	})
+	if err != nil {
+		s.LogError(ServerError{
+			Prompt: "Unable to log out user.",
+			Err:    err,
+		})
+	}
+}
+
+// AuthorizeDeposits authorizes the deposit of every quote on the card represented by token.
+// Deposits are authorized before the reservations of rsv are created, so that a declined card books no room.
+// It returns the authorization of every quote, which is empty if the quote has no deposit.
+func (s *Server) AuthorizeDeposits(rsv Reservation, quotes []Quote, token string) ([]payments.Transaction, error) {
+	auths := make([]payments.Transaction, len(quotes))
+	for i, quote := range quotes {
+		deposit := quote.Total.Percent(app.DepositPercent)
+		if deposit <= 0 {
+			continue
+		}
+
+		auth, err := s.authorizeDeposit(rsv, quote.Room, deposit, token)
+		if err != nil {
+			// release the deposits already authorized, as no room is booked
+			s.VoidDeposits(auths[:i])
+			return nil, err
+		}
+
+		auths[i] = auth
+	}
+
+	return auths, nil
+}
+
+// authorizeDeposit authorizes deposit for a stay of rsv in room on the card represented by token
+func (s *Server) authorizeDeposit(rsv Reservation, room Room, deposit Price, token string) (payments.Transaction, error) {
+	return s.Payments.Authorize(payments.AuthorizeRequest{
+		Amount:      int64(deposit),
+		Currency:    app.BaseCurrency.Code,
+		Token:       token,
+		Reference:   rsv.Code,
+		Description: fmt.Sprintf("Deposit for %s, %s to %s", room.Name, rsv.StartDate.Format(config.DateLayout), rsv.EndDate.Format(config.DateLayout)),
+	})
+}
+
+// VoidDeposits releases the deposit authorizations in auths that were not captured,
+// so that the card of a guest is not held when the booking fails.
+// Errors are logged, as the provider releases the authorizations when they expire in any case.
+func (s *Server) VoidDeposits(auths []payments.Transaction) {
+	for _, auth := range auths {
+		if auth.ID == "" {
+			continue
+		}
+
+		_, err := s.Payments.Void(auth.ID)
+		if err != nil {
+			s.LogError(ServerError{
+				Prompt: fmt.Sprintf("Unable to void deposit authorization %s.", auth.ID),
+				Err:    err,
+			})
+		}
+	}
+}
+
+// CaptureDeposits captures the deposit of every reservation in rsvs from its authorization in auths,
+// records the payment transactions, and confirms the reservation.
+// The deposit is taken from the total price saved with the reservation. If it differs from the amount authorized,
+// as the price was quoted again when booking, the authorization is voided and the deposit is authorized again
+// on the card represented by token, so that no part of an authorization is left held on the card.
+// Errors are logged and the reservation is left pending for staff to review, as the booking was already made.
+// It returns rsvs with the status of the reservations confirmed.
+func (s *Server) CaptureDeposits(rsvs []Reservation, auths []payments.Transaction, token string) []Reservation {
+	for i, rsv := range rsvs {
+		auth := auths[i]
+		if auth.ID == "" {
+			continue
+		}
+
+		deposit := rsv.TotalPrice.Percent(app.DepositPercent)
+		if deposit != Price(auth.Amount) {
+			s.VoidDeposits([]payments.Transaction{auth})
+			if deposit <= 0 {
+				continue
+			}
+
+			var err error
+			auth, err = s.authorizeDeposit(rsv, rsv.Room, deposit, token)
+			if err != nil {
+				s.LogError(ServerError{
+					Prompt: fmt.Sprintf("Unable to authorize deposit of reservation %s.", rsv.Code),
+					Err:    err,
+				})
+				continue
+			}
+		}
+
+		authPayment, err := s.CreatePayment(Payment{
+			ReservationID: rsv.ID,
+			Provider:      s.Payments.Name(),
+			ProviderRef:   auth.ID,
+			Kind:          PaymentAuthorization,
+			Status:        PaymentSucceeded,
+			Amount:        Price(auth.Amount),
+		})
+		if err != nil {
+			s.LogError(ServerError{
+				Prompt: fmt.Sprintf("Unable to record deposit authorization %s of reservation %s.", auth.ID, rsv.Code),
+				Err:    err,
+			})
+			continue
+		}
+
+		capture, err := s.Payments.Capture(auth.ID, int64(deposit))
+		if err != nil {
+			s.LogError(ServerError{
+				Prompt: fmt.Sprintf("Unable to capture deposit authorization %s of reservation %s.", auth.ID, rsv.Code),
+				Err:    err,
+			})
+			continue
+		}
+
+		_, err = s.CreatePayment(Payment{
+			ReservationID: rsv.ID,
+			ParentID:      authPayment.ID,
+			Provider:      s.Payments.Name(),
+			ProviderRef:   capture.ID,
+			Kind:          PaymentCapture,
+			Status:        PaymentStatus(capture.Status),
+			Amount:        Price(capture.Amount),
+		})
+		if err != nil {
+			s.LogError(ServerError{
+				Prompt: fmt.Sprintf("Unable to record deposit capture %s of reservation %s.", capture.ID, rsv.Code),
+				Err:    err,
+			})
+			continue
+		}
+
+		confirmed, err := s.UpdateReservationStatus(rsv, ReservationConfirmed, Today())
+		if err != nil {
+			s.LogError(ServerError{
+				Prompt: fmt.Sprintf("Unable to confirm reservation %s.", rsv.Code),
+				Err:    err,
+			})
+			continue
+		}
+
+		rsvs[i].Status = confirmed.Status
+	}
+
+	return rsvs
+}
+
+// GetInvoice returns the invoice of reservation rsv, issuing it and rendering its document if the reservation has no invoice yet.
+// Only reservations that are neither pending nor cancelled are issued an invoice, otherwise ErrNotInvoiceable is returned.
+// If regenerate is true, the document is rendered again with the current charges and payments, keeping the invoice number.
+func (s *Server) GetInvoice(rsv Reservation, regenerate bool) (Invoice, error) {
+	invoice, err := s.GetInvoiceByReservation(rsv.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		if !rsv.IsInvoiceable() {
+			return Invoice{}, ErrNotInvoiceable
+		}
+		invoice, err = s.IssueInvoice(rsv.ID)
+	}
+	if err != nil {
+		return Invoice{}, err
+	}
+
+	if invoice.Document != "" && !regenerate {
+		return invoice, nil
+	}
+
+	return s.RenderInvoice(invoice, rsv)
+}
+
+// RenderInvoice renders the document of invoice with the current charges and payments of reservation rsv, and saves it
+func (s *Server) RenderInvoice(invoice Invoice, rsv Reservation) (Invoice, error) {
+	var err error
+	rsv.Charges, err = s.ListReservationCharges(rsv.ID)
+	if err != nil {
+		return Invoice{}, err
+	}
+
+	pays, err := s.ListPayments(rsv.ID)
+	if err != nil {
+		return Invoice{}, err
+	}
+
+	document, err := s.Renderer.CreateInvoiceDocument(invoice, rsv, pays)
+	if err != nil {
+		return Invoice{}, err
+	}
+
+	return s.UpdateInvoiceDocument(invoice.ID, document)
+}
+
+// RefundCancellation refunds the amount refundable by the cancellation policy of the cancelled reservation rsv.
+// It returns an empty refund if there is nothing to refund.
+func (s *Server) RefundCancellation(rsv Reservation) (Refund, error) {
+	pays, err := s.ListPayments(rsv.ID)
+	if err != nil {
+		return Refund{}, err
+	}
+
+	amount := rsv.RefundableAmount(PaidAmount(pays))
+	if amount == 0 {
+		return Refund{}, nil
+	}
+
+	return s.refund(rsv, pays, amount, "", 0)
+}
+
+// RefundReservation refunds amount of the payments of reservation rsv on behalf of staff member userID.
+// Refunding an amount other than the amount refundable by the cancellation policy requires a reason, which is audited.
+func (s *Server) RefundReservation(rsv Reservation, amount Price, reason string, userID int64) (Refund, error) {
+	pays, err := s.ListPayments(rsv.ID)
+	if err != nil {
+		return Refund{}, err
+	}
+
+	if amount <= 0 || amount > PaidAmount(pays) {
+		return Refund{}, ErrRefundAmount
+	}
+
+	if amount != rsv.RefundableAmount(PaidAmount(pays)) && reason == "" {
+		return Refund{}, ErrRefundReason
+	}
+
+	return s.refund(rsv, pays, amount, reason, userID)
+}
+
+// refund refunds amount of the captures in pays through the payment provider, and records the refund.
+// If the provider fails after refunding part of amount, the part refunded is recorded and the provider error is returned with it.
+func (s *Server) refund(rsv Reservation, pays []Payment, amount Price, reason string, userID int64) (Refund, error) {
+	paid := PaidAmount(pays)
+	refund := Refund{
+		ReservationID: rsv.ID,
+		Paid:          paid,
+		PolicyAmount:  rsv.RefundableAmount(paid),
+		Reason:        reason,
+		UserID:        userID,
+	}
+
+	var txs []Payment
+	var providerErr error
+	for _, capture := range RefundableCaptures(pays) {
+		if refund.Amount == amount {
+			break
+		}
+
+		tx, err := s.Payments.Refund(capture.ProviderRef, int64(min(amount-refund.Amount, capture.Amount)))
+		if err != nil {
+			providerErr = err
+			break
+		}
+
+		txs = append(txs, Payment{
+			ReservationID: rsv.ID,
+			ParentID:      capture.ID,
+			Provider:      s.Payments.Name(),
+			ProviderRef:   tx.ID,
+			Kind:          PaymentRefund,
+			Status:        PaymentStatus(tx.Status),
+			Amount:        Price(tx.Amount),
+		})
+		refund.Amount += Price(tx.Amount)
+	}
+
+	if len(txs) == 0 {
+		if providerErr == nil {
+			providerErr = ErrRefundAmount
+		}
+		return Refund{}, providerErr
+	}
+
+	// the money refunded must be recorded even if it falls short of amount
+	if refund.IsOverride() && refund.Reason == "" {
+		refund.Reason = fmt.Sprintf("Refunded %s of %s requested: %v", refund.Amount, amount, providerErr)
+	}
+
+	refund, err := s.CreateRefund(refund, txs)
+	if err != nil {
+		return Refund{}, err
+	}
+
+	by := "on guest cancellation"
+	if refund.UserID != 0 {
+		by = fmt.Sprintf("by user %d", refund.UserID)
+	}
+
+	if refund.IsOverride() {
+		s.LogInfo(fmt.Sprintf("REFUND %s of reservation %s %s overrides policy amount %s: %s",
+			refund.Amount, rsv.Code, by, refund.PolicyAmount, refund.Reason))
+	} else {
+		s.LogInfo(fmt.Sprintf("REFUND %s of reservation %s %s", refund.Amount, rsv.Code, by))
+	}
+
+	return refund, providerErr
+}
+
 // LogError logs err using the InfoLogger
 func (s *Server) LogInfo(info string) {
 	var infoChan = s.InfoLogger.MyLogChannel()
@@ -199,6 +708,10 @@ func (s *Server) LogErrorAndRedirect(w http.ResponseWriter, r *http.Request, err
 
 // Render executes gohtml template and redirect in case of rendering error
 func (s *Server) Render(w http.ResponseWriter, r *http.Request, gohtml string, td *TemplateData, redirectURL string) {
+	// add the display currency of the session
+	td.Currency = s.DisplayCurrency(r)
+	td.Currencies = s.DisplayCurrencies()
+
 	err := s.Renderer.RenderGoHtmlPageTemplate(w, r, gohtml, td)
 	if err != nil {
 		sErr := CreateServerError(ErrorRenderTemplate, r.URL.Path, err)
@@ -206,6 +719,42 @@ func (s *Server) Render(w http.ResponseWriter, r *http.Request, gohtml string, t
 	}
 }
 
+// LoadExchangeRates loads the exchange rates of the display currencies from database into the cache of the server.
+// It is called on start up and whenever staff change the exchange rates.
+func (s *Server) LoadExchangeRates() error {
+	rates, err := s.ListExchangeRates()
+	if err != nil {
+		return err
+	}
+
+	s.ratesMutex.Lock()
+	s.exchangeRates = rates
+	s.ratesMutex.Unlock()
+
+	return nil
+}
+
+// DisplayCurrencies returns the base currency followed by the display currencies with an exchange rate
+func (s *Server) DisplayCurrencies() []ExchangeRate {
+	s.ratesMutex.RLock()
+	defer s.ratesMutex.RUnlock()
+
+	return append([]ExchangeRate{BaseExchangeRate()}, s.exchangeRates...)
+}
+
+// DisplayCurrency returns the exchange rate of the display currency selected in the session of r.
+// It returns the base currency if none is selected or the currency selected no longer has an exchange rate.
+func (s *Server) DisplayCurrency(r *http.Request) ExchangeRate {
+	code := app.Session.GetString(r.Context(), "currency")
+	for _, e := range s.DisplayCurrencies() {
+		if e.Currency == code {
+			return e
+		}
+	}
+
+	return BaseExchangeRate()
+}
+
 // ResponseJSON write v to w as json response.
 // Errors are loggied by the server and also returned
 func (s *Server) ResponseJSON(w http.ResponseWriter, r *http.Request, v any) error {
@@ -238,6 +787,19 @@ func (s *Server) ResponseJSON(w http.ResponseWriter, r *http.Request, v any) err
 	return nil
 }
 
+// WriteInvoice writes the document of invoice to w as a file download
+func (s *Server) WriteInvoice(w http.ResponseWriter, invoice Invoice) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", invoice.Filename()))
+	_, err := w.Write([]byte(invoice.Document))
+	if err != nil {
+		s.LogError(ServerError{
+			Prompt: "unable to write invoice",
+			Err:    err,
+		})
+	}
+}
+
 // SendMail sends email using the Mailer
 func (s *Server) SendMail(data mailers.MailData) {
 	var err error
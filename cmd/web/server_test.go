@@ -1,19 +1,27 @@
 package main
 
 import (
+	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/github-real-lb/bookings-web-app/db"
 	dbmocks "github.com/github-real-lb/bookings-web-app/db/mocks"
 	"github.com/github-real-lb/bookings-web-app/util"
 	loggermocks "github.com/github-real-lb/bookings-web-app/util/loggers/mocks"
 	"github.com/github-real-lb/bookings-web-app/util/mailers"
 	mailermocks "github.com/github-real-lb/bookings-web-app/util/mailers/mocks"
+	"github.com/github-real-lb/bookings-web-app/util/payments"
+	paymentmocks "github.com/github-real-lb/bookings-web-app/util/payments/mocks"
+	"github.com/jackc/pgx/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -22,8 +30,9 @@ func TestNewServer(t *testing.T) {
 	mockInfoLogger := loggermocks.NewMockLogger(t)
 	mockErrorLogger := loggermocks.NewMockLogger(t)
 	mockMailer := mailermocks.NewMockMailer(t)
+	mockPayments := paymentmocks.NewMockPaymentProvider(t)
 
-	server := NewServer(mockDbStore, mockInfoLogger, mockErrorLogger, mockMailer)
+	server := NewServer(mockDbStore, mockInfoLogger, mockErrorLogger, mockMailer, mockPayments)
 	require.IsType(t, (*Server)(nil), server)
 }
 
@@ -70,6 +79,803 @@ func TestServer_StartAndStop(t *testing.T) {
 	require.Error(t, err) // Expect an error because the server should be closed
 }
 
+func TestServer_SweepRoomHolds(t *testing.T) {
+	ts := NewTestServer(t)
+
+	// build stubs: the first sweep deletes holds, the following sweeps find none
+	deleted := make(chan struct{})
+	dbHolds := make([]db.RoomRestriction, 2)
+	for i := range dbHolds {
+		dbHolds[i] = db.RoomRestriction{RoomID: util.RandomID(), Restriction: db.RestrictionHold}
+	}
+	ts.MockDBStore.On("DeleteExpiredRoomHolds", mock.Anything).
+		Return(dbHolds, nil).
+		Once()
+	ts.MockDBStore.On("NotifyWaitlistTx", mock.Anything, mock.Anything).
+		Return([]db.WaitlistEntry{}, nil).
+		Twice()
+	ts.MockDBStore.On("DeleteExpiredRoomHolds", mock.Anything).
+		Return([]db.RoomRestriction{}, nil).
+		Run(func(args mock.Arguments) {
+			select {
+			case deleted <- struct{}{}:
+			default:
+			}
+		})
+	ts.BuildLogInfoStub("HOLDS 2 expired room holds deleted")
+
+	// start sweeping and wait for the second sweep
+	done := make(chan struct{})
+	go func() {
+		ts.SweepRoomHolds(10 * time.Millisecond)
+		close(done)
+	}()
+
+	select {
+	case <-deleted:
+	case <-time.After(time.Second):
+		t.Fatal("room holds were not swept")
+	}
+
+	// stop sweeping
+	close(ts.HoldSweeperDone)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("sweeper did not stop")
+	}
+}
+
+func TestServer_OfferFreedRoom(t *testing.T) {
+	// create random waitlist entry and the room freed
+	entry := randomWaitlistEntry()
+	room := randomRoom()
+	room.ID = entry.OfferedRoomID
+
+	dbEntry := db.WaitlistEntry{}
+	entry.Export(&dbEntry)
+
+	dbRoom := db.Room{}
+	room.Export(&dbRoom)
+
+	t.Run("Test OK", func(t *testing.T) {
+		ts := NewTestServer(t)
+
+		// build stubs
+		ts.MockDBStore.On("NotifyWaitlistTx", mock.Anything, mock.Anything).
+			Return([]db.WaitlistEntry{dbEntry}, nil).
+			Once()
+		ts.MockDBStore.On("GetRoom", mock.Anything, room.ID).
+			Return(dbRoom, nil).
+			Once()
+		ts.BuildSendAnyMailStub()
+		ts.BuildLogInfoStub(fmt.Sprintf("MAIL waitlist offer sent to %s", entry.Email))
+
+		// execute method
+		ts.OfferFreedRoom(room.ID, entry.StartDate, entry.EndDate)
+	})
+
+	t.Run("Test No Waitlisted Guests", func(t *testing.T) {
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("NotifyWaitlistTx", mock.Anything, mock.Anything).
+			Return([]db.WaitlistEntry{}, nil).
+			Once()
+
+		// execute method
+		ts.OfferFreedRoom(room.ID, entry.StartDate, entry.EndDate)
+	})
+
+	t.Run("Test NotifyWaitlistTx Error", func(t *testing.T) {
+		ts := NewTestServer(t)
+
+		// build stubs
+		ts.MockDBStore.On("NotifyWaitlistTx", mock.Anything, mock.Anything).
+			Return(nil, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		// execute method
+		ts.OfferFreedRoom(room.ID, entry.StartDate, entry.EndDate)
+	})
+
+	t.Run("Test GetRoom Error", func(t *testing.T) {
+		ts := NewTestServer(t)
+
+		// build stubs
+		ts.MockDBStore.On("NotifyWaitlistTx", mock.Anything, mock.Anything).
+			Return([]db.WaitlistEntry{dbEntry}, nil).
+			Once()
+		ts.MockDBStore.On("GetRoom", mock.Anything, room.ID).
+			Return(db.Room{}, errors.New("any error")).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		// execute method
+		ts.OfferFreedRoom(room.ID, entry.StartDate, entry.EndDate)
+	})
+}
+
+func TestServer_MailPasswordLink(t *testing.T) {
+	user := randomUser()
+
+	// matchArg returns true if arg sets a new password token of user
+	matchArg := mock.MatchedBy(func(arg db.UpdateUserPasswordTokenParams) bool {
+		return arg.ID == user.ID && len(arg.PasswordToken.String) == base64.RawURLEncoding.EncodedLen(PasswordTokenLength) &&
+			arg.PasswordTokenExpiresAt.Time.After(time.Now())
+	})
+
+	t.Run("Test OK", func(t *testing.T) {
+		ts := NewTestServer(t)
+
+		// build stubs
+		ts.MockDBStore.On("UpdateUserPasswordToken", mock.Anything, matchArg).
+			Return(nil).
+			Once()
+		ts.BuildSendAnyMailStub()
+		ts.BuildLogInfoStub(fmt.Sprintf("MAIL password link sent to %s", user.Email))
+
+		// execute method
+		err := ts.MailPasswordLink(user, true)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Test UpdateUserPasswordToken Error", func(t *testing.T) {
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("UpdateUserPasswordToken", mock.Anything, matchArg).
+			Return(errors.New("any error")).
+			Once()
+
+		// execute method
+		err := ts.MailPasswordLink(user, false)
+		assert.Error(t, err)
+	})
+}
+
+func TestServer_LogOutUser(t *testing.T) {
+	ts := NewTestServer(t)
+
+	// commit a session of the user, and a session of another user
+	tokens := make([]string, 2)
+	for i, id := range []int64{1, 2} {
+		req := ts.NewRequestWithSession(t, http.MethodGet, "/", nil)
+		app.Session.Put(req.Context(), "user_id", id)
+
+		token, _, err := app.Session.Commit(req.Context())
+		require.NoError(t, err)
+		tokens[i] = token
+	}
+
+	// execute method
+	ts.LogOutUser(1)
+
+	// only the sessions of the user were destroyed
+	_, found, err := app.Session.Store.Find(tokens[0])
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	_, found, err = app.Session.Store.Find(tokens[1])
+	require.NoError(t, err)
+	assert.True(t, found)
+}
+
+// cancelledWithPayments returns a cancelled reservation with a cancellation fee of feePercent,
+// and the captures of the deposits paid for it, each of amount
+func cancelledWithPayments(feePercent int, amounts ...Price) (Reservation, []db.Payment) {
+	rsv := randomReservation()
+	rsv.Status = ReservationCancelled
+	rsv.CancellationFeePercent = feePercent
+
+	dbPayments := make([]db.Payment, len(amounts))
+	for i, amount := range amounts {
+		p := randomPayment()
+		p.ReservationID = rsv.ID
+		p.ProviderRef = fmt.Sprintf("fake_capt_%06d", i+1)
+		p.Amount = amount
+		p.Export(&dbPayments[i])
+	}
+
+	return rsv, dbPayments
+}
+
+func TestServer_AuthorizeDeposits(t *testing.T) {
+	rsv := randomReservation()
+	quotes := []Quote{
+		{Room: randomRoom(), Total: Price(7 * 10000)},
+		{Room: randomRoom(), Total: Price(7 * 10000)},
+	}
+	deposit := int64(quotes[0].Total.Percent(app.DepositPercent))
+
+	t.Run("Test OK", func(t *testing.T) {
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockPayments.On("Authorize", mock.Anything).
+			Return(payments.Transaction{ID: "fake_auth_000001", Amount: deposit, Status: payments.StatusSucceeded}, nil).
+			Twice()
+
+		// execute method
+		auths, err := ts.AuthorizeDeposits(rsv, quotes, testCardToken)
+		require.NoError(t, err)
+		assert.Len(t, auths, len(quotes))
+	})
+
+	t.Run("Test Declined", func(t *testing.T) {
+		ts := NewTestServer(t)
+
+		// build stubs, the deposit of the first room is released when the second is declined
+		ts.MockPayments.On("Authorize", mock.Anything).
+			Return(payments.Transaction{ID: "fake_auth_000001", Amount: deposit, Status: payments.StatusSucceeded}, nil).
+			Once()
+		ts.MockPayments.On("Authorize", mock.Anything).
+			Return(payments.Transaction{ID: "fake_decl_000002", Status: payments.StatusFailed}, payments.ErrPaymentDeclined).
+			Once()
+		ts.BuildVoidDepositsStub(1)
+
+		// execute method
+		auths, err := ts.AuthorizeDeposits(rsv, quotes, testCardToken)
+		assert.ErrorIs(t, err, payments.ErrPaymentDeclined)
+		assert.Nil(t, auths)
+	})
+}
+
+func TestServer_VoidDeposits(t *testing.T) {
+	auths := []payments.Transaction{{ID: "fake_auth_000001"}, {}, {ID: "fake_auth_000002"}}
+
+	t.Run("Test OK", func(t *testing.T) {
+		ts := NewTestServer(t)
+
+		// build stub, no authorization is voided for the room without a deposit
+		ts.BuildVoidDepositsStub(2)
+
+		// execute method
+		ts.VoidDeposits(auths)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		ts := NewTestServer(t)
+
+		// build stubs, an error does not stop the other authorizations from being voided
+		ts.MockPayments.On("Void", "fake_auth_000001").
+			Return(payments.Transaction{}, errors.New("any error")).
+			Once()
+		ts.MockPayments.On("Void", "fake_auth_000002").
+			Return(payments.Transaction{ID: "fake_void_000003", Status: payments.StatusSucceeded}, nil).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		// execute method
+		ts.VoidDeposits(auths)
+	})
+}
+
+func TestServer_CaptureDeposits(t *testing.T) {
+	t.Run("Test OK", func(t *testing.T) {
+		rsv := randomReservation()
+		rsv.Status = ReservationPending
+		deposit := int64(rsv.TotalPrice.Percent(app.DepositPercent))
+		auths := []payments.Transaction{{ID: "fake_auth_000001", Amount: deposit, Status: payments.StatusSucceeded}}
+
+		ts := NewTestServer(t)
+
+		// build stubs
+		ts.BuildCaptureDepositsStub(1)
+
+		// execute method
+		rsvs := ts.CaptureDeposits([]Reservation{rsv}, auths, testCardToken)
+		require.Len(t, rsvs, 1)
+		assert.Equal(t, ReservationConfirmed, rsvs[0].Status)
+		ts.MockPayments.AssertCalled(t, "Capture", "fake_auth_000001", deposit)
+		ts.MockPayments.AssertNotCalled(t, "Void", mock.Anything)
+	})
+
+	t.Run("Test Price Changed", func(t *testing.T) {
+		rsv := randomReservation()
+		rsv.Status = ReservationPending
+		deposit := int64(rsv.TotalPrice.Percent(app.DepositPercent))
+		auths := []payments.Transaction{{ID: "fake_auth_000001", Amount: deposit + 1000, Status: payments.StatusSucceeded}}
+
+		ts := NewTestServer(t)
+
+		// build stubs, the authorization of the price quoted is replaced by one of the price saved
+		ts.BuildVoidDepositsStub(1)
+		ts.MockPayments.On("Authorize", mock.MatchedBy(func(req payments.AuthorizeRequest) bool {
+			return req.Amount == deposit && req.Token == testCardToken
+		})).
+			Return(payments.Transaction{ID: "fake_auth_000002", Amount: deposit, Status: payments.StatusSucceeded}, nil).
+			Once()
+		ts.BuildCaptureDepositsStub(1)
+
+		// execute method
+		rsvs := ts.CaptureDeposits([]Reservation{rsv}, auths, testCardToken)
+		require.Len(t, rsvs, 1)
+		assert.Equal(t, ReservationConfirmed, rsvs[0].Status)
+		ts.MockPayments.AssertCalled(t, "Capture", "fake_auth_000002", deposit)
+	})
+
+	t.Run("Test Authorize Error", func(t *testing.T) {
+		rsv := randomReservation()
+		rsv.Status = ReservationPending
+		deposit := int64(rsv.TotalPrice.Percent(app.DepositPercent))
+		auths := []payments.Transaction{{ID: "fake_auth_000001", Amount: deposit - 1000, Status: payments.StatusSucceeded}}
+
+		ts := NewTestServer(t)
+
+		// build stubs, the reservation is left pending when the deposit cannot be authorized again
+		ts.BuildVoidDepositsStub(1)
+		ts.MockPayments.On("Authorize", mock.Anything).
+			Return(payments.Transaction{Status: payments.StatusFailed}, payments.ErrPaymentDeclined).
+			Once()
+		ts.BuildLogAnyErrorStub()
+
+		// execute method
+		rsvs := ts.CaptureDeposits([]Reservation{rsv}, auths, testCardToken)
+		require.Len(t, rsvs, 1)
+		assert.Equal(t, ReservationPending, rsvs[0].Status)
+		ts.MockPayments.AssertNotCalled(t, "Capture", mock.Anything, mock.Anything)
+	})
+}
+
+func TestServer_RefundCancellation(t *testing.T) {
+	t.Run("Test OK", func(t *testing.T) {
+		rsv, dbPayments := cancelledWithPayments(50, 3000, 2000)
+		rsv.TotalPrice = 6000
+
+		ts := NewTestServer(t)
+
+		// build stubs
+		ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, rsv.ID).
+			Return(dbPayments, nil).
+			Once()
+		ts.BuildRefundStub(1)
+		ts.BuildLogInfoStub(fmt.Sprintf("REFUND $20.00 of reservation %s on guest cancellation", rsv.Code))
+
+		// execute method
+		refund, err := ts.RefundCancellation(rsv)
+
+		// testify
+		require.NoError(t, err)
+		assert.Equal(t, Price(5000), refund.Paid)
+		assert.Equal(t, Price(2000), refund.PolicyAmount)
+		assert.Equal(t, Price(2000), refund.Amount)
+		assert.Empty(t, refund.Reason)
+		assert.Zero(t, refund.UserID)
+	})
+
+	t.Run("Test Nothing Refundable", func(t *testing.T) {
+		rsv, dbPayments := cancelledWithPayments(50, 2000)
+		rsv.TotalPrice = 10000
+
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, rsv.ID).
+			Return(dbPayments, nil).
+			Once()
+
+		// execute method
+		refund, err := ts.RefundCancellation(rsv)
+
+		// testify
+		require.NoError(t, err)
+		assert.Empty(t, refund)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		rsv, _ := cancelledWithPayments(0)
+
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, rsv.ID).
+			Return(nil, errors.New("any error")).
+			Once()
+
+		// execute method
+		_, err := ts.RefundCancellation(rsv)
+
+		// testify
+		assert.Error(t, err)
+	})
+}
+
+func TestServer_RefundReservation(t *testing.T) {
+	userID := util.RandomID()
+
+	t.Run("Test OK Override", func(t *testing.T) {
+		rsv, dbPayments := cancelledWithPayments(50, 3000, 2000)
+		rsv.TotalPrice = 6000
+
+		ts := NewTestServer(t)
+
+		// build stubs
+		ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, rsv.ID).
+			Return(dbPayments, nil).
+			Once()
+		ts.BuildRefundStub(2)
+		ts.BuildLogInfoStub(fmt.Sprintf("REFUND $50.00 of reservation %s by user %d overrides policy amount $20.00: goodwill",
+			rsv.Code, userID))
+
+		// execute method
+		refund, err := ts.RefundReservation(rsv, 5000, "goodwill", userID)
+
+		// testify
+		require.NoError(t, err)
+		assert.Equal(t, Price(5000), refund.Amount)
+		assert.Equal(t, Price(2000), refund.PolicyAmount)
+		assert.Equal(t, "goodwill", refund.Reason)
+		assert.Equal(t, userID, refund.UserID)
+		assert.True(t, refund.IsOverride())
+	})
+
+	t.Run("Test Invalid Amount", func(t *testing.T) {
+		rsv, dbPayments := cancelledWithPayments(0, 3000)
+
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, rsv.ID).
+			Return(dbPayments, nil).
+			Twice()
+
+		// execute method and testify
+		_, err := ts.RefundReservation(rsv, 3001, "too much", userID)
+		assert.ErrorIs(t, err, ErrRefundAmount)
+
+		_, err = ts.RefundReservation(rsv, 0, "nothing", userID)
+		assert.ErrorIs(t, err, ErrRefundAmount)
+	})
+
+	t.Run("Test Missing Reason", func(t *testing.T) {
+		rsv, dbPayments := cancelledWithPayments(0, 3000)
+
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, rsv.ID).
+			Return(dbPayments, nil).
+			Once()
+
+		// execute method and testify
+		_, err := ts.RefundReservation(rsv, 1000, "", userID)
+		assert.ErrorIs(t, err, ErrRefundReason)
+	})
+
+	t.Run("Test Partial Provider Error", func(t *testing.T) {
+		rsv, dbPayments := cancelledWithPayments(0, 3000, 2000)
+		rsv.TotalPrice = 5000
+
+		ts := NewTestServer(t)
+
+		// build stubs
+		ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, rsv.ID).
+			Return(dbPayments, nil).
+			Once()
+		ts.MockPayments.On("Name").Return("fake")
+		ts.MockPayments.On("Refund", "fake_capt_000001", int64(3000)).
+			Return(payments.Transaction{ID: "fake_rfnd_000003", Amount: 3000, Status: payments.StatusSucceeded}, nil).
+			Once()
+		ts.MockPayments.On("Refund", "fake_capt_000002", int64(2000)).
+			Return(payments.Transaction{}, payments.ErrInvalidPayment).
+			Once()
+		ts.MockDBStore.On("CreateRefundTx", mock.Anything, mock.MatchedBy(func(arg db.CreateRefundTxParams) bool {
+			return arg.Amount == 3000 && arg.PolicyAmount == 5000 && arg.Reason != "" && len(arg.Payments) == 1
+		})).
+			Return(db.Refund{Amount: 3000, PolicyAmount: 5000}, nil).
+			Once()
+		ts.BuildLogAnyInfoStub()
+
+		// execute method
+		refund, err := ts.RefundReservation(rsv, 5000, "", 0)
+
+		// testify
+		assert.ErrorIs(t, err, payments.ErrInvalidPayment)
+		assert.Equal(t, Price(3000), refund.Amount)
+	})
+
+	t.Run("Test Provider Error", func(t *testing.T) {
+		rsv, dbPayments := cancelledWithPayments(0, 3000)
+		rsv.TotalPrice = 3000
+
+		ts := NewTestServer(t)
+
+		// build stubs
+		ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, rsv.ID).
+			Return(dbPayments, nil).
+			Once()
+		ts.MockPayments.On("Refund", "fake_capt_000001", int64(3000)).
+			Return(payments.Transaction{}, payments.ErrInvalidPayment).
+			Once()
+
+		// execute method
+		refund, err := ts.RefundReservation(rsv, 3000, "", userID)
+
+		// testify
+		assert.ErrorIs(t, err, payments.ErrInvalidPayment)
+		assert.Empty(t, refund)
+	})
+
+	t.Run("Test Database Error", func(t *testing.T) {
+		rsv, dbPayments := cancelledWithPayments(0, 3000)
+		rsv.TotalPrice = 3000
+
+		ts := NewTestServer(t)
+
+		// build stubs
+		ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, rsv.ID).
+			Return(dbPayments, nil).
+			Once()
+		ts.MockPayments.On("Name").Return("fake")
+		ts.MockPayments.On("Refund", mock.Anything, mock.Anything).
+			Return(payments.Transaction{ID: "fake_rfnd_000002", Amount: 3000, Status: payments.StatusSucceeded}, nil).
+			Once()
+		ts.MockDBStore.On("CreateRefundTx", mock.Anything, mock.Anything).
+			Return(db.Refund{}, errors.New("any error")).
+			Once()
+
+		// execute method
+		_, err := ts.RefundReservation(rsv, 3000, "", userID)
+
+		// testify
+		assert.Error(t, err)
+	})
+}
+
+func TestServer_GetInvoice(t *testing.T) {
+	rsv := randomReservation()
+	rsv.Status = ReservationConfirmed
+
+	// create stub call arguments
+	arg := db.IssueInvoiceTxParams{
+		Property:      app.Listing.Name,
+		ReservationID: rsv.ID,
+	}
+
+	issued := randomInvoice()
+	issued.ReservationID = rsv.ID
+	dbIssued := db.Invoice{}
+	issued.Export(&dbIssued)
+
+	blank := issued
+	blank.Document = ""
+	dbBlank := db.Invoice{}
+	blank.Export(&dbBlank)
+
+	// Test OK: an issued invoice is returned as it is
+	t.Run("Test OK Issued", func(t *testing.T) {
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("GetInvoiceByReservation", mock.Anything, rsv.ID).
+			Return(dbIssued, nil).
+			Once()
+
+		// execute method
+		invoice, err := ts.GetInvoice(rsv, false)
+
+		// testify
+		require.NoError(t, err)
+		testInvoice(t, dbIssued, invoice)
+	})
+
+	// Test OK: a new or regenerated invoice is rendered and saved
+	for _, test := range []struct {
+		Name       string
+		Invoice    db.Invoice
+		New        bool
+		Regenerate bool
+	}{
+		{Name: "Test OK New", Invoice: dbBlank, New: true},
+		{Name: "Test OK Blank", Invoice: dbBlank},
+		{Name: "Test OK Regenerate", Invoice: dbIssued, Regenerate: true},
+	} {
+		t.Run(test.Name, func(t *testing.T) {
+			ts := NewTestServer(t)
+
+			// build stubs
+			if test.New {
+				ts.MockDBStore.On("GetInvoiceByReservation", mock.Anything, rsv.ID).
+					Return(db.Invoice{}, pgx.ErrNoRows).
+					Once()
+				ts.MockDBStore.On("IssueInvoiceTx", mock.Anything, arg).
+					Return(test.Invoice, nil).
+					Once()
+			} else {
+				ts.MockDBStore.On("GetInvoiceByReservation", mock.Anything, rsv.ID).
+					Return(test.Invoice, nil).
+					Once()
+			}
+			ts.MockDBStore.On("ListReservationCharges", mock.Anything, rsv.ID).
+				Return([]db.ReservationCharge{}, nil).
+				Once()
+			ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, rsv.ID).
+				Return([]db.Payment{}, nil).
+				Once()
+			ts.MockDBStore.On("UpdateInvoiceDocument", mock.Anything, mock.MatchedBy(func(arg db.UpdateInvoiceDocumentParams) bool {
+				return arg.ID == issued.ID && strings.Contains(arg.Document, issued.InvoiceNumber())
+			})).
+				Return(func(_ context.Context, arg db.UpdateInvoiceDocumentParams) db.Invoice {
+					result := test.Invoice
+					result.Document = arg.Document
+					return result
+				}, nil).
+				Once()
+
+			// execute method
+			invoice, err := ts.GetInvoice(rsv, test.Regenerate)
+
+			// testify
+			require.NoError(t, err)
+			assert.Equal(t, issued.ID, invoice.ID)
+			assert.Contains(t, invoice.Document, rsv.Code)
+		})
+	}
+
+	// Test Not Invoiceable: pending and cancelled reservations are not issued an invoice
+	for _, status := range []ReservationStatus{ReservationPending, ReservationCancelled} {
+		t.Run("Test Not Invoiceable "+string(status), func(t *testing.T) {
+			ts := NewTestServer(t)
+
+			notInvoiceable := rsv
+			notInvoiceable.Status = status
+
+			// build stub
+			ts.MockDBStore.On("GetInvoiceByReservation", mock.Anything, rsv.ID).
+				Return(db.Invoice{}, pgx.ErrNoRows).
+				Once()
+
+			// execute method
+			_, err := ts.GetInvoice(notInvoiceable, false)
+
+			// testify
+			assert.ErrorIs(t, err, ErrNotInvoiceable)
+		})
+	}
+
+	// Test OK: an invoice already issued is returned for a cancelled reservation
+	t.Run("Test OK Issued Cancelled", func(t *testing.T) {
+		ts := NewTestServer(t)
+
+		cancelled := rsv
+		cancelled.Status = ReservationCancelled
+
+		// build stub
+		ts.MockDBStore.On("GetInvoiceByReservation", mock.Anything, rsv.ID).
+			Return(dbIssued, nil).
+			Once()
+
+		// execute method
+		invoice, err := ts.GetInvoice(cancelled, false)
+
+		// testify
+		require.NoError(t, err)
+		testInvoice(t, dbIssued, invoice)
+	})
+
+	t.Run("Test Error GetInvoiceByReservation", func(t *testing.T) {
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("GetInvoiceByReservation", mock.Anything, rsv.ID).
+			Return(db.Invoice{}, errors.New("any error")).
+			Once()
+
+		// execute method
+		_, err := ts.GetInvoice(rsv, false)
+
+		// testify
+		assert.Error(t, err)
+	})
+
+	t.Run("Test Error IssueInvoiceTx", func(t *testing.T) {
+		ts := NewTestServer(t)
+
+		// build stubs
+		ts.MockDBStore.On("GetInvoiceByReservation", mock.Anything, rsv.ID).
+			Return(db.Invoice{}, pgx.ErrNoRows).
+			Once()
+		ts.MockDBStore.On("IssueInvoiceTx", mock.Anything, arg).
+			Return(db.Invoice{}, errors.New("any error")).
+			Once()
+
+		// execute method
+		_, err := ts.GetInvoice(rsv, false)
+
+		// testify
+		assert.Error(t, err)
+	})
+
+	t.Run("Test Error ListPaymentsByReservation", func(t *testing.T) {
+		ts := NewTestServer(t)
+
+		// build stubs
+		ts.MockDBStore.On("GetInvoiceByReservation", mock.Anything, rsv.ID).
+			Return(dbBlank, nil).
+			Once()
+		ts.MockDBStore.On("ListReservationCharges", mock.Anything, rsv.ID).
+			Return([]db.ReservationCharge{}, nil).
+			Once()
+		ts.MockDBStore.On("ListPaymentsByReservation", mock.Anything, rsv.ID).
+			Return(nil, errors.New("any error")).
+			Once()
+
+		// execute method
+		_, err := ts.GetInvoice(rsv, false)
+
+		// testify
+		assert.Error(t, err)
+	})
+}
+
+func TestServer_LoadExchangeRates(t *testing.T) {
+	t.Run("Test OK", func(t *testing.T) {
+		// create stub return arguments
+		rate := randomExchangeRate()
+		dbRates := make([]db.ExchangeRate, 1)
+		rate.Export(&dbRates[0])
+
+		ts := NewTestServer(t)
+
+		// build stub
+		ts.MockDBStore.On("ListExchangeRates", mock.Anything).
+			Return(dbRates, nil).
+			Once()
+
+		// execute method and testify
+		require.NoError(t, ts.LoadExchangeRates())
+
+		currencies := ts.DisplayCurrencies()
+		require.Len(t, currencies, 2)
+		assert.Equal(t, BaseExchangeRate(), currencies[0])
+		testExchangeRate(t, dbRates[0], currencies[1])
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		ts := NewTestServer(t)
+		ts.exchangeRates = []ExchangeRate{randomExchangeRate()}
+
+		// build stub
+		ts.MockDBStore.On("ListExchangeRates", mock.Anything).
+			Return(nil, errors.New("any error")).
+			Once()
+
+		// execute method and testify
+		assert.Error(t, ts.LoadExchangeRates())
+		assert.Len(t, ts.DisplayCurrencies(), 2)
+	})
+}
+
+func TestServer_DisplayCurrency(t *testing.T) {
+	eur := ExchangeRate{Currency: "EUR", Symbol: "€", Rate: 921500}
+
+	ts := NewTestServer(t)
+	ts.exchangeRates = []ExchangeRate{eur}
+
+	for _, test := range []struct {
+		Name     string
+		Currency string
+		Expected ExchangeRate
+	}{
+		{Name: "No Currency", Expected: BaseExchangeRate()},
+		{Name: "Base Currency", Currency: app.BaseCurrency.Code, Expected: BaseExchangeRate()},
+		{Name: "Display Currency", Currency: "EUR", Expected: eur},
+		{Name: "Unavailable Currency", Currency: "GBP", Expected: BaseExchangeRate()},
+	} {
+		t.Run(test.Name, func(t *testing.T) {
+			req := ts.NewRequestWithSession(t, http.MethodGet, "/", nil)
+			if test.Currency != "" {
+				app.Session.Put(req.Context(), "currency", test.Currency)
+			}
+
+			assert.Equal(t, test.Expected, ts.DisplayCurrency(req))
+		})
+	}
+}
+
 func TestServer_LogError(t *testing.T) {
 	t.Run("LogChannel nil", func(t *testing.T) {
 		// create new test server
@@ -1,15 +1,24 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/github-real-lb/bookings-web-app/db"
 	dbmocks "github.com/github-real-lb/bookings-web-app/db/mocks"
+	"github.com/github-real-lb/bookings-web-app/util"
 	loggermocks "github.com/github-real-lb/bookings-web-app/util/loggers/mocks"
 	"github.com/github-real-lb/bookings-web-app/util/mailers"
 	mailermocks "github.com/github-real-lb/bookings-web-app/util/mailers/mocks"
+	"github.com/github-real-lb/bookings-web-app/util/payments"
+	paymentmocks "github.com/github-real-lb/bookings-web-app/util/payments/mocks"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -20,6 +29,7 @@ type TestServer struct {
 	MockErrorLogger *loggermocks.MockLogger
 	MockInfoLogger  *loggermocks.MockLogger
 	MockMailer      *mailermocks.MockMailer
+	MockPayments    *paymentmocks.MockPaymentProvider
 }
 
 // NewTestServer creates and returns a test server connected to a mock database store
@@ -29,13 +39,15 @@ func NewTestServer(t *testing.T) *TestServer {
 	mockErrorLogger := loggermocks.NewMockLogger(t)
 	mockInfoLogger := loggermocks.NewMockLogger(t)
 	mockMailer := mailermocks.NewMockMailer(t)
+	mockPayments := paymentmocks.NewMockPaymentProvider(t)
 
 	ts := TestServer{
-		Server:          NewServer(mockDBStore, mockErrorLogger, mockInfoLogger, mockMailer),
+		Server:          NewServer(mockDBStore, mockErrorLogger, mockInfoLogger, mockMailer, mockPayments),
 		MockDBStore:     mockDBStore,
 		MockErrorLogger: mockErrorLogger,
 		MockInfoLogger:  mockInfoLogger,
 		MockMailer:      mockMailer,
+		MockPayments:    mockPayments,
 	}
 
 	// load web page templates cache
@@ -46,6 +58,10 @@ func NewTestServer(t *testing.T) *TestServer {
 	err = ts.Renderer.LoadGoHtmlMailTemplates()
 	require.NoError(t, err)
 
+	// load invoice templates cache
+	err = ts.Renderer.LoadGoHtmlInvoiceTemplates()
+	require.NoError(t, err)
+
 	return &ts
 }
 
@@ -85,6 +101,146 @@ func (ts *TestServer) BuildSendMailStub(data mailers.MailData) {
 	ts.MockMailer.On("SendMail", data).Return(nil).Once()
 }
 
+// BuildQuoteStayStub builds the MockDBStore QuoteStay() stub for testing of the price quote of a stay
+// of adults and children in room from startDate to endDate, at the room's nightly rate
+func (ts *TestServer) BuildQuoteStayStub(room Room, startDate, endDate time.Time, adults, children int) {
+	arg := db.QuoteStayParams{RoomID: room.ID, Adults: int32(adults), Children: int32(children)}
+	arg.StartDate.Scan(startDate)
+	arg.EndDate.Scan(endDate)
+
+	ts.MockDBStore.On("QuoteStay", mock.Anything, arg).Return(nightlyQuote(room, startDate, endDate), nil).Once()
+}
+
+// BuildQuoteStaysStub builds the MockDBStore QuoteStays() stub for testing of the price quote of a stay
+// in every room of rooms from startDate to endDate, at the room's nightly rate, of the guests in split
+func (ts *TestServer) BuildQuoteStaysStub(rooms []Room, startDate, endDate time.Time, split []Guests) {
+	args := make([]db.QuoteStayParams, len(rooms))
+	quotes := make([]db.Quote, len(rooms))
+	for i, room := range rooms {
+		args[i] = db.QuoteStayParams{RoomID: room.ID, Adults: int32(split[i].Adults), Children: int32(split[i].Children)}
+		args[i].StartDate.Scan(startDate)
+		args[i].EndDate.Scan(endDate)
+		quotes[i] = nightlyQuote(room, startDate, endDate)
+	}
+
+	ts.MockDBStore.On("QuoteStays", mock.Anything, args).Return(quotes, nil).Once()
+}
+
+// nightlyQuote returns the price quote of a stay in room from startDate to endDate, at the room's nightly rate
+func nightlyQuote(room Room, startDate, endDate time.Time) db.Quote {
+	quote := db.Quote{RoomID: room.ID}
+	for date := startDate; date.Before(endDate); date = date.AddDate(0, 0, 1) {
+		quote.Nights = append(quote.Nights, db.NightlyRate{
+			Date: pgtype.Date{Time: date, Valid: true},
+			Rate: int64(room.NightlyRate),
+		})
+		quote.Subtotal += int64(room.NightlyRate)
+	}
+	quote.Total = quote.Subtotal
+
+	return quote
+}
+
+// BuildAuthorizeDepositsStub builds the MockDBStore QuoteStays() and MockPaymentProvider Authorize() stubs
+// for testing of the deposits authorized on card token for n rooms, each quoted at total
+func (ts *TestServer) BuildAuthorizeDepositsStub(n int, total Price, token string) {
+	quotes := make([]db.Quote, n)
+	for i := range quotes {
+		quotes[i] = db.Quote{Total: int64(total)}
+	}
+	ts.MockDBStore.On("QuoteStays", mock.Anything, mock.Anything).
+		Return(quotes, nil).
+		Once()
+
+	deposit := total.Percent(app.DepositPercent)
+	for i := 1; i <= n; i++ {
+		ts.MockPayments.On("Authorize", mock.MatchedBy(func(req payments.AuthorizeRequest) bool {
+			return req.Amount == int64(deposit) && req.Token == token && req.Currency == app.BaseCurrency.Code
+		})).
+			Return(payments.Transaction{
+				ID:     fmt.Sprintf("fake_auth_%06d", i),
+				Amount: int64(deposit),
+				Status: payments.StatusSucceeded,
+			}, nil).
+			Once()
+	}
+}
+
+// BuildVoidDepositsStub builds the MockPaymentProvider Void() stub
+// for testing of the deposits authorized by BuildAuthorizeDepositsStub released for n rooms
+func (ts *TestServer) BuildVoidDepositsStub(n int) {
+	for i := 1; i <= n; i++ {
+		id := fmt.Sprintf("fake_auth_%06d", i)
+		ts.MockPayments.On("Void", id).
+			Return(payments.Transaction{ID: fmt.Sprintf("fake_void_%06d", n+i), Status: payments.StatusSucceeded}, nil).
+			Once()
+	}
+}
+
+// BuildCaptureDepositsStub builds the MockPaymentProvider Capture(), MockDBStore CreatePayment()
+// and UpdateReservationStatusTx() stubs for testing of the deposits captured, recorded and confirmed for n reservations
+func (ts *TestServer) BuildCaptureDepositsStub(n int) {
+	ts.MockPayments.On("Name").Return("fake")
+	ts.MockDBStore.On("CreatePayment", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, arg db.CreatePaymentParams) (db.Payment, error) {
+			return db.Payment{
+				ID:            util.RandomID(),
+				ReservationID: arg.ReservationID,
+				ParentID:      arg.ParentID,
+				Provider:      arg.Provider,
+				ProviderRef:   arg.ProviderRef,
+				Kind:          arg.Kind,
+				Status:        arg.Status,
+				Amount:        arg.Amount,
+			}, nil
+		}, nil).
+		Times(2 * n)
+	ts.MockPayments.On("Capture", mock.Anything, mock.Anything).
+		Return(func(authorizationID string, amount int64) (payments.Transaction, error) {
+			return payments.Transaction{
+				ID:     strings.Replace(authorizationID, "auth", "capt", 1),
+				Amount: amount,
+				Status: payments.StatusSucceeded,
+			}, nil
+		}, nil).
+		Times(n)
+	ts.MockDBStore.On("UpdateReservationStatusTx", mock.Anything, mock.MatchedBy(func(arg db.UpdateReservationStatusTxParams) bool {
+		return arg.Status == db.ReservationStatusConfirmed
+	})).
+		Return(db.Reservation{Status: db.ReservationStatusConfirmed}, nil).
+		Times(n)
+}
+
+// BuildRefundStub builds the MockPaymentProvider Refund() and MockDBStore CreateRefundTx() stubs
+// for testing of a refund made of n refund transactions
+func (ts *TestServer) BuildRefundStub(n int) {
+	ts.MockPayments.On("Name").Return("fake")
+	ts.MockPayments.On("Refund", mock.Anything, mock.Anything).
+		Return(func(captureID string, amount int64) (payments.Transaction, error) {
+			return payments.Transaction{
+				ID:     strings.Replace(captureID, "capt", "rfnd", 1),
+				Amount: amount,
+				Status: payments.StatusSucceeded,
+			}, nil
+		}, nil).
+		Times(n)
+	ts.MockDBStore.On("CreateRefundTx", mock.Anything, mock.MatchedBy(func(arg db.CreateRefundTxParams) bool {
+		return len(arg.Payments) == n
+	})).
+		Return(func(_ context.Context, arg db.CreateRefundTxParams) (db.Refund, error) {
+			return db.Refund{
+				ID:            util.RandomID(),
+				ReservationID: arg.ReservationID,
+				Paid:          arg.Paid,
+				PolicyAmount:  arg.PolicyAmount,
+				Amount:        arg.Amount,
+				Reason:        arg.Reason,
+				UserID:        arg.UserID,
+			}, nil
+		}, nil).
+		Once()
+}
+
 // NewTestRequest creates a new get request for use in testing
 func (ts *TestServer) NewRequest(method string, url string, body io.Reader) *http.Request {
 	return httptest.NewRequest(method, url, body)
@@ -107,6 +263,9 @@ func (ts *TestServer) NewRequestWithSession(t *testing.T, method string, url str
 	require.NoError(t, err)
 	require.NotNil(t, ctx)
 
+	// the admin panels require a role, so requests are made as the owner unless a test puts another access level
+	app.Session.Put(ctx, "access_level", int64(RoleOwner))
+
 	return r.WithContext(ctx)
 }
 
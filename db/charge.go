@@ -0,0 +1,47 @@
+package db
+
+import "context"
+
+// QuoteCharge holds the amount of a tax or fee charged on a stay, in cents
+type QuoteCharge struct {
+	ChargeID int64  `json:"charge_id"`
+	Name     string `json:"name"`
+	Amount   int64  `json:"amount"`
+}
+
+// AmountFor returns the amount of charge c on a stay of nights and guests priced at price, in cents.
+// A percent charge is a percentage of price, in hundredths of a percent, rounded to the nearest cent.
+// Other charges are fixed amounts per night, per guest per night, or per stay.
+func (c Charge) AmountFor(price int64, nights, guests int32) int64 {
+	switch c.Kind {
+	case ChargeKindPercent:
+		return (price*c.Amount + 5000) / 10000
+	case ChargeKindPerNight:
+		return c.Amount * int64(nights)
+	case ChargeKindPerPersonNight:
+		return c.Amount * int64(nights) * int64(guests)
+	case ChargeKindPerStay:
+		return c.Amount
+	default:
+		return 0
+	}
+}
+
+// createReservationCharges saves charges as the itemised charges of reservation reservationID
+func (q *Queries) createReservationCharges(ctx context.Context, reservationID int64, charges []QuoteCharge) error {
+	for _, c := range charges {
+		arg := CreateReservationChargeParams{
+			ReservationID: reservationID,
+			Name:          c.Name,
+			Amount:        c.Amount,
+		}
+		arg.ChargeID.Scan(c.ChargeID)
+
+		_, err := q.CreateReservationCharge(ctx, arg)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
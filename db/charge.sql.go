@@ -0,0 +1,109 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: charge.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createCharge = `-- name: CreateCharge :one
+INSERT INTO charges (
+  name, kind, amount
+) VALUES (
+  $1, $2, $3
+)
+RETURNING id, name, kind, amount, created_at, updated_at
+`
+
+type CreateChargeParams struct {
+	Name   string     `json:"name"`
+	Kind   ChargeKind `json:"kind"`
+	Amount int64      `json:"amount"`
+}
+
+func (q *Queries) CreateCharge(ctx context.Context, arg CreateChargeParams) (Charge, error) {
+	row := q.db.QueryRow(ctx, createCharge, arg.Name, arg.Kind, arg.Amount)
+	var i Charge
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Kind,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteAllCharges = `-- name: DeleteAllCharges :exec
+DELETE FROM charges
+`
+
+func (q *Queries) DeleteAllCharges(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, deleteAllCharges)
+	return err
+}
+
+const deleteCharges = `-- name: DeleteCharges :exec
+DELETE FROM charges
+WHERE id = ANY($1::bigint[])
+`
+
+func (q *Queries) DeleteCharges(ctx context.Context, ids []int64) error {
+	_, err := q.db.Exec(ctx, deleteCharges, ids)
+	return err
+}
+
+const getCharge = `-- name: GetCharge :one
+SELECT id, name, kind, amount, created_at, updated_at FROM charges
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetCharge(ctx context.Context, id int64) (Charge, error) {
+	row := q.db.QueryRow(ctx, getCharge, id)
+	var i Charge
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Kind,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listCharges = `-- name: ListCharges :many
+SELECT id, name, kind, amount, created_at, updated_at FROM charges
+ORDER BY id
+`
+
+func (q *Queries) ListCharges(ctx context.Context) ([]Charge, error) {
+	rows, err := q.db.Query(ctx, listCharges)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Charge{}
+	for rows.Next() {
+		var i Charge
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Kind,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
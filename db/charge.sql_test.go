@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/bookings-web-app/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomCharge creates a charge of kind and amount, which is deleted when the test ends
+// so that it is not added to the quotes of other tests
+func createRandomCharge(t *testing.T, kind ChargeKind, amount int64) Charge {
+	arg := CreateChargeParams{
+		Name:   util.RandomName(),
+		Kind:   kind,
+		Amount: amount,
+	}
+
+	c, err := testStore.CreateCharge(context.Background(), arg)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		testStore.DeleteCharges(context.Background(), []int64{c.ID})
+	})
+
+	assert.NotEmpty(t, c.ID)
+	assert.Equal(t, arg.Name, c.Name)
+	assert.Equal(t, arg.Kind, c.Kind)
+	assert.Equal(t, arg.Amount, c.Amount)
+	assert.WithinDuration(t, time.Now(), c.CreatedAt.Time, time.Second)
+	assert.WithinDuration(t, time.Now(), c.UpdatedAt.Time, time.Second)
+
+	return c
+}
+
+func TestQueries_CreateCharge(t *testing.T) {
+	createRandomCharge(t, ChargeKindPercent, 1700)
+}
+
+func TestQueries_ListCharges(t *testing.T) {
+	vat := createRandomCharge(t, ChargeKindPercent, 1700)
+	cleaning := createRandomCharge(t, ChargeKindPerStay, 4000)
+
+	charges, err := testStore.ListCharges(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, charges, vat)
+	assert.Contains(t, charges, cleaning)
+}
+
+func TestQueries_DeleteCharges(t *testing.T) {
+	c := createRandomCharge(t, ChargeKindPerNight, 500)
+
+	err := testStore.DeleteCharges(context.Background(), []int64{c.ID})
+	require.NoError(t, err)
+
+	_, err = testStore.GetCharge(context.Background(), c.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
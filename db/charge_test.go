@@ -0,0 +1,30 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCharge_AmountFor(t *testing.T) {
+	tests := []struct {
+		name   string
+		kind   ChargeKind
+		amount int64
+		want   int64
+	}{
+		// a stay of 3 nights and 2 guests priced at $250.00
+		{name: "Percent", kind: ChargeKindPercent, amount: 1700, want: 4250},
+		{name: "Percent Rounded", kind: ChargeKindPercent, amount: 775, want: 1938},
+		{name: "Per Night", kind: ChargeKindPerNight, amount: 500, want: 1500},
+		{name: "Per Person Night", kind: ChargeKindPerPersonNight, amount: 250, want: 1500},
+		{name: "Per Stay", kind: ChargeKindPerStay, amount: 4000, want: 4000},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := Charge{Kind: test.kind, Amount: test.amount}
+			assert.Equal(t, test.want, c.AmountFor(25000, 3, 2))
+		})
+	}
+}
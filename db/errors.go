@@ -0,0 +1,44 @@
+package db
+
+import "errors"
+
+var (
+	// ErrReservationCancelled is returned when trying to change a reservation that was already cancelled
+	ErrReservationCancelled = errors.New("reservation is already cancelled")
+
+	// ErrReservationClosed is returned when trying to change the stay of a reservation
+	// that was already checked out or marked as a no show, or when a guest changes a reservation already checked in
+	ErrReservationClosed = errors.New("reservation can no longer be changed")
+
+	// ErrInvalidDateRange is returned when the end date of a stay is not after its start date
+	ErrInvalidDateRange = errors.New("invalid date range")
+
+	// ErrInvalidStatusTransition is returned when changing the status of a reservation to a status it cannot move to
+	ErrInvalidStatusTransition = errors.New("invalid reservation status transition")
+
+	// ErrEarlyCheckIn is returned when checking in a guest before the arrival date of the reservation
+	ErrEarlyCheckIn = errors.New("check-in before the arrival date")
+
+	// ErrFolioUnsettled is returned when checking out a guest whose folio has an unsettled balance,
+	// wrapped by a FolioBalanceError
+	ErrFolioUnsettled = errors.New("folio has an unsettled balance")
+
+	// ErrOwnerBlockConflict is returned when an owner block overlaps a reservation of the room
+	ErrOwnerBlockConflict = errors.New("owner block overlaps a reservation")
+
+	// ErrPromoCodeRejected is returned when a promo code does not apply to a stay, wrapped by a PromoCodeError
+	ErrPromoCodeRejected = errors.New("promo code rejected")
+
+	// ErrStayRuleViolation is returned when a stay breaks a stay rule of the room, wrapped by a StayRuleError
+	ErrStayRuleViolation = errors.New("stay breaks a stay rule")
+
+	// ErrRefundExceedsPaid is returned when recording a refund of more than the amount paid and not yet refunded
+	ErrRefundExceedsPaid = errors.New("refund exceeds the amount paid")
+
+	// ErrRoomHasReservations is returned when deleting a room that has reservations,
+	// as reservations and their payments, refunds, folios and invoices are never deleted
+	ErrRoomHasReservations = errors.New("room has reservations")
+
+	// ErrRoomUnavailable is returned when the room of a reservation is not available on the requested dates
+	ErrRoomUnavailable = errors.New("room is unavailable")
+)
@@ -0,0 +1,81 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: exchange_rate.sql
+
+package db
+
+import (
+	"context"
+)
+
+const deleteExchangeRates = `-- name: DeleteExchangeRates :exec
+DELETE FROM exchange_rates
+WHERE currency = ANY($1::varchar[])
+`
+
+func (q *Queries) DeleteExchangeRates(ctx context.Context, currencies []string) error {
+	_, err := q.db.Exec(ctx, deleteExchangeRates, currencies)
+	return err
+}
+
+const listExchangeRates = `-- name: ListExchangeRates :many
+SELECT currency, symbol, rate, updated_at FROM exchange_rates
+ORDER BY currency
+`
+
+func (q *Queries) ListExchangeRates(ctx context.Context) ([]ExchangeRate, error) {
+	rows, err := q.db.Query(ctx, listExchangeRates)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ExchangeRate{}
+	for rows.Next() {
+		var i ExchangeRate
+		if err := rows.Scan(
+			&i.Currency,
+			&i.Symbol,
+			&i.Rate,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertExchangeRate = `-- name: UpsertExchangeRate :one
+INSERT INTO exchange_rates (
+  currency, symbol, rate
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (currency) DO UPDATE
+SET symbol = EXCLUDED.symbol,
+    rate = EXCLUDED.rate,
+    updated_at = now()
+RETURNING currency, symbol, rate, updated_at
+`
+
+type UpsertExchangeRateParams struct {
+	Currency string `json:"currency"`
+	Symbol   string `json:"symbol"`
+	Rate     int64  `json:"rate"`
+}
+
+func (q *Queries) UpsertExchangeRate(ctx context.Context, arg UpsertExchangeRateParams) (ExchangeRate, error) {
+	row := q.db.QueryRow(ctx, upsertExchangeRate, arg.Currency, arg.Symbol, arg.Rate)
+	var i ExchangeRate
+	err := row.Scan(
+		&i.Currency,
+		&i.Symbol,
+		&i.Rate,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
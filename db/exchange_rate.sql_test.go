@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/bookings-web-app/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomExchangeRate creates the exchange rate of a random currency, which is deleted when the test ends
+func createRandomExchangeRate(t *testing.T) ExchangeRate {
+	arg := UpsertExchangeRateParams{
+		Currency: strings.ToUpper(util.RandomString(3)),
+		Symbol:   util.RandomString(2),
+		Rate:     util.RandomInt64(1, 100_000_000),
+	}
+
+	rate, err := testStore.UpsertExchangeRate(context.Background(), arg)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		testStore.DeleteExchangeRates(context.Background(), []string{rate.Currency})
+	})
+
+	assert.Equal(t, arg.Currency, rate.Currency)
+	assert.Equal(t, arg.Symbol, rate.Symbol)
+	assert.Equal(t, arg.Rate, rate.Rate)
+	assert.WithinDuration(t, time.Now(), rate.UpdatedAt.Time, time.Second)
+
+	return rate
+}
+
+func TestQueries_UpsertExchangeRate(t *testing.T) {
+	rate := createRandomExchangeRate(t)
+
+	// the rate of an existing currency is updated
+	arg := UpsertExchangeRateParams{
+		Currency: rate.Currency,
+		Symbol:   rate.Symbol,
+		Rate:     rate.Rate + 1,
+	}
+
+	updated, err := testStore.UpsertExchangeRate(context.Background(), arg)
+	require.NoError(t, err)
+	assert.Equal(t, arg.Rate, updated.Rate)
+	assert.False(t, updated.UpdatedAt.Time.Before(rate.UpdatedAt.Time))
+
+	t.Run("Invalid Rate", func(t *testing.T) {
+		arg.Rate = 0
+		_, err := testStore.UpsertExchangeRate(context.Background(), arg)
+		require.Error(t, err)
+	})
+}
+
+func TestQueries_ListExchangeRates(t *testing.T) {
+	rate1 := createRandomExchangeRate(t)
+	rate2 := createRandomExchangeRate(t)
+
+	rates, err := testStore.ListExchangeRates(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, rates, rate1)
+	assert.Contains(t, rates, rate2)
+}
+
+func TestQueries_DeleteExchangeRates(t *testing.T) {
+	rate := createRandomExchangeRate(t)
+
+	err := testStore.DeleteExchangeRates(context.Background(), []string{rate.Currency})
+	require.NoError(t, err)
+
+	rates, err := testStore.ListExchangeRates(context.Background())
+	require.NoError(t, err)
+	assert.NotContains(t, rates, rate)
+}
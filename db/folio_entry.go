@@ -0,0 +1,17 @@
+package db
+
+import "fmt"
+
+// FolioBalanceError is returned when checking out a guest whose folio has an unsettled balance.
+// It wraps ErrFolioUnsettled.
+type FolioBalanceError struct {
+	Balance int64
+}
+
+func (e *FolioBalanceError) Error() string {
+	return fmt.Sprintf("folio has an unsettled balance of %d", e.Balance)
+}
+
+func (e *FolioBalanceError) Unwrap() error {
+	return ErrFolioUnsettled
+}
@@ -0,0 +1,132 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: folio_entry.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createFolioEntry = `-- name: CreateFolioEntry :one
+INSERT INTO folio_entries (
+  reservation_id, kind, description, amount, user_id
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING id, reservation_id, kind, description, amount, user_id, voided_at, voided_by, created_at
+`
+
+type CreateFolioEntryParams struct {
+	ReservationID int64          `json:"reservation_id"`
+	Kind          FolioEntryKind `json:"kind"`
+	Description   string         `json:"description"`
+	Amount        int64          `json:"amount"`
+	UserID        pgtype.Int8    `json:"user_id"`
+}
+
+func (q *Queries) CreateFolioEntry(ctx context.Context, arg CreateFolioEntryParams) (FolioEntry, error) {
+	row := q.db.QueryRow(ctx, createFolioEntry,
+		arg.ReservationID,
+		arg.Kind,
+		arg.Description,
+		arg.Amount,
+		arg.UserID,
+	)
+	var i FolioEntry
+	err := row.Scan(
+		&i.ID,
+		&i.ReservationID,
+		&i.Kind,
+		&i.Description,
+		&i.Amount,
+		&i.UserID,
+		&i.VoidedAt,
+		&i.VoidedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getFolioBalance = `-- name: GetFolioBalance :one
+SELECT COALESCE(SUM(CASE WHEN kind = 'charge' THEN amount ELSE -amount END), 0)::bigint AS balance
+FROM folio_entries
+WHERE reservation_id = $1 AND voided_at IS NULL
+`
+
+func (q *Queries) GetFolioBalance(ctx context.Context, reservationID int64) (int64, error) {
+	row := q.db.QueryRow(ctx, getFolioBalance, reservationID)
+	var balance int64
+	err := row.Scan(&balance)
+	return balance, err
+}
+
+const listFolioEntriesByReservation = `-- name: ListFolioEntriesByReservation :many
+SELECT id, reservation_id, kind, description, amount, user_id, voided_at, voided_by, created_at FROM folio_entries
+WHERE reservation_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListFolioEntriesByReservation(ctx context.Context, reservationID int64) ([]FolioEntry, error) {
+	rows, err := q.db.Query(ctx, listFolioEntriesByReservation, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FolioEntry{}
+	for rows.Next() {
+		var i FolioEntry
+		if err := rows.Scan(
+			&i.ID,
+			&i.ReservationID,
+			&i.Kind,
+			&i.Description,
+			&i.Amount,
+			&i.UserID,
+			&i.VoidedAt,
+			&i.VoidedBy,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const voidFolioEntry = `-- name: VoidFolioEntry :one
+UPDATE folio_entries
+SET voided_at = now(),
+    voided_by = $3
+WHERE id = $1 AND reservation_id = $2 AND voided_at IS NULL
+RETURNING id, reservation_id, kind, description, amount, user_id, voided_at, voided_by, created_at
+`
+
+type VoidFolioEntryParams struct {
+	ID            int64       `json:"id"`
+	ReservationID int64       `json:"reservation_id"`
+	VoidedBy      pgtype.Int8 `json:"voided_by"`
+}
+
+func (q *Queries) VoidFolioEntry(ctx context.Context, arg VoidFolioEntryParams) (FolioEntry, error) {
+	row := q.db.QueryRow(ctx, voidFolioEntry, arg.ID, arg.ReservationID, arg.VoidedBy)
+	var i FolioEntry
+	err := row.Scan(
+		&i.ID,
+		&i.ReservationID,
+		&i.Kind,
+		&i.Description,
+		&i.Amount,
+		&i.UserID,
+		&i.VoidedAt,
+		&i.VoidedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/bookings-web-app/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomFolioEntry(t *testing.T, r Reservation, user User, kind FolioEntryKind, amount int64) FolioEntry {
+	arg := CreateFolioEntryParams{
+		ReservationID: r.ID,
+		Kind:          kind,
+		Description:   util.RandomName(),
+		Amount:        amount,
+		UserID:        pgtype.Int8{Int64: user.ID, Valid: true},
+	}
+
+	entry, err := testStore.CreateFolioEntry(context.Background(), arg)
+	require.NoError(t, err)
+	assert.NotEmpty(t, entry.ID)
+	assert.Equal(t, arg.ReservationID, entry.ReservationID)
+	assert.Equal(t, arg.Kind, entry.Kind)
+	assert.Equal(t, arg.Description, entry.Description)
+	assert.Equal(t, arg.Amount, entry.Amount)
+	assert.Equal(t, arg.UserID, entry.UserID)
+	assert.False(t, entry.VoidedAt.Valid)
+	assert.False(t, entry.VoidedBy.Valid)
+	assert.WithinDuration(t, time.Now(), entry.CreatedAt.Time, time.Second)
+
+	return entry
+}
+
+func TestQueries_CreateFolioEntry(t *testing.T) {
+	r := createRandomReservation(t, createRandomRoom(t))
+	createRandomFolioEntry(t, r, createRandomUser(t, util.RandomPassword()), FolioEntryKindCharge, 1500)
+
+	t.Run("Invalid Amount", func(t *testing.T) {
+		_, err := testStore.CreateFolioEntry(context.Background(), CreateFolioEntryParams{
+			ReservationID: r.ID,
+			Kind:          FolioEntryKindPayment,
+			Description:   util.RandomName(),
+			Amount:        0,
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestQueries_GetFolioBalance(t *testing.T) {
+	r := createRandomReservation(t, createRandomRoom(t))
+	user := createRandomUser(t, util.RandomPassword())
+
+	// an empty folio is settled
+	balance, err := testStore.GetFolioBalance(context.Background(), r.ID)
+	require.NoError(t, err)
+	assert.Zero(t, balance)
+
+	createRandomFolioEntry(t, r, user, FolioEntryKindCharge, 1500)
+	createRandomFolioEntry(t, r, user, FolioEntryKindCharge, 2500)
+	createRandomFolioEntry(t, r, user, FolioEntryKindPayment, 1000)
+	voided := createRandomFolioEntry(t, r, user, FolioEntryKindCharge, 9900)
+
+	_, err = testStore.VoidFolioEntry(context.Background(), VoidFolioEntryParams{
+		ID:            voided.ID,
+		ReservationID: r.ID,
+	})
+	require.NoError(t, err)
+
+	balance, err = testStore.GetFolioBalance(context.Background(), r.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3000), balance)
+}
+
+func TestQueries_ListFolioEntriesByReservation(t *testing.T) {
+	r := createRandomReservation(t, createRandomRoom(t))
+	user := createRandomUser(t, util.RandomPassword())
+	entry1 := createRandomFolioEntry(t, r, user, FolioEntryKindCharge, 1500)
+	entry2 := createRandomFolioEntry(t, r, user, FolioEntryKindPayment, 1500)
+
+	entries, err := testStore.ListFolioEntriesByReservation(context.Background(), r.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []FolioEntry{entry1, entry2}, entries)
+}
+
+func TestQueries_VoidFolioEntry(t *testing.T) {
+	r := createRandomReservation(t, createRandomRoom(t))
+	user := createRandomUser(t, util.RandomPassword())
+	entry := createRandomFolioEntry(t, r, user, FolioEntryKindCharge, 1500)
+
+	arg := VoidFolioEntryParams{
+		ID:            entry.ID,
+		ReservationID: r.ID,
+		VoidedBy:      pgtype.Int8{Int64: user.ID, Valid: true},
+	}
+
+	voided, err := testStore.VoidFolioEntry(context.Background(), arg)
+	require.NoError(t, err)
+	assert.Equal(t, entry.ID, voided.ID)
+	assert.Equal(t, entry.Amount, voided.Amount)
+	assert.Equal(t, arg.VoidedBy, voided.VoidedBy)
+	assert.WithinDuration(t, time.Now(), voided.VoidedAt.Time, time.Second)
+
+	t.Run("Already Voided", func(t *testing.T) {
+		_, err := testStore.VoidFolioEntry(context.Background(), arg)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("Other Reservation", func(t *testing.T) {
+		other := createRandomFolioEntry(t, r, user, FolioEntryKindCharge, 1500)
+		_, err := testStore.VoidFolioEntry(context.Background(), VoidFolioEntryParams{
+			ID:            other.ID,
+			ReservationID: createRandomReservation(t, createRandomRoom(t)).ID,
+		})
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+}
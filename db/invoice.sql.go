@@ -0,0 +1,109 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: invoice.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createInvoice = `-- name: CreateInvoice :one
+INSERT INTO invoices (
+  property, number, reservation_id
+) VALUES (
+  $1, $2, $3
+)
+RETURNING id, property, number, reservation_id, document, issued_at, updated_at
+`
+
+type CreateInvoiceParams struct {
+	Property      string `json:"property"`
+	Number        int64  `json:"number"`
+	ReservationID int64  `json:"reservation_id"`
+}
+
+func (q *Queries) CreateInvoice(ctx context.Context, arg CreateInvoiceParams) (Invoice, error) {
+	row := q.db.QueryRow(ctx, createInvoice, arg.Property, arg.Number, arg.ReservationID)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.Property,
+		&i.Number,
+		&i.ReservationID,
+		&i.Document,
+		&i.IssuedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getInvoiceByReservation = `-- name: GetInvoiceByReservation :one
+SELECT id, property, number, reservation_id, document, issued_at, updated_at FROM invoices
+WHERE reservation_id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetInvoiceByReservation(ctx context.Context, reservationID int64) (Invoice, error) {
+	row := q.db.QueryRow(ctx, getInvoiceByReservation, reservationID)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.Property,
+		&i.Number,
+		&i.ReservationID,
+		&i.Document,
+		&i.IssuedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const nextInvoiceNumber = `-- name: NextInvoiceNumber :one
+INSERT INTO invoice_sequences (
+  property, last_number
+) VALUES (
+  $1, 1
+)
+ON CONFLICT (property) DO UPDATE
+SET last_number = invoice_sequences.last_number + 1
+RETURNING last_number
+`
+
+// NextInvoiceNumber increments the invoice sequence of the property and locks it until the transaction ends,
+// so that a number is only used by a committed invoice.
+func (q *Queries) NextInvoiceNumber(ctx context.Context, property string) (int64, error) {
+	row := q.db.QueryRow(ctx, nextInvoiceNumber, property)
+	var last_number int64
+	err := row.Scan(&last_number)
+	return last_number, err
+}
+
+const updateInvoiceDocument = `-- name: UpdateInvoiceDocument :one
+UPDATE invoices
+SET document = $2,
+    updated_at = now()
+WHERE id = $1
+RETURNING id, property, number, reservation_id, document, issued_at, updated_at
+`
+
+type UpdateInvoiceDocumentParams struct {
+	ID       int64  `json:"id"`
+	Document string `json:"document"`
+}
+
+func (q *Queries) UpdateInvoiceDocument(ctx context.Context, arg UpdateInvoiceDocumentParams) (Invoice, error) {
+	row := q.db.QueryRow(ctx, updateInvoiceDocument, arg.ID, arg.Document)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.Property,
+		&i.Number,
+		&i.ReservationID,
+		&i.Document,
+		&i.IssuedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
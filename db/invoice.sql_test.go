@@ -0,0 +1,99 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/bookings-web-app/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomInvoice(t *testing.T, property string, r Reservation) Invoice {
+	number, err := testStore.NextInvoiceNumber(context.Background(), property)
+	require.NoError(t, err)
+
+	arg := CreateInvoiceParams{
+		Property:      property,
+		Number:        number,
+		ReservationID: r.ID,
+	}
+
+	invoice, err := testStore.CreateInvoice(context.Background(), arg)
+	require.NoError(t, err)
+	assert.NotEmpty(t, invoice.ID)
+	assert.Equal(t, arg.Property, invoice.Property)
+	assert.Equal(t, arg.Number, invoice.Number)
+	assert.Equal(t, arg.ReservationID, invoice.ReservationID)
+	assert.Empty(t, invoice.Document)
+	assert.WithinDuration(t, time.Now(), invoice.IssuedAt.Time, time.Second)
+	assert.WithinDuration(t, time.Now(), invoice.UpdatedAt.Time, time.Second)
+
+	return invoice
+}
+
+func TestQueries_NextInvoiceNumber(t *testing.T) {
+	property := util.RandomName()
+
+	for i := int64(1); i <= 3; i++ {
+		number, err := testStore.NextInvoiceNumber(context.Background(), property)
+		require.NoError(t, err)
+		assert.Equal(t, i, number)
+	}
+
+	// every property has its own sequence
+	number, err := testStore.NextInvoiceNumber(context.Background(), util.RandomName())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), number)
+}
+
+func TestQueries_CreateInvoice(t *testing.T) {
+	property := util.RandomName()
+	invoice := createRandomInvoice(t, property, createRandomReservation(t, createRandomRoom(t)))
+
+	// a reservation has a single invoice
+	_, err := testStore.CreateInvoice(context.Background(), CreateInvoiceParams{
+		Property:      property,
+		Number:        invoice.Number + 1,
+		ReservationID: invoice.ReservationID,
+	})
+	require.Error(t, err)
+
+	// an invoice number is used once by a property
+	_, err = testStore.CreateInvoice(context.Background(), CreateInvoiceParams{
+		Property:      property,
+		Number:        invoice.Number,
+		ReservationID: createRandomReservation(t, createRandomRoom(t)).ID,
+	})
+	require.Error(t, err)
+}
+
+func TestQueries_GetInvoiceByReservation(t *testing.T) {
+	r := createRandomReservation(t, createRandomRoom(t))
+	invoice1 := createRandomInvoice(t, util.RandomName(), r)
+
+	invoice2, err := testStore.GetInvoiceByReservation(context.Background(), r.ID)
+	require.NoError(t, err)
+	assert.Equal(t, invoice1, invoice2)
+
+	_, err = testStore.GetInvoiceByReservation(context.Background(), createRandomReservation(t, createRandomRoom(t)).ID)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestQueries_UpdateInvoiceDocument(t *testing.T) {
+	invoice1 := createRandomInvoice(t, util.RandomName(), createRandomReservation(t, createRandomRoom(t)))
+
+	arg := UpdateInvoiceDocumentParams{
+		ID:       invoice1.ID,
+		Document: "<html>" + util.RandomNote() + "</html>",
+	}
+
+	invoice2, err := testStore.UpdateInvoiceDocument(context.Background(), arg)
+	require.NoError(t, err)
+	assert.Equal(t, invoice1.Number, invoice2.Number)
+	assert.Equal(t, arg.Document, invoice2.Document)
+	assert.Equal(t, invoice1.IssuedAt, invoice2.IssuedAt)
+	assert.True(t, invoice2.UpdatedAt.Time.After(invoice1.UpdatedAt.Time))
+}
@@ -0,0 +1,3 @@
+ALTER TABLE "reservations" DROP COLUMN IF EXISTS "cancellation_fee_percent";
+ALTER TABLE "reservations" DROP COLUMN IF EXISTS "cancelled_by";
+ALTER TABLE "reservations" DROP COLUMN IF EXISTS "cancelled_at";
@@ -0,0 +1,5 @@
+ALTER TABLE "reservations" ADD COLUMN "cancelled_at" timestamptz;
+
+ALTER TABLE "reservations" ADD COLUMN "cancelled_by" varchar(255);
+
+ALTER TABLE "reservations" ADD COLUMN "cancellation_fee_percent" integer NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+ALTER TABLE "reservations" ADD COLUMN "parent_code" varchar(255);
+
+CREATE INDEX ON "reservations" ("parent_code");
@@ -0,0 +1,11 @@
+ALTER TABLE "reservations" DROP CONSTRAINT IF EXISTS "chk_reservations_guests";
+
+ALTER TABLE "reservations" DROP COLUMN IF EXISTS "children";
+
+ALTER TABLE "reservations" DROP COLUMN IF EXISTS "adults";
+
+ALTER TABLE "rooms" DROP COLUMN IF EXISTS "max_occupancy";
+
+ALTER TABLE "rooms" DROP COLUMN IF EXISTS "max_children";
+
+ALTER TABLE "rooms" DROP COLUMN IF EXISTS "max_adults";
@@ -0,0 +1,11 @@
+ALTER TABLE "rooms" ADD COLUMN "max_adults" integer NOT NULL DEFAULT 2;
+
+ALTER TABLE "rooms" ADD COLUMN "max_children" integer NOT NULL DEFAULT 0;
+
+ALTER TABLE "rooms" ADD COLUMN "max_occupancy" integer NOT NULL DEFAULT 2;
+
+ALTER TABLE "reservations" ADD COLUMN "adults" integer NOT NULL DEFAULT 1;
+
+ALTER TABLE "reservations" ADD COLUMN "children" integer NOT NULL DEFAULT 0;
+
+ALTER TABLE "reservations" ADD CONSTRAINT "chk_reservations_guests" CHECK ("adults" >= 1 AND "children" >= 0);
@@ -0,0 +1,16 @@
+DELETE FROM "room_restrictions" WHERE "restriction" = 'hold';
+
+ALTER TABLE "room_restrictions" DROP COLUMN IF EXISTS "expires_at";
+
+ALTER TABLE "room_restrictions" DROP COLUMN IF EXISTS "hold_token";
+
+ALTER TYPE "restriction" RENAME TO "restriction_old";
+
+CREATE TYPE "restriction" AS ENUM (
+  'reservation',
+  'owner_block'
+);
+
+ALTER TABLE "room_restrictions" ALTER COLUMN "restriction" TYPE "restriction" USING "restriction"::text::"restriction";
+
+DROP TYPE "restriction_old";
@@ -0,0 +1,9 @@
+ALTER TYPE "restriction" ADD VALUE 'hold';
+
+ALTER TABLE "room_restrictions" ADD COLUMN "hold_token" varchar(255);
+
+ALTER TABLE "room_restrictions" ADD COLUMN "expires_at" timestamptz;
+
+CREATE INDEX ON "room_restrictions" ("hold_token");
+
+CREATE INDEX ON "room_restrictions" ("expires_at");
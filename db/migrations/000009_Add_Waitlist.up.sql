@@ -0,0 +1,27 @@
+CREATE TABLE "waitlist_entries" (
+  "id" bigserial PRIMARY KEY,
+  "token" varchar(255) NOT NULL,
+  "first_name" varchar(255) NOT NULL,
+  "last_name" varchar(255) NOT NULL,
+  "email" varchar(255) NOT NULL,
+  "start_date" date NOT NULL,
+  "end_date" date NOT NULL,
+  "room_id" bigint,
+  "adults" integer NOT NULL DEFAULT 1,
+  "children" integer NOT NULL DEFAULT 0,
+  "offered_room_id" bigint,
+  "notified_at" timestamptz,
+  "expires_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "waitlist_entries" ("token");
+
+CREATE INDEX ON "waitlist_entries" ("start_date", "end_date");
+
+CREATE INDEX ON "waitlist_entries" ("created_at");
+
+ALTER TABLE "waitlist_entries" ADD CONSTRAINT "fk_waitlist_entries_room_id" FOREIGN KEY ("room_id") REFERENCES "rooms" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE "waitlist_entries" ADD CONSTRAINT "fk_waitlist_entries_offered_room_id" FOREIGN KEY ("offered_room_id") REFERENCES "rooms" ("id") ON DELETE SET NULL ON UPDATE CASCADE;
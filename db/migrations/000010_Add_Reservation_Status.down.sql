@@ -0,0 +1,7 @@
+ALTER TABLE "reservations" DROP COLUMN IF EXISTS "no_show_at";
+ALTER TABLE "reservations" DROP COLUMN IF EXISTS "checked_out_at";
+ALTER TABLE "reservations" DROP COLUMN IF EXISTS "checked_in_at";
+ALTER TABLE "reservations" DROP COLUMN IF EXISTS "confirmed_at";
+ALTER TABLE "reservations" DROP COLUMN IF EXISTS "status";
+
+DROP TYPE IF EXISTS "reservation_status";
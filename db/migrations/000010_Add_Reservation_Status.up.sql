@@ -0,0 +1,22 @@
+CREATE TYPE "reservation_status" AS ENUM (
+  'pending',
+  'confirmed',
+  'checked_in',
+  'checked_out',
+  'cancelled',
+  'no_show'
+);
+
+ALTER TABLE "reservations" ADD COLUMN "status" reservation_status NOT NULL DEFAULT 'pending';
+
+ALTER TABLE "reservations" ADD COLUMN "confirmed_at" timestamptz;
+
+ALTER TABLE "reservations" ADD COLUMN "checked_in_at" timestamptz;
+
+ALTER TABLE "reservations" ADD COLUMN "checked_out_at" timestamptz;
+
+ALTER TABLE "reservations" ADD COLUMN "no_show_at" timestamptz;
+
+UPDATE "reservations" SET "status" = 'cancelled' WHERE "cancelled_at" IS NOT NULL;
+
+CREATE INDEX ON "reservations" ("status");
@@ -0,0 +1,10 @@
+ALTER TABLE "rooms" ADD COLUMN "slug" varchar(255);
+
+UPDATE "rooms" SET "slug" = trim(BOTH '-' FROM regexp_replace(lower(replace("name", '''', '')), '[^a-z0-9]+', '-', 'g'));
+
+UPDATE "rooms" r SET "slug" = r."slug" || '-' || r."id"
+WHERE EXISTS (SELECT 1 FROM "rooms" o WHERE o."slug" = r."slug" AND o."id" < r."id");
+
+ALTER TABLE "rooms" ALTER COLUMN "slug" SET NOT NULL;
+
+CREATE UNIQUE INDEX ON "rooms" ("slug");
@@ -0,0 +1,5 @@
+ALTER TABLE "reservations" DROP COLUMN IF EXISTS "total_price";
+
+ALTER TABLE "rooms" DROP CONSTRAINT IF EXISTS "chk_rooms_nightly_rate";
+
+ALTER TABLE "rooms" DROP COLUMN IF EXISTS "nightly_rate";
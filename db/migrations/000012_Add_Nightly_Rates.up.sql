@@ -0,0 +1,19 @@
+ALTER TABLE "rooms" ADD COLUMN "nightly_rate" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "rooms" ADD CONSTRAINT "chk_rooms_nightly_rate" CHECK ("nightly_rate" >= 0);
+
+UPDATE "rooms" SET "nightly_rate" = 25000 WHERE "slug" = 'generals-quarters';
+
+UPDATE "rooms" SET "nightly_rate" = 22000 WHERE "slug" = 'majors-suite';
+
+UPDATE "rooms" SET "nightly_rate" = 18000 WHERE "slug" = 'colonels-chamber';
+
+UPDATE "rooms" SET "nightly_rate" = 15000 WHERE "slug" = 'captains-retreat';
+
+UPDATE "rooms" SET "nightly_rate" = 20000 WHERE "slug" = 'admirals-haven';
+
+ALTER TABLE "reservations" ADD COLUMN "total_price" bigint NOT NULL DEFAULT 0;
+
+UPDATE "reservations" r SET "total_price" = (r."end_date" - r."start_date") * rm."nightly_rate"
+FROM "rooms" rm
+WHERE rm."id" = r."room_id";
@@ -0,0 +1,22 @@
+CREATE TABLE "room_rates" (
+  "id" bigserial PRIMARY KEY,
+  "room_id" bigint NOT NULL,
+  "name" varchar(255) NOT NULL DEFAULT '',
+  "start_date" date NOT NULL,
+  "end_date" date NOT NULL,
+  "days_of_week" integer NOT NULL DEFAULT 127,
+  "rate" bigint NOT NULL,
+  "priority" integer NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "room_rates" ("room_id", "start_date", "end_date");
+
+ALTER TABLE "room_rates" ADD CONSTRAINT "chk_room_rates_dates" CHECK ("end_date" >= "start_date");
+
+ALTER TABLE "room_rates" ADD CONSTRAINT "chk_room_rates_days_of_week" CHECK ("days_of_week" BETWEEN 1 AND 127);
+
+ALTER TABLE "room_rates" ADD CONSTRAINT "chk_room_rates_rate" CHECK ("rate" >= 0);
+
+ALTER TABLE "room_rates" ADD CONSTRAINT "fk_room_rates_room_id" FOREIGN KEY ("room_id") REFERENCES "rooms" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,23 @@
+CREATE TABLE "stay_rules" (
+  "id" bigserial PRIMARY KEY,
+  "room_id" bigint NOT NULL,
+  "start_date" date NOT NULL,
+  "end_date" date NOT NULL,
+  "days_of_week" integer NOT NULL DEFAULT 127,
+  "min_nights" integer NOT NULL DEFAULT 0,
+  "max_nights" integer NOT NULL DEFAULT 0,
+  "closed_to_arrival" boolean NOT NULL DEFAULT false,
+  "closed_to_departure" boolean NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "stay_rules" ("room_id", "start_date", "end_date");
+
+ALTER TABLE "stay_rules" ADD CONSTRAINT "chk_stay_rules_dates" CHECK ("end_date" >= "start_date");
+
+ALTER TABLE "stay_rules" ADD CONSTRAINT "chk_stay_rules_days_of_week" CHECK ("days_of_week" BETWEEN 1 AND 127);
+
+ALTER TABLE "stay_rules" ADD CONSTRAINT "chk_stay_rules_nights" CHECK ("min_nights" >= 0 AND "max_nights" >= 0 AND ("max_nights" = 0 OR "max_nights" >= "min_nights"));
+
+ALTER TABLE "stay_rules" ADD CONSTRAINT "fk_stay_rules_room_id" FOREIGN KEY ("room_id") REFERENCES "rooms" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,9 @@
+ALTER TABLE "reservations" DROP CONSTRAINT IF EXISTS "fk_reservations_promo_code_id";
+
+ALTER TABLE "reservations" DROP COLUMN IF EXISTS "discount";
+
+ALTER TABLE "reservations" DROP COLUMN IF EXISTS "promo_code_id";
+
+DROP TABLE IF EXISTS "promo_codes";
+
+DROP TYPE IF EXISTS "discount_kind";
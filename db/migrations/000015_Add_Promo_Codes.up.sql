@@ -0,0 +1,39 @@
+CREATE TYPE "discount_kind" AS ENUM (
+  'percent',
+  'fixed'
+);
+
+CREATE TABLE "promo_codes" (
+  "id" bigserial PRIMARY KEY,
+  "code" varchar(50) UNIQUE NOT NULL,
+  "description" varchar(255) NOT NULL DEFAULT '',
+  "discount_kind" discount_kind NOT NULL,
+  "discount_value" bigint NOT NULL,
+  "valid_from" date NOT NULL,
+  "valid_to" date NOT NULL,
+  "min_nights" integer NOT NULL DEFAULT 0,
+  "room_ids" bigint[] NOT NULL DEFAULT '{}',
+  "max_redemptions" integer NOT NULL DEFAULT 0,
+  "times_redeemed" integer NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "promo_codes" ADD CONSTRAINT "chk_promo_codes_code" CHECK ("code" = upper("code"));
+
+ALTER TABLE "promo_codes" ADD CONSTRAINT "chk_promo_codes_dates" CHECK ("valid_to" >= "valid_from");
+
+ALTER TABLE "promo_codes" ADD CONSTRAINT "chk_promo_codes_discount_value" CHECK (
+  "discount_value" > 0 AND ("discount_kind" <> 'percent' OR "discount_value" <= 100)
+);
+
+ALTER TABLE "promo_codes" ADD CONSTRAINT "chk_promo_codes_redemptions" CHECK (
+  "min_nights" >= 0 AND "max_redemptions" >= 0 AND "times_redeemed" >= 0
+  AND ("max_redemptions" = 0 OR "times_redeemed" <= "max_redemptions")
+);
+
+ALTER TABLE "reservations" ADD COLUMN "promo_code_id" bigint;
+
+ALTER TABLE "reservations" ADD COLUMN "discount" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "reservations" ADD CONSTRAINT "fk_reservations_promo_code_id" FOREIGN KEY ("promo_code_id") REFERENCES "promo_codes" ("id") ON DELETE SET NULL ON UPDATE CASCADE;
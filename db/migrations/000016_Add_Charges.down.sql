@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "reservation_charges";
+
+DROP TABLE IF EXISTS "charges";
+
+DROP TYPE IF EXISTS "charge_kind";
@@ -0,0 +1,34 @@
+CREATE TYPE "charge_kind" AS ENUM (
+  'percent',
+  'per_night',
+  'per_person_night',
+  'per_stay'
+);
+
+CREATE TABLE "charges" (
+  "id" bigserial PRIMARY KEY,
+  "name" varchar(100) NOT NULL,
+  "kind" charge_kind NOT NULL,
+  "amount" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "charges"."amount" IS 'hundredths of a percent for percent charges, cents otherwise';
+
+ALTER TABLE "charges" ADD CONSTRAINT "chk_charges_amount" CHECK ("amount" > 0);
+
+CREATE TABLE "reservation_charges" (
+  "id" bigserial PRIMARY KEY,
+  "reservation_id" bigint NOT NULL,
+  "charge_id" bigint,
+  "name" varchar(100) NOT NULL,
+  "amount" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "reservation_charges" ("reservation_id");
+
+ALTER TABLE "reservation_charges" ADD CONSTRAINT "fk_reservation_charges_reservation_id" FOREIGN KEY ("reservation_id") REFERENCES "reservations" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE "reservation_charges" ADD CONSTRAINT "fk_reservation_charges_charge_id" FOREIGN KEY ("charge_id") REFERENCES "charges" ("id") ON DELETE SET NULL ON UPDATE CASCADE;
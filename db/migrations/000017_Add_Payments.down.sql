@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "payments";
+
+DROP TYPE IF EXISTS "payment_status";
+
+DROP TYPE IF EXISTS "payment_kind";
@@ -0,0 +1,38 @@
+CREATE TYPE "payment_kind" AS ENUM (
+  'authorization',
+  'capture',
+  'refund'
+);
+
+CREATE TYPE "payment_status" AS ENUM (
+  'pending',
+  'succeeded',
+  'failed'
+);
+
+CREATE TABLE "payments" (
+  "id" bigserial PRIMARY KEY,
+  "reservation_id" bigint NOT NULL,
+  "parent_id" bigint,
+  "provider" varchar(50) NOT NULL,
+  "provider_ref" varchar(255) NOT NULL,
+  "kind" payment_kind NOT NULL,
+  "status" payment_status NOT NULL,
+  "amount" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "payments"."parent_id" IS 'authorization of a capture, or capture of a refund';
+
+COMMENT ON COLUMN "payments"."provider_ref" IS 'transaction id assigned by the payment provider';
+
+CREATE INDEX ON "payments" ("reservation_id");
+
+CREATE INDEX ON "payments" ("provider", "provider_ref");
+
+ALTER TABLE "payments" ADD CONSTRAINT "chk_payments_amount" CHECK ("amount" >= 0);
+
+ALTER TABLE "payments" ADD CONSTRAINT "fk_payments_reservation_id" FOREIGN KEY ("reservation_id") REFERENCES "reservations" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE "payments" ADD CONSTRAINT "fk_payments_parent_id" FOREIGN KEY ("parent_id") REFERENCES "payments" ("id") ON DELETE SET NULL ON UPDATE CASCADE;
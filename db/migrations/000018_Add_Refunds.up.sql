@@ -0,0 +1,34 @@
+CREATE TABLE "refunds" (
+  "id" bigserial PRIMARY KEY,
+  "reservation_id" bigint NOT NULL,
+  "paid" bigint NOT NULL,
+  "policy_amount" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "reason" varchar(255) NOT NULL DEFAULT '',
+  "user_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "refunds"."paid" IS 'amount paid and not yet refunded when the refund was made';
+
+COMMENT ON COLUMN "refunds"."policy_amount" IS 'amount refundable according to the cancellation policy';
+
+COMMENT ON COLUMN "refunds"."reason" IS 'reason for overriding the amount refundable according to the cancellation policy';
+
+COMMENT ON COLUMN "refunds"."user_id" IS 'staff member who made the refund, null for refunds of guest cancellations';
+
+CREATE INDEX ON "refunds" ("reservation_id");
+
+ALTER TABLE "refunds" ADD CONSTRAINT "chk_refunds_amount" CHECK ("amount" > 0 AND "amount" <= "paid");
+
+ALTER TABLE "refunds" ADD CONSTRAINT "chk_refunds_reason" CHECK ("amount" = "policy_amount" OR "reason" <> '');
+
+ALTER TABLE "refunds" ADD CONSTRAINT "fk_refunds_reservation_id" FOREIGN KEY ("reservation_id") REFERENCES "reservations" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE "refunds" ADD CONSTRAINT "fk_refunds_user_id" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE SET NULL ON UPDATE CASCADE;
+
+ALTER TABLE "payments" ADD COLUMN "refund_id" bigint;
+
+COMMENT ON COLUMN "payments"."refund_id" IS 'refund a refund transaction was made for';
+
+ALTER TABLE "payments" ADD CONSTRAINT "fk_payments_refund_id" FOREIGN KEY ("refund_id") REFERENCES "refunds" ("id") ON DELETE SET NULL ON UPDATE CASCADE;
@@ -0,0 +1,26 @@
+CREATE TABLE "invoice_sequences" (
+  "property" varchar(255) PRIMARY KEY,
+  "last_number" bigint NOT NULL DEFAULT 0
+);
+
+COMMENT ON COLUMN "invoice_sequences"."last_number" IS 'last invoice number issued by the property';
+
+CREATE TABLE "invoices" (
+  "id" bigserial PRIMARY KEY,
+  "property" varchar(255) NOT NULL,
+  "number" bigint NOT NULL,
+  "reservation_id" bigint NOT NULL,
+  "document" text NOT NULL DEFAULT '',
+  "issued_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "invoices"."number" IS 'sequential invoice number of the property, without gaps';
+
+COMMENT ON COLUMN "invoices"."document" IS 'rendered invoice document, empty until rendered';
+
+CREATE UNIQUE INDEX ON "invoices" ("property", "number");
+
+CREATE UNIQUE INDEX ON "invoices" ("reservation_id");
+
+ALTER TABLE "invoices" ADD CONSTRAINT "fk_invoices_reservation_id" FOREIGN KEY ("reservation_id") REFERENCES "reservations" ("id") ON DELETE RESTRICT ON UPDATE CASCADE;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "folio_entries";
+
+DROP TYPE IF EXISTS "folio_entry_kind";
@@ -0,0 +1,32 @@
+CREATE TYPE "folio_entry_kind" AS ENUM (
+  'charge',
+  'payment'
+);
+
+CREATE TABLE "folio_entries" (
+  "id" bigserial PRIMARY KEY,
+  "reservation_id" bigint NOT NULL,
+  "kind" folio_entry_kind NOT NULL,
+  "description" varchar(255) NOT NULL,
+  "amount" bigint NOT NULL,
+  "user_id" bigint,
+  "voided_at" timestamptz,
+  "voided_by" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "folio_entries"."amount" IS 'charges add the amount to the balance of the folio, and payments subtract it';
+
+COMMENT ON COLUMN "folio_entries"."user_id" IS 'staff member who posted the entry';
+
+COMMENT ON COLUMN "folio_entries"."voided_by" IS 'staff member who voided the entry';
+
+CREATE INDEX ON "folio_entries" ("reservation_id");
+
+ALTER TABLE "folio_entries" ADD CONSTRAINT "chk_folio_entries_amount" CHECK ("amount" > 0);
+
+ALTER TABLE "folio_entries" ADD CONSTRAINT "fk_folio_entries_reservation_id" FOREIGN KEY ("reservation_id") REFERENCES "reservations" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE "folio_entries" ADD CONSTRAINT "fk_folio_entries_user_id" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE SET NULL ON UPDATE CASCADE;
+
+ALTER TABLE "folio_entries" ADD CONSTRAINT "fk_folio_entries_voided_by" FOREIGN KEY ("voided_by") REFERENCES "users" ("id") ON DELETE SET NULL ON UPDATE CASCADE;
@@ -0,0 +1,12 @@
+CREATE TABLE "exchange_rates" (
+  "currency" varchar(3) PRIMARY KEY,
+  "symbol" varchar(8) NOT NULL,
+  "rate" bigint NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "exchange_rates"."currency" IS 'ISO 4217 code of the display currency';
+
+COMMENT ON COLUMN "exchange_rates"."rate" IS 'millionths of the currency per unit of the base currency';
+
+ALTER TABLE "exchange_rates" ADD CONSTRAINT "chk_exchange_rates_rate" CHECK ("rate" > 0);
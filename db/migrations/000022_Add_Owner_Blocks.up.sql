@@ -0,0 +1,3 @@
+ALTER TABLE "room_restrictions" ADD COLUMN "reason" varchar(255) NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN "room_restrictions"."reason" IS 'reason the owner blocked the room, such as personal use or repairs';
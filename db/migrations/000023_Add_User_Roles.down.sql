@@ -0,0 +1,5 @@
+COMMENT ON COLUMN "users"."access_level" IS NULL;
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "password_token_expires_at";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "password_token";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "deactivated_at";
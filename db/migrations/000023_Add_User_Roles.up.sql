@@ -0,0 +1,9 @@
+ALTER TABLE "users" ADD COLUMN "deactivated_at" timestamptz;
+ALTER TABLE "users" ADD COLUMN "password_token" varchar(255);
+ALTER TABLE "users" ADD COLUMN "password_token_expires_at" timestamptz;
+
+CREATE UNIQUE INDEX ON "users" ("password_token");
+
+COMMENT ON COLUMN "users"."access_level" IS 'role of the staff user: 1 owner, 2 manager, 3 front desk, 4 read-only';
+COMMENT ON COLUMN "users"."deactivated_at" IS 'deactivated users cannot log in';
+COMMENT ON COLUMN "users"."password_token" IS 'token of the link mailed to the user to set a password';
@@ -0,0 +1,2 @@
+UPDATE "rooms" SET "max_adults" = 2, "max_children" = 0, "max_occupancy" = 2
+WHERE "name" IN ('General''s Quarters', 'Major''s Suite', 'Colonel''s Chamber', 'Captain''s Retreat', 'Admiral''s Haven');
@@ -0,0 +1,5 @@
+UPDATE "rooms" SET "max_adults" = 2, "max_children" = 2, "max_occupancy" = 4 WHERE "name" = 'General''s Quarters';
+UPDATE "rooms" SET "max_adults" = 3, "max_children" = 2, "max_occupancy" = 4 WHERE "name" = 'Major''s Suite';
+UPDATE "rooms" SET "max_adults" = 2, "max_children" = 1, "max_occupancy" = 3 WHERE "name" = 'Colonel''s Chamber';
+UPDATE "rooms" SET "max_adults" = 2, "max_children" = 2, "max_occupancy" = 4 WHERE "name" = 'Captain''s Retreat';
+UPDATE "rooms" SET "max_adults" = 2, "max_children" = 1, "max_occupancy" = 3 WHERE "name" = 'Admiral''s Haven';
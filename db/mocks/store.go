@@ -7,6 +7,8 @@ import (
 
 	db "github.com/github-real-lb/bookings-web-app/db"
 	mock "github.com/stretchr/testify/mock"
+
+	pgtype "github.com/jackc/pgx/v5/pgtype"
 )
 
 // MockDBStore is an autogenerated mock type for the DatabaseStore type
@@ -42,6 +44,80 @@ func (_m *MockDBStore) AuthenticateUser(ctx context.Context, arg db.Authenticate
 	return r0, r1
 }
 
+// CancelReservation provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) CancelReservation(ctx context.Context, arg db.CancelReservationParams) (db.Reservation, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelReservation")
+	}
+
+	var r0 db.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CancelReservationParams) (db.Reservation, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CancelReservationParams) db.Reservation); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Reservation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CancelReservationParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CancelReservationTx provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) CancelReservationTx(ctx context.Context, arg db.CancelReservationParams) (db.Reservation, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelReservationTx")
+	}
+
+	var r0 db.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CancelReservationParams) (db.Reservation, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CancelReservationParams) db.Reservation); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Reservation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CancelReservationParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChangeUserPassword provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) ChangeUserPassword(ctx context.Context, arg db.UpdateUserPasswordParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeUserPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateUserPasswordParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CheckRoomAvailability provides a mock function with given fields: ctx, arg
 func (_m *MockDBStore) CheckRoomAvailability(ctx context.Context, arg db.CheckRoomAvailabilityParams) (bool, error) {
 	ret := _m.Called(ctx, arg)
@@ -70,26 +146,26 @@ func (_m *MockDBStore) CheckRoomAvailability(ctx context.Context, arg db.CheckRo
 	return r0, r1
 }
 
-// CreateNewUser provides a mock function with given fields: ctx, arg
-func (_m *MockDBStore) CreateNewUser(ctx context.Context, arg db.CreateUserParams) (db.User, error) {
+// CheckRoomAvailabilityForReservation provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) CheckRoomAvailabilityForReservation(ctx context.Context, arg db.CheckRoomAvailabilityForReservationParams) (bool, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateNewUser")
+		panic("no return value specified for CheckRoomAvailabilityForReservation")
 	}
 
-	var r0 db.User
+	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateUserParams) (db.User, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CheckRoomAvailabilityForReservationParams) (bool, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateUserParams) db.User); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CheckRoomAvailabilityForReservationParams) bool); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.User)
+		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.CreateUserParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.CheckRoomAvailabilityForReservationParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -98,27 +174,45 @@ func (_m *MockDBStore) CreateNewUser(ctx context.Context, arg db.CreateUserParam
 	return r0, r1
 }
 
-// CreateReservation provides a mock function with given fields: ctx, arg
-func (_m *MockDBStore) CreateReservation(ctx context.Context, arg db.CreateReservationParams) (db.Reservation, error) {
+// CheckStayRules provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) CheckStayRules(ctx context.Context, arg db.CheckStayRulesParams) error {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateReservation")
+		panic("no return value specified for CheckStayRules")
 	}
 
-	var r0 db.Reservation
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CheckStayRulesParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountReservationsByRoom provides a mock function with given fields: ctx, roomID
+func (_m *MockDBStore) CountReservationsByRoom(ctx context.Context, roomID int64) (int64, error) {
+	ret := _m.Called(ctx, roomID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountReservationsByRoom")
+	}
+
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateReservationParams) (db.Reservation, error)); ok {
-		return rf(ctx, arg)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, roomID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateReservationParams) db.Reservation); ok {
-		r0 = rf(ctx, arg)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, roomID)
 	} else {
-		r0 = ret.Get(0).(db.Reservation)
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.CreateReservationParams) error); ok {
-		r1 = rf(ctx, arg)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, roomID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -126,26 +220,26 @@ func (_m *MockDBStore) CreateReservation(ctx context.Context, arg db.CreateReser
 	return r0, r1
 }
 
-// CreateReservationTx provides a mock function with given fields: ctx, arg
-func (_m *MockDBStore) CreateReservationTx(ctx context.Context, arg db.CreateReservationParams) (db.Reservation, error) {
+// CreateCharge provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) CreateCharge(ctx context.Context, arg db.CreateChargeParams) (db.Charge, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateReservationTx")
+		panic("no return value specified for CreateCharge")
 	}
 
-	var r0 db.Reservation
+	var r0 db.Charge
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateReservationParams) (db.Reservation, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateChargeParams) (db.Charge, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateReservationParams) db.Reservation); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateChargeParams) db.Charge); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Reservation)
+		r0 = ret.Get(0).(db.Charge)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.CreateReservationParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateChargeParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -154,26 +248,26 @@ func (_m *MockDBStore) CreateReservationTx(ctx context.Context, arg db.CreateRes
 	return r0, r1
 }
 
-// CreateRoom provides a mock function with given fields: ctx, arg
-func (_m *MockDBStore) CreateRoom(ctx context.Context, arg db.CreateRoomParams) (db.Room, error) {
+// CreateFolioEntry provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) CreateFolioEntry(ctx context.Context, arg db.CreateFolioEntryParams) (db.FolioEntry, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateRoom")
+		panic("no return value specified for CreateFolioEntry")
 	}
 
-	var r0 db.Room
+	var r0 db.FolioEntry
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateRoomParams) (db.Room, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateFolioEntryParams) (db.FolioEntry, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateRoomParams) db.Room); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateFolioEntryParams) db.FolioEntry); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Room)
+		r0 = ret.Get(0).(db.FolioEntry)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.CreateRoomParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateFolioEntryParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -182,26 +276,26 @@ func (_m *MockDBStore) CreateRoom(ctx context.Context, arg db.CreateRoomParams)
 	return r0, r1
 }
 
-// CreateRoomRestriction provides a mock function with given fields: ctx, arg
-func (_m *MockDBStore) CreateRoomRestriction(ctx context.Context, arg db.CreateRoomRestrictionParams) (db.RoomRestriction, error) {
+// CreateInvoice provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) CreateInvoice(ctx context.Context, arg db.CreateInvoiceParams) (db.Invoice, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateRoomRestriction")
+		panic("no return value specified for CreateInvoice")
 	}
 
-	var r0 db.RoomRestriction
+	var r0 db.Invoice
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateRoomRestrictionParams) (db.RoomRestriction, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateInvoiceParams) (db.Invoice, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateRoomRestrictionParams) db.RoomRestriction); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateInvoiceParams) db.Invoice); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.RoomRestriction)
+		r0 = ret.Get(0).(db.Invoice)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.CreateRoomRestrictionParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateInvoiceParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -210,12 +304,12 @@ func (_m *MockDBStore) CreateRoomRestriction(ctx context.Context, arg db.CreateR
 	return r0, r1
 }
 
-// CreateUser provides a mock function with given fields: ctx, arg
-func (_m *MockDBStore) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.User, error) {
+// CreateNewUser provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) CreateNewUser(ctx context.Context, arg db.CreateUserParams) (db.User, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateUser")
+		panic("no return value specified for CreateNewUser")
 	}
 
 	var r0 db.User
@@ -238,153 +332,2305 @@ func (_m *MockDBStore) CreateUser(ctx context.Context, arg db.CreateUserParams)
 	return r0, r1
 }
 
-// DeleteAllReservations provides a mock function with given fields: ctx
-func (_m *MockDBStore) DeleteAllReservations(ctx context.Context) error {
-	ret := _m.Called(ctx)
+// CreateOwnerBlock provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) CreateOwnerBlock(ctx context.Context, arg db.CreateOwnerBlockParams) (db.RoomRestriction, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteAllReservations")
+		panic("no return value specified for CreateOwnerBlock")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
-		r0 = rf(ctx)
+	var r0 db.RoomRestriction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateOwnerBlockParams) (db.RoomRestriction, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateOwnerBlockParams) db.RoomRestriction); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.RoomRestriction)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateOwnerBlockParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// DeleteAllRoomRestrictions provides a mock function with given fields: ctx
-func (_m *MockDBStore) DeleteAllRoomRestrictions(ctx context.Context) error {
-	ret := _m.Called(ctx)
+// CreateOwnerBlocksTx provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) CreateOwnerBlocksTx(ctx context.Context, arg db.CreateOwnerBlocksTxParams) ([]db.RoomRestriction, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteAllRoomRestrictions")
+		panic("no return value specified for CreateOwnerBlocksTx")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
-		r0 = rf(ctx)
+	var r0 []db.RoomRestriction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateOwnerBlocksTxParams) ([]db.RoomRestriction, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateOwnerBlocksTxParams) []db.RoomRestriction); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.RoomRestriction)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateOwnerBlocksTxParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// DeleteAllRooms provides a mock function with given fields: ctx
-func (_m *MockDBStore) DeleteAllRooms(ctx context.Context) error {
-	ret := _m.Called(ctx)
+// CreatePayment provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) CreatePayment(ctx context.Context, arg db.CreatePaymentParams) (db.Payment, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteAllRooms")
+		panic("no return value specified for CreatePayment")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
-		r0 = rf(ctx)
+	var r0 db.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreatePaymentParams) (db.Payment, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreatePaymentParams) db.Payment); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.Payment)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreatePaymentParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// DeleteReservation provides a mock function with given fields: ctx, id
-func (_m *MockDBStore) DeleteReservation(ctx context.Context, id int64) error {
-	ret := _m.Called(ctx, id)
+// CreatePromoCode provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) CreatePromoCode(ctx context.Context, arg db.CreatePromoCodeParams) (db.PromoCode, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteReservation")
+		panic("no return value specified for CreatePromoCode")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, id)
+	var r0 db.PromoCode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreatePromoCodeParams) (db.PromoCode, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreatePromoCodeParams) db.PromoCode); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.PromoCode)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreatePromoCodeParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// DeleteRoom provides a mock function with given fields: ctx, id
-func (_m *MockDBStore) DeleteRoom(ctx context.Context, id int64) error {
-	ret := _m.Called(ctx, id)
+// CreateRefund provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) CreateRefund(ctx context.Context, arg db.CreateRefundParams) (db.Refund, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteRoom")
+		panic("no return value specified for CreateRefund")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, id)
+	var r0 db.Refund
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateRefundParams) (db.Refund, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateRefundParams) db.Refund); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.Refund)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateRefundParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// DeleteRoomRestriction provides a mock function with given fields: ctx, id
-func (_m *MockDBStore) DeleteRoomRestriction(ctx context.Context, id int64) error {
-	ret := _m.Called(ctx, id)
+// CreateRefundTx provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) CreateRefundTx(ctx context.Context, arg db.CreateRefundTxParams) (db.Refund, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteRoomRestriction")
+		panic("no return value specified for CreateRefundTx")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, id)
+	var r0 db.Refund
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateRefundTxParams) (db.Refund, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateRefundTxParams) db.Refund); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.Refund)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateRefundTxParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// DeleteUser provides a mock function with given fields: ctx, id
+// CreateReservation provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) CreateReservation(ctx context.Context, arg db.CreateReservationParams) (db.Reservation, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReservation")
+	}
+
+	var r0 db.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateReservationParams) (db.Reservation, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateReservationParams) db.Reservation); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Reservation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateReservationParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateReservationCharge provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) CreateReservationCharge(ctx context.Context, arg db.CreateReservationChargeParams) (db.ReservationCharge, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReservationCharge")
+	}
+
+	var r0 db.ReservationCharge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateReservationChargeParams) (db.ReservationCharge, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateReservationChargeParams) db.ReservationCharge); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.ReservationCharge)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateReservationChargeParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateReservationTx provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) CreateReservationTx(ctx context.Context, arg db.CreateReservationParams) (db.Reservation, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReservationTx")
+	}
+
+	var r0 db.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateReservationParams) (db.Reservation, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateReservationParams) db.Reservation); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Reservation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateReservationParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateReservationsTx provides a mock function with given fields: ctx, args, holdToken, promoCode
+func (_m *MockDBStore) CreateReservationsTx(ctx context.Context, args []db.CreateReservationParams, holdToken string, promoCode string) ([]db.Reservation, error) {
+	ret := _m.Called(ctx, args, holdToken, promoCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReservationsTx")
+	}
+
+	var r0 []db.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []db.CreateReservationParams, string, string) ([]db.Reservation, error)); ok {
+		return rf(ctx, args, holdToken, promoCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []db.CreateReservationParams, string, string) []db.Reservation); ok {
+		r0 = rf(ctx, args, holdToken, promoCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Reservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []db.CreateReservationParams, string, string) error); ok {
+		r1 = rf(ctx, args, holdToken, promoCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateRoom provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) CreateRoom(ctx context.Context, arg db.CreateRoomParams) (db.Room, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRoom")
+	}
+
+	var r0 db.Room
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateRoomParams) (db.Room, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateRoomParams) db.Room); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Room)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateRoomParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateRoomHold provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) CreateRoomHold(ctx context.Context, arg db.CreateRoomHoldParams) (db.RoomRestriction, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRoomHold")
+	}
+
+	var r0 db.RoomRestriction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateRoomHoldParams) (db.RoomRestriction, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateRoomHoldParams) db.RoomRestriction); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.RoomRestriction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateRoomHoldParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateRoomHoldTx provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) CreateRoomHoldTx(ctx context.Context, arg db.CreateRoomHoldTxParams) (db.RoomRestriction, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRoomHoldTx")
+	}
+
+	var r0 db.RoomRestriction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateRoomHoldTxParams) (db.RoomRestriction, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateRoomHoldTxParams) db.RoomRestriction); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.RoomRestriction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateRoomHoldTxParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateRoomRate provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) CreateRoomRate(ctx context.Context, arg db.CreateRoomRateParams) (db.RoomRate, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRoomRate")
+	}
+
+	var r0 db.RoomRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateRoomRateParams) (db.RoomRate, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateRoomRateParams) db.RoomRate); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.RoomRate)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateRoomRateParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateRoomRatesTx provides a mock function with given fields: ctx, args
+func (_m *MockDBStore) CreateRoomRatesTx(ctx context.Context, args []db.CreateRoomRateParams) ([]db.RoomRate, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRoomRatesTx")
+	}
+
+	var r0 []db.RoomRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []db.CreateRoomRateParams) ([]db.RoomRate, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []db.CreateRoomRateParams) []db.RoomRate); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.RoomRate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []db.CreateRoomRateParams) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateRoomRestriction provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) CreateRoomRestriction(ctx context.Context, arg db.CreateRoomRestrictionParams) (db.RoomRestriction, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRoomRestriction")
+	}
+
+	var r0 db.RoomRestriction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateRoomRestrictionParams) (db.RoomRestriction, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateRoomRestrictionParams) db.RoomRestriction); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.RoomRestriction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateRoomRestrictionParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateStayRule provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) CreateStayRule(ctx context.Context, arg db.CreateStayRuleParams) (db.StayRule, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateStayRule")
+	}
+
+	var r0 db.StayRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateStayRuleParams) (db.StayRule, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateStayRuleParams) db.StayRule); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.StayRule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateStayRuleParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateUser provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.User, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 db.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateUserParams) (db.User, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateUserParams) db.User); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateUserParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateWaitlistEntry provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) CreateWaitlistEntry(ctx context.Context, arg db.CreateWaitlistEntryParams) (db.WaitlistEntry, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWaitlistEntry")
+	}
+
+	var r0 db.WaitlistEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateWaitlistEntryParams) (db.WaitlistEntry, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateWaitlistEntryParams) db.WaitlistEntry); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.WaitlistEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateWaitlistEntryParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteAllCharges provides a mock function with given fields: ctx
+func (_m *MockDBStore) DeleteAllCharges(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAllCharges")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAllPromoCodes provides a mock function with given fields: ctx
+func (_m *MockDBStore) DeleteAllPromoCodes(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAllPromoCodes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAllReservations provides a mock function with given fields: ctx
+func (_m *MockDBStore) DeleteAllReservations(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAllReservations")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAllRoomRates provides a mock function with given fields: ctx
+func (_m *MockDBStore) DeleteAllRoomRates(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAllRoomRates")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAllRoomRestrictions provides a mock function with given fields: ctx
+func (_m *MockDBStore) DeleteAllRoomRestrictions(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAllRoomRestrictions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAllRooms provides a mock function with given fields: ctx
+func (_m *MockDBStore) DeleteAllRooms(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAllRooms")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAllStayRules provides a mock function with given fields: ctx
+func (_m *MockDBStore) DeleteAllStayRules(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAllStayRules")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAllWaitlistEntries provides a mock function with given fields: ctx
+func (_m *MockDBStore) DeleteAllWaitlistEntries(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAllWaitlistEntries")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCharges provides a mock function with given fields: ctx, ids
+func (_m *MockDBStore) DeleteCharges(ctx context.Context, ids []int64) error {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCharges")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) error); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteExchangeRates provides a mock function with given fields: ctx, currencies
+func (_m *MockDBStore) DeleteExchangeRates(ctx context.Context, currencies []string) error {
+	ret := _m.Called(ctx, currencies)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExchangeRates")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, currencies)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteExpiredRoomHolds provides a mock function with given fields: ctx
+func (_m *MockDBStore) DeleteExpiredRoomHolds(ctx context.Context) ([]db.RoomRestriction, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpiredRoomHolds")
+	}
+
+	var r0 []db.RoomRestriction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.RoomRestriction, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.RoomRestriction); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.RoomRestriction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteOwnerBlocks provides a mock function with given fields: ctx, ids
+func (_m *MockDBStore) DeleteOwnerBlocks(ctx context.Context, ids []int64) ([]db.RoomRestriction, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOwnerBlocks")
+	}
+
+	var r0 []db.RoomRestriction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) ([]db.RoomRestriction, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) []db.RoomRestriction); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.RoomRestriction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeletePromoCode provides a mock function with given fields: ctx, id
+func (_m *MockDBStore) DeletePromoCode(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePromoCode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteReservation provides a mock function with given fields: ctx, id
+func (_m *MockDBStore) DeleteReservation(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReservation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteReservationCharges provides a mock function with given fields: ctx, reservationID
+func (_m *MockDBStore) DeleteReservationCharges(ctx context.Context, reservationID int64) error {
+	ret := _m.Called(ctx, reservationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReservationCharges")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, reservationID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRoom provides a mock function with given fields: ctx, id
+func (_m *MockDBStore) DeleteRoom(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRoom")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRoomHold provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) DeleteRoomHold(ctx context.Context, arg db.DeleteRoomHoldParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRoomHold")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteRoomHoldParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRoomHoldsByToken provides a mock function with given fields: ctx, holdToken
+func (_m *MockDBStore) DeleteRoomHoldsByToken(ctx context.Context, holdToken pgtype.Text) error {
+	ret := _m.Called(ctx, holdToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRoomHoldsByToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.Text) error); ok {
+		r0 = rf(ctx, holdToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRoomRates provides a mock function with given fields: ctx, ids
+func (_m *MockDBStore) DeleteRoomRates(ctx context.Context, ids []int64) error {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRoomRates")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) error); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRoomRestriction provides a mock function with given fields: ctx, id
+func (_m *MockDBStore) DeleteRoomRestriction(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRoomRestriction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRoomRestrictionsByReservationID provides a mock function with given fields: ctx, reservationID
+func (_m *MockDBStore) DeleteRoomRestrictionsByReservationID(ctx context.Context, reservationID pgtype.Int8) error {
+	ret := _m.Called(ctx, reservationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRoomRestrictionsByReservationID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.Int8) error); ok {
+		r0 = rf(ctx, reservationID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRoomTx provides a mock function with given fields: ctx, id
+func (_m *MockDBStore) DeleteRoomTx(ctx context.Context, id int64) (db.Room, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRoomTx")
+	}
+
+	var r0 db.Room
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Room, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Room); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.Room)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteStayRule provides a mock function with given fields: ctx, id
+func (_m *MockDBStore) DeleteStayRule(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteStayRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteUser provides a mock function with given fields: ctx, id
 func (_m *MockDBStore) DeleteUser(ctx context.Context, id int64) error {
 	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteUser")
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetCharge provides a mock function with given fields: ctx, id
+func (_m *MockDBStore) GetCharge(ctx context.Context, id int64) (db.Charge, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCharge")
+	}
+
+	var r0 db.Charge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Charge, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Charge); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.Charge)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFolioBalance provides a mock function with given fields: ctx, reservationID
+func (_m *MockDBStore) GetFolioBalance(ctx context.Context, reservationID int64) (int64, error) {
+	ret := _m.Called(ctx, reservationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFolioBalance")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, reservationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, reservationID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, reservationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInvoiceByReservation provides a mock function with given fields: ctx, reservationID
+func (_m *MockDBStore) GetInvoiceByReservation(ctx context.Context, reservationID int64) (db.Invoice, error) {
+	ret := _m.Called(ctx, reservationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvoiceByReservation")
+	}
+
+	var r0 db.Invoice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Invoice, error)); ok {
+		return rf(ctx, reservationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Invoice); ok {
+		r0 = rf(ctx, reservationID)
+	} else {
+		r0 = ret.Get(0).(db.Invoice)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, reservationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLastRoomRestriction provides a mock function with given fields: ctx, roomID
+func (_m *MockDBStore) GetLastRoomRestriction(ctx context.Context, roomID int64) (db.RoomRestriction, error) {
+	ret := _m.Called(ctx, roomID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastRoomRestriction")
+	}
+
+	var r0 db.RoomRestriction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.RoomRestriction, error)); ok {
+		return rf(ctx, roomID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.RoomRestriction); ok {
+		r0 = rf(ctx, roomID)
+	} else {
+		r0 = ret.Get(0).(db.RoomRestriction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, roomID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOwnerBlock provides a mock function with given fields: ctx, id
+func (_m *MockDBStore) GetOwnerBlock(ctx context.Context, id int64) (db.RoomRestriction, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOwnerBlock")
+	}
+
+	var r0 db.RoomRestriction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.RoomRestriction, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.RoomRestriction); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.RoomRestriction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaidAmount provides a mock function with given fields: ctx, reservationID
+func (_m *MockDBStore) GetPaidAmount(ctx context.Context, reservationID int64) (int64, error) {
+	ret := _m.Called(ctx, reservationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaidAmount")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, reservationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, reservationID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, reservationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPayment provides a mock function with given fields: ctx, id
+func (_m *MockDBStore) GetPayment(ctx context.Context, id int64) (db.Payment, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPayment")
+	}
+
+	var r0 db.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Payment, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Payment); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.Payment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPromoCode provides a mock function with given fields: ctx, id
+func (_m *MockDBStore) GetPromoCode(ctx context.Context, id int64) (db.PromoCode, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPromoCode")
+	}
+
+	var r0 db.PromoCode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.PromoCode, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.PromoCode); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.PromoCode)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPromoCodeByCode provides a mock function with given fields: ctx, code
+func (_m *MockDBStore) GetPromoCodeByCode(ctx context.Context, code interface{}) (db.PromoCode, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPromoCodeByCode")
+	}
+
+	var r0 db.PromoCode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) (db.PromoCode, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) db.PromoCode); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Get(0).(db.PromoCode)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReservation provides a mock function with given fields: ctx, id
+func (_m *MockDBStore) GetReservation(ctx context.Context, id int64) (db.Reservation, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReservation")
+	}
+
+	var r0 db.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Reservation, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Reservation); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.Reservation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReservationAndRoom provides a mock function with given fields: ctx, id
+func (_m *MockDBStore) GetReservationAndRoom(ctx context.Context, id int64) (db.GetReservationAndRoomRow, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReservationAndRoom")
+	}
+
+	var r0 db.GetReservationAndRoomRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.GetReservationAndRoomRow, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.GetReservationAndRoomRow); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.GetReservationAndRoomRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReservationByLastName provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) GetReservationByLastName(ctx context.Context, arg db.GetReservationByLastNameParams) (db.Reservation, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReservationByLastName")
+	}
+
+	var r0 db.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetReservationByLastNameParams) (db.Reservation, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetReservationByLastNameParams) db.Reservation); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Reservation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetReservationByLastNameParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReservationForUpdate provides a mock function with given fields: ctx, id
+func (_m *MockDBStore) GetReservationForUpdate(ctx context.Context, id int64) (db.Reservation, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReservationForUpdate")
+	}
+
+	var r0 db.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Reservation, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Reservation); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.Reservation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoom provides a mock function with given fields: ctx, id
+func (_m *MockDBStore) GetRoom(ctx context.Context, id int64) (db.Room, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoom")
+	}
+
+	var r0 db.Room
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Room, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Room); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.Room)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoomBySlug provides a mock function with given fields: ctx, slug
+func (_m *MockDBStore) GetRoomBySlug(ctx context.Context, slug string) (db.Room, error) {
+	ret := _m.Called(ctx, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoomBySlug")
+	}
+
+	var r0 db.Room
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.Room, error)); ok {
+		return rf(ctx, slug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.Room); ok {
+		r0 = rf(ctx, slug)
+	} else {
+		r0 = ret.Get(0).(db.Room)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoomForUpdate provides a mock function with given fields: ctx, id
+func (_m *MockDBStore) GetRoomForUpdate(ctx context.Context, id int64) (db.Room, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoomForUpdate")
+	}
+
+	var r0 db.Room
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Room, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Room); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.Room)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoomRate provides a mock function with given fields: ctx, id
+func (_m *MockDBStore) GetRoomRate(ctx context.Context, id int64) (db.RoomRate, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoomRate")
+	}
+
+	var r0 db.RoomRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.RoomRate, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.RoomRate); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.RoomRate)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoomRestriction provides a mock function with given fields: ctx, id
+func (_m *MockDBStore) GetRoomRestriction(ctx context.Context, id int64) (db.RoomRestriction, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoomRestriction")
+	}
+
+	var r0 db.RoomRestriction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.RoomRestriction, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.RoomRestriction); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.RoomRestriction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStayRule provides a mock function with given fields: ctx, id
+func (_m *MockDBStore) GetStayRule(ctx context.Context, id int64) (db.StayRule, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStayRule")
+	}
+
+	var r0 db.StayRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.StayRule, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.StayRule); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.StayRule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUser provides a mock function with given fields: ctx, id
+func (_m *MockDBStore) GetUser(ctx context.Context, id int64) (db.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 db.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByEmail provides a mock function with given fields: ctx, email
+func (_m *MockDBStore) GetUserByEmail(ctx context.Context, email string) (db.User, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByEmail")
+	}
+
+	var r0 db.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.User, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.User); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(db.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByPasswordToken provides a mock function with given fields: ctx, passwordToken
+func (_m *MockDBStore) GetUserByPasswordToken(ctx context.Context, passwordToken pgtype.Text) (db.User, error) {
+	ret := _m.Called(ctx, passwordToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByPasswordToken")
+	}
+
+	var r0 db.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.Text) (db.User, error)); ok {
+		return rf(ctx, passwordToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.Text) db.User); ok {
+		r0 = rf(ctx, passwordToken)
+	} else {
+		r0 = ret.Get(0).(db.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgtype.Text) error); ok {
+		r1 = rf(ctx, passwordToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWaitlistEntryByToken provides a mock function with given fields: ctx, token
+func (_m *MockDBStore) GetWaitlistEntryByToken(ctx context.Context, token string) (db.WaitlistEntry, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWaitlistEntryByToken")
+	}
+
+	var r0 db.WaitlistEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.WaitlistEntry, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.WaitlistEntry); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(db.WaitlistEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IssueInvoiceTx provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) IssueInvoiceTx(ctx context.Context, arg db.IssueInvoiceTxParams) (db.Invoice, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueInvoiceTx")
+	}
+
+	var r0 db.Invoice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.IssueInvoiceTxParams) (db.Invoice, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.IssueInvoiceTxParams) db.Invoice); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Invoice)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.IssueInvoiceTxParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListArrivalsAndRooms provides a mock function with given fields: ctx, startDate
+func (_m *MockDBStore) ListArrivalsAndRooms(ctx context.Context, startDate pgtype.Date) ([]db.ListArrivalsAndRoomsRow, error) {
+	ret := _m.Called(ctx, startDate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListArrivalsAndRooms")
+	}
+
+	var r0 []db.ListArrivalsAndRoomsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.Date) ([]db.ListArrivalsAndRoomsRow, error)); ok {
+		return rf(ctx, startDate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.Date) []db.ListArrivalsAndRoomsRow); ok {
+		r0 = rf(ctx, startDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListArrivalsAndRoomsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgtype.Date) error); ok {
+		r1 = rf(ctx, startDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAvailableRooms provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) ListAvailableRooms(ctx context.Context, arg db.ListAvailableRoomsParams) ([]db.Room, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAvailableRooms")
+	}
+
+	var r0 []db.Room
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListAvailableRoomsParams) ([]db.Room, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListAvailableRoomsParams) []db.Room); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Room)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListAvailableRoomsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCharges provides a mock function with given fields: ctx
+func (_m *MockDBStore) ListCharges(ctx context.Context) ([]db.Charge, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCharges")
+	}
+
+	var r0 []db.Charge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.Charge, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.Charge); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Charge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDeparturesAndRooms provides a mock function with given fields: ctx, date
+func (_m *MockDBStore) ListDeparturesAndRooms(ctx context.Context, date pgtype.Date) ([]db.ListDeparturesAndRoomsRow, error) {
+	ret := _m.Called(ctx, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeparturesAndRooms")
+	}
+
+	var r0 []db.ListDeparturesAndRoomsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.Date) ([]db.ListDeparturesAndRoomsRow, error)); ok {
+		return rf(ctx, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.Date) []db.ListDeparturesAndRoomsRow); ok {
+		r0 = rf(ctx, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListDeparturesAndRoomsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgtype.Date) error); ok {
+		r1 = rf(ctx, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListExchangeRates provides a mock function with given fields: ctx
+func (_m *MockDBStore) ListExchangeRates(ctx context.Context) ([]db.ExchangeRate, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExchangeRates")
+	}
+
+	var r0 []db.ExchangeRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.ExchangeRate, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.ExchangeRate); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ExchangeRate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListFolioEntriesByReservation provides a mock function with given fields: ctx, reservationID
+func (_m *MockDBStore) ListFolioEntriesByReservation(ctx context.Context, reservationID int64) ([]db.FolioEntry, error) {
+	ret := _m.Called(ctx, reservationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFolioEntriesByReservation")
+	}
+
+	var r0 []db.FolioEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.FolioEntry, error)); ok {
+		return rf(ctx, reservationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.FolioEntry); ok {
+		r0 = rf(ctx, reservationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.FolioEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, reservationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOwnerBlockConflicts provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) ListOwnerBlockConflicts(ctx context.Context, arg db.ListOwnerBlockConflictsParams) ([]db.RoomRestriction, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOwnerBlockConflicts")
+	}
+
+	var r0 []db.RoomRestriction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListOwnerBlockConflictsParams) ([]db.RoomRestriction, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListOwnerBlockConflictsParams) []db.RoomRestriction); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.RoomRestriction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListOwnerBlockConflictsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOwnerBlocksAndRooms provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) ListOwnerBlocksAndRooms(ctx context.Context, arg db.ListOwnerBlocksAndRoomsParams) ([]db.ListOwnerBlocksAndRoomsRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOwnerBlocksAndRooms")
+	}
+
+	var r0 []db.ListOwnerBlocksAndRoomsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListOwnerBlocksAndRoomsParams) ([]db.ListOwnerBlocksAndRoomsRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListOwnerBlocksAndRoomsParams) []db.ListOwnerBlocksAndRoomsRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListOwnerBlocksAndRoomsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListOwnerBlocksAndRoomsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPaymentsByReservation provides a mock function with given fields: ctx, reservationID
+func (_m *MockDBStore) ListPaymentsByReservation(ctx context.Context, reservationID int64) ([]db.Payment, error) {
+	ret := _m.Called(ctx, reservationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPaymentsByReservation")
+	}
+
+	var r0 []db.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.Payment, error)); ok {
+		return rf(ctx, reservationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.Payment); ok {
+		r0 = rf(ctx, reservationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, reservationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRefundsByReservation provides a mock function with given fields: ctx, reservationID
+func (_m *MockDBStore) ListRefundsByReservation(ctx context.Context, reservationID int64) ([]db.Refund, error) {
+	ret := _m.Called(ctx, reservationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRefundsByReservation")
+	}
+
+	var r0 []db.Refund
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.Refund, error)); ok {
+		return rf(ctx, reservationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.Refund); ok {
+		r0 = rf(ctx, reservationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Refund)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, reservationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReservationCharges provides a mock function with given fields: ctx, reservationID
+func (_m *MockDBStore) ListReservationCharges(ctx context.Context, reservationID int64) ([]db.ReservationCharge, error) {
+	ret := _m.Called(ctx, reservationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReservationCharges")
+	}
+
+	var r0 []db.ReservationCharge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.ReservationCharge, error)); ok {
+		return rf(ctx, reservationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.ReservationCharge); ok {
+		r0 = rf(ctx, reservationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ReservationCharge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, reservationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReservations provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) ListReservations(ctx context.Context, arg db.ListReservationsParams) ([]db.Reservation, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReservations")
+	}
+
+	var r0 []db.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListReservationsParams) ([]db.Reservation, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListReservationsParams) []db.Reservation); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Reservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListReservationsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReservationsAndRooms provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) ListReservationsAndRooms(ctx context.Context, arg db.ListReservationsAndRoomsParams) ([]db.ListReservationsAndRoomsRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReservationsAndRooms")
+	}
+
+	var r0 []db.ListReservationsAndRoomsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListReservationsAndRoomsParams) ([]db.ListReservationsAndRoomsRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListReservationsAndRoomsParams) []db.ListReservationsAndRoomsRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListReservationsAndRoomsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListReservationsAndRoomsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReservationsAndRoomsByStatus provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) ListReservationsAndRoomsByStatus(ctx context.Context, arg db.ListReservationsAndRoomsByStatusParams) ([]db.ListReservationsAndRoomsByStatusRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReservationsAndRoomsByStatus")
+	}
+
+	var r0 []db.ListReservationsAndRoomsByStatusRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListReservationsAndRoomsByStatusParams) ([]db.ListReservationsAndRoomsByStatusRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListReservationsAndRoomsByStatusParams) []db.ListReservationsAndRoomsByStatusRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListReservationsAndRoomsByStatusRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListReservationsAndRoomsByStatusParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRoomRatesAndRooms provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) ListRoomRatesAndRooms(ctx context.Context, arg db.ListRoomRatesAndRoomsParams) ([]db.ListRoomRatesAndRoomsRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRoomRatesAndRooms")
+	}
+
+	var r0 []db.ListRoomRatesAndRoomsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListRoomRatesAndRoomsParams) ([]db.ListRoomRatesAndRoomsRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListRoomRatesAndRoomsParams) []db.ListRoomRatesAndRoomsRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListRoomRatesAndRoomsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListRoomRatesAndRoomsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRoomRatesForStay provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) ListRoomRatesForStay(ctx context.Context, arg db.ListRoomRatesForStayParams) ([]db.RoomRate, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRoomRatesForStay")
+	}
+
+	var r0 []db.RoomRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListRoomRatesForStayParams) ([]db.RoomRate, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListRoomRatesForStayParams) []db.RoomRate); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.RoomRate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListRoomRatesForStayParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRoomRestrictions provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) ListRoomRestrictions(ctx context.Context, arg db.ListRoomRestrictionsParams) ([]db.RoomRestriction, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRoomRestrictions")
+	}
+
+	var r0 []db.RoomRestriction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListRoomRestrictionsParams) ([]db.RoomRestriction, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListRoomRestrictionsParams) []db.RoomRestriction); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.RoomRestriction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListRoomRestrictionsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRoomRestrictionsByReservationID provides a mock function with given fields: ctx, reservationID
+func (_m *MockDBStore) ListRoomRestrictionsByReservationID(ctx context.Context, reservationID pgtype.Int8) ([]db.RoomRestriction, error) {
+	ret := _m.Called(ctx, reservationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRoomRestrictionsByReservationID")
+	}
+
+	var r0 []db.RoomRestriction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.Int8) ([]db.RoomRestriction, error)); ok {
+		return rf(ctx, reservationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.Int8) []db.RoomRestriction); ok {
+		r0 = rf(ctx, reservationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.RoomRestriction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgtype.Int8) error); ok {
+		r1 = rf(ctx, reservationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRoomRestrictionsForPeriod provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) ListRoomRestrictionsForPeriod(ctx context.Context, arg db.ListRoomRestrictionsForPeriodParams) ([]db.RoomRestriction, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRoomRestrictionsForPeriod")
+	}
+
+	var r0 []db.RoomRestriction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListRoomRestrictionsForPeriodParams) ([]db.RoomRestriction, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListRoomRestrictionsForPeriodParams) []db.RoomRestriction); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.RoomRestriction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListRoomRestrictionsForPeriodParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRooms provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) ListRooms(ctx context.Context, arg db.ListRoomsParams) ([]db.Room, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRooms")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, id)
+	var r0 []db.Room
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListRoomsParams) ([]db.Room, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListRoomsParams) []db.Room); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Room)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListRoomsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// GetLastRoomRestriction provides a mock function with given fields: ctx, roomID
-func (_m *MockDBStore) GetLastRoomRestriction(ctx context.Context, roomID int64) (db.RoomRestriction, error) {
-	ret := _m.Called(ctx, roomID)
+// ListStayRulesForDates provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) ListStayRulesForDates(ctx context.Context, arg db.ListStayRulesForDatesParams) ([]db.StayRule, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLastRoomRestriction")
+		panic("no return value specified for ListStayRulesForDates")
 	}
 
-	var r0 db.RoomRestriction
+	var r0 []db.StayRule
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.RoomRestriction, error)); ok {
-		return rf(ctx, roomID)
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListStayRulesForDatesParams) ([]db.StayRule, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) db.RoomRestriction); ok {
-		r0 = rf(ctx, roomID)
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListStayRulesForDatesParams) []db.StayRule); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.RoomRestriction)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.StayRule)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, roomID)
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListStayRulesForDatesParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -392,27 +2638,29 @@ func (_m *MockDBStore) GetLastRoomRestriction(ctx context.Context, roomID int64)
 	return r0, r1
 }
 
-// GetReservation provides a mock function with given fields: ctx, id
-func (_m *MockDBStore) GetReservation(ctx context.Context, id int64) (db.Reservation, error) {
-	ret := _m.Called(ctx, id)
+// ListStayRulesForStay provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) ListStayRulesForStay(ctx context.Context, arg db.ListStayRulesForStayParams) ([]db.StayRule, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetReservation")
+		panic("no return value specified for ListStayRulesForStay")
 	}
 
-	var r0 db.Reservation
+	var r0 []db.StayRule
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Reservation, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListStayRulesForStayParams) ([]db.StayRule, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Reservation); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListStayRulesForStayParams) []db.StayRule); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Reservation)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.StayRule)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListStayRulesForStayParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -420,26 +2668,28 @@ func (_m *MockDBStore) GetReservation(ctx context.Context, id int64) (db.Reserva
 	return r0, r1
 }
 
-// GetReservationByLastName provides a mock function with given fields: ctx, arg
-func (_m *MockDBStore) GetReservationByLastName(ctx context.Context, arg db.GetReservationByLastNameParams) (db.Reservation, error) {
+// ListUsers provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) ListUsers(ctx context.Context, arg db.ListUsersParams) ([]db.User, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetReservationByLastName")
+		panic("no return value specified for ListUsers")
 	}
 
-	var r0 db.Reservation
+	var r0 []db.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.GetReservationByLastNameParams) (db.Reservation, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListUsersParams) ([]db.User, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.GetReservationByLastNameParams) db.Reservation); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListUsersParams) []db.User); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Reservation)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.User)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.GetReservationByLastNameParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListUsersParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -448,23 +2698,169 @@ func (_m *MockDBStore) GetReservationByLastName(ctx context.Context, arg db.GetR
 	return r0, r1
 }
 
-// GetRoom provides a mock function with given fields: ctx, id
-func (_m *MockDBStore) GetRoom(ctx context.Context, id int64) (db.Room, error) {
+// ListWaitlistEntriesForRoom provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) ListWaitlistEntriesForRoom(ctx context.Context, arg db.ListWaitlistEntriesForRoomParams) ([]db.WaitlistEntry, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWaitlistEntriesForRoom")
+	}
+
+	var r0 []db.WaitlistEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListWaitlistEntriesForRoomParams) ([]db.WaitlistEntry, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListWaitlistEntriesForRoomParams) []db.WaitlistEntry); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WaitlistEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListWaitlistEntriesForRoomParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NextInvoiceNumber provides a mock function with given fields: ctx, property
+func (_m *MockDBStore) NextInvoiceNumber(ctx context.Context, property string) (int64, error) {
+	ret := _m.Called(ctx, property)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NextInvoiceNumber")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, property)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, property)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, property)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyWaitlistTx provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) NotifyWaitlistTx(ctx context.Context, arg db.NotifyWaitlistTxParams) ([]db.WaitlistEntry, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotifyWaitlistTx")
+	}
+
+	var r0 []db.WaitlistEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.NotifyWaitlistTxParams) ([]db.WaitlistEntry, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.NotifyWaitlistTxParams) []db.WaitlistEntry); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WaitlistEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.NotifyWaitlistTxParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QuoteStay provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) QuoteStay(ctx context.Context, arg db.QuoteStayParams) (db.Quote, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QuoteStay")
+	}
+
+	var r0 db.Quote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.QuoteStayParams) (db.Quote, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.QuoteStayParams) db.Quote); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Quote)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.QuoteStayParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QuoteStays provides a mock function with given fields: ctx, args
+func (_m *MockDBStore) QuoteStays(ctx context.Context, args []db.QuoteStayParams) ([]db.Quote, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QuoteStays")
+	}
+
+	var r0 []db.Quote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []db.QuoteStayParams) ([]db.Quote, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []db.QuoteStayParams) []db.Quote); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Quote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []db.QuoteStayParams) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RedeemPromoCode provides a mock function with given fields: ctx, id
+func (_m *MockDBStore) RedeemPromoCode(ctx context.Context, id int64) (db.PromoCode, error) {
 	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRoom")
+		panic("no return value specified for RedeemPromoCode")
 	}
 
-	var r0 db.Room
+	var r0 db.PromoCode
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.Room, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.PromoCode, error)); ok {
 		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) db.Room); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int64) db.PromoCode); ok {
 		r0 = rf(ctx, id)
 	} else {
-		r0 = ret.Get(0).(db.Room)
+		r0 = ret.Get(0).(db.PromoCode)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
@@ -476,27 +2872,101 @@ func (_m *MockDBStore) GetRoom(ctx context.Context, id int64) (db.Room, error) {
 	return r0, r1
 }
 
-// GetRoomRestriction provides a mock function with given fields: ctx, id
-func (_m *MockDBStore) GetRoomRestriction(ctx context.Context, id int64) (db.RoomRestriction, error) {
-	ret := _m.Called(ctx, id)
+// ShortenRoomRestrictionsByReservationID provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) ShortenRoomRestrictionsByReservationID(ctx context.Context, arg db.ShortenRoomRestrictionsByReservationIDParams) error {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRoomRestriction")
+		panic("no return value specified for ShortenRoomRestrictionsByReservationID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ShortenRoomRestrictionsByReservationIDParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateInvoiceDocument provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) UpdateInvoiceDocument(ctx context.Context, arg db.UpdateInvoiceDocumentParams) (db.Invoice, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateInvoiceDocument")
+	}
+
+	var r0 db.Invoice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateInvoiceDocumentParams) (db.Invoice, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateInvoiceDocumentParams) db.Invoice); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Invoice)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateInvoiceDocumentParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateOwnerBlock provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) UpdateOwnerBlock(ctx context.Context, arg db.UpdateOwnerBlockParams) (db.RoomRestriction, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOwnerBlock")
 	}
 
 	var r0 db.RoomRestriction
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.RoomRestriction, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateOwnerBlockParams) (db.RoomRestriction, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateOwnerBlockParams) db.RoomRestriction); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.RoomRestriction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateOwnerBlockParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateOwnerBlockTx provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) UpdateOwnerBlockTx(ctx context.Context, arg db.UpdateOwnerBlockTxParams) (db.RoomRestriction, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOwnerBlockTx")
+	}
+
+	var r0 db.RoomRestriction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateOwnerBlockTxParams) (db.RoomRestriction, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) db.RoomRestriction); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateOwnerBlockTxParams) db.RoomRestriction); ok {
+		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Get(0).(db.RoomRestriction)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateOwnerBlockTxParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -504,27 +2974,29 @@ func (_m *MockDBStore) GetRoomRestriction(ctx context.Context, id int64) (db.Roo
 	return r0, r1
 }
 
-// GetUser provides a mock function with given fields: ctx, id
-func (_m *MockDBStore) GetUser(ctx context.Context, id int64) (db.User, error) {
-	ret := _m.Called(ctx, id)
+// UpdatePaymentStatusByProviderRef provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) UpdatePaymentStatusByProviderRef(ctx context.Context, arg db.UpdatePaymentStatusByProviderRefParams) ([]db.Payment, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUser")
+		panic("no return value specified for UpdatePaymentStatusByProviderRef")
 	}
 
-	var r0 db.User
+	var r0 []db.Payment
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (db.User, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdatePaymentStatusByProviderRefParams) ([]db.Payment, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) db.User); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdatePaymentStatusByProviderRefParams) []db.Payment); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.User)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Payment)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdatePaymentStatusByProviderRefParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -532,27 +3004,27 @@ func (_m *MockDBStore) GetUser(ctx context.Context, id int64) (db.User, error) {
 	return r0, r1
 }
 
-// GetUserByEmail provides a mock function with given fields: ctx, email
-func (_m *MockDBStore) GetUserByEmail(ctx context.Context, email string) (db.User, error) {
-	ret := _m.Called(ctx, email)
+// UpdateReservation provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) UpdateReservation(ctx context.Context, arg db.UpdateReservationParams) (db.Reservation, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserByEmail")
+		panic("no return value specified for UpdateReservation")
 	}
 
-	var r0 db.User
+	var r0 db.Reservation
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (db.User, error)); ok {
-		return rf(ctx, email)
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateReservationParams) (db.Reservation, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) db.User); ok {
-		r0 = rf(ctx, email)
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateReservationParams) db.Reservation); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.User)
+		r0 = ret.Get(0).(db.Reservation)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, email)
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateReservationParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -560,28 +3032,26 @@ func (_m *MockDBStore) GetUserByEmail(ctx context.Context, email string) (db.Use
 	return r0, r1
 }
 
-// ListAvailableRooms provides a mock function with given fields: ctx, arg
-func (_m *MockDBStore) ListAvailableRooms(ctx context.Context, arg db.ListAvailableRoomsParams) ([]db.Room, error) {
+// UpdateReservationDates provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) UpdateReservationDates(ctx context.Context, arg db.UpdateReservationDatesParams) (db.Reservation, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListAvailableRooms")
+		panic("no return value specified for UpdateReservationDates")
 	}
 
-	var r0 []db.Room
+	var r0 db.Reservation
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListAvailableRoomsParams) ([]db.Room, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateReservationDatesParams) (db.Reservation, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListAvailableRoomsParams) []db.Room); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateReservationDatesParams) db.Reservation); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Room)
-		}
+		r0 = ret.Get(0).(db.Reservation)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.ListAvailableRoomsParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateReservationDatesParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -590,28 +3060,26 @@ func (_m *MockDBStore) ListAvailableRooms(ctx context.Context, arg db.ListAvaila
 	return r0, r1
 }
 
-// ListReservations provides a mock function with given fields: ctx, arg
-func (_m *MockDBStore) ListReservations(ctx context.Context, arg db.ListReservationsParams) ([]db.Reservation, error) {
+// UpdateReservationDatesTx provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) UpdateReservationDatesTx(ctx context.Context, arg db.UpdateReservationDatesParams) (db.Reservation, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListReservations")
+		panic("no return value specified for UpdateReservationDatesTx")
 	}
 
-	var r0 []db.Reservation
+	var r0 db.Reservation
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListReservationsParams) ([]db.Reservation, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateReservationDatesParams) (db.Reservation, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListReservationsParams) []db.Reservation); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateReservationDatesParams) db.Reservation); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Reservation)
-		}
+		r0 = ret.Get(0).(db.Reservation)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.ListReservationsParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateReservationDatesParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -620,28 +3088,26 @@ func (_m *MockDBStore) ListReservations(ctx context.Context, arg db.ListReservat
 	return r0, r1
 }
 
-// ListReservationsAndRooms provides a mock function with given fields: ctx, arg
-func (_m *MockDBStore) ListReservationsAndRooms(ctx context.Context, arg db.ListReservationsAndRoomsParams) ([]db.ListReservationsAndRoomsRow, error) {
+// UpdateReservationStatus provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) UpdateReservationStatus(ctx context.Context, arg db.UpdateReservationStatusParams) (db.Reservation, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListReservationsAndRooms")
+		panic("no return value specified for UpdateReservationStatus")
 	}
 
-	var r0 []db.ListReservationsAndRoomsRow
+	var r0 db.Reservation
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListReservationsAndRoomsParams) ([]db.ListReservationsAndRoomsRow, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateReservationStatusParams) (db.Reservation, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListReservationsAndRoomsParams) []db.ListReservationsAndRoomsRow); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateReservationStatusParams) db.Reservation); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.ListReservationsAndRoomsRow)
-		}
+		r0 = ret.Get(0).(db.Reservation)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.ListReservationsAndRoomsParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateReservationStatusParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -650,28 +3116,26 @@ func (_m *MockDBStore) ListReservationsAndRooms(ctx context.Context, arg db.List
 	return r0, r1
 }
 
-// ListRoomRestrictions provides a mock function with given fields: ctx, arg
-func (_m *MockDBStore) ListRoomRestrictions(ctx context.Context, arg db.ListRoomRestrictionsParams) ([]db.RoomRestriction, error) {
+// UpdateReservationStatusTx provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) UpdateReservationStatusTx(ctx context.Context, arg db.UpdateReservationStatusTxParams) (db.Reservation, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListRoomRestrictions")
+		panic("no return value specified for UpdateReservationStatusTx")
 	}
 
-	var r0 []db.RoomRestriction
+	var r0 db.Reservation
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListRoomRestrictionsParams) ([]db.RoomRestriction, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateReservationStatusTxParams) (db.Reservation, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListRoomRestrictionsParams) []db.RoomRestriction); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateReservationStatusTxParams) db.Reservation); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.RoomRestriction)
-		}
+		r0 = ret.Get(0).(db.Reservation)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.ListRoomRestrictionsParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateReservationStatusTxParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -680,28 +3144,26 @@ func (_m *MockDBStore) ListRoomRestrictions(ctx context.Context, arg db.ListRoom
 	return r0, r1
 }
 
-// ListRooms provides a mock function with given fields: ctx, arg
-func (_m *MockDBStore) ListRooms(ctx context.Context, arg db.ListRoomsParams) ([]db.Room, error) {
+// UpdateReservationTx provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) UpdateReservationTx(ctx context.Context, arg db.UpdateReservationParams) (db.Reservation, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListRooms")
+		panic("no return value specified for UpdateReservationTx")
 	}
 
-	var r0 []db.Room
+	var r0 db.Reservation
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListRoomsParams) ([]db.Room, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateReservationParams) (db.Reservation, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListRoomsParams) []db.Room); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateReservationParams) db.Reservation); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Room)
-		}
+		r0 = ret.Get(0).(db.Reservation)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.ListRoomsParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateReservationParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -710,28 +3172,44 @@ func (_m *MockDBStore) ListRooms(ctx context.Context, arg db.ListRoomsParams) ([
 	return r0, r1
 }
 
-// ListUsers provides a mock function with given fields: ctx, arg
-func (_m *MockDBStore) ListUsers(ctx context.Context, arg db.ListUsersParams) ([]db.User, error) {
+// UpdateRoom provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) UpdateRoom(ctx context.Context, arg db.UpdateRoomParams) error {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListUsers")
+		panic("no return value specified for UpdateRoom")
 	}
 
-	var r0 []db.User
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateRoomParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateRoomRate provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) UpdateRoomRate(ctx context.Context, arg db.UpdateRoomRateParams) (db.RoomRate, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRoomRate")
+	}
+
+	var r0 db.RoomRate
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListUsersParams) ([]db.User, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateRoomRateParams) (db.RoomRate, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.ListUsersParams) []db.User); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateRoomRateParams) db.RoomRate); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.User)
-		}
+		r0 = ret.Get(0).(db.RoomRate)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.ListUsersParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateRoomRateParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -740,16 +3218,16 @@ func (_m *MockDBStore) ListUsers(ctx context.Context, arg db.ListUsersParams) ([
 	return r0, r1
 }
 
-// UpdateReservation provides a mock function with given fields: ctx, arg
-func (_m *MockDBStore) UpdateReservation(ctx context.Context, arg db.UpdateReservationParams) error {
+// UpdateRoomRestriction provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) UpdateRoomRestriction(ctx context.Context, arg db.UpdateRoomRestrictionParams) error {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateReservation")
+		panic("no return value specified for UpdateRoomRestriction")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateReservationParams) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateRoomRestrictionParams) error); ok {
 		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
@@ -758,16 +3236,16 @@ func (_m *MockDBStore) UpdateReservation(ctx context.Context, arg db.UpdateReser
 	return r0
 }
 
-// UpdateRoom provides a mock function with given fields: ctx, arg
-func (_m *MockDBStore) UpdateRoom(ctx context.Context, arg db.UpdateRoomParams) error {
+// UpdateRoomRestrictionDatesByReservationID provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) UpdateRoomRestrictionDatesByReservationID(ctx context.Context, arg db.UpdateRoomRestrictionDatesByReservationIDParams) error {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateRoom")
+		panic("no return value specified for UpdateRoomRestrictionDatesByReservationID")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateRoomParams) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateRoomRestrictionDatesByReservationIDParams) error); ok {
 		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
@@ -776,16 +3254,16 @@ func (_m *MockDBStore) UpdateRoom(ctx context.Context, arg db.UpdateRoomParams)
 	return r0
 }
 
-// UpdateRoomRestriction provides a mock function with given fields: ctx, arg
-func (_m *MockDBStore) UpdateRoomRestriction(ctx context.Context, arg db.UpdateRoomRestrictionParams) error {
+// UpdateRoomRestrictionsByReservationID provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) UpdateRoomRestrictionsByReservationID(ctx context.Context, arg db.UpdateRoomRestrictionsByReservationIDParams) error {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateRoomRestriction")
+		panic("no return value specified for UpdateRoomRestrictionsByReservationID")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateRoomRestrictionParams) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateRoomRestrictionsByReservationIDParams) error); ok {
 		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
@@ -794,6 +3272,34 @@ func (_m *MockDBStore) UpdateRoomRestriction(ctx context.Context, arg db.UpdateR
 	return r0
 }
 
+// UpdateStayRule provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) UpdateStayRule(ctx context.Context, arg db.UpdateStayRuleParams) (db.StayRule, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStayRule")
+	}
+
+	var r0 db.StayRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateStayRuleParams) (db.StayRule, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateStayRuleParams) db.StayRule); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.StayRule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateStayRuleParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateUser provides a mock function with given fields: ctx, arg
 func (_m *MockDBStore) UpdateUser(ctx context.Context, arg db.UpdateUserParams) error {
 	ret := _m.Called(ctx, arg)
@@ -812,6 +3318,24 @@ func (_m *MockDBStore) UpdateUser(ctx context.Context, arg db.UpdateUserParams)
 	return r0
 }
 
+// UpdateUserDeactivatedAt provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) UpdateUserDeactivatedAt(ctx context.Context, arg db.UpdateUserDeactivatedAtParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserDeactivatedAt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateUserDeactivatedAtParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateUserPassword provides a mock function with given fields: ctx, arg
 func (_m *MockDBStore) UpdateUserPassword(ctx context.Context, arg db.UpdateUserPasswordParams) error {
 	ret := _m.Called(ctx, arg)
@@ -830,6 +3354,108 @@ func (_m *MockDBStore) UpdateUserPassword(ctx context.Context, arg db.UpdateUser
 	return r0
 }
 
+// UpdateUserPasswordToken provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) UpdateUserPasswordToken(ctx context.Context, arg db.UpdateUserPasswordTokenParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserPasswordToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateUserPasswordTokenParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateWaitlistEntryOffer provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) UpdateWaitlistEntryOffer(ctx context.Context, arg db.UpdateWaitlistEntryOfferParams) (db.WaitlistEntry, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWaitlistEntryOffer")
+	}
+
+	var r0 db.WaitlistEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateWaitlistEntryOfferParams) (db.WaitlistEntry, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateWaitlistEntryOfferParams) db.WaitlistEntry); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.WaitlistEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateWaitlistEntryOfferParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertExchangeRate provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) UpsertExchangeRate(ctx context.Context, arg db.UpsertExchangeRateParams) (db.ExchangeRate, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertExchangeRate")
+	}
+
+	var r0 db.ExchangeRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertExchangeRateParams) (db.ExchangeRate, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertExchangeRateParams) db.ExchangeRate); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.ExchangeRate)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpsertExchangeRateParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VoidFolioEntry provides a mock function with given fields: ctx, arg
+func (_m *MockDBStore) VoidFolioEntry(ctx context.Context, arg db.VoidFolioEntryParams) (db.FolioEntry, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VoidFolioEntry")
+	}
+
+	var r0 db.FolioEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.VoidFolioEntryParams) (db.FolioEntry, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.VoidFolioEntryParams) db.FolioEntry); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.FolioEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.VoidFolioEntryParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewMockDBStore creates a new instance of MockDBStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockDBStore(t interface {
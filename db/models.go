@@ -11,11 +11,272 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type ChargeKind string
+
+const (
+	ChargeKindPercent        ChargeKind = "percent"
+	ChargeKindPerNight       ChargeKind = "per_night"
+	ChargeKindPerPersonNight ChargeKind = "per_person_night"
+	ChargeKindPerStay        ChargeKind = "per_stay"
+)
+
+func (e *ChargeKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ChargeKind(s)
+	case string:
+		*e = ChargeKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ChargeKind: %T", src)
+	}
+	return nil
+}
+
+type NullChargeKind struct {
+	ChargeKind ChargeKind `json:"charge_kind"`
+	Valid      bool       `json:"valid"` // Valid is true if ChargeKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullChargeKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.ChargeKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ChargeKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullChargeKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ChargeKind), nil
+}
+
+type DiscountKind string
+
+const (
+	DiscountKindPercent DiscountKind = "percent"
+	DiscountKindFixed   DiscountKind = "fixed"
+)
+
+func (e *DiscountKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DiscountKind(s)
+	case string:
+		*e = DiscountKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DiscountKind: %T", src)
+	}
+	return nil
+}
+
+type NullDiscountKind struct {
+	DiscountKind DiscountKind `json:"discount_kind"`
+	Valid        bool         `json:"valid"` // Valid is true if DiscountKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDiscountKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.DiscountKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DiscountKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDiscountKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DiscountKind), nil
+}
+
+type FolioEntryKind string
+
+const (
+	FolioEntryKindCharge  FolioEntryKind = "charge"
+	FolioEntryKindPayment FolioEntryKind = "payment"
+)
+
+func (e *FolioEntryKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = FolioEntryKind(s)
+	case string:
+		*e = FolioEntryKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for FolioEntryKind: %T", src)
+	}
+	return nil
+}
+
+type NullFolioEntryKind struct {
+	FolioEntryKind FolioEntryKind `json:"folio_entry_kind"`
+	Valid          bool           `json:"valid"` // Valid is true if FolioEntryKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullFolioEntryKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.FolioEntryKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.FolioEntryKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullFolioEntryKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.FolioEntryKind), nil
+}
+
+type PaymentKind string
+
+const (
+	PaymentKindAuthorization PaymentKind = "authorization"
+	PaymentKindCapture       PaymentKind = "capture"
+	PaymentKindRefund        PaymentKind = "refund"
+)
+
+func (e *PaymentKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PaymentKind(s)
+	case string:
+		*e = PaymentKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PaymentKind: %T", src)
+	}
+	return nil
+}
+
+type NullPaymentKind struct {
+	PaymentKind PaymentKind `json:"payment_kind"`
+	Valid       bool        `json:"valid"` // Valid is true if PaymentKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPaymentKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.PaymentKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PaymentKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPaymentKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PaymentKind), nil
+}
+
+type PaymentStatus string
+
+const (
+	PaymentStatusPending   PaymentStatus = "pending"
+	PaymentStatusSucceeded PaymentStatus = "succeeded"
+	PaymentStatusFailed    PaymentStatus = "failed"
+)
+
+func (e *PaymentStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PaymentStatus(s)
+	case string:
+		*e = PaymentStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PaymentStatus: %T", src)
+	}
+	return nil
+}
+
+type NullPaymentStatus struct {
+	PaymentStatus PaymentStatus `json:"payment_status"`
+	Valid         bool          `json:"valid"` // Valid is true if PaymentStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPaymentStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.PaymentStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PaymentStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPaymentStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PaymentStatus), nil
+}
+
+type ReservationStatus string
+
+const (
+	ReservationStatusPending    ReservationStatus = "pending"
+	ReservationStatusConfirmed  ReservationStatus = "confirmed"
+	ReservationStatusCheckedIn  ReservationStatus = "checked_in"
+	ReservationStatusCheckedOut ReservationStatus = "checked_out"
+	ReservationStatusCancelled  ReservationStatus = "cancelled"
+	ReservationStatusNoShow     ReservationStatus = "no_show"
+)
+
+func (e *ReservationStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ReservationStatus(s)
+	case string:
+		*e = ReservationStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ReservationStatus: %T", src)
+	}
+	return nil
+}
+
+type NullReservationStatus struct {
+	ReservationStatus ReservationStatus `json:"reservation_status"`
+	Valid             bool              `json:"valid"` // Valid is true if ReservationStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullReservationStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ReservationStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ReservationStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullReservationStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ReservationStatus), nil
+}
+
 type Restriction string
 
 const (
 	RestrictionReservation Restriction = "reservation"
 	RestrictionOwnerBlock  Restriction = "owner_block"
+	RestrictionHold        Restriction = "hold"
 )
 
 func (e *Restriction) Scan(src interface{}) error {
@@ -53,21 +314,144 @@ func (ns NullRestriction) Value() (driver.Value, error) {
 	return string(ns.Restriction), nil
 }
 
-type Reservation struct {
-	ID        int64              `json:"id"`
-	Code      string             `json:"code"`
-	FirstName string             `json:"first_name"`
-	LastName  string             `json:"last_name"`
-	Email     string             `json:"email"`
-	Phone     pgtype.Text        `json:"phone"`
-	StartDate pgtype.Date        `json:"start_date"`
-	EndDate   pgtype.Date        `json:"end_date"`
-	RoomID    int64              `json:"room_id"`
-	Notes     pgtype.Text        `json:"notes"`
+type Charge struct {
+	ID   int64      `json:"id"`
+	Name string     `json:"name"`
+	Kind ChargeKind `json:"kind"`
+	// hundredths of a percent for percent charges, cents otherwise
+	Amount    int64              `json:"amount"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 }
 
+type ExchangeRate struct {
+	// ISO 4217 code of the display currency
+	Currency string `json:"currency"`
+	Symbol   string `json:"symbol"`
+	// millionths of the currency per unit of the base currency
+	Rate      int64              `json:"rate"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type FolioEntry struct {
+	ID            int64          `json:"id"`
+	ReservationID int64          `json:"reservation_id"`
+	Kind          FolioEntryKind `json:"kind"`
+	Description   string         `json:"description"`
+	// charges add the amount to the balance of the folio, and payments subtract it
+	Amount int64 `json:"amount"`
+	// staff member who posted the entry
+	UserID   pgtype.Int8        `json:"user_id"`
+	VoidedAt pgtype.Timestamptz `json:"voided_at"`
+	// staff member who voided the entry
+	VoidedBy  pgtype.Int8        `json:"voided_by"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Invoice struct {
+	ID       int64  `json:"id"`
+	Property string `json:"property"`
+	// sequential invoice number of the property, without gaps
+	Number        int64 `json:"number"`
+	ReservationID int64 `json:"reservation_id"`
+	// rendered invoice document, empty until rendered
+	Document  string             `json:"document"`
+	IssuedAt  pgtype.Timestamptz `json:"issued_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type InvoiceSequence struct {
+	Property string `json:"property"`
+	// last invoice number issued by the property
+	LastNumber int64 `json:"last_number"`
+}
+
+type Payment struct {
+	ID            int64 `json:"id"`
+	ReservationID int64 `json:"reservation_id"`
+	// authorization of a capture, or capture of a refund
+	ParentID pgtype.Int8 `json:"parent_id"`
+	Provider string      `json:"provider"`
+	// transaction id assigned by the payment provider
+	ProviderRef string             `json:"provider_ref"`
+	Kind        PaymentKind        `json:"kind"`
+	Status      PaymentStatus      `json:"status"`
+	Amount      int64              `json:"amount"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	// refund a refund transaction was made for
+	RefundID pgtype.Int8 `json:"refund_id"`
+}
+
+type PromoCode struct {
+	ID             int64              `json:"id"`
+	Code           string             `json:"code"`
+	Description    string             `json:"description"`
+	DiscountKind   DiscountKind       `json:"discount_kind"`
+	DiscountValue  int64              `json:"discount_value"`
+	ValidFrom      pgtype.Date        `json:"valid_from"`
+	ValidTo        pgtype.Date        `json:"valid_to"`
+	MinNights      int32              `json:"min_nights"`
+	RoomIds        []int64            `json:"room_ids"`
+	MaxRedemptions int32              `json:"max_redemptions"`
+	TimesRedeemed  int32              `json:"times_redeemed"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
+type Refund struct {
+	ID            int64 `json:"id"`
+	ReservationID int64 `json:"reservation_id"`
+	// amount paid and not yet refunded when the refund was made
+	Paid int64 `json:"paid"`
+	// amount refundable according to the cancellation policy
+	PolicyAmount int64 `json:"policy_amount"`
+	Amount       int64 `json:"amount"`
+	// reason for overriding the amount refundable according to the cancellation policy
+	Reason string `json:"reason"`
+	// staff member who made the refund, null for refunds of guest cancellations
+	UserID    pgtype.Int8        `json:"user_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Reservation struct {
+	ID                     int64              `json:"id"`
+	Code                   string             `json:"code"`
+	FirstName              string             `json:"first_name"`
+	LastName               string             `json:"last_name"`
+	Email                  string             `json:"email"`
+	Phone                  pgtype.Text        `json:"phone"`
+	StartDate              pgtype.Date        `json:"start_date"`
+	EndDate                pgtype.Date        `json:"end_date"`
+	RoomID                 int64              `json:"room_id"`
+	Notes                  pgtype.Text        `json:"notes"`
+	CreatedAt              pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt              pgtype.Timestamptz `json:"updated_at"`
+	CancelledAt            pgtype.Timestamptz `json:"cancelled_at"`
+	CancelledBy            pgtype.Text        `json:"cancelled_by"`
+	CancellationFeePercent int32              `json:"cancellation_fee_percent"`
+	ParentCode             pgtype.Text        `json:"parent_code"`
+	Adults                 int32              `json:"adults"`
+	Children               int32              `json:"children"`
+	Status                 ReservationStatus  `json:"status"`
+	ConfirmedAt            pgtype.Timestamptz `json:"confirmed_at"`
+	CheckedInAt            pgtype.Timestamptz `json:"checked_in_at"`
+	CheckedOutAt           pgtype.Timestamptz `json:"checked_out_at"`
+	NoShowAt               pgtype.Timestamptz `json:"no_show_at"`
+	TotalPrice             int64              `json:"total_price"`
+	PromoCodeID            pgtype.Int8        `json:"promo_code_id"`
+	Discount               int64              `json:"discount"`
+}
+
+type ReservationCharge struct {
+	ID            int64              `json:"id"`
+	ReservationID int64              `json:"reservation_id"`
+	ChargeID      pgtype.Int8        `json:"charge_id"`
+	Name          string             `json:"name"`
+	Amount        int64              `json:"amount"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
 type Room struct {
 	ID            int64              `json:"id"`
 	Name          string             `json:"name"`
@@ -75,6 +459,24 @@ type Room struct {
 	ImageFilename string             `json:"image_filename"`
 	CreatedAt     pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	MaxAdults     int32              `json:"max_adults"`
+	MaxChildren   int32              `json:"max_children"`
+	MaxOccupancy  int32              `json:"max_occupancy"`
+	Slug          string             `json:"slug"`
+	NightlyRate   int64              `json:"nightly_rate"`
+}
+
+type RoomRate struct {
+	ID         int64              `json:"id"`
+	RoomID     int64              `json:"room_id"`
+	Name       string             `json:"name"`
+	StartDate  pgtype.Date        `json:"start_date"`
+	EndDate    pgtype.Date        `json:"end_date"`
+	DaysOfWeek int32              `json:"days_of_week"`
+	Rate       int64              `json:"rate"`
+	Priority   int32              `json:"priority"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
 }
 
 type RoomRestriction struct {
@@ -86,15 +488,57 @@ type RoomRestriction struct {
 	Restriction   Restriction        `json:"restriction"`
 	CreatedAt     pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	HoldToken     pgtype.Text        `json:"hold_token"`
+	ExpiresAt     pgtype.Timestamptz `json:"expires_at"`
+	// reason the owner blocked the room, such as personal use or repairs
+	Reason string `json:"reason"`
+}
+
+type StayRule struct {
+	ID                int64              `json:"id"`
+	RoomID            int64              `json:"room_id"`
+	StartDate         pgtype.Date        `json:"start_date"`
+	EndDate           pgtype.Date        `json:"end_date"`
+	DaysOfWeek        int32              `json:"days_of_week"`
+	MinNights         int32              `json:"min_nights"`
+	MaxNights         int32              `json:"max_nights"`
+	ClosedToArrival   bool               `json:"closed_to_arrival"`
+	ClosedToDeparture bool               `json:"closed_to_departure"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
 }
 
 type User struct {
-	ID          int64              `json:"id"`
-	FirstName   string             `json:"first_name"`
-	LastName    string             `json:"last_name"`
-	Email       string             `json:"email"`
-	Password    string             `json:"password"`
+	ID        int64  `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+	Password  string `json:"password"`
+	// role of the staff user: 1 owner, 2 manager, 3 front desk, 4 read-only
 	AccessLevel int64              `json:"access_level"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	// deactivated users cannot log in
+	DeactivatedAt pgtype.Timestamptz `json:"deactivated_at"`
+	// token of the link mailed to the user to set a password
+	PasswordToken          pgtype.Text        `json:"password_token"`
+	PasswordTokenExpiresAt pgtype.Timestamptz `json:"password_token_expires_at"`
+}
+
+type WaitlistEntry struct {
+	ID            int64              `json:"id"`
+	Token         string             `json:"token"`
+	FirstName     string             `json:"first_name"`
+	LastName      string             `json:"last_name"`
+	Email         string             `json:"email"`
+	StartDate     pgtype.Date        `json:"start_date"`
+	EndDate       pgtype.Date        `json:"end_date"`
+	RoomID        pgtype.Int8        `json:"room_id"`
+	Adults        int32              `json:"adults"`
+	Children      int32              `json:"children"`
+	OfferedRoomID pgtype.Int8        `json:"offered_room_id"`
+	NotifiedAt    pgtype.Timestamptz `json:"notified_at"`
+	ExpiresAt     pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
 }
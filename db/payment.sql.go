@@ -0,0 +1,185 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: payment.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createPayment = `-- name: CreatePayment :one
+INSERT INTO payments (
+  reservation_id, parent_id, refund_id, provider, provider_ref, kind, status, amount
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING id, reservation_id, parent_id, provider, provider_ref, kind, status, amount, created_at, updated_at, refund_id
+`
+
+type CreatePaymentParams struct {
+	ReservationID int64         `json:"reservation_id"`
+	ParentID      pgtype.Int8   `json:"parent_id"`
+	RefundID      pgtype.Int8   `json:"refund_id"`
+	Provider      string        `json:"provider"`
+	ProviderRef   string        `json:"provider_ref"`
+	Kind          PaymentKind   `json:"kind"`
+	Status        PaymentStatus `json:"status"`
+	Amount        int64         `json:"amount"`
+}
+
+func (q *Queries) CreatePayment(ctx context.Context, arg CreatePaymentParams) (Payment, error) {
+	row := q.db.QueryRow(ctx, createPayment,
+		arg.ReservationID,
+		arg.ParentID,
+		arg.RefundID,
+		arg.Provider,
+		arg.ProviderRef,
+		arg.Kind,
+		arg.Status,
+		arg.Amount,
+	)
+	var i Payment
+	err := row.Scan(
+		&i.ID,
+		&i.ReservationID,
+		&i.ParentID,
+		&i.Provider,
+		&i.ProviderRef,
+		&i.Kind,
+		&i.Status,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RefundID,
+	)
+	return i, err
+}
+
+const getPaidAmount = `-- name: GetPaidAmount :one
+SELECT COALESCE(SUM(CASE
+    WHEN kind = 'capture' AND status = 'succeeded' THEN amount
+    WHEN kind = 'refund' AND status <> 'failed' THEN -amount
+    ELSE 0
+  END), 0)::bigint AS paid
+FROM payments
+WHERE reservation_id = $1
+`
+
+func (q *Queries) GetPaidAmount(ctx context.Context, reservationID int64) (int64, error) {
+	row := q.db.QueryRow(ctx, getPaidAmount, reservationID)
+	var paid int64
+	err := row.Scan(&paid)
+	return paid, err
+}
+
+const getPayment = `-- name: GetPayment :one
+SELECT id, reservation_id, parent_id, provider, provider_ref, kind, status, amount, created_at, updated_at, refund_id FROM payments
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetPayment(ctx context.Context, id int64) (Payment, error) {
+	row := q.db.QueryRow(ctx, getPayment, id)
+	var i Payment
+	err := row.Scan(
+		&i.ID,
+		&i.ReservationID,
+		&i.ParentID,
+		&i.Provider,
+		&i.ProviderRef,
+		&i.Kind,
+		&i.Status,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RefundID,
+	)
+	return i, err
+}
+
+const listPaymentsByReservation = `-- name: ListPaymentsByReservation :many
+SELECT id, reservation_id, parent_id, provider, provider_ref, kind, status, amount, created_at, updated_at, refund_id FROM payments
+WHERE reservation_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListPaymentsByReservation(ctx context.Context, reservationID int64) ([]Payment, error) {
+	rows, err := q.db.Query(ctx, listPaymentsByReservation, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Payment{}
+	for rows.Next() {
+		var i Payment
+		if err := rows.Scan(
+			&i.ID,
+			&i.ReservationID,
+			&i.ParentID,
+			&i.Provider,
+			&i.ProviderRef,
+			&i.Kind,
+			&i.Status,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.RefundID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updatePaymentStatusByProviderRef = `-- name: UpdatePaymentStatusByProviderRef :many
+UPDATE payments
+SET status = $3,
+    updated_at = now()
+WHERE provider = $1 AND provider_ref = $2
+RETURNING id, reservation_id, parent_id, provider, provider_ref, kind, status, amount, created_at, updated_at, refund_id
+`
+
+type UpdatePaymentStatusByProviderRefParams struct {
+	Provider    string        `json:"provider"`
+	ProviderRef string        `json:"provider_ref"`
+	Status      PaymentStatus `json:"status"`
+}
+
+func (q *Queries) UpdatePaymentStatusByProviderRef(ctx context.Context, arg UpdatePaymentStatusByProviderRefParams) ([]Payment, error) {
+	rows, err := q.db.Query(ctx, updatePaymentStatusByProviderRef, arg.Provider, arg.ProviderRef, arg.Status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Payment{}
+	for rows.Next() {
+		var i Payment
+		if err := rows.Scan(
+			&i.ID,
+			&i.ReservationID,
+			&i.ParentID,
+			&i.Provider,
+			&i.ProviderRef,
+			&i.Kind,
+			&i.Status,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.RefundID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
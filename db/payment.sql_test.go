@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/bookings-web-app/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomPayment(t *testing.T, r Reservation, parentID pgtype.Int8, kind PaymentKind) Payment {
+	arg := CreatePaymentParams{
+		ReservationID: r.ID,
+		ParentID:      parentID,
+		Provider:      "fake",
+		ProviderRef:   util.RandomString(20),
+		Kind:          kind,
+		Status:        PaymentStatusSucceeded,
+		Amount:        util.RandomInt64(100, 10000),
+	}
+
+	p, err := testStore.CreatePayment(context.Background(), arg)
+	require.NoError(t, err)
+	assert.NotEmpty(t, p.ID)
+	assert.Equal(t, arg.ReservationID, p.ReservationID)
+	assert.Equal(t, arg.ParentID, p.ParentID)
+	assert.Equal(t, arg.Provider, p.Provider)
+	assert.Equal(t, arg.ProviderRef, p.ProviderRef)
+	assert.Equal(t, arg.Kind, p.Kind)
+	assert.Equal(t, arg.Status, p.Status)
+	assert.Equal(t, arg.Amount, p.Amount)
+	assert.WithinDuration(t, time.Now(), p.CreatedAt.Time, time.Second)
+	assert.WithinDuration(t, time.Now(), p.UpdatedAt.Time, time.Second)
+
+	return p
+}
+
+func TestQueries_CreatePayment(t *testing.T) {
+	r := createRandomReservation(t, createRandomRoom(t))
+	auth := createRandomPayment(t, r, pgtype.Int8{}, PaymentKindAuthorization)
+	createRandomPayment(t, r, pgtype.Int8{Int64: auth.ID, Valid: true}, PaymentKindCapture)
+}
+
+func TestQueries_GetPayment(t *testing.T) {
+	r := createRandomReservation(t, createRandomRoom(t))
+	p1 := createRandomPayment(t, r, pgtype.Int8{}, PaymentKindAuthorization)
+
+	p2, err := testStore.GetPayment(context.Background(), p1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, p1, p2)
+}
+
+func TestQueries_ListPaymentsByReservation(t *testing.T) {
+	r := createRandomReservation(t, createRandomRoom(t))
+	auth := createRandomPayment(t, r, pgtype.Int8{}, PaymentKindAuthorization)
+	capture := createRandomPayment(t, r, pgtype.Int8{Int64: auth.ID, Valid: true}, PaymentKindCapture)
+
+	payments, err := testStore.ListPaymentsByReservation(context.Background(), r.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []Payment{auth, capture}, payments)
+}
+
+func TestQueries_GetPaidAmount(t *testing.T) {
+	r := createRandomReservation(t, createRandomRoom(t))
+	auth := createRandomPayment(t, r, pgtype.Int8{}, PaymentKindAuthorization)
+	capture := createRandomPayment(t, r, pgtype.Int8{Int64: auth.ID, Valid: true}, PaymentKindCapture)
+	refund := createRandomPayment(t, r, pgtype.Int8{Int64: capture.ID, Valid: true}, PaymentKindRefund)
+
+	// authorizations are not paid, and refunds are deducted from the captures
+	paid, err := testStore.GetPaidAmount(context.Background(), r.ID)
+	require.NoError(t, err)
+	assert.Equal(t, capture.Amount-refund.Amount, paid)
+}
+
+func TestQueries_UpdatePaymentStatusByProviderRef(t *testing.T) {
+	r := createRandomReservation(t, createRandomRoom(t))
+	p := createRandomPayment(t, r, pgtype.Int8{}, PaymentKindCapture)
+
+	arg := UpdatePaymentStatusByProviderRefParams{
+		Provider:    p.Provider,
+		ProviderRef: p.ProviderRef,
+		Status:      PaymentStatusFailed,
+	}
+
+	payments, err := testStore.UpdatePaymentStatusByProviderRef(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, payments, 1)
+	assert.Equal(t, p.ID, payments[0].ID)
+	assert.Equal(t, PaymentStatusFailed, payments[0].Status)
+	assert.WithinDuration(t, time.Now(), payments[0].UpdatedAt.Time, time.Second)
+}
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// PromoCodeRejection is the reason a promo code does not apply to a stay
+type PromoCodeRejection string
+
+const (
+	RejectionNotFound  PromoCodeRejection = "not_found"
+	RejectionDates     PromoCodeRejection = "dates"
+	RejectionMinNights PromoCodeRejection = "min_nights"
+	RejectionRoom      PromoCodeRejection = "room"
+	RejectionUsedUp    PromoCodeRejection = "used_up"
+)
+
+// PromoCodeError is returned when a promo code does not apply to a stay.
+// It wraps ErrPromoCodeRejected.
+type PromoCodeError struct {
+	Code      string
+	PromoCode PromoCode
+	Rejection PromoCodeRejection
+}
+
+func (e *PromoCodeError) Error() string {
+	return fmt.Sprintf("promo code %s rejected: %s", e.Code, e.Rejection)
+}
+
+func (e *PromoCodeError) Unwrap() error {
+	return ErrPromoCodeRejected
+}
+
+// Check returns a PromoCodeError if promo code p does not apply to a stay in room roomID from startDate to endDate.
+// A promo code applies to stays arriving from its valid from date to its valid to date, both inclusive,
+// of at least its minimum nights, in any of its rooms or in any room if it has none,
+// as long as it was not redeemed its maximum redemptions already.
+func (p PromoCode) Check(roomID int64, startDate, endDate time.Time) error {
+	nights := int32(endDate.Sub(startDate).Hours() / 24)
+
+	switch {
+	case startDate.Before(p.ValidFrom.Time) || startDate.After(p.ValidTo.Time):
+		return &PromoCodeError{Code: p.Code, PromoCode: p, Rejection: RejectionDates}
+	case nights < p.MinNights:
+		return &PromoCodeError{Code: p.Code, PromoCode: p, Rejection: RejectionMinNights}
+	case len(p.RoomIds) > 0 && !slices.Contains(p.RoomIds, roomID):
+		return &PromoCodeError{Code: p.Code, PromoCode: p, Rejection: RejectionRoom}
+	case p.MaxRedemptions > 0 && p.TimesRedeemed >= p.MaxRedemptions:
+		return &PromoCodeError{Code: p.Code, PromoCode: p, Rejection: RejectionUsedUp}
+	}
+
+	return nil
+}
+
+// DiscountFor returns the discount of promo code p on a price, in cents.
+// A percent discount is rounded down to the cent, and a fixed discount is limited to the price.
+func (p PromoCode) DiscountFor(price int64) int64 {
+	switch p.DiscountKind {
+	case DiscountKindPercent:
+		return price * p.DiscountValue / 100
+	case DiscountKindFixed:
+		return min(p.DiscountValue, price)
+	default:
+		return 0
+	}
+}
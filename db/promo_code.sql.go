@@ -0,0 +1,165 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: promo_code.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createPromoCode = `-- name: CreatePromoCode :one
+INSERT INTO promo_codes (
+  code, description, discount_kind, discount_value, valid_from, valid_to, min_nights, room_ids, max_redemptions
+) VALUES (
+  upper($1::text), $2, $3, $4, $5,
+  $6, $7, $8, $9
+)
+RETURNING id, code, description, discount_kind, discount_value, valid_from, valid_to, min_nights, room_ids, max_redemptions, times_redeemed, created_at, updated_at
+`
+
+type CreatePromoCodeParams struct {
+	Code           string       `json:"code"`
+	Description    string       `json:"description"`
+	DiscountKind   DiscountKind `json:"discount_kind"`
+	DiscountValue  int64        `json:"discount_value"`
+	ValidFrom      pgtype.Date  `json:"valid_from"`
+	ValidTo        pgtype.Date  `json:"valid_to"`
+	MinNights      int32        `json:"min_nights"`
+	RoomIds        []int64      `json:"room_ids"`
+	MaxRedemptions int32        `json:"max_redemptions"`
+}
+
+func (q *Queries) CreatePromoCode(ctx context.Context, arg CreatePromoCodeParams) (PromoCode, error) {
+	row := q.db.QueryRow(ctx, createPromoCode,
+		arg.Code,
+		arg.Description,
+		arg.DiscountKind,
+		arg.DiscountValue,
+		arg.ValidFrom,
+		arg.ValidTo,
+		arg.MinNights,
+		arg.RoomIds,
+		arg.MaxRedemptions,
+	)
+	var i PromoCode
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Description,
+		&i.DiscountKind,
+		&i.DiscountValue,
+		&i.ValidFrom,
+		&i.ValidTo,
+		&i.MinNights,
+		&i.RoomIds,
+		&i.MaxRedemptions,
+		&i.TimesRedeemed,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteAllPromoCodes = `-- name: DeleteAllPromoCodes :exec
+DELETE FROM promo_codes
+`
+
+func (q *Queries) DeleteAllPromoCodes(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, deleteAllPromoCodes)
+	return err
+}
+
+const deletePromoCode = `-- name: DeletePromoCode :exec
+DELETE FROM promo_codes
+WHERE id = $1
+`
+
+func (q *Queries) DeletePromoCode(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, deletePromoCode, id)
+	return err
+}
+
+const getPromoCode = `-- name: GetPromoCode :one
+SELECT id, code, description, discount_kind, discount_value, valid_from, valid_to, min_nights, room_ids, max_redemptions, times_redeemed, created_at, updated_at FROM promo_codes
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetPromoCode(ctx context.Context, id int64) (PromoCode, error) {
+	row := q.db.QueryRow(ctx, getPromoCode, id)
+	var i PromoCode
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Description,
+		&i.DiscountKind,
+		&i.DiscountValue,
+		&i.ValidFrom,
+		&i.ValidTo,
+		&i.MinNights,
+		&i.RoomIds,
+		&i.MaxRedemptions,
+		&i.TimesRedeemed,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getPromoCodeByCode = `-- name: GetPromoCodeByCode :one
+SELECT id, code, description, discount_kind, discount_value, valid_from, valid_to, min_nights, room_ids, max_redemptions, times_redeemed, created_at, updated_at FROM promo_codes
+WHERE code = upper($1) LIMIT 1
+`
+
+func (q *Queries) GetPromoCodeByCode(ctx context.Context, code interface{}) (PromoCode, error) {
+	row := q.db.QueryRow(ctx, getPromoCodeByCode, code)
+	var i PromoCode
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Description,
+		&i.DiscountKind,
+		&i.DiscountValue,
+		&i.ValidFrom,
+		&i.ValidTo,
+		&i.MinNights,
+		&i.RoomIds,
+		&i.MaxRedemptions,
+		&i.TimesRedeemed,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const redeemPromoCode = `-- name: RedeemPromoCode :one
+UPDATE promo_codes
+  set   times_redeemed = times_redeemed + 1,
+        updated_at = now()
+WHERE id = $1 AND (max_redemptions = 0 OR times_redeemed < max_redemptions)
+RETURNING id, code, description, discount_kind, discount_value, valid_from, valid_to, min_nights, room_ids, max_redemptions, times_redeemed, created_at, updated_at
+`
+
+func (q *Queries) RedeemPromoCode(ctx context.Context, id int64) (PromoCode, error) {
+	row := q.db.QueryRow(ctx, redeemPromoCode, id)
+	var i PromoCode
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Description,
+		&i.DiscountKind,
+		&i.DiscountValue,
+		&i.ValidFrom,
+		&i.ValidTo,
+		&i.MinNights,
+		&i.RoomIds,
+		&i.MaxRedemptions,
+		&i.TimesRedeemed,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
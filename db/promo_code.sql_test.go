@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/bookings-web-app/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomPromoCode creates a percent promo code for arrivals from validFrom to validTo, limited to maxRedemptions
+func createRandomPromoCode(t *testing.T, validFrom, validTo time.Time, maxRedemptions int32) PromoCode {
+	arg := CreatePromoCodeParams{
+		Code:           strings.ToLower(util.RandomString(10)),
+		Description:    util.RandomNote(),
+		DiscountKind:   DiscountKindPercent,
+		DiscountValue:  util.RandomInt64(1, 50),
+		RoomIds:        []int64{},
+		MaxRedemptions: maxRedemptions,
+	}
+	arg.ValidFrom.Scan(validFrom)
+	arg.ValidTo.Scan(validTo)
+
+	promo, err := testStore.CreatePromoCode(context.Background(), arg)
+	require.NoError(t, err)
+	assert.NotEmpty(t, promo.ID)
+	assert.Equal(t, strings.ToUpper(arg.Code), promo.Code)
+	assert.Equal(t, arg.Description, promo.Description)
+	assert.Equal(t, arg.DiscountKind, promo.DiscountKind)
+	assert.Equal(t, arg.DiscountValue, promo.DiscountValue)
+	assert.Equal(t, arg.ValidFrom, promo.ValidFrom)
+	assert.Equal(t, arg.ValidTo, promo.ValidTo)
+	assert.Equal(t, arg.MaxRedemptions, promo.MaxRedemptions)
+	assert.Zero(t, promo.TimesRedeemed)
+	assert.WithinDuration(t, time.Now(), promo.CreatedAt.Time, time.Second)
+	assert.WithinDuration(t, time.Now(), promo.UpdatedAt.Time, time.Second)
+
+	return promo
+}
+
+func TestQueries_CreatePromoCode(t *testing.T) {
+	rDate := util.RandomDate()
+	createRandomPromoCode(t, rDate, rDate.AddDate(0, 0, 30), 0)
+}
+
+func TestQueries_GetPromoCodeByCode(t *testing.T) {
+	rDate := util.RandomDate()
+	promo := createRandomPromoCode(t, rDate, rDate.AddDate(0, 0, 30), 0)
+
+	// codes are matched regardless of case
+	got, err := testStore.GetPromoCodeByCode(context.Background(), strings.ToLower(promo.Code))
+	require.NoError(t, err)
+	assert.Equal(t, promo.ID, got.ID)
+
+	_, err = testStore.GetPromoCodeByCode(context.Background(), util.RandomString(12))
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestQueries_RedeemPromoCode(t *testing.T) {
+	rDate := util.RandomDate()
+	promo := createRandomPromoCode(t, rDate, rDate.AddDate(0, 0, 30), 2)
+
+	for i := int32(1); i <= 2; i++ {
+		redeemed, err := testStore.RedeemPromoCode(context.Background(), promo.ID)
+		require.NoError(t, err)
+		assert.Equal(t, i, redeemed.TimesRedeemed)
+	}
+
+	// the promo code is used up
+	_, err := testStore.RedeemPromoCode(context.Background(), promo.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestQueries_DeletePromoCode(t *testing.T) {
+	rDate := util.RandomDate()
+	promo := createRandomPromoCode(t, rDate, rDate.AddDate(0, 0, 30), 0)
+
+	err := testStore.DeletePromoCode(context.Background(), promo.ID)
+	require.NoError(t, err)
+
+	_, err = testStore.GetPromoCode(context.Background(), promo.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
@@ -0,0 +1,98 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromoCode_Check(t *testing.T) {
+	june := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	// newPromo returns a promo code for arrivals in June 2024
+	newPromo := func() PromoCode {
+		return PromoCode{
+			ID:            1,
+			Code:          "SUMMER",
+			DiscountKind:  DiscountKindPercent,
+			DiscountValue: 10,
+			ValidFrom:     pgtype.Date{Time: june, Valid: true},
+			ValidTo:       pgtype.Date{Time: time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC), Valid: true},
+		}
+	}
+
+	// requireRejection asserts that err is a PromoCodeError of rejection
+	requireRejection := func(t *testing.T, err error, rejection PromoCodeRejection) {
+		require.ErrorIs(t, err, ErrPromoCodeRejected)
+
+		var promoErr *PromoCodeError
+		require.ErrorAs(t, err, &promoErr)
+		assert.Equal(t, "SUMMER", promoErr.Code)
+		assert.Equal(t, rejection, promoErr.Rejection)
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		promo := newPromo()
+		assert.NoError(t, promo.Check(1, june, june.AddDate(0, 0, 1)))
+
+		// only the arrival date must be within the valid dates
+		assert.NoError(t, promo.Check(1, june.AddDate(0, 0, 29), june.AddDate(0, 0, 35)))
+	})
+
+	t.Run("Dates", func(t *testing.T) {
+		promo := newPromo()
+		requireRejection(t, promo.Check(1, june.AddDate(0, 0, -1), june.AddDate(0, 0, 2)), RejectionDates)
+		requireRejection(t, promo.Check(1, june.AddDate(0, 0, 30), june.AddDate(0, 0, 32)), RejectionDates)
+	})
+
+	t.Run("Min Nights", func(t *testing.T) {
+		promo := newPromo()
+		promo.MinNights = 3
+
+		requireRejection(t, promo.Check(1, june, june.AddDate(0, 0, 2)), RejectionMinNights)
+		assert.NoError(t, promo.Check(1, june, june.AddDate(0, 0, 3)))
+	})
+
+	t.Run("Room", func(t *testing.T) {
+		promo := newPromo()
+		promo.RoomIds = []int64{2, 3}
+
+		requireRejection(t, promo.Check(1, june, june.AddDate(0, 0, 1)), RejectionRoom)
+		assert.NoError(t, promo.Check(3, june, june.AddDate(0, 0, 1)))
+	})
+
+	t.Run("Used Up", func(t *testing.T) {
+		promo := newPromo()
+		promo.MaxRedemptions = 2
+		promo.TimesRedeemed = 1
+		assert.NoError(t, promo.Check(1, june, june.AddDate(0, 0, 1)))
+
+		promo.TimesRedeemed = 2
+		requireRejection(t, promo.Check(1, june, june.AddDate(0, 0, 1)), RejectionUsedUp)
+	})
+}
+
+func TestPromoCode_DiscountFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     DiscountKind
+		value    int64
+		price    int64
+		discount int64
+	}{
+		{name: "Percent", kind: DiscountKindPercent, value: 10, price: 25000, discount: 2500},
+		{name: "Percent Rounded Down", kind: DiscountKindPercent, value: 15, price: 999, discount: 149},
+		{name: "Fixed", kind: DiscountKindFixed, value: 5000, price: 25000, discount: 5000},
+		{name: "Fixed Above Price", kind: DiscountKindFixed, value: 50000, price: 25000, discount: 25000},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			promo := PromoCode{DiscountKind: test.kind, DiscountValue: test.value}
+			assert.Equal(t, test.discount, promo.DiscountFor(test.price))
+		})
+	}
+}
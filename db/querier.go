@@ -6,39 +6,127 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
+	CancelReservation(ctx context.Context, arg CancelReservationParams) (Reservation, error)
 	CheckRoomAvailability(ctx context.Context, arg CheckRoomAvailabilityParams) (bool, error)
+	CheckRoomAvailabilityForReservation(ctx context.Context, arg CheckRoomAvailabilityForReservationParams) (bool, error)
+	CountReservationsByRoom(ctx context.Context, roomID int64) (int64, error)
+	CreateCharge(ctx context.Context, arg CreateChargeParams) (Charge, error)
+	CreateFolioEntry(ctx context.Context, arg CreateFolioEntryParams) (FolioEntry, error)
+	CreateInvoice(ctx context.Context, arg CreateInvoiceParams) (Invoice, error)
+	CreateOwnerBlock(ctx context.Context, arg CreateOwnerBlockParams) (RoomRestriction, error)
+	CreatePayment(ctx context.Context, arg CreatePaymentParams) (Payment, error)
+	CreatePromoCode(ctx context.Context, arg CreatePromoCodeParams) (PromoCode, error)
+	CreateRefund(ctx context.Context, arg CreateRefundParams) (Refund, error)
 	CreateReservation(ctx context.Context, arg CreateReservationParams) (Reservation, error)
+	CreateReservationCharge(ctx context.Context, arg CreateReservationChargeParams) (ReservationCharge, error)
 	CreateRoom(ctx context.Context, arg CreateRoomParams) (Room, error)
+	CreateRoomHold(ctx context.Context, arg CreateRoomHoldParams) (RoomRestriction, error)
+	CreateRoomRate(ctx context.Context, arg CreateRoomRateParams) (RoomRate, error)
 	CreateRoomRestriction(ctx context.Context, arg CreateRoomRestrictionParams) (RoomRestriction, error)
+	CreateStayRule(ctx context.Context, arg CreateStayRuleParams) (StayRule, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateWaitlistEntry(ctx context.Context, arg CreateWaitlistEntryParams) (WaitlistEntry, error)
+	DeleteAllCharges(ctx context.Context) error
+	DeleteAllPromoCodes(ctx context.Context) error
 	DeleteAllReservations(ctx context.Context) error
+	DeleteAllRoomRates(ctx context.Context) error
 	DeleteAllRoomRestrictions(ctx context.Context) error
 	DeleteAllRooms(ctx context.Context) error
+	DeleteAllStayRules(ctx context.Context) error
+	DeleteAllWaitlistEntries(ctx context.Context) error
+	DeleteCharges(ctx context.Context, ids []int64) error
+	DeleteExchangeRates(ctx context.Context, currencies []string) error
+	DeleteExpiredRoomHolds(ctx context.Context) ([]RoomRestriction, error)
+	DeleteOwnerBlocks(ctx context.Context, ids []int64) ([]RoomRestriction, error)
+	DeletePromoCode(ctx context.Context, id int64) error
 	DeleteReservation(ctx context.Context, id int64) error
+	DeleteReservationCharges(ctx context.Context, reservationID int64) error
 	DeleteRoom(ctx context.Context, id int64) error
+	DeleteRoomHold(ctx context.Context, arg DeleteRoomHoldParams) error
+	DeleteRoomHoldsByToken(ctx context.Context, holdToken pgtype.Text) error
+	DeleteRoomRates(ctx context.Context, ids []int64) error
 	DeleteRoomRestriction(ctx context.Context, id int64) error
+	DeleteRoomRestrictionsByReservationID(ctx context.Context, reservationID pgtype.Int8) error
+	DeleteStayRule(ctx context.Context, id int64) error
 	DeleteUser(ctx context.Context, id int64) error
+	GetCharge(ctx context.Context, id int64) (Charge, error)
+	GetFolioBalance(ctx context.Context, reservationID int64) (int64, error)
+	GetInvoiceByReservation(ctx context.Context, reservationID int64) (Invoice, error)
 	GetLastRoomRestriction(ctx context.Context, roomID int64) (RoomRestriction, error)
+	GetOwnerBlock(ctx context.Context, id int64) (RoomRestriction, error)
+	GetPaidAmount(ctx context.Context, reservationID int64) (int64, error)
+	GetPayment(ctx context.Context, id int64) (Payment, error)
+	GetPromoCode(ctx context.Context, id int64) (PromoCode, error)
+	GetPromoCodeByCode(ctx context.Context, code interface{}) (PromoCode, error)
 	GetReservation(ctx context.Context, id int64) (Reservation, error)
+	GetReservationAndRoom(ctx context.Context, id int64) (GetReservationAndRoomRow, error)
 	GetReservationByLastName(ctx context.Context, arg GetReservationByLastNameParams) (Reservation, error)
+	GetReservationForUpdate(ctx context.Context, id int64) (Reservation, error)
 	GetRoom(ctx context.Context, id int64) (Room, error)
+	GetRoomBySlug(ctx context.Context, slug string) (Room, error)
+	GetRoomForUpdate(ctx context.Context, id int64) (Room, error)
+	GetRoomRate(ctx context.Context, id int64) (RoomRate, error)
 	GetRoomRestriction(ctx context.Context, id int64) (RoomRestriction, error)
+	GetStayRule(ctx context.Context, id int64) (StayRule, error)
 	GetUser(ctx context.Context, id int64) (User, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
+	GetUserByPasswordToken(ctx context.Context, passwordToken pgtype.Text) (User, error)
+	GetWaitlistEntryByToken(ctx context.Context, token string) (WaitlistEntry, error)
+	ListArrivalsAndRooms(ctx context.Context, startDate pgtype.Date) ([]ListArrivalsAndRoomsRow, error)
+	// a party is split across several rooms with at least one adult in every room,
+	// so every room that can accommodate one of the adults is returned
 	ListAvailableRooms(ctx context.Context, arg ListAvailableRoomsParams) ([]Room, error)
+	ListCharges(ctx context.Context) ([]Charge, error)
+	ListDeparturesAndRooms(ctx context.Context, date pgtype.Date) ([]ListDeparturesAndRoomsRow, error)
+	ListExchangeRates(ctx context.Context) ([]ExchangeRate, error)
+	ListFolioEntriesByReservation(ctx context.Context, reservationID int64) ([]FolioEntry, error)
+	ListOwnerBlockConflicts(ctx context.Context, arg ListOwnerBlockConflictsParams) ([]RoomRestriction, error)
+	ListOwnerBlocksAndRooms(ctx context.Context, arg ListOwnerBlocksAndRoomsParams) ([]ListOwnerBlocksAndRoomsRow, error)
+	ListPaymentsByReservation(ctx context.Context, reservationID int64) ([]Payment, error)
+	ListRefundsByReservation(ctx context.Context, reservationID int64) ([]Refund, error)
+	ListReservationCharges(ctx context.Context, reservationID int64) ([]ReservationCharge, error)
 	ListReservations(ctx context.Context, arg ListReservationsParams) ([]Reservation, error)
 	ListReservationsAndRooms(ctx context.Context, arg ListReservationsAndRoomsParams) ([]ListReservationsAndRoomsRow, error)
+	ListReservationsAndRoomsByStatus(ctx context.Context, arg ListReservationsAndRoomsByStatusParams) ([]ListReservationsAndRoomsByStatusRow, error)
+	ListRoomRatesAndRooms(ctx context.Context, arg ListRoomRatesAndRoomsParams) ([]ListRoomRatesAndRoomsRow, error)
+	ListRoomRatesForStay(ctx context.Context, arg ListRoomRatesForStayParams) ([]RoomRate, error)
 	ListRoomRestrictions(ctx context.Context, arg ListRoomRestrictionsParams) ([]RoomRestriction, error)
+	ListRoomRestrictionsByReservationID(ctx context.Context, reservationID pgtype.Int8) ([]RoomRestriction, error)
+	ListRoomRestrictionsForPeriod(ctx context.Context, arg ListRoomRestrictionsForPeriodParams) ([]RoomRestriction, error)
 	ListRooms(ctx context.Context, arg ListRoomsParams) ([]Room, error)
+	ListStayRulesForDates(ctx context.Context, arg ListStayRulesForDatesParams) ([]StayRule, error)
+	ListStayRulesForStay(ctx context.Context, arg ListStayRulesForStayParams) ([]StayRule, error)
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
-	UpdateReservation(ctx context.Context, arg UpdateReservationParams) error
+	ListWaitlistEntriesForRoom(ctx context.Context, arg ListWaitlistEntriesForRoomParams) ([]WaitlistEntry, error)
+	// NextInvoiceNumber increments the invoice sequence of the property and locks it until the transaction ends,
+	// so that a number is only used by a committed invoice.
+	NextInvoiceNumber(ctx context.Context, property string) (int64, error)
+	RedeemPromoCode(ctx context.Context, id int64) (PromoCode, error)
+	ShortenRoomRestrictionsByReservationID(ctx context.Context, arg ShortenRoomRestrictionsByReservationIDParams) error
+	UpdateInvoiceDocument(ctx context.Context, arg UpdateInvoiceDocumentParams) (Invoice, error)
+	UpdateOwnerBlock(ctx context.Context, arg UpdateOwnerBlockParams) (RoomRestriction, error)
+	UpdatePaymentStatusByProviderRef(ctx context.Context, arg UpdatePaymentStatusByProviderRefParams) ([]Payment, error)
+	UpdateReservation(ctx context.Context, arg UpdateReservationParams) (Reservation, error)
+	UpdateReservationDates(ctx context.Context, arg UpdateReservationDatesParams) (Reservation, error)
+	UpdateReservationStatus(ctx context.Context, arg UpdateReservationStatusParams) (Reservation, error)
 	UpdateRoom(ctx context.Context, arg UpdateRoomParams) error
+	UpdateRoomRate(ctx context.Context, arg UpdateRoomRateParams) (RoomRate, error)
 	UpdateRoomRestriction(ctx context.Context, arg UpdateRoomRestrictionParams) error
+	UpdateRoomRestrictionDatesByReservationID(ctx context.Context, arg UpdateRoomRestrictionDatesByReservationIDParams) error
+	UpdateRoomRestrictionsByReservationID(ctx context.Context, arg UpdateRoomRestrictionsByReservationIDParams) error
+	UpdateStayRule(ctx context.Context, arg UpdateStayRuleParams) (StayRule, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) error
+	UpdateUserDeactivatedAt(ctx context.Context, arg UpdateUserDeactivatedAtParams) error
 	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
+	UpdateUserPasswordToken(ctx context.Context, arg UpdateUserPasswordTokenParams) error
+	UpdateWaitlistEntryOffer(ctx context.Context, arg UpdateWaitlistEntryOfferParams) (WaitlistEntry, error)
+	UpsertExchangeRate(ctx context.Context, arg UpsertExchangeRateParams) (ExchangeRate, error)
+	VoidFolioEntry(ctx context.Context, arg VoidFolioEntryParams) (FolioEntry, error)
 }
 
 var _ Querier = (*Queries)(nil)
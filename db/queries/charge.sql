@@ -0,0 +1,22 @@
+-- name: CreateCharge :one
+INSERT INTO charges (
+  name, kind, amount
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: DeleteAllCharges :exec
+DELETE FROM charges;
+
+-- name: DeleteCharges :exec
+DELETE FROM charges
+WHERE id = ANY(@ids::bigint[]);
+
+-- name: GetCharge :one
+SELECT * FROM charges
+WHERE id = $1 LIMIT 1;
+
+-- name: ListCharges :many
+SELECT * FROM charges
+ORDER BY id;
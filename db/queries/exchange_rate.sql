@@ -0,0 +1,19 @@
+-- name: DeleteExchangeRates :exec
+DELETE FROM exchange_rates
+WHERE currency = ANY(@currencies::varchar[]);
+
+-- name: ListExchangeRates :many
+SELECT * FROM exchange_rates
+ORDER BY currency;
+
+-- name: UpsertExchangeRate :one
+INSERT INTO exchange_rates (
+  currency, symbol, rate
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (currency) DO UPDATE
+SET symbol = EXCLUDED.symbol,
+    rate = EXCLUDED.rate,
+    updated_at = now()
+RETURNING *;
@@ -0,0 +1,24 @@
+-- name: CreateFolioEntry :one
+INSERT INTO folio_entries (
+  reservation_id, kind, description, amount, user_id
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetFolioBalance :one
+SELECT COALESCE(SUM(CASE WHEN kind = 'charge' THEN amount ELSE -amount END), 0)::bigint AS balance
+FROM folio_entries
+WHERE reservation_id = $1 AND voided_at IS NULL;
+
+-- name: ListFolioEntriesByReservation :many
+SELECT * FROM folio_entries
+WHERE reservation_id = $1
+ORDER BY id;
+
+-- name: VoidFolioEntry :one
+UPDATE folio_entries
+SET voided_at = now(),
+    voided_by = $3
+WHERE id = $1 AND reservation_id = $2 AND voided_at IS NULL
+RETURNING *;
@@ -0,0 +1,31 @@
+-- name: NextInvoiceNumber :one
+-- NextInvoiceNumber increments the invoice sequence of the property and locks it until the transaction ends,
+-- so that a number is only used by a committed invoice.
+INSERT INTO invoice_sequences (
+  property, last_number
+) VALUES (
+  $1, 1
+)
+ON CONFLICT (property) DO UPDATE
+SET last_number = invoice_sequences.last_number + 1
+RETURNING last_number;
+
+-- name: CreateInvoice :one
+INSERT INTO invoices (
+  property, number, reservation_id
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: GetInvoiceByReservation :one
+SELECT * FROM invoices
+WHERE reservation_id = $1
+LIMIT 1;
+
+-- name: UpdateInvoiceDocument :one
+UPDATE invoices
+SET document = $2,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,32 @@
+-- name: CreatePayment :one
+INSERT INTO payments (
+  reservation_id, parent_id, refund_id, provider, provider_ref, kind, status, amount
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: GetPayment :one
+SELECT * FROM payments
+WHERE id = $1 LIMIT 1;
+
+-- name: ListPaymentsByReservation :many
+SELECT * FROM payments
+WHERE reservation_id = $1
+ORDER BY id;
+
+-- name: UpdatePaymentStatusByProviderRef :many
+UPDATE payments
+SET status = $3,
+    updated_at = now()
+WHERE provider = $1 AND provider_ref = $2
+RETURNING *;
+
+-- name: GetPaidAmount :one
+SELECT COALESCE(SUM(CASE
+    WHEN kind = 'capture' AND status = 'succeeded' THEN amount
+    WHEN kind = 'refund' AND status <> 'failed' THEN -amount
+    ELSE 0
+  END), 0)::bigint AS paid
+FROM payments
+WHERE reservation_id = $1;
@@ -0,0 +1,30 @@
+-- name: CreatePromoCode :one
+INSERT INTO promo_codes (
+  code, description, discount_kind, discount_value, valid_from, valid_to, min_nights, room_ids, max_redemptions
+) VALUES (
+  upper(sqlc.arg(code)::text), sqlc.arg(description), sqlc.arg(discount_kind), sqlc.arg(discount_value), sqlc.arg(valid_from),
+  sqlc.arg(valid_to), sqlc.arg(min_nights), sqlc.arg(room_ids), sqlc.arg(max_redemptions)
+)
+RETURNING *;
+
+-- name: DeleteAllPromoCodes :exec
+DELETE FROM promo_codes;
+
+-- name: DeletePromoCode :exec
+DELETE FROM promo_codes
+WHERE id = $1;
+
+-- name: GetPromoCode :one
+SELECT * FROM promo_codes
+WHERE id = $1 LIMIT 1;
+
+-- name: GetPromoCodeByCode :one
+SELECT * FROM promo_codes
+WHERE code = upper(sqlc.arg(code)) LIMIT 1;
+
+-- name: RedeemPromoCode :one
+UPDATE promo_codes
+  set   times_redeemed = times_redeemed + 1,
+        updated_at = now()
+WHERE id = $1 AND (max_redemptions = 0 OR times_redeemed < max_redemptions)
+RETURNING *;
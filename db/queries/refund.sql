@@ -0,0 +1,12 @@
+-- name: CreateRefund :one
+INSERT INTO refunds (
+  reservation_id, paid, policy_amount, amount, reason, user_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: ListRefundsByReservation :many
+SELECT * FROM refunds
+WHERE reservation_id = $1
+ORDER BY id;
@@ -1,8 +1,23 @@
+-- name: CancelReservation :one
+UPDATE reservations
+  set   cancelled_at = now(),
+        cancelled_by = $2,
+        cancellation_fee_percent = $3,
+        status = 'cancelled',
+        updated_at = now()
+WHERE id = $1 AND status IN ('pending', 'confirmed')
+RETURNING *;
+
+-- name: CountReservationsByRoom :one
+SELECT count(*) FROM reservations
+WHERE room_id = $1;
+
 -- name: CreateReservation :one
 INSERT INTO reservations (
-  code, first_name, last_name, email, phone, start_date, end_date, room_id, notes
+  code, first_name, last_name, email, phone, start_date, end_date, room_id, notes, parent_code, adults, children, total_price,
+  promo_code_id, discount
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8, $9
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
 )
 RETURNING *;
 
@@ -17,9 +32,20 @@ WHERE id = $1;
 SELECT * FROM reservations
 WHERE id = $1 LIMIT 1;
 
+-- name: GetReservationAndRoom :one
+SELECT sqlc.embed(reservations), sqlc.embed(rooms) 
+FROM reservations
+LEFT JOIN rooms ON (reservations.room_id = rooms.id)
+WHERE reservations.id = $1 LIMIT 1;
+
+-- name: GetReservationForUpdate :one
+SELECT * FROM reservations
+WHERE id = $1 LIMIT 1
+FOR UPDATE;
+
 -- name: GetReservationByLastName :one
 SELECT * FROM reservations
-WHERE code = $1 AND last_name = $2 LIMIT 1;
+WHERE code = @code AND lower(last_name) = lower(@last_name) LIMIT 1;
 
 -- name: ListReservations :many
 SELECT * FROM reservations 
@@ -35,17 +61,68 @@ ORDER BY reservations.start_date, rooms.name ASC
 LIMIT $1
 OFFSET $2;
 
+-- name: ListArrivalsAndRooms :many
+SELECT sqlc.embed(reservations), sqlc.embed(rooms) 
+FROM reservations
+LEFT JOIN rooms ON (reservations.room_id = rooms.id)
+WHERE reservations.start_date = $1
+AND reservations.status IN ('pending', 'confirmed', 'checked_in')
+ORDER BY rooms.name ASC;
 
--- name: UpdateReservation :exec
+-- name: ListDeparturesAndRooms :many
+SELECT sqlc.embed(reservations), sqlc.embed(rooms) 
+FROM reservations
+LEFT JOIN rooms ON (reservations.room_id = rooms.id)
+WHERE (reservations.status = 'checked_in' AND reservations.end_date <= sqlc.arg(date)::date)
+OR (reservations.status = 'checked_out' AND reservations.checked_out_at::date = sqlc.arg(date)::date)
+ORDER BY reservations.end_date, rooms.name ASC;
+
+-- name: ListReservationsAndRoomsByStatus :many
+SELECT sqlc.embed(reservations), sqlc.embed(rooms) 
+FROM reservations
+LEFT JOIN rooms ON (reservations.room_id = rooms.id)
+WHERE reservations.status = $1
+ORDER BY reservations.start_date, rooms.name ASC
+LIMIT $2
+OFFSET $3;
+
+-- name: UpdateReservation :one
 UPDATE reservations
-  set   code = $2,
-        first_name = $3,
-        last_name = $4, 
-        email = $5,
-        phone = $6, 
-        start_date =  $7,
-        end_date = $8,
-        room_id = $9,
-        notes = $10,
-        updated_at = $11
-WHERE id = $1;
\ No newline at end of file
+  set   first_name = $2,
+        last_name = $3,
+        email = $4,
+        phone = $5,
+        start_date = $6,
+        end_date = $7,
+        room_id = $8,
+        notes = $9,
+        adults = $10,
+        children = $11,
+        total_price = $12,
+        discount = $13,
+        updated_at = now()
+WHERE id = $1 AND status IN ('pending', 'confirmed', 'checked_in')
+RETURNING *;
+
+-- name: UpdateReservationDates :one
+UPDATE reservations
+  set   start_date = $2,
+        end_date = $3,
+        total_price = $4,
+        discount = $5,
+        updated_at = now()
+WHERE id = $1 AND status IN ('pending', 'confirmed')
+RETURNING *;
+
+-- name: UpdateReservationStatus :one
+UPDATE reservations
+  set   status = sqlc.arg(status)::reservation_status,
+        confirmed_at = CASE WHEN sqlc.arg(status)::reservation_status = 'confirmed' THEN now() ELSE confirmed_at END,
+        checked_in_at = CASE WHEN sqlc.arg(status)::reservation_status = 'checked_in' THEN now() ELSE checked_in_at END,
+        checked_out_at = CASE WHEN sqlc.arg(status)::reservation_status = 'checked_out' THEN now() ELSE checked_out_at END,
+        cancelled_at = CASE WHEN sqlc.arg(status)::reservation_status = 'cancelled' THEN now() ELSE cancelled_at END,
+        cancelled_by = CASE WHEN sqlc.arg(status)::reservation_status = 'cancelled' THEN 'staff' ELSE cancelled_by END,
+        no_show_at = CASE WHEN sqlc.arg(status)::reservation_status = 'no_show' THEN now() ELSE no_show_at END,
+        updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
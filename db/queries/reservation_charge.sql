@@ -0,0 +1,16 @@
+-- name: CreateReservationCharge :one
+INSERT INTO reservation_charges (
+  reservation_id, charge_id, name, amount
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: DeleteReservationCharges :exec
+DELETE FROM reservation_charges
+WHERE reservation_id = $1;
+
+-- name: ListReservationCharges :many
+SELECT * FROM reservation_charges
+WHERE reservation_id = $1
+ORDER BY id;
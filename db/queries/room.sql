@@ -1,13 +1,28 @@
 -- name: CheckRoomAvailability :one
+SELECT ((
+  SELECT count(*)
+  FROM room_restrictions
+  WHERE room_id = @room_id::bigint AND (end_date > @start_date::date AND start_date < @end_date::date)
+  AND (restriction <> 'hold' OR (expires_at > now() AND hold_token <> @hold_token::text))
+) = 0 AND EXISTS (
+  SELECT 1
+  FROM rooms
+  WHERE id = @room_id::bigint AND max_adults >= @adults::integer AND max_children >= @children::integer
+  AND max_occupancy >= sqlc.arg(adults)::integer + sqlc.arg(children)::integer
+))::boolean as availabe;
+
+-- name: CheckRoomAvailabilityForReservation :one
 SELECT count(*) = 0 as availabe
 FROM room_restrictions
-WHERE room_id = $1 AND (end_date > @start_date::date AND start_date < @end_date::date);
+WHERE room_id = $1 AND (end_date > @start_date::date AND start_date < @end_date::date)
+AND (reservation_id IS NULL OR reservation_id <> @reservation_id::bigint)
+AND (restriction <> 'hold' OR expires_at > now());
 
 -- name: CreateRoom :one
 INSERT INTO rooms (
-  name, description, image_filename
+  name, description, image_filename, max_adults, max_children, max_occupancy, slug, nightly_rate
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5, $6, $7, $8
 )
 RETURNING *;
 
@@ -22,6 +37,15 @@ WHERE id = $1;
 SELECT * FROM rooms
 WHERE id = $1 LIMIT 1;
 
+-- name: GetRoomBySlug :one
+SELECT * FROM rooms
+WHERE slug = lower(sqlc.arg(slug)) LIMIT 1;
+
+-- name: GetRoomForUpdate :one
+SELECT * FROM rooms
+WHERE id = $1 LIMIT 1
+FOR UPDATE;
+
 -- name: ListAvailableRooms :many
 SELECT *
 FROM rooms
@@ -29,7 +53,12 @@ WHERE id NOT IN (
 SELECT room_id
 FROM room_restrictions
 WHERE (end_date > @start_date::date AND start_date < @end_date::date)
+AND (restriction <> 'hold' OR expires_at > now())
 )
+-- a party is split across several rooms with at least one adult in every room,
+-- so every room that can accommodate one of the adults is returned
+AND max_adults >= LEAST(@adults::integer, 1)
+AND max_occupancy >= LEAST(sqlc.arg(adults)::integer + sqlc.arg(children)::integer, 1)
 ORDER BY name
 LIMIT $1
 OFFSET $2;
@@ -45,5 +74,10 @@ UPDATE rooms
   set   name = $2,
         description = $3,
         image_filename = $4,
-        updated_at = $5
+        max_adults = $5,
+        max_children = $6,
+        max_occupancy = $7,
+        slug = $8,
+        nightly_rate = $9,
+        updated_at = $10
 WHERE id = $1;
\ No newline at end of file
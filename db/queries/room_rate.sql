@@ -0,0 +1,44 @@
+-- name: CreateRoomRate :one
+INSERT INTO room_rates (
+  room_id, name, start_date, end_date, days_of_week, rate, priority
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: DeleteAllRoomRates :exec
+DELETE FROM room_rates;
+
+-- name: DeleteRoomRates :exec
+DELETE FROM room_rates
+WHERE id = ANY(@ids::bigint[]);
+
+-- name: GetRoomRate :one
+SELECT * FROM room_rates
+WHERE id = $1 LIMIT 1;
+
+-- name: ListRoomRatesAndRooms :many
+SELECT sqlc.embed(room_rates), sqlc.embed(rooms)
+FROM room_rates
+JOIN rooms ON (room_rates.room_id = rooms.id)
+WHERE room_rates.end_date >= @date::date
+ORDER BY rooms.name, room_rates.start_date, room_rates.priority DESC, room_rates.id DESC
+LIMIT $1
+OFFSET $2;
+
+-- name: ListRoomRatesForStay :many
+SELECT * FROM room_rates
+WHERE room_id = @room_id::bigint AND (end_date >= @start_date::date AND start_date < @end_date::date)
+ORDER BY priority DESC, id DESC;
+
+-- name: UpdateRoomRate :one
+UPDATE room_rates
+  set   name = $2,
+        start_date = $3,
+        end_date = $4,
+        days_of_week = $5,
+        rate = $6,
+        priority = $7,
+        updated_at = now()
+WHERE id = $1
+RETURNING *;
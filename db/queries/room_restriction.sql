@@ -6,29 +6,114 @@ INSERT INTO room_restrictions (
 )
 RETURNING *;
 
+-- name: CreateOwnerBlock :one
+INSERT INTO room_restrictions (
+  start_date, end_date, room_id, restriction, reason
+) VALUES (
+  $1, $2, $3, 'owner_block', $4
+)
+RETURNING *;
+
+-- name: CreateRoomHold :one
+INSERT INTO room_restrictions (
+  start_date, end_date, room_id, restriction, hold_token, expires_at
+) VALUES (
+  $1, $2, $3, 'hold', $4, $5
+)
+RETURNING *;
+
 -- name: DeleteAllRoomRestrictions :exec
 DELETE FROM room_restrictions;
 
+-- name: DeleteExpiredRoomHolds :many
+DELETE FROM room_restrictions
+WHERE restriction = 'hold' AND expires_at <= now()
+RETURNING *;
+
+-- name: DeleteOwnerBlocks :many
+DELETE FROM room_restrictions
+WHERE restriction = 'owner_block' AND id = ANY(@ids::bigint[])
+RETURNING *;
+
+-- name: DeleteRoomHold :exec
+DELETE FROM room_restrictions
+WHERE restriction = 'hold' AND room_id = $1 AND hold_token = $2;
+
+-- name: DeleteRoomHoldsByToken :exec
+DELETE FROM room_restrictions
+WHERE restriction = 'hold' AND hold_token = $1;
+
 -- name: DeleteRoomRestriction :exec
 DELETE FROM room_restrictions
 WHERE id = $1;
 
+-- name: DeleteRoomRestrictionsByReservationID :exec
+DELETE FROM room_restrictions
+WHERE reservation_id = $1;
+
 -- name: GetLastRoomRestriction :one
 SELECT * FROM room_restrictions
 WHERE room_id = $1 
 ORDER BY created_at DESC
 LIMIT 1;
 
+-- name: GetOwnerBlock :one
+SELECT * FROM room_restrictions
+WHERE restriction = 'owner_block' AND id = $1 LIMIT 1;
+
 -- name: GetRoomRestriction :one
 SELECT * FROM room_restrictions
 WHERE id = $1 LIMIT 1;
 
+-- name: ListOwnerBlockConflicts :many
+SELECT * FROM room_restrictions
+WHERE restriction = 'reservation' AND room_id = ANY(@room_ids::bigint[])
+AND end_date > @start_date::date AND start_date < @end_date::date
+ORDER BY room_id, start_date;
+
+-- name: ListOwnerBlocksAndRooms :many
+SELECT sqlc.embed(room_restrictions), sqlc.embed(rooms)
+FROM room_restrictions
+JOIN rooms ON (room_restrictions.room_id = rooms.id)
+WHERE room_restrictions.restriction = 'owner_block' AND room_restrictions.end_date > @date::date
+ORDER BY room_restrictions.start_date, rooms.name
+LIMIT $1
+OFFSET $2;
+
 -- name: ListRoomRestrictions :many
 SELECT * FROM room_restrictions
 ORDER BY room_id, start_date
 LIMIT $1
 OFFSET $2;
 
+-- name: ListRoomRestrictionsForPeriod :many
+SELECT * FROM room_restrictions
+WHERE end_date > @start_date::date AND start_date < @end_date::date
+AND restriction <> 'hold'
+ORDER BY room_id, start_date;
+
+-- name: ListRoomRestrictionsByReservationID :many
+SELECT * FROM room_restrictions
+WHERE reservation_id = $1
+ORDER BY start_date;
+
+-- name: ShortenRoomRestrictionsByReservationID :exec
+UPDATE room_restrictions
+  set   end_date = sqlc.arg(end_date)::date,
+        updated_at = now()
+WHERE reservation_id = sqlc.arg(reservation_id) 
+AND start_date < sqlc.arg(end_date)::date AND end_date > sqlc.arg(end_date)::date;
+
+-- name: UpdateOwnerBlock :one
+UPDATE room_restrictions
+  set   start_date = $2,
+        end_date = $3,
+        room_id = $4,
+        reason = $5,
+        updated_at = now()
+WHERE restriction = 'owner_block' AND id = $1
+RETURNING *;
+
 -- name: UpdateRoomRestriction :exec
 UPDATE room_restrictions
   set   start_date = $2,
@@ -37,4 +122,19 @@ UPDATE room_restrictions
         reservation_id = $5, 
         restriction =  $6,
         updated_at = $7
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: UpdateRoomRestrictionsByReservationID :exec
+UPDATE room_restrictions
+  set   room_id = $2,
+        start_date = $3,
+        end_date = $4,
+        updated_at = now()
+WHERE reservation_id = $1;
+
+-- name: UpdateRoomRestrictionDatesByReservationID :exec
+UPDATE room_restrictions
+  set   start_date = $2,
+        end_date = $3,
+        updated_at = now()
+WHERE reservation_id = $1;
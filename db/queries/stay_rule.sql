@@ -0,0 +1,41 @@
+-- name: CreateStayRule :one
+INSERT INTO stay_rules (
+  room_id, start_date, end_date, days_of_week, min_nights, max_nights, closed_to_arrival, closed_to_departure
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: DeleteAllStayRules :exec
+DELETE FROM stay_rules;
+
+-- name: DeleteStayRule :exec
+DELETE FROM stay_rules
+WHERE id = $1;
+
+-- name: GetStayRule :one
+SELECT * FROM stay_rules
+WHERE id = $1 LIMIT 1;
+
+-- name: ListStayRulesForDates :many
+SELECT * FROM stay_rules
+WHERE start_date <= @end_date::date AND end_date >= @start_date::date
+ORDER BY room_id, id;
+
+-- name: ListStayRulesForStay :many
+SELECT * FROM stay_rules
+WHERE room_id = @room_id::bigint AND (start_date <= @end_date::date AND end_date >= @start_date::date)
+ORDER BY id;
+
+-- name: UpdateStayRule :one
+UPDATE stay_rules
+  set   start_date = $2,
+        end_date = $3,
+        days_of_week = $4,
+        min_nights = $5,
+        max_nights = $6,
+        closed_to_arrival = $7,
+        closed_to_departure = $8,
+        updated_at = now()
+WHERE id = $1
+RETURNING *;
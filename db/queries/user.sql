@@ -18,6 +18,10 @@ WHERE id = $1 LIMIT 1;
 SELECT * FROM users
 WHERE email = $1 LIMIT 1;
 
+-- name: GetUserByPasswordToken :one
+SELECT * FROM users
+WHERE password_token = $1 LIMIT 1;
+
 -- name: ListUsers :many
 SELECT * FROM users
 ORDER BY first_name, last_name
@@ -33,8 +37,23 @@ UPDATE users
         updated_at = $6
 WHERE id = $1;
 
+-- name: UpdateUserDeactivatedAt :exec
+UPDATE users
+  set   deactivated_at = $2,
+        updated_at = $3
+WHERE id = $1;
+
 -- name: UpdateUserPassword :exec
 UPDATE users
   set   password = $2,
+        password_token = NULL,
+        password_token_expires_at = NULL,
         updated_at = $3
+WHERE id = $1;
+
+-- name: UpdateUserPasswordToken :exec
+UPDATE users
+  set   password_token = $2,
+        password_token_expires_at = $3,
+        updated_at = $4
 WHERE id = $1;
\ No newline at end of file
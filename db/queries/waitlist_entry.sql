@@ -0,0 +1,31 @@
+-- name: CreateWaitlistEntry :one
+INSERT INTO waitlist_entries (
+  token, first_name, last_name, email, start_date, end_date, room_id, adults, children
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING *;
+
+-- name: DeleteAllWaitlistEntries :exec
+DELETE FROM waitlist_entries;
+
+-- name: GetWaitlistEntryByToken :one
+SELECT * FROM waitlist_entries
+WHERE token = $1 LIMIT 1;
+
+-- name: ListWaitlistEntriesForRoom :many
+SELECT * FROM waitlist_entries
+WHERE notified_at IS NULL
+AND (room_id IS NULL OR room_id = @room_id::bigint)
+AND (end_date > @start_date::date AND start_date < @end_date::date)
+AND start_date >= current_date
+ORDER BY created_at, id;
+
+-- name: UpdateWaitlistEntryOffer :one
+UPDATE waitlist_entries
+  set   offered_room_id = $2,
+        notified_at = now(),
+        expires_at = $3,
+        updated_at = now()
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,204 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// NightlyRate holds the rate of a single night of a stay, in cents
+type NightlyRate struct {
+	Date pgtype.Date `json:"date"`
+	Rate int64       `json:"rate"`
+}
+
+// Quote holds the price of a stay in a room, in cents.
+// Total is Subtotal, the sum of the nightly rates, less the Discount of the promo code applied,
+// plus the taxes and fees itemised in Charges.
+type Quote struct {
+	RoomID      int64         `json:"room_id"`
+	Nights      []NightlyRate `json:"nights"`
+	Subtotal    int64         `json:"subtotal"`
+	PromoCodeID pgtype.Int8   `json:"promo_code_id"`
+	Discount    int64         `json:"discount"`
+	Charges     []QuoteCharge `json:"charges"`
+	Total       int64         `json:"total"`
+}
+
+// QuoteStayParams contains the input parameters of QuoteStay.
+// PromoCode is optional. PromoCodeID is the promo code already redeemed by a reservation,
+// which is applied without checking it again, and is ignored if PromoCode is set.
+type QuoteStayParams struct {
+	RoomID      int64       `json:"room_id"`
+	StartDate   pgtype.Date `json:"start_date"`
+	EndDate     pgtype.Date `json:"end_date"`
+	Adults      int32       `json:"adults"`
+	Children    int32       `json:"children"`
+	PromoCode   string      `json:"promo_code"`
+	PromoCodeID pgtype.Int8 `json:"promo_code_id"`
+}
+
+// QuoteStay returns the per night breakdown and total price of a stay of arg.Adults and arg.Children
+// in room arg.RoomID from arg.StartDate to arg.EndDate, discounted by promo code arg.PromoCode if any.
+// Every night is priced at the room rate of the highest priority that applies to it,
+// the most recent one winning a tie, or at the nightly rate of the room if no room rate applies.
+// Percent charges apply to the discounted price of the nights, and per person charges to the guests staying in the room only.
+// It returns ErrInvalidDateRange if the stay is not at least one night long,
+// and a PromoCodeError if the promo code does not exist or does not apply to the stay.
+func (q *Queries) QuoteStay(ctx context.Context, arg QuoteStayParams) (Quote, error) {
+	if !arg.StartDate.Valid || !arg.EndDate.Valid || !arg.EndDate.Time.After(arg.StartDate.Time) {
+		return Quote{}, ErrInvalidDateRange
+	}
+
+	room, err := q.GetRoom(ctx, arg.RoomID)
+	if err != nil {
+		return Quote{}, err
+	}
+
+	// room rates are ordered by priority and then by the most recent
+	rates, err := q.ListRoomRatesForStay(ctx, ListRoomRatesForStayParams{
+		RoomID:    arg.RoomID,
+		StartDate: arg.StartDate,
+		EndDate:   arg.EndDate,
+	})
+	if err != nil {
+		return Quote{}, err
+	}
+
+	quote := Quote{
+		RoomID: room.ID,
+		Nights: []NightlyRate{},
+	}
+
+	for date := arg.StartDate.Time; date.Before(arg.EndDate.Time); date = date.AddDate(0, 0, 1) {
+		rate := room.NightlyRate
+		for _, rr := range rates {
+			if rr.AppliesOn(date) {
+				rate = rr.Rate
+				break
+			}
+		}
+
+		quote.Nights = append(quote.Nights, NightlyRate{
+			Date: pgtype.Date{Time: date, Valid: true},
+			Rate: rate,
+		})
+		quote.Subtotal += rate
+	}
+
+	// apply the promo code
+	switch {
+	case arg.PromoCode != "":
+		promo, err := q.GetPromoCodeByCode(ctx, arg.PromoCode)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Quote{}, &PromoCodeError{Code: arg.PromoCode, Rejection: RejectionNotFound}
+		} else if err != nil {
+			return Quote{}, err
+		}
+
+		err = promo.Check(room.ID, arg.StartDate.Time, arg.EndDate.Time)
+		if err != nil {
+			return Quote{}, err
+		}
+
+		quote.PromoCodeID = pgtype.Int8{Int64: promo.ID, Valid: true}
+		quote.Discount = promo.DiscountFor(quote.Subtotal)
+	case arg.PromoCodeID.Valid:
+		promo, err := q.GetPromoCode(ctx, arg.PromoCodeID.Int64)
+		if err != nil {
+			return Quote{}, err
+		}
+
+		quote.PromoCodeID = arg.PromoCodeID
+		quote.Discount = promo.DiscountFor(quote.Subtotal)
+	}
+
+	err = q.quoteCharges(ctx, &quote, arg.Adults+arg.Children)
+	if err != nil {
+		return Quote{}, err
+	}
+
+	return quote, nil
+}
+
+// QuoteStays returns the price of several stays booked together, such as all rooms of a booking,
+// each quoted by QuoteStay and discounted by the promo code of its arguments if any.
+// The promo code applies once to the booking, so a fixed discount is split across the stays
+// in proportion to their subtotal, the last stay taking the remainder of the rounding.
+// It returns the errors of QuoteStay.
+func (q *Queries) QuoteStays(ctx context.Context, args []QuoteStayParams) ([]Quote, error) {
+	quotes := make([]Quote, len(args))
+	var subtotal int64
+	for i, arg := range args {
+		var err error
+		quotes[i], err = q.QuoteStay(ctx, arg)
+		if err != nil {
+			return nil, err
+		}
+		subtotal += quotes[i].Subtotal
+	}
+
+	if len(quotes) < 2 || !quotes[0].PromoCodeID.Valid || subtotal == 0 {
+		return quotes, nil
+	}
+
+	promo, err := q.GetPromoCode(ctx, quotes[0].PromoCodeID.Int64)
+	if err != nil {
+		return nil, err
+	}
+
+	if promo.DiscountKind != DiscountKindFixed {
+		return quotes, nil
+	}
+
+	// split the discount and quote the taxes and fees of every stay again
+	discount := promo.DiscountFor(subtotal)
+	left := discount
+	for i := range quotes {
+		share := left
+		if i < len(quotes)-1 {
+			share = discount * quotes[i].Subtotal / subtotal
+		}
+		left -= share
+
+		quotes[i].Discount = share
+		err = q.quoteCharges(ctx, &quotes[i], args[i].Adults+args[i].Children)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return quotes, nil
+}
+
+// quoteCharges sets the taxes and fees of quote for the number of guests staying,
+// and its total price, the subtotal less the discount plus the taxes and fees
+func (q *Queries) quoteCharges(ctx context.Context, quote *Quote, guests int32) error {
+	quote.Total = quote.Subtotal - quote.Discount
+
+	charges, err := q.ListCharges(ctx)
+	if err != nil {
+		return err
+	}
+
+	nights := int32(len(quote.Nights))
+	price := quote.Total
+	quote.Charges = make([]QuoteCharge, 0, len(charges))
+	for _, c := range charges {
+		amount := c.AmountFor(price, nights, guests)
+		if amount == 0 {
+			continue
+		}
+
+		quote.Charges = append(quote.Charges, QuoteCharge{
+			ChargeID: c.ID,
+			Name:     c.Name,
+			Amount:   amount,
+		})
+		quote.Total += amount
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/bookings-web-app/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueries_QuoteStay(t *testing.T) {
+	room := createRandomRoom(t)
+	rDate := util.RandomDate()
+
+	t.Run("Test OK", func(t *testing.T) {
+		arg := QuoteStayParams{RoomID: room.ID}
+		arg.StartDate.Scan(rDate)
+		arg.EndDate.Scan(rDate.Add(time.Hour * 24 * 3))
+
+		quote, err := testStore.QuoteStay(context.Background(), arg)
+		require.NoError(t, err)
+		assert.Equal(t, room.ID, quote.RoomID)
+		require.Len(t, quote.Nights, 3)
+		for i, night := range quote.Nights {
+			assert.WithinDuration(t, rDate.AddDate(0, 0, i), night.Date.Time, time.Second)
+			assert.Equal(t, room.NightlyRate, night.Rate)
+		}
+		assert.Equal(t, 3*room.NightlyRate, quote.Total)
+	})
+
+	t.Run("Test Room Rates", func(t *testing.T) {
+		// 2024-06-07 is a Friday
+		room := createRandomRoom(t)
+		friday := time.Date(2024, 6, 7, 0, 0, 0, 0, time.UTC)
+
+		// a season rate for every night, overridden by a weekend rate of a higher priority
+		season := createRandomRoomRate(t, room, friday.AddDate(0, 0, -30), friday.AddDate(0, 0, 30), AllDaysOfWeek, 0)
+		weekend := createRandomRoomRate(t, room, friday.AddDate(0, 0, -30), friday.AddDate(0, 0, 30),
+			DaysOfWeekMask(time.Friday, time.Saturday), 1)
+
+		// a newer season rate of the same priority replaces the older one
+		newer := createRandomRoomRate(t, room, friday.AddDate(0, 0, 2), friday.AddDate(0, 0, 2), AllDaysOfWeek, 0)
+
+		arg := QuoteStayParams{RoomID: room.ID}
+		arg.StartDate.Scan(friday)
+		arg.EndDate.Scan(friday.AddDate(0, 0, 4))
+
+		quote, err := testStore.QuoteStay(context.Background(), arg)
+		require.NoError(t, err)
+		require.Len(t, quote.Nights, 4)
+		assert.Equal(t, weekend.Rate, quote.Nights[0].Rate)
+		assert.Equal(t, weekend.Rate, quote.Nights[1].Rate)
+		assert.Equal(t, newer.Rate, quote.Nights[2].Rate)
+		assert.Equal(t, season.Rate, quote.Nights[3].Rate)
+		assert.Equal(t, 2*weekend.Rate+newer.Rate+season.Rate, quote.Total)
+	})
+
+	t.Run("Test Promo Code", func(t *testing.T) {
+		promo := createRandomPromoCode(t, rDate, rDate, 0)
+
+		arg := QuoteStayParams{RoomID: room.ID, PromoCode: strings.ToLower(promo.Code)}
+		arg.StartDate.Scan(rDate)
+		arg.EndDate.Scan(rDate.Add(time.Hour * 24 * 3))
+
+		quote, err := testStore.QuoteStay(context.Background(), arg)
+		require.NoError(t, err)
+		assert.Equal(t, 3*room.NightlyRate, quote.Subtotal)
+		assert.Equal(t, promo.ID, quote.PromoCodeID.Int64)
+		assert.Equal(t, promo.DiscountFor(quote.Subtotal), quote.Discount)
+		assert.Equal(t, quote.Subtotal-quote.Discount, quote.Total)
+
+		// the promo code is not valid for arrivals on the next day
+		arg.StartDate.Scan(rDate.Add(time.Hour * 24))
+		_, err = testStore.QuoteStay(context.Background(), arg)
+		require.ErrorIs(t, err, ErrPromoCodeRejected)
+
+		// an unknown promo code
+		arg.PromoCode = util.RandomString(12)
+		_, err = testStore.QuoteStay(context.Background(), arg)
+		var promoErr *PromoCodeError
+		require.ErrorAs(t, err, &promoErr)
+		assert.Equal(t, RejectionNotFound, promoErr.Rejection)
+	})
+
+	t.Run("Test Charges", func(t *testing.T) {
+		promo := createRandomPromoCode(t, rDate, rDate, 0)
+		vat := createRandomCharge(t, ChargeKindPercent, 1700)
+		cityTax := createRandomCharge(t, ChargeKindPerPersonNight, 250)
+		cleaning := createRandomCharge(t, ChargeKindPerStay, 4000)
+
+		arg := QuoteStayParams{RoomID: room.ID, Adults: 2, Children: 1, PromoCode: promo.Code}
+		arg.StartDate.Scan(rDate)
+		arg.EndDate.Scan(rDate.Add(time.Hour * 24 * 3))
+
+		quote, err := testStore.QuoteStay(context.Background(), arg)
+		require.NoError(t, err)
+
+		// percent charges apply to the discounted price of the nights
+		price := quote.Subtotal - quote.Discount
+		assert.Equal(t, []QuoteCharge{
+			{ChargeID: vat.ID, Name: vat.Name, Amount: vat.AmountFor(price, 3, 3)},
+			{ChargeID: cityTax.ID, Name: cityTax.Name, Amount: 3 * 3 * 250},
+			{ChargeID: cleaning.ID, Name: cleaning.Name, Amount: 4000},
+		}, quote.Charges)
+		assert.Equal(t, price+quote.Charges[0].Amount+3*3*250+4000, quote.Total)
+	})
+
+	t.Run("Test Invalid Date Range", func(t *testing.T) {
+		arg := QuoteStayParams{RoomID: room.ID}
+		arg.StartDate.Scan(rDate)
+		arg.EndDate.Scan(rDate)
+
+		_, err := testStore.QuoteStay(context.Background(), arg)
+		require.ErrorIs(t, err, ErrInvalidDateRange)
+	})
+
+	t.Run("Test Room Not Found", func(t *testing.T) {
+		arg := QuoteStayParams{RoomID: -1}
+		arg.StartDate.Scan(rDate)
+		arg.EndDate.Scan(rDate.Add(time.Hour * 24))
+
+		_, err := testStore.QuoteStay(context.Background(), arg)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+}
+
+func TestQueries_QuoteStays(t *testing.T) {
+	rooms := []Room{createRandomRoom(t), createRandomRoom(t)}
+	rDate := util.RandomDate()
+
+	// newArgs returns arguments of stays in rooms discounted by promoCode
+	newArgs := func(promoCode string) []QuoteStayParams {
+		args := make([]QuoteStayParams, len(rooms))
+		for i, room := range rooms {
+			args[i] = QuoteStayParams{RoomID: room.ID, Adults: 1, PromoCode: promoCode}
+			args[i].StartDate.Scan(rDate)
+			args[i].EndDate.Scan(rDate.Add(time.Hour * 24 * 3))
+		}
+		return args
+	}
+
+	t.Run("Test OK", func(t *testing.T) {
+		quotes, err := testStore.QuoteStays(context.Background(), newArgs(""))
+		require.NoError(t, err)
+		require.Len(t, quotes, len(rooms))
+		for i, quote := range quotes {
+			assert.Equal(t, rooms[i].ID, quote.RoomID)
+			assert.Equal(t, 3*rooms[i].NightlyRate, quote.Total)
+		}
+	})
+
+	t.Run("Test Percent Promo Code", func(t *testing.T) {
+		promo := createRandomPromoCode(t, rDate, rDate, 0)
+
+		quotes, err := testStore.QuoteStays(context.Background(), newArgs(promo.Code))
+		require.NoError(t, err)
+		require.Len(t, quotes, len(rooms))
+		for _, quote := range quotes {
+			assert.Equal(t, promo.DiscountFor(quote.Subtotal), quote.Discount)
+		}
+	})
+
+	t.Run("Test Fixed Promo Code", func(t *testing.T) {
+		arg := CreatePromoCodeParams{
+			Code:          util.RandomString(10),
+			DiscountKind:  DiscountKindFixed,
+			DiscountValue: 5001,
+			RoomIds:       []int64{},
+		}
+		arg.ValidFrom.Scan(rDate)
+		arg.ValidTo.Scan(rDate)
+		promo, err := testStore.CreatePromoCode(context.Background(), arg)
+		require.NoError(t, err)
+
+		quotes, err := testStore.QuoteStays(context.Background(), newArgs(promo.Code))
+		require.NoError(t, err)
+		require.Len(t, quotes, len(rooms))
+
+		// the discount is split in proportion to the subtotal of the stays
+		subtotal := quotes[0].Subtotal + quotes[1].Subtotal
+		assert.Equal(t, promo.DiscountValue*quotes[0].Subtotal/subtotal, quotes[0].Discount)
+		assert.Equal(t, promo.DiscountValue, quotes[0].Discount+quotes[1].Discount)
+		for _, quote := range quotes {
+			assert.Equal(t, quote.Subtotal-quote.Discount, quote.Total)
+		}
+	})
+}
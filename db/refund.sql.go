@@ -0,0 +1,88 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: refund.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createRefund = `-- name: CreateRefund :one
+INSERT INTO refunds (
+  reservation_id, paid, policy_amount, amount, reason, user_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+RETURNING id, reservation_id, paid, policy_amount, amount, reason, user_id, created_at
+`
+
+type CreateRefundParams struct {
+	ReservationID int64       `json:"reservation_id"`
+	Paid          int64       `json:"paid"`
+	PolicyAmount  int64       `json:"policy_amount"`
+	Amount        int64       `json:"amount"`
+	Reason        string      `json:"reason"`
+	UserID        pgtype.Int8 `json:"user_id"`
+}
+
+func (q *Queries) CreateRefund(ctx context.Context, arg CreateRefundParams) (Refund, error) {
+	row := q.db.QueryRow(ctx, createRefund,
+		arg.ReservationID,
+		arg.Paid,
+		arg.PolicyAmount,
+		arg.Amount,
+		arg.Reason,
+		arg.UserID,
+	)
+	var i Refund
+	err := row.Scan(
+		&i.ID,
+		&i.ReservationID,
+		&i.Paid,
+		&i.PolicyAmount,
+		&i.Amount,
+		&i.Reason,
+		&i.UserID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listRefundsByReservation = `-- name: ListRefundsByReservation :many
+SELECT id, reservation_id, paid, policy_amount, amount, reason, user_id, created_at FROM refunds
+WHERE reservation_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListRefundsByReservation(ctx context.Context, reservationID int64) ([]Refund, error) {
+	rows, err := q.db.Query(ctx, listRefundsByReservation, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Refund{}
+	for rows.Next() {
+		var i Refund
+		if err := rows.Scan(
+			&i.ID,
+			&i.ReservationID,
+			&i.Paid,
+			&i.PolicyAmount,
+			&i.Amount,
+			&i.Reason,
+			&i.UserID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/bookings-web-app/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomRefund(t *testing.T, r Reservation, user User) Refund {
+	arg := CreateRefundParams{
+		ReservationID: r.ID,
+		Paid:          10000,
+		PolicyAmount:  5000,
+		Amount:        10000,
+		Reason:        util.RandomNote(),
+		UserID:        pgtype.Int8{Int64: user.ID, Valid: true},
+	}
+
+	refund, err := testStore.CreateRefund(context.Background(), arg)
+	require.NoError(t, err)
+	assert.NotEmpty(t, refund.ID)
+	assert.Equal(t, arg.ReservationID, refund.ReservationID)
+	assert.Equal(t, arg.Paid, refund.Paid)
+	assert.Equal(t, arg.PolicyAmount, refund.PolicyAmount)
+	assert.Equal(t, arg.Amount, refund.Amount)
+	assert.Equal(t, arg.Reason, refund.Reason)
+	assert.Equal(t, arg.UserID, refund.UserID)
+	assert.WithinDuration(t, time.Now(), refund.CreatedAt.Time, time.Second)
+
+	return refund
+}
+
+func TestQueries_CreateRefund(t *testing.T) {
+	r := createRandomReservation(t, createRandomRoom(t))
+	createRandomRefund(t, r, createRandomUser(t, util.RandomPassword()))
+
+	t.Run("Policy Amount", func(t *testing.T) {
+		// refunds of the policy amount need no reason
+		_, err := testStore.CreateRefund(context.Background(), CreateRefundParams{
+			ReservationID: r.ID,
+			Paid:          10000,
+			PolicyAmount:  5000,
+			Amount:        5000,
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("Override Without Reason", func(t *testing.T) {
+		_, err := testStore.CreateRefund(context.Background(), CreateRefundParams{
+			ReservationID: r.ID,
+			Paid:          10000,
+			PolicyAmount:  5000,
+			Amount:        8000,
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("Amount Exceeds Paid", func(t *testing.T) {
+		_, err := testStore.CreateRefund(context.Background(), CreateRefundParams{
+			ReservationID: r.ID,
+			Paid:          10000,
+			PolicyAmount:  10001,
+			Amount:        10001,
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestQueries_ListRefundsByReservation(t *testing.T) {
+	r := createRandomReservation(t, createRandomRoom(t))
+	user := createRandomUser(t, util.RandomPassword())
+	refund1 := createRandomRefund(t, r, user)
+	refund2 := createRandomRefund(t, r, user)
+
+	refunds, err := testStore.ListRefundsByReservation(context.Background(), r.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []Refund{refund1, refund2}, refunds)
+}
@@ -11,25 +11,95 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const cancelReservation = `-- name: CancelReservation :one
+UPDATE reservations
+  set   cancelled_at = now(),
+        cancelled_by = $2,
+        cancellation_fee_percent = $3,
+        status = 'cancelled',
+        updated_at = now()
+WHERE id = $1 AND status IN ('pending', 'confirmed')
+RETURNING id, code, first_name, last_name, email, phone, start_date, end_date, room_id, notes, created_at, updated_at, cancelled_at, cancelled_by, cancellation_fee_percent, parent_code, adults, children, status, confirmed_at, checked_in_at, checked_out_at, no_show_at, total_price, promo_code_id, discount
+`
+
+type CancelReservationParams struct {
+	ID                     int64       `json:"id"`
+	CancelledBy            pgtype.Text `json:"cancelled_by"`
+	CancellationFeePercent int32       `json:"cancellation_fee_percent"`
+}
+
+func (q *Queries) CancelReservation(ctx context.Context, arg CancelReservationParams) (Reservation, error) {
+	row := q.db.QueryRow(ctx, cancelReservation, arg.ID, arg.CancelledBy, arg.CancellationFeePercent)
+	var i Reservation
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.Phone,
+		&i.StartDate,
+		&i.EndDate,
+		&i.RoomID,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CancelledAt,
+		&i.CancelledBy,
+		&i.CancellationFeePercent,
+		&i.ParentCode,
+		&i.Adults,
+		&i.Children,
+		&i.Status,
+		&i.ConfirmedAt,
+		&i.CheckedInAt,
+		&i.CheckedOutAt,
+		&i.NoShowAt,
+		&i.TotalPrice,
+		&i.PromoCodeID,
+		&i.Discount,
+	)
+	return i, err
+}
+
+const countReservationsByRoom = `-- name: CountReservationsByRoom :one
+SELECT count(*) FROM reservations
+WHERE room_id = $1
+`
+
+func (q *Queries) CountReservationsByRoom(ctx context.Context, roomID int64) (int64, error) {
+	row := q.db.QueryRow(ctx, countReservationsByRoom, roomID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createReservation = `-- name: CreateReservation :one
 INSERT INTO reservations (
-  code, first_name, last_name, email, phone, start_date, end_date, room_id, notes
+  code, first_name, last_name, email, phone, start_date, end_date, room_id, notes, parent_code, adults, children, total_price,
+  promo_code_id, discount
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8, $9
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
 )
-RETURNING id, code, first_name, last_name, email, phone, start_date, end_date, room_id, notes, created_at, updated_at
+RETURNING id, code, first_name, last_name, email, phone, start_date, end_date, room_id, notes, created_at, updated_at, cancelled_at, cancelled_by, cancellation_fee_percent, parent_code, adults, children, status, confirmed_at, checked_in_at, checked_out_at, no_show_at, total_price, promo_code_id, discount
 `
 
 type CreateReservationParams struct {
-	Code      string      `json:"code"`
-	FirstName string      `json:"first_name"`
-	LastName  string      `json:"last_name"`
-	Email     string      `json:"email"`
-	Phone     pgtype.Text `json:"phone"`
-	StartDate pgtype.Date `json:"start_date"`
-	EndDate   pgtype.Date `json:"end_date"`
-	RoomID    int64       `json:"room_id"`
-	Notes     pgtype.Text `json:"notes"`
+	Code        string      `json:"code"`
+	FirstName   string      `json:"first_name"`
+	LastName    string      `json:"last_name"`
+	Email       string      `json:"email"`
+	Phone       pgtype.Text `json:"phone"`
+	StartDate   pgtype.Date `json:"start_date"`
+	EndDate     pgtype.Date `json:"end_date"`
+	RoomID      int64       `json:"room_id"`
+	Notes       pgtype.Text `json:"notes"`
+	ParentCode  pgtype.Text `json:"parent_code"`
+	Adults      int32       `json:"adults"`
+	Children    int32       `json:"children"`
+	TotalPrice  int64       `json:"total_price"`
+	PromoCodeID pgtype.Int8 `json:"promo_code_id"`
+	Discount    int64       `json:"discount"`
 }
 
 func (q *Queries) CreateReservation(ctx context.Context, arg CreateReservationParams) (Reservation, error) {
@@ -43,6 +113,12 @@ func (q *Queries) CreateReservation(ctx context.Context, arg CreateReservationPa
 		arg.EndDate,
 		arg.RoomID,
 		arg.Notes,
+		arg.ParentCode,
+		arg.Adults,
+		arg.Children,
+		arg.TotalPrice,
+		arg.PromoCodeID,
+		arg.Discount,
 	)
 	var i Reservation
 	err := row.Scan(
@@ -58,6 +134,20 @@ func (q *Queries) CreateReservation(ctx context.Context, arg CreateReservationPa
 		&i.Notes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CancelledAt,
+		&i.CancelledBy,
+		&i.CancellationFeePercent,
+		&i.ParentCode,
+		&i.Adults,
+		&i.Children,
+		&i.Status,
+		&i.ConfirmedAt,
+		&i.CheckedInAt,
+		&i.CheckedOutAt,
+		&i.NoShowAt,
+		&i.TotalPrice,
+		&i.PromoCodeID,
+		&i.Discount,
 	)
 	return i, err
 }
@@ -82,7 +172,7 @@ func (q *Queries) DeleteReservation(ctx context.Context, id int64) error {
 }
 
 const getReservation = `-- name: GetReservation :one
-SELECT id, code, first_name, last_name, email, phone, start_date, end_date, room_id, notes, created_at, updated_at FROM reservations
+SELECT id, code, first_name, last_name, email, phone, start_date, end_date, room_id, notes, created_at, updated_at, cancelled_at, cancelled_by, cancellation_fee_percent, parent_code, adults, children, status, confirmed_at, checked_in_at, checked_out_at, no_show_at, total_price, promo_code_id, discount FROM reservations
 WHERE id = $1 LIMIT 1
 `
 
@@ -102,13 +192,84 @@ func (q *Queries) GetReservation(ctx context.Context, id int64) (Reservation, er
 		&i.Notes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CancelledAt,
+		&i.CancelledBy,
+		&i.CancellationFeePercent,
+		&i.ParentCode,
+		&i.Adults,
+		&i.Children,
+		&i.Status,
+		&i.ConfirmedAt,
+		&i.CheckedInAt,
+		&i.CheckedOutAt,
+		&i.NoShowAt,
+		&i.TotalPrice,
+		&i.PromoCodeID,
+		&i.Discount,
+	)
+	return i, err
+}
+
+const getReservationAndRoom = `-- name: GetReservationAndRoom :one
+SELECT reservations.id, reservations.code, reservations.first_name, reservations.last_name, reservations.email, reservations.phone, reservations.start_date, reservations.end_date, reservations.room_id, reservations.notes, reservations.created_at, reservations.updated_at, reservations.cancelled_at, reservations.cancelled_by, reservations.cancellation_fee_percent, reservations.parent_code, reservations.adults, reservations.children, reservations.status, reservations.confirmed_at, reservations.checked_in_at, reservations.checked_out_at, reservations.no_show_at, reservations.total_price, reservations.promo_code_id, reservations.discount, rooms.id, rooms.name, rooms.description, rooms.image_filename, rooms.created_at, rooms.updated_at, rooms.max_adults, rooms.max_children, rooms.max_occupancy, rooms.slug, rooms.nightly_rate 
+FROM reservations
+LEFT JOIN rooms ON (reservations.room_id = rooms.id)
+WHERE reservations.id = $1 LIMIT 1
+`
+
+type GetReservationAndRoomRow struct {
+	Reservation Reservation `json:"reservation"`
+	Room        Room        `json:"room"`
+}
+
+func (q *Queries) GetReservationAndRoom(ctx context.Context, id int64) (GetReservationAndRoomRow, error) {
+	row := q.db.QueryRow(ctx, getReservationAndRoom, id)
+	var i GetReservationAndRoomRow
+	err := row.Scan(
+		&i.Reservation.ID,
+		&i.Reservation.Code,
+		&i.Reservation.FirstName,
+		&i.Reservation.LastName,
+		&i.Reservation.Email,
+		&i.Reservation.Phone,
+		&i.Reservation.StartDate,
+		&i.Reservation.EndDate,
+		&i.Reservation.RoomID,
+		&i.Reservation.Notes,
+		&i.Reservation.CreatedAt,
+		&i.Reservation.UpdatedAt,
+		&i.Reservation.CancelledAt,
+		&i.Reservation.CancelledBy,
+		&i.Reservation.CancellationFeePercent,
+		&i.Reservation.ParentCode,
+		&i.Reservation.Adults,
+		&i.Reservation.Children,
+		&i.Reservation.Status,
+		&i.Reservation.ConfirmedAt,
+		&i.Reservation.CheckedInAt,
+		&i.Reservation.CheckedOutAt,
+		&i.Reservation.NoShowAt,
+		&i.Reservation.TotalPrice,
+		&i.Reservation.PromoCodeID,
+		&i.Reservation.Discount,
+		&i.Room.ID,
+		&i.Room.Name,
+		&i.Room.Description,
+		&i.Room.ImageFilename,
+		&i.Room.CreatedAt,
+		&i.Room.UpdatedAt,
+		&i.Room.MaxAdults,
+		&i.Room.MaxChildren,
+		&i.Room.MaxOccupancy,
+		&i.Room.Slug,
+		&i.Room.NightlyRate,
 	)
 	return i, err
 }
 
 const getReservationByLastName = `-- name: GetReservationByLastName :one
-SELECT id, code, first_name, last_name, email, phone, start_date, end_date, room_id, notes, created_at, updated_at FROM reservations
-WHERE code = $1 AND last_name = $2 LIMIT 1
+SELECT id, code, first_name, last_name, email, phone, start_date, end_date, room_id, notes, created_at, updated_at, cancelled_at, cancelled_by, cancellation_fee_percent, parent_code, adults, children, status, confirmed_at, checked_in_at, checked_out_at, no_show_at, total_price, promo_code_id, discount FROM reservations
+WHERE code = $1 AND lower(last_name) = lower($2) LIMIT 1
 `
 
 type GetReservationByLastNameParams struct {
@@ -132,12 +293,210 @@ func (q *Queries) GetReservationByLastName(ctx context.Context, arg GetReservati
 		&i.Notes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CancelledAt,
+		&i.CancelledBy,
+		&i.CancellationFeePercent,
+		&i.ParentCode,
+		&i.Adults,
+		&i.Children,
+		&i.Status,
+		&i.ConfirmedAt,
+		&i.CheckedInAt,
+		&i.CheckedOutAt,
+		&i.NoShowAt,
+		&i.TotalPrice,
+		&i.PromoCodeID,
+		&i.Discount,
 	)
 	return i, err
 }
 
+const getReservationForUpdate = `-- name: GetReservationForUpdate :one
+SELECT id, code, first_name, last_name, email, phone, start_date, end_date, room_id, notes, created_at, updated_at, cancelled_at, cancelled_by, cancellation_fee_percent, parent_code, adults, children, status, confirmed_at, checked_in_at, checked_out_at, no_show_at, total_price, promo_code_id, discount FROM reservations
+WHERE id = $1 LIMIT 1
+FOR UPDATE
+`
+
+func (q *Queries) GetReservationForUpdate(ctx context.Context, id int64) (Reservation, error) {
+	row := q.db.QueryRow(ctx, getReservationForUpdate, id)
+	var i Reservation
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.Phone,
+		&i.StartDate,
+		&i.EndDate,
+		&i.RoomID,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CancelledAt,
+		&i.CancelledBy,
+		&i.CancellationFeePercent,
+		&i.ParentCode,
+		&i.Adults,
+		&i.Children,
+		&i.Status,
+		&i.ConfirmedAt,
+		&i.CheckedInAt,
+		&i.CheckedOutAt,
+		&i.NoShowAt,
+		&i.TotalPrice,
+		&i.PromoCodeID,
+		&i.Discount,
+	)
+	return i, err
+}
+
+const listArrivalsAndRooms = `-- name: ListArrivalsAndRooms :many
+SELECT reservations.id, reservations.code, reservations.first_name, reservations.last_name, reservations.email, reservations.phone, reservations.start_date, reservations.end_date, reservations.room_id, reservations.notes, reservations.created_at, reservations.updated_at, reservations.cancelled_at, reservations.cancelled_by, reservations.cancellation_fee_percent, reservations.parent_code, reservations.adults, reservations.children, reservations.status, reservations.confirmed_at, reservations.checked_in_at, reservations.checked_out_at, reservations.no_show_at, reservations.total_price, reservations.promo_code_id, reservations.discount, rooms.id, rooms.name, rooms.description, rooms.image_filename, rooms.created_at, rooms.updated_at, rooms.max_adults, rooms.max_children, rooms.max_occupancy, rooms.slug, rooms.nightly_rate 
+FROM reservations
+LEFT JOIN rooms ON (reservations.room_id = rooms.id)
+WHERE reservations.start_date = $1
+AND reservations.status IN ('pending', 'confirmed', 'checked_in')
+ORDER BY rooms.name ASC
+`
+
+type ListArrivalsAndRoomsRow struct {
+	Reservation Reservation `json:"reservation"`
+	Room        Room        `json:"room"`
+}
+
+func (q *Queries) ListArrivalsAndRooms(ctx context.Context, startDate pgtype.Date) ([]ListArrivalsAndRoomsRow, error) {
+	rows, err := q.db.Query(ctx, listArrivalsAndRooms, startDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListArrivalsAndRoomsRow{}
+	for rows.Next() {
+		var i ListArrivalsAndRoomsRow
+		if err := rows.Scan(
+			&i.Reservation.ID,
+			&i.Reservation.Code,
+			&i.Reservation.FirstName,
+			&i.Reservation.LastName,
+			&i.Reservation.Email,
+			&i.Reservation.Phone,
+			&i.Reservation.StartDate,
+			&i.Reservation.EndDate,
+			&i.Reservation.RoomID,
+			&i.Reservation.Notes,
+			&i.Reservation.CreatedAt,
+			&i.Reservation.UpdatedAt,
+			&i.Reservation.CancelledAt,
+			&i.Reservation.CancelledBy,
+			&i.Reservation.CancellationFeePercent,
+			&i.Reservation.ParentCode,
+			&i.Reservation.Adults,
+			&i.Reservation.Children,
+			&i.Reservation.Status,
+			&i.Reservation.ConfirmedAt,
+			&i.Reservation.CheckedInAt,
+			&i.Reservation.CheckedOutAt,
+			&i.Reservation.NoShowAt,
+			&i.Reservation.TotalPrice,
+			&i.Reservation.PromoCodeID,
+			&i.Reservation.Discount,
+			&i.Room.ID,
+			&i.Room.Name,
+			&i.Room.Description,
+			&i.Room.ImageFilename,
+			&i.Room.CreatedAt,
+			&i.Room.UpdatedAt,
+			&i.Room.MaxAdults,
+			&i.Room.MaxChildren,
+			&i.Room.MaxOccupancy,
+			&i.Room.Slug,
+			&i.Room.NightlyRate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDeparturesAndRooms = `-- name: ListDeparturesAndRooms :many
+SELECT reservations.id, reservations.code, reservations.first_name, reservations.last_name, reservations.email, reservations.phone, reservations.start_date, reservations.end_date, reservations.room_id, reservations.notes, reservations.created_at, reservations.updated_at, reservations.cancelled_at, reservations.cancelled_by, reservations.cancellation_fee_percent, reservations.parent_code, reservations.adults, reservations.children, reservations.status, reservations.confirmed_at, reservations.checked_in_at, reservations.checked_out_at, reservations.no_show_at, reservations.total_price, reservations.promo_code_id, reservations.discount, rooms.id, rooms.name, rooms.description, rooms.image_filename, rooms.created_at, rooms.updated_at, rooms.max_adults, rooms.max_children, rooms.max_occupancy, rooms.slug, rooms.nightly_rate 
+FROM reservations
+LEFT JOIN rooms ON (reservations.room_id = rooms.id)
+WHERE (reservations.status = 'checked_in' AND reservations.end_date <= $1::date)
+OR (reservations.status = 'checked_out' AND reservations.checked_out_at::date = $1::date)
+ORDER BY reservations.end_date, rooms.name ASC
+`
+
+type ListDeparturesAndRoomsRow struct {
+	Reservation Reservation `json:"reservation"`
+	Room        Room        `json:"room"`
+}
+
+func (q *Queries) ListDeparturesAndRooms(ctx context.Context, date pgtype.Date) ([]ListDeparturesAndRoomsRow, error) {
+	rows, err := q.db.Query(ctx, listDeparturesAndRooms, date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListDeparturesAndRoomsRow{}
+	for rows.Next() {
+		var i ListDeparturesAndRoomsRow
+		if err := rows.Scan(
+			&i.Reservation.ID,
+			&i.Reservation.Code,
+			&i.Reservation.FirstName,
+			&i.Reservation.LastName,
+			&i.Reservation.Email,
+			&i.Reservation.Phone,
+			&i.Reservation.StartDate,
+			&i.Reservation.EndDate,
+			&i.Reservation.RoomID,
+			&i.Reservation.Notes,
+			&i.Reservation.CreatedAt,
+			&i.Reservation.UpdatedAt,
+			&i.Reservation.CancelledAt,
+			&i.Reservation.CancelledBy,
+			&i.Reservation.CancellationFeePercent,
+			&i.Reservation.ParentCode,
+			&i.Reservation.Adults,
+			&i.Reservation.Children,
+			&i.Reservation.Status,
+			&i.Reservation.ConfirmedAt,
+			&i.Reservation.CheckedInAt,
+			&i.Reservation.CheckedOutAt,
+			&i.Reservation.NoShowAt,
+			&i.Reservation.TotalPrice,
+			&i.Reservation.PromoCodeID,
+			&i.Reservation.Discount,
+			&i.Room.ID,
+			&i.Room.Name,
+			&i.Room.Description,
+			&i.Room.ImageFilename,
+			&i.Room.CreatedAt,
+			&i.Room.UpdatedAt,
+			&i.Room.MaxAdults,
+			&i.Room.MaxChildren,
+			&i.Room.MaxOccupancy,
+			&i.Room.Slug,
+			&i.Room.NightlyRate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listReservations = `-- name: ListReservations :many
-SELECT id, code, first_name, last_name, email, phone, start_date, end_date, room_id, notes, created_at, updated_at FROM reservations 
+SELECT id, code, first_name, last_name, email, phone, start_date, end_date, room_id, notes, created_at, updated_at, cancelled_at, cancelled_by, cancellation_fee_percent, parent_code, adults, children, status, confirmed_at, checked_in_at, checked_out_at, no_show_at, total_price, promo_code_id, discount FROM reservations 
 ORDER BY start_date, end_date ASC
 LIMIT $1
 OFFSET $2
@@ -170,6 +529,20 @@ func (q *Queries) ListReservations(ctx context.Context, arg ListReservationsPara
 			&i.Notes,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.CancelledAt,
+			&i.CancelledBy,
+			&i.CancellationFeePercent,
+			&i.ParentCode,
+			&i.Adults,
+			&i.Children,
+			&i.Status,
+			&i.ConfirmedAt,
+			&i.CheckedInAt,
+			&i.CheckedOutAt,
+			&i.NoShowAt,
+			&i.TotalPrice,
+			&i.PromoCodeID,
+			&i.Discount,
 		); err != nil {
 			return nil, err
 		}
@@ -182,7 +555,7 @@ func (q *Queries) ListReservations(ctx context.Context, arg ListReservationsPara
 }
 
 const listReservationsAndRooms = `-- name: ListReservationsAndRooms :many
-SELECT reservations.id, reservations.code, reservations.first_name, reservations.last_name, reservations.email, reservations.phone, reservations.start_date, reservations.end_date, reservations.room_id, reservations.notes, reservations.created_at, reservations.updated_at, rooms.id, rooms.name, rooms.description, rooms.image_filename, rooms.created_at, rooms.updated_at 
+SELECT reservations.id, reservations.code, reservations.first_name, reservations.last_name, reservations.email, reservations.phone, reservations.start_date, reservations.end_date, reservations.room_id, reservations.notes, reservations.created_at, reservations.updated_at, reservations.cancelled_at, reservations.cancelled_by, reservations.cancellation_fee_percent, reservations.parent_code, reservations.adults, reservations.children, reservations.status, reservations.confirmed_at, reservations.checked_in_at, reservations.checked_out_at, reservations.no_show_at, reservations.total_price, reservations.promo_code_id, reservations.discount, rooms.id, rooms.name, rooms.description, rooms.image_filename, rooms.created_at, rooms.updated_at, rooms.max_adults, rooms.max_children, rooms.max_occupancy, rooms.slug, rooms.nightly_rate 
 FROM reservations
 LEFT JOIN rooms ON (reservations.room_id = rooms.id)
 ORDER BY reservations.start_date, rooms.name ASC
@@ -222,12 +595,110 @@ func (q *Queries) ListReservationsAndRooms(ctx context.Context, arg ListReservat
 			&i.Reservation.Notes,
 			&i.Reservation.CreatedAt,
 			&i.Reservation.UpdatedAt,
+			&i.Reservation.CancelledAt,
+			&i.Reservation.CancelledBy,
+			&i.Reservation.CancellationFeePercent,
+			&i.Reservation.ParentCode,
+			&i.Reservation.Adults,
+			&i.Reservation.Children,
+			&i.Reservation.Status,
+			&i.Reservation.ConfirmedAt,
+			&i.Reservation.CheckedInAt,
+			&i.Reservation.CheckedOutAt,
+			&i.Reservation.NoShowAt,
+			&i.Reservation.TotalPrice,
+			&i.Reservation.PromoCodeID,
+			&i.Reservation.Discount,
+			&i.Room.ID,
+			&i.Room.Name,
+			&i.Room.Description,
+			&i.Room.ImageFilename,
+			&i.Room.CreatedAt,
+			&i.Room.UpdatedAt,
+			&i.Room.MaxAdults,
+			&i.Room.MaxChildren,
+			&i.Room.MaxOccupancy,
+			&i.Room.Slug,
+			&i.Room.NightlyRate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReservationsAndRoomsByStatus = `-- name: ListReservationsAndRoomsByStatus :many
+SELECT reservations.id, reservations.code, reservations.first_name, reservations.last_name, reservations.email, reservations.phone, reservations.start_date, reservations.end_date, reservations.room_id, reservations.notes, reservations.created_at, reservations.updated_at, reservations.cancelled_at, reservations.cancelled_by, reservations.cancellation_fee_percent, reservations.parent_code, reservations.adults, reservations.children, reservations.status, reservations.confirmed_at, reservations.checked_in_at, reservations.checked_out_at, reservations.no_show_at, reservations.total_price, reservations.promo_code_id, reservations.discount, rooms.id, rooms.name, rooms.description, rooms.image_filename, rooms.created_at, rooms.updated_at, rooms.max_adults, rooms.max_children, rooms.max_occupancy, rooms.slug, rooms.nightly_rate 
+FROM reservations
+LEFT JOIN rooms ON (reservations.room_id = rooms.id)
+WHERE reservations.status = $1
+ORDER BY reservations.start_date, rooms.name ASC
+LIMIT $2
+OFFSET $3
+`
+
+type ListReservationsAndRoomsByStatusParams struct {
+	Status ReservationStatus `json:"status"`
+	Limit  int32             `json:"limit"`
+	Offset int32             `json:"offset"`
+}
+
+type ListReservationsAndRoomsByStatusRow struct {
+	Reservation Reservation `json:"reservation"`
+	Room        Room        `json:"room"`
+}
+
+func (q *Queries) ListReservationsAndRoomsByStatus(ctx context.Context, arg ListReservationsAndRoomsByStatusParams) ([]ListReservationsAndRoomsByStatusRow, error) {
+	rows, err := q.db.Query(ctx, listReservationsAndRoomsByStatus, arg.Status, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListReservationsAndRoomsByStatusRow{}
+	for rows.Next() {
+		var i ListReservationsAndRoomsByStatusRow
+		if err := rows.Scan(
+			&i.Reservation.ID,
+			&i.Reservation.Code,
+			&i.Reservation.FirstName,
+			&i.Reservation.LastName,
+			&i.Reservation.Email,
+			&i.Reservation.Phone,
+			&i.Reservation.StartDate,
+			&i.Reservation.EndDate,
+			&i.Reservation.RoomID,
+			&i.Reservation.Notes,
+			&i.Reservation.CreatedAt,
+			&i.Reservation.UpdatedAt,
+			&i.Reservation.CancelledAt,
+			&i.Reservation.CancelledBy,
+			&i.Reservation.CancellationFeePercent,
+			&i.Reservation.ParentCode,
+			&i.Reservation.Adults,
+			&i.Reservation.Children,
+			&i.Reservation.Status,
+			&i.Reservation.ConfirmedAt,
+			&i.Reservation.CheckedInAt,
+			&i.Reservation.CheckedOutAt,
+			&i.Reservation.NoShowAt,
+			&i.Reservation.TotalPrice,
+			&i.Reservation.PromoCodeID,
+			&i.Reservation.Discount,
 			&i.Room.ID,
 			&i.Room.Name,
 			&i.Room.Description,
 			&i.Room.ImageFilename,
 			&i.Room.CreatedAt,
 			&i.Room.UpdatedAt,
+			&i.Room.MaxAdults,
+			&i.Room.MaxChildren,
+			&i.Room.MaxOccupancy,
+			&i.Room.Slug,
+			&i.Room.NightlyRate,
 		); err != nil {
 			return nil, err
 		}
@@ -239,39 +710,44 @@ func (q *Queries) ListReservationsAndRooms(ctx context.Context, arg ListReservat
 	return items, nil
 }
 
-const updateReservation = `-- name: UpdateReservation :exec
+const updateReservation = `-- name: UpdateReservation :one
 UPDATE reservations
-  set   code = $2,
-        first_name = $3,
-        last_name = $4, 
-        email = $5,
-        phone = $6, 
-        start_date =  $7,
-        end_date = $8,
-        room_id = $9,
-        notes = $10,
-        updated_at = $11
-WHERE id = $1
+  set   first_name = $2,
+        last_name = $3,
+        email = $4,
+        phone = $5,
+        start_date = $6,
+        end_date = $7,
+        room_id = $8,
+        notes = $9,
+        adults = $10,
+        children = $11,
+        total_price = $12,
+        discount = $13,
+        updated_at = now()
+WHERE id = $1 AND status IN ('pending', 'confirmed', 'checked_in')
+RETURNING id, code, first_name, last_name, email, phone, start_date, end_date, room_id, notes, created_at, updated_at, cancelled_at, cancelled_by, cancellation_fee_percent, parent_code, adults, children, status, confirmed_at, checked_in_at, checked_out_at, no_show_at, total_price, promo_code_id, discount
 `
 
 type UpdateReservationParams struct {
-	ID        int64              `json:"id"`
-	Code      string             `json:"code"`
-	FirstName string             `json:"first_name"`
-	LastName  string             `json:"last_name"`
-	Email     string             `json:"email"`
-	Phone     pgtype.Text        `json:"phone"`
-	StartDate pgtype.Date        `json:"start_date"`
-	EndDate   pgtype.Date        `json:"end_date"`
-	RoomID    int64              `json:"room_id"`
-	Notes     pgtype.Text        `json:"notes"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	ID         int64       `json:"id"`
+	FirstName  string      `json:"first_name"`
+	LastName   string      `json:"last_name"`
+	Email      string      `json:"email"`
+	Phone      pgtype.Text `json:"phone"`
+	StartDate  pgtype.Date `json:"start_date"`
+	EndDate    pgtype.Date `json:"end_date"`
+	RoomID     int64       `json:"room_id"`
+	Notes      pgtype.Text `json:"notes"`
+	Adults     int32       `json:"adults"`
+	Children   int32       `json:"children"`
+	TotalPrice int64       `json:"total_price"`
+	Discount   int64       `json:"discount"`
 }
 
-func (q *Queries) UpdateReservation(ctx context.Context, arg UpdateReservationParams) error {
-	_, err := q.db.Exec(ctx, updateReservation,
+func (q *Queries) UpdateReservation(ctx context.Context, arg UpdateReservationParams) (Reservation, error) {
+	row := q.db.QueryRow(ctx, updateReservation,
 		arg.ID,
-		arg.Code,
 		arg.FirstName,
 		arg.LastName,
 		arg.Email,
@@ -280,7 +756,151 @@ func (q *Queries) UpdateReservation(ctx context.Context, arg UpdateReservationPa
 		arg.EndDate,
 		arg.RoomID,
 		arg.Notes,
-		arg.UpdatedAt,
+		arg.Adults,
+		arg.Children,
+		arg.TotalPrice,
+		arg.Discount,
 	)
-	return err
+	var i Reservation
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.Phone,
+		&i.StartDate,
+		&i.EndDate,
+		&i.RoomID,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CancelledAt,
+		&i.CancelledBy,
+		&i.CancellationFeePercent,
+		&i.ParentCode,
+		&i.Adults,
+		&i.Children,
+		&i.Status,
+		&i.ConfirmedAt,
+		&i.CheckedInAt,
+		&i.CheckedOutAt,
+		&i.NoShowAt,
+		&i.TotalPrice,
+		&i.PromoCodeID,
+		&i.Discount,
+	)
+	return i, err
+}
+
+const updateReservationDates = `-- name: UpdateReservationDates :one
+UPDATE reservations
+  set   start_date = $2,
+        end_date = $3,
+        total_price = $4,
+        discount = $5,
+        updated_at = now()
+WHERE id = $1 AND status IN ('pending', 'confirmed')
+RETURNING id, code, first_name, last_name, email, phone, start_date, end_date, room_id, notes, created_at, updated_at, cancelled_at, cancelled_by, cancellation_fee_percent, parent_code, adults, children, status, confirmed_at, checked_in_at, checked_out_at, no_show_at, total_price, promo_code_id, discount
+`
+
+type UpdateReservationDatesParams struct {
+	ID         int64       `json:"id"`
+	StartDate  pgtype.Date `json:"start_date"`
+	EndDate    pgtype.Date `json:"end_date"`
+	TotalPrice int64       `json:"total_price"`
+	Discount   int64       `json:"discount"`
+}
+
+func (q *Queries) UpdateReservationDates(ctx context.Context, arg UpdateReservationDatesParams) (Reservation, error) {
+	row := q.db.QueryRow(ctx, updateReservationDates,
+		arg.ID,
+		arg.StartDate,
+		arg.EndDate,
+		arg.TotalPrice,
+		arg.Discount,
+	)
+	var i Reservation
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.Phone,
+		&i.StartDate,
+		&i.EndDate,
+		&i.RoomID,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CancelledAt,
+		&i.CancelledBy,
+		&i.CancellationFeePercent,
+		&i.ParentCode,
+		&i.Adults,
+		&i.Children,
+		&i.Status,
+		&i.ConfirmedAt,
+		&i.CheckedInAt,
+		&i.CheckedOutAt,
+		&i.NoShowAt,
+		&i.TotalPrice,
+		&i.PromoCodeID,
+		&i.Discount,
+	)
+	return i, err
+}
+
+const updateReservationStatus = `-- name: UpdateReservationStatus :one
+UPDATE reservations
+  set   status = $1::reservation_status,
+        confirmed_at = CASE WHEN $1::reservation_status = 'confirmed' THEN now() ELSE confirmed_at END,
+        checked_in_at = CASE WHEN $1::reservation_status = 'checked_in' THEN now() ELSE checked_in_at END,
+        checked_out_at = CASE WHEN $1::reservation_status = 'checked_out' THEN now() ELSE checked_out_at END,
+        cancelled_at = CASE WHEN $1::reservation_status = 'cancelled' THEN now() ELSE cancelled_at END,
+        cancelled_by = CASE WHEN $1::reservation_status = 'cancelled' THEN 'staff' ELSE cancelled_by END,
+        no_show_at = CASE WHEN $1::reservation_status = 'no_show' THEN now() ELSE no_show_at END,
+        updated_at = now()
+WHERE id = $2
+RETURNING id, code, first_name, last_name, email, phone, start_date, end_date, room_id, notes, created_at, updated_at, cancelled_at, cancelled_by, cancellation_fee_percent, parent_code, adults, children, status, confirmed_at, checked_in_at, checked_out_at, no_show_at, total_price, promo_code_id, discount
+`
+
+type UpdateReservationStatusParams struct {
+	Status ReservationStatus `json:"status"`
+	ID     int64             `json:"id"`
+}
+
+func (q *Queries) UpdateReservationStatus(ctx context.Context, arg UpdateReservationStatusParams) (Reservation, error) {
+	row := q.db.QueryRow(ctx, updateReservationStatus, arg.Status, arg.ID)
+	var i Reservation
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.Phone,
+		&i.StartDate,
+		&i.EndDate,
+		&i.RoomID,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CancelledAt,
+		&i.CancelledBy,
+		&i.CancellationFeePercent,
+		&i.ParentCode,
+		&i.Adults,
+		&i.Children,
+		&i.Status,
+		&i.ConfirmedAt,
+		&i.CheckedInAt,
+		&i.CheckedOutAt,
+		&i.NoShowAt,
+		&i.TotalPrice,
+		&i.PromoCodeID,
+		&i.Discount,
+	)
+	return i, err
 }
@@ -2,10 +2,14 @@ package db
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/github-real-lb/bookings-web-app/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -21,6 +25,7 @@ func createRandomReservation(t *testing.T, room Room) Reservation {
 		LastName:  util.RandomName(),
 		Email:     util.RandomEmail(),
 		RoomID:    room.ID,
+		Adults:    1,
 	}
 	arg.Phone.Scan(util.RandomPhone())
 	arg.StartDate.Scan(rDate)
@@ -51,6 +56,7 @@ func createRandomWeekReservation(t *testing.T, room Room, startDate time.Time) R
 		LastName:  util.RandomName(),
 		Email:     util.RandomEmail(),
 		RoomID:    room.ID,
+		Adults:    1,
 	}
 	arg.Phone.Scan(util.RandomPhone())
 	arg.StartDate.Scan(startDate)
@@ -79,6 +85,37 @@ func TestQueries_CreateReservation(t *testing.T) {
 	createRandomReservation(t, room)
 }
 
+func TestQueries_GetReservationAndRoom(t *testing.T) {
+	room := createRandomRoom(t)
+	rsv := createRandomReservation(t, room)
+
+	result, err := testStore.GetReservationAndRoom(context.Background(), rsv.ID)
+	require.NoError(t, err)
+	assert.Equal(t, rsv, result.Reservation)
+	assert.Equal(t, room, result.Room)
+}
+
+func TestQueries_GetReservationByLastName(t *testing.T) {
+	room := createRandomRoom(t)
+	rsv := createRandomReservation(t, room)
+
+	// the last name is matched whatever its case
+	for _, lastName := range []string{rsv.LastName, strings.ToLower(rsv.LastName), strings.ToUpper(rsv.LastName)} {
+		result, err := testStore.GetReservationByLastName(context.Background(), GetReservationByLastNameParams{
+			Code:     rsv.Code,
+			LastName: lastName,
+		})
+		require.NoError(t, err, lastName)
+		assert.Equal(t, rsv, result, lastName)
+	}
+
+	_, err := testStore.GetReservationByLastName(context.Background(), GetReservationByLastNameParams{
+		Code:     rsv.Code,
+		LastName: util.RandomName(),
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
 func TestQueries_ListReservationsAndRooms(t *testing.T) {
 	const N = 10
 	rooms := make([]Room, N)
@@ -103,3 +140,42 @@ func TestQueries_ListReservationsAndRooms(t *testing.T) {
 	}
 
 }
+
+func TestQueries_ListArrivalsAndDeparturesAndRooms(t *testing.T) {
+	room := createRandomRoom(t)
+	date := util.RandomDate()
+
+	// arrival on date, confirmed
+	arrival := createRandomWeekReservation(t, room, date)
+	_, err := testStore.UpdateReservationStatus(context.Background(), UpdateReservationStatusParams{
+		ID:     arrival.ID,
+		Status: ReservationStatusConfirmed,
+	})
+	require.NoError(t, err)
+
+	// departure on date, checked in
+	departure := createRandomWeekReservation(t, room, date.Add(-time.Hour*24*7))
+	_, err = testStore.UpdateReservationStatus(context.Background(), UpdateReservationStatusParams{
+		ID:     departure.ID,
+		Status: ReservationStatusCheckedIn,
+	})
+	require.NoError(t, err)
+
+	var arg pgtype.Date
+	arg.Scan(date)
+
+	arrivals, err := testStore.ListArrivalsAndRooms(context.Background(), arg)
+	require.NoError(t, err)
+	assert.True(t, slices.ContainsFunc(arrivals, func(r ListArrivalsAndRoomsRow) bool {
+		return r.Reservation.ID == arrival.ID && r.Room.ID == room.ID
+	}))
+
+	departures, err := testStore.ListDeparturesAndRooms(context.Background(), arg)
+	require.NoError(t, err)
+	assert.True(t, slices.ContainsFunc(departures, func(r ListDeparturesAndRoomsRow) bool {
+		return r.Reservation.ID == departure.ID && r.Room.ID == room.ID
+	}))
+	assert.False(t, slices.ContainsFunc(departures, func(r ListDeparturesAndRoomsRow) bool {
+		return r.Reservation.ID == arrival.ID
+	}))
+}
@@ -0,0 +1,90 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: reservation_charge.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createReservationCharge = `-- name: CreateReservationCharge :one
+INSERT INTO reservation_charges (
+  reservation_id, charge_id, name, amount
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING id, reservation_id, charge_id, name, amount, created_at
+`
+
+type CreateReservationChargeParams struct {
+	ReservationID int64       `json:"reservation_id"`
+	ChargeID      pgtype.Int8 `json:"charge_id"`
+	Name          string      `json:"name"`
+	Amount        int64       `json:"amount"`
+}
+
+func (q *Queries) CreateReservationCharge(ctx context.Context, arg CreateReservationChargeParams) (ReservationCharge, error) {
+	row := q.db.QueryRow(ctx, createReservationCharge,
+		arg.ReservationID,
+		arg.ChargeID,
+		arg.Name,
+		arg.Amount,
+	)
+	var i ReservationCharge
+	err := row.Scan(
+		&i.ID,
+		&i.ReservationID,
+		&i.ChargeID,
+		&i.Name,
+		&i.Amount,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteReservationCharges = `-- name: DeleteReservationCharges :exec
+DELETE FROM reservation_charges
+WHERE reservation_id = $1
+`
+
+func (q *Queries) DeleteReservationCharges(ctx context.Context, reservationID int64) error {
+	_, err := q.db.Exec(ctx, deleteReservationCharges, reservationID)
+	return err
+}
+
+const listReservationCharges = `-- name: ListReservationCharges :many
+SELECT id, reservation_id, charge_id, name, amount, created_at FROM reservation_charges
+WHERE reservation_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListReservationCharges(ctx context.Context, reservationID int64) ([]ReservationCharge, error) {
+	rows, err := q.db.Query(ctx, listReservationCharges, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ReservationCharge{}
+	for rows.Next() {
+		var i ReservationCharge
+		if err := rows.Scan(
+			&i.ID,
+			&i.ReservationID,
+			&i.ChargeID,
+			&i.Name,
+			&i.Amount,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,24 @@
+package db
+
+// reservationStatusTransitions maps every reservation status to the statuses a reservation can move to
+var reservationStatusTransitions = map[ReservationStatus][]ReservationStatus{
+	ReservationStatusPending:   {ReservationStatusConfirmed, ReservationStatusCancelled},
+	ReservationStatusConfirmed: {ReservationStatusCheckedIn, ReservationStatusCancelled, ReservationStatusNoShow},
+	ReservationStatusCheckedIn: {ReservationStatusCheckedOut},
+}
+
+// CanTransitionTo returns true if a reservation in status e can move to status next
+func (e ReservationStatus) CanTransitionTo(next ReservationStatus) bool {
+	for _, s := range reservationStatusTransitions[e] {
+		if s == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NextStatuses returns the statuses a reservation in status e can move to
+func (e ReservationStatus) NextStatuses() []ReservationStatus {
+	return reservationStatusTransitions[e]
+}
@@ -12,19 +12,64 @@ import (
 )
 
 const checkRoomAvailability = `-- name: CheckRoomAvailability :one
-SELECT count(*) = 0 as availabe
-FROM room_restrictions
-WHERE room_id = $1 AND (end_date > $2::date AND start_date < $3::date)
+SELECT ((
+  SELECT count(*)
+  FROM room_restrictions
+  WHERE room_id = $1::bigint AND (end_date > $2::date AND start_date < $3::date)
+  AND (restriction <> 'hold' OR (expires_at > now() AND hold_token <> $4::text))
+) = 0 AND EXISTS (
+  SELECT 1
+  FROM rooms
+  WHERE id = $1::bigint AND max_adults >= $5::integer AND max_children >= $6::integer
+  AND max_occupancy >= $5::integer + $6::integer
+))::boolean as availabe
 `
 
 type CheckRoomAvailabilityParams struct {
 	RoomID    int64       `json:"room_id"`
 	StartDate pgtype.Date `json:"start_date"`
 	EndDate   pgtype.Date `json:"end_date"`
+	HoldToken string      `json:"hold_token"`
+	Adults    int32       `json:"adults"`
+	Children  int32       `json:"children"`
 }
 
 func (q *Queries) CheckRoomAvailability(ctx context.Context, arg CheckRoomAvailabilityParams) (bool, error) {
-	row := q.db.QueryRow(ctx, checkRoomAvailability, arg.RoomID, arg.StartDate, arg.EndDate)
+	row := q.db.QueryRow(ctx, checkRoomAvailability,
+		arg.RoomID,
+		arg.StartDate,
+		arg.EndDate,
+		arg.HoldToken,
+		arg.Adults,
+		arg.Children,
+	)
+	var availabe bool
+	err := row.Scan(&availabe)
+	return availabe, err
+}
+
+const checkRoomAvailabilityForReservation = `-- name: CheckRoomAvailabilityForReservation :one
+SELECT count(*) = 0 as availabe
+FROM room_restrictions
+WHERE room_id = $1 AND (end_date > $2::date AND start_date < $3::date)
+AND (reservation_id IS NULL OR reservation_id <> $4::bigint)
+AND (restriction <> 'hold' OR expires_at > now())
+`
+
+type CheckRoomAvailabilityForReservationParams struct {
+	RoomID        int64       `json:"room_id"`
+	StartDate     pgtype.Date `json:"start_date"`
+	EndDate       pgtype.Date `json:"end_date"`
+	ReservationID int64       `json:"reservation_id"`
+}
+
+func (q *Queries) CheckRoomAvailabilityForReservation(ctx context.Context, arg CheckRoomAvailabilityForReservationParams) (bool, error) {
+	row := q.db.QueryRow(ctx, checkRoomAvailabilityForReservation,
+		arg.RoomID,
+		arg.StartDate,
+		arg.EndDate,
+		arg.ReservationID,
+	)
 	var availabe bool
 	err := row.Scan(&availabe)
 	return availabe, err
@@ -32,21 +77,35 @@ func (q *Queries) CheckRoomAvailability(ctx context.Context, arg CheckRoomAvaila
 
 const createRoom = `-- name: CreateRoom :one
 INSERT INTO rooms (
-  name, description, image_filename
+  name, description, image_filename, max_adults, max_children, max_occupancy, slug, nightly_rate
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5, $6, $7, $8
 )
-RETURNING id, name, description, image_filename, created_at, updated_at
+RETURNING id, name, description, image_filename, created_at, updated_at, max_adults, max_children, max_occupancy, slug, nightly_rate
 `
 
 type CreateRoomParams struct {
 	Name          string `json:"name"`
 	Description   string `json:"description"`
 	ImageFilename string `json:"image_filename"`
+	MaxAdults     int32  `json:"max_adults"`
+	MaxChildren   int32  `json:"max_children"`
+	MaxOccupancy  int32  `json:"max_occupancy"`
+	Slug          string `json:"slug"`
+	NightlyRate   int64  `json:"nightly_rate"`
 }
 
 func (q *Queries) CreateRoom(ctx context.Context, arg CreateRoomParams) (Room, error) {
-	row := q.db.QueryRow(ctx, createRoom, arg.Name, arg.Description, arg.ImageFilename)
+	row := q.db.QueryRow(ctx, createRoom,
+		arg.Name,
+		arg.Description,
+		arg.ImageFilename,
+		arg.MaxAdults,
+		arg.MaxChildren,
+		arg.MaxOccupancy,
+		arg.Slug,
+		arg.NightlyRate,
+	)
 	var i Room
 	err := row.Scan(
 		&i.ID,
@@ -55,6 +114,11 @@ func (q *Queries) CreateRoom(ctx context.Context, arg CreateRoomParams) (Room, e
 		&i.ImageFilename,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxAdults,
+		&i.MaxChildren,
+		&i.MaxOccupancy,
+		&i.Slug,
+		&i.NightlyRate,
 	)
 	return i, err
 }
@@ -79,7 +143,7 @@ func (q *Queries) DeleteRoom(ctx context.Context, id int64) error {
 }
 
 const getRoom = `-- name: GetRoom :one
-SELECT id, name, description, image_filename, created_at, updated_at FROM rooms
+SELECT id, name, description, image_filename, created_at, updated_at, max_adults, max_children, max_occupancy, slug, nightly_rate FROM rooms
 WHERE id = $1 LIMIT 1
 `
 
@@ -93,18 +157,75 @@ func (q *Queries) GetRoom(ctx context.Context, id int64) (Room, error) {
 		&i.ImageFilename,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxAdults,
+		&i.MaxChildren,
+		&i.MaxOccupancy,
+		&i.Slug,
+		&i.NightlyRate,
+	)
+	return i, err
+}
+
+const getRoomBySlug = `-- name: GetRoomBySlug :one
+SELECT id, name, description, image_filename, created_at, updated_at, max_adults, max_children, max_occupancy, slug, nightly_rate FROM rooms
+WHERE slug = lower($1) LIMIT 1
+`
+
+func (q *Queries) GetRoomBySlug(ctx context.Context, slug string) (Room, error) {
+	row := q.db.QueryRow(ctx, getRoomBySlug, slug)
+	var i Room
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.ImageFilename,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.MaxAdults,
+		&i.MaxChildren,
+		&i.MaxOccupancy,
+		&i.Slug,
+		&i.NightlyRate,
+	)
+	return i, err
+}
+
+const getRoomForUpdate = `-- name: GetRoomForUpdate :one
+SELECT id, name, description, image_filename, created_at, updated_at, max_adults, max_children, max_occupancy, slug, nightly_rate FROM rooms
+WHERE id = $1 LIMIT 1
+FOR UPDATE
+`
+
+func (q *Queries) GetRoomForUpdate(ctx context.Context, id int64) (Room, error) {
+	row := q.db.QueryRow(ctx, getRoomForUpdate, id)
+	var i Room
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.ImageFilename,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.MaxAdults,
+		&i.MaxChildren,
+		&i.MaxOccupancy,
+		&i.Slug,
+		&i.NightlyRate,
 	)
 	return i, err
 }
 
 const listAvailableRooms = `-- name: ListAvailableRooms :many
-SELECT id, name, description, image_filename, created_at, updated_at
+SELECT id, name, description, image_filename, created_at, updated_at, max_adults, max_children, max_occupancy, slug, nightly_rate
 FROM rooms
 WHERE id NOT IN (
 SELECT room_id
 FROM room_restrictions
 WHERE (end_date > $3::date AND start_date < $4::date)
+AND (restriction <> 'hold' OR expires_at > now())
 )
+AND max_adults >= LEAST($5::integer, 1)
+AND max_occupancy >= LEAST($5::integer + $6::integer, 1)
 ORDER BY name
 LIMIT $1
 OFFSET $2
@@ -115,14 +236,20 @@ type ListAvailableRoomsParams struct {
 	Offset    int32       `json:"offset"`
 	StartDate pgtype.Date `json:"start_date"`
 	EndDate   pgtype.Date `json:"end_date"`
+	Adults    int32       `json:"adults"`
+	Children  int32       `json:"children"`
 }
 
+// a party is split across several rooms with at least one adult in every room,
+// so every room that can accommodate one of the adults is returned
 func (q *Queries) ListAvailableRooms(ctx context.Context, arg ListAvailableRoomsParams) ([]Room, error) {
 	rows, err := q.db.Query(ctx, listAvailableRooms,
 		arg.Limit,
 		arg.Offset,
 		arg.StartDate,
 		arg.EndDate,
+		arg.Adults,
+		arg.Children,
 	)
 	if err != nil {
 		return nil, err
@@ -138,6 +265,11 @@ func (q *Queries) ListAvailableRooms(ctx context.Context, arg ListAvailableRooms
 			&i.ImageFilename,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.MaxAdults,
+			&i.MaxChildren,
+			&i.MaxOccupancy,
+			&i.Slug,
+			&i.NightlyRate,
 		); err != nil {
 			return nil, err
 		}
@@ -150,7 +282,7 @@ func (q *Queries) ListAvailableRooms(ctx context.Context, arg ListAvailableRooms
 }
 
 const listRooms = `-- name: ListRooms :many
-SELECT id, name, description, image_filename, created_at, updated_at FROM rooms
+SELECT id, name, description, image_filename, created_at, updated_at, max_adults, max_children, max_occupancy, slug, nightly_rate FROM rooms
 ORDER BY name
 LIMIT $1
 OFFSET $2
@@ -177,6 +309,11 @@ func (q *Queries) ListRooms(ctx context.Context, arg ListRoomsParams) ([]Room, e
 			&i.ImageFilename,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.MaxAdults,
+			&i.MaxChildren,
+			&i.MaxOccupancy,
+			&i.Slug,
+			&i.NightlyRate,
 		); err != nil {
 			return nil, err
 		}
@@ -193,7 +330,12 @@ UPDATE rooms
   set   name = $2,
         description = $3,
         image_filename = $4,
-        updated_at = $5
+        max_adults = $5,
+        max_children = $6,
+        max_occupancy = $7,
+        slug = $8,
+        nightly_rate = $9,
+        updated_at = $10
 WHERE id = $1
 `
 
@@ -202,6 +344,11 @@ type UpdateRoomParams struct {
 	Name          string             `json:"name"`
 	Description   string             `json:"description"`
 	ImageFilename string             `json:"image_filename"`
+	MaxAdults     int32              `json:"max_adults"`
+	MaxChildren   int32              `json:"max_children"`
+	MaxOccupancy  int32              `json:"max_occupancy"`
+	Slug          string             `json:"slug"`
+	NightlyRate   int64              `json:"nightly_rate"`
 	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
 }
 
@@ -211,6 +358,11 @@ func (q *Queries) UpdateRoom(ctx context.Context, arg UpdateRoomParams) error {
 		arg.Name,
 		arg.Description,
 		arg.ImageFilename,
+		arg.MaxAdults,
+		arg.MaxChildren,
+		arg.MaxOccupancy,
+		arg.Slug,
+		arg.NightlyRate,
 		arg.UpdatedAt,
 	)
 	return err
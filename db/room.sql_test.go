@@ -3,10 +3,12 @@ package db
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/github-real-lb/bookings-web-app/util"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,7 +22,12 @@ func createRandomRoom(t *testing.T) Room {
 		Name:          util.RandomName(),
 		Description:   util.RandomNote(),
 		ImageFilename: fmt.Sprintf("%s.png", util.RandomName()),
+		MaxAdults:     2,
+		MaxChildren:   2,
+		MaxOccupancy:  4,
+		NightlyRate:   util.RandomInt64(50, 500) * 100,
 	}
+	arg.Slug = fmt.Sprintf("%s-%s", util.Slugify(arg.Name), util.Slugify(util.RandomString(8)))
 	//arg.Unmarshal(data)
 
 	r, err := testStore.CreateRoom(context.Background(), arg)
@@ -29,6 +36,11 @@ func createRandomRoom(t *testing.T) Room {
 	assert.Equal(t, arg.Name, r.Name)
 	assert.Equal(t, arg.Description, r.Description)
 	assert.Equal(t, arg.ImageFilename, r.ImageFilename)
+	assert.Equal(t, arg.MaxAdults, r.MaxAdults)
+	assert.Equal(t, arg.MaxChildren, r.MaxChildren)
+	assert.Equal(t, arg.MaxOccupancy, r.MaxOccupancy)
+	assert.Equal(t, arg.Slug, r.Slug)
+	assert.Equal(t, arg.NightlyRate, r.NightlyRate)
 	assert.WithinDuration(t, time.Now(), r.CreatedAt.Time, time.Second)
 	assert.True(t, r.CreatedAt.Valid)
 	assert.WithinDuration(t, time.Now(), r.UpdatedAt.Time, time.Second)
@@ -51,6 +63,20 @@ func TestQueries_CreateRoom(t *testing.T) {
 	createRandomRoom(t)
 }
 
+func TestQueries_GetRoomBySlug(t *testing.T) {
+	room := createRandomRoom(t)
+
+	// slug is matched regardless of case
+	for _, slug := range []string{room.Slug, strings.ToUpper(room.Slug)} {
+		result, err := testStore.GetRoomBySlug(context.Background(), slug)
+		require.NoError(t, err)
+		assert.Equal(t, room.ID, result.ID)
+	}
+
+	_, err := testStore.GetRoomBySlug(context.Background(), "no-such-room")
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
 func TestQueries_ListAvailableRooms(t *testing.T) {
 	// remove all restrictions, reservations and rooms
 	err := testStore.DeleteAllRoomRestrictions(context.Background())
@@ -80,8 +106,10 @@ func TestQueries_ListAvailableRooms(t *testing.T) {
 
 	t.Run("All Rooms Available", func(t *testing.T) {
 		arg := ListAvailableRoomsParams{
-			Limit:  N * 2,
-			Offset: 0,
+			Limit:    N * 2,
+			Offset:   0,
+			Adults:   1,
+			Children: 0,
 			StartDate: pgtype.Date{
 				Time:  startDate.Add(-time.Hour * 24 * 90),
 				Valid: true,
@@ -103,8 +131,10 @@ func TestQueries_ListAvailableRooms(t *testing.T) {
 
 	t.Run("All Rooms Unavailable", func(t *testing.T) {
 		arg := ListAvailableRoomsParams{
-			Limit:  N * 2,
-			Offset: 0,
+			Limit:    N * 2,
+			Offset:   0,
+			Adults:   1,
+			Children: 0,
 			StartDate: pgtype.Date{
 				Time:  startDate.Add(-time.Hour * 24 * 7),
 				Valid: true,
@@ -120,10 +150,33 @@ func TestQueries_ListAvailableRooms(t *testing.T) {
 		require.Len(t, resultRooms, 0)
 	})
 
+	t.Run("Party Larger Than Any Room", func(t *testing.T) {
+		arg := ListAvailableRoomsParams{
+			Limit:    N * 2,
+			Offset:   0,
+			Adults:   5,
+			Children: 3,
+			StartDate: pgtype.Date{
+				Time:  startDate.Add(-time.Hour * 24 * 90),
+				Valid: true,
+			},
+			EndDate: pgtype.Date{
+				Time:  startDate.Add(-time.Hour * 24 * 80),
+				Valid: true,
+			},
+		}
+
+		resultRooms, err := testStore.ListAvailableRooms(context.Background(), arg)
+		require.NoError(t, err)
+		require.Len(t, resultRooms, N)
+	})
+
 	t.Run("1st Room Unavailable", func(t *testing.T) {
 		arg := ListAvailableRoomsParams{
-			Limit:  N * 2,
-			Offset: 0,
+			Limit:    N * 2,
+			Offset:   0,
+			Adults:   1,
+			Children: 0,
 			StartDate: pgtype.Date{
 				Time:  startDate.Add(time.Hour * 24 * 2),
 				Valid: true,
@@ -145,8 +198,10 @@ func TestQueries_ListAvailableRooms(t *testing.T) {
 
 	t.Run("2nd & 3rd Rooms Unavailable", func(t *testing.T) {
 		arg := ListAvailableRoomsParams{
-			Limit:  N * 2,
-			Offset: 0,
+			Limit:    N * 2,
+			Offset:   0,
+			Adults:   1,
+			Children: 0,
 			StartDate: pgtype.Date{
 				Time:  startDate.Add(time.Hour * 24 * 8),
 				Valid: true,
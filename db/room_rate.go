@@ -0,0 +1,37 @@
+package db
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// AllDaysOfWeek is the days of week mask of a rate that applies on every day of the week.
+// Bit 0 of the mask is Sunday and bit 6 is Saturday, following time.Weekday.
+const AllDaysOfWeek int32 = 127
+
+// DaysOfWeekMask returns the days of week mask of days
+func DaysOfWeekMask(days ...time.Weekday) int32 {
+	var mask int32
+	for _, day := range days {
+		mask |= 1 << int(day)
+	}
+
+	return mask
+}
+
+// AppliesOn returns true if rate r applies to the night of date.
+// A rate applies from its start date to its end date, both inclusive, on the days of week of its mask.
+func (r RoomRate) AppliesOn(date time.Time) bool {
+	return appliesOn(r.StartDate, r.EndDate, r.DaysOfWeek, date)
+}
+
+// appliesOn returns true if date is between startDate and endDate, both inclusive,
+// and its day of week is in the days of week mask daysOfWeek
+func appliesOn(startDate, endDate pgtype.Date, daysOfWeek int32, date time.Time) bool {
+	if date.Before(startDate.Time) || date.After(endDate.Time) {
+		return false
+	}
+
+	return daysOfWeek&DaysOfWeekMask(date.Weekday()) != 0
+}
@@ -0,0 +1,254 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: room_rate.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createRoomRate = `-- name: CreateRoomRate :one
+INSERT INTO room_rates (
+  room_id, name, start_date, end_date, days_of_week, rate, priority
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING id, room_id, name, start_date, end_date, days_of_week, rate, priority, created_at, updated_at
+`
+
+type CreateRoomRateParams struct {
+	RoomID     int64       `json:"room_id"`
+	Name       string      `json:"name"`
+	StartDate  pgtype.Date `json:"start_date"`
+	EndDate    pgtype.Date `json:"end_date"`
+	DaysOfWeek int32       `json:"days_of_week"`
+	Rate       int64       `json:"rate"`
+	Priority   int32       `json:"priority"`
+}
+
+func (q *Queries) CreateRoomRate(ctx context.Context, arg CreateRoomRateParams) (RoomRate, error) {
+	row := q.db.QueryRow(ctx, createRoomRate,
+		arg.RoomID,
+		arg.Name,
+		arg.StartDate,
+		arg.EndDate,
+		arg.DaysOfWeek,
+		arg.Rate,
+		arg.Priority,
+	)
+	var i RoomRate
+	err := row.Scan(
+		&i.ID,
+		&i.RoomID,
+		&i.Name,
+		&i.StartDate,
+		&i.EndDate,
+		&i.DaysOfWeek,
+		&i.Rate,
+		&i.Priority,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteAllRoomRates = `-- name: DeleteAllRoomRates :exec
+DELETE FROM room_rates
+`
+
+func (q *Queries) DeleteAllRoomRates(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, deleteAllRoomRates)
+	return err
+}
+
+const deleteRoomRates = `-- name: DeleteRoomRates :exec
+DELETE FROM room_rates
+WHERE id = ANY($1::bigint[])
+`
+
+func (q *Queries) DeleteRoomRates(ctx context.Context, ids []int64) error {
+	_, err := q.db.Exec(ctx, deleteRoomRates, ids)
+	return err
+}
+
+const getRoomRate = `-- name: GetRoomRate :one
+SELECT id, room_id, name, start_date, end_date, days_of_week, rate, priority, created_at, updated_at FROM room_rates
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetRoomRate(ctx context.Context, id int64) (RoomRate, error) {
+	row := q.db.QueryRow(ctx, getRoomRate, id)
+	var i RoomRate
+	err := row.Scan(
+		&i.ID,
+		&i.RoomID,
+		&i.Name,
+		&i.StartDate,
+		&i.EndDate,
+		&i.DaysOfWeek,
+		&i.Rate,
+		&i.Priority,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listRoomRatesAndRooms = `-- name: ListRoomRatesAndRooms :many
+SELECT room_rates.id, room_rates.room_id, room_rates.name, room_rates.start_date, room_rates.end_date, room_rates.days_of_week, room_rates.rate, room_rates.priority, room_rates.created_at, room_rates.updated_at, rooms.id, rooms.name, rooms.description, rooms.image_filename, rooms.created_at, rooms.updated_at, rooms.max_adults, rooms.max_children, rooms.max_occupancy, rooms.slug, rooms.nightly_rate
+FROM room_rates
+JOIN rooms ON (room_rates.room_id = rooms.id)
+WHERE room_rates.end_date >= $3::date
+ORDER BY rooms.name, room_rates.start_date, room_rates.priority DESC, room_rates.id DESC
+LIMIT $1
+OFFSET $2
+`
+
+type ListRoomRatesAndRoomsParams struct {
+	Limit  int32       `json:"limit"`
+	Offset int32       `json:"offset"`
+	Date   pgtype.Date `json:"date"`
+}
+
+type ListRoomRatesAndRoomsRow struct {
+	RoomRate RoomRate `json:"room_rate"`
+	Room     Room     `json:"room"`
+}
+
+func (q *Queries) ListRoomRatesAndRooms(ctx context.Context, arg ListRoomRatesAndRoomsParams) ([]ListRoomRatesAndRoomsRow, error) {
+	rows, err := q.db.Query(ctx, listRoomRatesAndRooms, arg.Limit, arg.Offset, arg.Date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListRoomRatesAndRoomsRow{}
+	for rows.Next() {
+		var i ListRoomRatesAndRoomsRow
+		if err := rows.Scan(
+			&i.RoomRate.ID,
+			&i.RoomRate.RoomID,
+			&i.RoomRate.Name,
+			&i.RoomRate.StartDate,
+			&i.RoomRate.EndDate,
+			&i.RoomRate.DaysOfWeek,
+			&i.RoomRate.Rate,
+			&i.RoomRate.Priority,
+			&i.RoomRate.CreatedAt,
+			&i.RoomRate.UpdatedAt,
+			&i.Room.ID,
+			&i.Room.Name,
+			&i.Room.Description,
+			&i.Room.ImageFilename,
+			&i.Room.CreatedAt,
+			&i.Room.UpdatedAt,
+			&i.Room.MaxAdults,
+			&i.Room.MaxChildren,
+			&i.Room.MaxOccupancy,
+			&i.Room.Slug,
+			&i.Room.NightlyRate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRoomRatesForStay = `-- name: ListRoomRatesForStay :many
+SELECT id, room_id, name, start_date, end_date, days_of_week, rate, priority, created_at, updated_at FROM room_rates
+WHERE room_id = $1::bigint AND (end_date >= $2::date AND start_date < $3::date)
+ORDER BY priority DESC, id DESC
+`
+
+type ListRoomRatesForStayParams struct {
+	RoomID    int64       `json:"room_id"`
+	StartDate pgtype.Date `json:"start_date"`
+	EndDate   pgtype.Date `json:"end_date"`
+}
+
+func (q *Queries) ListRoomRatesForStay(ctx context.Context, arg ListRoomRatesForStayParams) ([]RoomRate, error) {
+	rows, err := q.db.Query(ctx, listRoomRatesForStay, arg.RoomID, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []RoomRate{}
+	for rows.Next() {
+		var i RoomRate
+		if err := rows.Scan(
+			&i.ID,
+			&i.RoomID,
+			&i.Name,
+			&i.StartDate,
+			&i.EndDate,
+			&i.DaysOfWeek,
+			&i.Rate,
+			&i.Priority,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateRoomRate = `-- name: UpdateRoomRate :one
+UPDATE room_rates
+  set   name = $2,
+        start_date = $3,
+        end_date = $4,
+        days_of_week = $5,
+        rate = $6,
+        priority = $7,
+        updated_at = now()
+WHERE id = $1
+RETURNING id, room_id, name, start_date, end_date, days_of_week, rate, priority, created_at, updated_at
+`
+
+type UpdateRoomRateParams struct {
+	ID         int64       `json:"id"`
+	Name       string      `json:"name"`
+	StartDate  pgtype.Date `json:"start_date"`
+	EndDate    pgtype.Date `json:"end_date"`
+	DaysOfWeek int32       `json:"days_of_week"`
+	Rate       int64       `json:"rate"`
+	Priority   int32       `json:"priority"`
+}
+
+func (q *Queries) UpdateRoomRate(ctx context.Context, arg UpdateRoomRateParams) (RoomRate, error) {
+	row := q.db.QueryRow(ctx, updateRoomRate,
+		arg.ID,
+		arg.Name,
+		arg.StartDate,
+		arg.EndDate,
+		arg.DaysOfWeek,
+		arg.Rate,
+		arg.Priority,
+	)
+	var i RoomRate
+	err := row.Scan(
+		&i.ID,
+		&i.RoomID,
+		&i.Name,
+		&i.StartDate,
+		&i.EndDate,
+		&i.DaysOfWeek,
+		&i.Rate,
+		&i.Priority,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/bookings-web-app/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomRoomRate creates a random room rate for room from startDate to endDate, on days of week mask
+func createRandomRoomRate(t *testing.T, room Room, startDate, endDate time.Time, daysOfWeek, priority int32) RoomRate {
+	arg := CreateRoomRateParams{
+		RoomID:     room.ID,
+		Name:       util.RandomName(),
+		DaysOfWeek: daysOfWeek,
+		Rate:       util.RandomInt64(50, 500) * 100,
+		Priority:   priority,
+	}
+	arg.StartDate.Scan(startDate)
+	arg.EndDate.Scan(endDate)
+
+	rr, err := testStore.CreateRoomRate(context.Background(), arg)
+	require.NoError(t, err)
+	assert.NotEmpty(t, rr.ID)
+	assert.Equal(t, arg.RoomID, rr.RoomID)
+	assert.Equal(t, arg.Name, rr.Name)
+	assert.Equal(t, arg.StartDate, rr.StartDate)
+	assert.Equal(t, arg.EndDate, rr.EndDate)
+	assert.Equal(t, arg.DaysOfWeek, rr.DaysOfWeek)
+	assert.Equal(t, arg.Rate, rr.Rate)
+	assert.Equal(t, arg.Priority, rr.Priority)
+	assert.WithinDuration(t, time.Now(), rr.CreatedAt.Time, time.Second)
+	assert.WithinDuration(t, time.Now(), rr.UpdatedAt.Time, time.Second)
+
+	return rr
+}
+
+func TestQueries_CreateRoomRate(t *testing.T) {
+	rDate := util.RandomDate()
+	createRandomRoomRate(t, createRandomRoom(t), rDate, rDate.AddDate(0, 0, 30), AllDaysOfWeek, 0)
+}
+
+func TestQueries_UpdateRoomRate(t *testing.T) {
+	rDate := util.RandomDate()
+	rr := createRandomRoomRate(t, createRandomRoom(t), rDate, rDate.AddDate(0, 0, 30), AllDaysOfWeek, 0)
+
+	arg := UpdateRoomRateParams{
+		ID:         rr.ID,
+		Name:       util.RandomName(),
+		DaysOfWeek: DaysOfWeekMask(time.Friday, time.Saturday),
+		Rate:       rr.Rate + 1000,
+		Priority:   rr.Priority + 1,
+	}
+	arg.StartDate.Scan(rDate.AddDate(0, 0, 1))
+	arg.EndDate.Scan(rDate.AddDate(0, 0, 10))
+
+	updated, err := testStore.UpdateRoomRate(context.Background(), arg)
+	require.NoError(t, err)
+	assert.Equal(t, rr.ID, updated.ID)
+	assert.Equal(t, arg.Name, updated.Name)
+	assert.Equal(t, arg.StartDate, updated.StartDate)
+	assert.Equal(t, arg.EndDate, updated.EndDate)
+	assert.Equal(t, arg.DaysOfWeek, updated.DaysOfWeek)
+	assert.Equal(t, arg.Rate, updated.Rate)
+	assert.Equal(t, arg.Priority, updated.Priority)
+}
+
+func TestQueries_DeleteRoomRates(t *testing.T) {
+	room := createRandomRoom(t)
+	rDate := util.RandomDate()
+	rr1 := createRandomRoomRate(t, room, rDate, rDate.AddDate(0, 0, 30), AllDaysOfWeek, 0)
+	rr2 := createRandomRoomRate(t, room, rDate, rDate.AddDate(0, 0, 30), AllDaysOfWeek, 1)
+	kept := createRandomRoomRate(t, room, rDate, rDate.AddDate(0, 0, 30), AllDaysOfWeek, 2)
+
+	err := testStore.DeleteRoomRates(context.Background(), []int64{rr1.ID, rr2.ID})
+	require.NoError(t, err)
+
+	_, err = testStore.GetRoomRate(context.Background(), rr1.ID)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	_, err = testStore.GetRoomRate(context.Background(), rr2.ID)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	rr, err := testStore.GetRoomRate(context.Background(), kept.ID)
+	require.NoError(t, err)
+	assert.Equal(t, kept, rr)
+}
+
+func TestQueries_ListRoomRatesForStay(t *testing.T) {
+	room := createRandomRoom(t)
+	rDate := util.RandomDate()
+
+	// create rates overlapping and not overlapping the stay
+	low := createRandomRoomRate(t, room, rDate.AddDate(0, 0, -10), rDate, AllDaysOfWeek, 0)
+	high := createRandomRoomRate(t, room, rDate.AddDate(0, 0, 3), rDate.AddDate(0, 0, 20), AllDaysOfWeek, 5)
+	createRandomRoomRate(t, room, rDate.AddDate(0, 0, 7), rDate.AddDate(0, 0, 20), AllDaysOfWeek, 9)
+	createRandomRoomRate(t, createRandomRoom(t), rDate, rDate.AddDate(0, 0, 7), AllDaysOfWeek, 9)
+
+	arg := ListRoomRatesForStayParams{RoomID: room.ID}
+	arg.StartDate.Scan(rDate)
+	arg.EndDate.Scan(rDate.AddDate(0, 0, 7))
+
+	rates, err := testStore.ListRoomRatesForStay(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+	assert.Equal(t, high.ID, rates[0].ID)
+	assert.Equal(t, low.ID, rates[1].ID)
+}
+
+func TestQueries_ListRoomRatesAndRooms(t *testing.T) {
+	room := createRandomRoom(t)
+	rDate := util.RandomDate()
+
+	current := createRandomRoomRate(t, room, rDate, rDate.AddDate(0, 0, 7), AllDaysOfWeek, 0)
+	past := createRandomRoomRate(t, room, rDate.AddDate(0, 0, -20), rDate.AddDate(0, 0, -10), AllDaysOfWeek, 0)
+
+	arg := ListRoomRatesAndRoomsParams{
+		Limit:  1000,
+		Offset: 0,
+	}
+	arg.Date.Scan(rDate)
+
+	rows, err := testStore.ListRoomRatesAndRooms(context.Background(), arg)
+	require.NoError(t, err)
+
+	ids := map[int64]bool{}
+	for _, row := range rows {
+		assert.Equal(t, row.Room.ID, row.RoomRate.RoomID)
+		assert.False(t, row.RoomRate.EndDate.Time.Before(rDate))
+		ids[row.RoomRate.ID] = true
+	}
+	assert.True(t, ids[current.ID])
+	assert.False(t, ids[past.ID])
+}
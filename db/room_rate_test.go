@@ -0,0 +1,35 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDaysOfWeekMask(t *testing.T) {
+	assert.Equal(t, int32(1), DaysOfWeekMask(time.Sunday))
+	assert.Equal(t, int32(96), DaysOfWeekMask(time.Friday, time.Saturday))
+	assert.Equal(t, AllDaysOfWeek, DaysOfWeekMask(time.Sunday, time.Monday, time.Tuesday,
+		time.Wednesday, time.Thursday, time.Friday, time.Saturday))
+	assert.Zero(t, DaysOfWeekMask())
+}
+
+func TestRoomRate_AppliesOn(t *testing.T) {
+	// 2024-06-07 is a Friday
+	friday := time.Date(2024, 6, 7, 0, 0, 0, 0, time.UTC)
+
+	rr := RoomRate{
+		StartDate:  pgtype.Date{Time: friday, Valid: true},
+		EndDate:    pgtype.Date{Time: friday.AddDate(0, 0, 7), Valid: true},
+		DaysOfWeek: DaysOfWeekMask(time.Friday, time.Saturday),
+	}
+
+	assert.True(t, rr.AppliesOn(friday))
+	assert.True(t, rr.AppliesOn(friday.AddDate(0, 0, 1)))
+	assert.False(t, rr.AppliesOn(friday.AddDate(0, 0, 2)))
+	assert.True(t, rr.AppliesOn(friday.AddDate(0, 0, 7)))
+	assert.False(t, rr.AppliesOn(friday.AddDate(0, 0, -1)))
+	assert.False(t, rr.AppliesOn(friday.AddDate(0, 0, 8)))
+}
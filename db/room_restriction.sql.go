@@ -11,13 +11,95 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const createOwnerBlock = `-- name: CreateOwnerBlock :one
+INSERT INTO room_restrictions (
+  start_date, end_date, room_id, restriction, reason
+) VALUES (
+  $1, $2, $3, 'owner_block', $4
+)
+RETURNING id, start_date, end_date, room_id, reservation_id, restriction, created_at, updated_at, hold_token, expires_at, reason
+`
+
+type CreateOwnerBlockParams struct {
+	StartDate pgtype.Date `json:"start_date"`
+	EndDate   pgtype.Date `json:"end_date"`
+	RoomID    int64       `json:"room_id"`
+	Reason    string      `json:"reason"`
+}
+
+func (q *Queries) CreateOwnerBlock(ctx context.Context, arg CreateOwnerBlockParams) (RoomRestriction, error) {
+	row := q.db.QueryRow(ctx, createOwnerBlock,
+		arg.StartDate,
+		arg.EndDate,
+		arg.RoomID,
+		arg.Reason,
+	)
+	var i RoomRestriction
+	err := row.Scan(
+		&i.ID,
+		&i.StartDate,
+		&i.EndDate,
+		&i.RoomID,
+		&i.ReservationID,
+		&i.Restriction,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.HoldToken,
+		&i.ExpiresAt,
+		&i.Reason,
+	)
+	return i, err
+}
+
+const createRoomHold = `-- name: CreateRoomHold :one
+INSERT INTO room_restrictions (
+  start_date, end_date, room_id, restriction, hold_token, expires_at
+) VALUES (
+  $1, $2, $3, 'hold', $4, $5
+)
+RETURNING id, start_date, end_date, room_id, reservation_id, restriction, created_at, updated_at, hold_token, expires_at, reason
+`
+
+type CreateRoomHoldParams struct {
+	StartDate pgtype.Date        `json:"start_date"`
+	EndDate   pgtype.Date        `json:"end_date"`
+	RoomID    int64              `json:"room_id"`
+	HoldToken pgtype.Text        `json:"hold_token"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) CreateRoomHold(ctx context.Context, arg CreateRoomHoldParams) (RoomRestriction, error) {
+	row := q.db.QueryRow(ctx, createRoomHold,
+		arg.StartDate,
+		arg.EndDate,
+		arg.RoomID,
+		arg.HoldToken,
+		arg.ExpiresAt,
+	)
+	var i RoomRestriction
+	err := row.Scan(
+		&i.ID,
+		&i.StartDate,
+		&i.EndDate,
+		&i.RoomID,
+		&i.ReservationID,
+		&i.Restriction,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.HoldToken,
+		&i.ExpiresAt,
+		&i.Reason,
+	)
+	return i, err
+}
+
 const createRoomRestriction = `-- name: CreateRoomRestriction :one
 INSERT INTO room_restrictions (
   start_date, end_date, room_id, reservation_id, restriction
 ) VALUES (
   $1, $2, $3, $4, $5
 )
-RETURNING id, start_date, end_date, room_id, reservation_id, restriction, created_at, updated_at
+RETURNING id, start_date, end_date, room_id, reservation_id, restriction, created_at, updated_at, hold_token, expires_at, reason
 `
 
 type CreateRoomRestrictionParams struct {
@@ -46,6 +128,9 @@ func (q *Queries) CreateRoomRestriction(ctx context.Context, arg CreateRoomRestr
 		&i.Restriction,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.HoldToken,
+		&i.ExpiresAt,
+		&i.Reason,
 	)
 	return i, err
 }
@@ -59,6 +144,107 @@ func (q *Queries) DeleteAllRoomRestrictions(ctx context.Context) error {
 	return err
 }
 
+const deleteExpiredRoomHolds = `-- name: DeleteExpiredRoomHolds :many
+DELETE FROM room_restrictions
+WHERE restriction = 'hold' AND expires_at <= now()
+RETURNING id, start_date, end_date, room_id, reservation_id, restriction, created_at, updated_at, hold_token, expires_at, reason
+`
+
+func (q *Queries) DeleteExpiredRoomHolds(ctx context.Context) ([]RoomRestriction, error) {
+	rows, err := q.db.Query(ctx, deleteExpiredRoomHolds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []RoomRestriction{}
+	for rows.Next() {
+		var i RoomRestriction
+		if err := rows.Scan(
+			&i.ID,
+			&i.StartDate,
+			&i.EndDate,
+			&i.RoomID,
+			&i.ReservationID,
+			&i.Restriction,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.HoldToken,
+			&i.ExpiresAt,
+			&i.Reason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteOwnerBlocks = `-- name: DeleteOwnerBlocks :many
+DELETE FROM room_restrictions
+WHERE restriction = 'owner_block' AND id = ANY($1::bigint[])
+RETURNING id, start_date, end_date, room_id, reservation_id, restriction, created_at, updated_at, hold_token, expires_at, reason
+`
+
+func (q *Queries) DeleteOwnerBlocks(ctx context.Context, ids []int64) ([]RoomRestriction, error) {
+	rows, err := q.db.Query(ctx, deleteOwnerBlocks, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []RoomRestriction{}
+	for rows.Next() {
+		var i RoomRestriction
+		if err := rows.Scan(
+			&i.ID,
+			&i.StartDate,
+			&i.EndDate,
+			&i.RoomID,
+			&i.ReservationID,
+			&i.Restriction,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.HoldToken,
+			&i.ExpiresAt,
+			&i.Reason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteRoomHold = `-- name: DeleteRoomHold :exec
+DELETE FROM room_restrictions
+WHERE restriction = 'hold' AND room_id = $1 AND hold_token = $2
+`
+
+type DeleteRoomHoldParams struct {
+	RoomID    int64       `json:"room_id"`
+	HoldToken pgtype.Text `json:"hold_token"`
+}
+
+func (q *Queries) DeleteRoomHold(ctx context.Context, arg DeleteRoomHoldParams) error {
+	_, err := q.db.Exec(ctx, deleteRoomHold, arg.RoomID, arg.HoldToken)
+	return err
+}
+
+const deleteRoomHoldsByToken = `-- name: DeleteRoomHoldsByToken :exec
+DELETE FROM room_restrictions
+WHERE restriction = 'hold' AND hold_token = $1
+`
+
+func (q *Queries) DeleteRoomHoldsByToken(ctx context.Context, holdToken pgtype.Text) error {
+	_, err := q.db.Exec(ctx, deleteRoomHoldsByToken, holdToken)
+	return err
+}
+
 const deleteRoomRestriction = `-- name: DeleteRoomRestriction :exec
 DELETE FROM room_restrictions
 WHERE id = $1
@@ -69,8 +255,18 @@ func (q *Queries) DeleteRoomRestriction(ctx context.Context, id int64) error {
 	return err
 }
 
+const deleteRoomRestrictionsByReservationID = `-- name: DeleteRoomRestrictionsByReservationID :exec
+DELETE FROM room_restrictions
+WHERE reservation_id = $1
+`
+
+func (q *Queries) DeleteRoomRestrictionsByReservationID(ctx context.Context, reservationID pgtype.Int8) error {
+	_, err := q.db.Exec(ctx, deleteRoomRestrictionsByReservationID, reservationID)
+	return err
+}
+
 const getLastRoomRestriction = `-- name: GetLastRoomRestriction :one
-SELECT id, start_date, end_date, room_id, reservation_id, restriction, created_at, updated_at FROM room_restrictions
+SELECT id, start_date, end_date, room_id, reservation_id, restriction, created_at, updated_at, hold_token, expires_at, reason FROM room_restrictions
 WHERE room_id = $1 
 ORDER BY created_at DESC
 LIMIT 1
@@ -88,12 +284,39 @@ func (q *Queries) GetLastRoomRestriction(ctx context.Context, roomID int64) (Roo
 		&i.Restriction,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.HoldToken,
+		&i.ExpiresAt,
+		&i.Reason,
+	)
+	return i, err
+}
+
+const getOwnerBlock = `-- name: GetOwnerBlock :one
+SELECT id, start_date, end_date, room_id, reservation_id, restriction, created_at, updated_at, hold_token, expires_at, reason FROM room_restrictions
+WHERE restriction = 'owner_block' AND id = $1 LIMIT 1
+`
+
+func (q *Queries) GetOwnerBlock(ctx context.Context, id int64) (RoomRestriction, error) {
+	row := q.db.QueryRow(ctx, getOwnerBlock, id)
+	var i RoomRestriction
+	err := row.Scan(
+		&i.ID,
+		&i.StartDate,
+		&i.EndDate,
+		&i.RoomID,
+		&i.ReservationID,
+		&i.Restriction,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.HoldToken,
+		&i.ExpiresAt,
+		&i.Reason,
 	)
 	return i, err
 }
 
 const getRoomRestriction = `-- name: GetRoomRestriction :one
-SELECT id, start_date, end_date, room_id, reservation_id, restriction, created_at, updated_at FROM room_restrictions
+SELECT id, start_date, end_date, room_id, reservation_id, restriction, created_at, updated_at, hold_token, expires_at, reason FROM room_restrictions
 WHERE id = $1 LIMIT 1
 `
 
@@ -109,12 +332,124 @@ func (q *Queries) GetRoomRestriction(ctx context.Context, id int64) (RoomRestric
 		&i.Restriction,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.HoldToken,
+		&i.ExpiresAt,
+		&i.Reason,
 	)
 	return i, err
 }
 
+const listOwnerBlockConflicts = `-- name: ListOwnerBlockConflicts :many
+SELECT id, start_date, end_date, room_id, reservation_id, restriction, created_at, updated_at, hold_token, expires_at, reason FROM room_restrictions
+WHERE restriction = 'reservation' AND room_id = ANY($1::bigint[])
+AND end_date > $2::date AND start_date < $3::date
+ORDER BY room_id, start_date
+`
+
+type ListOwnerBlockConflictsParams struct {
+	RoomIds   []int64     `json:"room_ids"`
+	StartDate pgtype.Date `json:"start_date"`
+	EndDate   pgtype.Date `json:"end_date"`
+}
+
+func (q *Queries) ListOwnerBlockConflicts(ctx context.Context, arg ListOwnerBlockConflictsParams) ([]RoomRestriction, error) {
+	rows, err := q.db.Query(ctx, listOwnerBlockConflicts, arg.RoomIds, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []RoomRestriction{}
+	for rows.Next() {
+		var i RoomRestriction
+		if err := rows.Scan(
+			&i.ID,
+			&i.StartDate,
+			&i.EndDate,
+			&i.RoomID,
+			&i.ReservationID,
+			&i.Restriction,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.HoldToken,
+			&i.ExpiresAt,
+			&i.Reason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOwnerBlocksAndRooms = `-- name: ListOwnerBlocksAndRooms :many
+SELECT room_restrictions.id, room_restrictions.start_date, room_restrictions.end_date, room_restrictions.room_id, room_restrictions.reservation_id, room_restrictions.restriction, room_restrictions.created_at, room_restrictions.updated_at, room_restrictions.hold_token, room_restrictions.expires_at, room_restrictions.reason, rooms.id, rooms.name, rooms.description, rooms.image_filename, rooms.created_at, rooms.updated_at, rooms.max_adults, rooms.max_children, rooms.max_occupancy, rooms.slug, rooms.nightly_rate
+FROM room_restrictions
+JOIN rooms ON (room_restrictions.room_id = rooms.id)
+WHERE room_restrictions.restriction = 'owner_block' AND room_restrictions.end_date > $3::date
+ORDER BY room_restrictions.start_date, rooms.name
+LIMIT $1
+OFFSET $2
+`
+
+type ListOwnerBlocksAndRoomsParams struct {
+	Limit  int32       `json:"limit"`
+	Offset int32       `json:"offset"`
+	Date   pgtype.Date `json:"date"`
+}
+
+type ListOwnerBlocksAndRoomsRow struct {
+	RoomRestriction RoomRestriction `json:"room_restriction"`
+	Room            Room            `json:"room"`
+}
+
+func (q *Queries) ListOwnerBlocksAndRooms(ctx context.Context, arg ListOwnerBlocksAndRoomsParams) ([]ListOwnerBlocksAndRoomsRow, error) {
+	rows, err := q.db.Query(ctx, listOwnerBlocksAndRooms, arg.Limit, arg.Offset, arg.Date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListOwnerBlocksAndRoomsRow{}
+	for rows.Next() {
+		var i ListOwnerBlocksAndRoomsRow
+		if err := rows.Scan(
+			&i.RoomRestriction.ID,
+			&i.RoomRestriction.StartDate,
+			&i.RoomRestriction.EndDate,
+			&i.RoomRestriction.RoomID,
+			&i.RoomRestriction.ReservationID,
+			&i.RoomRestriction.Restriction,
+			&i.RoomRestriction.CreatedAt,
+			&i.RoomRestriction.UpdatedAt,
+			&i.RoomRestriction.HoldToken,
+			&i.RoomRestriction.ExpiresAt,
+			&i.RoomRestriction.Reason,
+			&i.Room.ID,
+			&i.Room.Name,
+			&i.Room.Description,
+			&i.Room.ImageFilename,
+			&i.Room.CreatedAt,
+			&i.Room.UpdatedAt,
+			&i.Room.MaxAdults,
+			&i.Room.MaxChildren,
+			&i.Room.MaxOccupancy,
+			&i.Room.Slug,
+			&i.Room.NightlyRate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listRoomRestrictions = `-- name: ListRoomRestrictions :many
-SELECT id, start_date, end_date, room_id, reservation_id, restriction, created_at, updated_at FROM room_restrictions
+SELECT id, start_date, end_date, room_id, reservation_id, restriction, created_at, updated_at, hold_token, expires_at, reason FROM room_restrictions
 ORDER BY room_id, start_date
 LIMIT $1
 OFFSET $2
@@ -143,6 +478,91 @@ func (q *Queries) ListRoomRestrictions(ctx context.Context, arg ListRoomRestrict
 			&i.Restriction,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.HoldToken,
+			&i.ExpiresAt,
+			&i.Reason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRoomRestrictionsByReservationID = `-- name: ListRoomRestrictionsByReservationID :many
+SELECT id, start_date, end_date, room_id, reservation_id, restriction, created_at, updated_at, hold_token, expires_at, reason FROM room_restrictions
+WHERE reservation_id = $1
+ORDER BY start_date
+`
+
+func (q *Queries) ListRoomRestrictionsByReservationID(ctx context.Context, reservationID pgtype.Int8) ([]RoomRestriction, error) {
+	rows, err := q.db.Query(ctx, listRoomRestrictionsByReservationID, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []RoomRestriction{}
+	for rows.Next() {
+		var i RoomRestriction
+		if err := rows.Scan(
+			&i.ID,
+			&i.StartDate,
+			&i.EndDate,
+			&i.RoomID,
+			&i.ReservationID,
+			&i.Restriction,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.HoldToken,
+			&i.ExpiresAt,
+			&i.Reason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRoomRestrictionsForPeriod = `-- name: ListRoomRestrictionsForPeriod :many
+SELECT id, start_date, end_date, room_id, reservation_id, restriction, created_at, updated_at, hold_token, expires_at, reason FROM room_restrictions
+WHERE end_date > $1::date AND start_date < $2::date
+AND restriction <> 'hold'
+ORDER BY room_id, start_date
+`
+
+type ListRoomRestrictionsForPeriodParams struct {
+	StartDate pgtype.Date `json:"start_date"`
+	EndDate   pgtype.Date `json:"end_date"`
+}
+
+func (q *Queries) ListRoomRestrictionsForPeriod(ctx context.Context, arg ListRoomRestrictionsForPeriodParams) ([]RoomRestriction, error) {
+	rows, err := q.db.Query(ctx, listRoomRestrictionsForPeriod, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []RoomRestriction{}
+	for rows.Next() {
+		var i RoomRestriction
+		if err := rows.Scan(
+			&i.ID,
+			&i.StartDate,
+			&i.EndDate,
+			&i.RoomID,
+			&i.ReservationID,
+			&i.Restriction,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.HoldToken,
+			&i.ExpiresAt,
+			&i.Reason,
 		); err != nil {
 			return nil, err
 		}
@@ -154,6 +574,68 @@ func (q *Queries) ListRoomRestrictions(ctx context.Context, arg ListRoomRestrict
 	return items, nil
 }
 
+const shortenRoomRestrictionsByReservationID = `-- name: ShortenRoomRestrictionsByReservationID :exec
+UPDATE room_restrictions
+  set   end_date = $1::date,
+        updated_at = now()
+WHERE reservation_id = $2 
+AND start_date < $1::date AND end_date > $1::date
+`
+
+type ShortenRoomRestrictionsByReservationIDParams struct {
+	EndDate       pgtype.Date `json:"end_date"`
+	ReservationID pgtype.Int8 `json:"reservation_id"`
+}
+
+func (q *Queries) ShortenRoomRestrictionsByReservationID(ctx context.Context, arg ShortenRoomRestrictionsByReservationIDParams) error {
+	_, err := q.db.Exec(ctx, shortenRoomRestrictionsByReservationID, arg.EndDate, arg.ReservationID)
+	return err
+}
+
+const updateOwnerBlock = `-- name: UpdateOwnerBlock :one
+UPDATE room_restrictions
+  set   start_date = $2,
+        end_date = $3,
+        room_id = $4,
+        reason = $5,
+        updated_at = now()
+WHERE restriction = 'owner_block' AND id = $1
+RETURNING id, start_date, end_date, room_id, reservation_id, restriction, created_at, updated_at, hold_token, expires_at, reason
+`
+
+type UpdateOwnerBlockParams struct {
+	ID        int64       `json:"id"`
+	StartDate pgtype.Date `json:"start_date"`
+	EndDate   pgtype.Date `json:"end_date"`
+	RoomID    int64       `json:"room_id"`
+	Reason    string      `json:"reason"`
+}
+
+func (q *Queries) UpdateOwnerBlock(ctx context.Context, arg UpdateOwnerBlockParams) (RoomRestriction, error) {
+	row := q.db.QueryRow(ctx, updateOwnerBlock,
+		arg.ID,
+		arg.StartDate,
+		arg.EndDate,
+		arg.RoomID,
+		arg.Reason,
+	)
+	var i RoomRestriction
+	err := row.Scan(
+		&i.ID,
+		&i.StartDate,
+		&i.EndDate,
+		&i.RoomID,
+		&i.ReservationID,
+		&i.Restriction,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.HoldToken,
+		&i.ExpiresAt,
+		&i.Reason,
+	)
+	return i, err
+}
+
 const updateRoomRestriction = `-- name: UpdateRoomRestriction :exec
 UPDATE room_restrictions
   set   start_date = $2,
@@ -187,3 +669,48 @@ func (q *Queries) UpdateRoomRestriction(ctx context.Context, arg UpdateRoomRestr
 	)
 	return err
 }
+
+const updateRoomRestrictionDatesByReservationID = `-- name: UpdateRoomRestrictionDatesByReservationID :exec
+UPDATE room_restrictions
+  set   start_date = $2,
+        end_date = $3,
+        updated_at = now()
+WHERE reservation_id = $1
+`
+
+type UpdateRoomRestrictionDatesByReservationIDParams struct {
+	ReservationID pgtype.Int8 `json:"reservation_id"`
+	StartDate     pgtype.Date `json:"start_date"`
+	EndDate       pgtype.Date `json:"end_date"`
+}
+
+func (q *Queries) UpdateRoomRestrictionDatesByReservationID(ctx context.Context, arg UpdateRoomRestrictionDatesByReservationIDParams) error {
+	_, err := q.db.Exec(ctx, updateRoomRestrictionDatesByReservationID, arg.ReservationID, arg.StartDate, arg.EndDate)
+	return err
+}
+
+const updateRoomRestrictionsByReservationID = `-- name: UpdateRoomRestrictionsByReservationID :exec
+UPDATE room_restrictions
+  set   room_id = $2,
+        start_date = $3,
+        end_date = $4,
+        updated_at = now()
+WHERE reservation_id = $1
+`
+
+type UpdateRoomRestrictionsByReservationIDParams struct {
+	ReservationID pgtype.Int8 `json:"reservation_id"`
+	RoomID        int64       `json:"room_id"`
+	StartDate     pgtype.Date `json:"start_date"`
+	EndDate       pgtype.Date `json:"end_date"`
+}
+
+func (q *Queries) UpdateRoomRestrictionsByReservationID(ctx context.Context, arg UpdateRoomRestrictionsByReservationIDParams) error {
+	_, err := q.db.Exec(ctx, updateRoomRestrictionsByReservationID,
+		arg.ReservationID,
+		arg.RoomID,
+		arg.StartDate,
+		arg.EndDate,
+	)
+	return err
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/github-real-lb/bookings-web-app/util"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -47,3 +48,120 @@ func TestQueries_CreateRoomRestriction(t *testing.T) {
 	reservation := createRandomReservation(t, room)
 	createRandomRoomRestriction(t, reservation)
 }
+
+func TestQueries_ListRoomRestrictionsForPeriod(t *testing.T) {
+	room := createRandomRoom(t)
+	startDate := util.RandomDate()
+	restriction := createRandomRoomRestriction(t, createRandomWeekReservation(t, room, startDate))
+
+	// holds are not listed
+	hold := CreateRoomHoldParams{RoomID: room.ID}
+	hold.StartDate.Scan(startDate.AddDate(0, 0, 7))
+	hold.EndDate.Scan(startDate.AddDate(0, 0, 9))
+	hold.HoldToken.Scan(util.RandomString(32))
+	hold.ExpiresAt.Scan(time.Now().Add(time.Hour))
+	_, err := testStore.CreateRoomHold(context.Background(), hold)
+	require.NoError(t, err)
+
+	// listRoomRestrictions returns the restrictions of room overlapping the period from startDate to endDate
+	listRoomRestrictions := func(startDate, endDate time.Time) []RoomRestriction {
+		arg := ListRoomRestrictionsForPeriodParams{}
+		arg.StartDate.Scan(startDate)
+		arg.EndDate.Scan(endDate)
+
+		restrictions, err := testStore.ListRoomRestrictionsForPeriod(context.Background(), arg)
+		require.NoError(t, err)
+
+		var roomRestrictions []RoomRestriction
+		for _, v := range restrictions {
+			if v.RoomID == room.ID {
+				roomRestrictions = append(roomRestrictions, v)
+			}
+		}
+
+		return roomRestrictions
+	}
+
+	// the period overlaps the last night of the reservation and the hold
+	restrictions := listRoomRestrictions(startDate.AddDate(0, 0, 6), startDate.AddDate(0, 0, 30))
+	require.Len(t, restrictions, 1)
+	assert.Equal(t, restriction.ID, restrictions[0].ID)
+
+	// the period starts on the departure date of the reservation
+	assert.Empty(t, listRoomRestrictions(startDate.AddDate(0, 0, 9), startDate.AddDate(0, 0, 30)))
+}
+
+// createRandomOwnerBlock creates an owner block of room in the database for the nights from startDate to endDate
+func createRandomOwnerBlock(t *testing.T, room Room, startDate, endDate time.Time) RoomRestriction {
+	arg := CreateOwnerBlockParams{
+		RoomID: room.ID,
+		Reason: util.RandomString(20),
+	}
+	arg.StartDate.Scan(startDate)
+	arg.EndDate.Scan(endDate)
+
+	block, err := testStore.CreateOwnerBlock(context.Background(), arg)
+	require.NoError(t, err)
+	assert.NotEmpty(t, block.ID)
+
+	assert.Equal(t, arg.StartDate, block.StartDate)
+	assert.Equal(t, arg.EndDate, block.EndDate)
+	assert.Equal(t, arg.RoomID, block.RoomID)
+	assert.Equal(t, arg.Reason, block.Reason)
+	assert.Equal(t, RestrictionOwnerBlock, block.Restriction)
+	assert.False(t, block.ReservationID.Valid)
+
+	return block
+}
+
+func TestQueries_ListOwnerBlocksAndRooms(t *testing.T) {
+	room := createRandomRoom(t)
+	startDate := util.RandomDate()
+	block := createRandomOwnerBlock(t, room, startDate, startDate.AddDate(0, 0, 3))
+	createRandomRoomRestriction(t, createRandomWeekReservation(t, room, startDate))
+
+	// listOwnerBlocks returns the owner blocks of room that end after date
+	listOwnerBlocks := func(date time.Time) []ListOwnerBlocksAndRoomsRow {
+		arg := ListOwnerBlocksAndRoomsParams{Limit: 1000}
+		arg.Date.Scan(date)
+
+		rows, err := testStore.ListOwnerBlocksAndRooms(context.Background(), arg)
+		require.NoError(t, err)
+
+		var roomRows []ListOwnerBlocksAndRoomsRow
+		for _, v := range rows {
+			if v.Room.ID == room.ID {
+				roomRows = append(roomRows, v)
+			}
+		}
+
+		return roomRows
+	}
+
+	rows := listOwnerBlocks(startDate.AddDate(0, 0, 2))
+	require.Len(t, rows, 1)
+	assert.Equal(t, block.ID, rows[0].RoomRestriction.ID)
+	assert.Equal(t, room.Name, rows[0].Room.Name)
+
+	// the block ended
+	assert.Empty(t, listOwnerBlocks(startDate.AddDate(0, 0, 3)))
+}
+
+func TestQueries_DeleteOwnerBlocks(t *testing.T) {
+	room := createRandomRoom(t)
+	startDate := util.RandomDate()
+	block := createRandomOwnerBlock(t, room, startDate, startDate.AddDate(0, 0, 3))
+	restriction := createRandomRoomRestriction(t, createRandomWeekReservation(t, room, startDate))
+
+	// only owner blocks are deleted
+	deleted, err := testStore.DeleteOwnerBlocks(context.Background(), []int64{block.ID, restriction.ID})
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.Equal(t, block.ID, deleted[0].ID)
+
+	_, err = testStore.GetOwnerBlock(context.Background(), block.ID)
+	require.Error(t, err)
+
+	_, err = testStore.GetRoomRestriction(context.Background(), restriction.ID)
+	require.NoError(t, err)
+}
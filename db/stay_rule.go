@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// StayRuleViolation is the part of a stay rule broken by a stay
+type StayRuleViolation string
+
+const (
+	ViolationMinNights         StayRuleViolation = "min_nights"
+	ViolationMaxNights         StayRuleViolation = "max_nights"
+	ViolationClosedToArrival   StayRuleViolation = "closed_to_arrival"
+	ViolationClosedToDeparture StayRuleViolation = "closed_to_departure"
+)
+
+// StayRuleError is returned when a stay breaks a stay rule of the room.
+// It wraps ErrStayRuleViolation.
+type StayRuleError struct {
+	Rule      StayRule
+	Violation StayRuleViolation
+}
+
+func (e *StayRuleError) Error() string {
+	return fmt.Sprintf("stay breaks the %s rule of stay rule %d", e.Violation, e.Rule.ID)
+}
+
+func (e *StayRuleError) Unwrap() error {
+	return ErrStayRuleViolation
+}
+
+// AppliesOn returns true if rule r applies to date.
+// A rule applies from its start date to its end date, both inclusive, on the days of week of its mask.
+func (r StayRule) AppliesOn(date time.Time) bool {
+	return appliesOn(r.StartDate, r.EndDate, r.DaysOfWeek, date)
+}
+
+// Check returns a StayRuleError if a stay from startDate to endDate breaks rule r.
+// The minimum and maximum nights and the closed to arrival rules apply to the arrival date,
+// and the closed to departure rule applies to the departure date.
+func (r StayRule) Check(startDate, endDate time.Time) error {
+	if r.AppliesOn(startDate) {
+		nights := int32(endDate.Sub(startDate).Hours() / 24)
+
+		switch {
+		case r.ClosedToArrival:
+			return &StayRuleError{Rule: r, Violation: ViolationClosedToArrival}
+		case r.MinNights > 0 && nights < r.MinNights:
+			return &StayRuleError{Rule: r, Violation: ViolationMinNights}
+		case r.MaxNights > 0 && nights > r.MaxNights:
+			return &StayRuleError{Rule: r, Violation: ViolationMaxNights}
+		}
+	}
+
+	if r.ClosedToDeparture && r.AppliesOn(endDate) {
+		return &StayRuleError{Rule: r, Violation: ViolationClosedToDeparture}
+	}
+
+	return nil
+}
+
+// CheckStayRulesParams contains the input parameters of CheckStayRules
+type CheckStayRulesParams struct {
+	RoomID    int64       `json:"room_id"`
+	StartDate pgtype.Date `json:"start_date"`
+	EndDate   pgtype.Date `json:"end_date"`
+}
+
+// CheckStayRules checks a stay in room arg.RoomID from arg.StartDate to arg.EndDate against the stay rules of the room.
+// It returns a StayRuleError for the first stay rule broken.
+func (q *Queries) CheckStayRules(ctx context.Context, arg CheckStayRulesParams) error {
+	rules, err := q.ListStayRulesForStay(ctx, ListStayRulesForStayParams{
+		RoomID:    arg.RoomID,
+		StartDate: arg.StartDate,
+		EndDate:   arg.EndDate,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		if err = rule.Check(arg.StartDate.Time, arg.EndDate.Time); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,243 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: stay_rule.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createStayRule = `-- name: CreateStayRule :one
+INSERT INTO stay_rules (
+  room_id, start_date, end_date, days_of_week, min_nights, max_nights, closed_to_arrival, closed_to_departure
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING id, room_id, start_date, end_date, days_of_week, min_nights, max_nights, closed_to_arrival, closed_to_departure, created_at, updated_at
+`
+
+type CreateStayRuleParams struct {
+	RoomID            int64       `json:"room_id"`
+	StartDate         pgtype.Date `json:"start_date"`
+	EndDate           pgtype.Date `json:"end_date"`
+	DaysOfWeek        int32       `json:"days_of_week"`
+	MinNights         int32       `json:"min_nights"`
+	MaxNights         int32       `json:"max_nights"`
+	ClosedToArrival   bool        `json:"closed_to_arrival"`
+	ClosedToDeparture bool        `json:"closed_to_departure"`
+}
+
+func (q *Queries) CreateStayRule(ctx context.Context, arg CreateStayRuleParams) (StayRule, error) {
+	row := q.db.QueryRow(ctx, createStayRule,
+		arg.RoomID,
+		arg.StartDate,
+		arg.EndDate,
+		arg.DaysOfWeek,
+		arg.MinNights,
+		arg.MaxNights,
+		arg.ClosedToArrival,
+		arg.ClosedToDeparture,
+	)
+	var i StayRule
+	err := row.Scan(
+		&i.ID,
+		&i.RoomID,
+		&i.StartDate,
+		&i.EndDate,
+		&i.DaysOfWeek,
+		&i.MinNights,
+		&i.MaxNights,
+		&i.ClosedToArrival,
+		&i.ClosedToDeparture,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteAllStayRules = `-- name: DeleteAllStayRules :exec
+DELETE FROM stay_rules
+`
+
+func (q *Queries) DeleteAllStayRules(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, deleteAllStayRules)
+	return err
+}
+
+const deleteStayRule = `-- name: DeleteStayRule :exec
+DELETE FROM stay_rules
+WHERE id = $1
+`
+
+func (q *Queries) DeleteStayRule(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, deleteStayRule, id)
+	return err
+}
+
+const getStayRule = `-- name: GetStayRule :one
+SELECT id, room_id, start_date, end_date, days_of_week, min_nights, max_nights, closed_to_arrival, closed_to_departure, created_at, updated_at FROM stay_rules
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetStayRule(ctx context.Context, id int64) (StayRule, error) {
+	row := q.db.QueryRow(ctx, getStayRule, id)
+	var i StayRule
+	err := row.Scan(
+		&i.ID,
+		&i.RoomID,
+		&i.StartDate,
+		&i.EndDate,
+		&i.DaysOfWeek,
+		&i.MinNights,
+		&i.MaxNights,
+		&i.ClosedToArrival,
+		&i.ClosedToDeparture,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listStayRulesForDates = `-- name: ListStayRulesForDates :many
+SELECT id, room_id, start_date, end_date, days_of_week, min_nights, max_nights, closed_to_arrival, closed_to_departure, created_at, updated_at FROM stay_rules
+WHERE start_date <= $1::date AND end_date >= $2::date
+ORDER BY room_id, id
+`
+
+type ListStayRulesForDatesParams struct {
+	EndDate   pgtype.Date `json:"end_date"`
+	StartDate pgtype.Date `json:"start_date"`
+}
+
+func (q *Queries) ListStayRulesForDates(ctx context.Context, arg ListStayRulesForDatesParams) ([]StayRule, error) {
+	rows, err := q.db.Query(ctx, listStayRulesForDates, arg.EndDate, arg.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []StayRule{}
+	for rows.Next() {
+		var i StayRule
+		if err := rows.Scan(
+			&i.ID,
+			&i.RoomID,
+			&i.StartDate,
+			&i.EndDate,
+			&i.DaysOfWeek,
+			&i.MinNights,
+			&i.MaxNights,
+			&i.ClosedToArrival,
+			&i.ClosedToDeparture,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStayRulesForStay = `-- name: ListStayRulesForStay :many
+SELECT id, room_id, start_date, end_date, days_of_week, min_nights, max_nights, closed_to_arrival, closed_to_departure, created_at, updated_at FROM stay_rules
+WHERE room_id = $1::bigint AND (start_date <= $2::date AND end_date >= $3::date)
+ORDER BY id
+`
+
+type ListStayRulesForStayParams struct {
+	RoomID    int64       `json:"room_id"`
+	EndDate   pgtype.Date `json:"end_date"`
+	StartDate pgtype.Date `json:"start_date"`
+}
+
+func (q *Queries) ListStayRulesForStay(ctx context.Context, arg ListStayRulesForStayParams) ([]StayRule, error) {
+	rows, err := q.db.Query(ctx, listStayRulesForStay, arg.RoomID, arg.EndDate, arg.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []StayRule{}
+	for rows.Next() {
+		var i StayRule
+		if err := rows.Scan(
+			&i.ID,
+			&i.RoomID,
+			&i.StartDate,
+			&i.EndDate,
+			&i.DaysOfWeek,
+			&i.MinNights,
+			&i.MaxNights,
+			&i.ClosedToArrival,
+			&i.ClosedToDeparture,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateStayRule = `-- name: UpdateStayRule :one
+UPDATE stay_rules
+  set   start_date = $2,
+        end_date = $3,
+        days_of_week = $4,
+        min_nights = $5,
+        max_nights = $6,
+        closed_to_arrival = $7,
+        closed_to_departure = $8,
+        updated_at = now()
+WHERE id = $1
+RETURNING id, room_id, start_date, end_date, days_of_week, min_nights, max_nights, closed_to_arrival, closed_to_departure, created_at, updated_at
+`
+
+type UpdateStayRuleParams struct {
+	ID                int64       `json:"id"`
+	StartDate         pgtype.Date `json:"start_date"`
+	EndDate           pgtype.Date `json:"end_date"`
+	DaysOfWeek        int32       `json:"days_of_week"`
+	MinNights         int32       `json:"min_nights"`
+	MaxNights         int32       `json:"max_nights"`
+	ClosedToArrival   bool        `json:"closed_to_arrival"`
+	ClosedToDeparture bool        `json:"closed_to_departure"`
+}
+
+func (q *Queries) UpdateStayRule(ctx context.Context, arg UpdateStayRuleParams) (StayRule, error) {
+	row := q.db.QueryRow(ctx, updateStayRule,
+		arg.ID,
+		arg.StartDate,
+		arg.EndDate,
+		arg.DaysOfWeek,
+		arg.MinNights,
+		arg.MaxNights,
+		arg.ClosedToArrival,
+		arg.ClosedToDeparture,
+	)
+	var i StayRule
+	err := row.Scan(
+		&i.ID,
+		&i.RoomID,
+		&i.StartDate,
+		&i.EndDate,
+		&i.DaysOfWeek,
+		&i.MinNights,
+		&i.MaxNights,
+		&i.ClosedToArrival,
+		&i.ClosedToDeparture,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
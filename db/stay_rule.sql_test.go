@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/bookings-web-app/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomStayRule creates a stay rule for room from startDate to endDate, on days of week mask, with minNights
+func createRandomStayRule(t *testing.T, room Room, startDate, endDate time.Time, daysOfWeek, minNights int32) StayRule {
+	arg := CreateStayRuleParams{
+		RoomID:     room.ID,
+		DaysOfWeek: daysOfWeek,
+		MinNights:  minNights,
+	}
+	arg.StartDate.Scan(startDate)
+	arg.EndDate.Scan(endDate)
+
+	sr, err := testStore.CreateStayRule(context.Background(), arg)
+	require.NoError(t, err)
+	assert.NotEmpty(t, sr.ID)
+	assert.Equal(t, arg.RoomID, sr.RoomID)
+	assert.Equal(t, arg.StartDate, sr.StartDate)
+	assert.Equal(t, arg.EndDate, sr.EndDate)
+	assert.Equal(t, arg.DaysOfWeek, sr.DaysOfWeek)
+	assert.Equal(t, arg.MinNights, sr.MinNights)
+	assert.Zero(t, sr.MaxNights)
+	assert.False(t, sr.ClosedToArrival)
+	assert.False(t, sr.ClosedToDeparture)
+	assert.WithinDuration(t, time.Now(), sr.CreatedAt.Time, time.Second)
+	assert.WithinDuration(t, time.Now(), sr.UpdatedAt.Time, time.Second)
+
+	return sr
+}
+
+func TestQueries_CreateStayRule(t *testing.T) {
+	rDate := util.RandomDate()
+	createRandomStayRule(t, createRandomRoom(t), rDate, rDate.AddDate(0, 0, 30), AllDaysOfWeek, 2)
+}
+
+func TestQueries_UpdateStayRule(t *testing.T) {
+	rDate := util.RandomDate()
+	sr := createRandomStayRule(t, createRandomRoom(t), rDate, rDate.AddDate(0, 0, 30), AllDaysOfWeek, 2)
+
+	arg := UpdateStayRuleParams{
+		ID:                sr.ID,
+		DaysOfWeek:        DaysOfWeekMask(time.Sunday),
+		MinNights:         0,
+		MaxNights:         14,
+		ClosedToArrival:   true,
+		ClosedToDeparture: true,
+	}
+	arg.StartDate.Scan(rDate.AddDate(0, 0, 1))
+	arg.EndDate.Scan(rDate.AddDate(0, 0, 10))
+
+	updated, err := testStore.UpdateStayRule(context.Background(), arg)
+	require.NoError(t, err)
+	assert.Equal(t, sr.ID, updated.ID)
+	assert.Equal(t, arg.StartDate, updated.StartDate)
+	assert.Equal(t, arg.EndDate, updated.EndDate)
+	assert.Equal(t, arg.DaysOfWeek, updated.DaysOfWeek)
+	assert.Equal(t, arg.MinNights, updated.MinNights)
+	assert.Equal(t, arg.MaxNights, updated.MaxNights)
+	assert.True(t, updated.ClosedToArrival)
+	assert.True(t, updated.ClosedToDeparture)
+}
+
+func TestQueries_ListStayRulesForStay(t *testing.T) {
+	room := createRandomRoom(t)
+	rDate := util.RandomDate()
+
+	// create rules covering the arrival date, the departure date, neither, and another room
+	arrival := createRandomStayRule(t, room, rDate.AddDate(0, 0, -10), rDate, AllDaysOfWeek, 2)
+	departure := createRandomStayRule(t, room, rDate.AddDate(0, 0, 7), rDate.AddDate(0, 0, 20), AllDaysOfWeek, 2)
+	createRandomStayRule(t, room, rDate.AddDate(0, 0, 8), rDate.AddDate(0, 0, 20), AllDaysOfWeek, 2)
+	createRandomStayRule(t, createRandomRoom(t), rDate, rDate.AddDate(0, 0, 7), AllDaysOfWeek, 2)
+
+	arg := ListStayRulesForStayParams{RoomID: room.ID}
+	arg.StartDate.Scan(rDate)
+	arg.EndDate.Scan(rDate.AddDate(0, 0, 7))
+
+	rules, err := testStore.ListStayRulesForStay(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, arrival.ID, rules[0].ID)
+	assert.Equal(t, departure.ID, rules[1].ID)
+}
+
+func TestQueries_ListStayRulesForDates(t *testing.T) {
+	room1 := createRandomRoom(t)
+	room2 := createRandomRoom(t)
+	rDate := util.RandomDate()
+
+	sr1 := createRandomStayRule(t, room1, rDate, rDate.AddDate(0, 0, 7), AllDaysOfWeek, 2)
+	sr2 := createRandomStayRule(t, room2, rDate, rDate.AddDate(0, 0, 7), AllDaysOfWeek, 2)
+	past := createRandomStayRule(t, room1, rDate.AddDate(0, 0, -20), rDate.AddDate(0, 0, -10), AllDaysOfWeek, 2)
+
+	arg := ListStayRulesForDatesParams{}
+	arg.StartDate.Scan(rDate)
+	arg.EndDate.Scan(rDate.AddDate(0, 0, 3))
+
+	rules, err := testStore.ListStayRulesForDates(context.Background(), arg)
+	require.NoError(t, err)
+
+	ids := map[int64]bool{}
+	for _, rule := range rules {
+		ids[rule.ID] = true
+	}
+	assert.True(t, ids[sr1.ID])
+	assert.True(t, ids[sr2.ID])
+	assert.False(t, ids[past.ID])
+}
+
+func TestQueries_CheckStayRules(t *testing.T) {
+	room := createRandomRoom(t)
+	rDate := util.RandomDate()
+	sr := createRandomStayRule(t, room, rDate, rDate.AddDate(0, 0, 30), AllDaysOfWeek, 3)
+
+	arg := CheckStayRulesParams{RoomID: room.ID}
+	arg.StartDate.Scan(rDate)
+	arg.EndDate.Scan(rDate.AddDate(0, 0, 2))
+
+	err := testStore.CheckStayRules(context.Background(), arg)
+	var ruleErr *StayRuleError
+	require.ErrorAs(t, err, &ruleErr)
+	assert.Equal(t, sr.ID, ruleErr.Rule.ID)
+	assert.Equal(t, ViolationMinNights, ruleErr.Violation)
+
+	arg.EndDate.Scan(rDate.AddDate(0, 0, 3))
+	assert.NoError(t, testStore.CheckStayRules(context.Background(), arg))
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStayRule_Check(t *testing.T) {
+	// 2024-06-07 is a Friday
+	friday := time.Date(2024, 6, 7, 0, 0, 0, 0, time.UTC)
+
+	// newRule returns a stay rule for June 2024 on days of week mask
+	newRule := func(daysOfWeek int32) StayRule {
+		return StayRule{
+			ID:         1,
+			StartDate:  pgtype.Date{Time: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+			EndDate:    pgtype.Date{Time: time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC), Valid: true},
+			DaysOfWeek: daysOfWeek,
+		}
+	}
+
+	// requireViolation asserts that err is a StayRuleError of violation
+	requireViolation := func(t *testing.T, err error, violation StayRuleViolation) {
+		require.ErrorIs(t, err, ErrStayRuleViolation)
+
+		var ruleErr *StayRuleError
+		require.ErrorAs(t, err, &ruleErr)
+		assert.Equal(t, violation, ruleErr.Violation)
+	}
+
+	t.Run("Min Nights", func(t *testing.T) {
+		rule := newRule(DaysOfWeekMask(time.Friday, time.Saturday))
+		rule.MinNights = 2
+
+		requireViolation(t, rule.Check(friday, friday.AddDate(0, 0, 1)), ViolationMinNights)
+		assert.NoError(t, rule.Check(friday, friday.AddDate(0, 0, 2)))
+
+		// the rule does not apply to arrivals on other days or dates
+		assert.NoError(t, rule.Check(friday.AddDate(0, 0, -1), friday))
+		assert.NoError(t, rule.Check(friday.AddDate(0, 0, 28), friday.AddDate(0, 0, 29)))
+	})
+
+	t.Run("Max Nights", func(t *testing.T) {
+		rule := newRule(AllDaysOfWeek)
+		rule.MaxNights = 7
+
+		requireViolation(t, rule.Check(friday, friday.AddDate(0, 0, 8)), ViolationMaxNights)
+		assert.NoError(t, rule.Check(friday, friday.AddDate(0, 0, 7)))
+	})
+
+	t.Run("Closed To Arrival", func(t *testing.T) {
+		sunday := friday.AddDate(0, 0, 2)
+		rule := newRule(DaysOfWeekMask(time.Sunday))
+		rule.ClosedToArrival = true
+
+		requireViolation(t, rule.Check(sunday, sunday.AddDate(0, 0, 3)), ViolationClosedToArrival)
+		assert.NoError(t, rule.Check(friday, sunday))
+	})
+
+	t.Run("Closed To Departure", func(t *testing.T) {
+		sunday := friday.AddDate(0, 0, 2)
+		rule := newRule(DaysOfWeekMask(time.Sunday))
+		rule.ClosedToDeparture = true
+
+		requireViolation(t, rule.Check(friday, sunday), ViolationClosedToDeparture)
+		assert.NoError(t, rule.Check(sunday, sunday.AddDate(0, 0, 3)))
+	})
+}
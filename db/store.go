@@ -13,8 +13,25 @@ import (
 type DatabaseStore interface {
 	Querier
 	AuthenticateUser(ctx context.Context, arg AuthenticateUserParams) (User, error)
+	CancelReservationTx(ctx context.Context, arg CancelReservationParams) (Reservation, error)
+	ChangeUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
+	CheckStayRules(ctx context.Context, arg CheckStayRulesParams) error
 	CreateNewUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateOwnerBlocksTx(ctx context.Context, arg CreateOwnerBlocksTxParams) ([]RoomRestriction, error)
+	CreateRefundTx(ctx context.Context, arg CreateRefundTxParams) (Refund, error)
 	CreateReservationTx(ctx context.Context, arg CreateReservationParams) (Reservation, error)
+	CreateReservationsTx(ctx context.Context, args []CreateReservationParams, holdToken string, promoCode string) ([]Reservation, error)
+	CreateRoomRatesTx(ctx context.Context, args []CreateRoomRateParams) ([]RoomRate, error)
+	CreateRoomHoldTx(ctx context.Context, arg CreateRoomHoldTxParams) (RoomRestriction, error)
+	DeleteRoomTx(ctx context.Context, id int64) (Room, error)
+	IssueInvoiceTx(ctx context.Context, arg IssueInvoiceTxParams) (Invoice, error)
+	NotifyWaitlistTx(ctx context.Context, arg NotifyWaitlistTxParams) ([]WaitlistEntry, error)
+	QuoteStay(ctx context.Context, arg QuoteStayParams) (Quote, error)
+	QuoteStays(ctx context.Context, args []QuoteStayParams) ([]Quote, error)
+	UpdateOwnerBlockTx(ctx context.Context, arg UpdateOwnerBlockTxParams) (RoomRestriction, error)
+	UpdateReservationDatesTx(ctx context.Context, arg UpdateReservationDatesParams) (Reservation, error)
+	UpdateReservationTx(ctx context.Context, arg UpdateReservationParams) (Reservation, error)
+	UpdateReservationStatusTx(ctx context.Context, arg UpdateReservationStatusTxParams) (Reservation, error)
 }
 
 // PostgresDBStore holds the database connections pool, and provides all functions
@@ -2,39 +2,864 @@ package db
 
 import (
 	"context"
+	"errors"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+// CancelReservationTx cancels a reservation and deletes its room restrictions in order to release the room.
+// It returns ErrReservationCancelled if the reservation was already cancelled,
+// and ErrInvalidStatusTransition if the guest already checked in or did not show up.
+func (store *PostgresDBStore) CancelReservationTx(ctx context.Context, arg CancelReservationParams) (Reservation, error) {
+	var reservation Reservation
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		// mark reservation as cancelled
+		reservation, err = q.CancelReservation(ctx, arg)
+		if errors.Is(err, pgx.ErrNoRows) {
+			// check if reservation exists in order to return the right error
+			reservation, err = q.GetReservation(ctx, arg.ID)
+			if err != nil {
+				return err
+			}
+			if reservation.Status == ReservationStatusCancelled {
+				return ErrReservationCancelled
+			}
+			return ErrInvalidStatusTransition
+		} else if err != nil {
+			return err
+		}
+
+		// release the room
+		return q.DeleteRoomRestrictionsByReservationID(ctx, pgtype.Int8{
+			Int64: reservation.ID,
+			Valid: true,
+		})
+	})
+
+	return reservation, err
+}
+
+// CreateReservationTx creates a reservation and its room restriction.
+// The total price of the reservation is quoted from the room rates and saved with the reservation,
+// along with its itemised taxes and fees, so that later rate and charge changes do not change it.
+// It is a single room CreateReservationsTx, and returns its errors.
 func (store *PostgresDBStore) CreateReservationTx(ctx context.Context, arg CreateReservationParams) (Reservation, error) {
+	reservations, err := store.CreateReservationsTx(ctx, []CreateReservationParams{arg}, "", "")
+	if err != nil {
+		return Reservation{}, err
+	}
+
+	return reservations[0], nil
+}
+
+// CreateReservationsTx creates several reservations and their room restrictions in a single transaction,
+// such as all rooms booked together under the same parent code.
+// Each room is locked and its availability and capacity checked before the reservation is created,
+// ignoring the room holds of holdToken, which are released once all reservations are created.
+// The total price of every reservation is quoted from the room rates, discounted by promoCode if not empty,
+// and saved with the reservation along with its itemised taxes and fees.
+// Per person charges are quoted for the adults and children of each reservation, the guests staying in its room.
+// The promo code is redeemed once for all reservations, and a fixed discount is split across them.
+// It returns ErrRoomUnavailable if any of the rooms is not available, a StayRuleError if any of the stays
+// breaks a stay rule of its room, or a PromoCodeError if the promo code does not apply to any of the stays,
+// in which case no reservation is created.
+func (store *PostgresDBStore) CreateReservationsTx(ctx context.Context, args []CreateReservationParams, holdToken string, promoCode string) ([]Reservation, error) {
+	reservations := make([]Reservation, len(args))
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// quote the price of the stays together, so that the promo code applies once to the booking
+		quoteArgs := make([]QuoteStayParams, len(args))
+		for i, arg := range args {
+			quoteArgs[i] = QuoteStayParams{
+				RoomID:    arg.RoomID,
+				StartDate: arg.StartDate,
+				EndDate:   arg.EndDate,
+				Adults:    arg.Adults,
+				Children:  arg.Children,
+				PromoCode: promoCode,
+			}
+		}
+
+		quotes, err := q.QuoteStays(ctx, quoteArgs)
+		if err != nil {
+			return err
+		}
+
+		for i, arg := range args {
+			// lock the room to prevent concurrent bookings of the same dates
+			_, err := q.GetRoomForUpdate(ctx, arg.RoomID)
+			if err != nil {
+				return err
+			}
+
+			err = q.CheckStayRules(ctx, CheckStayRulesParams{
+				RoomID:    arg.RoomID,
+				StartDate: arg.StartDate,
+				EndDate:   arg.EndDate,
+			})
+			if err != nil {
+				return err
+			}
+
+			available, err := q.CheckRoomAvailability(ctx, CheckRoomAvailabilityParams{
+				RoomID:    arg.RoomID,
+				StartDate: arg.StartDate,
+				EndDate:   arg.EndDate,
+				HoldToken: holdToken,
+				Adults:    arg.Adults,
+				Children:  arg.Children,
+			})
+			if err != nil {
+				return err
+			}
+
+			if !available {
+				return ErrRoomUnavailable
+			}
+
+			quote := quotes[i]
+			arg.TotalPrice = quote.Total
+			arg.PromoCodeID = quote.PromoCodeID
+			arg.Discount = quote.Discount
+
+			// insert new reservation into database
+			reservations[i], err = q.CreateReservation(ctx, arg)
+			if err != nil {
+				return err
+			}
+
+			err = q.createReservationCharges(ctx, reservations[i].ID, quote.Charges)
+			if err != nil {
+				return err
+			}
+
+			_, err = q.CreateRoomRestriction(ctx, CreateRoomRestrictionParams{
+				StartDate: reservations[i].StartDate,
+				EndDate:   reservations[i].EndDate,
+				RoomID:    reservations[i].RoomID,
+				ReservationID: pgtype.Int8{
+					Int64: reservations[i].ID,
+					Valid: true,
+				},
+				Restriction: RestrictionReservation,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// count the redemption of the promo code, unless it was used up by a concurrent booking
+		if len(quotes) > 0 && quotes[0].PromoCodeID.Valid {
+			_, err = q.RedeemPromoCode(ctx, quotes[0].PromoCodeID.Int64)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return &PromoCodeError{Code: promoCode, Rejection: RejectionUsedUp}
+			} else if err != nil {
+				return err
+			}
+		}
+
+		// release the rooms held during checkout
+		return q.DeleteRoomHoldsByToken(ctx, pgtype.Text{
+			String: holdToken,
+			Valid:  true,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+// CreateRoomRatesTx creates several room rates in a single transaction,
+// such as the same rate set for several rooms. All room rates are created, or none.
+func (store *PostgresDBStore) CreateRoomRatesTx(ctx context.Context, args []CreateRoomRateParams) ([]RoomRate, error) {
+	rates := make([]RoomRate, len(args))
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		for i, arg := range args {
+			var err error
+			rates[i], err = q.CreateRoomRate(ctx, arg)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+// CreateOwnerBlocksTxParams contains the input parameters of CreateOwnerBlocksTx
+type CreateOwnerBlocksTxParams struct {
+	RoomIDs   []int64     `json:"room_ids"`
+	StartDate pgtype.Date `json:"start_date"`
+	EndDate   pgtype.Date `json:"end_date"`
+	Reason    string      `json:"reason"`
+	// Override blocks the rooms even if they are reserved on some of the nights
+	Override bool `json:"override"`
+}
+
+// CreateOwnerBlocksTx blocks several rooms for the owner over the same nights in a single transaction.
+// The rooms are locked until the transaction ends. All owner blocks are created, or none.
+// It returns ErrOwnerBlockConflict if a room is reserved on some of the nights, unless arg.Override is set.
+func (store *PostgresDBStore) CreateOwnerBlocksTx(ctx context.Context, arg CreateOwnerBlocksTxParams) ([]RoomRestriction, error) {
+	blocks := make([]RoomRestriction, len(arg.RoomIDs))
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		err := checkOwnerBlockConflicts(ctx, q, arg.RoomIDs, arg.StartDate, arg.EndDate, arg.Override)
+		if err != nil {
+			return err
+		}
+
+		for i, roomID := range arg.RoomIDs {
+			blocks[i], err = q.CreateOwnerBlock(ctx, CreateOwnerBlockParams{
+				StartDate: arg.StartDate,
+				EndDate:   arg.EndDate,
+				RoomID:    roomID,
+				Reason:    arg.Reason,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
+
+// UpdateOwnerBlockTxParams contains the input parameters of UpdateOwnerBlockTx
+type UpdateOwnerBlockTxParams struct {
+	UpdateOwnerBlockParams
+	// Override blocks the room even if it is reserved on some of the nights
+	Override bool `json:"override"`
+}
+
+// UpdateOwnerBlockTx changes the room, nights and reason of an owner block.
+// The room is locked until the transaction ends.
+// It returns ErrOwnerBlockConflict if the room is reserved on some of the nights, unless arg.Override is set,
+// and pgx.ErrNoRows if the room restriction is not an owner block.
+func (store *PostgresDBStore) UpdateOwnerBlockTx(ctx context.Context, arg UpdateOwnerBlockTxParams) (RoomRestriction, error) {
+	var block RoomRestriction
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		err := checkOwnerBlockConflicts(ctx, q, []int64{arg.RoomID}, arg.StartDate, arg.EndDate, arg.Override)
+		if err != nil {
+			return err
+		}
+
+		block, err = q.UpdateOwnerBlock(ctx, arg.UpdateOwnerBlockParams)
+		return err
+	})
+
+	return block, err
+}
+
+// checkOwnerBlockConflicts locks the rooms and returns ErrOwnerBlockConflict
+// if any of them is reserved between startDate and endDate, unless override is set
+func checkOwnerBlockConflicts(ctx context.Context, q *Queries, roomIDs []int64, startDate, endDate pgtype.Date, override bool) error {
+	// lock the rooms to prevent concurrent reservations of the same nights
+	for _, roomID := range roomIDs {
+		_, err := q.GetRoomForUpdate(ctx, roomID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if override {
+		return nil
+	}
+
+	conflicts, err := q.ListOwnerBlockConflicts(ctx, ListOwnerBlockConflictsParams{
+		RoomIds:   roomIDs,
+		StartDate: startDate,
+		EndDate:   endDate,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(conflicts) > 0 {
+		return ErrOwnerBlockConflict
+	}
+
+	return nil
+}
+
+// CreateRefundTxParams contains the input parameters of CreateRefundTx
+type CreateRefundTxParams struct {
+	CreateRefundParams
+	// Payments are the refund transactions made through the payment provider for the refund
+	Payments []CreatePaymentParams `json:"payments"`
+}
+
+// CreateRefundTx records a refund of a reservation together with the refund transactions made for it.
+// The refund id is set on every payment, so that the refund is recorded with all its transactions or not at all.
+// The reservation is locked until the transaction ends, and ErrRefundExceedsPaid is returned
+// if the refund is more than the amount paid and not yet refunded.
+func (store *PostgresDBStore) CreateRefundTx(ctx context.Context, arg CreateRefundTxParams) (Refund, error) {
+	var refund Refund
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// lock the reservation to prevent concurrent refunds of the same payments
+		_, err := q.GetReservationForUpdate(ctx, arg.ReservationID)
+		if err != nil {
+			return err
+		}
+
+		paid, err := q.GetPaidAmount(ctx, arg.ReservationID)
+		if err != nil {
+			return err
+		}
+
+		if arg.Amount > paid {
+			return ErrRefundExceedsPaid
+		}
+
+		refund, err = q.CreateRefund(ctx, arg.CreateRefundParams)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range arg.Payments {
+			p.RefundID = pgtype.Int8{Int64: refund.ID, Valid: true}
+			_, err = q.CreatePayment(ctx, p)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return refund, err
+}
+
+// CreateRoomHoldTxParams contains the input parameters of CreateRoomHoldTx
+type CreateRoomHoldTxParams struct {
+	CreateRoomHoldParams
+	Adults   int32 `json:"adults"`
+	Children int32 `json:"children"`
+}
+
+// CreateRoomHoldTx holds a room for the guest identified by arg.HoldToken until arg.ExpiresAt.
+// The room is locked and its availability and capacity checked, ignoring the guest's own holds.
+// An existing hold of the guest on the room is replaced by the new one.
+// It returns ErrRoomUnavailable if the room is not available,
+// and a StayRuleError if the stay breaks a stay rule of the room.
+func (store *PostgresDBStore) CreateRoomHoldTx(ctx context.Context, arg CreateRoomHoldTxParams) (RoomRestriction, error) {
+	var hold RoomRestriction
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// lock the room to prevent concurrent holds of the same dates
+		_, err := q.GetRoomForUpdate(ctx, arg.RoomID)
+		if err != nil {
+			return err
+		}
+
+		err = q.CheckStayRules(ctx, CheckStayRulesParams{
+			RoomID:    arg.RoomID,
+			StartDate: arg.StartDate,
+			EndDate:   arg.EndDate,
+		})
+		if err != nil {
+			return err
+		}
+
+		available, err := q.CheckRoomAvailability(ctx, CheckRoomAvailabilityParams{
+			RoomID:    arg.RoomID,
+			StartDate: arg.StartDate,
+			EndDate:   arg.EndDate,
+			HoldToken: arg.HoldToken.String,
+			Adults:    arg.Adults,
+			Children:  arg.Children,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !available {
+			return ErrRoomUnavailable
+		}
+
+		// replace any previous hold of the guest on the room
+		err = q.DeleteRoomHold(ctx, DeleteRoomHoldParams{
+			RoomID:    arg.RoomID,
+			HoldToken: arg.HoldToken,
+		})
+		if err != nil {
+			return err
+		}
+
+		hold, err = q.CreateRoomHold(ctx, arg.CreateRoomHoldParams)
+		return err
+	})
+
+	return hold, err
+}
+
+// DeleteRoomTx deletes a room that was never booked together with its owner blocks, rates and stay rules.
+// The room is locked until the transaction ends.
+// It returns ErrRoomHasReservations if the room has any reservation, even cancelled or checked out,
+// as deleting it would delete the payments, refunds, folios and invoices of the reservation.
+// It returns the deleted room otherwise.
+func (store *PostgresDBStore) DeleteRoomTx(ctx context.Context, id int64) (Room, error) {
+	var room Room
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// lock the room to prevent concurrent reservations
+		var err error
+		room, err = q.GetRoomForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		count, err := q.CountReservationsByRoom(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if count > 0 {
+			return ErrRoomHasReservations
+		}
+
+		return q.DeleteRoom(ctx, id)
+	})
+
+	return room, err
+}
+
+// UpdateReservationDatesTx changes the dates of a reservation and of its room restrictions, as requested by the guest.
+// Only pending and confirmed reservations can be changed.
+// The room is locked until the transaction ends, and the room availability is checked
+// ignoring the reservation's own restrictions. The total price and the taxes and fees are quoted again
+// for the new dates, and discounted by the promo code of the reservation if any.
+// It returns ErrRoomUnavailable if the room is not available on the new dates,
+// a StayRuleError if the new dates break a stay rule of the room,
+// ErrReservationCancelled if the reservation was cancelled, and ErrReservationClosed if it is no longer pending or confirmed.
+func (store *PostgresDBStore) UpdateReservationDatesTx(ctx context.Context, arg UpdateReservationDatesParams) (Reservation, error) {
 	var reservation Reservation
 
 	err := store.execTx(ctx, func(q *Queries) error {
 		var err error
-		// insert new reservation into database
-		reservation, err = store.CreateReservation(ctx, arg)
+		reservation, err = q.GetReservation(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		if reservation.CancelledAt.Valid {
+			return ErrReservationCancelled
+		}
+
+		if reservation.Status != ReservationStatusPending && reservation.Status != ReservationStatusConfirmed {
+			return ErrReservationClosed
+		}
+
+		// lock the room to prevent concurrent bookings of the same dates
+		_, err = q.GetRoomForUpdate(ctx, reservation.RoomID)
+		if err != nil {
+			return err
+		}
+
+		err = q.CheckStayRules(ctx, CheckStayRulesParams{
+			RoomID:    reservation.RoomID,
+			StartDate: arg.StartDate,
+			EndDate:   arg.EndDate,
+		})
 		if err != nil {
 			return err
 		}
 
-		rrArg := CreateRoomRestrictionParams{
+		available, err := q.CheckRoomAvailabilityForReservation(ctx, CheckRoomAvailabilityForReservationParams{
+			RoomID:        reservation.RoomID,
+			StartDate:     arg.StartDate,
+			EndDate:       arg.EndDate,
+			ReservationID: reservation.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !available {
+			return ErrRoomUnavailable
+		}
+
+		// quote the price of the new dates, keeping the promo code of the reservation
+		quote, err := q.QuoteStay(ctx, QuoteStayParams{
+			RoomID:      reservation.RoomID,
+			StartDate:   arg.StartDate,
+			EndDate:     arg.EndDate,
+			Adults:      reservation.Adults,
+			Children:    reservation.Children,
+			PromoCodeID: reservation.PromoCodeID,
+		})
+		if err != nil {
+			return err
+		}
+		arg.Discount = quote.Discount
+		arg.TotalPrice = quote.Total
+
+		// update reservation dates, unless the status of the reservation changed concurrently
+		reservation, err = q.UpdateReservationDates(ctx, arg)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrReservationClosed
+		} else if err != nil {
+			return err
+		}
+
+		// replace the taxes and fees of the reservation
+		err = q.DeleteReservationCharges(ctx, reservation.ID)
+		if err != nil {
+			return err
+		}
+
+		err = q.createReservationCharges(ctx, reservation.ID, quote.Charges)
+		if err != nil {
+			return err
+		}
+
+		// update room restriction dates
+		return q.UpdateRoomRestrictionDatesByReservationID(ctx, UpdateRoomRestrictionDatesByReservationIDParams{
+			ReservationID: pgtype.Int8{
+				Int64: reservation.ID,
+				Valid: true,
+			},
 			StartDate: reservation.StartDate,
 			EndDate:   reservation.EndDate,
-			RoomID:    reservation.RoomID,
+		})
+	})
+
+	return reservation, err
+}
+
+// UpdateReservationTx updates a reservation and moves its room restrictions to the room and dates of the reservation.
+// If the room, dates or guests of the stay change, the room is locked until the transaction ends,
+// its availability and capacity are checked ignoring the reservation's own restrictions,
+// and the total price and the taxes and fees are quoted again, discounted by the promo code of the reservation if any.
+// Otherwise the price of the reservation is kept. Stay rules are not checked, so that staff can make exceptions.
+// Only pending, confirmed and checked in reservations can be changed.
+// It returns ErrRoomUnavailable if the room is not available or does not fit the guests,
+// ErrReservationCancelled if the reservation was cancelled, and ErrReservationClosed if it was checked out
+// or marked as a no show.
+func (store *PostgresDBStore) UpdateReservationTx(ctx context.Context, arg UpdateReservationParams) (Reservation, error) {
+	var reservation Reservation
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		current, err := q.GetReservationForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		if current.CancelledAt.Valid {
+			return ErrReservationCancelled
+		}
+
+		if current.Status == ReservationStatusCheckedOut || current.Status == ReservationStatusNoShow {
+			return ErrReservationClosed
+		}
+
+		arg.TotalPrice = current.TotalPrice
+		arg.Discount = current.Discount
+
+		var quote Quote
+		stayChanged := arg.RoomID != current.RoomID ||
+			!arg.StartDate.Time.Equal(current.StartDate.Time) || !arg.EndDate.Time.Equal(current.EndDate.Time) ||
+			arg.Adults != current.Adults || arg.Children != current.Children
+
+		if stayChanged {
+			// lock the room to prevent concurrent bookings of the same dates
+			room, err := q.GetRoomForUpdate(ctx, arg.RoomID)
+			if err != nil {
+				return err
+			}
+
+			if arg.Adults > room.MaxAdults || arg.Children > room.MaxChildren ||
+				arg.Adults+arg.Children > room.MaxOccupancy {
+				return ErrRoomUnavailable
+			}
+
+			available, err := q.CheckRoomAvailabilityForReservation(ctx, CheckRoomAvailabilityForReservationParams{
+				RoomID:        arg.RoomID,
+				StartDate:     arg.StartDate,
+				EndDate:       arg.EndDate,
+				ReservationID: current.ID,
+			})
+			if err != nil {
+				return err
+			}
+
+			if !available {
+				return ErrRoomUnavailable
+			}
+
+			// quote the price of the new stay, keeping the promo code of the reservation
+			quote, err = q.QuoteStay(ctx, QuoteStayParams{
+				RoomID:      arg.RoomID,
+				StartDate:   arg.StartDate,
+				EndDate:     arg.EndDate,
+				Adults:      arg.Adults,
+				Children:    arg.Children,
+				PromoCodeID: current.PromoCodeID,
+			})
+			if err != nil {
+				return err
+			}
+			arg.Discount = quote.Discount
+			arg.TotalPrice = quote.Total
+		}
+
+		reservation, err = q.UpdateReservation(ctx, arg)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrReservationClosed
+		} else if err != nil {
+			return err
+		}
+
+		if !stayChanged {
+			return nil
+		}
+
+		// replace the taxes and fees of the reservation
+		err = q.DeleteReservationCharges(ctx, reservation.ID)
+		if err != nil {
+			return err
+		}
+
+		err = q.createReservationCharges(ctx, reservation.ID, quote.Charges)
+		if err != nil {
+			return err
+		}
+
+		// move the room restrictions to the room and dates of the reservation
+		return q.UpdateRoomRestrictionsByReservationID(ctx, UpdateRoomRestrictionsByReservationIDParams{
 			ReservationID: pgtype.Int8{
 				Int64: reservation.ID,
 				Valid: true,
 			},
-			Restriction: RestrictionReservation,
+			RoomID:    reservation.RoomID,
+			StartDate: reservation.StartDate,
+			EndDate:   reservation.EndDate,
+		})
+	})
+
+	return reservation, err
+}
+
+// IssueInvoiceTxParams contains the input parameters of IssueInvoiceTx
+type IssueInvoiceTxParams struct {
+	Property      string `json:"property"`
+	ReservationID int64  `json:"reservation_id"`
+}
+
+// IssueInvoiceTx returns the invoice of reservation arg.ReservationID,
+// issuing it with the next invoice number of arg.Property if the reservation has no invoice yet.
+// The invoice sequence of the property stays locked until the invoice is created,
+// so that invoice numbers are sequential and a number is never skipped.
+func (store *PostgresDBStore) IssueInvoiceTx(ctx context.Context, arg IssueInvoiceTxParams) (Invoice, error) {
+	var invoice Invoice
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		invoice, err = q.GetInvoiceByReservation(ctx, arg.ReservationID)
+		if err == nil || !errors.Is(err, pgx.ErrNoRows) {
+			return err
 		}
 
-		_, err = store.CreateRoomRestriction(ctx, rrArg)
+		number, err := q.NextInvoiceNumber(ctx, arg.Property)
+		if err != nil {
+			return err
+		}
+
+		invoice, err = q.CreateInvoice(ctx, CreateInvoiceParams{
+			Property:      arg.Property,
+			Number:        number,
+			ReservationID: arg.ReservationID,
+		})
+		return err
+	})
+
+	return invoice, err
+}
+
+// NotifyWaitlistTxParams contains the input parameters of NotifyWaitlistTx
+type NotifyWaitlistTxParams struct {
+	RoomID    int64              `json:"room_id"`
+	StartDate pgtype.Date        `json:"start_date"`
+	EndDate   pgtype.Date        `json:"end_date"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+// NotifyWaitlistTx offers a room freed between arg.StartDate and arg.EndDate to the guests waiting for it,
+// in the order they joined the waitlist.
+// A guest is offered the room only if it is available and fits the guests on all the dates requested,
+// and the stay requested does not break a stay rule of the room,
+// in which case the room is held for the guest until arg.ExpiresAt.
+// It returns the waitlist entries of the guests offered the room.
+func (store *PostgresDBStore) NotifyWaitlistTx(ctx context.Context, arg NotifyWaitlistTxParams) ([]WaitlistEntry, error) {
+	offered := []WaitlistEntry{}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// lock the room to prevent concurrent bookings of the same dates
+		_, err := q.GetRoomForUpdate(ctx, arg.RoomID)
 		if err != nil {
 			return err
 		}
 
+		entries, err := q.ListWaitlistEntriesForRoom(ctx, ListWaitlistEntriesForRoomParams{
+			RoomID:    arg.RoomID,
+			StartDate: arg.StartDate,
+			EndDate:   arg.EndDate,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			// the guest could not book a stay breaking a stay rule of the room
+			err = q.CheckStayRules(ctx, CheckStayRulesParams{
+				RoomID:    arg.RoomID,
+				StartDate: entry.StartDate,
+				EndDate:   entry.EndDate,
+			})
+			if errors.Is(err, ErrStayRuleViolation) {
+				continue
+			} else if err != nil {
+				return err
+			}
+
+			available, err := q.CheckRoomAvailability(ctx, CheckRoomAvailabilityParams{
+				RoomID:    arg.RoomID,
+				StartDate: entry.StartDate,
+				EndDate:   entry.EndDate,
+				Adults:    entry.Adults,
+				Children:  entry.Children,
+			})
+			if err != nil {
+				return err
+			}
+
+			if !available {
+				continue
+			}
+
+			// hold the room for the guest, using the waitlist token as the hold token
+			_, err = q.CreateRoomHold(ctx, CreateRoomHoldParams{
+				StartDate: entry.StartDate,
+				EndDate:   entry.EndDate,
+				RoomID:    arg.RoomID,
+				HoldToken: pgtype.Text{
+					String: entry.Token,
+					Valid:  true,
+				},
+				ExpiresAt: arg.ExpiresAt,
+			})
+			if err != nil {
+				return err
+			}
+
+			entry, err = q.UpdateWaitlistEntryOffer(ctx, UpdateWaitlistEntryOfferParams{
+				ID: entry.ID,
+				OfferedRoomID: pgtype.Int8{
+					Int64: arg.RoomID,
+					Valid: true,
+				},
+				ExpiresAt: arg.ExpiresAt,
+			})
+			if err != nil {
+				return err
+			}
+
+			offered = append(offered, entry)
+		}
+
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	return offered, nil
+}
+
+// UpdateReservationStatusTxParams contains the input parameters of UpdateReservationStatusTx
+type UpdateReservationStatusTxParams struct {
+	UpdateReservationStatusParams
+	// Date is the date of the transition, on which a guest checking out early releases the remaining nights
+	Date pgtype.Date `json:"date"`
+}
+
+// UpdateReservationStatusTx moves a reservation to arg.Status and stamps the time of the transition.
+// It returns ErrInvalidStatusTransition if the reservation cannot move from its current status to arg.Status,
+// ErrEarlyCheckIn if the guest is checked in before the arrival date,
+// and a FolioBalanceError if the guest is checked out with an unsettled folio balance.
+// The room restrictions of a reservation cancelled or marked as no-show are deleted in order to release the room,
+// and the room restrictions of a guest checking out before the departure date are shortened to arg.Date.
+func (store *PostgresDBStore) UpdateReservationStatusTx(ctx context.Context, arg UpdateReservationStatusTxParams) (Reservation, error) {
+	var reservation Reservation
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// lock the reservation to prevent concurrent status changes
+		current, err := q.GetReservationForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		if !current.Status.CanTransitionTo(arg.Status) {
+			return ErrInvalidStatusTransition
+		}
+
+		if arg.Status == ReservationStatusCheckedIn && arg.Date.Time.Before(current.StartDate.Time) {
+			return ErrEarlyCheckIn
+		}
+
+		if arg.Status == ReservationStatusCheckedOut {
+			// the lock holds back the folio entries posted meanwhile, as they reference the reservation
+			balance, err := q.GetFolioBalance(ctx, arg.ID)
+			if err != nil {
+				return err
+			}
+
+			if balance > 0 {
+				return &FolioBalanceError{Balance: balance}
+			}
+		}
+
+		reservation, err = q.UpdateReservationStatus(ctx, arg.UpdateReservationStatusParams)
+		if err != nil {
+			return err
+		}
+
+		reservationID := pgtype.Int8{
+			Int64: reservation.ID,
+			Valid: true,
+		}
+
+		switch arg.Status {
+		case ReservationStatusCancelled, ReservationStatusNoShow:
+			// release the room
+			return q.DeleteRoomRestrictionsByReservationID(ctx, reservationID)
+		case ReservationStatusCheckedOut:
+			// release the remaining nights
+			return q.ShortenRoomRestrictionsByReservationID(ctx, ShortenRoomRestrictionsByReservationIDParams{
+				EndDate:       arg.Date,
+				ReservationID: reservationID,
+			})
+		default:
+			return nil
+		}
+	})
 
 	return reservation, err
 }
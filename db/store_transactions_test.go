@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/github-real-lb/bookings-web-app/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -21,6 +23,7 @@ func TestStore_CreateReservationTx(t *testing.T) {
 			LastName:  util.RandomName(),
 			Email:     util.RandomEmail(),
 			RoomID:    room.ID,
+			Adults:    1,
 		}
 		arg.Phone.Scan(util.RandomPhone())
 		arg.StartDate.Scan(rDate)
@@ -41,6 +44,7 @@ func TestStore_CreateReservationTx(t *testing.T) {
 		assert.Equal(t, arg.EndDate, rsv.EndDate)
 		assert.Equal(t, arg.RoomID, rsv.RoomID)
 		assert.Equal(t, arg.Notes, rsv.Notes)
+		assert.Equal(t, 7*room.NightlyRate, rsv.TotalPrice)
 		assert.WithinDuration(t, time.Now(), rsv.CreatedAt.Time, time.Second)
 		assert.True(t, rsv.CreatedAt.Valid)
 		assert.WithinDuration(t, time.Now(), rsv.UpdatedAt.Time, time.Second)
@@ -75,3 +79,1201 @@ func TestStore_CreateReservationTx(t *testing.T) {
 		require.Empty(t, rsv)
 	})
 }
+
+// createRandomReservationTx creates a reservation with its room restriction in room
+func createRandomReservationTx(t *testing.T, room Room, startDate time.Time) Reservation {
+	arg := CreateReservationParams{
+		Code:      util.RandomString(ReservationCodeLenght),
+		FirstName: util.RandomName(),
+		LastName:  util.RandomName(),
+		Email:     util.RandomEmail(),
+		RoomID:    room.ID,
+		Adults:    1,
+	}
+	arg.StartDate.Scan(startDate)
+	arg.EndDate.Scan(startDate.Add(time.Hour * 24 * 7))
+
+	rsv, err := testStore.CreateReservationTx(context.Background(), arg)
+	require.NoError(t, err)
+	return rsv
+}
+
+func TestStore_CancelReservationTx(t *testing.T) {
+	t.Run("Test OK", func(t *testing.T) {
+		rsv := createRandomReservationTx(t, createRandomRoom(t), util.RandomDate())
+
+		arg := CancelReservationParams{
+			ID:                     rsv.ID,
+			CancellationFeePercent: 50,
+		}
+		arg.CancelledBy.Scan("guest")
+
+		// execute transaction
+		cancelled, err := testStore.CancelReservationTx(context.Background(), arg)
+
+		// testify reservation
+		require.NoError(t, err)
+		assert.Equal(t, rsv.ID, cancelled.ID)
+		assert.WithinDuration(t, time.Now(), cancelled.CancelledAt.Time, time.Second)
+		assert.True(t, cancelled.CancelledAt.Valid)
+		assert.Equal(t, arg.CancelledBy, cancelled.CancelledBy)
+		assert.Equal(t, arg.CancellationFeePercent, cancelled.CancellationFeePercent)
+		assert.Equal(t, ReservationStatusCancelled, cancelled.Status)
+
+		// testify room is released
+		_, err = testStore.GetLastRoomRestriction(context.Background(), rsv.RoomID)
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+		// testify reservation cannot be cancelled twice
+		_, err = testStore.CancelReservationTx(context.Background(), arg)
+		assert.ErrorIs(t, err, ErrReservationCancelled)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		arg := CancelReservationParams{ID: 0}
+
+		// execute transaction
+		rsv, err := testStore.CancelReservationTx(context.Background(), arg)
+
+		//testify
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		require.Empty(t, rsv)
+	})
+}
+
+func TestStore_UpdateReservationStatusTx(t *testing.T) {
+	// newArg returns the arguments moving reservation rsv to status on date
+	newArg := func(rsv Reservation, status ReservationStatus, date time.Time) UpdateReservationStatusTxParams {
+		arg := UpdateReservationStatusTxParams{
+			UpdateReservationStatusParams: UpdateReservationStatusParams{
+				ID:     rsv.ID,
+				Status: status,
+			},
+		}
+		arg.Date.Scan(date)
+
+		return arg
+	}
+
+	t.Run("Test OK", func(t *testing.T) {
+		rsv := createRandomReservationTx(t, createRandomRoom(t), util.RandomDate())
+		require.Equal(t, ReservationStatusPending, rsv.Status)
+		endDate := rsv.EndDate
+
+		// move the reservation through its lifecycle
+		for _, status := range []ReservationStatus{
+			ReservationStatusConfirmed,
+			ReservationStatusCheckedIn,
+			ReservationStatusCheckedOut,
+		} {
+			updated, err := testStore.UpdateReservationStatusTx(context.Background(), newArg(rsv, status, endDate.Time))
+			require.NoError(t, err)
+			assert.Equal(t, status, updated.Status)
+			rsv = updated
+		}
+
+		// testify the time of every transition is stamped
+		assert.True(t, rsv.ConfirmedAt.Valid)
+		assert.True(t, rsv.CheckedInAt.Valid)
+		assert.True(t, rsv.CheckedOutAt.Valid)
+		assert.False(t, rsv.NoShowAt.Valid)
+		assert.False(t, rsv.CancelledAt.Valid)
+
+		// testify the room is still booked for the whole stay
+		rr, err := testStore.GetLastRoomRestriction(context.Background(), rsv.RoomID)
+		require.NoError(t, err)
+		assert.Equal(t, rsv.ID, rr.ReservationID.Int64)
+		assert.Equal(t, endDate, rr.EndDate)
+	})
+
+	t.Run("Test Early Check-Out Releases Nights", func(t *testing.T) {
+		startDate := util.RandomDate()
+		rsv := createRandomReservationTx(t, createRandomRoom(t), startDate)
+		checkOutDate := startDate.Add(time.Hour * 24 * 2)
+
+		for _, status := range []ReservationStatus{
+			ReservationStatusConfirmed,
+			ReservationStatusCheckedIn,
+			ReservationStatusCheckedOut,
+		} {
+			_, err := testStore.UpdateReservationStatusTx(context.Background(), newArg(rsv, status, checkOutDate))
+			require.NoError(t, err)
+		}
+
+		// testify the room restriction ends on the check-out date
+		rr, err := testStore.GetLastRoomRestriction(context.Background(), rsv.RoomID)
+		require.NoError(t, err)
+		assert.Equal(t, rsv.ID, rr.ReservationID.Int64)
+		assert.True(t, rr.EndDate.Time.Equal(checkOutDate))
+
+		// testify the reservation keeps the dates booked
+		result, err := testStore.GetReservation(context.Background(), rsv.ID)
+		require.NoError(t, err)
+		assert.Equal(t, rsv.EndDate, result.EndDate)
+	})
+
+	t.Run("Test No Show Releases Room", func(t *testing.T) {
+		rsv := createRandomReservationTx(t, createRandomRoom(t), util.RandomDate())
+
+		for _, status := range []ReservationStatus{ReservationStatusConfirmed, ReservationStatusNoShow} {
+			_, err := testStore.UpdateReservationStatusTx(context.Background(), newArg(rsv, status, rsv.StartDate.Time))
+			require.NoError(t, err)
+		}
+
+		_, err := testStore.GetLastRoomRestriction(context.Background(), rsv.RoomID)
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("Test Invalid Transition", func(t *testing.T) {
+		rsv := createRandomReservationTx(t, createRandomRoom(t), util.RandomDate())
+
+		// a pending reservation cannot be checked in
+		_, err := testStore.UpdateReservationStatusTx(context.Background(), newArg(rsv, ReservationStatusCheckedIn, rsv.StartDate.Time))
+		require.ErrorIs(t, err, ErrInvalidStatusTransition)
+
+		result, err := testStore.GetReservation(context.Background(), rsv.ID)
+		require.NoError(t, err)
+		assert.Equal(t, ReservationStatusPending, result.Status)
+	})
+
+	t.Run("Test Early Check-In", func(t *testing.T) {
+		rsv := createRandomReservationTx(t, createRandomRoom(t), util.RandomDate())
+		dayBefore := rsv.StartDate.Time.AddDate(0, 0, -1)
+
+		_, err := testStore.UpdateReservationStatusTx(context.Background(), newArg(rsv, ReservationStatusConfirmed, dayBefore))
+		require.NoError(t, err)
+
+		// a guest cannot be checked in before the arrival date
+		_, err = testStore.UpdateReservationStatusTx(context.Background(), newArg(rsv, ReservationStatusCheckedIn, dayBefore))
+		require.ErrorIs(t, err, ErrEarlyCheckIn)
+
+		result, err := testStore.GetReservation(context.Background(), rsv.ID)
+		require.NoError(t, err)
+		assert.Equal(t, ReservationStatusConfirmed, result.Status)
+	})
+
+	t.Run("Test Unsettled Folio", func(t *testing.T) {
+		rsv := createRandomReservationTx(t, createRandomRoom(t), util.RandomDate())
+		user := createRandomUser(t, util.RandomPassword())
+
+		for _, status := range []ReservationStatus{ReservationStatusConfirmed, ReservationStatusCheckedIn} {
+			_, err := testStore.UpdateReservationStatusTx(context.Background(), newArg(rsv, status, rsv.StartDate.Time))
+			require.NoError(t, err)
+		}
+
+		// a guest owing a balance on the folio cannot be checked out
+		createRandomFolioEntry(t, rsv, user, FolioEntryKindCharge, 2400)
+		createRandomFolioEntry(t, rsv, user, FolioEntryKindPayment, 1000)
+
+		_, err := testStore.UpdateReservationStatusTx(context.Background(), newArg(rsv, ReservationStatusCheckedOut, rsv.EndDate.Time))
+		require.ErrorIs(t, err, ErrFolioUnsettled)
+		var folioErr *FolioBalanceError
+		require.ErrorAs(t, err, &folioErr)
+		assert.Equal(t, int64(1400), folioErr.Balance)
+
+		result, err := testStore.GetReservation(context.Background(), rsv.ID)
+		require.NoError(t, err)
+		assert.Equal(t, ReservationStatusCheckedIn, result.Status)
+
+		// a guest with a credit on the folio is checked out
+		createRandomFolioEntry(t, rsv, user, FolioEntryKindPayment, 2000)
+
+		updated, err := testStore.UpdateReservationStatusTx(context.Background(), newArg(rsv, ReservationStatusCheckedOut, rsv.EndDate.Time))
+		require.NoError(t, err)
+		assert.Equal(t, ReservationStatusCheckedOut, updated.Status)
+	})
+}
+
+func TestStore_UpdateReservationDatesTx(t *testing.T) {
+	t.Run("Test OK", func(t *testing.T) {
+		rsv := createRandomReservationTx(t, createRandomRoom(t), util.RandomDate())
+
+		// shift the stay by one day, overlapping the current dates of the reservation
+		arg := UpdateReservationDatesParams{ID: rsv.ID}
+		arg.StartDate.Scan(rsv.StartDate.Time.AddDate(0, 0, 1))
+		arg.EndDate.Scan(rsv.EndDate.Time.AddDate(0, 0, 1))
+
+		// execute transaction
+		updated, err := testStore.UpdateReservationDatesTx(context.Background(), arg)
+
+		// testify reservation
+		require.NoError(t, err)
+		assert.Equal(t, rsv.ID, updated.ID)
+		assert.Equal(t, arg.StartDate, updated.StartDate)
+		assert.Equal(t, arg.EndDate, updated.EndDate)
+		assert.Equal(t, rsv.TotalPrice, updated.TotalPrice)
+
+		// testify room restriction
+		rr, err := testStore.GetLastRoomRestriction(context.Background(), rsv.RoomID)
+		require.NoError(t, err)
+		assert.Equal(t, rsv.ID, rr.ReservationID.Int64)
+		assert.WithinDuration(t, arg.StartDate.Time, rr.StartDate.Time, time.Second)
+		assert.WithinDuration(t, arg.EndDate.Time, rr.EndDate.Time, time.Second)
+	})
+
+	t.Run("Test Charges", func(t *testing.T) {
+		createRandomCharge(t, ChargeKindPerNight, 500)
+		rsv := createRandomReservationTx(t, createRandomRoom(t), util.RandomDate())
+
+		// extend the stay by one night
+		arg := UpdateReservationDatesParams{ID: rsv.ID}
+		arg.StartDate = rsv.StartDate
+		arg.EndDate.Scan(rsv.EndDate.Time.AddDate(0, 0, 1))
+
+		// execute transaction
+		_, err := testStore.UpdateReservationDatesTx(context.Background(), arg)
+		require.NoError(t, err)
+
+		// testify the charges of the reservation were replaced
+		charges, err := testStore.ListReservationCharges(context.Background(), rsv.ID)
+		require.NoError(t, err)
+		require.Len(t, charges, 1)
+		nights := int64(arg.EndDate.Time.Sub(arg.StartDate.Time).Hours() / 24)
+		assert.Equal(t, 500*nights, charges[0].Amount)
+	})
+
+	t.Run("Test Room Unavailable", func(t *testing.T) {
+		room := createRandomRoom(t)
+		rDate := util.RandomDate()
+		rsv := createRandomReservationTx(t, room, rDate)
+		other := createRandomReservationTx(t, room, rDate.AddDate(0, 0, 7))
+
+		// move the reservation onto the dates of the other reservation
+		arg := UpdateReservationDatesParams{ID: rsv.ID}
+		arg.StartDate = other.StartDate
+		arg.EndDate = other.EndDate
+
+		// execute transaction
+		_, err := testStore.UpdateReservationDatesTx(context.Background(), arg)
+		require.ErrorIs(t, err, ErrRoomUnavailable)
+
+		// testify the original reservation is untouched
+		original, err := testStore.GetReservation(context.Background(), rsv.ID)
+		require.NoError(t, err)
+		assert.Equal(t, rsv.StartDate, original.StartDate)
+		assert.Equal(t, rsv.EndDate, original.EndDate)
+	})
+
+	t.Run("Test Cancelled", func(t *testing.T) {
+		rsv := createRandomReservationTx(t, createRandomRoom(t), util.RandomDate())
+
+		_, err := testStore.CancelReservationTx(context.Background(), CancelReservationParams{ID: rsv.ID})
+		require.NoError(t, err)
+
+		arg := UpdateReservationDatesParams{ID: rsv.ID}
+		arg.StartDate.Scan(rsv.StartDate.Time.AddDate(0, 0, 1))
+		arg.EndDate.Scan(rsv.EndDate.Time.AddDate(0, 0, 1))
+
+		// execute transaction
+		_, err = testStore.UpdateReservationDatesTx(context.Background(), arg)
+		require.ErrorIs(t, err, ErrReservationCancelled)
+	})
+
+	t.Run("Test Closed", func(t *testing.T) {
+		for _, status := range []ReservationStatus{
+			ReservationStatusCheckedIn,
+			ReservationStatusCheckedOut,
+			ReservationStatusNoShow,
+		} {
+			rsv := createRandomReservationTx(t, createRandomRoom(t), util.RandomDate())
+
+			_, err := testStore.UpdateReservationStatus(context.Background(), UpdateReservationStatusParams{
+				ID:     rsv.ID,
+				Status: status,
+			})
+			require.NoError(t, err)
+
+			arg := UpdateReservationDatesParams{ID: rsv.ID}
+			arg.StartDate.Scan(rsv.StartDate.Time.AddDate(0, 0, 1))
+			arg.EndDate.Scan(rsv.EndDate.Time.AddDate(0, 0, 1))
+
+			// execute transaction
+			_, err = testStore.UpdateReservationDatesTx(context.Background(), arg)
+			require.ErrorIs(t, err, ErrReservationClosed)
+		}
+	})
+}
+
+// updateReservationParams returns the parameters to update rsv with its current data
+func updateReservationParams(rsv Reservation) UpdateReservationParams {
+	return UpdateReservationParams{
+		ID:        rsv.ID,
+		FirstName: rsv.FirstName,
+		LastName:  rsv.LastName,
+		Email:     rsv.Email,
+		Phone:     rsv.Phone,
+		StartDate: rsv.StartDate,
+		EndDate:   rsv.EndDate,
+		RoomID:    rsv.RoomID,
+		Notes:     rsv.Notes,
+		Adults:    rsv.Adults,
+		Children:  rsv.Children,
+	}
+}
+
+func TestStore_UpdateReservationTx(t *testing.T) {
+	t.Run("Test OK Guest Details", func(t *testing.T) {
+		rsv := createRandomReservationTx(t, createRandomRoom(t), util.RandomDate())
+
+		arg := updateReservationParams(rsv)
+		arg.FirstName = util.RandomName()
+		arg.Email = util.RandomEmail()
+		arg.Notes.Scan(util.RandomNote())
+
+		// execute transaction
+		updated, err := testStore.UpdateReservationTx(context.Background(), arg)
+
+		// testify reservation, keeping its price
+		require.NoError(t, err)
+		assert.Equal(t, rsv.ID, updated.ID)
+		assert.Equal(t, rsv.Code, updated.Code)
+		assert.Equal(t, arg.FirstName, updated.FirstName)
+		assert.Equal(t, arg.Email, updated.Email)
+		assert.Equal(t, arg.Notes, updated.Notes)
+		assert.Equal(t, rsv.TotalPrice, updated.TotalPrice)
+		assert.True(t, updated.UpdatedAt.Time.After(rsv.UpdatedAt.Time))
+	})
+
+	t.Run("Test OK Room And Dates", func(t *testing.T) {
+		rsv := createRandomReservationTx(t, createRandomRoom(t), util.RandomDate())
+		room := createRandomRoom(t)
+
+		// move the reservation to another room, one day later
+		arg := updateReservationParams(rsv)
+		arg.RoomID = room.ID
+		arg.StartDate.Scan(rsv.StartDate.Time.AddDate(0, 0, 1))
+		arg.EndDate.Scan(rsv.EndDate.Time.AddDate(0, 0, 1))
+		arg.Adults = 2
+
+		// execute transaction
+		updated, err := testStore.UpdateReservationTx(context.Background(), arg)
+
+		// testify reservation is priced at the nightly rate of the new room
+		require.NoError(t, err)
+		assert.Equal(t, room.ID, updated.RoomID)
+		assert.Equal(t, arg.StartDate, updated.StartDate)
+		assert.Equal(t, arg.EndDate, updated.EndDate)
+		assert.Equal(t, int32(2), updated.Adults)
+		assert.Equal(t, room.NightlyRate*7, updated.TotalPrice)
+
+		// testify room restriction moved with the reservation
+		rr, err := testStore.GetLastRoomRestriction(context.Background(), room.ID)
+		require.NoError(t, err)
+		assert.Equal(t, rsv.ID, rr.ReservationID.Int64)
+		assert.WithinDuration(t, arg.StartDate.Time, rr.StartDate.Time, time.Second)
+		assert.WithinDuration(t, arg.EndDate.Time, rr.EndDate.Time, time.Second)
+	})
+
+	t.Run("Test Room Unavailable", func(t *testing.T) {
+		room := createRandomRoom(t)
+		rDate := util.RandomDate()
+		rsv := createRandomReservationTx(t, createRandomRoom(t), rDate)
+		createRandomReservationTx(t, room, rDate.AddDate(0, 0, 3))
+
+		// move the reservation to a room booked on overlapping dates
+		arg := updateReservationParams(rsv)
+		arg.RoomID = room.ID
+
+		// execute transaction
+		_, err := testStore.UpdateReservationTx(context.Background(), arg)
+		require.ErrorIs(t, err, ErrRoomUnavailable)
+
+		// testify the original reservation is untouched
+		original, err := testStore.GetReservation(context.Background(), rsv.ID)
+		require.NoError(t, err)
+		assert.Equal(t, rsv.RoomID, original.RoomID)
+	})
+
+	t.Run("Test Room Capacity", func(t *testing.T) {
+		rsv := createRandomReservationTx(t, createRandomRoom(t), util.RandomDate())
+
+		arg := updateReservationParams(rsv)
+		arg.Adults = 3
+
+		// execute transaction
+		_, err := testStore.UpdateReservationTx(context.Background(), arg)
+		require.ErrorIs(t, err, ErrRoomUnavailable)
+	})
+
+	t.Run("Test Cancelled", func(t *testing.T) {
+		rsv := createRandomReservationTx(t, createRandomRoom(t), util.RandomDate())
+
+		_, err := testStore.CancelReservationTx(context.Background(), CancelReservationParams{ID: rsv.ID})
+		require.NoError(t, err)
+
+		// execute transaction
+		_, err = testStore.UpdateReservationTx(context.Background(), updateReservationParams(rsv))
+		require.ErrorIs(t, err, ErrReservationCancelled)
+	})
+
+	t.Run("Test Closed", func(t *testing.T) {
+		for _, status := range []ReservationStatus{
+			ReservationStatusCheckedOut,
+			ReservationStatusNoShow,
+		} {
+			rsv := createRandomReservationTx(t, createRandomRoom(t), util.RandomDate())
+
+			_, err := testStore.UpdateReservationStatus(context.Background(), UpdateReservationStatusParams{
+				ID:     rsv.ID,
+				Status: status,
+			})
+			require.NoError(t, err)
+
+			// execute transaction
+			_, err = testStore.UpdateReservationTx(context.Background(), updateReservationParams(rsv))
+			require.ErrorIs(t, err, ErrReservationClosed)
+		}
+	})
+}
+
+func TestStore_CreateReservationsTx(t *testing.T) {
+	// newArgs returns arguments of reservations booked together in rooms
+	newArgs := func(rooms []Room, startDate time.Time) []CreateReservationParams {
+		parentCode := util.RandomString(ReservationCodeLenght)
+		lastName := util.RandomName()
+
+		args := make([]CreateReservationParams, len(rooms))
+		for i, room := range rooms {
+			args[i] = CreateReservationParams{
+				Code:      util.RandomString(ReservationCodeLenght),
+				FirstName: util.RandomName(),
+				LastName:  lastName,
+				Email:     util.RandomEmail(),
+				RoomID:    room.ID,
+				Adults:    1,
+			}
+			args[i].StartDate.Scan(startDate)
+			args[i].EndDate.Scan(startDate.Add(time.Hour * 24 * 7))
+			args[i].ParentCode.Scan(parentCode)
+		}
+
+		return args
+	}
+
+	t.Run("Test OK", func(t *testing.T) {
+		rooms := []Room{createRandomRoom(t), createRandomRoom(t)}
+		args := newArgs(rooms, util.RandomDate())
+
+		// execute transaction
+		rsvs, err := testStore.CreateReservationsTx(context.Background(), args, "", "")
+
+		// testify reservations and room restrictions
+		require.NoError(t, err)
+		require.Len(t, rsvs, len(args))
+		for i, rsv := range rsvs {
+			assert.NotEmpty(t, rsv.ID)
+			assert.Equal(t, args[i].Code, rsv.Code)
+			assert.Equal(t, args[i].ParentCode, rsv.ParentCode)
+			assert.Equal(t, args[i].RoomID, rsv.RoomID)
+			assert.Equal(t, 7*rooms[i].NightlyRate, rsv.TotalPrice)
+
+			rr, err := testStore.GetLastRoomRestriction(context.Background(), rsv.RoomID)
+			require.NoError(t, err)
+			assert.Equal(t, rsv.ID, rr.ReservationID.Int64)
+			assert.Equal(t, RestrictionReservation, rr.Restriction)
+		}
+	})
+
+	t.Run("Test Room Unavailable", func(t *testing.T) {
+		rooms := []Room{createRandomRoom(t), createRandomRoom(t)}
+		rDate := util.RandomDate()
+
+		// book the second room on the same dates
+		createRandomReservationTx(t, rooms[1], rDate)
+		args := newArgs(rooms, rDate)
+
+		// execute transaction
+		rsvs, err := testStore.CreateReservationsTx(context.Background(), args, "", "")
+		require.ErrorIs(t, err, ErrRoomUnavailable)
+		require.Empty(t, rsvs)
+
+		// testify the first room was not booked
+		_, err = testStore.GetLastRoomRestriction(context.Background(), rooms[0].ID)
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("Test Room Capacity Exceeded", func(t *testing.T) {
+		room := createRandomRoom(t)
+		args := newArgs([]Room{room}, util.RandomDate())
+		args[0].Adults = room.MaxAdults + 1
+
+		// execute transaction
+		rsvs, err := testStore.CreateReservationsTx(context.Background(), args, "", "")
+		require.ErrorIs(t, err, ErrRoomUnavailable)
+		require.Empty(t, rsvs)
+	})
+
+	t.Run("Test Stay Rule", func(t *testing.T) {
+		rooms := []Room{createRandomRoom(t), createRandomRoom(t)}
+		rDate := util.RandomDate()
+
+		// require a longer stay in the second room
+		createRandomStayRule(t, rooms[1], rDate, rDate, AllDaysOfWeek, 10)
+		args := newArgs(rooms, rDate)
+
+		// execute transaction
+		rsvs, err := testStore.CreateReservationsTx(context.Background(), args, "", "")
+		require.ErrorIs(t, err, ErrStayRuleViolation)
+		require.Empty(t, rsvs)
+
+		// testify the first room was not booked
+		_, err = testStore.GetLastRoomRestriction(context.Background(), rooms[0].ID)
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("Test Promo Code", func(t *testing.T) {
+		rooms := []Room{createRandomRoom(t), createRandomRoom(t)}
+		rDate := util.RandomDate()
+		promo := createRandomPromoCode(t, rDate, rDate, 0)
+		args := newArgs(rooms, rDate)
+
+		// execute transaction
+		rsvs, err := testStore.CreateReservationsTx(context.Background(), args, "", promo.Code)
+		require.NoError(t, err)
+		require.Len(t, rsvs, len(args))
+		for i, rsv := range rsvs {
+			subtotal := 7 * rooms[i].NightlyRate
+			assert.Equal(t, promo.ID, rsv.PromoCodeID.Int64)
+			assert.Equal(t, promo.DiscountFor(subtotal), rsv.Discount)
+			assert.Equal(t, subtotal-rsv.Discount, rsv.TotalPrice)
+		}
+
+		// testify the booking counts as a single redemption
+		promo, err = testStore.GetPromoCode(context.Background(), promo.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), promo.TimesRedeemed)
+	})
+
+	t.Run("Test Fixed Promo Code", func(t *testing.T) {
+		rooms := []Room{createRandomRoom(t), createRandomRoom(t)}
+		rDate := util.RandomDate()
+
+		// a single use promo code of a fixed discount
+		promoArg := CreatePromoCodeParams{
+			Code:           util.RandomString(10),
+			DiscountKind:   DiscountKindFixed,
+			DiscountValue:  5000,
+			RoomIds:        []int64{},
+			MaxRedemptions: 1,
+		}
+		promoArg.ValidFrom.Scan(rDate)
+		promoArg.ValidTo.Scan(rDate)
+		promo, err := testStore.CreatePromoCode(context.Background(), promoArg)
+		require.NoError(t, err)
+		args := newArgs(rooms, rDate)
+
+		// execute transaction
+		rsvs, err := testStore.CreateReservationsTx(context.Background(), args, "", promo.Code)
+		require.NoError(t, err)
+		require.Len(t, rsvs, len(args))
+
+		// testify the discount is split across the reservations
+		var discount int64
+		for i, rsv := range rsvs {
+			assert.Equal(t, promo.ID, rsv.PromoCodeID.Int64)
+			assert.Equal(t, 7*rooms[i].NightlyRate-rsv.Discount, rsv.TotalPrice)
+			discount += rsv.Discount
+		}
+		assert.Equal(t, promo.DiscountValue, discount)
+
+		promo, err = testStore.GetPromoCode(context.Background(), promo.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), promo.TimesRedeemed)
+	})
+
+	t.Run("Test Charges", func(t *testing.T) {
+		rooms := []Room{createRandomRoom(t)}
+		cleaning := createRandomCharge(t, ChargeKindPerStay, 4000)
+		args := newArgs(rooms, util.RandomDate())
+
+		// execute transaction
+		rsvs, err := testStore.CreateReservationsTx(context.Background(), args, "", "")
+		require.NoError(t, err)
+		require.Len(t, rsvs, 1)
+		assert.Equal(t, 7*rooms[0].NightlyRate+4000, rsvs[0].TotalPrice)
+
+		// testify the charges are itemised with the reservation
+		charges, err := testStore.ListReservationCharges(context.Background(), rsvs[0].ID)
+		require.NoError(t, err)
+		require.Len(t, charges, 1)
+		assert.Equal(t, cleaning.ID, charges[0].ChargeID.Int64)
+		assert.Equal(t, cleaning.Name, charges[0].Name)
+		assert.Equal(t, int64(4000), charges[0].Amount)
+	})
+
+	t.Run("Test Per Person Charges", func(t *testing.T) {
+		rooms := []Room{createRandomRoom(t), createRandomRoom(t)}
+		cityTax := createRandomCharge(t, ChargeKindPerPersonNight, 250)
+
+		// the party is split across the rooms
+		args := newArgs(rooms, util.RandomDate())
+		args[0].Adults, args[0].Children = 2, 1
+		args[1].Adults, args[1].Children = 1, 0
+
+		// execute transaction
+		rsvs, err := testStore.CreateReservationsTx(context.Background(), args, "", "")
+		require.NoError(t, err)
+		require.Len(t, rsvs, len(args))
+
+		// testify every room is charged for its own guests only
+		for i, rsv := range rsvs {
+			amount := int64(7*(args[i].Adults+args[i].Children)) * 250
+			assert.Equal(t, 7*rooms[i].NightlyRate+amount, rsv.TotalPrice)
+
+			charges, err := testStore.ListReservationCharges(context.Background(), rsv.ID)
+			require.NoError(t, err)
+			require.Len(t, charges, 1)
+			assert.Equal(t, cityTax.ID, charges[0].ChargeID.Int64)
+			assert.Equal(t, amount, charges[0].Amount)
+		}
+	})
+
+	t.Run("Test Promo Code Used Up", func(t *testing.T) {
+		rooms := []Room{createRandomRoom(t), createRandomRoom(t)}
+		rDate := util.RandomDate()
+		promo := createRandomPromoCode(t, rDate, rDate, 1)
+		args := newArgs(rooms, rDate)
+
+		// execute transaction
+		rsvs, err := testStore.CreateReservationsTx(context.Background(), args, "", promo.Code)
+		require.ErrorIs(t, err, ErrPromoCodeRejected)
+		require.Empty(t, rsvs)
+
+		// testify no room was booked and the promo code was not redeemed
+		for _, room := range rooms {
+			_, err = testStore.GetLastRoomRestriction(context.Background(), room.ID)
+			assert.ErrorIs(t, err, pgx.ErrNoRows)
+		}
+
+		promo, err = testStore.GetPromoCode(context.Background(), promo.ID)
+		require.NoError(t, err)
+		assert.Zero(t, promo.TimesRedeemed)
+	})
+}
+
+func TestStore_CreateRoomRatesTx(t *testing.T) {
+	rooms := []Room{createRandomRoom(t), createRandomRoom(t)}
+	rDate := util.RandomDate()
+
+	// newArgs returns arguments of the same rate set for rooms
+	newArgs := func(rooms []Room) []CreateRoomRateParams {
+		args := make([]CreateRoomRateParams, len(rooms))
+		for i, room := range rooms {
+			args[i] = CreateRoomRateParams{
+				RoomID:     room.ID,
+				Name:       "Summer",
+				DaysOfWeek: AllDaysOfWeek,
+				Rate:       30000,
+				Priority:   1,
+			}
+			args[i].StartDate.Scan(rDate)
+			args[i].EndDate.Scan(rDate.AddDate(0, 0, 30))
+		}
+
+		return args
+	}
+
+	t.Run("Test OK", func(t *testing.T) {
+		args := newArgs(rooms)
+
+		// execute transaction
+		rates, err := testStore.CreateRoomRatesTx(context.Background(), args)
+
+		// testify
+		require.NoError(t, err)
+		require.Len(t, rates, len(args))
+		for i, rr := range rates {
+			assert.NotEmpty(t, rr.ID)
+			assert.Equal(t, args[i].RoomID, rr.RoomID)
+			assert.Equal(t, args[i].Rate, rr.Rate)
+			assert.Equal(t, args[i].StartDate, rr.StartDate)
+			assert.Equal(t, args[i].EndDate, rr.EndDate)
+		}
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// the second rate ends before it starts
+		args := newArgs([]Room{createRandomRoom(t), createRandomRoom(t)})
+		args[1].EndDate.Scan(rDate.AddDate(0, 0, -1))
+
+		// execute transaction
+		rates, err := testStore.CreateRoomRatesTx(context.Background(), args)
+		require.Error(t, err)
+		require.Empty(t, rates)
+
+		// testify the first rate was not created
+		arg := ListRoomRatesForStayParams{RoomID: args[0].RoomID}
+		arg.StartDate.Scan(rDate)
+		arg.EndDate.Scan(rDate.AddDate(0, 0, 1))
+		existing, err := testStore.ListRoomRatesForStay(context.Background(), arg)
+		require.NoError(t, err)
+		assert.Empty(t, existing)
+	})
+}
+
+func TestStore_CreateOwnerBlocksTx(t *testing.T) {
+	rooms := []Room{createRandomRoom(t), createRandomRoom(t)}
+	startDate := util.RandomDate()
+
+	// the second room is reserved on the last nights of the block
+	createRandomReservationTx(t, rooms[1], startDate.AddDate(0, 0, 5))
+
+	// newArg returns the arguments of a block of rooms for a week from startDate
+	newArg := func(override bool) CreateOwnerBlocksTxParams {
+		arg := CreateOwnerBlocksTxParams{
+			RoomIDs:  []int64{rooms[0].ID, rooms[1].ID},
+			Reason:   "Repairs",
+			Override: override,
+		}
+		arg.StartDate.Scan(startDate)
+		arg.EndDate.Scan(startDate.AddDate(0, 0, 7))
+		return arg
+	}
+
+	t.Run("Test Conflict", func(t *testing.T) {
+		// execute transaction
+		blocks, err := testStore.CreateOwnerBlocksTx(context.Background(), newArg(false))
+		require.ErrorIs(t, err, ErrOwnerBlockConflict)
+		require.Empty(t, blocks)
+
+		// testify the first room was not blocked
+		arg := ListRoomRestrictionsForPeriodParams{}
+		arg.StartDate.Scan(startDate)
+		arg.EndDate.Scan(startDate.AddDate(0, 0, 7))
+		restrictions, err := testStore.ListRoomRestrictionsForPeriod(context.Background(), arg)
+		require.NoError(t, err)
+		for _, rr := range restrictions {
+			assert.NotEqual(t, rooms[0].ID, rr.RoomID)
+		}
+	})
+
+	t.Run("Test Override", func(t *testing.T) {
+		arg := newArg(true)
+
+		// execute transaction
+		blocks, err := testStore.CreateOwnerBlocksTx(context.Background(), arg)
+
+		// testify
+		require.NoError(t, err)
+		require.Len(t, blocks, len(arg.RoomIDs))
+		for i, block := range blocks {
+			assert.NotEmpty(t, block.ID)
+			assert.Equal(t, arg.RoomIDs[i], block.RoomID)
+			assert.Equal(t, RestrictionOwnerBlock, block.Restriction)
+			assert.Equal(t, arg.StartDate, block.StartDate)
+			assert.Equal(t, arg.EndDate, block.EndDate)
+			assert.Equal(t, arg.Reason, block.Reason)
+			assert.False(t, block.ReservationID.Valid)
+		}
+	})
+}
+
+func TestStore_UpdateOwnerBlockTx(t *testing.T) {
+	room := createRandomRoom(t)
+	startDate := util.RandomDate()
+
+	arg := CreateOwnerBlockParams{RoomID: room.ID, Reason: "Personal use"}
+	arg.StartDate.Scan(startDate)
+	arg.EndDate.Scan(startDate.AddDate(0, 0, 3))
+	block, err := testStore.CreateOwnerBlock(context.Background(), arg)
+	require.NoError(t, err)
+
+	// the room is reserved from the day after the block
+	createRandomReservationTx(t, room, startDate.AddDate(0, 0, 4))
+
+	// newArg returns the arguments extending the block by the days specified
+	newArg := func(days int, override bool) UpdateOwnerBlockTxParams {
+		arg := UpdateOwnerBlockTxParams{
+			UpdateOwnerBlockParams: UpdateOwnerBlockParams{
+				ID:        block.ID,
+				StartDate: block.StartDate,
+				RoomID:    room.ID,
+				Reason:    "Repairs",
+			},
+			Override: override,
+		}
+		arg.EndDate.Scan(block.EndDate.Time.AddDate(0, 0, days))
+		return arg
+	}
+
+	t.Run("Test OK", func(t *testing.T) {
+		arg := newArg(1, false)
+
+		// execute transaction
+		updated, err := testStore.UpdateOwnerBlockTx(context.Background(), arg)
+
+		// testify
+		require.NoError(t, err)
+		assert.Equal(t, block.ID, updated.ID)
+		assert.Equal(t, arg.EndDate, updated.EndDate)
+		assert.Equal(t, arg.Reason, updated.Reason)
+		assert.Equal(t, RestrictionOwnerBlock, updated.Restriction)
+	})
+
+	t.Run("Test Conflict", func(t *testing.T) {
+		_, err := testStore.UpdateOwnerBlockTx(context.Background(), newArg(2, false))
+		require.ErrorIs(t, err, ErrOwnerBlockConflict)
+
+		// the block can be extended over the reservation when overridden
+		arg := newArg(2, true)
+		updated, err := testStore.UpdateOwnerBlockTx(context.Background(), arg)
+		require.NoError(t, err)
+		assert.Equal(t, arg.EndDate, updated.EndDate)
+	})
+
+	t.Run("Test Not Owner Block", func(t *testing.T) {
+		rsv := createRandomReservationTx(t, createRandomRoom(t), startDate)
+		restrictions, err := testStore.ListRoomRestrictionsByReservationID(context.Background(),
+			pgtype.Int8{Int64: rsv.ID, Valid: true})
+		require.NoError(t, err)
+		require.NotEmpty(t, restrictions)
+
+		arg := newArg(0, true)
+		arg.ID = restrictions[0].ID
+		arg.RoomID = rsv.RoomID
+		_, err = testStore.UpdateOwnerBlockTx(context.Background(), arg)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+}
+
+func TestStore_DeleteRoomTx(t *testing.T) {
+	t.Run("Test OK", func(t *testing.T) {
+		room := createRandomRoom(t)
+
+		deleted, err := testStore.DeleteRoomTx(context.Background(), room.ID)
+		require.NoError(t, err)
+		assert.Equal(t, room.ID, deleted.ID)
+		assert.Equal(t, room.Name, deleted.Name)
+
+		_, err = testStore.GetRoom(context.Background(), room.ID)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("Test Cancelled Reservation", func(t *testing.T) {
+		room := createRandomRoom(t)
+		rsv := createRandomReservationTx(t, room, util.RandomDate())
+
+		_, err := testStore.CancelReservationTx(context.Background(), CancelReservationParams{
+			ID:          rsv.ID,
+			CancelledBy: pgtype.Text{String: "guest", Valid: true},
+		})
+		require.NoError(t, err)
+
+		// the cancelled reservation is kept with the room
+		_, err = testStore.DeleteRoomTx(context.Background(), room.ID)
+		require.ErrorIs(t, err, ErrRoomHasReservations)
+
+		_, err = testStore.GetRoom(context.Background(), room.ID)
+		require.NoError(t, err)
+
+		_, err = testStore.GetReservation(context.Background(), rsv.ID)
+		require.NoError(t, err)
+	})
+}
+
+func TestStore_CreateRoomHoldTx(t *testing.T) {
+	// newArg returns the arguments of a hold on room for a week from startDate, expiring after ttl
+	newArg := func(room Room, startDate time.Time, ttl time.Duration) CreateRoomHoldTxParams {
+		arg := CreateRoomHoldTxParams{
+			CreateRoomHoldParams: CreateRoomHoldParams{RoomID: room.ID},
+			Adults:               1,
+		}
+		arg.StartDate.Scan(startDate)
+		arg.EndDate.Scan(startDate.Add(time.Hour * 24 * 7))
+		arg.HoldToken.Scan(util.RandomString(32))
+		arg.ExpiresAt.Scan(time.Now().Add(ttl))
+
+		return arg
+	}
+
+	t.Run("Test OK", func(t *testing.T) {
+		room := createRandomRoom(t)
+		arg := newArg(room, util.RandomDate(), time.Minute)
+
+		// execute transaction twice, in order to refresh the hold
+		_, err := testStore.CreateRoomHoldTx(context.Background(), arg)
+		require.NoError(t, err)
+		hold, err := testStore.CreateRoomHoldTx(context.Background(), arg)
+		require.NoError(t, err)
+
+		// testify
+		assert.Equal(t, RestrictionHold, hold.Restriction)
+		assert.Equal(t, arg.HoldToken, hold.HoldToken)
+		assert.WithinDuration(t, arg.ExpiresAt.Time, hold.ExpiresAt.Time, time.Second)
+		assert.False(t, hold.ReservationID.Valid)
+
+		rr, err := testStore.GetLastRoomRestriction(context.Background(), room.ID)
+		require.NoError(t, err)
+		assert.Equal(t, hold.ID, rr.ID)
+	})
+
+	t.Run("Test Room Held", func(t *testing.T) {
+		room := createRandomRoom(t)
+		rDate := util.RandomDate()
+
+		// hold the room by another guest
+		_, err := testStore.CreateRoomHoldTx(context.Background(), newArg(room, rDate, time.Minute))
+		require.NoError(t, err)
+
+		// execute transaction
+		_, err = testStore.CreateRoomHoldTx(context.Background(), newArg(room, rDate, time.Minute))
+		require.ErrorIs(t, err, ErrRoomUnavailable)
+	})
+
+	t.Run("Test Stay Rule", func(t *testing.T) {
+		room := createRandomRoom(t)
+		rDate := util.RandomDate()
+		createRandomStayRule(t, room, rDate, rDate, AllDaysOfWeek, 10)
+
+		// execute transaction
+		_, err := testStore.CreateRoomHoldTx(context.Background(), newArg(room, rDate, time.Minute))
+		require.ErrorIs(t, err, ErrStayRuleViolation)
+
+		_, err = testStore.GetLastRoomRestriction(context.Background(), room.ID)
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("Test Room Hold Expired", func(t *testing.T) {
+		room := createRandomRoom(t)
+		rDate := util.RandomDate()
+
+		// hold the room by another guest with an expired hold
+		_, err := testStore.CreateRoomHoldTx(context.Background(), newArg(room, rDate, -time.Minute))
+		require.NoError(t, err)
+
+		// execute transaction
+		_, err = testStore.CreateRoomHoldTx(context.Background(), newArg(room, rDate, time.Minute))
+		require.NoError(t, err)
+
+		// testify the expired hold is deleted by the sweep
+		holds, err := testStore.DeleteExpiredRoomHolds(context.Background())
+		require.NoError(t, err)
+		assert.NotEmpty(t, holds)
+	})
+
+	t.Run("Test Reservation Releases Hold", func(t *testing.T) {
+		room := createRandomRoom(t)
+		rDate := util.RandomDate()
+		arg := newArg(room, rDate, time.Minute)
+
+		_, err := testStore.CreateRoomHoldTx(context.Background(), arg)
+		require.NoError(t, err)
+
+		// book the held room with the same hold token
+		rsvArg := CreateReservationParams{
+			Code:      util.RandomString(ReservationCodeLenght),
+			FirstName: util.RandomName(),
+			LastName:  util.RandomName(),
+			Email:     util.RandomEmail(),
+			RoomID:    room.ID,
+			Adults:    1,
+			StartDate: arg.StartDate,
+			EndDate:   arg.EndDate,
+		}
+		rsvs, err := testStore.CreateReservationsTx(context.Background(), []CreateReservationParams{rsvArg}, arg.HoldToken.String, "")
+		require.NoError(t, err)
+		require.Len(t, rsvs, 1)
+
+		// testify the hold was replaced by the reservation
+		rr, err := testStore.GetLastRoomRestriction(context.Background(), room.ID)
+		require.NoError(t, err)
+		assert.Equal(t, RestrictionReservation, rr.Restriction)
+		assert.Equal(t, rsvs[0].ID, rr.ReservationID.Int64)
+	})
+}
+
+func TestStore_NotifyWaitlistTx(t *testing.T) {
+	// newArg returns the arguments of room freed for a week from startDate
+	newArg := func(room Room, startDate time.Time) NotifyWaitlistTxParams {
+		arg := NotifyWaitlistTxParams{RoomID: room.ID}
+		arg.StartDate.Scan(startDate)
+		arg.EndDate.Scan(startDate.Add(time.Hour * 24 * 7))
+		arg.ExpiresAt.Scan(time.Now().Add(time.Hour))
+
+		return arg
+	}
+
+	t.Run("Test OK", func(t *testing.T) {
+		room := createRandomRoom(t)
+		arg := newArg(room, util.RandomDate())
+
+		// two guests are waiting for the same dates
+		first := createRandomWaitlistEntry(t, room, arg.StartDate.Time, arg.EndDate.Time)
+		second := createRandomWaitlistEntry(t, room, arg.StartDate.Time, arg.EndDate.Time)
+
+		// execute transaction
+		entries, err := testStore.NotifyWaitlistTx(context.Background(), arg)
+		require.NoError(t, err)
+
+		// testify that only the first guest is offered the room
+		require.Len(t, entries, 1)
+		assert.Equal(t, first.ID, entries[0].ID)
+		assert.Equal(t, room.ID, entries[0].OfferedRoomID.Int64)
+		assert.True(t, entries[0].NotifiedAt.Valid)
+		assert.WithinDuration(t, arg.ExpiresAt.Time, entries[0].ExpiresAt.Time, time.Second)
+
+		// testify the room is held for the first guest
+		rr, err := testStore.GetLastRoomRestriction(context.Background(), room.ID)
+		require.NoError(t, err)
+		assert.Equal(t, RestrictionHold, rr.Restriction)
+		assert.Equal(t, first.Token, rr.HoldToken.String)
+
+		entry, err := testStore.GetWaitlistEntryByToken(context.Background(), second.Token)
+		require.NoError(t, err)
+		assert.False(t, entry.NotifiedAt.Valid)
+	})
+
+	t.Run("Test Room Unavailable", func(t *testing.T) {
+		room := createRandomRoom(t)
+		arg := newArg(room, util.RandomDate())
+
+		// the room is booked again before the waitlist is notified
+		createRandomReservationTx(t, room, arg.StartDate.Time)
+		entry := createRandomWaitlistEntry(t, room, arg.StartDate.Time, arg.EndDate.Time)
+
+		// execute transaction
+		entries, err := testStore.NotifyWaitlistTx(context.Background(), arg)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+
+		result, err := testStore.GetWaitlistEntryByToken(context.Background(), entry.Token)
+		require.NoError(t, err)
+		assert.False(t, result.NotifiedAt.Valid)
+	})
+}
+
+func TestStore_CreateRefundTx(t *testing.T) {
+	r := createRandomReservation(t, createRandomRoom(t))
+	capture := createRandomPayment(t, r, pgtype.Int8{}, PaymentKindCapture)
+
+	// newArg returns the arguments of a full refund of capture, refunded as providerRef
+	newArg := func(providerRef string) CreateRefundTxParams {
+		return CreateRefundTxParams{
+			CreateRefundParams: CreateRefundParams{
+				ReservationID: r.ID,
+				Paid:          capture.Amount,
+				PolicyAmount:  capture.Amount,
+				Amount:        capture.Amount,
+			},
+			Payments: []CreatePaymentParams{{
+				ReservationID: r.ID,
+				ParentID:      pgtype.Int8{Int64: capture.ID, Valid: true},
+				Provider:      capture.Provider,
+				ProviderRef:   providerRef,
+				Kind:          PaymentKindRefund,
+				Status:        PaymentStatusSucceeded,
+				Amount:        capture.Amount,
+			}},
+		}
+	}
+
+	t.Run("Test OK", func(t *testing.T) {
+		arg := newArg(util.RandomString(20))
+
+		// execute transaction
+		refund, err := testStore.CreateRefundTx(context.Background(), arg)
+		require.NoError(t, err)
+		assert.NotEmpty(t, refund.ID)
+		assert.Equal(t, arg.Amount, refund.Amount)
+
+		// testify the refund transaction is recorded for the refund
+		payments, err := testStore.ListPaymentsByReservation(context.Background(), r.ID)
+		require.NoError(t, err)
+		require.Len(t, payments, 2)
+		assert.Equal(t, PaymentKindRefund, payments[1].Kind)
+		assert.Equal(t, arg.Payments[0].ProviderRef, payments[1].ProviderRef)
+		assert.Equal(t, pgtype.Int8{Int64: refund.ID, Valid: true}, payments[1].RefundID)
+	})
+
+	t.Run("Test Exceeds Paid", func(t *testing.T) {
+		// the capture was fully refunded by the previous test
+		arg := newArg(util.RandomString(20))
+
+		// execute transaction
+		_, err := testStore.CreateRefundTx(context.Background(), arg)
+		require.ErrorIs(t, err, ErrRefundExceedsPaid)
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// the refund overrides the policy amount without a reason
+		arg := newArg(util.RandomString(20))
+		arg.PolicyAmount = 0
+
+		before, err := testStore.ListPaymentsByReservation(context.Background(), r.ID)
+		require.NoError(t, err)
+
+		// execute transaction
+		_, err = testStore.CreateRefundTx(context.Background(), arg)
+		require.Error(t, err)
+
+		// testify no refund transaction was recorded
+		after, err := testStore.ListPaymentsByReservation(context.Background(), r.ID)
+		require.NoError(t, err)
+		assert.Equal(t, before, after)
+	})
+}
+
+func TestStore_IssueInvoiceTx(t *testing.T) {
+	property := util.RandomName()
+
+	t.Run("Test OK", func(t *testing.T) {
+		r := createRandomReservation(t, createRandomRoom(t))
+		arg := IssueInvoiceTxParams{Property: property, ReservationID: r.ID}
+
+		invoice1, err := testStore.IssueInvoiceTx(context.Background(), arg)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), invoice1.Number)
+		assert.Equal(t, r.ID, invoice1.ReservationID)
+
+		// the invoice of a reservation is issued once
+		invoice2, err := testStore.IssueInvoiceTx(context.Background(), arg)
+		require.NoError(t, err)
+		assert.Equal(t, invoice1, invoice2)
+	})
+
+	t.Run("Test Concurrent", func(t *testing.T) {
+		n := 5
+		errs := make(chan error, n)
+		numbers := make(chan int64, n)
+
+		for i := 0; i < n; i++ {
+			r := createRandomReservation(t, createRandomRoom(t))
+			go func() {
+				invoice, err := testStore.IssueInvoiceTx(context.Background(), IssueInvoiceTxParams{
+					Property:      property,
+					ReservationID: r.ID,
+				})
+				errs <- err
+				numbers <- invoice.Number
+			}()
+		}
+
+		// testify the numbers follow the first invoice without gaps
+		issued := make(map[int64]bool)
+		for i := 0; i < n; i++ {
+			require.NoError(t, <-errs)
+			issued[<-numbers] = true
+		}
+		for number := int64(2); number <= int64(n+1); number++ {
+			assert.True(t, issued[number], number)
+		}
+	})
+
+	t.Run("Test Error", func(t *testing.T) {
+		// the reservation does not exist, so its invoice cannot be created
+		_, err := testStore.IssueInvoiceTx(context.Background(), IssueInvoiceTxParams{
+			Property:      property,
+			ReservationID: util.RandomID(),
+		})
+		require.Error(t, err)
+
+		// testify the number was not used
+		r := createRandomReservation(t, createRandomRoom(t))
+		invoice, err := testStore.IssueInvoiceTx(context.Background(), IssueInvoiceTxParams{
+			Property:      property,
+			ReservationID: r.ID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), invoice.Number)
+	})
+}
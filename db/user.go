@@ -18,18 +18,32 @@ func (store *PostgresDBStore) CreateNewUser(ctx context.Context, arg CreateUserP
 	return store.CreateUser(ctx, arg)
 }
 
+// ChangeUserPassword hashes the new password of the user and saves it.
+// The password token of the user is cleared, so that the link mailed to set the password can no longer be used.
+func (store *PostgresDBStore) ChangeUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(arg.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	arg.Password = string(hash)
+
+	return store.UpdateUserPassword(ctx, arg)
+}
+
 type AuthenticateUserParams struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
 }
 
 // AuthenticateUser validates the email and password of a user.
+// Deactivated users are not authenticated.
 // Returns nil on success, or an error on failure.
 func (store *PostgresDBStore) AuthenticateUser(ctx context.Context, arg AuthenticateUserParams) (User, error) {
 	// get user from database using email
 	user, err := store.GetUserByEmail(ctx, arg.Email)
-	if err != nil {
-		return user, errors.New("could not authenticate user")
+	if err != nil || user.DeactivatedAt.Valid {
+		return User{}, errors.New("could not authenticate user")
 	}
 
 	// compate database hash to passed password
@@ -17,7 +17,7 @@ INSERT INTO users (
 ) VALUES (
   $1, $2, $3, $4, $5
 )
-RETURNING id, first_name, last_name, email, password, access_level, created_at, updated_at
+RETURNING id, first_name, last_name, email, password, access_level, created_at, updated_at, deactivated_at, password_token, password_token_expires_at
 `
 
 type CreateUserParams struct {
@@ -46,6 +46,9 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.AccessLevel,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeactivatedAt,
+		&i.PasswordToken,
+		&i.PasswordTokenExpiresAt,
 	)
 	return i, err
 }
@@ -61,7 +64,7 @@ func (q *Queries) DeleteUser(ctx context.Context, id int64) error {
 }
 
 const getUser = `-- name: GetUser :one
-SELECT id, first_name, last_name, email, password, access_level, created_at, updated_at FROM users
+SELECT id, first_name, last_name, email, password, access_level, created_at, updated_at, deactivated_at, password_token, password_token_expires_at FROM users
 WHERE id = $1 LIMIT 1
 `
 
@@ -77,12 +80,15 @@ func (q *Queries) GetUser(ctx context.Context, id int64) (User, error) {
 		&i.AccessLevel,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeactivatedAt,
+		&i.PasswordToken,
+		&i.PasswordTokenExpiresAt,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, first_name, last_name, email, password, access_level, created_at, updated_at FROM users
+SELECT id, first_name, last_name, email, password, access_level, created_at, updated_at, deactivated_at, password_token, password_token_expires_at FROM users
 WHERE email = $1 LIMIT 1
 `
 
@@ -98,12 +104,39 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.AccessLevel,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeactivatedAt,
+		&i.PasswordToken,
+		&i.PasswordTokenExpiresAt,
+	)
+	return i, err
+}
+
+const getUserByPasswordToken = `-- name: GetUserByPasswordToken :one
+SELECT id, first_name, last_name, email, password, access_level, created_at, updated_at, deactivated_at, password_token, password_token_expires_at FROM users
+WHERE password_token = $1 LIMIT 1
+`
+
+func (q *Queries) GetUserByPasswordToken(ctx context.Context, passwordToken pgtype.Text) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByPasswordToken, passwordToken)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.Password,
+		&i.AccessLevel,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeactivatedAt,
+		&i.PasswordToken,
+		&i.PasswordTokenExpiresAt,
 	)
 	return i, err
 }
 
 const listUsers = `-- name: ListUsers :many
-SELECT id, first_name, last_name, email, password, access_level, created_at, updated_at FROM users
+SELECT id, first_name, last_name, email, password, access_level, created_at, updated_at, deactivated_at, password_token, password_token_expires_at FROM users
 ORDER BY first_name, last_name
 LIMIT $1
 OFFSET $2
@@ -132,6 +165,9 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 			&i.AccessLevel,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeactivatedAt,
+			&i.PasswordToken,
+			&i.PasswordTokenExpiresAt,
 		); err != nil {
 			return nil, err
 		}
@@ -174,9 +210,29 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) error {
 	return err
 }
 
+const updateUserDeactivatedAt = `-- name: UpdateUserDeactivatedAt :exec
+UPDATE users
+  set   deactivated_at = $2,
+        updated_at = $3
+WHERE id = $1
+`
+
+type UpdateUserDeactivatedAtParams struct {
+	ID            int64              `json:"id"`
+	DeactivatedAt pgtype.Timestamptz `json:"deactivated_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+}
+
+func (q *Queries) UpdateUserDeactivatedAt(ctx context.Context, arg UpdateUserDeactivatedAtParams) error {
+	_, err := q.db.Exec(ctx, updateUserDeactivatedAt, arg.ID, arg.DeactivatedAt, arg.UpdatedAt)
+	return err
+}
+
 const updateUserPassword = `-- name: UpdateUserPassword :exec
 UPDATE users
   set   password = $2,
+        password_token = NULL,
+        password_token_expires_at = NULL,
         updated_at = $3
 WHERE id = $1
 `
@@ -191,3 +247,28 @@ func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPassword
 	_, err := q.db.Exec(ctx, updateUserPassword, arg.ID, arg.Password, arg.UpdatedAt)
 	return err
 }
+
+const updateUserPasswordToken = `-- name: UpdateUserPasswordToken :exec
+UPDATE users
+  set   password_token = $2,
+        password_token_expires_at = $3,
+        updated_at = $4
+WHERE id = $1
+`
+
+type UpdateUserPasswordTokenParams struct {
+	ID                     int64              `json:"id"`
+	PasswordToken          pgtype.Text        `json:"password_token"`
+	PasswordTokenExpiresAt pgtype.Timestamptz `json:"password_token_expires_at"`
+	UpdatedAt              pgtype.Timestamptz `json:"updated_at"`
+}
+
+func (q *Queries) UpdateUserPasswordToken(ctx context.Context, arg UpdateUserPasswordTokenParams) error {
+	_, err := q.db.Exec(ctx, updateUserPasswordToken,
+		arg.ID,
+		arg.PasswordToken,
+		arg.PasswordTokenExpiresAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
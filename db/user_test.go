@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/github-real-lb/bookings-web-app/util"
+	"github.com/jackc/pgx/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/bcrypt"
@@ -74,4 +76,61 @@ func TestPostgresDBStore_AuthenticateUser(t *testing.T) {
 		require.Equal(t, err, errors.New("could not authenticate user"))
 		assert.Empty(t, result)
 	})
+
+	t.Run("Deactivated", func(t *testing.T) {
+		deactivated := createRandomUser(t, password)
+
+		arg := UpdateUserDeactivatedAtParams{ID: deactivated.ID}
+		arg.DeactivatedAt.Scan(time.Now())
+		arg.UpdatedAt.Scan(time.Now())
+		err := testStore.UpdateUserDeactivatedAt(context.Background(), arg)
+		require.NoError(t, err)
+
+		result, err := testStore.AuthenticateUser(context.Background(), AuthenticateUserParams{
+			Email:    deactivated.Email,
+			Password: password,
+		})
+		require.Equal(t, err, errors.New("could not authenticate user"))
+		assert.Empty(t, result)
+	})
+}
+
+func TestPostgresDBStore_ChangeUserPassword(t *testing.T) {
+	user := createRandomUser(t, util.RandomPassword())
+
+	// set a password token, as mailed to the user
+	tokenArg := UpdateUserPasswordTokenParams{ID: user.ID}
+	tokenArg.PasswordToken.Scan(util.RandomString(32))
+	tokenArg.PasswordTokenExpiresAt.Scan(time.Now().Add(time.Hour))
+	tokenArg.UpdatedAt.Scan(time.Now())
+	err := testStore.UpdateUserPasswordToken(context.Background(), tokenArg)
+	require.NoError(t, err)
+
+	result, err := testStore.GetUserByPasswordToken(context.Background(), tokenArg.PasswordToken)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, result.ID)
+
+	// change the password
+	password := util.RandomPassword()
+	arg := UpdateUserPasswordParams{
+		ID:       user.ID,
+		Password: password,
+	}
+	arg.UpdatedAt.Scan(time.Now())
+
+	err = testStore.ChangeUserPassword(context.Background(), arg)
+	require.NoError(t, err)
+
+	result, err = testStore.AuthenticateUser(context.Background(), AuthenticateUserParams{
+		Email:    user.Email,
+		Password: password,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, result.ID)
+	assert.False(t, result.PasswordToken.Valid)
+	assert.False(t, result.PasswordTokenExpiresAt.Valid)
+
+	// the password token can no longer be used
+	_, err = testStore.GetUserByPasswordToken(context.Background(), tokenArg.PasswordToken)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
 }
@@ -0,0 +1,193 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: waitlist_entry.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createWaitlistEntry = `-- name: CreateWaitlistEntry :one
+INSERT INTO waitlist_entries (
+  token, first_name, last_name, email, start_date, end_date, room_id, adults, children
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING id, token, first_name, last_name, email, start_date, end_date, room_id, adults, children, offered_room_id, notified_at, expires_at, created_at, updated_at
+`
+
+type CreateWaitlistEntryParams struct {
+	Token     string      `json:"token"`
+	FirstName string      `json:"first_name"`
+	LastName  string      `json:"last_name"`
+	Email     string      `json:"email"`
+	StartDate pgtype.Date `json:"start_date"`
+	EndDate   pgtype.Date `json:"end_date"`
+	RoomID    pgtype.Int8 `json:"room_id"`
+	Adults    int32       `json:"adults"`
+	Children  int32       `json:"children"`
+}
+
+func (q *Queries) CreateWaitlistEntry(ctx context.Context, arg CreateWaitlistEntryParams) (WaitlistEntry, error) {
+	row := q.db.QueryRow(ctx, createWaitlistEntry,
+		arg.Token,
+		arg.FirstName,
+		arg.LastName,
+		arg.Email,
+		arg.StartDate,
+		arg.EndDate,
+		arg.RoomID,
+		arg.Adults,
+		arg.Children,
+	)
+	var i WaitlistEntry
+	err := row.Scan(
+		&i.ID,
+		&i.Token,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.StartDate,
+		&i.EndDate,
+		&i.RoomID,
+		&i.Adults,
+		&i.Children,
+		&i.OfferedRoomID,
+		&i.NotifiedAt,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteAllWaitlistEntries = `-- name: DeleteAllWaitlistEntries :exec
+DELETE FROM waitlist_entries
+`
+
+func (q *Queries) DeleteAllWaitlistEntries(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, deleteAllWaitlistEntries)
+	return err
+}
+
+const getWaitlistEntryByToken = `-- name: GetWaitlistEntryByToken :one
+SELECT id, token, first_name, last_name, email, start_date, end_date, room_id, adults, children, offered_room_id, notified_at, expires_at, created_at, updated_at FROM waitlist_entries
+WHERE token = $1 LIMIT 1
+`
+
+func (q *Queries) GetWaitlistEntryByToken(ctx context.Context, token string) (WaitlistEntry, error) {
+	row := q.db.QueryRow(ctx, getWaitlistEntryByToken, token)
+	var i WaitlistEntry
+	err := row.Scan(
+		&i.ID,
+		&i.Token,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.StartDate,
+		&i.EndDate,
+		&i.RoomID,
+		&i.Adults,
+		&i.Children,
+		&i.OfferedRoomID,
+		&i.NotifiedAt,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listWaitlistEntriesForRoom = `-- name: ListWaitlistEntriesForRoom :many
+SELECT id, token, first_name, last_name, email, start_date, end_date, room_id, adults, children, offered_room_id, notified_at, expires_at, created_at, updated_at FROM waitlist_entries
+WHERE notified_at IS NULL
+AND (room_id IS NULL OR room_id = $1::bigint)
+AND (end_date > $2::date AND start_date < $3::date)
+AND start_date >= current_date
+ORDER BY created_at, id
+`
+
+type ListWaitlistEntriesForRoomParams struct {
+	RoomID    int64       `json:"room_id"`
+	StartDate pgtype.Date `json:"start_date"`
+	EndDate   pgtype.Date `json:"end_date"`
+}
+
+func (q *Queries) ListWaitlistEntriesForRoom(ctx context.Context, arg ListWaitlistEntriesForRoomParams) ([]WaitlistEntry, error) {
+	rows, err := q.db.Query(ctx, listWaitlistEntriesForRoom, arg.RoomID, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WaitlistEntry{}
+	for rows.Next() {
+		var i WaitlistEntry
+		if err := rows.Scan(
+			&i.ID,
+			&i.Token,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.StartDate,
+			&i.EndDate,
+			&i.RoomID,
+			&i.Adults,
+			&i.Children,
+			&i.OfferedRoomID,
+			&i.NotifiedAt,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateWaitlistEntryOffer = `-- name: UpdateWaitlistEntryOffer :one
+UPDATE waitlist_entries
+  set   offered_room_id = $2,
+        notified_at = now(),
+        expires_at = $3,
+        updated_at = now()
+WHERE id = $1
+RETURNING id, token, first_name, last_name, email, start_date, end_date, room_id, adults, children, offered_room_id, notified_at, expires_at, created_at, updated_at
+`
+
+type UpdateWaitlistEntryOfferParams struct {
+	ID            int64              `json:"id"`
+	OfferedRoomID pgtype.Int8        `json:"offered_room_id"`
+	ExpiresAt     pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) UpdateWaitlistEntryOffer(ctx context.Context, arg UpdateWaitlistEntryOfferParams) (WaitlistEntry, error) {
+	row := q.db.QueryRow(ctx, updateWaitlistEntryOffer, arg.ID, arg.OfferedRoomID, arg.ExpiresAt)
+	var i WaitlistEntry
+	err := row.Scan(
+		&i.ID,
+		&i.Token,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.StartDate,
+		&i.EndDate,
+		&i.RoomID,
+		&i.Adults,
+		&i.Children,
+		&i.OfferedRoomID,
+		&i.NotifiedAt,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
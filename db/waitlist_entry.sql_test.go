@@ -0,0 +1,85 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/github-real-lb/bookings-web-app/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomWaitlistEntry creates a random waitlist entry for room between startDate and endDate
+func createRandomWaitlistEntry(t *testing.T, room Room, startDate, endDate time.Time) WaitlistEntry {
+	arg := CreateWaitlistEntryParams{
+		Token:     util.RandomString(32),
+		FirstName: util.RandomName(),
+		LastName:  util.RandomName(),
+		Email:     util.RandomEmail(),
+		Adults:    1,
+	}
+	arg.StartDate.Scan(startDate)
+	arg.EndDate.Scan(endDate)
+	arg.RoomID.Scan(room.ID)
+
+	e, err := testStore.CreateWaitlistEntry(context.Background(), arg)
+	require.NoError(t, err)
+	assert.NotEmpty(t, e.ID)
+	assert.Equal(t, arg.Token, e.Token)
+	assert.Equal(t, arg.FirstName, e.FirstName)
+	assert.Equal(t, arg.LastName, e.LastName)
+	assert.Equal(t, arg.Email, e.Email)
+	assert.Equal(t, arg.RoomID, e.RoomID)
+	assert.Equal(t, arg.Adults, e.Adults)
+	assert.Equal(t, arg.Children, e.Children)
+	assert.False(t, e.OfferedRoomID.Valid)
+	assert.False(t, e.NotifiedAt.Valid)
+	assert.WithinDuration(t, time.Now(), e.CreatedAt.Time, time.Second)
+
+	return e
+}
+
+func TestQueries_CreateWaitlistEntry(t *testing.T) {
+	room := createRandomRoom(t)
+	rDate := util.RandomDate()
+	createRandomWaitlistEntry(t, room, rDate, rDate.Add(time.Hour*24*7))
+}
+
+func TestQueries_ListWaitlistEntriesForRoom(t *testing.T) {
+	t.Run("Test OK", func(t *testing.T) {
+		room := createRandomRoom(t)
+		rDate := util.RandomDate().AddDate(1, 0, 0)
+
+		// create entries overlapping and not overlapping the dates freed
+		overlapping := createRandomWaitlistEntry(t, room, rDate.Add(time.Hour*24*3), rDate.Add(time.Hour*24*10))
+		createRandomWaitlistEntry(t, room, rDate.Add(time.Hour*24*7), rDate.Add(time.Hour*24*14))
+
+		arg := ListWaitlistEntriesForRoomParams{RoomID: room.ID}
+		arg.StartDate.Scan(rDate)
+		arg.EndDate.Scan(rDate.Add(time.Hour * 24 * 7))
+
+		entries, err := testStore.ListWaitlistEntriesForRoom(context.Background(), arg)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, overlapping.ID, entries[0].ID)
+	})
+
+	t.Run("Test Past Arrival", func(t *testing.T) {
+		room := createRandomRoom(t)
+		today, _ := time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+
+		// create an entry of a stay that has already started, and one arriving today
+		createRandomWaitlistEntry(t, room, today.AddDate(0, 0, -3), today.AddDate(0, 0, 4))
+		arriving := createRandomWaitlistEntry(t, room, today, today.AddDate(0, 0, 4))
+
+		arg := ListWaitlistEntriesForRoomParams{RoomID: room.ID}
+		arg.StartDate.Scan(today.AddDate(0, 0, -7))
+		arg.EndDate.Scan(today.AddDate(0, 0, 7))
+
+		entries, err := testStore.ListWaitlistEntriesForRoom(context.Background(), arg)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, arriving.ID, entries[0].ID)
+	})
+}
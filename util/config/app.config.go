@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/alexedwards/scs/v2"
 )
@@ -20,8 +21,12 @@ const (
 	DebuggingMode   AppMode = 3
 )
 
+// PaymentWebhookSecretEnv is the environment variable holding the payment webhook secret
+const PaymentWebhookSecretEnv = "PAYMENT_WEBHOOK_SECRET"
+
 const DateTimeLayout = "2006-01-02 15:04:05.999999999Z07:00"
 const DateLayout = "2006-01-02"
+const MonthLayout = "2006-01"
 
 // AppConfig holds the application config
 type AppConfig struct {
@@ -47,6 +52,56 @@ type AppConfig struct {
 
 	// TemplatePath is the full path of the templates folder.
 	TemplatePath string
+
+	// CancellationPolicy is the policy applied to reservations cancelled by guests.
+	CancellationPolicy CancellationPolicy `json:"cancellation_policy"`
+
+	// RoomHoldMinutes is the number of minutes a room selected during checkout is held for the guest.
+	RoomHoldMinutes int `json:"room_hold_minutes"`
+
+	// WaitlistOfferHours is the number of hours a room freed for a waitlisted guest is held before it is offered to the next guest.
+	WaitlistOfferHours int `json:"waitlist_offer_hours"`
+
+	// DepositPercent is the percentage of the reservation price taken as a deposit before the reservation is confirmed.
+	DepositPercent int `json:"deposit_percent"`
+
+	// PaymentProvider is the name of the payment provider taking the deposits, such as "fake" for development.
+	PaymentProvider string `json:"payment_provider"`
+
+	// PaymentWebhookSecret is the secret shared with the payment provider to sign its webhooks.
+	// It is loaded from the PaymentWebhookSecretEnv environment variable, and is never kept in the config file.
+	PaymentWebhookSecret string `json:"-"`
+
+	// InvoicePrefix is the prefix of the invoice numbers of the property, such as FS in FS-000042.
+	InvoicePrefix string `json:"invoice_prefix"`
+
+	// BaseCurrency is the currency in which all prices are stored and all payments are taken.
+	BaseCurrency Currency `json:"base_currency"`
+}
+
+// Currency holds the ISO 4217 code of a currency and the symbol its amounts are displayed with
+type Currency struct {
+	Code   string `json:"code"`
+	Symbol string `json:"symbol"`
+}
+
+// CancellationPolicy holds the terms of reservation cancellations
+type CancellationPolicy struct {
+	// FreeCancellationDays is the minimum number of days before arrival for a cancellation to be free of charge.
+	FreeCancellationDays int `json:"free_cancellation_days"`
+
+	// LateCancellationFeePercent is the percentage of the reservation charged for later cancellations.
+	LateCancellationFeePercent int `json:"late_cancellation_fee_percent"`
+}
+
+// RoomHoldTTL returns the duration a room selected during checkout is held for the guest.
+func (app *AppConfig) RoomHoldTTL() time.Duration {
+	return time.Duration(app.RoomHoldMinutes) * time.Minute
+}
+
+// WaitlistOfferTTL returns the duration a room freed for a waitlisted guest is held for the guest.
+func (app *AppConfig) WaitlistOfferTTL() time.Duration {
+	return time.Duration(app.WaitlistOfferHours) * time.Hour
 }
 
 // LoadConfig returns the Application Configuration.
@@ -66,6 +121,9 @@ func LoadAppConfig(filename string, mode AppMode) (*AppConfig, error) {
 		return &app, err
 	}
 
+	// loading secrets from environment
+	app.PaymentWebhookSecret = os.Getenv(PaymentWebhookSecretEnv)
+
 	// setting directories names
 	app.TemplateDirectoryName = strings.TrimSuffix(app.TemplateDirectoryName, "/")
 	app.StaticDirectoryName = strings.TrimSuffix(app.StaticDirectoryName, "/")
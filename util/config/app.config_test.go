@@ -2,17 +2,32 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestLoadAppConfig(t *testing.T) {
+	t.Setenv(PaymentWebhookSecretEnv, "secret")
+
 	config, err := LoadAppConfig(testAppConfigFilename, ProductionMode)
 	require.NoError(t, err)
 	assert.Equal(t, ProductionMode, config.Mode)
 	assert.Equal(t, config.StartingPathProduction+config.TemplateDirectoryName, config.TemplatePath)
 	assert.Equal(t, config.StartingPathProduction+config.StaticDirectoryName, config.StaticPath)
+	assert.NotZero(t, config.CancellationPolicy.FreeCancellationDays)
+	assert.NotZero(t, config.CancellationPolicy.LateCancellationFeePercent)
+	assert.Equal(t, time.Duration(config.RoomHoldMinutes)*time.Minute, config.RoomHoldTTL())
+	assert.NotZero(t, config.RoomHoldTTL())
+	assert.Equal(t, time.Duration(config.WaitlistOfferHours)*time.Hour, config.WaitlistOfferTTL())
+	assert.NotZero(t, config.WaitlistOfferTTL())
+	assert.NotZero(t, config.DepositPercent)
+	assert.NotEmpty(t, config.PaymentProvider)
+	assert.Equal(t, "secret", config.PaymentWebhookSecret)
+	assert.NotEmpty(t, config.InvoicePrefix)
+	assert.Len(t, config.BaseCurrency.Code, 3)
+	assert.NotEmpty(t, config.BaseCurrency.Symbol)
 
 	config, err = LoadAppConfig(testAppConfigFilename, DevelopmentMode)
 	require.NoError(t, err)
@@ -114,6 +114,24 @@ func (f *Form) CheckPassword(field string) bool {
 	return true
 }
 
+// CheckIntRange checks if the field passed is an integer between min and max (inclusive), and returns the result.
+// Run TrimSpaces before to remove leading and trailing white spaces if needed.
+// Error message is added to f.Errors.
+func (f *Form) CheckIntRange(field string, min, max int) bool {
+	n, err := strconv.Atoi(f.Get(field))
+	if err != nil {
+		f.Errors.Add(field, "Field requires a whole number!")
+		return false
+	}
+
+	if n < min || n > max {
+		f.Errors.Add(field, fmt.Sprintf("Field requires a number between %d and %d!", min, max))
+		return false
+	}
+
+	return true
+}
+
 // CheckMinLenght checks if the first value of the field passed has minimum characters, and returns the result.
 // Run TrimSpaces before to remove leading and trailing white spaces if needed.
 // Error message is added to f.Errors.
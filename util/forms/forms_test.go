@@ -152,6 +152,26 @@ func TestForm_MinLenght(t *testing.T) {
 	assert.True(t, form.CheckMinLenght("WhiteSpaces", 3))
 }
 
+func TestForm_CheckIntRange(t *testing.T) {
+	form := New(url.Values{})
+	form.Set("number", "5")
+	form.Set("text", "abc")
+
+	// check ok
+	assert.True(t, form.CheckIntRange("number", 0, 10))
+	assert.True(t, form.CheckIntRange("number", 5, 5))
+	assert.True(t, form.Valid())
+
+	// check out of range
+	assert.False(t, form.CheckIntRange("number", 6, 10))
+	assert.NotEmpty(t, form.Errors.Get("number"))
+
+	// check not a number and missing key
+	assert.False(t, form.CheckIntRange("text", 0, 10))
+	assert.False(t, form.CheckIntRange(util.RandomName(), 0, 10))
+	assert.False(t, form.Valid())
+}
+
 func TestForm_Required(t *testing.T) {
 	form := createRandomForm(t)
 
@@ -0,0 +1,77 @@
+package limiters
+
+import (
+	"sync"
+	"time"
+)
+
+type Limiterer interface {
+	Allow(key string) bool
+}
+
+// window holds the number of events registered for a key since start
+type window struct {
+	start time.Time
+	count int
+}
+
+// SmartLimiter is a fixed window rate limiter.
+// It allows up to Limit events per key (e.g. client ip address) in every Window.
+type SmartLimiter struct {
+	Limit  int           // maximum number of events allowed per key in a window
+	Window time.Duration // duration of a window
+
+	mu      sync.Mutex         // protects windows
+	windows map[string]*window // windows of all keys
+}
+
+// NewSmartLimiter returns an initialized SmartLimiter that allows limit events per key in every duration
+func NewSmartLimiter(limit int, duration time.Duration) *SmartLimiter {
+	return &SmartLimiter{
+		Limit:   limit,
+		Window:  duration,
+		windows: make(map[string]*window),
+	}
+}
+
+// Allow registers an event for key, and returns true if the event is within the limit
+func (sl *SmartLimiter) Allow(key string) bool {
+	now := time.Now()
+
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+
+	w, ok := sl.windows[key]
+	if !ok || now.Sub(w.start) >= sl.Window {
+		// remove expired windows before adding a new one to prevent the map from growing
+		sl.removeExpired(now)
+
+		sl.windows[key] = &window{start: now, count: 1}
+		return sl.Limit > 0
+	}
+
+	if w.count >= sl.Limit {
+		return false
+	}
+
+	w.count++
+	return true
+}
+
+// Reset removes all registered events of key
+func (sl *SmartLimiter) Reset(key string) {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+
+	delete(sl.windows, key)
+}
+
+// removeExpired removes all windows that expired before now.
+// sl.mu must be held by the caller.
+func (sl *SmartLimiter) removeExpired(now time.Time) {
+	for key, w := range sl.windows {
+		if now.Sub(w.start) >= sl.Window {
+			delete(sl.windows, key)
+		}
+	}
+}
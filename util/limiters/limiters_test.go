@@ -0,0 +1,93 @@
+package limiters
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSmartLimiter(t *testing.T) {
+	sl := NewSmartLimiter(5, time.Minute)
+	require.NotNil(t, sl)
+	assert.Implements(t, (*Limiterer)(nil), sl)
+	assert.Equal(t, 5, sl.Limit)
+	assert.Equal(t, time.Minute, sl.Window)
+	assert.NotNil(t, sl.windows)
+}
+
+func TestSmartLimiter_Allow(t *testing.T) {
+	const N = 5
+
+	t.Run("Within Limit", func(t *testing.T) {
+		sl := NewSmartLimiter(N, time.Minute)
+
+		for i := 0; i < N; i++ {
+			assert.True(t, sl.Allow("key"))
+		}
+	})
+
+	t.Run("Exceeds Limit", func(t *testing.T) {
+		sl := NewSmartLimiter(N, time.Minute)
+
+		for i := 0; i < N; i++ {
+			require.True(t, sl.Allow("key"))
+		}
+		assert.False(t, sl.Allow("key"))
+
+		// other keys are not affected
+		assert.True(t, sl.Allow("other key"))
+	})
+
+	t.Run("Window Expired", func(t *testing.T) {
+		sl := NewSmartLimiter(N, 100*time.Millisecond)
+
+		for i := 0; i < N; i++ {
+			require.True(t, sl.Allow("key"))
+		}
+		require.False(t, sl.Allow("key"))
+
+		time.Sleep(150 * time.Millisecond)
+		assert.True(t, sl.Allow("key"))
+	})
+
+	t.Run("Zero Limit", func(t *testing.T) {
+		sl := NewSmartLimiter(0, time.Minute)
+		assert.False(t, sl.Allow("key"))
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		sl := NewSmartLimiter(N, time.Minute)
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		allowed := 0
+
+		for i := 0; i < N*4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if sl.Allow("key") {
+					mu.Lock()
+					allowed++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, N, allowed)
+	})
+}
+
+func TestSmartLimiter_Reset(t *testing.T) {
+	sl := NewSmartLimiter(1, time.Minute)
+
+	require.True(t, sl.Allow("key"))
+	require.False(t, sl.Allow("key"))
+
+	sl.Reset("key")
+	assert.True(t, sl.Allow("key"))
+}
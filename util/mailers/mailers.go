@@ -15,10 +15,18 @@ type Mailerer interface {
 
 // MailData holds an email
 type MailData struct {
-	To      string
-	From    string
-	Subject string
-	Content string
+	To          string
+	From        string
+	Subject     string
+	Content     string
+	Attachments []Attachment
+}
+
+// Attachment holds a file attached to an email
+type Attachment struct {
+	Name     string // file name shown to the recipient
+	MimeType string // MIME type of the file, obtained from Name if empty
+	Data     []byte
 }
 
 type SmartMailer struct {
@@ -59,6 +67,14 @@ func (sm *SmartMailer) SendMail(data MailData) error {
 
 	email.SetBody(mail.TextHTML, data.Content)
 
+	for _, a := range data.Attachments {
+		email.Attach(&mail.File{
+			Name:     a.Name,
+			MimeType: a.MimeType,
+			Data:     a.Data,
+		})
+	}
+
 	err = email.Send(client)
 
 	if err != nil {
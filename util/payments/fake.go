@@ -0,0 +1,125 @@
+package payments
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// DeclinedCardToken is the card token that FakeProvider always declines
+const DeclinedCardToken = "4000000000000002"
+
+// FakeProvider is a deterministic in-memory payment provider for development and tests.
+// Every token except DeclinedCardToken is authorized, and transaction ids are sequential.
+type FakeProvider struct {
+	Secret string // secret used to sign webhooks
+
+	mu             sync.Mutex       // protects the fields below
+	counter        int              // number of transactions created
+	authorizations map[string]int64 // authorized amounts by authorization id
+	captures       map[string]int64 // captured amounts not yet refunded by capture id
+}
+
+// NewFakeProvider returns an initialized FakeProvider that signs webhooks with secret
+func NewFakeProvider(secret string) *FakeProvider {
+	return &FakeProvider{
+		Secret:         secret,
+		authorizations: make(map[string]int64),
+		captures:       make(map[string]int64),
+	}
+}
+
+// Name returns the name of the provider
+func (fp *FakeProvider) Name() string {
+	return "fake"
+}
+
+// Authorize authorizes req.Amount on the card represented by req.Token
+func (fp *FakeProvider) Authorize(req AuthorizeRequest) (Transaction, error) {
+	if req.Amount <= 0 || req.Token == "" {
+		return Transaction{}, ErrInvalidPayment
+	}
+
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	if req.Token == DeclinedCardToken {
+		return Transaction{ID: fp.nextID("decl"), Amount: req.Amount, Status: StatusFailed}, ErrPaymentDeclined
+	}
+
+	t := Transaction{ID: fp.nextID("auth"), Amount: req.Amount, Status: StatusSucceeded}
+	fp.authorizations[t.ID] = req.Amount
+	return t, nil
+}
+
+// Capture captures amount of an authorization. An authorization can only be captured once.
+func (fp *FakeProvider) Capture(authorizationID string, amount int64) (Transaction, error) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	authorized, ok := fp.authorizations[authorizationID]
+	if !ok || amount <= 0 || amount > authorized {
+		return Transaction{}, ErrInvalidPayment
+	}
+	delete(fp.authorizations, authorizationID)
+
+	t := Transaction{ID: fp.nextID("capt"), Amount: amount, Status: StatusSucceeded}
+	fp.captures[t.ID] = amount
+	return t, nil
+}
+
+// Void releases an authorization that was not captured
+func (fp *FakeProvider) Void(authorizationID string) (Transaction, error) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	authorized, ok := fp.authorizations[authorizationID]
+	if !ok {
+		return Transaction{}, ErrInvalidPayment
+	}
+	delete(fp.authorizations, authorizationID)
+
+	return Transaction{ID: fp.nextID("void"), Amount: authorized, Status: StatusSucceeded}, nil
+}
+
+// Refund returns amount of a capture. A capture can be refunded partially several times.
+func (fp *FakeProvider) Refund(captureID string, amount int64) (Transaction, error) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	captured, ok := fp.captures[captureID]
+	if !ok || amount <= 0 || amount > captured {
+		return Transaction{}, ErrInvalidPayment
+	}
+	fp.captures[captureID] = captured - amount
+
+	return Transaction{ID: fp.nextID("rfnd"), Amount: amount, Status: StatusSucceeded}, nil
+}
+
+// VerifyWebhook checks that signature is the hex encoded HMAC-SHA256 of payload, and returns the event it holds
+func (fp *FakeProvider) VerifyWebhook(payload []byte, signature string) (WebhookEvent, error) {
+	var event WebhookEvent
+
+	if !hmac.Equal([]byte(fp.Sign(payload)), []byte(signature)) {
+		return event, ErrInvalidSignature
+	}
+
+	err := json.Unmarshal(payload, &event)
+	return event, err
+}
+
+// Sign returns the signature of a webhook payload
+func (fp *FakeProvider) Sign(payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(fp.Secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// nextID returns the next transaction id with prefix. fp.mu must be held.
+func (fp *FakeProvider) nextID(prefix string) string {
+	fp.counter++
+	return fmt.Sprintf("fake_%s_%06d", prefix, fp.counter)
+}
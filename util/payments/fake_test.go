@@ -0,0 +1,145 @@
+package payments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFakeProvider(t *testing.T) {
+	fp := NewFakeProvider("secret")
+	require.NotNil(t, fp)
+	assert.Implements(t, (*PaymentProvider)(nil), fp)
+	assert.Equal(t, "secret", fp.Secret)
+	assert.Equal(t, "fake", fp.Name())
+}
+
+func TestNewProvider(t *testing.T) {
+	provider, err := NewProvider("fake", "secret")
+	require.NoError(t, err)
+	assert.IsType(t, &FakeProvider{}, provider)
+
+	_, err = NewProvider("fake", "")
+	assert.ErrorIs(t, err, ErrMissingSecret)
+
+	_, err = NewProvider("any", "secret")
+	assert.Error(t, err)
+}
+
+func TestFakeProvider_Authorize(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		fp := NewFakeProvider("secret")
+
+		tx, err := fp.Authorize(AuthorizeRequest{Amount: 5000, Token: "4242424242424242"})
+		require.NoError(t, err)
+		assert.Equal(t, "fake_auth_000001", tx.ID)
+		assert.Equal(t, int64(5000), tx.Amount)
+		assert.Equal(t, StatusSucceeded, tx.Status)
+
+		tx, err = fp.Authorize(AuthorizeRequest{Amount: 100, Token: "4242424242424242"})
+		require.NoError(t, err)
+		assert.Equal(t, "fake_auth_000002", tx.ID)
+	})
+
+	t.Run("Declined", func(t *testing.T) {
+		fp := NewFakeProvider("secret")
+
+		tx, err := fp.Authorize(AuthorizeRequest{Amount: 5000, Token: DeclinedCardToken})
+		assert.ErrorIs(t, err, ErrPaymentDeclined)
+		assert.Equal(t, StatusFailed, tx.Status)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		fp := NewFakeProvider("secret")
+
+		_, err := fp.Authorize(AuthorizeRequest{Amount: 5000})
+		assert.ErrorIs(t, err, ErrInvalidPayment)
+
+		_, err = fp.Authorize(AuthorizeRequest{Amount: 0, Token: "4242424242424242"})
+		assert.ErrorIs(t, err, ErrInvalidPayment)
+	})
+}
+
+func TestFakeProvider_CaptureAndRefund(t *testing.T) {
+	fp := NewFakeProvider("secret")
+
+	auth, err := fp.Authorize(AuthorizeRequest{Amount: 5000, Token: "4242424242424242"})
+	require.NoError(t, err)
+
+	// capture more than authorized
+	_, err = fp.Capture(auth.ID, 5001)
+	assert.ErrorIs(t, err, ErrInvalidPayment)
+
+	capture, err := fp.Capture(auth.ID, 5000)
+	require.NoError(t, err)
+	assert.Equal(t, "fake_capt_000002", capture.ID)
+	assert.Equal(t, int64(5000), capture.Amount)
+	assert.Equal(t, StatusSucceeded, capture.Status)
+
+	// authorization can only be captured once
+	_, err = fp.Capture(auth.ID, 5000)
+	assert.ErrorIs(t, err, ErrInvalidPayment)
+
+	refund, err := fp.Refund(capture.ID, 2000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2000), refund.Amount)
+
+	// refund more than what is left of the capture
+	_, err = fp.Refund(capture.ID, 3001)
+	assert.ErrorIs(t, err, ErrInvalidPayment)
+
+	_, err = fp.Refund(capture.ID, 3000)
+	require.NoError(t, err)
+
+	_, err = fp.Refund("unknown", 1)
+	assert.ErrorIs(t, err, ErrInvalidPayment)
+}
+
+func TestFakeProvider_VerifyWebhook(t *testing.T) {
+	fp := NewFakeProvider("secret")
+	payload := []byte(`{"type":"capture.updated","transaction_id":"fake_capt_000001","status":"failed"}`)
+
+	event, err := fp.VerifyWebhook(payload, fp.Sign(payload))
+	require.NoError(t, err)
+	assert.Equal(t, "capture.updated", event.Type)
+	assert.Equal(t, "fake_capt_000001", event.TransactionID)
+	assert.Equal(t, StatusFailed, event.Status)
+
+	_, err = fp.VerifyWebhook(payload, "bad signature")
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	other := NewFakeProvider("other secret")
+	_, err = fp.VerifyWebhook(payload, other.Sign(payload))
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestFakeProvider_Void(t *testing.T) {
+	fp := NewFakeProvider("secret")
+
+	auth, err := fp.Authorize(AuthorizeRequest{Amount: 5000, Token: "4242424242424242"})
+	require.NoError(t, err)
+
+	void, err := fp.Void(auth.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "fake_void_000002", void.ID)
+	assert.Equal(t, int64(5000), void.Amount)
+	assert.Equal(t, StatusSucceeded, void.Status)
+
+	// a voided authorization can no longer be voided or captured
+	_, err = fp.Void(auth.ID)
+	assert.ErrorIs(t, err, ErrInvalidPayment)
+
+	_, err = fp.Capture(auth.ID, 5000)
+	assert.ErrorIs(t, err, ErrInvalidPayment)
+
+	// a captured authorization cannot be voided
+	auth, err = fp.Authorize(AuthorizeRequest{Amount: 5000, Token: "4242424242424242"})
+	require.NoError(t, err)
+
+	_, err = fp.Capture(auth.ID, 5000)
+	require.NoError(t, err)
+
+	_, err = fp.Void(auth.ID)
+	assert.ErrorIs(t, err, ErrInvalidPayment)
+}
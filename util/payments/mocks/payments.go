@@ -0,0 +1,185 @@
+// Code generated by mockery v2.42.0. DO NOT EDIT.
+
+package paymentmocks
+
+import (
+	payments "github.com/github-real-lb/bookings-web-app/util/payments"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockPaymentProvider is an autogenerated mock type for the PaymentProvider type
+type MockPaymentProvider struct {
+	mock.Mock
+}
+
+// Authorize provides a mock function with given fields: req
+func (_m *MockPaymentProvider) Authorize(req payments.AuthorizeRequest) (payments.Transaction, error) {
+	ret := _m.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authorize")
+	}
+
+	var r0 payments.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(payments.AuthorizeRequest) (payments.Transaction, error)); ok {
+		return rf(req)
+	}
+	if rf, ok := ret.Get(0).(func(payments.AuthorizeRequest) payments.Transaction); ok {
+		r0 = rf(req)
+	} else {
+		r0 = ret.Get(0).(payments.Transaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(payments.AuthorizeRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Capture provides a mock function with given fields: authorizationID, amount
+func (_m *MockPaymentProvider) Capture(authorizationID string, amount int64) (payments.Transaction, error) {
+	ret := _m.Called(authorizationID, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Capture")
+	}
+
+	var r0 payments.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int64) (payments.Transaction, error)); ok {
+		return rf(authorizationID, amount)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64) payments.Transaction); ok {
+		r0 = rf(authorizationID, amount)
+	} else {
+		r0 = ret.Get(0).(payments.Transaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(authorizationID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Name provides a mock function with given fields:
+func (_m *MockPaymentProvider) Name() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Name")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Refund provides a mock function with given fields: captureID, amount
+func (_m *MockPaymentProvider) Refund(captureID string, amount int64) (payments.Transaction, error) {
+	ret := _m.Called(captureID, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refund")
+	}
+
+	var r0 payments.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int64) (payments.Transaction, error)); ok {
+		return rf(captureID, amount)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64) payments.Transaction); ok {
+		r0 = rf(captureID, amount)
+	} else {
+		r0 = ret.Get(0).(payments.Transaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(captureID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyWebhook provides a mock function with given fields: payload, signature
+func (_m *MockPaymentProvider) VerifyWebhook(payload []byte, signature string) (payments.WebhookEvent, error) {
+	ret := _m.Called(payload, signature)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyWebhook")
+	}
+
+	var r0 payments.WebhookEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]byte, string) (payments.WebhookEvent, error)); ok {
+		return rf(payload, signature)
+	}
+	if rf, ok := ret.Get(0).(func([]byte, string) payments.WebhookEvent); ok {
+		r0 = rf(payload, signature)
+	} else {
+		r0 = ret.Get(0).(payments.WebhookEvent)
+	}
+
+	if rf, ok := ret.Get(1).(func([]byte, string) error); ok {
+		r1 = rf(payload, signature)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Void provides a mock function with given fields: authorizationID
+func (_m *MockPaymentProvider) Void(authorizationID string) (payments.Transaction, error) {
+	ret := _m.Called(authorizationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Void")
+	}
+
+	var r0 payments.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (payments.Transaction, error)); ok {
+		return rf(authorizationID)
+	}
+	if rf, ok := ret.Get(0).(func(string) payments.Transaction); ok {
+		r0 = rf(authorizationID)
+	} else {
+		r0 = ret.Get(0).(payments.Transaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(authorizationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockPaymentProvider creates a new instance of MockPaymentProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPaymentProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPaymentProvider {
+	mock := &MockPaymentProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,89 @@
+package payments
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PaymentProvider is the interface of the payment gateways taking the reservation deposits.
+// Amounts are in cents of the currency of the authorization.
+type PaymentProvider interface {
+	// Name returns the name of the provider, recorded with the payments it makes
+	Name() string
+
+	// Authorize holds req.Amount on the card represented by req.Token, without taking it
+	Authorize(req AuthorizeRequest) (Transaction, error)
+
+	// Capture takes amount of an authorization, which cannot exceed the amount authorized
+	Capture(authorizationID string, amount int64) (Transaction, error)
+
+	// Void releases an authorization that was not captured, so that the card is no longer held
+	Void(authorizationID string) (Transaction, error)
+
+	// Refund returns amount of a capture to the card
+	Refund(captureID string, amount int64) (Transaction, error)
+
+	// VerifyWebhook checks the signature of a webhook payload, and returns the event it holds
+	VerifyWebhook(payload []byte, signature string) (WebhookEvent, error)
+}
+
+// TransactionStatus is the status of a transaction reported by a payment provider
+type TransactionStatus string
+
+const (
+	StatusPending   TransactionStatus = "pending"
+	StatusSucceeded TransactionStatus = "succeeded"
+	StatusFailed    TransactionStatus = "failed"
+)
+
+var (
+	// ErrPaymentDeclined is returned when the provider declines an authorization
+	ErrPaymentDeclined = errors.New("payment declined")
+
+	// ErrInvalidPayment is returned when a request is rejected by the provider as invalid
+	ErrInvalidPayment = errors.New("invalid payment request")
+
+	// ErrInvalidSignature is returned when a webhook signature does not match its payload
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+
+	// ErrMissingSecret is returned when a provider is created without a webhook secret
+	ErrMissingSecret = errors.New("missing payment webhook secret")
+)
+
+// NewProvider returns the payment provider with name, which signs its webhooks with secret
+func NewProvider(name string, secret string) (PaymentProvider, error) {
+	if secret == "" {
+		return nil, ErrMissingSecret
+	}
+
+	switch name {
+	case "fake":
+		return NewFakeProvider(secret), nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", name)
+	}
+}
+
+// AuthorizeRequest holds the details of a payment to authorize.
+// Amount is in cents of Currency.
+type AuthorizeRequest struct {
+	Amount      int64
+	Currency    string
+	Token       string // card token collected by the provider's checkout form
+	Reference   string // merchant reference, e.g. the reservation code
+	Description string
+}
+
+// Transaction is the result of a provider operation
+type Transaction struct {
+	ID     string
+	Amount int64
+	Status TransactionStatus
+}
+
+// WebhookEvent is a verified notification sent by a provider about a transaction
+type WebhookEvent struct {
+	Type          string            `json:"type"`
+	TransactionID string            `json:"transaction_id"`
+	Status        TransactionStatus `json:"status"`
+}
@@ -55,3 +55,27 @@ func (t *Text) String() string {
 func (t Text) Error() string {
 	return t.String()
 }
+
+// Slugify converts s to a lowercase URL slug, such as "generals-quarters" for "General's Quarters".
+// Apostrophes are removed, and every run of other characters that are not letters or digits is replaced by a hyphen.
+func Slugify(s string) string {
+	var sb strings.Builder
+	hyphen := false
+
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r == '\'':
+			continue
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'):
+			if hyphen && sb.Len() > 0 {
+				sb.WriteRune('-')
+			}
+			sb.WriteRune(r)
+			hyphen = false
+		default:
+			hyphen = true
+		}
+	}
+
+	return sb.String()
+}
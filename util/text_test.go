@@ -112,3 +112,10 @@ func TestText_Error(t *testing.T) {
 	expected := "\tline a\n\tline b\n\tline c\n"
 	assert.Equal(t, expected, actual)
 }
+
+func TestSlugify(t *testing.T) {
+	assert.Equal(t, "generals-quarters", Slugify("General's Quarters"))
+	assert.Equal(t, "majors-suite", Slugify("Major's Suite"))
+	assert.Equal(t, "room-101", Slugify("  Room #101! "))
+	assert.Equal(t, "", Slugify("---"))
+}
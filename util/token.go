@@ -0,0 +1,19 @@
+package util
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+)
+
+// NewToken generates a random URL safe token of n bytes using crypto/rand.
+// Unlike the random helpers used for test data, the token cannot be predicted,
+// so it can be used in links and sessions that grant access.
+// It panics if the operating system random number generator fails.
+func NewToken(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
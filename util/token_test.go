@@ -0,0 +1,23 @@
+package util
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewToken(t *testing.T) {
+	tokens := make(map[string]bool)
+	for i := 0; i < N; i++ {
+		token := NewToken(32)
+		assert.Len(t, token, base64.RawURLEncoding.EncodedLen(32))
+		assert.False(t, tokens[token])
+		tokens[token] = true
+
+		b, err := base64.RawURLEncoding.DecodeString(token)
+		require.NoError(t, err)
+		assert.Len(t, b, 32)
+	}
+}